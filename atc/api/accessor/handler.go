@@ -7,12 +7,16 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/felixge/httpsnoop"
+	uuid "github.com/nu7hatch/gouuid"
 )
 
 //counterfeiter:generate net/http.Handler
 
 const accessorContextKey atc.ContextKey = "accessor"
 
+const requestIDHeader = "X-Request-Id"
+
 //counterfeiter:generate . AccessFactory
 type AccessFactory interface {
 	Create(req *http.Request, role string) (Access, error)
@@ -62,8 +66,54 @@ func (h *accessorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	ctx := context.WithValue(r.Context(), accessorContextKey, acc)
 
+	// the ID ends up in the audit history, so one chosen by the client is
+	// only kept if it looks like one we would have generated
+	if !validRequestID(r.Header.Get(requestIDHeader)) {
+		requestID, err := uuid.NewV4()
+		if err == nil {
+			r.Header.Set(requestIDHeader, requestID.String())
+		}
+	}
+
+	w.Header().Set(requestIDHeader, r.Header.Get(requestIDHeader))
+
 	h.auditor.Audit(h.action, claims.UserName, r)
-	h.handler.ServeHTTP(w, r.WithContext(ctx))
+
+	r = auditor.WithDiff(r.WithContext(ctx))
+
+	metrics := httpsnoop.CaptureMetrics(h.handler, w, r)
+
+	// only requests which change something are kept in the audit history,
+	// so that reads, e.g. of the web UI polling, don't each write to the
+	// database
+	if changesState(r) && !systemActions[h.action] {
+		h.auditor.Record(h.action, claims.UserName, r, metrics.Code)
+	}
+}
+
+func changesState(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+// systemActions are taken by workers, through the TSA, or by webhooks rather
+// than by users. They happen all the time and would drown out the rest of the
+// audit history.
+var systemActions = map[string]bool{
+	atc.RegisterWorker:         true,
+	atc.HeartbeatWorker:        true,
+	atc.ReportWorkerContainers: true,
+	atc.ReportWorkerVolumes:    true,
+	atc.CheckResourceWebHook:   true,
+}
+
+func validRequestID(requestID string) bool {
+	_, err := uuid.ParseHex(requestID)
+	return err == nil
 }
 
 func GetAccessor(r *http.Request) Access {
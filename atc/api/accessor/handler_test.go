@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/auditor/auditorfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -44,7 +45,7 @@ var _ = Describe("Handler", func() {
 		customRoles = map[string]string{"some-action": "some-role"}
 
 		var err error
-		r, err = http.NewRequest("GET", "localhost:8080", nil)
+		r, err = http.NewRequest("PUT", "localhost:8080", nil)
 		Expect(err).NotTo(HaveOccurred())
 
 		w = httptest.NewRecorder()
@@ -138,6 +139,101 @@ var _ = Describe("Handler", func() {
 				_, r := fakeHandler.ServeHTTPArgsForCall(0)
 				Expect(accessor.GetAccessor(r)).To(Equal(fakeAccess))
 			})
+
+			It("records the outcome of the event", func() {
+				Expect(fakeAuditor.RecordCallCount()).To(Equal(1))
+				action, userName, _, status := fakeAuditor.RecordArgsForCall(0)
+				Expect(action).To(Equal("some-action"))
+				Expect(userName).To(Equal("some-user"))
+				Expect(status).To(Equal(http.StatusOK))
+			})
+
+			Context("when the request doesn't change anything", func() {
+				BeforeEach(func() {
+					r.Method = http.MethodGet
+				})
+
+				It("audits the event", func() {
+					Expect(fakeAuditor.AuditCallCount()).To(Equal(1))
+				})
+
+				It("does not record it", func() {
+					Expect(fakeAuditor.RecordCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the handler records a diff", func() {
+				BeforeEach(func() {
+					fakeHandler.ServeHTTPStub = func(w http.ResponseWriter, r *http.Request) {
+						auditor.SetDiff(r, "some-diff")
+						w.WriteHeader(http.StatusCreated)
+					}
+				})
+
+				It("records the outcome of the event along with the diff", func() {
+					Expect(fakeAuditor.RecordCallCount()).To(Equal(1))
+					_, _, req, status := fakeAuditor.RecordArgsForCall(0)
+					Expect(status).To(Equal(http.StatusCreated))
+					Expect(auditor.Diff(req)).To(Equal("some-diff"))
+				})
+			})
+
+			Context("when the request has no ID", func() {
+				It("assigns one and responds with it", func() {
+					_, _, req := fakeAuditor.AuditArgsForCall(0)
+					Expect(req.Header.Get("X-Request-Id")).ToNot(BeEmpty())
+					Expect(w.Header().Get("X-Request-Id")).To(Equal(req.Header.Get("X-Request-Id")))
+				})
+			})
+
+			Context("when the request has an ID", func() {
+				BeforeEach(func() {
+					r.Header.Set("X-Request-Id", "6ba7b810-9dad-41d1-80b4-00c04fd430c8")
+				})
+
+				It("keeps it", func() {
+					_, _, req := fakeAuditor.AuditArgsForCall(0)
+					Expect(req.Header.Get("X-Request-Id")).To(Equal("6ba7b810-9dad-41d1-80b4-00c04fd430c8"))
+					Expect(w.Header().Get("X-Request-Id")).To(Equal("6ba7b810-9dad-41d1-80b4-00c04fd430c8"))
+				})
+			})
+
+			Context("when the request has an ID which isn't a UUID", func() {
+				BeforeEach(func() {
+					r.Header.Set("X-Request-Id", "some-request-id")
+				})
+
+				It("replaces it", func() {
+					_, _, req := fakeAuditor.AuditArgsForCall(0)
+					Expect(req.Header.Get("X-Request-Id")).ToNot(BeEmpty())
+					Expect(req.Header.Get("X-Request-Id")).ToNot(Equal("some-request-id"))
+					Expect(w.Header().Get("X-Request-Id")).To(Equal(req.Header.Get("X-Request-Id")))
+				})
+			})
+
+			for _, systemAction := range []string{
+				atc.RegisterWorker,
+				atc.HeartbeatWorker,
+				atc.ReportWorkerContainers,
+				atc.ReportWorkerVolumes,
+				atc.CheckResourceWebHook,
+			} {
+				systemAction := systemAction
+
+				Context("when the action is "+systemAction, func() {
+					BeforeEach(func() {
+						action = systemAction
+					})
+
+					It("audits the event", func() {
+						Expect(fakeAuditor.AuditCallCount()).To(Equal(1))
+					})
+
+					It("does not record it", func() {
+						Expect(fakeAuditor.RecordCallCount()).To(BeZero())
+					})
+				})
+			}
 		})
 
 		Context("when the request is not authenticated", func() {
@@ -17,6 +17,8 @@ var DefaultRoles = map[string]string{
 	atc.GetCC:                          ViewerRole,
 	atc.GetBuild:                       ViewerRole,
 	atc.GetBuildPlan:                   ViewerRole,
+	atc.GetBuildUsage:                  ViewerRole,
+	atc.GetBuildTimeline:               ViewerRole,
 	atc.CreateBuild:                    MemberRole,
 	atc.ListBuilds:                     ViewerRole,
 	atc.BuildEvents:                    ViewerRole,
@@ -27,6 +29,7 @@ var DefaultRoles = map[string]string{
 	atc.CreateJobBuild:                 OperatorRole,
 	atc.RerunJobBuild:                  OperatorRole,
 	atc.SetBuildComment:                OperatorRole,
+	atc.ListBuildNotifications:         ViewerRole,
 	atc.ListAllJobs:                    ViewerRole,
 	atc.ListJobs:                       ViewerRole,
 	atc.ListJobBuilds:                  ViewerRole,
@@ -81,6 +84,9 @@ var DefaultRoles = map[string]string{
 	atc.HeartbeatWorker:                MemberRole,
 	atc.ListWorkers:                    ViewerRole,
 	atc.DeleteWorker:                   MemberRole,
+	atc.ListTeamWorkerKeys:             MemberRole,
+	atc.CreateTeamWorkerKey:            OwnerRole,
+	atc.RevokeTeamWorkerKey:            OwnerRole,
 	atc.SetLogLevel:                    MemberRole,
 	atc.GetLogLevel:                    ViewerRole,
 	atc.DownloadCLI:                    ViewerRole,
@@ -100,6 +106,10 @@ var DefaultRoles = map[string]string{
 	atc.RenameTeam:                     OwnerRole,
 	atc.DestroyTeam:                    OwnerRole,
 	atc.ListTeamBuilds:                 ViewerRole,
+	atc.ListTeamNotifications:          ViewerRole,
+	atc.SearchBuildLogs:                ViewerRole,
+	atc.GetBuildStats:                  ViewerRole,
+	atc.ListFlakyJobs:                  ViewerRole,
 	atc.CreateArtifact:                 MemberRole,
 	atc.GetArtifact:                    MemberRole,
 	atc.ListBuildArtifacts:             ViewerRole,
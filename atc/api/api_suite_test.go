@@ -42,6 +42,7 @@ var (
 	clusterName      = "Test Cluster"
 	featureFlagsJson = ` {
 	"across_step": false,
+	"build_log_search": false,
 	"build_rerun": false,
 	"cache_streamed_volumes": false,
 	"global_resources": false,
@@ -71,9 +72,14 @@ var (
 	dbCheckFactory          *dbfakes.FakeCheckFactory
 	dbTeam                  *dbfakes.FakeTeam
 	dbWall                  *dbfakes.FakeWall
+	dbWorkerKeyFactory      *dbfakes.FakeWorkerKeyFactory
+	dbNotificationFactory   *dbfakes.FakeBuildNotificationFactory
+	dbAuditLog              *dbfakes.FakeAuditLog
+	fakeCapacityPlanner     *workerfakes.FakeCapacityPlanner
 	fakeSecretManager       *credsfakes.FakeSecrets
 	fakeVarSourcePool       *credsfakes.FakeVarSourcePool
 	fakePolicyChecker       *policycheckerfakes.FakePolicyChecker
+	fakeAuditor             *auditorfakes.FakeAuditor
 	credsManagers           creds.Managers
 	interceptTimeoutFactory *containerserverfakes.FakeInterceptTimeoutFactory
 	interceptTimeout        *containerserverfakes.FakeInterceptTimeout
@@ -119,6 +125,10 @@ var _ = BeforeEach(func() {
 	dbUserFactory = new(dbfakes.FakeUserFactory)
 	dbCheckFactory = new(dbfakes.FakeCheckFactory)
 	dbWall = new(dbfakes.FakeWall)
+	dbWorkerKeyFactory = new(dbfakes.FakeWorkerKeyFactory)
+	dbAuditLog = new(dbfakes.FakeAuditLog)
+	dbNotificationFactory = new(dbfakes.FakeBuildNotificationFactory)
+	fakeCapacityPlanner = new(workerfakes.FakeCapacityPlanner)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
 	interceptTimeout = new(containerserverfakes.FakeInterceptTimeout)
@@ -227,6 +237,10 @@ var _ = BeforeEach(func() {
 		interceptTimeoutFactory,
 		time.Second,
 		dbWall,
+		dbWorkerKeyFactory,
+		dbNotificationFactory,
+		fakeCapacityPlanner,
+		dbAuditLog,
 		fakeClock,
 	)
 
@@ -234,12 +248,14 @@ var _ = BeforeEach(func() {
 
 	Expect(err).NotTo(HaveOccurred())
 
+	fakeAuditor = new(auditorfakes.FakeAuditor)
+
 	accessorHandler := accessor.NewHandler(
 		logger,
 		"some-action",
 		handler,
 		fakeAccessor,
-		new(auditorfakes.FakeAuditor),
+		fakeAuditor,
 		map[string]string{},
 	)
 
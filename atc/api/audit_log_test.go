@@ -0,0 +1,176 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit Log API", func() {
+	var (
+		response *http.Response
+		query    string
+	)
+
+	Describe("GET /api/v1/audit-log", func() {
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/audit-log" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			Context("when the events can be listed", func() {
+				BeforeEach(func() {
+					dbAuditLog.EventsReturns([]atc.AuditEvent{
+						{
+							ID:           2,
+							Time:         100,
+							Action:       atc.SaveConfig,
+							User:         "some-user",
+							TeamName:     "some-team",
+							PipelineName: "some-pipeline",
+							RequestID:    "some-request-id",
+							Status:       200,
+							Diff:         "jobs: +some-job",
+						},
+						{
+							ID:     1,
+							Time:   90,
+							Action: atc.SetTeam,
+							User:   "some-user",
+							Status: 403,
+						},
+					}, db.Pagination{}, nil)
+				})
+
+				It("returns 200 with the events", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+						{
+							"id": 2,
+							"time": 100,
+							"action": "SaveConfig",
+							"user": "some-user",
+							"team_name": "some-team",
+							"pipeline_name": "some-pipeline",
+							"request_id": "some-request-id",
+							"status": 200,
+							"diff": "jobs: +some-job"
+						},
+						{
+							"id": 1,
+							"time": 90,
+							"action": "SetTeam",
+							"user": "some-user",
+							"status": 403
+						}
+					]`))
+				})
+
+				It("lists the first page of every event", func() {
+					Expect(dbAuditLog.EventsCallCount()).To(Equal(1))
+					filter, page := dbAuditLog.EventsArgsForCall(0)
+					Expect(filter).To(Equal(db.AuditLogFilter{}))
+					Expect(page).To(Equal(db.Page{Limit: atc.PaginationAPIDefaultLimit}))
+				})
+
+				Context("when filters are given", func() {
+					BeforeEach(func() {
+						query = "?user=some-user&action=SaveConfig&team_name=some-team&since=10&until=20&limit=5&to=7"
+					})
+
+					It("filters the events", func() {
+						Expect(dbAuditLog.EventsCallCount()).To(Equal(1))
+						filter, page := dbAuditLog.EventsArgsForCall(0)
+						Expect(filter).To(Equal(db.AuditLogFilter{
+							User:     "some-user",
+							Action:   atc.SaveConfig,
+							TeamName: "some-team",
+							Since:    time.Unix(10, 0),
+							Until:    time.Unix(20, 0),
+						}))
+						Expect(page).To(Equal(db.Page{To: db.NewIntPtr(7), Limit: 5}))
+					})
+				})
+
+				Context("when a time is malformed", func() {
+					BeforeEach(func() {
+						query = "?since=yesterday"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(dbAuditLog.EventsCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when there are more pages", func() {
+				BeforeEach(func() {
+					query = "?user=some-user&limit=2"
+
+					dbAuditLog.EventsReturns([]atc.AuditEvent{{ID: 5}, {ID: 4}}, db.Pagination{
+						Older: &db.Page{To: db.NewIntPtr(3), Limit: 2},
+						Newer: &db.Page{From: db.NewIntPtr(6), Limit: 2},
+					}, nil)
+				})
+
+				It("links to them", func() {
+					Expect(response.Header["Link"]).To(ConsistOf(
+						`<https://example.com/api/v1/audit-log?limit=2&to=3&user=some-user>; rel="next"`,
+						`<https://example.com/api/v1/audit-log?from=6&limit=2&user=some-user>; rel="previous"`,
+					))
+				})
+			})
+
+			Context("when listing the events fails", func() {
+				BeforeEach(func() {
+					dbAuditLog.EventsReturns(nil, db.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbAuditLog.EventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
@@ -0,0 +1,109 @@
+package auditlogserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-audit-events")
+
+	filter := db.AuditLogFilter{
+		User:     r.FormValue(atc.AuditLogQueryUser),
+		Action:   r.FormValue(atc.AuditLogQueryAction),
+		TeamName: r.FormValue(atc.AuditLogQueryTeam),
+	}
+
+	var err error
+	filter.Since, err = parseUnixTime(r.FormValue(atc.AuditLogQuerySince))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid %s parameter: %s", atc.AuditLogQuerySince, err)
+		return
+	}
+
+	filter.Until, err = parseUnixTime(r.FormValue(atc.AuditLogQueryUntil))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid %s parameter: %s", atc.AuditLogQueryUntil, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit == 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	page := db.Page{Limit: limit}
+	if urlFrom := r.FormValue(atc.PaginationQueryFrom); urlFrom != "" {
+		from, _ := strconv.Atoi(urlFrom)
+		page.From = db.NewIntPtr(from)
+	}
+	if urlTo := r.FormValue(atc.PaginationQueryTo); urlTo != "" {
+		to, _ := strconv.Atoi(urlTo)
+		page.To = db.NewIntPtr(to)
+	}
+
+	events, pagination, err := s.auditLog.Events(filter, page)
+	if err != nil {
+		logger.Error("failed-to-get-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Older != nil {
+		s.addLink(w, r, atc.PaginationQueryTo, *pagination.Older.To, limit, atc.LinkRelNext)
+	}
+
+	if pagination.Newer != nil {
+		s.addLink(w, r, atc.PaginationQueryFrom, *pagination.Newer.From, limit, atc.LinkRelPrevious)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(events)
+	if err != nil {
+		logger.Error("failed-to-encode-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) addLink(w http.ResponseWriter, r *http.Request, boundary string, id int, limit int, rel string) {
+	query := url.Values{}
+	for k, v := range r.URL.Query() {
+		query[k] = v
+	}
+
+	query.Del(atc.PaginationQueryFrom)
+	query.Del(atc.PaginationQueryTo)
+	query.Set(boundary, strconv.Itoa(id))
+	query.Set(atc.PaginationQueryLimit, strconv.Itoa(limit))
+
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/audit-log?%s>; rel="%s"`,
+		s.externalURL,
+		query.Encode(),
+		rel,
+	))
+}
+
+func parseUnixTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(seconds, 0), nil
+}
@@ -1326,6 +1326,358 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/builds/:build_id/usage", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/usage")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				build.TeamNameReturns("some-team")
+				build.JobIDReturns(42)
+				build.JobNameReturns("job1")
+				build.PipelineIDReturns(42)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when not authenticated and the build is one off", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+					build.PipelineIDReturns(0)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when getting the usage succeeds", func() {
+					BeforeEach(func() {
+						build.StepUsageReturns([]atc.StepUsage{
+							{
+								PlanID:   "some-plan-id",
+								StepName: "some-task",
+								Usage: atc.ContainerUsage{
+									CPUAverage:    0.5,
+									CPUPeak:       1.5,
+									MemoryAverage: 1024,
+									MemoryPeak:    2048,
+									Samples:       3,
+								},
+							},
+						}, nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns the usage of each step", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"plan_id": "some-plan-id",
+								"step_name": "some-task",
+								"usage": {
+									"cpu_average": 0.5,
+									"cpu_peak": 1.5,
+									"memory_average": 1024,
+									"memory_peak": 2048,
+									"samples": 3
+								}
+							}
+						]`))
+					})
+				})
+
+				Context("when getting the usage fails", func() {
+					BeforeEach(func() {
+						build.StepUsageReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/timeline", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/timeline")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				build.TeamNameReturns("some-team")
+				build.JobIDReturns(42)
+				build.JobNameReturns("job1")
+				build.PipelineIDReturns(42)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when not authenticated and the build is one off", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+					build.PipelineIDReturns(0)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when getting the timings succeeds", func() {
+					BeforeEach(func() {
+						streamedBytes := int64(1024)
+
+						build.StepTimingsReturns([]atc.StepTiming{
+							{
+								PlanID:    "some-plan-id",
+								StepName:  "some-task",
+								Phase:     atc.StepPhaseStreamingInputs,
+								StartTime: 1600000000,
+								Duration:  9.25,
+								Bytes:     &streamedBytes,
+							},
+							{
+								PlanID:    "some-plan-id",
+								StepName:  "some-task",
+								Phase:     atc.StepPhaseRunning,
+								StartTime: 1600000010,
+								Duration:  42.5,
+							},
+						}, nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns the timing of each phase of the steps", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"plan_id": "some-plan-id",
+								"step_name": "some-task",
+								"phase": "streaming-inputs",
+								"start_time": 1600000000,
+								"duration": 9.25,
+								"bytes": 1024
+							},
+							{
+								"plan_id": "some-plan-id",
+								"step_name": "some-task",
+								"phase": "running",
+								"start_time": 1600000010,
+								"duration": 42.5
+							}
+						]`))
+					})
+				})
+
+				Context("when getting the timings fails", func() {
+					BeforeEach(func() {
+						build.StepTimingsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/notifications", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/notifications")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					dbBuildFactory.BuildReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the build is found", func() {
+				BeforeEach(func() {
+					build.IDReturns(42)
+					build.TeamNameReturns("some-team")
+					dbBuildFactory.BuildReturns(build, true, nil)
+				})
+
+				Context("when not authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when authorized", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAuthorizedReturns(true)
+					})
+
+					Context("when getting the notifications succeeds", func() {
+						BeforeEach(func() {
+							dbNotificationFactory.BuildNotificationsReturns([]atc.BuildNotification{
+								{
+									ID:             1,
+									BuildID:        42,
+									Notifier:       "slack",
+									Source:         "pipeline",
+									Event:          "started",
+									Status:         "delivered",
+									Attempts:       1,
+									ResponseStatus: 200,
+									CreatedAt:      100,
+									DeliveredAt:    101,
+								},
+								{
+									ID:             2,
+									BuildID:        42,
+									Notifier:       "status",
+									Source:         "team",
+									Event:          "failed",
+									Status:         "pending",
+									Attempts:       2,
+									ResponseStatus: 502,
+									Error:          "unexpected response: 502 Bad Gateway",
+									CreatedAt:      200,
+									NextAttemptAt:  300,
+								},
+							}, nil)
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+						})
+
+						It("looks up the build's notifications", func() {
+							Expect(dbNotificationFactory.BuildNotificationsArgsForCall(0)).To(Equal(42))
+						})
+
+						It("returns the delivery log", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`[
+								{
+									"id": 1,
+									"build_id": 42,
+									"notifier": "slack",
+									"source": "pipeline",
+									"event": "started",
+									"status": "delivered",
+									"attempts": 1,
+									"response_status": 200,
+									"created_at": 100,
+									"delivered_at": 101
+								},
+								{
+									"id": 2,
+									"build_id": 42,
+									"notifier": "status",
+									"source": "team",
+									"event": "failed",
+									"status": "pending",
+									"attempts": 2,
+									"response_status": 502,
+									"error": "unexpected response: 502 Bad Gateway",
+									"created_at": 200,
+									"next_attempt_at": 300
+								}
+							]`))
+						})
+					})
+
+					Context("when getting the notifications fails", func() {
+						BeforeEach(func() {
+							dbNotificationFactory.BuildNotificationsReturns(nil, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/plan", func() {
 		var plan *json.RawMessage
 
@@ -0,0 +1,30 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListBuildNotifications(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-build-notifications", lager.Data{"build": build.ID()})
+
+		notifications, err := s.notificationFactory.BuildNotifications(build.ID())
+		if err != nil {
+			logger.Error("failed-to-get-build-notifications", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(notifications)
+		if err != nil {
+			logger.Error("failed-to-encode-build-notifications", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetBuildTimeline(build db.Build) http.Handler {
+	hLog := s.logger.Session("get-build-timeline")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timings, err := build.StepTimings()
+		if err != nil {
+			hLog.Error("failed-to-get-step-timings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(timings)
+		if err != nil {
+			hLog.Error("failed-to-encode-step-timings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+}
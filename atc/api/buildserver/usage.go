@@ -0,0 +1,29 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetBuildUsage(build db.Build) http.Handler {
+	hLog := s.logger.Session("get-build-usage")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		usage, err := build.StepUsage()
+		if err != nil {
+			hLog.Error("failed-to-get-step-usage", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(usage)
+		if err != nil {
+			hLog.Error("failed-to-encode-step-usage", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+}
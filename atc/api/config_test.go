@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/creds/noop"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -549,6 +550,23 @@ var _ = Describe("Config API", func() {
 							})
 						})
 
+						Context("when the pipeline already exists", func() {
+							BeforeEach(func() {
+								existingConfig := pipelineConfig
+								existingConfig.Jobs = nil
+
+								existingPipeline := new(dbfakes.FakePipeline)
+								existingPipeline.ConfigReturns(existingConfig, nil)
+								dbTeam.PipelineReturns(existingPipeline, true, nil)
+							})
+
+							It("records a summary of the changes for the audit log", func() {
+								Eventually(fakeAuditor.RecordCallCount).Should(Equal(1))
+								_, _, req, _ := fakeAuditor.RecordArgsForCall(0)
+								Expect(auditor.Diff(req)).To(Equal("jobs: +some-job"))
+							})
+						})
+
 						Context("when the config is invalid", func() {
 							BeforeEach(func() {
 								pipelineConfig.Groups[0].Resources = []string{"missing-resource"}
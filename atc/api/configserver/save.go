@@ -9,6 +9,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/configvalidate"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
@@ -128,6 +129,11 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	existingConfig, existingConfigErr := s.existingConfig(team, pipelineRef)
+	if existingConfigErr != nil {
+		session.Error("failed-to-get-existing-config", existingConfigErr)
+	}
+
 	_, created, err := team.SavePipeline(pipelineRef, config, version, true)
 	if err != nil {
 		session.Error("failed-to-save-config", err)
@@ -136,6 +142,10 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if existingConfigErr == nil {
+		auditor.SetDiff(r, existingConfig.DiffSummary(config))
+	}
+
 	if !created {
 		if err = s.teamFactory.NotifyResourceScanner(); err != nil {
 			session.Error("failed-to-notify-resource-scanner", err)
@@ -155,6 +165,22 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	s.writeSaveConfigResponse(w, atc.SaveConfigResponse{Warnings: warnings})
 }
 
+// existingConfig returns the config of the pipeline before it is saved, or an
+// empty config for a new pipeline, to summarize the changes made for the
+// audit log.
+func (s *Server) existingConfig(team db.Team, pipelineRef atc.PipelineRef) (atc.Config, error) {
+	pipeline, found, err := team.Pipeline(pipelineRef)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	if !found {
+		return atc.Config{}, nil
+	}
+
+	return pipeline.Config()
+}
+
 // Simply validate that the credentials exist; don't do anything with the actual secrets
 func validateCredParams(credMgrVars vars.Variables, config atc.Config, session lager.Logger) error {
 	var errs error
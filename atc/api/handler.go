@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/artifactserver"
+	"github.com/concourse/concourse/atc/api/auditlogserver"
 	"github.com/concourse/concourse/atc/api/buildserver"
 	"github.com/concourse/concourse/atc/api/ccserver"
 	"github.com/concourse/concourse/atc/api/cliserver"
@@ -24,6 +25,7 @@ import (
 	"github.com/concourse/concourse/atc/api/usersserver"
 	"github.com/concourse/concourse/atc/api/volumeserver"
 	"github.com/concourse/concourse/atc/api/wallserver"
+	"github.com/concourse/concourse/atc/api/workerkeyserver"
 	"github.com/concourse/concourse/atc/api/workerserver"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
@@ -73,6 +75,10 @@ func NewHandler(
 	interceptTimeoutFactory containerserver.InterceptTimeoutFactory,
 	interceptUpdateInterval time.Duration,
 	dbWall db.Wall,
+	dbWorkerKeyFactory db.WorkerKeyFactory,
+	dbBuildNotificationFactory db.BuildNotificationFactory,
+	capacityPlanner worker.CapacityPlanner,
+	dbAuditLog db.AuditLog,
 	clock clock.Clock,
 ) (http.Handler, error) {
 
@@ -85,7 +91,7 @@ func NewHandler(
 	buildHandlerFactory := buildserver.NewScopedHandlerFactory(logger)
 	teamHandlerFactory := NewTeamScopedHandlerFactory(logger, dbTeamFactory)
 
-	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, eventHandlerFactory)
+	buildServer := buildserver.NewServer(logger, externalURL, dbTeamFactory, dbBuildFactory, dbBuildNotificationFactory, eventHandlerFactory)
 	jobServer := jobserver.NewServer(logger, externalURL, secretManager, dbJobFactory, dbCheckFactory)
 	resourceServer := resourceserver.NewServer(logger, secretManager, varSourcePool, dbCheckFactory, dbResourceFactory, dbResourceConfigFactory)
 
@@ -93,16 +99,18 @@ func NewHandler(
 	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL)
 	configServer := configserver.NewServer(logger, dbTeamFactory, secretManager)
 	ccServer := ccserver.NewServer(logger, dbTeamFactory, externalURL)
-	workerServer := workerserver.NewServer(logger, workerTeamFactory, dbWorkerFactory)
+	workerServer := workerserver.NewServer(logger, workerTeamFactory, dbWorkerFactory, capacityPlanner)
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
 	containerServer := containerserver.NewServer(logger, workerPool, secretManager, varSourcePool, interceptTimeoutFactory, interceptUpdateInterval, containerRepository, destroyer, clock)
 	volumesServer := volumeserver.NewServer(logger, volumeRepository, destroyer)
-	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL)
+	teamServer := teamserver.NewServer(logger, dbTeamFactory, dbBuildNotificationFactory, externalURL)
 	infoServer := infoserver.NewServer(logger, version, workerVersion, externalURL, clusterName, credsManagers)
 	artifactServer := artifactserver.NewServer(logger, workerPool)
 	usersServer := usersserver.NewServer(logger, dbUserFactory)
 	wallServer := wallserver.NewServer(dbWall, logger)
+	workerKeyServer := workerkeyserver.NewServer(logger, dbTeamFactory, dbWorkerKeyFactory)
+	auditLogServer := auditlogserver.NewServer(logger, externalURL, dbAuditLog)
 
 	handlers := map[string]http.Handler{
 		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
@@ -116,11 +124,15 @@ func NewHandler(
 		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
 		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
+		atc.GetBuildUsage:       buildHandlerFactory.HandlerFor(buildServer.GetBuildUsage),
+		atc.GetBuildTimeline:    buildHandlerFactory.HandlerFor(buildServer.GetBuildTimeline),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
 		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifacts),
 		atc.SetBuildComment:     buildHandlerFactory.HandlerFor(buildServer.SetBuildComment),
 
+		atc.ListBuildNotifications: buildHandlerFactory.HandlerFor(buildServer.ListBuildNotifications),
+
 		atc.ListAllJobs:    http.HandlerFunc(jobServer.ListAllJobs),
 		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
 		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
@@ -187,6 +199,15 @@ func NewHandler(
 		atc.HeartbeatWorker: http.HandlerFunc(workerServer.HeartbeatWorker),
 		atc.DeleteWorker:    http.HandlerFunc(workerServer.DeleteWorker),
 
+		atc.GetWorkerCapacity: http.HandlerFunc(workerServer.GetWorkerCapacity),
+
+		atc.ListWorkerKeys:      http.HandlerFunc(workerKeyServer.ListWorkerKeys),
+		atc.CreateWorkerKey:     http.HandlerFunc(workerKeyServer.CreateWorkerKey),
+		atc.RevokeWorkerKey:     http.HandlerFunc(workerKeyServer.RevokeWorkerKey),
+		atc.ListTeamWorkerKeys:  teamHandlerFactory.HandlerFor(workerKeyServer.ListTeamWorkerKeys),
+		atc.CreateTeamWorkerKey: teamHandlerFactory.HandlerFor(workerKeyServer.CreateTeamWorkerKey),
+		atc.RevokeTeamWorkerKey: teamHandlerFactory.HandlerFor(workerKeyServer.RevokeTeamWorkerKey),
+
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
 
@@ -214,12 +235,20 @@ func NewHandler(
 		atc.DestroyTeam:    teamHandlerFactory.HandlerFor(teamServer.DestroyTeam),
 		atc.ListTeamBuilds: teamHandlerFactory.HandlerFor(teamServer.ListTeamBuilds),
 
+		atc.ListTeamNotifications: teamHandlerFactory.HandlerFor(teamServer.ListTeamNotifications),
+
+		atc.SearchBuildLogs: teamHandlerFactory.HandlerFor(teamServer.SearchBuildLogs),
+		atc.GetBuildStats:   teamHandlerFactory.HandlerFor(teamServer.GetBuildStats),
+		atc.ListFlakyJobs:   teamHandlerFactory.HandlerFor(teamServer.ListFlakyJobs),
+
 		atc.CreateArtifact: teamHandlerFactory.HandlerFor(artifactServer.CreateArtifact),
 		atc.GetArtifact:    teamHandlerFactory.HandlerFor(artifactServer.GetArtifact),
 
 		atc.GetWall:   http.HandlerFunc(wallServer.GetWall),
 		atc.SetWall:   http.HandlerFunc(wallServer.SetWall),
 		atc.ClearWall: http.HandlerFunc(wallServer.ClearWall),
+
+		atc.ListAuditEvents: http.HandlerFunc(auditLogServer.ListAuditEvents),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
@@ -23,6 +23,7 @@ func (s *Server) Info(w http.ResponseWriter, r *http.Request) {
 			"pipeline_instances":     atc.EnablePipelineInstances,
 			"cache_streamed_volumes": atc.EnableCacheStreamedVolumes,
 			"resource_causality":     atc.EnableResourceCausality,
+			"build_log_search":       atc.EnableBuildLogSearch,
 		},
 	})
 	if err != nil {
@@ -1563,6 +1563,17 @@ var _ = Describe("Jobs API", func() {
 							Expect(fakeJob.CreateBuildCallCount()).To(Equal(1))
 						})
 
+						Context("when the request carries a traceparent header", func() {
+							BeforeEach(func() {
+								request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+							})
+
+							It("creates the build in the trace", func() {
+								ctx, _ := fakeJob.CreateBuildArgsForCall(0)
+								Expect(db.NewSpanContext(ctx).Get("traceparent")).To(ContainSubstring("4bf92f3577b34da6a3ce929d0e0e4736"))
+							})
+						})
+
 						Context("when finding the pipeline resources fails", func() {
 							BeforeEach(func() {
 								fakePipeline.ResourcesReturns(nil, errors.New("nope"))
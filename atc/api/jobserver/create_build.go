@@ -1,7 +1,6 @@
 package jobserver
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
 
@@ -10,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/tracing"
 )
 
 func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
@@ -36,8 +36,21 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		// continue the trace of whatever triggered the build, if it sent one
+		ctx, span := tracing.StartSpanLinkedToFollowing(
+			r.Context(),
+			tracing.HeaderSpanContext(r.Header),
+			"job.CreateBuild",
+			tracing.Attrs{
+				"team":     pipeline.TeamName(),
+				"pipeline": pipeline.Name(),
+				"job":      job.Name(),
+			},
+		)
+		defer span.End()
+
 		acc := accessor.GetAccessor(r)
-		build, err := job.CreateBuild(acc.UserInfo().DisplayUserId)
+		build, err := job.CreateBuild(ctx, acc.UserInfo().DisplayUserId)
 		if err != nil {
 			logger.Error("failed-to-create-job-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -70,7 +83,7 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			if found {
 				version := resource.CurrentPinnedVersion()
 				_, _, err := s.checkFactory.TryCreateCheck(
-					lagerctx.NewContext(context.Background(), logger),
+					lagerctx.NewContext(ctx, logger),
 					resource,
 					resourceTypes,
 					version,
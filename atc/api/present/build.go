@@ -31,6 +31,7 @@ func Build(build db.Build, job db.Job, access accessor.Access) atc.Build {
 		Status:               atc.BuildStatus(build.Status()),
 		APIURL:               apiURL,
 		CreatedBy:            build.CreatedBy(),
+		Flaky:                build.Flaky(),
 	}
 
 	showComments := false
@@ -52,4 +52,12 @@ var _ = Describe("Build", func() {
 			})
 		}
 	})
+	Describe("Flaky", func() {
+		It("is set when the build is flaky", func() {
+			dbBuild.FlakyReturns(true)
+
+			build := present.Build(&dbBuild, nil, nil)
+			Expect(build.Flaky).To(BeTrue())
+		})
+	})
 })
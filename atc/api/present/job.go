@@ -66,6 +66,7 @@ func Job(
 		NextBuild:            presentedNextBuild,
 		TransitionBuild:      presentedTransitionBuild,
 		HasNewInputs:         job.HasNewInputs(),
+		Flaky:                finishedBuild != nil && finishedBuild.Flaky(),
 
 		Inputs:  sanitizedInputs,
 		Outputs: sanitizedOutputs,
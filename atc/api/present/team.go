@@ -6,9 +6,17 @@ import (
 )
 
 func Team(team db.Team) atc.Team {
-	return atc.Team{
+	presented := atc.Team{
 		ID:   team.ID(),
 		Name: team.Name(),
 		Auth: team.Auth(),
 	}
+
+	if limits := team.ContainerLimits(); limits != (atc.ContainerLimits{}) {
+		presented.ContainerLimits = &limits
+	}
+
+	presented.Notifiers = team.Notifiers()
+
+	return presented
 }
@@ -40,6 +40,18 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		State:            string(workerInfo.State()),
 		Version:          version,
 		Ephemeral:        workerInfo.Ephemeral(),
+		Capacity:         workerInfo.Capacity(),
+		Rootless:         workerInfo.Rootless(),
+
+		SecurityAllowList: workerInfo.SecurityAllowList(),
+		WarmPool:          workerInfo.WarmPool(),
+		CheckpointRestore: workerInfo.CheckpointRestore(),
+		NetworkConfig:     workerInfo.NetworkConfig(),
+		WarmTaskImages:    workerInfo.WarmTaskImages(),
+	}
+
+	if allocated := workerInfo.Allocated(); allocated != (atc.WorkerResources{}) {
+		atcWorker.Allocated = &allocated
 	}
 
 	if !workerInfo.StartTime().IsZero() {
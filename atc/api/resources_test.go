@@ -1621,12 +1621,14 @@ var _ = Describe("Resources API", func() {
 	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", func() {
 		var (
 			checkRequestBody atc.CheckRequestBody
+			traceparent      string
 			response         *http.Response
 			fakeResource     *dbfakes.FakeResource
 		)
 
 		BeforeEach(func() {
 			checkRequestBody = atc.CheckRequestBody{}
+			traceparent = ""
 
 			fakeResource = new(dbfakes.FakeResource)
 			fakeResource.NameReturns("resource-name")
@@ -1640,6 +1642,9 @@ var _ = Describe("Resources API", func() {
 			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token=fake-token", bytes.NewBuffer(reqPayload))
 			Expect(err).NotTo(HaveOccurred())
 			request.Header.Set("Content-Type", "application/json")
+			if traceparent != "" {
+				request.Header.Set("traceparent", traceparent)
+			}
 
 			response, err = client.Do(request)
 			Expect(err).NotTo(HaveOccurred())
@@ -1701,6 +1706,18 @@ var _ = Describe("Resources API", func() {
 						Expect(manuallyTriggered).To(BeTrue())
 					})
 
+					Context("when the request carries a traceparent header", func() {
+						BeforeEach(func() {
+							traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+						})
+
+						It("checks within the caller's trace", func() {
+							Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+							ctx, _, _, _, _ := dbCheckFactory.TryCreateCheckArgsForCall(0)
+							Expect(db.NewSpanContext(ctx).Get("traceparent")).To(ContainSubstring("4bf92f3577b34da6a3ce929d0e0e4736"))
+						})
+					})
+
 					Context("when checking fails", func() {
 						BeforeEach(func() {
 							dbCheckFactory.TryCreateCheckReturns(nil, false, errors.New("nope"))
@@ -1,7 +1,6 @@
 package resourceserver
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
 
@@ -10,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/tracing"
 	"github.com/tedsuo/rata"
 )
 
@@ -67,8 +67,21 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		// continue the trace of whatever sent the webhook, if it sent one
+		ctx, span := tracing.StartSpanLinkedToFollowing(
+			r.Context(),
+			tracing.HeaderSpanContext(r.Header),
+			"resource.CheckWebhook",
+			tracing.Attrs{
+				"team":     dbPipeline.TeamName(),
+				"pipeline": dbPipeline.Name(),
+				"resource": resourceName,
+			},
+		)
+		defer span.End()
+
 		build, created, err := s.checkFactory.TryCreateCheck(
-			lagerctx.NewContext(context.Background(), logger),
+			lagerctx.NewContext(ctx, logger),
 			dbResource,
 			dbResourceTypes,
 			nil,
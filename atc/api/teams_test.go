@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/auditor"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/testhelpers"
@@ -255,17 +256,85 @@ var _ = Describe("Teams API", func() {
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 				})
 
-				It("updates provider auth", func() {
+				It("updates the team", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusOK))
-					Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(1))
+					Expect(fakeTeam.UpdateCallCount()).To(Equal(1))
 
-					updatedProviderAuth := fakeTeam.UpdateProviderAuthArgsForCall(0)
-					Expect(updatedProviderAuth).To(Equal(atcTeam.Auth))
+					updatedTeam := fakeTeam.UpdateArgsForCall(0)
+					Expect(updatedTeam.Auth).To(Equal(atcTeam.Auth))
 				})
 
-				Context("when updating provider auth fails", func() {
+				Context("when the team's auth changes", func() {
 					BeforeEach(func() {
-						fakeTeam.UpdateProviderAuthReturns(errors.New("stop trying to make fetch happen"))
+						fakeTeam.AuthReturns(atc.TeamAuth{
+							"owner": map[string][]string{
+								"users": []string{"local:other-username"},
+							},
+						})
+					})
+
+					It("records a summary of the changes for the audit log", func() {
+						Eventually(fakeAuditor.RecordCallCount).Should(Equal(1))
+						_, _, req, _ := fakeAuditor.RecordArgsForCall(0)
+						Expect(auditor.Diff(req)).To(Equal("owner: +user:local:username -user:local:other-username"))
+					})
+				})
+
+				It("leaves the container limits and notifiers as they are when none are given", func() {
+					Expect(fakeTeam.UpdateCallCount()).To(Equal(1))
+
+					updatedTeam := fakeTeam.UpdateArgsForCall(0)
+					Expect(updatedTeam.ContainerLimits).To(BeNil())
+					Expect(updatedTeam.Notifiers).To(BeNil())
+				})
+
+				Context("when container limits are given", func() {
+					var disk atc.DiskLimit
+
+					BeforeEach(func() {
+						disk = atc.DiskLimit(1024)
+						atcTeam.ContainerLimits = &atc.ContainerLimits{Disk: &disk}
+					})
+
+					It("updates the container limits", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateArgsForCall(0).ContainerLimits).To(Equal(&atc.ContainerLimits{Disk: &disk}))
+					})
+				})
+
+				Context("when notifiers are given", func() {
+					BeforeEach(func() {
+						atcTeam.Notifiers = atc.NotifierConfigs{{
+							Name: "chat",
+							Type: atc.NotifierTypeSlack,
+							URL:  "https://hooks.example.com/chat",
+						}}
+					})
+
+					It("updates the notifiers", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateArgsForCall(0).Notifiers).To(Equal(atcTeam.Notifiers))
+					})
+				})
+
+				Context("when a notifier is invalid", func() {
+					BeforeEach(func() {
+						atcTeam.Notifiers = atc.NotifierConfigs{{
+							Name: "chat",
+							Type: "carrier-pigeon",
+						}}
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(fakeTeam.UpdateCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when updating the team fails", func() {
+					BeforeEach(func() {
+						fakeTeam.UpdateReturns(errors.New("stop trying to make fetch happen"))
 					})
 
 					It("returns 500 Internal Server error", func() {
@@ -280,7 +349,7 @@ var _ = Describe("Teams API", func() {
 
 					It("does not update provider auth", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
-						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(0))
+						Expect(fakeTeam.UpdateCallCount()).To(Equal(0))
 					})
 				})
 
@@ -299,7 +368,7 @@ var _ = Describe("Teams API", func() {
 
 					It("does not update provider auth", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
-						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(0))
+						Expect(fakeTeam.UpdateCallCount()).To(Equal(0))
 					})
 				})
 			})
@@ -789,4 +858,671 @@ var _ = Describe("Teams API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/notifications", func() {
+		var (
+			response    *http.Response
+			queryParams string
+		)
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/notifications" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbNotificationFactory.TeamBuildNotificationsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbNotificationFactory.TeamBuildNotificationsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeTeam.IDReturns(5)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				dbNotificationFactory.TeamBuildNotificationsReturns([]atc.BuildNotification{
+					{
+						ID:        3,
+						BuildID:   42,
+						Notifier:  "chat",
+						Source:    "team",
+						Event:     "errored",
+						Status:    "failed",
+						Attempts:  5,
+						Error:     "connection refused",
+						CreatedAt: 100,
+					},
+				}, nil)
+			})
+
+			It("returns the team's most recent notifications", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				teamID, limit := dbNotificationFactory.TeamBuildNotificationsArgsForCall(0)
+				Expect(teamID).To(Equal(5))
+				Expect(limit).To(Equal(atc.PaginationAPIDefaultLimit))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 3,
+						"build_id": 42,
+						"notifier": "chat",
+						"source": "team",
+						"event": "errored",
+						"status": "failed",
+						"attempts": 5,
+						"error": "connection refused",
+						"created_at": 100
+					}
+				]`))
+			})
+
+			Context("when a limit is given", func() {
+				BeforeEach(func() {
+					queryParams = "?limit=10"
+				})
+
+				It("limits the notifications", func() {
+					_, limit := dbNotificationFactory.TeamBuildNotificationsArgsForCall(0)
+					Expect(limit).To(Equal(10))
+				})
+			})
+
+			Context("when getting the notifications fails", func() {
+				BeforeEach(func() {
+					dbNotificationFactory.TeamBuildNotificationsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/logs", func() {
+		var (
+			response    *http.Response
+			queryParams string
+		)
+
+		BeforeEach(func() {
+			atc.EnableBuildLogSearch = true
+			queryParams = "?query=connection+refused"
+		})
+
+		AfterEach(func() {
+			atc.EnableBuildLogSearch = false
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/logs" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			Context("when build log search is disabled", func() {
+				BeforeEach(func() {
+					atc.EnableBuildLogSearch = false
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when no query is given", func() {
+				BeforeEach(func() {
+					queryParams = ""
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the limit is not positive", func() {
+				BeforeEach(func() {
+					queryParams = "?query=oops&limit=-1"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the limit is not a number", func() {
+				BeforeEach(func() {
+					queryParams = "?query=oops&limit=lots"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the context is too large", func() {
+				BeforeEach(func() {
+					queryParams = "?query=oops&context=11"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the context is negative", func() {
+				BeforeEach(func() {
+					queryParams = "?query=oops&context=-1"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when a job is given without a pipeline", func() {
+				BeforeEach(func() {
+					queryParams = "?query=oops&job_name=some-job"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when all the params are passed", func() {
+				var fakePipeline *dbfakes.FakePipeline
+
+				BeforeEach(func() {
+					queryParams = "?query=oops&pipeline_name=some-pipeline&vars.branch=%22main%22&job_name=some-job&since=10&until=20&context=2&from=3&to=8&limit=5"
+
+					fakePipeline = new(dbfakes.FakePipeline)
+					fakePipeline.IDReturns(42)
+					fakeTeam.PipelineReturns(fakePipeline, true, nil)
+
+					fakeJob := new(dbfakes.FakeJob)
+					fakeJob.IDReturns(7)
+					fakePipeline.JobReturns(fakeJob, true, nil)
+				})
+
+				It("looks up the pipeline and job", func() {
+					Expect(fakeTeam.PipelineCallCount()).To(Equal(1))
+					Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{
+						Name:         "some-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "main"},
+					}))
+
+					Expect(fakePipeline.JobCallCount()).To(Equal(1))
+					Expect(fakePipeline.JobArgsForCall(0)).To(Equal("some-job"))
+				})
+
+				It("passes them through", func() {
+					Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(1))
+
+					search, page := fakeTeam.SearchBuildLogsArgsForCall(0)
+					Expect(search).To(Equal(db.BuildLogSearch{
+						Query:      "oops",
+						PipelineID: 42,
+						JobID:      7,
+						Since:      time.Unix(10, 0),
+						Until:      time.Unix(20, 0),
+						Context:    2,
+					}))
+					Expect(page).To(Equal(db.Page{
+						From:  db.NewIntPtr(3),
+						To:    db.NewIntPtr(8),
+						Limit: 5,
+					}))
+				})
+
+				Context("when the pipeline is not found", func() {
+					BeforeEach(func() {
+						fakeTeam.PipelineReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the job is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.JobReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("when the search succeeds", func() {
+				BeforeEach(func() {
+					fakeTeam.SearchBuildLogsReturns([]atc.BuildLogMatch{
+						{
+							ID:           12,
+							TeamName:     "some-team",
+							PipelineID:   1,
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							BuildID:      3,
+							BuildName:    "4",
+							Origin:       "some-origin",
+							Time:         100,
+							Line:         "dial tcp: connection refused",
+							Before:       []string{"connecting"},
+						},
+					}, db.Pagination{
+						Older: &db.Page{To: db.NewIntPtr(11), Limit: 100},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the matches", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 12,
+							"team_name": "some-team",
+							"pipeline_id": 1,
+							"pipeline_name": "some-pipeline",
+							"job_name": "some-job",
+							"build_id": 3,
+							"build_name": "4",
+							"origin": "some-origin",
+							"time": 100,
+							"line": "dial tcp: connection refused",
+							"before": ["connecting"]
+						}
+					]`))
+				})
+
+				It("links to the next page with the same search", func() {
+					Expect(response.Header.Get("Link")).To(Equal(
+						`<https://example.com/api/v1/teams/some-team/logs?limit=100&query=connection+refused&to=11>; rel="next"`,
+					))
+				})
+			})
+
+			Context("when the search fails", func() {
+				BeforeEach(func() {
+					fakeTeam.SearchBuildLogsReturns(nil, db.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/stats", func() {
+		var (
+			response    *http.Response
+			queryParams string
+		)
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/stats" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakeTeam.BuildStatsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authenticated but not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.BuildStatsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			Context("when no window is given", func() {
+				It("summarizes the last 30 days", func() {
+					Expect(fakeTeam.BuildStatsCallCount()).To(Equal(1))
+
+					filter := fakeTeam.BuildStatsArgsForCall(0)
+					Expect(filter.Until).To(BeTemporally("~", time.Now(), time.Minute))
+					Expect(filter.Until.Sub(filter.Since)).To(Equal(30 * 24 * time.Hour))
+				})
+			})
+
+			Context("when since is after until", func() {
+				BeforeEach(func() {
+					queryParams = "?since=20&until=10"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.BuildStatsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when a job is given without a pipeline", func() {
+				BeforeEach(func() {
+					queryParams = "?job_name=some-job"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when all the params are passed", func() {
+				var fakePipeline *dbfakes.FakePipeline
+
+				BeforeEach(func() {
+					queryParams = "?pipeline_name=some-pipeline&vars.branch=%22main%22&job_name=some-job&since=10&until=20"
+
+					fakePipeline = new(dbfakes.FakePipeline)
+					fakePipeline.IDReturns(42)
+					fakeTeam.PipelineReturns(fakePipeline, true, nil)
+
+					fakeJob := new(dbfakes.FakeJob)
+					fakeJob.IDReturns(7)
+					fakePipeline.JobReturns(fakeJob, true, nil)
+				})
+
+				It("passes them through", func() {
+					Expect(fakeTeam.PipelineCallCount()).To(Equal(1))
+					Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{
+						Name:         "some-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "main"},
+					}))
+
+					Expect(fakePipeline.JobCallCount()).To(Equal(1))
+					Expect(fakePipeline.JobArgsForCall(0)).To(Equal("some-job"))
+
+					Expect(fakeTeam.BuildStatsCallCount()).To(Equal(1))
+					Expect(fakeTeam.BuildStatsArgsForCall(0)).To(Equal(db.BuildStatsFilter{
+						PipelineID: 42,
+						JobID:      7,
+						Since:      time.Unix(10, 0),
+						Until:      time.Unix(20, 0),
+					}))
+				})
+
+				Context("when the job is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.JobReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						Expect(fakeTeam.BuildStatsCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("when getting the stats succeeds", func() {
+				BeforeEach(func() {
+					fakeTeam.BuildStatsReturns(atc.BuildStats{
+						Since:       10,
+						Until:       20,
+						Builds:      map[atc.BuildStatus]int{atc.StatusSucceeded: 3, atc.StatusFailed: 1},
+						SuccessRate: 0.75,
+						Duration: atc.DurationPercentiles{
+							P50: 60,
+							P90: 90,
+							P99: 120,
+						},
+						QueueTime: atc.DurationPercentiles{
+							P50: 1,
+							P90: 2,
+							P99: 3,
+						},
+						Recoveries:         1,
+						MeanTimeToRecovery: 300,
+						Reruns:             2,
+						FlippedReruns:      1,
+						Flakiness:          0.5,
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the stats", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"since": 10,
+						"until": 20,
+						"builds": {"succeeded": 3, "failed": 1},
+						"success_rate": 0.75,
+						"duration": {"p50": 60, "p90": 90, "p99": 120},
+						"queue_time": {"p50": 1, "p90": 2, "p99": 3},
+						"recoveries": 1,
+						"mean_time_to_recovery": 300,
+						"reruns": 2,
+						"flipped_reruns": 1,
+						"flakiness": 0.5
+					}`))
+				})
+			})
+
+			Context("when getting the stats fails", func() {
+				BeforeEach(func() {
+					fakeTeam.BuildStatsReturns(atc.BuildStats{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+	Describe("GET /api/v1/teams/:team_name/flaky_jobs", func() {
+		var (
+			response    *http.Response
+			queryParams string
+		)
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/flaky_jobs" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakeTeam.FlakyJobsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			Context("when a pipeline and window are given", func() {
+				BeforeEach(func() {
+					queryParams = "?pipeline_name=some-pipeline&since=10&until=20"
+
+					fakePipeline := new(dbfakes.FakePipeline)
+					fakePipeline.IDReturns(42)
+					fakeTeam.PipelineReturns(fakePipeline, true, nil)
+				})
+
+				It("passes them through", func() {
+					Expect(fakeTeam.FlakyJobsCallCount()).To(Equal(1))
+					Expect(fakeTeam.FlakyJobsArgsForCall(0)).To(Equal(db.BuildStatsFilter{
+						PipelineID: 42,
+						Since:      time.Unix(10, 0),
+						Until:      time.Unix(20, 0),
+					}))
+				})
+			})
+
+			Context("when the pipeline is not found", func() {
+				BeforeEach(func() {
+					queryParams = "?pipeline_name=some-pipeline"
+
+					fakeTeam.PipelineReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(fakeTeam.FlakyJobsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when getting the flaky jobs succeeds", func() {
+				BeforeEach(func() {
+					fakeTeam.FlakyJobsReturns([]atc.FlakyJob{
+						{
+							TeamName:     "some-team",
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							FlakyBuilds:  2,
+							Builds:       10,
+							LastFlaky:    20,
+						},
+					}, nil)
+				})
+
+				It("returns the flaky jobs", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[{
+						"team_name": "some-team",
+						"pipeline_name": "some-pipeline",
+						"job_name": "some-job",
+						"flaky_builds": 2,
+						"builds": 10,
+						"last_flaky": 20
+					}]`))
+				})
+			})
+
+			Context("when getting the flaky jobs fails", func() {
+				BeforeEach(func() {
+					fakeTeam.FlakyJobsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
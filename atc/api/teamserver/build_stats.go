@@ -0,0 +1,157 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+const defaultBuildStatsWindow = 30 * 24 * time.Hour
+
+func (s *Server) GetBuildStats(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-build-stats")
+
+		filter, ok := buildStatsFilter(logger, team, w, r)
+		if !ok {
+			return
+		}
+
+		stats, err := team.BuildStats(filter)
+		if err != nil {
+			logger.Error("failed-to-get-build-stats", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(stats)
+		if err != nil {
+			logger.Error("failed-to-encode-build-stats", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) ListFlakyJobs(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-flaky-jobs")
+
+		filter, ok := buildStatsFilter(logger, team, w, r)
+		if !ok {
+			return
+		}
+
+		jobs, err := team.FlakyJobs(filter)
+		if err != nil {
+			logger.Error("failed-to-get-flaky-jobs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(jobs)
+		if err != nil {
+			logger.Error("failed-to-encode-flaky-jobs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// buildStatsFilter parses the window and the pipeline or job of the builds to
+// summarize. It writes the response and returns false when the request is
+// invalid or the pipeline or job can't be found.
+func buildStatsFilter(logger lager.Logger, team db.Team, w http.ResponseWriter, r *http.Request) (db.BuildStatsFilter, bool) {
+	var (
+		filter db.BuildStatsFilter
+		err    error
+	)
+
+	filter.Since, err = parseUnixTime(r.FormValue(atc.BuildStatsQuerySince))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid %s parameter: %s", atc.BuildStatsQuerySince, err)
+		return db.BuildStatsFilter{}, false
+	}
+
+	filter.Until, err = parseUnixTime(r.FormValue(atc.BuildStatsQueryUntil))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid %s parameter: %s", atc.BuildStatsQueryUntil, err)
+		return db.BuildStatsFilter{}, false
+	}
+
+	if filter.Until.IsZero() {
+		filter.Until = time.Now()
+	}
+
+	if filter.Since.IsZero() {
+		filter.Since = filter.Until.Add(-defaultBuildStatsWindow)
+	}
+
+	if filter.Since.After(filter.Until) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%s must not be after %s", atc.BuildStatsQuerySince, atc.BuildStatsQueryUntil)
+		return db.BuildStatsFilter{}, false
+	}
+
+	pipelineName := r.FormValue(atc.BuildStatsQueryPipeline)
+	jobName := r.FormValue(atc.BuildStatsQueryJob)
+
+	if jobName != "" && pipelineName == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%s requires %s", atc.BuildStatsQueryJob, atc.BuildStatsQueryPipeline)
+		return db.BuildStatsFilter{}, false
+	}
+
+	if pipelineName != "" {
+		pipelineRef := atc.PipelineRef{Name: pipelineName}
+		pipelineRef.InstanceVars, err = atc.InstanceVarsFromQueryParams(r.URL.Query())
+		if err != nil {
+			logger.Error("malformed-instance-vars", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return db.BuildStatsFilter{}, false
+		}
+
+		pipeline, found, err := team.Pipeline(pipelineRef)
+		if err != nil {
+			logger.Error("failed-to-get-pipeline", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return db.BuildStatsFilter{}, false
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return db.BuildStatsFilter{}, false
+		}
+
+		filter.PipelineID = pipeline.ID()
+
+		if jobName != "" {
+			job, found, err := pipeline.Job(jobName)
+			if err != nil {
+				logger.Error("failed-to-get-job", err, lager.Data{"job": jobName})
+				w.WriteHeader(http.StatusInternalServerError)
+				return db.BuildStatsFilter{}, false
+			}
+
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return db.BuildStatsFilter{}, false
+			}
+
+			filter.JobID = job.ID()
+		}
+	}
+
+	return filter, true
+}
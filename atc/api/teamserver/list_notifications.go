@@ -0,0 +1,37 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListTeamNotifications(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-team-notifications", lager.Data{"team": team.Name()})
+
+		limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if limit <= 0 {
+			limit = atc.PaginationAPIDefaultLimit
+		}
+
+		notifications, err := s.notificationFactory.TeamBuildNotifications(team.ID(), limit)
+		if err != nil {
+			logger.Error("failed-to-get-team-notifications", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(notifications)
+		if err != nil {
+			logger.Error("failed-to-encode-team-notifications", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// maxSearchContext is the most lines around each match that a search can ask
+// for, as each of them is looked up for every match.
+const maxSearchContext = 10
+
+func (s *Server) SearchBuildLogs(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("search-build-logs")
+
+		if !atc.EnableBuildLogSearch {
+			logger.Info("build-log-search-disabled")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		search := db.BuildLogSearch{
+			Query: r.FormValue(atc.SearchBuildLogsQueryText),
+		}
+
+		if search.Query == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "missing %s parameter", atc.SearchBuildLogsQueryText)
+			return
+		}
+
+		var err error
+		search.Since, err = parseUnixTime(r.FormValue(atc.SearchBuildLogsQuerySince))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "invalid %s parameter: %s", atc.SearchBuildLogsQuerySince, err)
+			return
+		}
+
+		search.Until, err = parseUnixTime(r.FormValue(atc.SearchBuildLogsQueryUntil))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "invalid %s parameter: %s", atc.SearchBuildLogsQueryUntil, err)
+			return
+		}
+
+		if urlContext := r.FormValue(atc.SearchBuildLogsQueryContext); urlContext != "" {
+			search.Context, err = strconv.Atoi(urlContext)
+			if err != nil || search.Context < 0 || search.Context > maxSearchContext {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "invalid %s parameter: must be between 0 and %d", atc.SearchBuildLogsQueryContext, maxSearchContext)
+				return
+			}
+		}
+
+		pipelineName := r.FormValue(atc.SearchBuildLogsQueryPipeline)
+		jobName := r.FormValue(atc.SearchBuildLogsQueryJob)
+
+		if jobName != "" && pipelineName == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "%s requires %s", atc.SearchBuildLogsQueryJob, atc.SearchBuildLogsQueryPipeline)
+			return
+		}
+
+		if pipelineName != "" {
+			pipelineRef := atc.PipelineRef{Name: pipelineName}
+			pipelineRef.InstanceVars, err = atc.InstanceVarsFromQueryParams(r.URL.Query())
+			if err != nil {
+				logger.Error("malformed-instance-vars", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			pipeline, found, err := team.Pipeline(pipelineRef)
+			if err != nil {
+				logger.Error("failed-to-get-pipeline", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			search.PipelineID = pipeline.ID()
+
+			if jobName != "" {
+				job, found, err := pipeline.Job(jobName)
+				if err != nil {
+					logger.Error("failed-to-get-job", err, lager.Data{"job": jobName})
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				if !found {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				search.JobID = job.ID()
+			}
+		}
+
+		limit := atc.PaginationAPIDefaultLimit
+		if urlLimit := r.FormValue(atc.PaginationQueryLimit); urlLimit != "" {
+			limit, err = strconv.Atoi(urlLimit)
+			if err != nil || limit <= 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "invalid %s parameter: must be a positive integer", atc.PaginationQueryLimit)
+				return
+			}
+		}
+
+		page := db.Page{Limit: limit}
+		if urlFrom := r.FormValue(atc.PaginationQueryFrom); urlFrom != "" {
+			from, _ := strconv.Atoi(urlFrom)
+			page.From = db.NewIntPtr(from)
+		}
+		if urlTo := r.FormValue(atc.PaginationQueryTo); urlTo != "" {
+			to, _ := strconv.Atoi(urlTo)
+			page.To = db.NewIntPtr(to)
+		}
+
+		matches, pagination, err := team.SearchBuildLogs(search, page)
+		if err != nil {
+			logger.Error("failed-to-search-build-logs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if pagination.Older != nil {
+			s.addSearchLink(w, r, atc.PaginationQueryTo, *pagination.Older.To, limit, atc.LinkRelNext)
+		}
+
+		if pagination.Newer != nil {
+			s.addSearchLink(w, r, atc.PaginationQueryFrom, *pagination.Newer.From, limit, atc.LinkRelPrevious)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(matches)
+		if err != nil {
+			logger.Error("failed-to-encode-build-log-matches", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) addSearchLink(w http.ResponseWriter, r *http.Request, boundary string, id int, limit int, rel string) {
+	query := url.Values{}
+	for k, v := range r.URL.Query() {
+		if !strings.HasPrefix(k, ":") {
+			query[k] = v
+		}
+	}
+
+	query.Del(atc.PaginationQueryFrom)
+	query.Del(atc.PaginationQueryTo)
+	query.Set(boundary, strconv.Itoa(id))
+	query.Set(atc.PaginationQueryLimit, strconv.Itoa(limit))
+
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/teams/%s/logs?%s>; rel="%s"`,
+		s.externalURL,
+		r.FormValue(":team_name"),
+		query.Encode(),
+		rel,
+	))
+}
+
+func parseUnixTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(seconds, 0), nil
+}
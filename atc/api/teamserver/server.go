@@ -6,19 +6,22 @@ import (
 )
 
 type Server struct {
-	logger      lager.Logger
-	teamFactory db.TeamFactory
-	externalURL string
+	logger              lager.Logger
+	teamFactory         db.TeamFactory
+	notificationFactory db.BuildNotificationFactory
+	externalURL         string
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
+	notificationFactory db.BuildNotificationFactory,
 	externalURL string,
 ) *Server {
 	return &Server{
-		logger:      logger,
-		teamFactory: teamFactory,
-		externalURL: externalURL,
+		logger:              logger,
+		teamFactory:         teamFactory,
+		notificationFactory: notificationFactory,
+		externalURL:         externalURL,
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/auditor"
 )
 
 type SetTeamResponse struct {
@@ -51,8 +52,10 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 
 	response := SetTeamResponse{}
 	if found {
-		hLog.Debug("updating-credentials")
-		err = team.UpdateProviderAuth(atcTeam.Auth)
+		auditor.SetDiff(r, present.Team(team).DiffSummary(atcTeam))
+
+		hLog.Debug("updating-team")
+		err = team.Update(atcTeam)
 		if err != nil {
 			hLog.Error("failed-to-update-team", err, lager.Data{"teamName": teamName})
 			w.WriteHeader(http.StatusInternalServerError)
@@ -78,6 +81,8 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+
+		auditor.SetDiff(r, atc.Team{}.DiffSummary(atcTeam))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
 	} else {
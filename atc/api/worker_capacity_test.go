@@ -0,0 +1,120 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Worker Capacity API", func() {
+	Describe("GET /api/v1/worker-capacity", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/worker-capacity")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			Context("when the capacity can be planned", func() {
+				BeforeEach(func() {
+					fakeCapacityPlanner.PlanReturns(atc.WorkerCapacity{
+						Pools: []atc.WorkerPoolCapacity{
+							{
+								Platform:        "linux",
+								Tags:            []string{},
+								Workers:         3,
+								ActiveTasks:     1,
+								DesiredWorkers:  1,
+								LandableWorkers: []string{"worker-a", "worker-b"},
+							},
+							{
+								Platform:        "linux",
+								Tags:            []string{"gpu"},
+								Team:            "some-team",
+								Workers:         1,
+								StepsWaiting:    2,
+								NoFit:           1,
+								DesiredWorkers:  2,
+								LandableWorkers: []string{},
+							},
+						},
+					}, nil)
+				})
+
+				It("returns 200 with the planned capacity", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"pools": [
+							{
+								"platform": "linux",
+								"tags": [],
+								"workers": 3,
+								"active_tasks": 1,
+								"steps_waiting": 0,
+								"no_fit": 0,
+								"desired_workers": 1,
+								"landable_workers": ["worker-a", "worker-b"]
+							},
+							{
+								"platform": "linux",
+								"tags": ["gpu"],
+								"team": "some-team",
+								"workers": 1,
+								"active_tasks": 0,
+								"steps_waiting": 2,
+								"no_fit": 1,
+								"desired_workers": 2,
+								"landable_workers": []
+							}
+						]
+					}`))
+				})
+			})
+
+			Context("when planning the capacity fails", func() {
+				BeforeEach(func() {
+					fakeCapacityPlanner.PlanReturns(atc.WorkerCapacity{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeCapacityPlanner.PlanCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
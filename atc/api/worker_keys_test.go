@@ -0,0 +1,429 @@
+package api_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Worker Keys API", func() {
+	var (
+		response   *http.Response
+		publicKey  ssh.PublicKey
+		authorized string
+	)
+
+	BeforeEach(func() {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		publicKey, err = ssh.NewPublicKey(pub)
+		Expect(err).ToNot(HaveOccurred())
+
+		authorized = strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+	})
+
+	Describe("GET /api/v1/worker-keys", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/worker-keys")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			Context("when the keys can be listed", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.WorkerKeysReturns([]atc.WorkerKey{
+						{ID: 1, PublicKey: "ssh-ed25519 AAAA", Fingerprint: "SHA256:global", CreatedAt: 42},
+						{ID: 2, TeamName: "some-team", PublicKey: "ssh-ed25519 BBBB", Fingerprint: "SHA256:team", CreatedAt: 43, LastUsedAt: 44},
+					}, nil)
+				})
+
+				It("returns 200 with the keys", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+						{"id":1,"public_key":"ssh-ed25519 AAAA","fingerprint":"SHA256:global","created_at":42},
+						{"id":2,"team_name":"some-team","public_key":"ssh-ed25519 BBBB","fingerprint":"SHA256:team","created_at":43,"last_used_at":44}
+					]`))
+				})
+			})
+
+			Context("when listing the keys fails", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.WorkerKeysReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbWorkerKeyFactory.WorkerKeysCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/worker-keys", func() {
+		var body string
+
+		BeforeEach(func() {
+			body = `{"public_key":"` + authorized + `"}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(server.URL+"/api/v1/worker-keys", "application/json", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+
+				dbWorkerKeyFactory.CreateWorkerKeyReturns(atc.WorkerKey{
+					ID:          1,
+					PublicKey:   authorized,
+					Fingerprint: "SHA256:some-fingerprint",
+				}, nil)
+			})
+
+			It("creates a global key", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+				Expect(dbWorkerKeyFactory.CreateWorkerKeyCallCount()).To(Equal(1))
+
+				teamID, key := dbWorkerKeyFactory.CreateWorkerKeyArgsForCall(0)
+				Expect(teamID).To(BeZero())
+				Expect(key.Marshal()).To(Equal(publicKey.Marshal()))
+			})
+
+			It("returns the key", func() {
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+					"id":1,
+					"public_key":"` + authorized + `",
+					"fingerprint":"SHA256:some-fingerprint"
+				}`))
+			})
+
+			Context("with a team", func() {
+				BeforeEach(func() {
+					body = `{"public_key":"` + authorized + `","team_name":"some-team"}`
+				})
+
+				It("creates a key for the team", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+
+					teamID, _ := dbWorkerKeyFactory.CreateWorkerKeyArgsForCall(0)
+					Expect(teamID).To(Equal(734))
+				})
+
+				Context("when the team does not exist", func() {
+					BeforeEach(func() {
+						dbTeamFactory.FindTeamReturns(nil, false, nil)
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(dbWorkerKeyFactory.CreateWorkerKeyCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the key is invalid", func() {
+				BeforeEach(func() {
+					body = `{"public_key":"bogus"}`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbWorkerKeyFactory.CreateWorkerKeyCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the key already exists", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.CreateWorkerKeyReturns(atc.WorkerKey{}, db.ErrWorkerKeyExists)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when the key is used by other workers", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.CreateWorkerKeyReturns(atc.WorkerKey{}, db.ErrWorkerKeyUnavailable)
+				})
+
+				It("returns 400 without saying whose it is", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("worker key cannot be added")))
+				})
+			})
+
+			Context("when creating the key fails", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.CreateWorkerKeyReturns(atc.WorkerKey{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbWorkerKeyFactory.CreateWorkerKeyCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/worker-keys/:worker_key_id", func() {
+		var id string
+
+		BeforeEach(func() {
+			id = "42"
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/worker-keys/"+id, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+			})
+
+			Context("when the key exists", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.RevokeWorkerKeyReturns(true, nil)
+				})
+
+				It("revokes the key", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					Expect(dbWorkerKeyFactory.RevokeWorkerKeyArgsForCall(0)).To(Equal(42))
+				})
+			})
+
+			Context("when the key does not exist", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.RevokeWorkerKeyReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the id is not a number", func() {
+				BeforeEach(func() {
+					id = "some-key"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(dbWorkerKeyFactory.RevokeWorkerKeyCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/worker-keys", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/worker-keys")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				dbWorkerKeyFactory.TeamWorkerKeysReturns([]atc.WorkerKey{
+					{ID: 2, TeamName: "some-team", PublicKey: "ssh-ed25519 BBBB"},
+				}, nil)
+			})
+
+			It("returns the keys of the team", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(dbWorkerKeyFactory.TeamWorkerKeysArgsForCall(0)).To(Equal(734))
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{"id":2,"team_name":"some-team","public_key":"ssh-ed25519 BBBB"}
+				]`))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/worker-keys", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(
+				server.URL+"/api/v1/teams/some-team/worker-keys",
+				"application/json",
+				bytes.NewBufferString(`{"public_key":"`+authorized+`","team_name":"other-team"}`),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			It("creates a key for the team in the URL", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+				teamID, key := dbWorkerKeyFactory.CreateWorkerKeyArgsForCall(0)
+				Expect(teamID).To(Equal(734))
+				Expect(key.Marshal()).To(Equal(publicKey.Marshal()))
+			})
+
+			Context("when the key is used by another team's workers", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.CreateWorkerKeyReturns(atc.WorkerKey{}, db.ErrWorkerKeyUnavailable)
+				})
+
+				It("returns 400 without saying whose it is", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("worker key cannot be added")))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbWorkerKeyFactory.CreateWorkerKeyCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/worker-keys/:worker_key_id", func() {
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/worker-keys/42", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the key belongs to the team", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.RevokeTeamWorkerKeyReturns(true, nil)
+				})
+
+				It("revokes the key", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+					teamID, id := dbWorkerKeyFactory.RevokeTeamWorkerKeyArgsForCall(0)
+					Expect(teamID).To(Equal(734))
+					Expect(id).To(Equal(42))
+				})
+			})
+
+			Context("when the key does not belong to the team", func() {
+				BeforeEach(func() {
+					dbWorkerKeyFactory.RevokeTeamWorkerKeyReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+})
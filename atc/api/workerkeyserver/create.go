@@ -0,0 +1,106 @@
+package workerkeyserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"golang.org/x/crypto/ssh"
+)
+
+// CreateWorkerKey adds a key for global workers, or for the workers of the
+// team given in the request.
+func (s *Server) CreateWorkerKey(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("create-worker-key")
+
+	var req atc.WorkerKey
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Error("malformed-request", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var teamID int
+	if req.TeamName != "" {
+		team, found, err := s.teamFactory.FindTeam(req.TeamName)
+		if err != nil {
+			logger.Error("failed-to-find-team", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "team '%s' not found", req.TeamName)
+			return
+		}
+
+		teamID = team.ID()
+	}
+
+	s.createKey(logger, w, teamID, req)
+}
+
+func (s *Server) CreateTeamWorkerKey(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("create-team-worker-key", lager.Data{"team": team.Name()})
+
+		var req atc.WorkerKey
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Error("malformed-request", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.createKey(logger, w, team.ID(), req)
+	})
+}
+
+func (s *Server) createKey(logger lager.Logger, w http.ResponseWriter, teamID int, req atc.WorkerKey) {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(req.PublicKey))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid public key: %s", err)
+		return
+	}
+
+	if _, ok := publicKey.(*ssh.Certificate); ok {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "certificates cannot be added as worker keys; trust their CA instead")
+		return
+	}
+
+	key, err := s.workerKeyFactory.CreateWorkerKey(teamID, publicKey)
+	if err != nil {
+		if err == db.ErrWorkerKeyExists {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, "worker key already exists")
+			return
+		}
+
+		if err == db.ErrWorkerKeyUnavailable {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, "worker key cannot be added")
+			return
+		}
+
+		logger.Error("failed-to-create-worker-key", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info("created", lager.Data{"fingerprint": key.Fingerprint})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err = json.NewEncoder(w).Encode(key)
+	if err != nil {
+		logger.Error("failed-to-encode-worker-key", err)
+	}
+}
@@ -0,0 +1,48 @@
+package workerkeyserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListWorkerKeys(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-worker-keys")
+
+	keys, err := s.workerKeyFactory.WorkerKeys()
+	if err != nil {
+		logger.Error("failed-to-get-worker-keys", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	s.writeKeys(logger, w, keys)
+}
+
+func (s *Server) ListTeamWorkerKeys(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-team-worker-keys", lager.Data{"team": team.Name()})
+
+		keys, err := s.workerKeyFactory.TeamWorkerKeys(team.ID())
+		if err != nil {
+			logger.Error("failed-to-get-worker-keys", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		s.writeKeys(logger, w, keys)
+	})
+}
+
+func (s *Server) writeKeys(logger lager.Logger, w http.ResponseWriter, keys []atc.WorkerKey) {
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(keys)
+	if err != nil {
+		logger.Error("failed-to-encode-worker-keys", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,54 @@
+package workerkeyserver
+
+import (
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) RevokeWorkerKey(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("revoke-worker-key")
+
+	id, err := strconv.Atoi(r.FormValue(":worker_key_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	found, err := s.workerKeyFactory.RevokeWorkerKey(id)
+	s.writeRevoked(logger.WithData(lager.Data{"id": id}), w, found, err)
+}
+
+func (s *Server) RevokeTeamWorkerKey(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("revoke-team-worker-key", lager.Data{"team": team.Name()})
+
+		id, err := strconv.Atoi(r.FormValue(":worker_key_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		found, err := s.workerKeyFactory.RevokeTeamWorkerKey(team.ID(), id)
+		s.writeRevoked(logger.WithData(lager.Data{"id": id}), w, found, err)
+	})
+}
+
+func (s *Server) writeRevoked(logger lager.Logger, w http.ResponseWriter, found bool, err error) {
+	if err != nil {
+		logger.Error("failed-to-revoke-worker-key", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	logger.Info("revoked")
+
+	w.WriteHeader(http.StatusNoContent)
+}
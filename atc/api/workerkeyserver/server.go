@@ -0,0 +1,24 @@
+package workerkeyserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger           lager.Logger
+	teamFactory      db.TeamFactory
+	workerKeyFactory db.WorkerKeyFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	teamFactory db.TeamFactory,
+	workerKeyFactory db.WorkerKeyFactory,
+) *Server {
+	return &Server{
+		logger:           logger,
+		teamFactory:      teamFactory,
+		workerKeyFactory: workerKeyFactory,
+	}
+}
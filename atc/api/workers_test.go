@@ -860,6 +860,29 @@ var _ = Describe("Workers API", func() {
 			Expect(t).To(Equal(ttl))
 		})
 
+		Context("when the worker reports its capacity", func() {
+			BeforeEach(func() {
+				worker.Capacity = &atc.WorkerResources{CPU: 4096, Memory: 8192, Disk: 16384}
+
+				fakeWorker.CapacityReturns(worker.Capacity)
+				fakeWorker.AllocatedReturns(atc.WorkerResources{CPU: 1024, Memory: 2048})
+			})
+
+			It("heartbeats with the capacity", func() {
+				w, _ := dbWorkerFactory.HeartbeatWorkerArgsForCall(0)
+				Expect(w.Capacity).To(Equal(worker.Capacity))
+			})
+
+			It("returns the capacity and allocated resources", func() {
+				var returned atc.Worker
+				err := json.NewDecoder(response.Body).Decode(&returned)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(returned.Capacity).To(Equal(&atc.WorkerResources{CPU: 4096, Memory: 8192, Disk: 16384}))
+				Expect(returned.Allocated).To(Equal(&atc.WorkerResources{CPU: 1024, Memory: 2048}))
+			})
+		})
+
 		Context("when the TTL is invalid", func() {
 			BeforeEach(func() {
 				ttlStr = "invalid-duration"
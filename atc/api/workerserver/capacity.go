@@ -0,0 +1,24 @@
+package workerserver
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func (s *Server) GetWorkerCapacity(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-worker-capacity")
+
+	capacity, err := s.capacityPlanner.Plan(logger)
+	if err != nil {
+		logger.Error("failed-to-plan-worker-capacity", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(capacity)
+	if err != nil {
+		logger.Error("failed-to-encode-worker-capacity", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -3,6 +3,7 @@ package workerserver
 import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
 )
 
 type Server struct {
@@ -10,17 +11,19 @@ type Server struct {
 
 	teamFactory     db.TeamFactory
 	dbWorkerFactory db.WorkerFactory
+	capacityPlanner worker.CapacityPlanner
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	dbWorkerFactory db.WorkerFactory,
-
+	capacityPlanner worker.CapacityPlanner,
 ) *Server {
 	return &Server{
 		logger:          logger,
 		teamFactory:     teamFactory,
 		dbWorkerFactory: dbWorkerFactory,
+		capacityPlanner: capacityPlanner,
 	}
 }
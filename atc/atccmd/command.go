@@ -42,6 +42,7 @@ import (
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/lidar"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/notifications"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/scheduler"
@@ -65,6 +66,7 @@ import (
 	"github.com/cppforlife/go-semi-semantic/version"
 	"github.com/hashicorp/go-multierror"
 	"github.com/jessevdk/go-flags"
+	uuid "github.com/nu7hatch/gouuid"
 	gocache "github.com/patrickmn/go-cache"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
@@ -77,7 +79,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	// dynamically registered metric emitters
-	_ "github.com/concourse/concourse/atc/metric/emitter"
+	"github.com/concourse/concourse/atc/metric/emitter"
 
 	// dynamically registered policy checkers
 	_ "github.com/concourse/concourse/atc/policy/opa"
@@ -112,6 +114,10 @@ type RunCommand struct {
 
 	varSourcePool creds.VarSourcePool
 
+	workerKeysConn db.Conn
+
+	workerDemand *worker.Demand
+
 	BindIP   flag.IP `long:"bind-ip"   default:"0.0.0.0" description:"IP address on which to listen for web traffic."`
 	BindPort uint16  `long:"bind-port" default:"8080"    description:"Port on which to listen for HTTP traffic."`
 
@@ -212,6 +218,9 @@ type RunCommand struct {
 
 	DefaultCpuLimit    *int    `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task, 0 means unlimited"`
 	DefaultMemoryLimit *string `long:"default-task-memory-limit" description:"Default maximum memory per task, 0 means unlimited"`
+	DefaultDiskLimit   *string `long:"default-task-disk-limit" description:"Default maximum disk space a task may write to its image and volumes, enforced on workers with --containerd-disk-quota. 0 means unlimited"`
+
+	TaskUsageSamplingInterval time.Duration `long:"task-usage-sampling-interval" default:"10s" description:"Interval on which the CPU and memory usage of running task containers is sampled, 0 means disabled"`
 
 	Auditor struct {
 		EnableBuildAuditLog     bool `long:"enable-build-auditing" description:"Enable auditing for all api requests connected to builds."`
@@ -223,6 +232,10 @@ type RunCommand struct {
 		EnableTeamAuditLog      bool `long:"enable-team-auditing" description:"Enable auditing for all api requests connected to teams."`
 		EnableWorkerAuditLog    bool `long:"enable-worker-auditing" description:"Enable auditing for all api requests connected to workers."`
 		EnableVolumeAuditLog    bool `long:"enable-volume-auditing" description:"Enable auditing for all api requests connected to volumes."`
+
+		EnableHistory     bool          `long:"enable-audit-history" description:"Save api requests which change something to the database, along with their outcome, so they can be queried through the API and with 'fly audit-log'. This doesn't depend on the other audit flags."`
+		HistoryRetention  time.Duration `long:"audit-history-retention" default:"2160h" description:"Period after which saved audit events are removed, 0 means they are kept forever."`
+		EnableSyslogDrain bool          `long:"enable-audit-syslog-drain" description:"Forward saved audit events as JSON to the syslog server configured for the syslog drainer. Requires --enable-audit-history."`
 	}
 
 	Syslog struct {
@@ -252,6 +265,7 @@ type RunCommand struct {
 		EnableP2PVolumeStreaming             bool `long:"enable-p2p-volume-streaming" description:"Enable P2P volume streaming. NOTE: All workers must be on the same LAN network"`
 		EnableCacheStreamedVolumes           bool `long:"enable-cache-streamed-volumes" description:"When enabled, streamed resource volumes will be cached on the destination worker."`
 		EnableResourceCausality              bool `long:"enable-resource-causality" description:"Enable the resource causality page. Computing causality can be expensive for the database. "`
+		EnableBuildLogSearch                 bool `long:"enable-build-log-search" description:"Index build logs as they are saved so that they can be searched. The index roughly doubles the database storage used by build logs."`
 	} `group:"Feature Flags"`
 
 	BaseResourceTypeDefaults flag.File `long:"base-resource-type-defaults" description:"Base resource type defaults"`
@@ -524,6 +538,7 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	atc.EnablePipelineInstances = cmd.FeatureFlags.EnablePipelineInstances
 	atc.EnableCacheStreamedVolumes = cmd.FeatureFlags.EnableCacheStreamedVolumes
 	atc.EnableResourceCausality = cmd.FeatureFlags.EnableResourceCausality
+	atc.EnableBuildLogSearch = cmd.FeatureFlags.EnableBuildLogSearch
 
 	if cmd.BaseResourceTypeDefaults.Path() != "" {
 		content, err := ioutil.ReadFile(cmd.BaseResourceTypeDefaults.Path())
@@ -557,6 +572,8 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		logger.RegisterSink(&errorSinkCollector)
 	}
 
+	cmd.Tracing.ClusterName = cmd.Server.ClusterName
+
 	err = cmd.Tracing.Prepare()
 	if err != nil {
 		return nil, err
@@ -617,6 +634,10 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		clock.NewClock(),
 	)
 
+	cmd.workerKeysConn = apiConn
+
+	cmd.workerDemand = worker.NewDemand()
+
 	members, err := cmd.constructMembers(logger, reconfigurableSink, apiConn, workerConn, backendConn, gcConn, storage, lockFactory, secretManager)
 	if err != nil {
 		return nil, err
@@ -631,6 +652,22 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		),
 	})
 
+	atcID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	members = append(members, grouper.Member{
+		Name: "worker-demand",
+		Runner: worker.ReportDemand(
+			logger.Session("worker-demand"),
+			cmd.workerDemand,
+			db.NewWorkerDemandFactory(backendConn),
+			atcID.String(),
+			10*time.Second,
+		),
+	})
+
 	onReady := func() {
 		logData := lager.Data{
 			"http":  cmd.nonTLSBindAddr(),
@@ -655,6 +692,14 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	return run(grouper.NewParallel(os.Interrupt, members), onReady, onExit), nil
 }
 
+// NewWorkerKeyCache constructs a cache of the worker keys managed through the
+// API, for a TSA running alongside the ATC to authorize workers with. It must
+// be called after Runner.
+func (cmd *RunCommand) NewWorkerKeyCache() (*db.WorkerKeyCache, error) {
+	logger, _ := cmd.Logger.Logger("atc")
+	return db.NewWorkerKeyCache(logger.Session("worker-key-cache"), cmd.workerKeysConn)
+}
+
 func (cmd *RunCommand) constructMembers(
 	logger lager.Logger,
 	reconfigurableSink *lager.ReconfigurableSink,
@@ -810,7 +855,7 @@ func (cmd *RunCommand) constructAPIMembers(
 		cmd.GardenRequestTimeout,
 	)
 
-	pool := worker.NewPool(workerProvider)
+	pool := worker.NewPool(workerProvider, cmd.workerDemand)
 
 	credsManagers := cmd.CredentialManagers
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
@@ -827,6 +872,14 @@ func (cmd *RunCommand) constructAPIMembers(
 	dbAccessTokenFactory := db.NewAccessTokenFactory(dbConn)
 	dbClock := db.NewClock()
 	dbWall := db.NewWall(dbConn, &dbClock)
+	dbWorkerKeyFactory := db.NewWorkerKeyFactory(dbConn)
+	dbBuildNotificationFactory := db.NewBuildNotificationFactory(dbConn)
+	dbAuditLog := db.NewAuditLog(dbConn)
+	capacityPlanner := worker.NewCapacityPlanner(
+		dbWorkerFactory,
+		db.NewWorkerDemandFactory(dbConn),
+		cmd.ContainerPlacementStrategyOptions,
+	)
 
 	tokenVerifier := cmd.constructTokenVerifier(dbAccessTokenFactory)
 
@@ -874,6 +927,10 @@ func (cmd *RunCommand) constructAPIMembers(
 		credsManagers,
 		accessFactory,
 		dbWall,
+		dbWorkerKeyFactory,
+		dbBuildNotificationFactory,
+		capacityPlanner,
+		dbAuditLog,
 		policyChecker,
 	)
 	if err != nil {
@@ -1032,6 +1089,7 @@ func (cmd *RunCommand) backendComponents(
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
 	dbJobFactory := db.NewJobFactory(dbConn, lockFactory)
 	dbPipelineLifecycle := db.NewPipelineLifecycle(dbConn, lockFactory)
+	dbBuildNotificationFactory := db.NewBuildNotificationFactory(dbConn)
 
 	alg := algorithm.New(db.NewVersionsDB(dbConn, algorithmLimitRows, schedulerCache))
 
@@ -1069,7 +1127,7 @@ func (cmd *RunCommand) backendComponents(
 		cmd.GardenRequestTimeout,
 	)
 
-	pool := worker.NewPool(workerProvider)
+	pool := worker.NewPool(workerProvider, cmd.workerDemand)
 	artifactStreamer := worker.NewArtifactStreamer(pool, compressionLib)
 	artifactSourcer := worker.NewArtifactSourcer(compressionLib, pool, cmd.FeatureFlags.EnableP2PVolumeStreaming, cmd.P2pVolumeStreamingTimeout, dbResourceCacheFactory)
 
@@ -1108,6 +1166,7 @@ func (cmd *RunCommand) backendComponents(
 		lockFactory,
 		rateLimiter,
 		policyChecker,
+		dbBuildNotificationFactory,
 	)
 
 	// In case that a user configures resource-checking-interval, but forgets to
@@ -1176,6 +1235,35 @@ func (cmd *RunCommand) backendComponents(
 				syslogDrainConfigured,
 			),
 		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentWorkerCapacity,
+				Interval: 10 * time.Second,
+			},
+			Runnable: worker.NewCapacityEmitter(
+				worker.NewCapacityPlanner(
+					dbWorkerFactory,
+					db.NewWorkerDemandFactory(dbConn),
+					cmd.ContainerPlacementStrategyOptions,
+				),
+			),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentNotifier,
+				Interval: 5 * time.Second,
+			},
+			Runnable: notifications.NewDeliverer(
+				dbBuildNotificationFactory,
+				dbBuildFactory,
+				teamFactory,
+				secretManager,
+				cmd.varSourcePool,
+				cmd.ExternalURL.String(),
+				&http.Client{Timeout: 30 * time.Second},
+				clock.NewClock(),
+			),
+		},
 	}
 
 	if syslogDrainConfigured {
@@ -1192,6 +1280,22 @@ func (cmd *RunCommand) backendComponents(
 				dbBuildFactory,
 			),
 		})
+
+		if cmd.Auditor.EnableSyslogDrain {
+			components = append(components, RunnableComponent{
+				Component: atc.Component{
+					Name:     atc.ComponentSyslogAuditDrainer,
+					Interval: cmd.Syslog.DrainInterval,
+				},
+				Runnable: syslog.NewAuditDrainer(
+					cmd.Syslog.Transport,
+					cmd.Syslog.Address,
+					cmd.Syslog.Hostname,
+					cmd.Syslog.CACerts,
+					db.NewAuditLog(dbConn),
+				),
+			})
+		}
 	}
 
 	return components, err
@@ -1212,6 +1316,7 @@ func (cmd *RunCommand) gcComponents(
 	dbResourceConfigFactory := db.NewResourceConfigFactory(gcConn, lockFactory)
 	dbPipelineLifecycle := db.NewPipelineLifecycle(gcConn, lockFactory)
 	dbCheckLifecycle := db.NewCheckLifecycle(gcConn)
+	dbAuditLog := db.NewAuditLog(gcConn)
 
 	dbVolumeRepository := db.NewVolumeRepository(gcConn)
 
@@ -1237,6 +1342,7 @@ func (cmd *RunCommand) gcComponents(
 		atc.ComponentCollectorPipelines:         gc.NewPipelineCollector(dbPipelineLifecycle),
 		atc.ComponentCollectorAccessTokens:      gc.NewAccessTokensCollector(dbAccessTokenLifecycle, jwt.DefaultLeeway),
 		atc.ComponentCollectorChecks:            gc.NewChecksCollector(dbCheckLifecycle),
+		atc.ComponentCollectorAuditLog:          gc.NewAuditLogCollector(dbAuditLog, cmd.Auditor.HistoryRetention),
 	}
 
 	var components []RunnableComponent
@@ -1495,6 +1601,13 @@ func (cmd *RunCommand) parseDefaultLimits() (atc.ContainerLimits, error) {
 		}
 		limits.Memory = &memory
 	}
+	if cmd.DefaultDiskLimit != nil {
+		disk, err := atc.ParseDiskLimit(*cmd.DefaultDiskLimit)
+		if err != nil {
+			return atc.ContainerLimits{}, err
+		}
+		limits.Disk = &disk
+	}
 	return limits, nil
 }
 
@@ -1575,6 +1688,22 @@ func (cmd *RunCommand) validate() error {
 		errs = multierror.Append(errs, err)
 	}
 
+	if cmd.Auditor.EnableSyslogDrain {
+		if !cmd.Auditor.EnableHistory {
+			errs = multierror.Append(
+				errs,
+				errors.New("must specify --enable-audit-history to use --enable-audit-syslog-drain"),
+			)
+		}
+
+		if cmd.Syslog.Address == "" {
+			errs = multierror.Append(
+				errs,
+				errors.New("must specify --syslog-address to use --enable-audit-syslog-drain"),
+			)
+		}
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -1596,6 +1725,9 @@ func (cmd *RunCommand) configureMetrics(logger lager.Logger) error {
 		host, _ = os.Hostname()
 	}
 
+	// describe metrics the same way as traces, so that they can be correlated
+	emitter.OTLP.Resource = cmd.Tracing.Resource()
+
 	return metric.Metrics.Initialize(logger.Session("metrics"), host, cmd.Metrics.Attributes, cmd.Metrics.BufferSize)
 }
 
@@ -1688,6 +1820,7 @@ func (cmd *RunCommand) constructEngine(
 	lockFactory lock.LockFactory,
 	rateLimiter engine.RateLimiter,
 	policyChecker policy.Checker,
+	notificationFactory db.BuildNotificationFactory,
 ) engine.Engine {
 	return engine.NewEngine(
 		engine.NewStepperFactory(
@@ -1701,6 +1834,7 @@ func (cmd *RunCommand) constructEngine(
 				resourceCacheFactory,
 				resourceConfigFactory,
 				defaultLimits,
+				cmd.TaskUsageSamplingInterval,
 				strategy,
 				cmd.GlobalResourceCheckTimeout,
 			),
@@ -1713,6 +1847,7 @@ func (cmd *RunCommand) constructEngine(
 		),
 		secretManager,
 		cmd.varSourcePool,
+		notificationFactory,
 	)
 }
 
@@ -1838,12 +1973,21 @@ func (cmd *RunCommand) constructLoginHandler(
 		Scopes:       []string{"openid", "profile", "email", "federated:id", "groups"},
 	}
 
+	flyOAuth2Config := &oauth2.Config{
+		Endpoint:     endpoint,
+		ClientID:     flyClientID,
+		ClientSecret: flyClientSecret,
+		RedirectURL:  redirectURL.String(),
+		Scopes:       []string{"openid", "profile", "email", "federated:id", "groups", "offline_access"},
+	}
+
 	skyServer, err := skyserver.NewSkyServer(&skyserver.SkyConfig{
 		Logger:          logger.Session("sky"),
 		TokenMiddleware: middleware,
 		TokenParser:     token.Factory{},
 		OAuthConfig:     oauth2Config,
 		HTTPClient:      httpClient,
+		FlyOAuthConfig:  flyOAuth2Config,
 	})
 	if err != nil {
 		return nil, err
@@ -1886,6 +2030,10 @@ func (cmd *RunCommand) constructAPIHandler(
 	credsManagers creds.Managers,
 	accessFactory accessor.AccessFactory,
 	dbWall db.Wall,
+	dbWorkerKeyFactory db.WorkerKeyFactory,
+	dbBuildNotificationFactory db.BuildNotificationFactory,
+	capacityPlanner worker.CapacityPlanner,
+	dbAuditLog db.AuditLog,
 	policyChecker policy.Checker,
 ) (http.Handler, error) {
 
@@ -1896,6 +2044,11 @@ func (cmd *RunCommand) constructAPIHandler(
 
 	rejectArchivedHandlerFactory := pipelineserver.NewRejectArchivedHandlerFactory(teamFactory)
 
+	var auditSink auditor.Sink
+	if cmd.Auditor.EnableHistory {
+		auditSink = dbAuditLog
+	}
+
 	aud := auditor.NewAuditor(
 		cmd.Auditor.EnableBuildAuditLog,
 		cmd.Auditor.EnableContainerAuditLog,
@@ -1906,6 +2059,7 @@ func (cmd *RunCommand) constructAPIHandler(
 		cmd.Auditor.EnableTeamAuditLog,
 		cmd.Auditor.EnableWorkerAuditLog,
 		cmd.Auditor.EnableVolumeAuditLog,
+		auditSink,
 		logger,
 	)
 
@@ -1975,6 +2129,10 @@ func (cmd *RunCommand) constructAPIHandler(
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
 		time.Minute,
 		dbWall,
+		dbWorkerKeyFactory,
+		dbBuildNotificationFactory,
+		capacityPlanner,
+		dbAuditLog,
 		clock.NewClock(),
 	)
 }
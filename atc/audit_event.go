@@ -0,0 +1,26 @@
+package atc
+
+// AuditEvent records an action taken through the API.
+type AuditEvent struct {
+	ID int `json:"id"`
+
+	// Time is when the action was taken, in seconds since the epoch.
+	Time int64 `json:"time"`
+
+	// Action is the name of the API route, e.g. SaveConfig.
+	Action string `json:"action"`
+	User   string `json:"user"`
+
+	TeamName             string       `json:"team_name,omitempty"`
+	PipelineName         string       `json:"pipeline_name,omitempty"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+
+	RequestID string `json:"request_id,omitempty"`
+
+	// Status is the HTTP status code the request was responded to with.
+	Status int `json:"status"`
+
+	// Diff summarizes the changes made by actions which update
+	// configuration, such as SaveConfig and SetTeam.
+	Diff string `json:"diff,omitempty"`
+}
@@ -1,11 +1,13 @@
 package auditor
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/tedsuo/rata"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -21,6 +23,7 @@ func NewAuditor(
 	EnableTeamAuditLog bool,
 	EnableWorkerAuditLog bool,
 	EnableVolumeAuditLog bool,
+	sink Sink,
 	logger lager.Logger,
 ) *auditor {
 	return &auditor{
@@ -33,12 +36,51 @@ func NewAuditor(
 		EnableTeamAuditLog:      EnableTeamAuditLog,
 		EnableWorkerAuditLog:    EnableWorkerAuditLog,
 		EnableVolumeAuditLog:    EnableVolumeAuditLog,
+		sink:                    sink,
 		logger:                  logger,
 	}
 }
 
 type Auditor interface {
 	Audit(action string, userName string, r *http.Request)
+
+	// Record saves the outcome of an action to the sink, if any, once the
+	// request has been responded to. Unlike Audit, it doesn't depend on
+	// whether the action's category is audited: the sink is only given when
+	// audit history is enabled.
+	Record(action string, userName string, r *http.Request, status int)
+}
+
+// Sink keeps audit events in a dedicated stream, apart from the logs.
+//
+//counterfeiter:generate . Sink
+type Sink interface {
+	Save(atc.AuditEvent) error
+}
+
+const diffContextKey atc.ContextKey = "audit-diff"
+
+// WithDiff returns a copy of the request in which handlers can record a
+// summary of the changes made by the action with SetDiff.
+func WithDiff(r *http.Request) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), diffContextKey, new(string)))
+}
+
+// SetDiff records a summary of the changes made by the action of the
+// request, to be saved along with its audit event.
+func SetDiff(r *http.Request, summary string) {
+	if diff, ok := r.Context().Value(diffContextKey).(*string); ok {
+		*diff = summary
+	}
+}
+
+// Diff returns the summary of changes recorded with SetDiff, if any.
+func Diff(r *http.Request) string {
+	if diff, ok := r.Context().Value(diffContextKey).(*string); ok {
+		return *diff
+	}
+
+	return ""
 }
 
 type auditor struct {
@@ -51,6 +93,7 @@ type auditor struct {
 	EnableTeamAuditLog      bool
 	EnableWorkerAuditLog    bool
 	EnableVolumeAuditLog    bool
+	sink                    Sink
 	logger                  lager.Logger
 }
 
@@ -58,9 +101,12 @@ func (a *auditor) ValidateAction(action string) bool {
 	switch action {
 	case atc.GetBuild,
 		atc.GetBuildPlan,
+		atc.GetBuildUsage,
+		atc.GetBuildTimeline,
 		atc.CreateBuild,
 		atc.RerunJobBuild,
 		atc.SetBuildComment,
+		atc.ListBuildNotifications,
 		atc.ListBuilds,
 		atc.BuildEvents,
 		atc.BuildResources,
@@ -70,7 +116,10 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.ListBuildsWithVersionAsOutput,
 		atc.CreateArtifact,
 		atc.GetArtifact,
-		atc.ListBuildArtifacts:
+		atc.ListBuildArtifacts,
+		atc.SearchBuildLogs,
+		atc.GetBuildStats,
+		atc.ListFlakyJobs:
 		return a.EnableBuildAuditLog
 	case atc.ListContainers,
 		atc.GetContainer,
@@ -141,13 +190,15 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.GetUser,
 		atc.GetWall,
 		atc.SetWall,
-		atc.ClearWall:
+		atc.ClearWall,
+		atc.ListAuditEvents:
 		return a.EnableSystemAuditLog
 	case atc.ListTeams,
 		atc.SetTeam,
 		atc.RenameTeam,
 		atc.DestroyTeam,
 		atc.ListTeamBuilds,
+		atc.ListTeamNotifications,
 		atc.GetTeam:
 		return a.EnableTeamAuditLog
 	case atc.RegisterWorker,
@@ -156,7 +207,14 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.PruneWorker,
 		atc.HeartbeatWorker,
 		atc.ListWorkers,
-		atc.DeleteWorker:
+		atc.DeleteWorker,
+		atc.GetWorkerCapacity,
+		atc.ListWorkerKeys,
+		atc.CreateWorkerKey,
+		atc.RevokeWorkerKey,
+		atc.ListTeamWorkerKeys,
+		atc.CreateTeamWorkerKey,
+		atc.RevokeTeamWorkerKey:
 		return a.EnableWorkerAuditLog
 	case atc.ListVolumes,
 		atc.ListDestroyingVolumes,
@@ -173,3 +231,31 @@ func (a *auditor) Audit(action string, userName string, r *http.Request) {
 		a.logger.Info("audit", lager.Data{"action": action, "user": userName, "parameters": r.Form})
 	}
 }
+
+func (a *auditor) Record(action string, userName string, r *http.Request, status int) {
+	if a.sink == nil {
+		return
+	}
+
+	event := atc.AuditEvent{
+		Action:       action,
+		User:         userName,
+		TeamName:     rata.Param(r, "team_name"),
+		PipelineName: rata.Param(r, "pipeline_name"),
+		RequestID:    r.Header.Get("X-Request-Id"),
+		Status:       status,
+		Diff:         Diff(r),
+	}
+
+	if event.PipelineName != "" {
+		instanceVars, err := atc.InstanceVarsFromQueryParams(r.URL.Query())
+		if err == nil {
+			event.PipelineInstanceVars = instanceVars
+		}
+	}
+
+	err := a.sink.Save(event)
+	if err != nil {
+		a.logger.Error("failed-to-save-audit-event", err, lager.Data{"action": action, "user": userName})
+	}
+}
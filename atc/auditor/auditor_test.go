@@ -1,12 +1,14 @@
 package auditor_test
 
 import (
+	"errors"
 	"net/http"
 
 	"code.cloudfoundry.org/lager/lagertest"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/auditor/auditorfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -28,6 +30,7 @@ var _ = Describe("Audit", func() {
 		EnableTeamAuditLog      bool
 		EnableWorkerAuditLog    bool
 		EnableVolumeAuditLog    bool
+		sink                    auditor.Sink
 	)
 
 	BeforeEach(func() {
@@ -51,6 +54,7 @@ var _ = Describe("Audit", func() {
 			EnableTeamAuditLog,
 			EnableWorkerAuditLog,
 			EnableVolumeAuditLog,
+			sink,
 			logger,
 		)
 	})
@@ -65,6 +69,7 @@ var _ = Describe("Audit", func() {
 		EnableTeamAuditLog = false
 		EnableWorkerAuditLog = false
 		EnableVolumeAuditLog = false
+		sink = nil
 	})
 	Context("when audit is called", func() {
 		BeforeEach(func() {
@@ -590,4 +595,60 @@ var _ = Describe("Audit", func() {
 			})
 		})
 	})
+
+	Describe("Record", func() {
+		var fakeSink *auditorfakes.FakeSink
+
+		BeforeEach(func() {
+			fakeSink = new(auditorfakes.FakeSink)
+			sink = fakeSink
+
+			var err error
+			req, err = http.NewRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/config?:team_name=main&:pipeline_name=some-pipeline&vars.branch=%22feature%22", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			req.Header.Set("X-Request-Id", "some-request-id")
+		})
+
+		It("saves the event to the sink", func() {
+			req = auditor.WithDiff(req)
+			auditor.SetDiff(req, "jobs: +some-job")
+
+			aud.Record(atc.SaveConfig, userName, req, http.StatusOK)
+
+			Expect(fakeSink.SaveCallCount()).To(Equal(1))
+			Expect(fakeSink.SaveArgsForCall(0)).To(Equal(atc.AuditEvent{
+				Action:               atc.SaveConfig,
+				User:                 userName,
+				TeamName:             "main",
+				PipelineName:         "some-pipeline",
+				PipelineInstanceVars: atc.InstanceVars{"branch": "feature"},
+				RequestID:            "some-request-id",
+				Status:               http.StatusOK,
+				Diff:                 "jobs: +some-job",
+			}))
+		})
+
+		It("saves actions whose category isn't audited", func() {
+			aud.Record(atc.GetBuild, userName, req, http.StatusOK)
+			Expect(fakeSink.SaveCallCount()).To(Equal(1))
+		})
+
+		It("logs when the event can't be saved", func() {
+			fakeSink.SaveReturns(errors.New("nope"))
+
+			aud.Record(atc.SaveConfig, userName, req, http.StatusOK)
+			Expect(logger.LogMessages()).To(ContainElement("access_handler.failed-to-save-audit-event"))
+		})
+
+		Context("when there is no sink", func() {
+			BeforeEach(func() {
+				sink = nil
+			})
+
+			It("does nothing", func() {
+				aud.Record(atc.SaveConfig, userName, req, http.StatusOK)
+			})
+		})
+	})
 })
@@ -16,6 +16,14 @@ type FakeAuditor struct {
 		arg2 string
 		arg3 *http.Request
 	}
+	RecordStub        func(string, string, *http.Request, int)
+	recordMutex       sync.RWMutex
+	recordArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *http.Request
+		arg4 int
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -54,11 +62,48 @@ func (fake *FakeAuditor) AuditArgsForCall(i int) (string, string, *http.Request)
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakeAuditor) Record(arg1 string, arg2 string, arg3 *http.Request, arg4 int) {
+	fake.recordMutex.Lock()
+	fake.recordArgsForCall = append(fake.recordArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *http.Request
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.RecordStub
+	fake.recordInvocation("Record", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordMutex.Unlock()
+	if stub != nil {
+		fake.RecordStub(arg1, arg2, arg3, arg4)
+	}
+}
+
+func (fake *FakeAuditor) RecordCallCount() int {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return len(fake.recordArgsForCall)
+}
+
+func (fake *FakeAuditor) RecordCalls(stub func(string, string, *http.Request, int)) {
+	fake.recordMutex.Lock()
+	defer fake.recordMutex.Unlock()
+	fake.RecordStub = stub
+}
+
+func (fake *FakeAuditor) RecordArgsForCall(i int) (string, string, *http.Request, int) {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	argsForCall := fake.recordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
 func (fake *FakeAuditor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.auditMutex.RLock()
 	defer fake.auditMutex.RUnlock()
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
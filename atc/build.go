@@ -33,6 +33,7 @@ type Build struct {
 	RerunNumber          int           `json:"rerun_number,omitempty"`
 	RerunOf              *RerunOfBuild `json:"rerun_of,omitempty"`
 	CreatedBy            *string       `json:"created_by,omitempty"`
+	Flaky                bool          `json:"flaky,omitempty"`
 }
 
 type RerunOfBuild struct {
@@ -0,0 +1,22 @@
+package atc
+
+// BuildLogMatch is a line of a build's log which matched a search, along with
+// the lines logged around it by the same step.
+type BuildLogMatch struct {
+	ID int `json:"id"`
+
+	TeamName             string       `json:"team_name"`
+	PipelineID           int          `json:"pipeline_id,omitempty"`
+	PipelineName         string       `json:"pipeline_name,omitempty"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	JobName              string       `json:"job_name,omitempty"`
+	BuildID              int          `json:"build_id"`
+	BuildName            string       `json:"build_name"`
+
+	Origin string `json:"origin"`
+	Time   int64  `json:"time"`
+	Line   string `json:"line"`
+
+	Before []string `json:"before,omitempty"`
+	After  []string `json:"after,omitempty"`
+}
@@ -0,0 +1,44 @@
+package atc
+
+// BuildStats summarizes the builds of the jobs of a team, pipeline or job that
+// were created within a window of time. One-off and check builds are not
+// counted.
+type BuildStats struct {
+	// Since and Until are the bounds of the window, in seconds since the
+	// epoch.
+	Since int64 `json:"since"`
+	Until int64 `json:"until"`
+
+	// Builds is the number of builds in each status.
+	Builds map[BuildStatus]int `json:"builds"`
+
+	// SuccessRate is the fraction of the builds that finished, other than by
+	// being aborted, which succeeded.
+	SuccessRate float64 `json:"success_rate"`
+
+	// Duration is how long the builds that finished ran for, and QueueTime is
+	// how long the builds that started waited to start.
+	Duration  DurationPercentiles `json:"duration"`
+	QueueTime DurationPercentiles `json:"queue_time"`
+
+	// Recoveries is the number of times a job succeeded after failing or
+	// erroring, and MeanTimeToRecovery is the mean time in seconds from the
+	// first build that didn't succeed to the next one that did.
+	Recoveries         int     `json:"recoveries"`
+	MeanTimeToRecovery float64 `json:"mean_time_to_recovery"`
+
+	// Reruns is the number of finished reruns of builds, which run with the
+	// same inputs as the build they rerun. FlippedReruns is how many of them
+	// succeeded where the original build didn't, or the other way around, and
+	// Flakiness is the fraction of reruns which flipped.
+	Reruns        int     `json:"reruns"`
+	FlippedReruns int     `json:"flipped_reruns"`
+	Flakiness     float64 `json:"flakiness"`
+}
+
+// DurationPercentiles are percentiles of a duration, in seconds.
+type DurationPercentiles struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P99 float64 `json:"p99"`
+}
@@ -58,6 +58,7 @@ func (visitor *planVisitor) VisitTask(step *atc.TaskStep) error {
 		Privileged:        step.Privileged,
 		Config:            step.Config,
 		Limits:            step.Limits,
+		Network:           step.Network,
 		ConfigPath:        step.ConfigPath,
 		Vars:              step.Vars,
 		Tags:              step.Tags,
@@ -66,6 +67,7 @@ func (visitor *planVisitor) VisitTask(step *atc.TaskStep) error {
 		OutputMapping:     step.OutputMapping,
 		ImageArtifactName: step.ImageArtifactName,
 		Timeout:           step.Timeout,
+		FlakyTolerant:     step.FlakyTolerant,
 
 		VersionedResourceTypes: visitor.resourceTypes,
 	})
@@ -257,6 +257,7 @@ var factoryTests = []PlannerTest{
 			OutputMapping:     map[string]string{"specific": "generic"},
 			ImageArtifactName: "some-image",
 			Timeout:           "1h",
+			FlakyTolerant:     true,
 		},
 
 		PlanJSON: `{
@@ -276,6 +277,7 @@ var factoryTests = []PlannerTest{
 				"output_mapping": {"specific": "generic"},
 				"image": "some-image",
 				"timeout": "1h",
+				"flaky_tolerant": true,
 				"resource_types": [
 					{
 						"name": "some-resource-type",
@@ -342,6 +344,40 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "task step with network config",
+
+		Config: &atc.TaskStep{
+			Name:       "some-task",
+			ConfigPath: "some-task-file",
+			Network: &atc.NetworkConfig{
+				Egress: []atc.EgressRule{
+					{Host: "github.com", Ports: []atc.PortRange{{Start: 443, End: 443}}, Protocol: atc.EgressProtocolTCP},
+				},
+			},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"task": {
+				"name": "some-task",
+				"privileged": false,
+				"config_path": "some-task-file",
+				"network": {
+					"egress": [{"host": "github.com", "ports": [443], "protocol": "tcp"}]
+				},
+				"resource_types": [
+					{
+						"name": "some-resource-type",
+						"type": "some-base-resource-type",
+						"source": {"some": "type-source"},
+						"defaults": {"default-key":"default-value"},
+						"version": {"some": "type-version"}
+					}
+				]
+			}
+		}`,
+	},
 	{
 		Title: "run step",
 
@@ -8,8 +8,12 @@ const (
 	ComponentLidarScanner               = "scanner"
 	ComponentBuildReaper                = "reaper"
 	ComponentSyslogDrainer              = "drainer"
+	ComponentSyslogAuditDrainer         = "audit_drainer"
+	ComponentWorkerCapacity             = "worker_capacity"
+	ComponentNotifier                   = "notifier"
 	ComponentCollectorAccessTokens      = "collector_access_tokens"
 	ComponentCollectorArtifacts         = "collector_artifacts"
+	ComponentCollectorAuditLog          = "collector_audit_log"
 	ComponentCollectorBuilds            = "collector_builds"
 	ComponentCollectorCheckSessions     = "collector_check_sessions"
 	ComponentCollectorChecks            = "collector_checks"
@@ -27,6 +27,7 @@ type Config struct {
 	Prototypes    Prototypes       `json:"prototypes,omitempty"`
 	Jobs          JobConfigs       `json:"jobs,omitempty"`
 	Display       *DisplayConfig   `json:"display,omitempty"`
+	Notifiers     NotifierConfigs  `json:"notifiers,omitempty"`
 }
 
 func UnmarshalConfig(payload []byte, config interface{}) error {
@@ -39,6 +40,7 @@ func UnmarshalConfig(payload []byte, config interface{}) error {
 		Prototypes    interface{} `json:"prototypes,omitempty"`
 		Jobs          interface{} `json:"jobs,omitempty"`
 		Display       interface{} `json:"display,omitempty"`
+		Notifiers     interface{} `json:"notifiers,omitempty"`
 	}
 
 	var stripped skeletonConfig
@@ -106,6 +106,21 @@ func (index VarSourceIndex) FindEquivalent(obj interface{}) (interface{}, bool)
 	return VarSourceConfigs(index).Lookup(name(obj))
 }
 
+type NotifierIndex NotifierConfigs
+
+func (index NotifierIndex) Slice() []interface{} {
+	slice := make([]interface{}, len(index))
+	for i, object := range index {
+		slice[i] = object
+	}
+
+	return slice
+}
+
+func (index NotifierIndex) FindEquivalent(obj interface{}) (interface{}, bool) {
+	return NotifierConfigs(index).Lookup(name(obj))
+}
+
 type JobIndex JobConfigs
 
 func (index JobIndex) Slice() []interface{} {
@@ -329,6 +344,16 @@ func (c Config) Diff(out io.Writer, newConfig Config) bool {
 		}
 	}
 
+	notifierDiffs := diffIndices(NotifierIndex(c.Notifiers), NotifierIndex(newConfig.Notifiers))
+	if len(notifierDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "notifiers:")
+
+		for _, diff := range notifierDiffs {
+			diff.Render(indent, "notifier")
+		}
+	}
+
 	displayDiff, diff := diffDisplay(c.Display, newConfig.Display)
 	if diff {
 		diffExists = true
@@ -337,3 +362,57 @@ func (c Config) Diff(out io.Writer, newConfig Config) bool {
 
 	return diffExists
 }
+
+// Summary lists the names of what has been added (+), changed (~) or
+// removed (-), e.g. "+some-job ~other-job".
+func (diffs Diffs) Summary() string {
+	var changes []string
+	seen := map[string]bool{}
+	for _, diff := range diffs {
+		var change string
+		if diff.Before != nil && diff.After != nil {
+			change = "~" + name(diff.Before)
+		} else if diff.Before != nil {
+			change = "-" + name(diff.Before)
+		} else {
+			change = "+" + name(diff.After)
+		}
+
+		if !seen[change] {
+			seen[change] = true
+			changes = append(changes, change)
+		}
+	}
+
+	return strings.Join(changes, " ")
+}
+
+// DiffSummary describes the changes from c to newConfig on a single line,
+// listing what has been added (+), changed (~) or removed (-) in each
+// section, e.g. "jobs: +some-job ~other-job; resources: -some-resource".
+func (c Config) DiffSummary(newConfig Config) string {
+	sections := []struct {
+		label string
+		diffs Diffs
+	}{
+		{"groups", groupDiffIndices(GroupIndex(c.Groups), GroupIndex(newConfig.Groups))},
+		{"var_sources", diffIndices(VarSourceIndex(c.VarSources), VarSourceIndex(newConfig.VarSources))},
+		{"resources", diffIndices(ResourceIndex(c.Resources), ResourceIndex(newConfig.Resources))},
+		{"resource_types", diffIndices(ResourceTypeIndex(c.ResourceTypes), ResourceTypeIndex(newConfig.ResourceTypes))},
+		{"jobs", diffIndices(JobIndex(c.Jobs), JobIndex(newConfig.Jobs))},
+		{"notifiers", diffIndices(NotifierIndex(c.Notifiers), NotifierIndex(newConfig.Notifiers))},
+	}
+
+	var summary []string
+	for _, section := range sections {
+		if len(section.diffs) > 0 {
+			summary = append(summary, section.label+": "+section.diffs.Summary())
+		}
+	}
+
+	if _, changed := diffDisplay(c.Display, newConfig.Display); changed {
+		summary = append(summary, "display: ~")
+	}
+
+	return strings.Join(summary, "; ")
+}
@@ -216,4 +216,39 @@ var _ = Describe("Config diff", func() {
 			})
 		})
 	})
+
+	Describe("DiffSummary", func() {
+		var oldConfig Config
+
+		BeforeEach(func() {
+			oldConfig = Config{
+				Resources: ResourceConfigs{
+					{Name: "some-resource", Type: "git"},
+					{Name: "other-resource", Type: "git"},
+				},
+				Jobs: JobConfigs{
+					{Name: "some-job", Public: true},
+				},
+			}
+		})
+
+		It("is empty when nothing changed", func() {
+			Expect(oldConfig.DiffSummary(oldConfig)).To(BeEmpty())
+		})
+
+		It("lists what has been added, changed and removed in each section", func() {
+			newConfig := Config{
+				Resources: ResourceConfigs{
+					{Name: "some-resource", Type: "time"},
+				},
+				Jobs: JobConfigs{
+					{Name: "some-job", Public: true},
+					{Name: "new-job"},
+				},
+				Display: &DisplayConfig{BackgroundImage: "some-background.jpg"},
+			}
+
+			Expect(oldConfig.DiffSummary(newConfig)).To(Equal("resources: ~some-resource -other-resource; jobs: +new-job; display: ~"))
+		})
+	})
 })
@@ -80,6 +80,11 @@ func Validate(c atc.Config) ([]atc.ConfigWarning, []string) {
 	}
 	warnings = append(warnings, jobWarnings...)
 
+	notifiersErr := validateNotifiers(c)
+	if notifiersErr != nil {
+		errorMessages = append(errorMessages, formatErr("notifiers", notifiersErr))
+	}
+
 	displayWarnings, displayErr := validateDisplay(c)
 	if displayErr != nil {
 		errorMessages = append(errorMessages, formatErr("display config", displayErr))
@@ -362,6 +367,13 @@ func validateJobs(c atc.Config) ([]atc.ConfigWarning, error) {
 			)
 		}
 
+		if job.RetryOnFlake < 0 {
+			errorMessages = append(
+				errorMessages,
+				identifier+fmt.Sprintf(" has negative retry_on_flake: %d", job.RetryOnFlake),
+			)
+		}
+
 		if job.BuildLogRetention != nil {
 			if job.BuildLogRetention.Builds < 0 {
 				errorMessages = append(
@@ -466,6 +478,32 @@ func validateVarSources(c atc.Config) ([]atc.ConfigWarning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateNotifiers(c atc.Config) error {
+	var errorMessages []string
+
+	names := map[string]location{}
+
+	for i, notifier := range c.Notifiers {
+		location := location{section: "notifiers", index: i}
+		identifier := location.Identifier(notifier.Name)
+
+		if other, ok := names[notifier.Name]; ok && notifier.Name != "" {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"%s and %s have the same name ('%s')",
+					other, location, notifier.Name))
+		}
+		names[notifier.Name] = location
+
+		err := notifier.Validate()
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s is invalid: %s", identifier, err))
+		}
+	}
+
+	return compositeErr(errorMessages)
+}
+
 func validateDisplay(c atc.Config) ([]atc.ConfigWarning, error) {
 	var warnings []atc.ConfigWarning
 
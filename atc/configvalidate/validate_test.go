@@ -969,6 +969,19 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has a negative retry_on_flake", func() {
+			BeforeEach(func() {
+				job.RetryOnFlake = -1
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative retry_on_flake: -1"))
+			})
+		})
+
 		Context("when a job has duplicate inputs", func() {
 			BeforeEach(func() {
 				job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1095,6 +1108,51 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a task plan has invalid egress rules", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:       "lol",
+							ConfigPath: "task.yml",
+							Network: &atc.NetworkConfig{
+								Egress: []atc.EgressRule{
+									{CIDR: "10.0.0.0/8", Host: "example.com"},
+									{CIDR: "bogus", Protocol: "icmp", Ports: []atc.PortRange{{Start: 443, End: 443}}},
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol).network: egress[0]: must specify one of `cidr:` or `host:`, not both"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol).network: egress[1]: invalid cidr 'bogus'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol).network: egress[1]: cannot specify `ports:` for protocol 'icmp'"))
+				})
+			})
+
+			Context("when a task plan has network config without egress rules", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:       "lol",
+							ConfigPath: "task.yml",
+							Network:    &atc.NetworkConfig{},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol).network: must specify `egress:` rules or be 'none'"))
+				})
+			})
+
 			Context("when a task plan is invalid", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -13,6 +13,7 @@ var memoryRegex = regexp.MustCompile(`^([0-9]+)([GMK]?[B])?$`)
 type ContainerLimits struct {
 	CPU    *CPULimit    `json:"cpu,omitempty"`
 	Memory *MemoryLimit `json:"memory,omitempty"`
+	Disk   *DiskLimit   `json:"disk,omitempty"`
 }
 
 type CPULimit uint64
@@ -74,3 +75,32 @@ func ParseMemoryLimit(limit string) (MemoryLimit, error) {
 
 	return MemoryLimit(value * (1 << power)), nil
 }
+
+type DiskLimit uint64
+
+func (d *DiskLimit) UnmarshalJSON(data []byte) error {
+	var dst interface{}
+	if err := json.Unmarshal(data, &dst); err != nil {
+		return err
+	}
+	switch v := dst.(type) {
+	case float64:
+		*d = DiskLimit(v)
+	case string:
+		var err error
+		*d, err = ParseDiskLimit(v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ParseDiskLimit(limit string) (DiskLimit, error) {
+	size, err := ParseMemoryLimit(limit)
+	if err != nil {
+		return 0, errors.New("could not parse container disk limit")
+	}
+
+	return DiskLimit(size), nil
+}
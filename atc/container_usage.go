@@ -0,0 +1,22 @@
+package atc
+
+// ContainerUsage is the resource usage of a step's container, sampled
+// periodically while its process was running. CPU usage is measured in cores,
+// memory usage in bytes, and block IO in bytes read and written in total.
+// Block IO is zero for workers which do not report it.
+type ContainerUsage struct {
+	CPUAverage    float64 `json:"cpu_average"`
+	CPUPeak       float64 `json:"cpu_peak"`
+	MemoryAverage uint64  `json:"memory_average"`
+	MemoryPeak    uint64  `json:"memory_peak"`
+	BlockIORead   uint64  `json:"block_io_read,omitempty"`
+	BlockIOWrite  uint64  `json:"block_io_write,omitempty"`
+	Samples       int     `json:"samples"`
+}
+
+// StepUsage is the resource usage of a step of a build.
+type StepUsage struct {
+	PlanID   PlanID         `json:"plan_id"`
+	StepName string         `json:"step_name"`
+	Usage    ContainerUsage `json:"usage"`
+}
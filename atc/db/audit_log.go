@@ -0,0 +1,309 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// AuditLog keeps the history of actions taken through the API, for as long
+// as the retention allows.
+//
+//counterfeiter:generate . AuditLog
+type AuditLog interface {
+	Save(atc.AuditEvent) error
+
+	Events(AuditLogFilter, Page) ([]atc.AuditEvent, Pagination, error)
+
+	// UndrainedEvents returns the oldest events not yet forwarded to the
+	// syslog drain, oldest first.
+	UndrainedEvents(limit int) ([]atc.AuditEvent, error)
+	MarkDrained(ids []int) error
+
+	RemoveEventsBefore(time.Time) (int64, error)
+}
+
+// AuditLogFilter narrows down the events of the audit log. Zero values match
+// every event.
+type AuditLogFilter struct {
+	User     string
+	Action   string
+	TeamName string
+
+	Since time.Time
+	Until time.Time
+}
+
+func (filter AuditLogFilter) filter() sq.And {
+	and := sq.And{}
+
+	if filter.User != "" {
+		and = append(and, sq.Eq{"user_name": filter.User})
+	}
+
+	if filter.Action != "" {
+		and = append(and, sq.Eq{"action": filter.Action})
+	}
+
+	if filter.TeamName != "" {
+		and = append(and, sq.Eq{"team_name": filter.TeamName})
+	}
+
+	if !filter.Since.IsZero() {
+		and = append(and, sq.GtOrEq{"time": filter.Since})
+	}
+
+	if !filter.Until.IsZero() {
+		and = append(and, sq.LtOrEq{"time": filter.Until})
+	}
+
+	return and
+}
+
+var auditEventsQuery = psql.Select(
+	"id",
+	"time",
+	"action",
+	"user_name",
+	"team_name",
+	"pipeline_name",
+	"pipeline_instance_vars",
+	"request_id",
+	"status",
+	"diff",
+).
+	From("audit_events")
+
+type auditLog struct {
+	conn Conn
+}
+
+func NewAuditLog(conn Conn) AuditLog {
+	return &auditLog{
+		conn: conn,
+	}
+}
+
+func (l *auditLog) Save(event atc.AuditEvent) error {
+	var instanceVars interface{}
+	if len(event.PipelineInstanceVars) != 0 {
+		payload, err := json.Marshal(event.PipelineInstanceVars)
+		if err != nil {
+			return err
+		}
+
+		instanceVars = payload
+	}
+
+	values := map[string]interface{}{
+		"action":                 event.Action,
+		"user_name":              event.User,
+		"team_name":              sql.NullString{String: event.TeamName, Valid: event.TeamName != ""},
+		"pipeline_name":          sql.NullString{String: event.PipelineName, Valid: event.PipelineName != ""},
+		"pipeline_instance_vars": instanceVars,
+		"request_id":             sql.NullString{String: event.RequestID, Valid: event.RequestID != ""},
+		"status":                 event.Status,
+		"diff":                   sql.NullString{String: event.Diff, Valid: event.Diff != ""},
+	}
+
+	if event.Time != 0 {
+		values["time"] = time.Unix(event.Time, 0)
+	}
+
+	_, err := psql.Insert("audit_events").
+		SetMap(values).
+		RunWith(l.conn).
+		Exec()
+	return err
+}
+
+func (l *auditLog) Events(filter AuditLogFilter, page Page) ([]atc.AuditEvent, Pagination, error) {
+	tx, err := l.conn.Begin()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Rollback(tx)
+
+	where := filter.filter()
+
+	query := auditEventsQuery.
+		Where(where).
+		Limit(uint64(page.Limit))
+
+	var reverse bool
+	if page.From == nil && page.To == nil {
+		query = query.OrderBy("id DESC")
+	} else if page.From != nil && page.To == nil {
+		query = query.
+			Where(sq.GtOrEq{"id": *page.From}).
+			OrderBy("id ASC")
+		reverse = true
+	} else if page.From == nil && page.To != nil {
+		query = query.
+			Where(sq.LtOrEq{"id": *page.To}).
+			OrderBy("id DESC")
+	} else {
+		if *page.From > *page.To {
+			return nil, Pagination{}, fmt.Errorf("invalid range boundaries")
+		}
+
+		query = query.
+			Where(sq.GtOrEq{"id": *page.From}).
+			Where(sq.LtOrEq{"id": *page.To}).
+			OrderBy("id DESC")
+	}
+
+	events, err := queryAuditEvents(tx, query)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	if reverse {
+		for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+			events[i], events[j] = events[j], events[i]
+		}
+	}
+
+	if len(events) == 0 {
+		return events, Pagination{}, nil
+	}
+
+	var pagination Pagination
+
+	var olderID sql.NullInt64
+	err = psql.Select("max(id)").
+		From("audit_events").
+		Where(where).
+		Where(sq.Lt{"id": events[len(events)-1].ID}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&olderID)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	if olderID.Valid {
+		pagination.Older = &Page{
+			To:    NewIntPtr(int(olderID.Int64)),
+			Limit: page.Limit,
+		}
+	}
+
+	var newerID sql.NullInt64
+	err = psql.Select("min(id)").
+		From("audit_events").
+		Where(where).
+		Where(sq.Gt{"id": events[0].ID}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&newerID)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	if newerID.Valid {
+		pagination.Newer = &Page{
+			From:  NewIntPtr(int(newerID.Int64)),
+			Limit: page.Limit,
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	return events, pagination, nil
+}
+
+func (l *auditLog) UndrainedEvents(limit int) ([]atc.AuditEvent, error) {
+	return queryAuditEvents(l.conn, auditEventsQuery.
+		Where(sq.Expr("NOT drained")).
+		OrderBy("id ASC").
+		Limit(uint64(limit)))
+}
+
+func (l *auditLog) MarkDrained(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := psql.Update("audit_events").
+		Set("drained", true).
+		Where(sq.Eq{"id": ids}).
+		RunWith(l.conn).
+		Exec()
+	return err
+}
+
+func (l *auditLog) RemoveEventsBefore(before time.Time) (int64, error) {
+	result, err := psql.Delete("audit_events").
+		Where(sq.Lt{"time": before}).
+		RunWith(l.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func queryAuditEvents(runner sq.BaseRunner, query sq.SelectBuilder) ([]atc.AuditEvent, error) {
+	rows, err := query.RunWith(runner).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	events := []atc.AuditEvent{}
+	for rows.Next() {
+		var (
+			event        atc.AuditEvent
+			eventTime    time.Time
+			teamName     sql.NullString
+			pipelineName sql.NullString
+			instanceVars sql.NullString
+			requestID    sql.NullString
+			diff         sql.NullString
+		)
+
+		err = rows.Scan(
+			&event.ID,
+			&eventTime,
+			&event.Action,
+			&event.User,
+			&teamName,
+			&pipelineName,
+			&instanceVars,
+			&requestID,
+			&event.Status,
+			&diff,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Time = eventTime.Unix()
+		event.TeamName = teamName.String
+		event.PipelineName = pipelineName.String
+		event.RequestID = requestID.String
+		event.Diff = diff.String
+
+		if instanceVars.Valid {
+			err = json.Unmarshal([]byte(instanceVars.String), &event.PipelineInstanceVars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
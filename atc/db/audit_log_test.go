@@ -0,0 +1,189 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditLog", func() {
+	var auditLog db.AuditLog
+
+	BeforeEach(func() {
+		auditLog = db.NewAuditLog(dbConn)
+	})
+
+	save := func(event atc.AuditEvent) {
+		err := auditLog.Save(event)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	ids := func(events []atc.AuditEvent) []int {
+		var ids []int
+		for _, event := range events {
+			ids = append(ids, event.ID)
+		}
+		return ids
+	}
+
+	Describe("Save", func() {
+		It("saves the event", func() {
+			save(atc.AuditEvent{
+				Action:               atc.SaveConfig,
+				User:                 "some-user",
+				TeamName:             "some-team",
+				PipelineName:         "some-pipeline",
+				PipelineInstanceVars: atc.InstanceVars{"branch": "main"},
+				RequestID:            "some-request-id",
+				Status:               200,
+				Diff:                 "jobs: +some-job",
+			})
+
+			events, _, err := auditLog.Events(db.AuditLogFilter{}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+
+			event := events[0]
+			Expect(event.ID).ToNot(BeZero())
+			Expect(event.Time).To(BeNumerically("~", time.Now().Unix(), 60))
+
+			event.ID = 0
+			event.Time = 0
+			Expect(event).To(Equal(atc.AuditEvent{
+				Action:               atc.SaveConfig,
+				User:                 "some-user",
+				TeamName:             "some-team",
+				PipelineName:         "some-pipeline",
+				PipelineInstanceVars: atc.InstanceVars{"branch": "main"},
+				RequestID:            "some-request-id",
+				Status:               200,
+				Diff:                 "jobs: +some-job",
+			}))
+		})
+	})
+
+	Describe("Events", func() {
+		BeforeEach(func() {
+			save(atc.AuditEvent{Action: atc.SaveConfig, User: "alice", TeamName: "some-team", Status: 200})
+			save(atc.AuditEvent{Action: atc.SetTeam, User: "bob", TeamName: "some-team", Status: 200})
+			save(atc.AuditEvent{Action: atc.SaveConfig, User: "bob", TeamName: "other-team", Status: 403})
+			save(atc.AuditEvent{
+				Action: atc.SaveConfig,
+				User:   "alice",
+				Time:   time.Now().Add(-48 * time.Hour).Unix(),
+				Status: 200,
+			})
+		})
+
+		It("returns the events newest first", func() {
+			events, pagination, err := auditLog.Events(db.AuditLogFilter{}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(4))
+			Expect(events[0].ID).To(BeNumerically(">", events[1].ID))
+			Expect(pagination).To(Equal(db.Pagination{}))
+		})
+
+		It("filters by user", func() {
+			events, _, err := auditLog.Events(db.AuditLogFilter{User: "bob"}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].User).To(Equal("bob"))
+			Expect(events[1].User).To(Equal("bob"))
+		})
+
+		It("filters by action and team", func() {
+			events, _, err := auditLog.Events(db.AuditLogFilter{Action: atc.SaveConfig, TeamName: "some-team"}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].User).To(Equal("alice"))
+		})
+
+		It("filters by time", func() {
+			events, _, err := auditLog.Events(db.AuditLogFilter{
+				User:  "alice",
+				Until: time.Now().Add(-time.Hour),
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+
+			events, _, err = auditLog.Events(db.AuditLogFilter{
+				User:  "alice",
+				Since: time.Now().Add(-time.Hour),
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].TeamName).To(Equal("some-team"))
+		})
+
+		It("paginates", func() {
+			all, _, err := auditLog.Events(db.AuditLogFilter{}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+
+			events, pagination, err := auditLog.Events(db.AuditLogFilter{}, db.Page{Limit: 2})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids(events)).To(Equal(ids(all[:2])))
+			Expect(pagination.Newer).To(BeNil())
+			Expect(pagination.Older).To(Equal(&db.Page{To: db.NewIntPtr(all[2].ID), Limit: 2}))
+
+			events, pagination, err = auditLog.Events(db.AuditLogFilter{}, *pagination.Older)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids(events)).To(Equal(ids(all[2:])))
+			Expect(pagination.Older).To(BeNil())
+			Expect(pagination.Newer).To(Equal(&db.Page{From: db.NewIntPtr(all[1].ID), Limit: 2}))
+
+			events, _, err = auditLog.Events(db.AuditLogFilter{}, *pagination.Newer)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids(events)).To(Equal(ids(all[:2])))
+		})
+	})
+
+	Describe("draining", func() {
+		BeforeEach(func() {
+			save(atc.AuditEvent{Action: atc.SaveConfig, User: "alice", Status: 200})
+			save(atc.AuditEvent{Action: atc.SetTeam, User: "bob", Status: 200})
+		})
+
+		It("returns the undrained events oldest first until they are marked drained", func() {
+			events, err := auditLog.UndrainedEvents(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].User).To(Equal("alice"))
+			Expect(events[1].User).To(Equal("bob"))
+
+			err = auditLog.MarkDrained([]int{events[0].ID})
+			Expect(err).ToNot(HaveOccurred())
+
+			events, err = auditLog.UndrainedEvents(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].User).To(Equal("bob"))
+		})
+	})
+
+	Describe("RemoveEventsBefore", func() {
+		BeforeEach(func() {
+			save(atc.AuditEvent{Action: atc.SaveConfig, User: "alice", Status: 200})
+			save(atc.AuditEvent{
+				Action: atc.SaveConfig,
+				User:   "bob",
+				Time:   time.Now().Add(-48 * time.Hour).Unix(),
+				Status: 200,
+			})
+		})
+
+		It("removes the events older than the given time", func() {
+			removed, err := auditLog.RemoveEventsBefore(time.Now().Add(-24 * time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(removed).To(Equal(int64(1)))
+
+			events, _, err := auditLog.Events(db.AuditLogFilter{}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].User).To(Equal("alice"))
+		})
+	})
+})
@@ -96,7 +96,8 @@ var buildsQuery = psql.Select(`
 		rb.name,
 		b.rerun_number,
 		b.span_context,
-		COALESCE(bc.comment, '')
+		COALESCE(bc.comment, ''),
+		b.flaky
 	`).
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
@@ -152,6 +153,7 @@ type Build interface {
 	RerunOfName() string
 	RerunNumber() int
 	CreatedBy() *string
+	Flaky() bool
 
 	LagerData() lager.Data
 	TracingAttrs() tracing.Attrs
@@ -181,6 +183,15 @@ type Build interface {
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
 
+	SaveStepUsage(atc.StepUsage) error
+	StepUsage() ([]atc.StepUsage, error)
+
+	SaveStepTiming(atc.StepTiming) error
+	StepTimings() ([]atc.StepTiming, error)
+
+	SaveTaskFailure(flakyTolerant bool) error
+	RetryableOnFlake(attempts int) (bool, error)
+
 	SaveOutput(string, atc.Source, atc.VersionedResourceTypes, atc.Version, ResourceConfigMetadataFields, string, string) error
 	AdoptInputsAndPipes() ([]BuildInput, bool, error)
 	AdoptRerunInputsAndPipes() ([]BuildInput, bool, error)
@@ -235,6 +246,7 @@ type build struct {
 	rerunOf     int
 	rerunOfName string
 	rerunNumber int
+	flaky       bool
 
 	schema      string
 	privatePlan atc.Plan
@@ -373,6 +385,7 @@ func (b *build) RerunOf() int          { return b.rerunOf }
 func (b *build) RerunOfName() string   { return b.rerunOfName }
 func (b *build) RerunNumber() int      { return b.rerunNumber }
 func (b *build) CreatedBy() *string    { return b.createdBy }
+func (b *build) Flaky() bool           { return b.flaky }
 
 func (b *build) Reload() (bool, error) {
 	row := buildsQuery.Where(sq.Eq{"b.id": b.id}).
@@ -756,6 +769,13 @@ WITH RECURSIVE pipelines_to_archive AS (
 			return err
 		}
 
+		flaky, err := markFlakyBuilds(tx, b.rerunGroup())
+		if err != nil {
+			return err
+		}
+
+		b.flaky = b.flaky || flaky
+
 		latestNonRerunID, err := latestCompletedNonRerunBuild(tx, b.jobID)
 		if err != nil {
 			return err
@@ -1182,6 +1202,120 @@ func (b *build) Artifacts() ([]WorkerArtifact, error) {
 	return artifacts, nil
 }
 
+func (b *build) SaveStepUsage(usage atc.StepUsage) error {
+	_, err := psql.Insert("build_step_usage").
+		Columns("build_id", "plan_id", "step_name", "cpu_average", "cpu_peak", "memory_average", "memory_peak", "block_io_read", "block_io_write", "samples").
+		Values(b.id, string(usage.PlanID), usage.StepName, usage.Usage.CPUAverage, usage.Usage.CPUPeak, usage.Usage.MemoryAverage, usage.Usage.MemoryPeak, usage.Usage.BlockIORead, usage.Usage.BlockIOWrite, usage.Usage.Samples).
+		Suffix(`ON CONFLICT (build_id, plan_id) DO UPDATE SET
+			cpu_average = EXCLUDED.cpu_average,
+			cpu_peak = EXCLUDED.cpu_peak,
+			memory_average = EXCLUDED.memory_average,
+			memory_peak = EXCLUDED.memory_peak,
+			block_io_read = EXCLUDED.block_io_read,
+			block_io_write = EXCLUDED.block_io_write,
+			samples = EXCLUDED.samples`).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (b *build) StepUsage() ([]atc.StepUsage, error) {
+	rows, err := psql.Select("plan_id", "step_name", "cpu_average", "cpu_peak", "memory_average", "memory_peak", "block_io_read", "block_io_write", "samples").
+		From("build_step_usage").
+		Where(sq.Eq{
+			"build_id": b.id,
+		}).
+		OrderBy("created_at", "plan_id").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	usages := []atc.StepUsage{}
+	for rows.Next() {
+		var usage atc.StepUsage
+		err = rows.Scan(
+			&usage.PlanID,
+			&usage.StepName,
+			&usage.Usage.CPUAverage,
+			&usage.Usage.CPUPeak,
+			&usage.Usage.MemoryAverage,
+			&usage.Usage.MemoryPeak,
+			&usage.Usage.BlockIORead,
+			&usage.Usage.BlockIOWrite,
+			&usage.Usage.Samples,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		usages = append(usages, usage)
+	}
+
+	return usages, nil
+}
+
+func (b *build) SaveStepTiming(timing atc.StepTiming) error {
+	_, err := psql.Insert("build_step_timings").
+		Columns("build_id", "plan_id", "step_name", "phase", "start_time", "duration", "bytes").
+		Values(b.id, string(timing.PlanID), timing.StepName, string(timing.Phase), time.Unix(timing.StartTime, 0), timing.Duration, timing.Bytes).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (b *build) StepTimings() ([]atc.StepTiming, error) {
+	rows, err := psql.Select("plan_id", "step_name", "phase", "EXTRACT(EPOCH FROM start_time)::bigint", "duration", "bytes").
+		From("build_step_timings").
+		Where(sq.Eq{
+			"build_id": b.id,
+		}).
+		OrderBy("start_time", "id").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	timings := []atc.StepTiming{}
+	for rows.Next() {
+		var timing atc.StepTiming
+		var bytes sql.NullInt64
+		err = rows.Scan(
+			&timing.PlanID,
+			&timing.StepName,
+			&timing.Phase,
+			&timing.StartTime,
+			&timing.Duration,
+			&bytes,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.Valid {
+			timing.Bytes = &bytes.Int64
+		}
+
+		timings = append(timings, timing)
+	}
+
+	return timings, nil
+}
+
 func (b *build) SaveOutput(
 	resourceType string,
 	source atc.Source,
@@ -1835,6 +1969,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		&rerunNumber,
 		&spanContext,
 		&comment,
+		&b.flaky,
 	)
 	if err != nil {
 		return err
@@ -1911,18 +2046,26 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	return nil
 }
 
-func (b *build) saveEvent(tx Tx, event atc.Event) error {
-	payload, err := json.Marshal(event)
+func (b *build) saveEvent(tx Tx, ev atc.Event) error {
+	payload, err := json.Marshal(ev)
 	if err != nil {
 		return err
 	}
 
 	_, err = psql.Insert(b.eventsTable()).
 		Columns("event_id", "build_id", "type", "version", "payload").
-		Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), payload).
+		Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(ev.EventType()), string(ev.Version()), payload).
 		RunWith(tx).
 		Exec()
-	return err
+	if err != nil {
+		return err
+	}
+
+	if atc.EnableBuildLogSearch && !b.isForCheck() {
+		return indexBuildEvent(tx, b.id, ev)
+	}
+
+	return nil
 }
 
 func (b *build) isForCheck() bool {
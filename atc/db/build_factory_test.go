@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"context"
 	"time"
 
 	"github.com/concourse/concourse/atc"
@@ -107,10 +108,10 @@ var _ = Describe("BuildFactory", func() {
 		Context("pipeline builds", func() {
 
 			It("[#139963615] marks builds that aren't the latest as non-interceptible, ", func() {
-				build1, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build1, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
-				build2, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build2, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				err = build1.Finish(db.BuildStatusErrored)
@@ -131,10 +132,10 @@ var _ = Describe("BuildFactory", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				pb1, err := j.CreateBuild(defaultBuildCreatedBy)
+				pb1, err := j.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
-				pb2, err := j.CreateBuild(defaultBuildCreatedBy)
+				pb2, err := j.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				err = pb1.Finish(db.BuildStatusErrored)
@@ -166,7 +167,7 @@ var _ = Describe("BuildFactory", func() {
 
 			DescribeTable("completed builds",
 				func(status db.BuildStatus, matcher types.GomegaMatcher) {
-					b, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+					b, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).NotTo(HaveOccurred())
 
 					var i bool
@@ -187,7 +188,7 @@ var _ = Describe("BuildFactory", func() {
 			)
 
 			It("does not mark non-completed builds", func() {
-				b, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+				b, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				var i bool
@@ -214,7 +215,7 @@ var _ = Describe("BuildFactory", func() {
 		Context("GC failed builds", func() {
 			It("marks failed builds non-interceptible after failed-grace-period", func() {
 				buildFactory = db.NewBuildFactory(dbConn, lockFactory, 0, 2*time.Second) // 1 second could create a flaky test
-				build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				err = build.Finish(db.BuildStatusFailed)
@@ -260,7 +261,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build2, err = privateJob.CreateBuild(defaultBuildCreatedBy)
+			build2, err = privateJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			publicPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "public-pipeline"}, config, db.ConfigVersion(1), false)
@@ -272,7 +273,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build3, err = publicJob.CreateBuild(defaultBuildCreatedBy)
+			build3, err = publicJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
@@ -319,7 +320,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build2, err = privateJob.CreateBuild(defaultBuildCreatedBy)
+			build2, err = privateJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			publicPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "public-pipeline"}, config, db.ConfigVersion(1), false)
@@ -331,7 +332,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build3, err = publicJob.CreateBuild(defaultBuildCreatedBy)
+			build3, err = publicJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
@@ -365,7 +366,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			_, err = privateJob.CreateBuild(defaultBuildCreatedBy)
+			_, err = privateJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			publicPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "public-pipeline"}, config, db.ConfigVersion(1), false)
@@ -377,7 +378,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			publicBuild, err = publicJob.CreateBuild(defaultBuildCreatedBy)
+			publicBuild, err = publicJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -413,10 +414,10 @@ var _ = Describe("BuildFactory", func() {
 			build2DB, err = team.CreateOneOffBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			build3DB, err = job.CreateBuild(defaultBuildCreatedBy)
+			build3DB, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
-			build4DB, err = job.CreateBuild(defaultBuildCreatedBy)
+			build4DB, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			started, err := build2DB.Start(atc.Plan{})
@@ -465,7 +466,7 @@ var _ = Describe("BuildFactory", func() {
 			build1DB, err = team.CreateOneOffBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			build2DB, err = job.CreateBuild(defaultBuildCreatedBy)
+			build2DB, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			_, err = team.CreateOneOffBuild()
@@ -514,7 +515,7 @@ var _ = Describe("BuildFactory", func() {
 			build1DB, err = team.CreateOneOffBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			build2DB, err = job.CreateBuild(defaultBuildCreatedBy)
+			build2DB, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			_, err = team.CreateOneOffBuild()
@@ -0,0 +1,373 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/lib/pq"
+)
+
+// BuildLogSearch describes a search over the indexed lines of build logs.
+// Lines match when each word of Query starts a word of the line and the line
+// contains Query as a case-insensitive substring, so "conn ref" finds
+// "connection refused" but "nnection" finds nothing.
+type BuildLogSearch struct {
+	Query string
+
+	PipelineID int
+	JobID      int
+
+	Since time.Time
+	Until time.Time
+
+	// Context is the number of lines before and after each match to include
+	// in the result.
+	Context int
+}
+
+// prefixTSQuery turns every lexeme of the plain text query into a prefix
+// match, e.g. 'conn' & 'ref' becomes 'conn':* & 'ref':*.
+const prefixTSQuery = `to_tsquery('simple', regexp_replace(plainto_tsquery('simple', ?)::text, '''(\s|$)', ''':*\1', 'g'))`
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (search BuildLogSearch) filter(teamID int) sq.And {
+	filter := sq.And{
+		sq.Eq{"b.team_id": teamID},
+		sq.Expr("to_tsvector('simple', l.line) @@ "+prefixTSQuery, search.Query),
+		sq.Expr("l.line ILIKE ?", "%"+likeEscaper.Replace(search.Query)+"%"),
+	}
+
+	if search.PipelineID != 0 {
+		filter = append(filter, sq.Eq{"b.pipeline_id": search.PipelineID})
+	}
+
+	if search.JobID != 0 {
+		filter = append(filter, sq.Eq{"b.job_id": search.JobID})
+	}
+
+	if !search.Since.IsZero() {
+		filter = append(filter, sq.GtOrEq{"l.time": search.Since})
+	}
+
+	if !search.Until.IsZero() {
+		filter = append(filter, sq.LtOrEq{"l.time": search.Until})
+	}
+
+	return filter
+}
+
+// buildLogTails holds the last line of each step's logs until it is
+// terminated, as a line can be written in several pieces.
+var buildLogTails = newLogTails()
+
+// maxBuildLogTail is the longest unterminated line held for a step.
+const maxBuildLogTail = 64 * 1024
+
+type logTails struct {
+	tails map[logTailKey]logLine
+	lock  *sync.Mutex
+}
+
+type logTailKey struct {
+	buildID int
+	origin  event.OriginID
+}
+
+type logLine struct {
+	origin event.OriginID
+	time   int64
+	line   string
+}
+
+func newLogTails() *logTails {
+	return &logTails{
+		tails: make(map[logTailKey]logLine),
+		lock:  &sync.Mutex{},
+	}
+}
+
+// Append adds a log to what was written before it by the same step, and
+// returns the lines that are now complete. The rest is held until the next
+// log of the step, or until the step finishes.
+func (t *logTails) Append(buildID int, log event.Log) []logLine {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	key := logTailKey{buildID, log.Origin.ID}
+
+	tail, found := t.tails[key]
+	if !found {
+		tail = logLine{origin: log.Origin.ID, time: log.Time}
+	}
+
+	var lines []logLine
+
+	payload := log.Payload
+	for {
+		end := strings.Index(payload, "\n")
+		if end == -1 {
+			break
+		}
+
+		tail.line += payload[:end]
+		lines = append(lines, tail)
+
+		tail = logLine{origin: log.Origin.ID, time: log.Time}
+		payload = payload[end+1:]
+	}
+
+	tail.line += payload
+
+	// output which never ends a line is indexed in pieces, rather than
+	// held indefinitely
+	if len(tail.line) >= maxBuildLogTail {
+		lines = append(lines, tail)
+		tail = logLine{origin: log.Origin.ID, time: log.Time}
+	}
+
+	if tail.line == "" {
+		delete(t.tails, key)
+	} else {
+		t.tails[key] = tail
+	}
+
+	return lines
+}
+
+// Flush returns the unterminated lines of a build's steps, and forgets them.
+// An empty origin flushes every step of the build.
+func (t *logTails) Flush(buildID int, origin event.OriginID) []logLine {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var lines []logLine
+	for key, tail := range t.tails {
+		if key.buildID != buildID || (origin != "" && key.origin != origin) {
+			continue
+		}
+
+		lines = append(lines, tail)
+		delete(t.tails, key)
+	}
+
+	return lines
+}
+
+// indexBuildEvent indexes the lines of the build's logs once they're
+// complete.
+func indexBuildEvent(tx Tx, buildID int, ev atc.Event) error {
+	switch e := ev.(type) {
+	case event.Log:
+		return indexBuildLogLines(tx, buildID, buildLogTails.Append(buildID, e))
+	case event.FinishTask:
+		return indexBuildLogLines(tx, buildID, buildLogTails.Flush(buildID, e.Origin.ID))
+	case event.FinishGet:
+		return indexBuildLogLines(tx, buildID, buildLogTails.Flush(buildID, e.Origin.ID))
+	case event.FinishPut:
+		return indexBuildLogLines(tx, buildID, buildLogTails.Flush(buildID, e.Origin.ID))
+	case event.Finish:
+		return indexBuildLogLines(tx, buildID, buildLogTails.Flush(buildID, e.Origin.ID))
+	case event.Error:
+		return indexBuildLogLines(tx, buildID, buildLogTails.Flush(buildID, e.Origin.ID))
+	case event.Status:
+		return indexBuildLogLines(tx, buildID, buildLogTails.Flush(buildID, ""))
+	}
+
+	return nil
+}
+
+func indexBuildLogLines(tx Tx, buildID int, lines []logLine) error {
+	insert := psql.Insert("build_log_lines").
+		Columns("build_id", "origin", "time", "line")
+
+	var count int
+	for _, line := range lines {
+		text := strings.TrimRight(line.line, "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		insert = insert.Values(buildID, string(line.origin), time.Unix(line.time, 0), text)
+		count++
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	_, err := insert.RunWith(tx).Exec()
+	return err
+}
+
+func searchBuildLogs(conn Conn, teamID int, search BuildLogSearch, page Page) ([]atc.BuildLogMatch, Pagination, error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Rollback(tx)
+
+	filter := search.filter(teamID)
+
+	query := psql.Select("l.id", "l.build_id", "b.name", "t.name", "p.id", "p.name", "p.instance_vars", "j.name", "l.origin", "l.time", "l.line").
+		Column(sq.Expr("ARRAY(SELECT c.line FROM build_log_lines c WHERE c.build_id = l.build_id AND c.origin = l.origin AND c.id < l.id ORDER BY c.id DESC LIMIT ?)", search.Context)).
+		Column(sq.Expr("ARRAY(SELECT c.line FROM build_log_lines c WHERE c.build_id = l.build_id AND c.origin = l.origin AND c.id > l.id ORDER BY c.id ASC LIMIT ?)", search.Context)).
+		From("build_log_lines l").
+		Join("builds b ON b.id = l.build_id").
+		Join("teams t ON t.id = b.team_id").
+		LeftJoin("pipelines p ON p.id = b.pipeline_id").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		Where(filter).
+		Limit(uint64(page.Limit))
+
+	var reverse bool
+	if page.From == nil && page.To == nil {
+		query = query.OrderBy("l.id DESC")
+	} else if page.From != nil && page.To == nil {
+		query = query.
+			Where(sq.GtOrEq{"l.id": *page.From}).
+			OrderBy("l.id ASC")
+		reverse = true
+	} else if page.From == nil && page.To != nil {
+		query = query.
+			Where(sq.LtOrEq{"l.id": *page.To}).
+			OrderBy("l.id DESC")
+	} else {
+		if *page.From > *page.To {
+			return nil, Pagination{}, fmt.Errorf("invalid range boundaries")
+		}
+
+		query = query.
+			Where(sq.GtOrEq{"l.id": *page.From}).
+			Where(sq.LtOrEq{"l.id": *page.To}).
+			OrderBy("l.id DESC")
+	}
+
+	rows, err := query.RunWith(tx).Query()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Close(rows)
+
+	matches := []atc.BuildLogMatch{}
+	for rows.Next() {
+		var (
+			match        atc.BuildLogMatch
+			pipelineID   sql.NullInt64
+			pipelineName sql.NullString
+			instanceVars sql.NullString
+			jobName      sql.NullString
+			logTime      time.Time
+			before       []string
+			after        []string
+		)
+
+		err = rows.Scan(
+			&match.ID,
+			&match.BuildID,
+			&match.BuildName,
+			&match.TeamName,
+			&pipelineID,
+			&pipelineName,
+			&instanceVars,
+			&jobName,
+			&match.Origin,
+			&logTime,
+			&match.Line,
+			pq.Array(&before),
+			pq.Array(&after),
+		)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		match.PipelineID = int(pipelineID.Int64)
+		match.PipelineName = pipelineName.String
+		match.JobName = jobName.String
+		match.Time = logTime.Unix()
+
+		if instanceVars.Valid {
+			err = json.Unmarshal([]byte(instanceVars.String), &match.PipelineInstanceVars)
+			if err != nil {
+				return nil, Pagination{}, err
+			}
+		}
+
+		for i, j := 0, len(before)-1; i < j; i, j = i+1, j-1 {
+			before[i], before[j] = before[j], before[i]
+		}
+
+		match.Before = before
+		match.After = after
+
+		matches = append(matches, match)
+	}
+
+	if reverse {
+		for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+			matches[i], matches[j] = matches[j], matches[i]
+		}
+	}
+
+	if len(matches) == 0 {
+		return matches, Pagination{}, nil
+	}
+
+	var pagination Pagination
+
+	var olderID sql.NullInt64
+	err = psql.Select("max(l.id)").
+		From("build_log_lines l").
+		Join("builds b ON b.id = l.build_id").
+		Where(filter).
+		Where(sq.Lt{"l.id": matches[len(matches)-1].ID}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&olderID)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	if olderID.Valid {
+		pagination.Older = &Page{
+			To:    NewIntPtr(int(olderID.Int64)),
+			Limit: page.Limit,
+		}
+	}
+
+	var newerID sql.NullInt64
+	err = psql.Select("min(l.id)").
+		From("build_log_lines l").
+		Join("builds b ON b.id = l.build_id").
+		Where(filter).
+		Where(sq.Gt{"l.id": matches[0].ID}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&newerID)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	if newerID.Valid {
+		pagination.Newer = &Page{
+			From:  NewIntPtr(int(newerID.Int64)),
+			Limit: page.Limit,
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	return matches, pagination, nil
+}
@@ -0,0 +1,259 @@
+package db_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Build log search", func() {
+	var (
+		build      db.Build
+		otherBuild db.Build
+	)
+
+	BeforeEach(func() {
+		atc.EnableBuildLogSearch = true
+
+		var err error
+		build, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = build.SaveEvent(event.Log{
+			Time:    10,
+			Origin:  event.Origin{ID: "some-step"},
+			Payload: "connecting to db\r\ndial tcp: connection refused\nretrying\n",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = build.SaveEvent(event.Log{
+			Time:    20,
+			Origin:  event.Origin{ID: "other-step"},
+			Payload: "Connection Refused by peer\n",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		otherBuild, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = otherBuild.SaveEvent(event.Log{
+			Time:    30,
+			Origin:  event.Origin{ID: "some-step"},
+			Payload: "connection refused again\n",
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		atc.EnableBuildLogSearch = false
+	})
+
+	Describe("SearchBuildLogs", func() {
+		It("returns matching lines across the team's builds, newest first", func() {
+			matches, pagination, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+				Query: "connection refused",
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pagination).To(Equal(db.Pagination{}))
+
+			Expect(matches).To(HaveLen(3))
+			Expect(matches[0].BuildID).To(Equal(otherBuild.ID()))
+			Expect(matches[0].Line).To(Equal("connection refused again"))
+			Expect(matches[0].TeamName).To(Equal(defaultTeam.Name()))
+			Expect(matches[0].PipelineName).To(BeEmpty())
+
+			Expect(matches[1].BuildID).To(Equal(build.ID()))
+			Expect(matches[1].Line).To(Equal("Connection Refused by peer"))
+			Expect(matches[1].Origin).To(Equal("other-step"))
+			Expect(matches[1].Time).To(Equal(int64(20)))
+
+			Expect(matches[2].Line).To(Equal("dial tcp: connection refused"))
+			Expect(matches[2].PipelineName).To(Equal(defaultPipeline.Name()))
+			Expect(matches[2].PipelineInstanceVars).To(Equal(defaultPipeline.InstanceVars()))
+			Expect(matches[2].JobName).To(Equal(defaultJob.Name()))
+			Expect(matches[2].BuildName).To(Equal(build.Name()))
+		})
+
+		It("matches the beginning of words", func() {
+			matches, _, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+				Query: "dial tc",
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(HaveLen(1))
+			Expect(matches[0].Line).To(Equal("dial tcp: connection refused"))
+
+			matches, _, err = defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+				Query: "nnection",
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+		})
+
+		It("includes surrounding lines from the same step", func() {
+			matches, _, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+				Query:   "dial tcp",
+				Context: 2,
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(matches).To(HaveLen(1))
+			Expect(matches[0].Before).To(Equal([]string{"connecting to db"}))
+			Expect(matches[0].After).To(Equal([]string{"retrying"}))
+		})
+
+		It("filters by job and time range", func() {
+			matches, _, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+				Query:      "connection refused",
+				PipelineID: defaultPipeline.ID(),
+				JobID:      defaultJob.ID(),
+				Until:      time.Unix(15, 0),
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(matches).To(HaveLen(1))
+			Expect(matches[0].Line).To(Equal("dial tcp: connection refused"))
+		})
+
+		It("paginates the matches", func() {
+			matches, pagination, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+				Query: "connection refused",
+			}, db.Page{Limit: 2})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(HaveLen(2))
+			Expect(pagination.Newer).To(BeNil())
+			Expect(pagination.Older).ToNot(BeNil())
+
+			olderMatches, olderPagination, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+				Query: "connection refused",
+			}, *pagination.Older)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(olderMatches).To(HaveLen(1))
+			Expect(olderMatches[0].Line).To(Equal("dial tcp: connection refused"))
+			Expect(olderPagination.Older).To(BeNil())
+			Expect(olderPagination.Newer).To(Equal(&db.Page{From: db.NewIntPtr(matches[1].ID), Limit: 2}))
+		})
+
+		It("does not return lines from other teams", func() {
+			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			matches, _, err := otherTeam.SearchBuildLogs(db.BuildLogSearch{
+				Query: "connection refused",
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+		})
+
+		It("does not return lines of builds whose events were reaped", func() {
+			err := defaultPipeline.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+			Expect(err).ToNot(HaveOccurred())
+
+			matches, _, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+				Query: "connection refused",
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(matches).To(HaveLen(1))
+			Expect(matches[0].BuildID).To(Equal(otherBuild.ID()))
+		})
+
+		Context("when a line is written in pieces", func() {
+			BeforeEach(func() {
+				err := build.SaveEvent(event.Log{
+					Time:    40,
+					Origin:  event.Origin{ID: "split-step"},
+					Payload: "foo ba",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveEvent(event.Log{
+					Time:    41,
+					Origin:  event.Origin{ID: "split-step"},
+					Payload: "r\n",
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("indexes the whole line", func() {
+				matches, _, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+					Query: "foo bar",
+				}, db.Page{Limit: 10})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(matches).To(HaveLen(1))
+				Expect(matches[0].Line).To(Equal("foo bar"))
+				Expect(matches[0].Time).To(Equal(int64(40)))
+			})
+		})
+
+		Context("when the last line of a step is not terminated", func() {
+			BeforeEach(func() {
+				err := build.SaveEvent(event.Log{
+					Time:    40,
+					Origin:  event.Origin{ID: "unterminated-step"},
+					Payload: "exiting without a newline",
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not index it while the step is running", func() {
+				matches, _, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+					Query: "without a newline",
+				}, db.Page{Limit: 10})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(matches).To(BeEmpty())
+			})
+
+			It("indexes it once the step finishes", func() {
+				err := build.SaveEvent(event.FinishTask{
+					Time:       41,
+					Origin:     event.Origin{ID: "unterminated-step"},
+					ExitStatus: 0,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				matches, _, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+					Query: "without a newline",
+				}, db.Page{Limit: 10})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(matches).To(HaveLen(1))
+				Expect(matches[0].Line).To(Equal("exiting without a newline"))
+			})
+
+			It("indexes it once the build finishes", func() {
+				err := build.Finish(db.BuildStatusFailed)
+				Expect(err).ToNot(HaveOccurred())
+
+				matches, _, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+					Query: "without a newline",
+				}, db.Page{Limit: 10})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(matches).To(HaveLen(1))
+			})
+		})
+
+		Context("when build log search is disabled", func() {
+			BeforeEach(func() {
+				atc.EnableBuildLogSearch = false
+
+				err := build.SaveEvent(event.Log{
+					Time:    40,
+					Origin:  event.Origin{ID: "some-step"},
+					Payload: "unindexed connection refused\n",
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not index new log lines", func() {
+				matches, _, err := defaultTeam.SearchBuildLogs(db.BuildLogSearch{
+					Query: "unindexed",
+				}, db.Page{Limit: 10})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(matches).To(BeEmpty())
+			})
+		})
+	})
+})
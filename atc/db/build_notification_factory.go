@@ -0,0 +1,297 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/encryption"
+)
+
+// BuildNotificationFactory queues the notifications configured by pipelines
+// and teams for their builds' events, and records their delivery.
+//
+//counterfeiter:generate . BuildNotificationFactory
+type BuildNotificationFactory interface {
+	// QueueBuildNotifications queues a notification of the event for each
+	// notifier of the build's pipeline and team that notifies for it.
+	// Notifications already queued for the event are left as they are, so
+	// that resuming a build does not notify twice.
+	QueueBuildNotifications(build Build, event string) error
+
+	// DueBuildNotifications returns the pending notifications whose next
+	// attempt is due, oldest first.
+	DueBuildNotifications(limit int) ([]atc.BuildNotification, error)
+
+	BuildNotifications(buildID int) ([]atc.BuildNotification, error)
+	TeamBuildNotifications(teamID int, limit int) ([]atc.BuildNotification, error)
+
+	BuildNotificationDelivered(id int, responseStatus int) error
+
+	// BuildNotificationFailed records a failed attempt at delivering the
+	// notification. It is attempted again at retryAt, or given up on if
+	// retryAt is zero.
+	BuildNotificationFailed(id int, responseStatus int, deliveryErr string, retryAt time.Time) error
+}
+
+var buildNotificationsQuery = psql.Select(
+	"n.id",
+	"n.build_id",
+	"n.notifier",
+	"n.source",
+	"n.event",
+	"n.status",
+	"n.attempts",
+	"n.response_status",
+	"n.last_error",
+	"n.created_at",
+	"n.next_attempt_at",
+	"n.delivered_at",
+).
+	From("build_notifications n")
+
+type buildNotificationFactory struct {
+	conn Conn
+}
+
+func NewBuildNotificationFactory(conn Conn) BuildNotificationFactory {
+	return &buildNotificationFactory{
+		conn: conn,
+	}
+}
+
+func (f *buildNotificationFactory) QueueBuildNotifications(build Build, event string) error {
+	// team notifiers only apply to the builds of the team's pipelines
+	if build.PipelineID() == 0 {
+		return nil
+	}
+
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	var pipelineNotifiers, pipelineNonce, teamNotifiers, teamNonce sql.NullString
+	err = psql.Select("p.notifiers", "p.notifiers_nonce", "t.notifiers", "t.notifiers_nonce").
+		From("pipelines p").
+		Join("teams t ON t.id = p.team_id").
+		Where(sq.Eq{"p.id": build.PipelineID()}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&pipelineNotifiers, &pipelineNonce, &teamNotifiers, &teamNonce)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+
+		return err
+	}
+
+	sources := []struct {
+		source  string
+		payload sql.NullString
+		nonce   sql.NullString
+	}{
+		{atc.NotificationSourcePipeline, pipelineNotifiers, pipelineNonce},
+		{atc.NotificationSourceTeam, teamNotifiers, teamNonce},
+	}
+
+	for _, s := range sources {
+		notifiers, err := decryptNotifiers(f.conn.EncryptionStrategy(), s.payload, s.nonce)
+		if err != nil {
+			return err
+		}
+
+		for _, notifier := range notifiers {
+			if !notifier.Notifies(event) {
+				continue
+			}
+
+			_, err = psql.Insert("build_notifications").
+				Columns("build_id", "team_id", "notifier", "source", "event").
+				Values(build.ID(), build.TeamID(), notifier.Name, s.source, event).
+				Suffix("ON CONFLICT (build_id, source, notifier, event) DO NOTHING").
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (f *buildNotificationFactory) DueBuildNotifications(limit int) ([]atc.BuildNotification, error) {
+	return f.buildNotifications(buildNotificationsQuery.
+		Where(sq.Eq{"n.status": atc.NotificationStatusPending}).
+		Where(sq.Expr("n.next_attempt_at <= now()")).
+		OrderBy("n.next_attempt_at ASC", "n.id ASC").
+		Limit(uint64(limit)))
+}
+
+func (f *buildNotificationFactory) BuildNotifications(buildID int) ([]atc.BuildNotification, error) {
+	return f.buildNotifications(buildNotificationsQuery.
+		Where(sq.Eq{"n.build_id": buildID}).
+		OrderBy("n.id ASC"))
+}
+
+func (f *buildNotificationFactory) TeamBuildNotifications(teamID int, limit int) ([]atc.BuildNotification, error) {
+	return f.buildNotifications(buildNotificationsQuery.
+		Where(sq.Eq{"n.team_id": teamID}).
+		OrderBy("n.id DESC").
+		Limit(uint64(limit)))
+}
+
+func (f *buildNotificationFactory) buildNotifications(query sq.SelectBuilder) ([]atc.BuildNotification, error) {
+	rows, err := query.
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	notifications := []atc.BuildNotification{}
+	for rows.Next() {
+		notification, err := scanBuildNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, rows.Err()
+}
+
+func (f *buildNotificationFactory) BuildNotificationDelivered(id int, responseStatus int) error {
+	_, err := psql.Update("build_notifications").
+		Set("status", atc.NotificationStatusDelivered).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("response_status", responseStatus).
+		Set("last_error", nil).
+		Set("delivered_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": id}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+func (f *buildNotificationFactory) BuildNotificationFailed(id int, responseStatus int, deliveryErr string, retryAt time.Time) error {
+	var responseStatusValue interface{}
+	if responseStatus != 0 {
+		responseStatusValue = responseStatus
+	}
+
+	update := psql.Update("build_notifications").
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("response_status", responseStatusValue).
+		Set("last_error", deliveryErr)
+
+	if retryAt.IsZero() {
+		update = update.Set("status", atc.NotificationStatusFailed)
+	} else {
+		update = update.Set("next_attempt_at", retryAt)
+	}
+
+	_, err := update.
+		Where(sq.Eq{"id": id}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+func scanBuildNotification(scan scannable) (atc.BuildNotification, error) {
+	var (
+		notification   atc.BuildNotification
+		responseStatus sql.NullInt64
+		lastError      sql.NullString
+		createdAt      time.Time
+		nextAttemptAt  time.Time
+		deliveredAt    sql.NullTime
+	)
+
+	err := scan.Scan(
+		&notification.ID,
+		&notification.BuildID,
+		&notification.Notifier,
+		&notification.Source,
+		&notification.Event,
+		&notification.Status,
+		&notification.Attempts,
+		&responseStatus,
+		&lastError,
+		&createdAt,
+		&nextAttemptAt,
+		&deliveredAt,
+	)
+	if err != nil {
+		return atc.BuildNotification{}, err
+	}
+
+	notification.ResponseStatus = int(responseStatus.Int64)
+	notification.Error = lastError.String
+	notification.CreatedAt = createdAt.Unix()
+
+	if notification.Status == atc.NotificationStatusPending {
+		notification.NextAttemptAt = nextAttemptAt.Unix()
+	}
+
+	if deliveredAt.Valid {
+		notification.DeliveredAt = deliveredAt.Time.Unix()
+	}
+
+	return notification, nil
+}
+
+// encryptNotifiers returns the notifiers encrypted for storing, along with
+// their nonce. Notifiers hold the credentials of the services they notify, so
+// they're encrypted like the var sources of pipelines. No notifiers are stored
+// as NULL.
+func encryptNotifiers(strategy encryption.Strategy, notifiers atc.NotifierConfigs) (*string, *string, error) {
+	if len(notifiers) == 0 {
+		return nil, nil, nil
+	}
+
+	payload, err := json.Marshal(notifiers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encrypted, nonce, err := strategy.Encrypt(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &encrypted, nonce, nil
+}
+
+func decryptNotifiers(strategy encryption.Strategy, payload sql.NullString, nonce sql.NullString) (atc.NotifierConfigs, error) {
+	if !payload.Valid {
+		return nil, nil
+	}
+
+	var noncePtr *string
+	if nonce.Valid {
+		noncePtr = &nonce.String
+	}
+
+	decrypted, err := strategy.Decrypt(payload.String, noncePtr)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifiers atc.NotifierConfigs
+	err = json.Unmarshal(decrypted, &notifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	return notifiers, nil
+}
@@ -0,0 +1,235 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+// BuildStatsFilter describes the builds to summarize: those of the jobs of a
+// team, optionally narrowed down to a pipeline or job, which were created
+// between Since and Until.
+type BuildStatsFilter struct {
+	PipelineID int
+	JobID      int
+
+	Since time.Time
+	Until time.Time
+}
+
+var finishedBuildStatuses = []string{
+	string(BuildStatusSucceeded),
+	string(BuildStatusFailed),
+	string(BuildStatusErrored),
+}
+
+var buildStatsPercentiles = pq.Float64Array{0.5, 0.9, 0.99}
+
+func (filter BuildStatsFilter) filter(teamID int) sq.And {
+	conditions := sq.And{
+		sq.Eq{"b.team_id": teamID},
+		sq.NotEq{"b.job_id": nil},
+		sq.GtOrEq{"b.create_time": filter.Since},
+		sq.Lt{"b.create_time": filter.Until},
+	}
+
+	if filter.PipelineID != 0 {
+		conditions = append(conditions, sq.Eq{"b.pipeline_id": filter.PipelineID})
+	}
+
+	if filter.JobID != 0 {
+		conditions = append(conditions, sq.Eq{"b.job_id": filter.JobID})
+	}
+
+	return conditions
+}
+
+func buildStats(conn Conn, teamID int, filter BuildStatsFilter) (atc.BuildStats, error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return atc.BuildStats{}, err
+	}
+
+	defer Rollback(tx)
+
+	conditions := filter.filter(teamID)
+
+	stats := atc.BuildStats{
+		Since:  filter.Since.Unix(),
+		Until:  filter.Until.Unix(),
+		Builds: map[atc.BuildStatus]int{},
+	}
+
+	err = countBuildsByStatus(tx, conditions, &stats)
+	if err != nil {
+		return atc.BuildStats{}, err
+	}
+
+	err = buildDurationPercentiles(tx, conditions, &stats)
+	if err != nil {
+		return atc.BuildStats{}, err
+	}
+
+	err = buildRecoveries(tx, conditions, &stats)
+	if err != nil {
+		return atc.BuildStats{}, err
+	}
+
+	err = buildReruns(tx, conditions, &stats)
+	if err != nil {
+		return atc.BuildStats{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return atc.BuildStats{}, err
+	}
+
+	return stats, nil
+}
+
+func countBuildsByStatus(tx Tx, conditions sq.And, stats *atc.BuildStats) error {
+	rows, err := psql.Select("b.status", "COUNT(*)").
+		From("builds b").
+		Where(conditions).
+		GroupBy("b.status").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	var finished, succeeded int
+	for rows.Next() {
+		var status atc.BuildStatus
+		var count int
+		err = rows.Scan(&status, &count)
+		if err != nil {
+			return err
+		}
+
+		stats.Builds[status] = count
+
+		switch status {
+		case atc.StatusSucceeded:
+			succeeded += count
+			finished += count
+		case atc.StatusFailed, atc.StatusErrored:
+			finished += count
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	if finished > 0 {
+		stats.SuccessRate = float64(succeeded) / float64(finished)
+	}
+
+	return nil
+}
+
+func buildDurationPercentiles(tx Tx, conditions sq.And, stats *atc.BuildStats) error {
+	var durations, queueTimes pq.Float64Array
+	err := psql.Select().
+		Column(sq.Expr("percentile_cont(?::float8[]) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM b.end_time - b.start_time)) FILTER (WHERE b.status = ANY(?))", buildStatsPercentiles, pq.StringArray(finishedBuildStatuses))).
+		Column(sq.Expr("percentile_cont(?::float8[]) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM b.start_time - b.create_time))", buildStatsPercentiles)).
+		From("builds b").
+		Where(conditions).
+		Where(sq.NotEq{"b.start_time": nil}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&durations, &queueTimes)
+	if err != nil {
+		return err
+	}
+
+	stats.Duration = durationPercentiles(durations)
+	stats.QueueTime = durationPercentiles(queueTimes)
+
+	return nil
+}
+
+func durationPercentiles(values pq.Float64Array) atc.DurationPercentiles {
+	// there are no percentiles when no builds were aggregated
+	if len(values) != len(buildStatsPercentiles) {
+		return atc.DurationPercentiles{}
+	}
+
+	return atc.DurationPercentiles{
+		P50: values[0],
+		P90: values[1],
+		P99: values[2],
+	}
+}
+
+// buildRecoveries measures the time each job took to recover from a build not
+// succeeding. Only the builds where a job starts or stops succeeding are
+// kept, so that each build that succeeded is preceded by the build that
+// started the failure it recovered from.
+func buildRecoveries(tx Tx, conditions sq.And, stats *atc.BuildStats) error {
+	finished := psql.Select("b.id", "b.job_id", "b.end_time", "b.status = 'succeeded' AS succeeded").
+		From("builds b").
+		Where(conditions).
+		Where(sq.Eq{"b.status": finishedBuildStatuses})
+
+	transitions := psql.Select("id", "job_id", "end_time", "succeeded").
+		Column("lag(succeeded) OVER (PARTITION BY job_id ORDER BY end_time, id) AS previously_succeeded").
+		FromSelect(finished, "finished")
+
+	changes := psql.Select("end_time", "succeeded").
+		Column("lag(end_time) OVER (PARTITION BY job_id ORDER BY end_time, id) AS failed_at").
+		FromSelect(transitions, "transitions").
+		Where("previously_succeeded IS DISTINCT FROM succeeded")
+
+	var recoveries int
+	var meanTimeToRecovery *float64
+	err := psql.Select("COUNT(*)", "AVG(EXTRACT(EPOCH FROM end_time - failed_at))").
+		FromSelect(changes, "changes").
+		Where("succeeded AND failed_at IS NOT NULL").
+		RunWith(tx).
+		QueryRow().
+		Scan(&recoveries, &meanTimeToRecovery)
+	if err != nil {
+		return err
+	}
+
+	stats.Recoveries = recoveries
+	if meanTimeToRecovery != nil {
+		stats.MeanTimeToRecovery = *meanTimeToRecovery
+	}
+
+	return nil
+}
+
+func buildReruns(tx Tx, conditions sq.And, stats *atc.BuildStats) error {
+	var reruns, flipped int
+	err := psql.Select("COUNT(*)", "COUNT(*) FILTER (WHERE (b.status = 'succeeded') <> (o.status = 'succeeded'))").
+		From("builds b").
+		Join("builds o ON o.id = b.rerun_of").
+		Where(conditions).
+		Where(sq.Eq{
+			"b.status": finishedBuildStatuses,
+			"o.status": finishedBuildStatuses,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&reruns, &flipped)
+	if err != nil {
+		return err
+	}
+
+	stats.Reruns = reruns
+	stats.FlippedReruns = flipped
+	if reruns > 0 {
+		stats.Flakiness = float64(flipped) / float64(reruns)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package db_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Build stats", func() {
+	var (
+		start  time.Time
+		filter db.BuildStatsFilter
+	)
+
+	setTimes := func(build db.Build, created, started, ended int) {
+		_, err := dbConn.Exec(
+			"UPDATE builds SET create_time = to_timestamp($1), start_time = to_timestamp($2), end_time = to_timestamp($3) WHERE id = $4",
+			start.Unix()+int64(created),
+			start.Unix()+int64(started),
+			start.Unix()+int64(ended),
+			build.ID(),
+		)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	finishBuild := func(build db.Build, status db.BuildStatus, created, started, ended int) {
+		err := build.Finish(status)
+		Expect(err).ToNot(HaveOccurred())
+
+		setTimes(build, created, started, ended)
+	}
+
+	BeforeEach(func() {
+		start = time.Now().Add(-time.Hour).Truncate(time.Second)
+
+		filter = db.BuildStatsFilter{
+			Since: start,
+			Until: start.Add(time.Hour),
+		}
+
+		succeededBuild, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+		finishBuild(succeededBuild, db.BuildStatusSucceeded, 0, 10, 70)
+
+		failedBuild, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+		finishBuild(failedBuild, db.BuildStatusFailed, 100, 110, 230)
+
+		erroredBuild, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+		finishBuild(erroredBuild, db.BuildStatusErrored, 300, 310, 340)
+
+		rerunBuild, err := defaultJob.RerunBuild(failedBuild, defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+		finishBuild(rerunBuild, db.BuildStatusSucceeded, 400, 420, 520)
+
+		pendingBuild, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = dbConn.Exec("UPDATE builds SET create_time = to_timestamp($1) WHERE id = $2", start.Unix()+600, pendingBuild.ID())
+		Expect(err).ToNot(HaveOccurred())
+
+		oneOffBuild, err := defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+		finishBuild(oneOffBuild, db.BuildStatusFailed, 700, 710, 720)
+	})
+
+	Describe("BuildStats", func() {
+		It("summarizes the builds of the team's jobs created within the window", func() {
+			stats, err := defaultTeam.BuildStats(filter)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(stats.Since).To(Equal(start.Unix()))
+			Expect(stats.Until).To(Equal(start.Add(time.Hour).Unix()))
+
+			Expect(stats.Builds).To(Equal(map[atc.BuildStatus]int{
+				atc.StatusSucceeded: 2,
+				atc.StatusFailed:    1,
+				atc.StatusErrored:   1,
+				atc.StatusPending:   1,
+			}))
+			Expect(stats.SuccessRate).To(Equal(0.5))
+
+			By("interpolating percentiles of the durations of 30s, 60s, 100s and 120s")
+			Expect(stats.Duration.P50).To(BeNumerically("~", 80, 0.001))
+			Expect(stats.Duration.P90).To(BeNumerically("~", 114, 0.001))
+			Expect(stats.Duration.P99).To(BeNumerically("~", 119.4, 0.001))
+
+			By("leaving out the pending build, which hasn't started")
+			Expect(stats.QueueTime.P50).To(BeNumerically("~", 10, 0.001))
+			Expect(stats.QueueTime.P90).To(BeNumerically("~", 17, 0.001))
+			Expect(stats.QueueTime.P99).To(BeNumerically("~", 19.7, 0.001))
+
+			By("measuring recovery from the end of the first build that failed")
+			Expect(stats.Recoveries).To(Equal(1))
+			Expect(stats.MeanTimeToRecovery).To(BeNumerically("~", 290, 0.001))
+
+			Expect(stats.Reruns).To(Equal(1))
+			Expect(stats.FlippedReruns).To(Equal(1))
+			Expect(stats.Flakiness).To(Equal(1.0))
+		})
+
+		It("narrows the builds down to a job", func() {
+			filter.PipelineID = defaultPipeline.ID()
+			filter.JobID = defaultJob.ID()
+
+			stats, err := defaultTeam.BuildStats(filter)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stats.Builds[atc.StatusSucceeded]).To(Equal(2))
+
+			filter.JobID = defaultJob.ID() + 1
+
+			stats, err = defaultTeam.BuildStats(filter)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stats.Builds).To(BeEmpty())
+		})
+
+		Context("when no builds were created within the window", func() {
+			BeforeEach(func() {
+				filter.Since = start.Add(time.Hour)
+				filter.Until = start.Add(2 * time.Hour)
+			})
+
+			It("returns empty stats", func() {
+				stats, err := defaultTeam.BuildStats(filter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(stats).To(Equal(atc.BuildStats{
+					Since:  filter.Since.Unix(),
+					Until:  filter.Until.Unix(),
+					Builds: map[atc.BuildStatus]int{},
+				}))
+			})
+		})
+	})
+})
@@ -60,7 +60,7 @@ var _ = Describe("Build", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(found).To(BeTrue())
 
-		build, err = job.CreateBuild(defaultBuildCreatedBy)
+		build, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
@@ -133,7 +133,7 @@ var _ = Describe("Build", func() {
 		Context("for a job build", func() {
 			BeforeEach(func() {
 				var err error
-				build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(build.CreatedBy()).ToNot(BeNil())
 				Expect(*build.CreatedBy()).To(Equal(defaultBuildCreatedBy))
@@ -196,7 +196,7 @@ var _ = Describe("Build", func() {
 		Context("for a job build", func() {
 			BeforeEach(func() {
 				var err error
-				build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -248,7 +248,7 @@ var _ = Describe("Build", func() {
 		Context("for a job build", func() {
 			BeforeEach(func() {
 				var err error
-				build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -621,7 +621,7 @@ var _ = Describe("Build", func() {
 
 			Context("when there is a pending build that is not a rerun", func() {
 				BeforeEach(func() {
-					pdBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+					pdBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).NotTo(HaveOccurred())
 				})
 
@@ -657,7 +657,7 @@ var _ = Describe("Build", func() {
 
 					Context("when there is another pending build that is not a rerun and the first pending build finishes", func() {
 						BeforeEach(func() {
-							pdBuild2, err = job.CreateBuild(defaultBuildCreatedBy)
+							pdBuild2, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 							Expect(err).NotTo(HaveOccurred())
 
 							err = pdBuild.Finish(db.BuildStatusSucceeded)
@@ -756,7 +756,7 @@ var _ = Describe("Build", func() {
 				job := scenario.Job("some-job")
 				downstreamJob := scenario.Job("downstream-job")
 
-				newBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+				newBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(newBuild.CreatedBy()).ToNot(BeNil())
 				Expect(*newBuild.CreatedBy()).To(Equal(defaultBuildCreatedBy))
@@ -777,7 +777,7 @@ var _ = Describe("Build", func() {
 				job := scenario.Job("some-job")
 				noRequestJob := scenario.Job("no-request-job")
 
-				newBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+				newBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(newBuild.CreatedBy()).ToNot(BeNil())
 				Expect(*newBuild.CreatedBy()).To(Equal(defaultBuildCreatedBy))
@@ -800,7 +800,7 @@ var _ = Describe("Build", func() {
 
 			BeforeEach(func() {
 				By("creating a child pipeline")
-				build, _ := defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build, _ := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				childPipeline, _, _ = build.SavePipeline(atc.PipelineRef{Name: "child1-pipeline"}, defaultTeam.ID(), defaultPipelineConfig, db.ConfigVersion(0), false)
 				build.Finish(db.BuildStatusSucceeded)
 
@@ -811,7 +811,7 @@ var _ = Describe("Build", func() {
 			Context("build is successful", func() {
 				It("archives pipelines no longer set by the job", func() {
 					By("no longer setting the child pipeline")
-					build2, _ := defaultJob.CreateBuild(defaultBuildCreatedBy)
+					build2, _ := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					build2.Finish(db.BuildStatusSucceeded)
 
 					childPipeline.Reload()
@@ -825,14 +825,14 @@ var _ = Describe("Build", func() {
 						By("creating a chain of pipelines, previous pipeline setting the next pipeline")
 						for i := 0; i < 5; i++ {
 							job, _, _ := childPipeline.Job("some-job")
-							build, _ := job.CreateBuild(defaultBuildCreatedBy)
+							build, _ := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 							childPipeline, _, _ = build.SavePipeline(atc.PipelineRef{Name: "child-pipeline-" + strconv.Itoa(i)}, defaultTeam.ID(), defaultPipelineConfig, db.ConfigVersion(0), false)
 							build.Finish(db.BuildStatusSucceeded)
 							childPipelines = append(childPipelines, childPipeline)
 						}
 
 						By("parent pipeline no longer sets child pipeline in most recent build")
-						build, _ := defaultJob.CreateBuild(defaultBuildCreatedBy)
+						build, _ := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 						build.Finish(db.BuildStatusSucceeded)
 
 						for _, pipeline := range childPipelines {
@@ -845,7 +845,7 @@ var _ = Describe("Build", func() {
 
 				Context("when the pipeline is not set by build", func() {
 					It("never gets archived", func() {
-						build, _ := defaultJob.CreateBuild(defaultBuildCreatedBy)
+						build, _ := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 						teamPipeline, _, _ := defaultTeam.SavePipeline(atc.PipelineRef{Name: "team-pipeline"}, defaultPipelineConfig, db.ConfigVersion(0), false)
 						build.Finish(db.BuildStatusSucceeded)
 
@@ -857,7 +857,7 @@ var _ = Describe("Build", func() {
 			Context("build is not successful", func() {
 				It("does not archive pipelines", func() {
 					By("no longer setting the child pipeline")
-					build2, _ := defaultJob.CreateBuild(defaultBuildCreatedBy)
+					build2, _ := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					build2.Finish(db.BuildStatusFailed)
 
 					childPipeline.Reload()
@@ -931,7 +931,7 @@ var _ = Describe("Build", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err = job.CreateBuild(defaultBuildCreatedBy)
+				build, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -1150,6 +1150,87 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SaveStepUsage", func() {
+		It("saves the usage of each step, replacing it if saved again", func() {
+			usages, err := build.StepUsage()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usages).To(BeEmpty())
+
+			taskUsage := atc.StepUsage{
+				PlanID:   "some-plan-id",
+				StepName: "some-task",
+				Usage: atc.ContainerUsage{
+					CPUAverage:    0.5,
+					CPUPeak:       1.5,
+					MemoryAverage: 1024,
+					MemoryPeak:    2048,
+					BlockIORead:   4096,
+					BlockIOWrite:  512,
+					Samples:       3,
+				},
+			}
+
+			err = build.SaveStepUsage(taskUsage)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherUsage := atc.StepUsage{
+				PlanID:   "other-plan-id",
+				StepName: "other-task",
+				Usage: atc.ContainerUsage{
+					CPUAverage: 0.1,
+					Samples:    1,
+				},
+			}
+
+			err = build.SaveStepUsage(otherUsage)
+			Expect(err).ToNot(HaveOccurred())
+
+			taskUsage.Usage.MemoryPeak = 4096
+			err = build.SaveStepUsage(taskUsage)
+			Expect(err).ToNot(HaveOccurred())
+
+			usages, err = build.StepUsage()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usages).To(Equal([]atc.StepUsage{taskUsage, otherUsage}))
+		})
+	})
+
+	Describe("SaveStepTiming", func() {
+		It("saves the timing of each phase of the steps, in the order they started", func() {
+			timings, err := build.StepTimings()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(timings).To(BeEmpty())
+
+			runningTiming := atc.StepTiming{
+				PlanID:    "some-plan-id",
+				StepName:  "some-task",
+				Phase:     atc.StepPhaseRunning,
+				StartTime: 1600000010,
+				Duration:  42.5,
+			}
+
+			err = build.SaveStepTiming(runningTiming)
+			Expect(err).ToNot(HaveOccurred())
+
+			streamedBytes := int64(1024)
+			streamingTiming := atc.StepTiming{
+				PlanID:    "some-plan-id",
+				StepName:  "some-task",
+				Phase:     atc.StepPhaseStreamingInputs,
+				StartTime: 1600000000,
+				Duration:  9.25,
+				Bytes:     &streamedBytes,
+			}
+
+			err = build.SaveStepTiming(streamingTiming)
+			Expect(err).ToNot(HaveOccurred())
+
+			timings, err = build.StepTimings()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(timings).To(Equal([]atc.StepTiming{streamingTiming, runningTiming}))
+		})
+	})
+
 	Describe("SaveOutput", func() {
 		var pipelineConfig atc.Config
 
@@ -1540,7 +1621,7 @@ var _ = Describe("Build", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err = job.CreateBuild(defaultBuildCreatedBy)
+				build, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -2359,7 +2440,7 @@ var _ = Describe("Build", func() {
 	Describe("SavePipeline", func() {
 		It("saves the parent job and build ids", func() {
 			By("creating a build")
-			build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			By("saving a pipeline with the build")
@@ -2395,9 +2476,9 @@ var _ = Describe("Build", func() {
 
 		It("only saves the pipeline if it is the latest build", func() {
 			By("creating two builds")
-			buildOne, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			buildOne, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
-			buildTwo, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			buildTwo, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			By("saving a pipeline with the second build")
@@ -2462,7 +2543,7 @@ var _ = Describe("Build", func() {
 		Context("a pipeline is previously saved by team.SavePipeline", func() {
 			It("the parent job and build ID are updated", func() {
 				By("creating a build")
-				build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				By("re-saving the default pipeline with the build")
@@ -2485,7 +2566,7 @@ var _ = Describe("Build", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 			By("setting the pipeline again via a build")
-			build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			pipeline, _, err = build.SavePipeline(defaultPipelineRef, build.TeamID(), defaultPipelineConfig, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
@@ -2505,7 +2586,7 @@ var _ = Describe("Build", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 			By("setting the pipeline again via a build")
-			build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+			build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			pipeline, _, err = build.SavePipeline(defaultPipelineRef, build.TeamID(), defaultPipelineConfig, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
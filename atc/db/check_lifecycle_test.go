@@ -133,14 +133,14 @@ var _ = Describe("Check Lifecycle", func() {
 	})
 
 	It("ignores job builds", func() {
-		build, err := defaultJob.CreateBuild("foo")
+		build, err := defaultJob.CreateBuild(context.TODO(), "foo")
 		Expect(err).ToNot(HaveOccurred())
 
 		err = build.Finish(db.BuildStatusSucceeded)
 		Expect(err).ToNot(HaveOccurred())
 
 		By("creating a new build for the same job")
-		_, err = defaultJob.CreateBuild("foo")
+		_, err = defaultJob.CreateBuild(context.TODO(), "foo")
 		Expect(err).ToNot(HaveOccurred())
 
 		err = lifecycle.DeleteCompletedChecks()
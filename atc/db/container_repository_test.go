@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"context"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -208,7 +209,7 @@ var _ = Describe("ContainerRepository", func() {
 
 			BeforeEach(func() {
 				var err error
-				build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				creatingContainer, err = defaultWorker.CreateContainer(
@@ -316,7 +317,7 @@ var _ = Describe("ContainerRepository", func() {
 
 			BeforeEach(func() {
 				var err error
-				build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				creatingTaskContainer, err = defaultWorker.CreateContainer(
@@ -384,7 +385,7 @@ var _ = Describe("ContainerRepository", func() {
 
 			BeforeEach(func() {
 				var err error
-				build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				creatingTaskContainer, err = defaultWorker.CreateContainer(
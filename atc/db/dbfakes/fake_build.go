@@ -175,6 +175,16 @@ type FakeBuild struct {
 	finishReturnsOnCall map[int]struct {
 		result1 error
 	}
+	FlakyStub        func() bool
+	flakyMutex       sync.RWMutex
+	flakyArgsForCall []struct {
+	}
+	flakyReturns struct {
+		result1 bool
+	}
+	flakyReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	HasPlanStub        func() bool
 	hasPlanMutex       sync.RWMutex
 	hasPlanArgsForCall []struct {
@@ -548,6 +558,19 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	RetryableOnFlakeStub        func(int) (bool, error)
+	retryableOnFlakeMutex       sync.RWMutex
+	retryableOnFlakeArgsForCall []struct {
+		arg1 int
+	}
+	retryableOnFlakeReturns struct {
+		result1 bool
+		result2 error
+	}
+	retryableOnFlakeReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	SaveEventStub        func(atc.Event) error
 	saveEventMutex       sync.RWMutex
 	saveEventArgsForCall []struct {
@@ -606,6 +629,39 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	SaveStepTimingStub        func(atc.StepTiming) error
+	saveStepTimingMutex       sync.RWMutex
+	saveStepTimingArgsForCall []struct {
+		arg1 atc.StepTiming
+	}
+	saveStepTimingReturns struct {
+		result1 error
+	}
+	saveStepTimingReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveStepUsageStub        func(atc.StepUsage) error
+	saveStepUsageMutex       sync.RWMutex
+	saveStepUsageArgsForCall []struct {
+		arg1 atc.StepUsage
+	}
+	saveStepUsageReturns struct {
+		result1 error
+	}
+	saveStepUsageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveTaskFailureStub        func(bool) error
+	saveTaskFailureMutex       sync.RWMutex
+	saveTaskFailureArgsForCall []struct {
+		arg1 bool
+	}
+	saveTaskFailureReturns struct {
+		result1 error
+	}
+	saveTaskFailureReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SchemaStub        func() string
 	schemaMutex       sync.RWMutex
 	schemaArgsForCall []struct {
@@ -692,6 +748,30 @@ type FakeBuild struct {
 	statusReturnsOnCall map[int]struct {
 		result1 db.BuildStatus
 	}
+	StepTimingsStub        func() ([]atc.StepTiming, error)
+	stepTimingsMutex       sync.RWMutex
+	stepTimingsArgsForCall []struct {
+	}
+	stepTimingsReturns struct {
+		result1 []atc.StepTiming
+		result2 error
+	}
+	stepTimingsReturnsOnCall map[int]struct {
+		result1 []atc.StepTiming
+		result2 error
+	}
+	StepUsageStub        func() ([]atc.StepUsage, error)
+	stepUsageMutex       sync.RWMutex
+	stepUsageArgsForCall []struct {
+	}
+	stepUsageReturns struct {
+		result1 []atc.StepUsage
+		result2 error
+	}
+	stepUsageReturnsOnCall map[int]struct {
+		result1 []atc.StepUsage
+		result2 error
+	}
 	SyslogTagStub        func(event.OriginID) string
 	syslogTagMutex       sync.RWMutex
 	syslogTagArgsForCall []struct {
@@ -1507,6 +1587,59 @@ func (fake *FakeBuild) FinishReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) Flaky() bool {
+	fake.flakyMutex.Lock()
+	ret, specificReturn := fake.flakyReturnsOnCall[len(fake.flakyArgsForCall)]
+	fake.flakyArgsForCall = append(fake.flakyArgsForCall, struct {
+	}{})
+	stub := fake.FlakyStub
+	fakeReturns := fake.flakyReturns
+	fake.recordInvocation("Flaky", []interface{}{})
+	fake.flakyMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) FlakyCallCount() int {
+	fake.flakyMutex.RLock()
+	defer fake.flakyMutex.RUnlock()
+	return len(fake.flakyArgsForCall)
+}
+
+func (fake *FakeBuild) FlakyCalls(stub func() bool) {
+	fake.flakyMutex.Lock()
+	defer fake.flakyMutex.Unlock()
+	fake.FlakyStub = stub
+}
+
+func (fake *FakeBuild) FlakyReturns(result1 bool) {
+	fake.flakyMutex.Lock()
+	defer fake.flakyMutex.Unlock()
+	fake.FlakyStub = nil
+	fake.flakyReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) FlakyReturnsOnCall(i int, result1 bool) {
+	fake.flakyMutex.Lock()
+	defer fake.flakyMutex.Unlock()
+	fake.FlakyStub = nil
+	if fake.flakyReturnsOnCall == nil {
+		fake.flakyReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.flakyReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) HasPlan() bool {
 	fake.hasPlanMutex.Lock()
 	ret, specificReturn := fake.hasPlanReturnsOnCall[len(fake.hasPlanArgsForCall)]
@@ -3403,6 +3536,70 @@ func (fake *FakeBuild) ResourcesCheckedReturnsOnCall(i int, result1 bool, result
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) RetryableOnFlake(arg1 int) (bool, error) {
+	fake.retryableOnFlakeMutex.Lock()
+	ret, specificReturn := fake.retryableOnFlakeReturnsOnCall[len(fake.retryableOnFlakeArgsForCall)]
+	fake.retryableOnFlakeArgsForCall = append(fake.retryableOnFlakeArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.RetryableOnFlakeStub
+	fakeReturns := fake.retryableOnFlakeReturns
+	fake.recordInvocation("RetryableOnFlake", []interface{}{arg1})
+	fake.retryableOnFlakeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) RetryableOnFlakeCallCount() int {
+	fake.retryableOnFlakeMutex.RLock()
+	defer fake.retryableOnFlakeMutex.RUnlock()
+	return len(fake.retryableOnFlakeArgsForCall)
+}
+
+func (fake *FakeBuild) RetryableOnFlakeCalls(stub func(int) (bool, error)) {
+	fake.retryableOnFlakeMutex.Lock()
+	defer fake.retryableOnFlakeMutex.Unlock()
+	fake.RetryableOnFlakeStub = stub
+}
+
+func (fake *FakeBuild) RetryableOnFlakeArgsForCall(i int) int {
+	fake.retryableOnFlakeMutex.RLock()
+	defer fake.retryableOnFlakeMutex.RUnlock()
+	argsForCall := fake.retryableOnFlakeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) RetryableOnFlakeReturns(result1 bool, result2 error) {
+	fake.retryableOnFlakeMutex.Lock()
+	defer fake.retryableOnFlakeMutex.Unlock()
+	fake.RetryableOnFlakeStub = nil
+	fake.retryableOnFlakeReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) RetryableOnFlakeReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.retryableOnFlakeMutex.Lock()
+	defer fake.retryableOnFlakeMutex.Unlock()
+	fake.RetryableOnFlakeStub = nil
+	if fake.retryableOnFlakeReturnsOnCall == nil {
+		fake.retryableOnFlakeReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.retryableOnFlakeReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) SaveEvent(arg1 atc.Event) error {
 	fake.saveEventMutex.Lock()
 	ret, specificReturn := fake.saveEventReturnsOnCall[len(fake.saveEventArgsForCall)]
@@ -3663,6 +3860,189 @@ func (fake *FakeBuild) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) SaveStepTiming(arg1 atc.StepTiming) error {
+	fake.saveStepTimingMutex.Lock()
+	ret, specificReturn := fake.saveStepTimingReturnsOnCall[len(fake.saveStepTimingArgsForCall)]
+	fake.saveStepTimingArgsForCall = append(fake.saveStepTimingArgsForCall, struct {
+		arg1 atc.StepTiming
+	}{arg1})
+	stub := fake.SaveStepTimingStub
+	fakeReturns := fake.saveStepTimingReturns
+	fake.recordInvocation("SaveStepTiming", []interface{}{arg1})
+	fake.saveStepTimingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveStepTimingCallCount() int {
+	fake.saveStepTimingMutex.RLock()
+	defer fake.saveStepTimingMutex.RUnlock()
+	return len(fake.saveStepTimingArgsForCall)
+}
+
+func (fake *FakeBuild) SaveStepTimingCalls(stub func(atc.StepTiming) error) {
+	fake.saveStepTimingMutex.Lock()
+	defer fake.saveStepTimingMutex.Unlock()
+	fake.SaveStepTimingStub = stub
+}
+
+func (fake *FakeBuild) SaveStepTimingArgsForCall(i int) atc.StepTiming {
+	fake.saveStepTimingMutex.RLock()
+	defer fake.saveStepTimingMutex.RUnlock()
+	argsForCall := fake.saveStepTimingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveStepTimingReturns(result1 error) {
+	fake.saveStepTimingMutex.Lock()
+	defer fake.saveStepTimingMutex.Unlock()
+	fake.SaveStepTimingStub = nil
+	fake.saveStepTimingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveStepTimingReturnsOnCall(i int, result1 error) {
+	fake.saveStepTimingMutex.Lock()
+	defer fake.saveStepTimingMutex.Unlock()
+	fake.SaveStepTimingStub = nil
+	if fake.saveStepTimingReturnsOnCall == nil {
+		fake.saveStepTimingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveStepTimingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveStepUsage(arg1 atc.StepUsage) error {
+	fake.saveStepUsageMutex.Lock()
+	ret, specificReturn := fake.saveStepUsageReturnsOnCall[len(fake.saveStepUsageArgsForCall)]
+	fake.saveStepUsageArgsForCall = append(fake.saveStepUsageArgsForCall, struct {
+		arg1 atc.StepUsage
+	}{arg1})
+	stub := fake.SaveStepUsageStub
+	fakeReturns := fake.saveStepUsageReturns
+	fake.recordInvocation("SaveStepUsage", []interface{}{arg1})
+	fake.saveStepUsageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveStepUsageCallCount() int {
+	fake.saveStepUsageMutex.RLock()
+	defer fake.saveStepUsageMutex.RUnlock()
+	return len(fake.saveStepUsageArgsForCall)
+}
+
+func (fake *FakeBuild) SaveStepUsageCalls(stub func(atc.StepUsage) error) {
+	fake.saveStepUsageMutex.Lock()
+	defer fake.saveStepUsageMutex.Unlock()
+	fake.SaveStepUsageStub = stub
+}
+
+func (fake *FakeBuild) SaveStepUsageArgsForCall(i int) atc.StepUsage {
+	fake.saveStepUsageMutex.RLock()
+	defer fake.saveStepUsageMutex.RUnlock()
+	argsForCall := fake.saveStepUsageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveStepUsageReturns(result1 error) {
+	fake.saveStepUsageMutex.Lock()
+	defer fake.saveStepUsageMutex.Unlock()
+	fake.SaveStepUsageStub = nil
+	fake.saveStepUsageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveStepUsageReturnsOnCall(i int, result1 error) {
+	fake.saveStepUsageMutex.Lock()
+	defer fake.saveStepUsageMutex.Unlock()
+	fake.SaveStepUsageStub = nil
+	if fake.saveStepUsageReturnsOnCall == nil {
+		fake.saveStepUsageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveStepUsageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveTaskFailure(arg1 bool) error {
+	fake.saveTaskFailureMutex.Lock()
+	ret, specificReturn := fake.saveTaskFailureReturnsOnCall[len(fake.saveTaskFailureArgsForCall)]
+	fake.saveTaskFailureArgsForCall = append(fake.saveTaskFailureArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	stub := fake.SaveTaskFailureStub
+	fakeReturns := fake.saveTaskFailureReturns
+	fake.recordInvocation("SaveTaskFailure", []interface{}{arg1})
+	fake.saveTaskFailureMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveTaskFailureCallCount() int {
+	fake.saveTaskFailureMutex.RLock()
+	defer fake.saveTaskFailureMutex.RUnlock()
+	return len(fake.saveTaskFailureArgsForCall)
+}
+
+func (fake *FakeBuild) SaveTaskFailureCalls(stub func(bool) error) {
+	fake.saveTaskFailureMutex.Lock()
+	defer fake.saveTaskFailureMutex.Unlock()
+	fake.SaveTaskFailureStub = stub
+}
+
+func (fake *FakeBuild) SaveTaskFailureArgsForCall(i int) bool {
+	fake.saveTaskFailureMutex.RLock()
+	defer fake.saveTaskFailureMutex.RUnlock()
+	argsForCall := fake.saveTaskFailureArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveTaskFailureReturns(result1 error) {
+	fake.saveTaskFailureMutex.Lock()
+	defer fake.saveTaskFailureMutex.Unlock()
+	fake.SaveTaskFailureStub = nil
+	fake.saveTaskFailureReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveTaskFailureReturnsOnCall(i int, result1 error) {
+	fake.saveTaskFailureMutex.Lock()
+	defer fake.saveTaskFailureMutex.Unlock()
+	fake.SaveTaskFailureStub = nil
+	if fake.saveTaskFailureReturnsOnCall == nil {
+		fake.saveTaskFailureReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveTaskFailureReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Schema() string {
 	fake.schemaMutex.Lock()
 	ret, specificReturn := fake.schemaReturnsOnCall[len(fake.schemaArgsForCall)]
@@ -4122,6 +4502,118 @@ func (fake *FakeBuild) StatusReturnsOnCall(i int, result1 db.BuildStatus) {
 	}{result1}
 }
 
+func (fake *FakeBuild) StepTimings() ([]atc.StepTiming, error) {
+	fake.stepTimingsMutex.Lock()
+	ret, specificReturn := fake.stepTimingsReturnsOnCall[len(fake.stepTimingsArgsForCall)]
+	fake.stepTimingsArgsForCall = append(fake.stepTimingsArgsForCall, struct {
+	}{})
+	stub := fake.StepTimingsStub
+	fakeReturns := fake.stepTimingsReturns
+	fake.recordInvocation("StepTimings", []interface{}{})
+	fake.stepTimingsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) StepTimingsCallCount() int {
+	fake.stepTimingsMutex.RLock()
+	defer fake.stepTimingsMutex.RUnlock()
+	return len(fake.stepTimingsArgsForCall)
+}
+
+func (fake *FakeBuild) StepTimingsCalls(stub func() ([]atc.StepTiming, error)) {
+	fake.stepTimingsMutex.Lock()
+	defer fake.stepTimingsMutex.Unlock()
+	fake.StepTimingsStub = stub
+}
+
+func (fake *FakeBuild) StepTimingsReturns(result1 []atc.StepTiming, result2 error) {
+	fake.stepTimingsMutex.Lock()
+	defer fake.stepTimingsMutex.Unlock()
+	fake.StepTimingsStub = nil
+	fake.stepTimingsReturns = struct {
+		result1 []atc.StepTiming
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) StepTimingsReturnsOnCall(i int, result1 []atc.StepTiming, result2 error) {
+	fake.stepTimingsMutex.Lock()
+	defer fake.stepTimingsMutex.Unlock()
+	fake.StepTimingsStub = nil
+	if fake.stepTimingsReturnsOnCall == nil {
+		fake.stepTimingsReturnsOnCall = make(map[int]struct {
+			result1 []atc.StepTiming
+			result2 error
+		})
+	}
+	fake.stepTimingsReturnsOnCall[i] = struct {
+		result1 []atc.StepTiming
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) StepUsage() ([]atc.StepUsage, error) {
+	fake.stepUsageMutex.Lock()
+	ret, specificReturn := fake.stepUsageReturnsOnCall[len(fake.stepUsageArgsForCall)]
+	fake.stepUsageArgsForCall = append(fake.stepUsageArgsForCall, struct {
+	}{})
+	stub := fake.StepUsageStub
+	fakeReturns := fake.stepUsageReturns
+	fake.recordInvocation("StepUsage", []interface{}{})
+	fake.stepUsageMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) StepUsageCallCount() int {
+	fake.stepUsageMutex.RLock()
+	defer fake.stepUsageMutex.RUnlock()
+	return len(fake.stepUsageArgsForCall)
+}
+
+func (fake *FakeBuild) StepUsageCalls(stub func() ([]atc.StepUsage, error)) {
+	fake.stepUsageMutex.Lock()
+	defer fake.stepUsageMutex.Unlock()
+	fake.StepUsageStub = stub
+}
+
+func (fake *FakeBuild) StepUsageReturns(result1 []atc.StepUsage, result2 error) {
+	fake.stepUsageMutex.Lock()
+	defer fake.stepUsageMutex.Unlock()
+	fake.StepUsageStub = nil
+	fake.stepUsageReturns = struct {
+		result1 []atc.StepUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) StepUsageReturnsOnCall(i int, result1 []atc.StepUsage, result2 error) {
+	fake.stepUsageMutex.Lock()
+	defer fake.stepUsageMutex.Unlock()
+	fake.StepUsageStub = nil
+	if fake.stepUsageReturnsOnCall == nil {
+		fake.stepUsageReturnsOnCall = make(map[int]struct {
+			result1 []atc.StepUsage
+			result2 error
+		})
+	}
+	fake.stepUsageReturnsOnCall[i] = struct {
+		result1 []atc.StepUsage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) SyslogTag(arg1 event.OriginID) string {
 	fake.syslogTagMutex.Lock()
 	ret, specificReturn := fake.syslogTagReturnsOnCall[len(fake.syslogTagArgsForCall)]
@@ -4437,6 +4929,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.eventsMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
+	fake.flakyMutex.RLock()
+	defer fake.flakyMutex.RUnlock()
 	fake.hasPlanMutex.RLock()
 	defer fake.hasPlanMutex.RUnlock()
 	fake.iDMutex.RLock()
@@ -4507,6 +5001,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.resourcesMutex.RUnlock()
 	fake.resourcesCheckedMutex.RLock()
 	defer fake.resourcesCheckedMutex.RUnlock()
+	fake.retryableOnFlakeMutex.RLock()
+	defer fake.retryableOnFlakeMutex.RUnlock()
 	fake.saveEventMutex.RLock()
 	defer fake.saveEventMutex.RUnlock()
 	fake.saveImageResourceVersionMutex.RLock()
@@ -4515,6 +5011,12 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.saveOutputMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
+	fake.saveStepTimingMutex.RLock()
+	defer fake.saveStepTimingMutex.RUnlock()
+	fake.saveStepUsageMutex.RLock()
+	defer fake.saveStepUsageMutex.RUnlock()
+	fake.saveTaskFailureMutex.RLock()
+	defer fake.saveTaskFailureMutex.RUnlock()
 	fake.schemaMutex.RLock()
 	defer fake.schemaMutex.RUnlock()
 	fake.setCommentMutex.RLock()
@@ -4531,6 +5033,10 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.startTimeMutex.RUnlock()
 	fake.statusMutex.RLock()
 	defer fake.statusMutex.RUnlock()
+	fake.stepTimingsMutex.RLock()
+	defer fake.stepTimingsMutex.RUnlock()
+	fake.stepUsageMutex.RLock()
+	defer fake.stepUsageMutex.RUnlock()
 	fake.syslogTagMutex.RLock()
 	defer fake.syslogTagMutex.RUnlock()
 	fake.teamIDMutex.RLock()
@@ -111,10 +111,11 @@ type FakeJob struct {
 		result1 atc.JobConfig
 		result2 error
 	}
-	CreateBuildStub        func(string) (db.Build, error)
+	CreateBuildStub        func(context.Context, string) (db.Build, error)
 	createBuildMutex       sync.RWMutex
 	createBuildArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	createBuildReturns struct {
 		result1 db.Build
@@ -948,18 +949,19 @@ func (fake *FakeJob) ConfigReturnsOnCall(i int, result1 atc.JobConfig, result2 e
 	}{result1, result2}
 }
 
-func (fake *FakeJob) CreateBuild(arg1 string) (db.Build, error) {
+func (fake *FakeJob) CreateBuild(arg1 context.Context, arg2 string) (db.Build, error) {
 	fake.createBuildMutex.Lock()
 	ret, specificReturn := fake.createBuildReturnsOnCall[len(fake.createBuildArgsForCall)]
 	fake.createBuildArgsForCall = append(fake.createBuildArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.CreateBuildStub
 	fakeReturns := fake.createBuildReturns
-	fake.recordInvocation("CreateBuild", []interface{}{arg1})
+	fake.recordInvocation("CreateBuild", []interface{}{arg1, arg2})
 	fake.createBuildMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -973,17 +975,17 @@ func (fake *FakeJob) CreateBuildCallCount() int {
 	return len(fake.createBuildArgsForCall)
 }
 
-func (fake *FakeJob) CreateBuildCalls(stub func(string) (db.Build, error)) {
+func (fake *FakeJob) CreateBuildCalls(stub func(context.Context, string) (db.Build, error)) {
 	fake.createBuildMutex.Lock()
 	defer fake.createBuildMutex.Unlock()
 	fake.CreateBuildStub = stub
 }
 
-func (fake *FakeJob) CreateBuildArgsForCall(i int) string {
+func (fake *FakeJob) CreateBuildArgsForCall(i int) (context.Context, string) {
 	fake.createBuildMutex.RLock()
 	defer fake.createBuildMutex.RUnlock()
 	argsForCall := fake.createBuildArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeJob) CreateBuildReturns(result1 db.Build, result2 error) {
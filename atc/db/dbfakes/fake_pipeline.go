@@ -302,6 +302,16 @@ type FakePipeline struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NotifiersStub        func() atc.NotifierConfigs
+	notifiersMutex       sync.RWMutex
+	notifiersArgsForCall []struct {
+	}
+	notifiersReturns struct {
+		result1 atc.NotifierConfigs
+	}
+	notifiersReturnsOnCall map[int]struct {
+		result1 atc.NotifierConfigs
+	}
 	ParentBuildIDStub        func() int
 	parentBuildIDMutex       sync.RWMutex
 	parentBuildIDArgsForCall []struct {
@@ -1979,6 +1989,59 @@ func (fake *FakePipeline) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakePipeline) Notifiers() atc.NotifierConfigs {
+	fake.notifiersMutex.Lock()
+	ret, specificReturn := fake.notifiersReturnsOnCall[len(fake.notifiersArgsForCall)]
+	fake.notifiersArgsForCall = append(fake.notifiersArgsForCall, struct {
+	}{})
+	stub := fake.NotifiersStub
+	fakeReturns := fake.notifiersReturns
+	fake.recordInvocation("Notifiers", []interface{}{})
+	fake.notifiersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) NotifiersCallCount() int {
+	fake.notifiersMutex.RLock()
+	defer fake.notifiersMutex.RUnlock()
+	return len(fake.notifiersArgsForCall)
+}
+
+func (fake *FakePipeline) NotifiersCalls(stub func() atc.NotifierConfigs) {
+	fake.notifiersMutex.Lock()
+	defer fake.notifiersMutex.Unlock()
+	fake.NotifiersStub = stub
+}
+
+func (fake *FakePipeline) NotifiersReturns(result1 atc.NotifierConfigs) {
+	fake.notifiersMutex.Lock()
+	defer fake.notifiersMutex.Unlock()
+	fake.NotifiersStub = nil
+	fake.notifiersReturns = struct {
+		result1 atc.NotifierConfigs
+	}{result1}
+}
+
+func (fake *FakePipeline) NotifiersReturnsOnCall(i int, result1 atc.NotifierConfigs) {
+	fake.notifiersMutex.Lock()
+	defer fake.notifiersMutex.Unlock()
+	fake.NotifiersStub = nil
+	if fake.notifiersReturnsOnCall == nil {
+		fake.notifiersReturnsOnCall = make(map[int]struct {
+			result1 atc.NotifierConfigs
+		})
+	}
+	fake.notifiersReturnsOnCall[i] = struct {
+		result1 atc.NotifierConfigs
+	}{result1}
+}
+
 func (fake *FakePipeline) ParentBuildID() int {
 	fake.parentBuildIDMutex.Lock()
 	ret, specificReturn := fake.parentBuildIDReturnsOnCall[len(fake.parentBuildIDArgsForCall)]
@@ -3196,6 +3259,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.loadDebugVersionsDBMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.notifiersMutex.RLock()
+	defer fake.notifiersMutex.RUnlock()
 	fake.parentBuildIDMutex.RLock()
 	defer fake.parentBuildIDMutex.RUnlock()
 	fake.parentJobIDMutex.RLock()
@@ -32,6 +32,19 @@ type FakeTeam struct {
 	authReturnsOnCall map[int]struct {
 		result1 atc.TeamAuth
 	}
+	BuildStatsStub        func(db.BuildStatsFilter) (atc.BuildStats, error)
+	buildStatsMutex       sync.RWMutex
+	buildStatsArgsForCall []struct {
+		arg1 db.BuildStatsFilter
+	}
+	buildStatsReturns struct {
+		result1 atc.BuildStats
+		result2 error
+	}
+	buildStatsReturnsOnCall map[int]struct {
+		result1 atc.BuildStats
+		result2 error
+	}
 	BuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -62,6 +75,16 @@ type FakeTeam struct {
 		result2 db.Pagination
 		result3 error
 	}
+	ContainerLimitsStub        func() atc.ContainerLimits
+	containerLimitsMutex       sync.RWMutex
+	containerLimitsArgsForCall []struct {
+	}
+	containerLimitsReturns struct {
+		result1 atc.ContainerLimits
+	}
+	containerLimitsReturnsOnCall map[int]struct {
+		result1 atc.ContainerLimits
+	}
 	ContainersStub        func() ([]db.Container, error)
 	containersMutex       sync.RWMutex
 	containersArgsForCall []struct {
@@ -229,6 +252,19 @@ type FakeTeam struct {
 		result1 []db.Worker
 		result2 error
 	}
+	FlakyJobsStub        func(db.BuildStatsFilter) ([]atc.FlakyJob, error)
+	flakyJobsMutex       sync.RWMutex
+	flakyJobsArgsForCall []struct {
+		arg1 db.BuildStatsFilter
+	}
+	flakyJobsReturns struct {
+		result1 []atc.FlakyJob
+		result2 error
+	}
+	flakyJobsReturnsOnCall map[int]struct {
+		result1 []atc.FlakyJob
+		result2 error
+	}
 	IDStub        func() int
 	iDMutex       sync.RWMutex
 	iDArgsForCall []struct {
@@ -276,6 +312,16 @@ type FakeTeam struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NotifiersStub        func() atc.NotifierConfigs
+	notifiersMutex       sync.RWMutex
+	notifiersArgsForCall []struct {
+	}
+	notifiersReturns struct {
+		result1 atc.NotifierConfigs
+	}
+	notifiersReturnsOnCall map[int]struct {
+		result1 atc.NotifierConfigs
+	}
 	OrderPipelinesStub        func([]string) error
 	orderPipelinesMutex       sync.RWMutex
 	orderPipelinesArgsForCall []struct {
@@ -410,6 +456,33 @@ type FakeTeam struct {
 		result1 db.Worker
 		result2 error
 	}
+	SearchBuildLogsStub        func(db.BuildLogSearch, db.Page) ([]atc.BuildLogMatch, db.Pagination, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		arg1 db.BuildLogSearch
+		arg2 db.Page
+	}
+	searchBuildLogsReturns struct {
+		result1 []atc.BuildLogMatch
+		result2 db.Pagination
+		result3 error
+	}
+	searchBuildLogsReturnsOnCall map[int]struct {
+		result1 []atc.BuildLogMatch
+		result2 db.Pagination
+		result3 error
+	}
+	UpdateStub        func(atc.Team) error
+	updateMutex       sync.RWMutex
+	updateArgsForCall []struct {
+		arg1 atc.Team
+	}
+	updateReturns struct {
+		result1 error
+	}
+	updateReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateProviderAuthStub        func(atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
@@ -543,6 +616,70 @@ func (fake *FakeTeam) AuthReturnsOnCall(i int, result1 atc.TeamAuth) {
 	}{result1}
 }
 
+func (fake *FakeTeam) BuildStats(arg1 db.BuildStatsFilter) (atc.BuildStats, error) {
+	fake.buildStatsMutex.Lock()
+	ret, specificReturn := fake.buildStatsReturnsOnCall[len(fake.buildStatsArgsForCall)]
+	fake.buildStatsArgsForCall = append(fake.buildStatsArgsForCall, struct {
+		arg1 db.BuildStatsFilter
+	}{arg1})
+	stub := fake.BuildStatsStub
+	fakeReturns := fake.buildStatsReturns
+	fake.recordInvocation("BuildStats", []interface{}{arg1})
+	fake.buildStatsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) BuildStatsCallCount() int {
+	fake.buildStatsMutex.RLock()
+	defer fake.buildStatsMutex.RUnlock()
+	return len(fake.buildStatsArgsForCall)
+}
+
+func (fake *FakeTeam) BuildStatsCalls(stub func(db.BuildStatsFilter) (atc.BuildStats, error)) {
+	fake.buildStatsMutex.Lock()
+	defer fake.buildStatsMutex.Unlock()
+	fake.BuildStatsStub = stub
+}
+
+func (fake *FakeTeam) BuildStatsArgsForCall(i int) db.BuildStatsFilter {
+	fake.buildStatsMutex.RLock()
+	defer fake.buildStatsMutex.RUnlock()
+	argsForCall := fake.buildStatsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) BuildStatsReturns(result1 atc.BuildStats, result2 error) {
+	fake.buildStatsMutex.Lock()
+	defer fake.buildStatsMutex.Unlock()
+	fake.BuildStatsStub = nil
+	fake.buildStatsReturns = struct {
+		result1 atc.BuildStats
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) BuildStatsReturnsOnCall(i int, result1 atc.BuildStats, result2 error) {
+	fake.buildStatsMutex.Lock()
+	defer fake.buildStatsMutex.Unlock()
+	fake.BuildStatsStub = nil
+	if fake.buildStatsReturnsOnCall == nil {
+		fake.buildStatsReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildStats
+			result2 error
+		})
+	}
+	fake.buildStatsReturnsOnCall[i] = struct {
+		result1 atc.BuildStats
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Builds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -677,6 +814,59 @@ func (fake *FakeTeam) BuildsWithTimeReturnsOnCall(i int, result1 []db.Build, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) ContainerLimits() atc.ContainerLimits {
+	fake.containerLimitsMutex.Lock()
+	ret, specificReturn := fake.containerLimitsReturnsOnCall[len(fake.containerLimitsArgsForCall)]
+	fake.containerLimitsArgsForCall = append(fake.containerLimitsArgsForCall, struct {
+	}{})
+	stub := fake.ContainerLimitsStub
+	fakeReturns := fake.containerLimitsReturns
+	fake.recordInvocation("ContainerLimits", []interface{}{})
+	fake.containerLimitsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) ContainerLimitsCallCount() int {
+	fake.containerLimitsMutex.RLock()
+	defer fake.containerLimitsMutex.RUnlock()
+	return len(fake.containerLimitsArgsForCall)
+}
+
+func (fake *FakeTeam) ContainerLimitsCalls(stub func() atc.ContainerLimits) {
+	fake.containerLimitsMutex.Lock()
+	defer fake.containerLimitsMutex.Unlock()
+	fake.ContainerLimitsStub = stub
+}
+
+func (fake *FakeTeam) ContainerLimitsReturns(result1 atc.ContainerLimits) {
+	fake.containerLimitsMutex.Lock()
+	defer fake.containerLimitsMutex.Unlock()
+	fake.ContainerLimitsStub = nil
+	fake.containerLimitsReturns = struct {
+		result1 atc.ContainerLimits
+	}{result1}
+}
+
+func (fake *FakeTeam) ContainerLimitsReturnsOnCall(i int, result1 atc.ContainerLimits) {
+	fake.containerLimitsMutex.Lock()
+	defer fake.containerLimitsMutex.Unlock()
+	fake.ContainerLimitsStub = nil
+	if fake.containerLimitsReturnsOnCall == nil {
+		fake.containerLimitsReturnsOnCall = make(map[int]struct {
+			result1 atc.ContainerLimits
+		})
+	}
+	fake.containerLimitsReturnsOnCall[i] = struct {
+		result1 atc.ContainerLimits
+	}{result1}
+}
+
 func (fake *FakeTeam) Containers() ([]db.Container, error) {
 	fake.containersMutex.Lock()
 	ret, specificReturn := fake.containersReturnsOnCall[len(fake.containersArgsForCall)]
@@ -1440,6 +1630,70 @@ func (fake *FakeTeam) FindWorkersForResourceCacheReturnsOnCall(i int, result1 []
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) FlakyJobs(arg1 db.BuildStatsFilter) ([]atc.FlakyJob, error) {
+	fake.flakyJobsMutex.Lock()
+	ret, specificReturn := fake.flakyJobsReturnsOnCall[len(fake.flakyJobsArgsForCall)]
+	fake.flakyJobsArgsForCall = append(fake.flakyJobsArgsForCall, struct {
+		arg1 db.BuildStatsFilter
+	}{arg1})
+	stub := fake.FlakyJobsStub
+	fakeReturns := fake.flakyJobsReturns
+	fake.recordInvocation("FlakyJobs", []interface{}{arg1})
+	fake.flakyJobsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) FlakyJobsCallCount() int {
+	fake.flakyJobsMutex.RLock()
+	defer fake.flakyJobsMutex.RUnlock()
+	return len(fake.flakyJobsArgsForCall)
+}
+
+func (fake *FakeTeam) FlakyJobsCalls(stub func(db.BuildStatsFilter) ([]atc.FlakyJob, error)) {
+	fake.flakyJobsMutex.Lock()
+	defer fake.flakyJobsMutex.Unlock()
+	fake.FlakyJobsStub = stub
+}
+
+func (fake *FakeTeam) FlakyJobsArgsForCall(i int) db.BuildStatsFilter {
+	fake.flakyJobsMutex.RLock()
+	defer fake.flakyJobsMutex.RUnlock()
+	argsForCall := fake.flakyJobsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) FlakyJobsReturns(result1 []atc.FlakyJob, result2 error) {
+	fake.flakyJobsMutex.Lock()
+	defer fake.flakyJobsMutex.Unlock()
+	fake.FlakyJobsStub = nil
+	fake.flakyJobsReturns = struct {
+		result1 []atc.FlakyJob
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) FlakyJobsReturnsOnCall(i int, result1 []atc.FlakyJob, result2 error) {
+	fake.flakyJobsMutex.Lock()
+	defer fake.flakyJobsMutex.Unlock()
+	fake.FlakyJobsStub = nil
+	if fake.flakyJobsReturnsOnCall == nil {
+		fake.flakyJobsReturnsOnCall = make(map[int]struct {
+			result1 []atc.FlakyJob
+			result2 error
+		})
+	}
+	fake.flakyJobsReturnsOnCall[i] = struct {
+		result1 []atc.FlakyJob
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) ID() int {
 	fake.iDMutex.Lock()
 	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
@@ -1675,6 +1929,59 @@ func (fake *FakeTeam) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeTeam) Notifiers() atc.NotifierConfigs {
+	fake.notifiersMutex.Lock()
+	ret, specificReturn := fake.notifiersReturnsOnCall[len(fake.notifiersArgsForCall)]
+	fake.notifiersArgsForCall = append(fake.notifiersArgsForCall, struct {
+	}{})
+	stub := fake.NotifiersStub
+	fakeReturns := fake.notifiersReturns
+	fake.recordInvocation("Notifiers", []interface{}{})
+	fake.notifiersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) NotifiersCallCount() int {
+	fake.notifiersMutex.RLock()
+	defer fake.notifiersMutex.RUnlock()
+	return len(fake.notifiersArgsForCall)
+}
+
+func (fake *FakeTeam) NotifiersCalls(stub func() atc.NotifierConfigs) {
+	fake.notifiersMutex.Lock()
+	defer fake.notifiersMutex.Unlock()
+	fake.NotifiersStub = stub
+}
+
+func (fake *FakeTeam) NotifiersReturns(result1 atc.NotifierConfigs) {
+	fake.notifiersMutex.Lock()
+	defer fake.notifiersMutex.Unlock()
+	fake.NotifiersStub = nil
+	fake.notifiersReturns = struct {
+		result1 atc.NotifierConfigs
+	}{result1}
+}
+
+func (fake *FakeTeam) NotifiersReturnsOnCall(i int, result1 atc.NotifierConfigs) {
+	fake.notifiersMutex.Lock()
+	defer fake.notifiersMutex.Unlock()
+	fake.NotifiersStub = nil
+	if fake.notifiersReturnsOnCall == nil {
+		fake.notifiersReturnsOnCall = make(map[int]struct {
+			result1 atc.NotifierConfigs
+		})
+	}
+	fake.notifiersReturnsOnCall[i] = struct {
+		result1 atc.NotifierConfigs
+	}{result1}
+}
+
 func (fake *FakeTeam) OrderPipelines(arg1 []string) error {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -2315,6 +2622,135 @@ func (fake *FakeTeam) SaveWorkerReturnsOnCall(i int, result1 db.Worker, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SearchBuildLogs(arg1 db.BuildLogSearch, arg2 db.Page) ([]atc.BuildLogMatch, db.Pagination, error) {
+	fake.searchBuildLogsMutex.Lock()
+	ret, specificReturn := fake.searchBuildLogsReturnsOnCall[len(fake.searchBuildLogsArgsForCall)]
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		arg1 db.BuildLogSearch
+		arg2 db.Page
+	}{arg1, arg2})
+	stub := fake.SearchBuildLogsStub
+	fakeReturns := fake.searchBuildLogsReturns
+	fake.recordInvocation("SearchBuildLogs", []interface{}{arg1, arg2})
+	fake.searchBuildLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakeTeam) SearchBuildLogsCalls(stub func(db.BuildLogSearch, db.Page) ([]atc.BuildLogMatch, db.Pagination, error)) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = stub
+}
+
+func (fake *FakeTeam) SearchBuildLogsArgsForCall(i int) (db.BuildLogSearch, db.Page) {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	argsForCall := fake.searchBuildLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturns(result1 []atc.BuildLogMatch, result2 db.Pagination, result3 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 []atc.BuildLogMatch
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturnsOnCall(i int, result1 []atc.BuildLogMatch, result2 db.Pagination, result3 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	if fake.searchBuildLogsReturnsOnCall == nil {
+		fake.searchBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 []atc.BuildLogMatch
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.searchBuildLogsReturnsOnCall[i] = struct {
+		result1 []atc.BuildLogMatch
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) Update(arg1 atc.Team) error {
+	fake.updateMutex.Lock()
+	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
+	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
+		arg1 atc.Team
+	}{arg1})
+	stub := fake.UpdateStub
+	fakeReturns := fake.updateReturns
+	fake.recordInvocation("Update", []interface{}{arg1})
+	fake.updateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateCallCount() int {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	return len(fake.updateArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateCalls(stub func(atc.Team) error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = stub
+}
+
+func (fake *FakeTeam) UpdateArgsForCall(i int) atc.Team {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	argsForCall := fake.updateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateReturns(result1 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	fake.updateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateReturnsOnCall(i int, result1 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	if fake.updateReturnsOnCall == nil {
+		fake.updateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) UpdateProviderAuth(arg1 atc.TeamAuth) error {
 	fake.updateProviderAuthMutex.Lock()
 	ret, specificReturn := fake.updateProviderAuthReturnsOnCall[len(fake.updateProviderAuthArgsForCall)]
@@ -2439,10 +2875,14 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.adminMutex.RUnlock()
 	fake.authMutex.RLock()
 	defer fake.authMutex.RUnlock()
+	fake.buildStatsMutex.RLock()
+	defer fake.buildStatsMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
 	fake.buildsWithTimeMutex.RLock()
 	defer fake.buildsWithTimeMutex.RUnlock()
+	fake.containerLimitsMutex.RLock()
+	defer fake.containerLimitsMutex.RUnlock()
 	fake.containersMutex.RLock()
 	defer fake.containersMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
@@ -2467,6 +2907,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.findWorkerForVolumeMutex.RUnlock()
 	fake.findWorkersForResourceCacheMutex.RLock()
 	defer fake.findWorkersForResourceCacheMutex.RUnlock()
+	fake.flakyJobsMutex.RLock()
+	defer fake.flakyJobsMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
 	fake.isCheckContainerMutex.RLock()
@@ -2475,6 +2917,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.isContainerWithinTeamMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.notifiersMutex.RLock()
+	defer fake.notifiersMutex.RUnlock()
 	fake.orderPipelinesMutex.RLock()
 	defer fake.orderPipelinesMutex.RUnlock()
 	fake.orderPipelinesWithinGroupMutex.RLock()
@@ -2495,6 +2939,10 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.savePipelineMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
 	fake.workersMutex.RLock()
@@ -42,6 +42,29 @@ type FakeWorker struct {
 	activeVolumesReturnsOnCall map[int]struct {
 		result1 int
 	}
+	AllocateResourcesStub        func(atc.WorkerResources) (atc.WorkerResources, error)
+	allocateResourcesMutex       sync.RWMutex
+	allocateResourcesArgsForCall []struct {
+		arg1 atc.WorkerResources
+	}
+	allocateResourcesReturns struct {
+		result1 atc.WorkerResources
+		result2 error
+	}
+	allocateResourcesReturnsOnCall map[int]struct {
+		result1 atc.WorkerResources
+		result2 error
+	}
+	AllocatedStub        func() atc.WorkerResources
+	allocatedMutex       sync.RWMutex
+	allocatedArgsForCall []struct {
+	}
+	allocatedReturns struct {
+		result1 atc.WorkerResources
+	}
+	allocatedReturnsOnCall map[int]struct {
+		result1 atc.WorkerResources
+	}
 	BaggageclaimURLStub        func() *string
 	baggageclaimURLMutex       sync.RWMutex
 	baggageclaimURLArgsForCall []struct {
@@ -52,6 +75,16 @@ type FakeWorker struct {
 	baggageclaimURLReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	CapacityStub        func() *atc.WorkerResources
+	capacityMutex       sync.RWMutex
+	capacityArgsForCall []struct {
+	}
+	capacityReturns struct {
+		result1 *atc.WorkerResources
+	}
+	capacityReturnsOnCall map[int]struct {
+		result1 *atc.WorkerResources
+	}
 	CertsPathStub        func() *string
 	certsPathMutex       sync.RWMutex
 	certsPathArgsForCall []struct {
@@ -62,6 +95,16 @@ type FakeWorker struct {
 	certsPathReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	CheckpointRestoreStub        func() bool
+	checkpointRestoreMutex       sync.RWMutex
+	checkpointRestoreArgsForCall []struct {
+	}
+	checkpointRestoreReturns struct {
+		result1 bool
+	}
+	checkpointRestoreReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	CreateContainerStub        func(db.ContainerOwner, db.ContainerMetadata) (db.CreatingContainer, error)
 	createContainerMutex       sync.RWMutex
 	createContainerArgsForCall []struct {
@@ -195,6 +238,16 @@ type FakeWorker struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	NetworkConfigStub        func() bool
+	networkConfigMutex       sync.RWMutex
+	networkConfigArgsForCall []struct {
+	}
+	networkConfigReturns struct {
+		result1 bool
+	}
+	networkConfigReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	NoProxyStub        func() string
 	noProxyMutex       sync.RWMutex
 	noProxyArgsForCall []struct {
@@ -225,6 +278,19 @@ type FakeWorker struct {
 	pruneReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ReleaseResourcesStub        func(atc.WorkerResources) (atc.WorkerResources, error)
+	releaseResourcesMutex       sync.RWMutex
+	releaseResourcesArgsForCall []struct {
+		arg1 atc.WorkerResources
+	}
+	releaseResourcesReturns struct {
+		result1 atc.WorkerResources
+		result2 error
+	}
+	releaseResourcesReturnsOnCall map[int]struct {
+		result1 atc.WorkerResources
+		result2 error
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -271,6 +337,26 @@ type FakeWorker struct {
 	retireReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RootlessStub        func() bool
+	rootlessMutex       sync.RWMutex
+	rootlessArgsForCall []struct {
+	}
+	rootlessReturns struct {
+		result1 bool
+	}
+	rootlessReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	SecurityAllowListStub        func() *atc.SecurityAllowList
+	securityAllowListMutex       sync.RWMutex
+	securityAllowListArgsForCall []struct {
+	}
+	securityAllowListReturns struct {
+		result1 *atc.SecurityAllowList
+	}
+	securityAllowListReturnsOnCall map[int]struct {
+		result1 *atc.SecurityAllowList
+	}
 	StartTimeStub        func() time.Time
 	startTimeMutex       sync.RWMutex
 	startTimeArgsForCall []struct {
@@ -331,6 +417,26 @@ type FakeWorker struct {
 	versionReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	WarmPoolStub        func() []string
+	warmPoolMutex       sync.RWMutex
+	warmPoolArgsForCall []struct {
+	}
+	warmPoolReturns struct {
+		result1 []string
+	}
+	warmPoolReturnsOnCall map[int]struct {
+		result1 []string
+	}
+	WarmTaskImagesStub        func() bool
+	warmTaskImagesMutex       sync.RWMutex
+	warmTaskImagesArgsForCall []struct {
+	}
+	warmTaskImagesReturns struct {
+		result1 bool
+	}
+	warmTaskImagesReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -497,6 +603,123 @@ func (fake *FakeWorker) ActiveVolumesReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeWorker) AllocateResources(arg1 atc.WorkerResources) (atc.WorkerResources, error) {
+	fake.allocateResourcesMutex.Lock()
+	ret, specificReturn := fake.allocateResourcesReturnsOnCall[len(fake.allocateResourcesArgsForCall)]
+	fake.allocateResourcesArgsForCall = append(fake.allocateResourcesArgsForCall, struct {
+		arg1 atc.WorkerResources
+	}{arg1})
+	stub := fake.AllocateResourcesStub
+	fakeReturns := fake.allocateResourcesReturns
+	fake.recordInvocation("AllocateResources", []interface{}{arg1})
+	fake.allocateResourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) AllocateResourcesCallCount() int {
+	fake.allocateResourcesMutex.RLock()
+	defer fake.allocateResourcesMutex.RUnlock()
+	return len(fake.allocateResourcesArgsForCall)
+}
+
+func (fake *FakeWorker) AllocateResourcesCalls(stub func(atc.WorkerResources) (atc.WorkerResources, error)) {
+	fake.allocateResourcesMutex.Lock()
+	defer fake.allocateResourcesMutex.Unlock()
+	fake.AllocateResourcesStub = stub
+}
+
+func (fake *FakeWorker) AllocateResourcesArgsForCall(i int) atc.WorkerResources {
+	fake.allocateResourcesMutex.RLock()
+	defer fake.allocateResourcesMutex.RUnlock()
+	argsForCall := fake.allocateResourcesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorker) AllocateResourcesReturns(result1 atc.WorkerResources, result2 error) {
+	fake.allocateResourcesMutex.Lock()
+	defer fake.allocateResourcesMutex.Unlock()
+	fake.AllocateResourcesStub = nil
+	fake.allocateResourcesReturns = struct {
+		result1 atc.WorkerResources
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) AllocateResourcesReturnsOnCall(i int, result1 atc.WorkerResources, result2 error) {
+	fake.allocateResourcesMutex.Lock()
+	defer fake.allocateResourcesMutex.Unlock()
+	fake.AllocateResourcesStub = nil
+	if fake.allocateResourcesReturnsOnCall == nil {
+		fake.allocateResourcesReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerResources
+			result2 error
+		})
+	}
+	fake.allocateResourcesReturnsOnCall[i] = struct {
+		result1 atc.WorkerResources
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) Allocated() atc.WorkerResources {
+	fake.allocatedMutex.Lock()
+	ret, specificReturn := fake.allocatedReturnsOnCall[len(fake.allocatedArgsForCall)]
+	fake.allocatedArgsForCall = append(fake.allocatedArgsForCall, struct {
+	}{})
+	stub := fake.AllocatedStub
+	fakeReturns := fake.allocatedReturns
+	fake.recordInvocation("Allocated", []interface{}{})
+	fake.allocatedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) AllocatedCallCount() int {
+	fake.allocatedMutex.RLock()
+	defer fake.allocatedMutex.RUnlock()
+	return len(fake.allocatedArgsForCall)
+}
+
+func (fake *FakeWorker) AllocatedCalls(stub func() atc.WorkerResources) {
+	fake.allocatedMutex.Lock()
+	defer fake.allocatedMutex.Unlock()
+	fake.AllocatedStub = stub
+}
+
+func (fake *FakeWorker) AllocatedReturns(result1 atc.WorkerResources) {
+	fake.allocatedMutex.Lock()
+	defer fake.allocatedMutex.Unlock()
+	fake.AllocatedStub = nil
+	fake.allocatedReturns = struct {
+		result1 atc.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) AllocatedReturnsOnCall(i int, result1 atc.WorkerResources) {
+	fake.allocatedMutex.Lock()
+	defer fake.allocatedMutex.Unlock()
+	fake.AllocatedStub = nil
+	if fake.allocatedReturnsOnCall == nil {
+		fake.allocatedReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerResources
+		})
+	}
+	fake.allocatedReturnsOnCall[i] = struct {
+		result1 atc.WorkerResources
+	}{result1}
+}
+
 func (fake *FakeWorker) BaggageclaimURL() *string {
 	fake.baggageclaimURLMutex.Lock()
 	ret, specificReturn := fake.baggageclaimURLReturnsOnCall[len(fake.baggageclaimURLArgsForCall)]
@@ -550,6 +773,59 @@ func (fake *FakeWorker) BaggageclaimURLReturnsOnCall(i int, result1 *string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Capacity() *atc.WorkerResources {
+	fake.capacityMutex.Lock()
+	ret, specificReturn := fake.capacityReturnsOnCall[len(fake.capacityArgsForCall)]
+	fake.capacityArgsForCall = append(fake.capacityArgsForCall, struct {
+	}{})
+	stub := fake.CapacityStub
+	fakeReturns := fake.capacityReturns
+	fake.recordInvocation("Capacity", []interface{}{})
+	fake.capacityMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) CapacityCallCount() int {
+	fake.capacityMutex.RLock()
+	defer fake.capacityMutex.RUnlock()
+	return len(fake.capacityArgsForCall)
+}
+
+func (fake *FakeWorker) CapacityCalls(stub func() *atc.WorkerResources) {
+	fake.capacityMutex.Lock()
+	defer fake.capacityMutex.Unlock()
+	fake.CapacityStub = stub
+}
+
+func (fake *FakeWorker) CapacityReturns(result1 *atc.WorkerResources) {
+	fake.capacityMutex.Lock()
+	defer fake.capacityMutex.Unlock()
+	fake.CapacityStub = nil
+	fake.capacityReturns = struct {
+		result1 *atc.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) CapacityReturnsOnCall(i int, result1 *atc.WorkerResources) {
+	fake.capacityMutex.Lock()
+	defer fake.capacityMutex.Unlock()
+	fake.CapacityStub = nil
+	if fake.capacityReturnsOnCall == nil {
+		fake.capacityReturnsOnCall = make(map[int]struct {
+			result1 *atc.WorkerResources
+		})
+	}
+	fake.capacityReturnsOnCall[i] = struct {
+		result1 *atc.WorkerResources
+	}{result1}
+}
+
 func (fake *FakeWorker) CertsPath() *string {
 	fake.certsPathMutex.Lock()
 	ret, specificReturn := fake.certsPathReturnsOnCall[len(fake.certsPathArgsForCall)]
@@ -603,6 +879,59 @@ func (fake *FakeWorker) CertsPathReturnsOnCall(i int, result1 *string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) CheckpointRestore() bool {
+	fake.checkpointRestoreMutex.Lock()
+	ret, specificReturn := fake.checkpointRestoreReturnsOnCall[len(fake.checkpointRestoreArgsForCall)]
+	fake.checkpointRestoreArgsForCall = append(fake.checkpointRestoreArgsForCall, struct {
+	}{})
+	stub := fake.CheckpointRestoreStub
+	fakeReturns := fake.checkpointRestoreReturns
+	fake.recordInvocation("CheckpointRestore", []interface{}{})
+	fake.checkpointRestoreMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) CheckpointRestoreCallCount() int {
+	fake.checkpointRestoreMutex.RLock()
+	defer fake.checkpointRestoreMutex.RUnlock()
+	return len(fake.checkpointRestoreArgsForCall)
+}
+
+func (fake *FakeWorker) CheckpointRestoreCalls(stub func() bool) {
+	fake.checkpointRestoreMutex.Lock()
+	defer fake.checkpointRestoreMutex.Unlock()
+	fake.CheckpointRestoreStub = stub
+}
+
+func (fake *FakeWorker) CheckpointRestoreReturns(result1 bool) {
+	fake.checkpointRestoreMutex.Lock()
+	defer fake.checkpointRestoreMutex.Unlock()
+	fake.CheckpointRestoreStub = nil
+	fake.checkpointRestoreReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) CheckpointRestoreReturnsOnCall(i int, result1 bool) {
+	fake.checkpointRestoreMutex.Lock()
+	defer fake.checkpointRestoreMutex.Unlock()
+	fake.CheckpointRestoreStub = nil
+	if fake.checkpointRestoreReturnsOnCall == nil {
+		fake.checkpointRestoreReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.checkpointRestoreReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeWorker) CreateContainer(arg1 db.ContainerOwner, arg2 db.ContainerMetadata) (db.CreatingContainer, error) {
 	fake.createContainerMutex.Lock()
 	ret, specificReturn := fake.createContainerReturnsOnCall[len(fake.createContainerArgsForCall)]
@@ -1271,6 +1600,59 @@ func (fake *FakeWorker) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) NetworkConfig() bool {
+	fake.networkConfigMutex.Lock()
+	ret, specificReturn := fake.networkConfigReturnsOnCall[len(fake.networkConfigArgsForCall)]
+	fake.networkConfigArgsForCall = append(fake.networkConfigArgsForCall, struct {
+	}{})
+	stub := fake.NetworkConfigStub
+	fakeReturns := fake.networkConfigReturns
+	fake.recordInvocation("NetworkConfig", []interface{}{})
+	fake.networkConfigMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) NetworkConfigCallCount() int {
+	fake.networkConfigMutex.RLock()
+	defer fake.networkConfigMutex.RUnlock()
+	return len(fake.networkConfigArgsForCall)
+}
+
+func (fake *FakeWorker) NetworkConfigCalls(stub func() bool) {
+	fake.networkConfigMutex.Lock()
+	defer fake.networkConfigMutex.Unlock()
+	fake.NetworkConfigStub = stub
+}
+
+func (fake *FakeWorker) NetworkConfigReturns(result1 bool) {
+	fake.networkConfigMutex.Lock()
+	defer fake.networkConfigMutex.Unlock()
+	fake.NetworkConfigStub = nil
+	fake.networkConfigReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) NetworkConfigReturnsOnCall(i int, result1 bool) {
+	fake.networkConfigMutex.Lock()
+	defer fake.networkConfigMutex.Unlock()
+	fake.NetworkConfigStub = nil
+	if fake.networkConfigReturnsOnCall == nil {
+		fake.networkConfigReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.networkConfigReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeWorker) NoProxy() string {
 	fake.noProxyMutex.Lock()
 	ret, specificReturn := fake.noProxyReturnsOnCall[len(fake.noProxyArgsForCall)]
@@ -1430,6 +1812,70 @@ func (fake *FakeWorker) PruneReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) ReleaseResources(arg1 atc.WorkerResources) (atc.WorkerResources, error) {
+	fake.releaseResourcesMutex.Lock()
+	ret, specificReturn := fake.releaseResourcesReturnsOnCall[len(fake.releaseResourcesArgsForCall)]
+	fake.releaseResourcesArgsForCall = append(fake.releaseResourcesArgsForCall, struct {
+		arg1 atc.WorkerResources
+	}{arg1})
+	stub := fake.ReleaseResourcesStub
+	fakeReturns := fake.releaseResourcesReturns
+	fake.recordInvocation("ReleaseResources", []interface{}{arg1})
+	fake.releaseResourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) ReleaseResourcesCallCount() int {
+	fake.releaseResourcesMutex.RLock()
+	defer fake.releaseResourcesMutex.RUnlock()
+	return len(fake.releaseResourcesArgsForCall)
+}
+
+func (fake *FakeWorker) ReleaseResourcesCalls(stub func(atc.WorkerResources) (atc.WorkerResources, error)) {
+	fake.releaseResourcesMutex.Lock()
+	defer fake.releaseResourcesMutex.Unlock()
+	fake.ReleaseResourcesStub = stub
+}
+
+func (fake *FakeWorker) ReleaseResourcesArgsForCall(i int) atc.WorkerResources {
+	fake.releaseResourcesMutex.RLock()
+	defer fake.releaseResourcesMutex.RUnlock()
+	argsForCall := fake.releaseResourcesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorker) ReleaseResourcesReturns(result1 atc.WorkerResources, result2 error) {
+	fake.releaseResourcesMutex.Lock()
+	defer fake.releaseResourcesMutex.Unlock()
+	fake.ReleaseResourcesStub = nil
+	fake.releaseResourcesReturns = struct {
+		result1 atc.WorkerResources
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ReleaseResourcesReturnsOnCall(i int, result1 atc.WorkerResources, result2 error) {
+	fake.releaseResourcesMutex.Lock()
+	defer fake.releaseResourcesMutex.Unlock()
+	fake.ReleaseResourcesStub = nil
+	if fake.releaseResourcesReturnsOnCall == nil {
+		fake.releaseResourcesReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerResources
+			result2 error
+		})
+	}
+	fake.releaseResourcesReturnsOnCall[i] = struct {
+		result1 atc.WorkerResources
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -1651,6 +2097,112 @@ func (fake *FakeWorker) RetireReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Rootless() bool {
+	fake.rootlessMutex.Lock()
+	ret, specificReturn := fake.rootlessReturnsOnCall[len(fake.rootlessArgsForCall)]
+	fake.rootlessArgsForCall = append(fake.rootlessArgsForCall, struct {
+	}{})
+	stub := fake.RootlessStub
+	fakeReturns := fake.rootlessReturns
+	fake.recordInvocation("Rootless", []interface{}{})
+	fake.rootlessMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) RootlessCallCount() int {
+	fake.rootlessMutex.RLock()
+	defer fake.rootlessMutex.RUnlock()
+	return len(fake.rootlessArgsForCall)
+}
+
+func (fake *FakeWorker) RootlessCalls(stub func() bool) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = stub
+}
+
+func (fake *FakeWorker) RootlessReturns(result1 bool) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = nil
+	fake.rootlessReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) RootlessReturnsOnCall(i int, result1 bool) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = nil
+	if fake.rootlessReturnsOnCall == nil {
+		fake.rootlessReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.rootlessReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) SecurityAllowList() *atc.SecurityAllowList {
+	fake.securityAllowListMutex.Lock()
+	ret, specificReturn := fake.securityAllowListReturnsOnCall[len(fake.securityAllowListArgsForCall)]
+	fake.securityAllowListArgsForCall = append(fake.securityAllowListArgsForCall, struct {
+	}{})
+	stub := fake.SecurityAllowListStub
+	fakeReturns := fake.securityAllowListReturns
+	fake.recordInvocation("SecurityAllowList", []interface{}{})
+	fake.securityAllowListMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) SecurityAllowListCallCount() int {
+	fake.securityAllowListMutex.RLock()
+	defer fake.securityAllowListMutex.RUnlock()
+	return len(fake.securityAllowListArgsForCall)
+}
+
+func (fake *FakeWorker) SecurityAllowListCalls(stub func() *atc.SecurityAllowList) {
+	fake.securityAllowListMutex.Lock()
+	defer fake.securityAllowListMutex.Unlock()
+	fake.SecurityAllowListStub = stub
+}
+
+func (fake *FakeWorker) SecurityAllowListReturns(result1 *atc.SecurityAllowList) {
+	fake.securityAllowListMutex.Lock()
+	defer fake.securityAllowListMutex.Unlock()
+	fake.SecurityAllowListStub = nil
+	fake.securityAllowListReturns = struct {
+		result1 *atc.SecurityAllowList
+	}{result1}
+}
+
+func (fake *FakeWorker) SecurityAllowListReturnsOnCall(i int, result1 *atc.SecurityAllowList) {
+	fake.securityAllowListMutex.Lock()
+	defer fake.securityAllowListMutex.Unlock()
+	fake.SecurityAllowListStub = nil
+	if fake.securityAllowListReturnsOnCall == nil {
+		fake.securityAllowListReturnsOnCall = make(map[int]struct {
+			result1 *atc.SecurityAllowList
+		})
+	}
+	fake.securityAllowListReturnsOnCall[i] = struct {
+		result1 *atc.SecurityAllowList
+	}{result1}
+}
+
 func (fake *FakeWorker) StartTime() time.Time {
 	fake.startTimeMutex.Lock()
 	ret, specificReturn := fake.startTimeReturnsOnCall[len(fake.startTimeArgsForCall)]
@@ -1969,6 +2521,112 @@ func (fake *FakeWorker) VersionReturnsOnCall(i int, result1 *string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) WarmPool() []string {
+	fake.warmPoolMutex.Lock()
+	ret, specificReturn := fake.warmPoolReturnsOnCall[len(fake.warmPoolArgsForCall)]
+	fake.warmPoolArgsForCall = append(fake.warmPoolArgsForCall, struct {
+	}{})
+	stub := fake.WarmPoolStub
+	fakeReturns := fake.warmPoolReturns
+	fake.recordInvocation("WarmPool", []interface{}{})
+	fake.warmPoolMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) WarmPoolCallCount() int {
+	fake.warmPoolMutex.RLock()
+	defer fake.warmPoolMutex.RUnlock()
+	return len(fake.warmPoolArgsForCall)
+}
+
+func (fake *FakeWorker) WarmPoolCalls(stub func() []string) {
+	fake.warmPoolMutex.Lock()
+	defer fake.warmPoolMutex.Unlock()
+	fake.WarmPoolStub = stub
+}
+
+func (fake *FakeWorker) WarmPoolReturns(result1 []string) {
+	fake.warmPoolMutex.Lock()
+	defer fake.warmPoolMutex.Unlock()
+	fake.WarmPoolStub = nil
+	fake.warmPoolReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeWorker) WarmPoolReturnsOnCall(i int, result1 []string) {
+	fake.warmPoolMutex.Lock()
+	defer fake.warmPoolMutex.Unlock()
+	fake.WarmPoolStub = nil
+	if fake.warmPoolReturnsOnCall == nil {
+		fake.warmPoolReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.warmPoolReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeWorker) WarmTaskImages() bool {
+	fake.warmTaskImagesMutex.Lock()
+	ret, specificReturn := fake.warmTaskImagesReturnsOnCall[len(fake.warmTaskImagesArgsForCall)]
+	fake.warmTaskImagesArgsForCall = append(fake.warmTaskImagesArgsForCall, struct {
+	}{})
+	stub := fake.WarmTaskImagesStub
+	fakeReturns := fake.warmTaskImagesReturns
+	fake.recordInvocation("WarmTaskImages", []interface{}{})
+	fake.warmTaskImagesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) WarmTaskImagesCallCount() int {
+	fake.warmTaskImagesMutex.RLock()
+	defer fake.warmTaskImagesMutex.RUnlock()
+	return len(fake.warmTaskImagesArgsForCall)
+}
+
+func (fake *FakeWorker) WarmTaskImagesCalls(stub func() bool) {
+	fake.warmTaskImagesMutex.Lock()
+	defer fake.warmTaskImagesMutex.Unlock()
+	fake.WarmTaskImagesStub = stub
+}
+
+func (fake *FakeWorker) WarmTaskImagesReturns(result1 bool) {
+	fake.warmTaskImagesMutex.Lock()
+	defer fake.warmTaskImagesMutex.Unlock()
+	fake.WarmTaskImagesStub = nil
+	fake.warmTaskImagesReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) WarmTaskImagesReturnsOnCall(i int, result1 bool) {
+	fake.warmTaskImagesMutex.Lock()
+	defer fake.warmTaskImagesMutex.Unlock()
+	fake.WarmTaskImagesStub = nil
+	if fake.warmTaskImagesReturnsOnCall == nil {
+		fake.warmTaskImagesReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.warmTaskImagesReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1978,10 +2636,18 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.activeTasksMutex.RUnlock()
 	fake.activeVolumesMutex.RLock()
 	defer fake.activeVolumesMutex.RUnlock()
+	fake.allocateResourcesMutex.RLock()
+	defer fake.allocateResourcesMutex.RUnlock()
+	fake.allocatedMutex.RLock()
+	defer fake.allocatedMutex.RUnlock()
 	fake.baggageclaimURLMutex.RLock()
 	defer fake.baggageclaimURLMutex.RUnlock()
+	fake.capacityMutex.RLock()
+	defer fake.capacityMutex.RUnlock()
 	fake.certsPathMutex.RLock()
 	defer fake.certsPathMutex.RUnlock()
+	fake.checkpointRestoreMutex.RLock()
+	defer fake.checkpointRestoreMutex.RUnlock()
 	fake.createContainerMutex.RLock()
 	defer fake.createContainerMutex.RUnlock()
 	fake.decreaseActiveTasksMutex.RLock()
@@ -2006,12 +2672,16 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.landMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.networkConfigMutex.RLock()
+	defer fake.networkConfigMutex.RUnlock()
 	fake.noProxyMutex.RLock()
 	defer fake.noProxyMutex.RUnlock()
 	fake.platformMutex.RLock()
 	defer fake.platformMutex.RUnlock()
 	fake.pruneMutex.RLock()
 	defer fake.pruneMutex.RUnlock()
+	fake.releaseResourcesMutex.RLock()
+	defer fake.releaseResourcesMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	fake.resourceCertsMutex.RLock()
@@ -2020,6 +2690,10 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.resourceTypesMutex.RUnlock()
 	fake.retireMutex.RLock()
 	defer fake.retireMutex.RUnlock()
+	fake.rootlessMutex.RLock()
+	defer fake.rootlessMutex.RUnlock()
+	fake.securityAllowListMutex.RLock()
+	defer fake.securityAllowListMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.stateMutex.RLock()
@@ -2032,6 +2706,10 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.teamNameMutex.RUnlock()
 	fake.versionMutex.RLock()
 	defer fake.versionMutex.RUnlock()
+	fake.warmPoolMutex.RLock()
+	defer fake.warmPoolMutex.RUnlock()
+	fake.warmTaskImagesMutex.RLock()
+	defer fake.warmTaskImagesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -22,6 +22,18 @@ type FakeWorkerFactory struct {
 		result1 map[string]int
 		result2 error
 	}
+	BuildCriticalWorkersStub        func() (map[string]bool, error)
+	buildCriticalWorkersMutex       sync.RWMutex
+	buildCriticalWorkersArgsForCall []struct {
+	}
+	buildCriticalWorkersReturns struct {
+		result1 map[string]bool
+		result2 error
+	}
+	buildCriticalWorkersReturnsOnCall map[int]struct {
+		result1 map[string]bool
+		result2 error
+	}
 	FindWorkersForContainerByOwnerStub        func(db.ContainerOwner) ([]db.Worker, error)
 	findWorkersForContainerByOwnerMutex       sync.RWMutex
 	findWorkersForContainerByOwnerArgsForCall []struct {
@@ -163,6 +175,62 @@ func (fake *FakeWorkerFactory) BuildContainersCountPerWorkerReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerFactory) BuildCriticalWorkers() (map[string]bool, error) {
+	fake.buildCriticalWorkersMutex.Lock()
+	ret, specificReturn := fake.buildCriticalWorkersReturnsOnCall[len(fake.buildCriticalWorkersArgsForCall)]
+	fake.buildCriticalWorkersArgsForCall = append(fake.buildCriticalWorkersArgsForCall, struct {
+	}{})
+	stub := fake.BuildCriticalWorkersStub
+	fakeReturns := fake.buildCriticalWorkersReturns
+	fake.recordInvocation("BuildCriticalWorkers", []interface{}{})
+	fake.buildCriticalWorkersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerFactory) BuildCriticalWorkersCallCount() int {
+	fake.buildCriticalWorkersMutex.RLock()
+	defer fake.buildCriticalWorkersMutex.RUnlock()
+	return len(fake.buildCriticalWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerFactory) BuildCriticalWorkersCalls(stub func() (map[string]bool, error)) {
+	fake.buildCriticalWorkersMutex.Lock()
+	defer fake.buildCriticalWorkersMutex.Unlock()
+	fake.BuildCriticalWorkersStub = stub
+}
+
+func (fake *FakeWorkerFactory) BuildCriticalWorkersReturns(result1 map[string]bool, result2 error) {
+	fake.buildCriticalWorkersMutex.Lock()
+	defer fake.buildCriticalWorkersMutex.Unlock()
+	fake.BuildCriticalWorkersStub = nil
+	fake.buildCriticalWorkersReturns = struct {
+		result1 map[string]bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerFactory) BuildCriticalWorkersReturnsOnCall(i int, result1 map[string]bool, result2 error) {
+	fake.buildCriticalWorkersMutex.Lock()
+	defer fake.buildCriticalWorkersMutex.Unlock()
+	fake.BuildCriticalWorkersStub = nil
+	if fake.buildCriticalWorkersReturnsOnCall == nil {
+		fake.buildCriticalWorkersReturnsOnCall = make(map[int]struct {
+			result1 map[string]bool
+			result2 error
+		})
+	}
+	fake.buildCriticalWorkersReturnsOnCall[i] = struct {
+		result1 map[string]bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerFactory) FindWorkersForContainerByOwner(arg1 db.ContainerOwner) ([]db.Worker, error) {
 	fake.findWorkersForContainerByOwnerMutex.Lock()
 	ret, specificReturn := fake.findWorkersForContainerByOwnerReturnsOnCall[len(fake.findWorkersForContainerByOwnerArgsForCall)]
@@ -554,6 +622,8 @@ func (fake *FakeWorkerFactory) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.buildContainersCountPerWorkerMutex.RLock()
 	defer fake.buildContainersCountPerWorkerMutex.RUnlock()
+	fake.buildCriticalWorkersMutex.RLock()
+	defer fake.buildCriticalWorkersMutex.RUnlock()
 	fake.findWorkersForContainerByOwnerMutex.RLock()
 	defer fake.findWorkersForContainerByOwnerMutex.RUnlock()
 	fake.getWorkerMutex.RLock()
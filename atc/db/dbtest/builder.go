@@ -1,6 +1,7 @@
 package dbtest
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
@@ -369,7 +370,7 @@ func (builder Builder) WithPendingJobBuild(assign *db.Build, jobName string) Set
 			return fmt.Errorf("job '%s' not configured in pipeline", jobName)
 		}
 
-		build, err := job.CreateBuild("some-user")
+		build, err := job.CreateBuild(context.TODO(), "some-user")
 		if err != nil {
 			return fmt.Errorf("create build: %w", err)
 		}
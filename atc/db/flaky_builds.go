@@ -0,0 +1,130 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// rerunGroup identifies the build along with its reruns, which all run with
+// the same inputs. Reruns of a rerun are reruns of the original build.
+func (b *build) rerunGroup() int {
+	if b.rerunOf != 0 {
+		return b.rerunOf
+	}
+
+	return b.id
+}
+
+// markFlakyBuilds flags every build of a rerun group as flaky once the group
+// has both succeeded and failed or errored, and returns whether it did.
+func markFlakyBuilds(tx Tx, group int) (bool, error) {
+	result, err := tx.Exec(`
+		UPDATE builds
+		SET flaky = true
+		WHERE (id = $1 OR rerun_of = $1)
+		AND EXISTS (
+			SELECT 1 FROM builds
+			WHERE (id = $1 OR rerun_of = $1) AND status = 'succeeded'
+		)
+		AND EXISTS (
+			SELECT 1 FROM builds
+			WHERE (id = $1 OR rerun_of = $1) AND status IN ('failed', 'errored')
+		)
+	`, group)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// SaveTaskFailure records that a task of the build failed. The failure of the
+// build is only tolerated as flakiness if every task that failed was flaky
+// tolerant.
+func (b *build) SaveTaskFailure(flakyTolerant bool) error {
+	_, err := psql.Update("builds").
+		Set("tolerated_failure", sq.Expr("COALESCE(tolerated_failure, true) AND ?", flakyTolerant)).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
+// RetryableOnFlake returns whether the build failed only in flaky tolerant
+// tasks and its original build has been rerun fewer than attempts times.
+func (b *build) RetryableOnFlake(attempts int) (bool, error) {
+	var (
+		toleratedFailure sql.NullBool
+		reruns           int
+	)
+	err := psql.Select("b.tolerated_failure").
+		Column(sq.Expr("(SELECT COUNT(*) FROM builds r WHERE r.rerun_of = ?)", b.rerunGroup())).
+		From("builds b").
+		Where(sq.Eq{"b.id": b.id}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&toleratedFailure, &reruns)
+	if err != nil {
+		return false, err
+	}
+
+	return toleratedFailure.Valid && toleratedFailure.Bool && reruns < attempts, nil
+}
+
+func flakyJobs(conn Conn, teamID int, filter BuildStatsFilter) ([]atc.FlakyJob, error) {
+	rows, err := psql.Select("t.name", "p.name", "p.instance_vars", "j.name").
+		Column("COUNT(DISTINCT COALESCE(b.rerun_of, b.id)) FILTER (WHERE b.flaky) AS flaky_builds").
+		Column("COUNT(DISTINCT COALESCE(b.rerun_of, b.id))").
+		Column("EXTRACT(EPOCH FROM MAX(b.end_time) FILTER (WHERE b.flaky))::bigint AS last_flaky").
+		From("builds b").
+		Join("jobs j ON j.id = b.job_id").
+		Join("pipelines p ON p.id = b.pipeline_id").
+		Join("teams t ON t.id = b.team_id").
+		Where(filter.filter(teamID)).
+		Where(sq.Eq{"b.status": finishedBuildStatuses}).
+		GroupBy("t.id", "p.id", "j.id").
+		Having("bool_or(b.flaky)").
+		OrderBy("flaky_builds DESC", "last_flaky DESC", "j.id").
+		RunWith(conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	jobs := []atc.FlakyJob{}
+	for rows.Next() {
+		var (
+			job          atc.FlakyJob
+			instanceVars sql.NullString
+			lastFlaky    sql.NullInt64
+		)
+
+		err = rows.Scan(&job.TeamName, &job.PipelineName, &instanceVars, &job.JobName, &job.FlakyBuilds, &job.Builds, &lastFlaky)
+		if err != nil {
+			return nil, err
+		}
+
+		if instanceVars.Valid {
+			err = json.Unmarshal([]byte(instanceVars.String), &job.PipelineInstanceVars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		job.LastFlaky = lastFlaky.Int64
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
@@ -0,0 +1,182 @@
+package db_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Flaky builds", func() {
+	var build db.Build
+
+	BeforeEach(func() {
+		var err error
+		build, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	reload := func(build db.Build) db.Build {
+		found, err := build.Reload()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		return build
+	}
+
+	Describe("Finish", func() {
+		BeforeEach(func() {
+			err := build.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("does not flag a build without reruns", func() {
+			Expect(reload(build).Flaky()).To(BeFalse())
+		})
+
+		Context("when a rerun has the same outcome", func() {
+			It("does not flag the builds", func() {
+				rerun, err := defaultJob.RerunBuild(build, defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = rerun.Finish(db.BuildStatusErrored)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(rerun.Flaky()).To(BeFalse())
+				Expect(reload(build).Flaky()).To(BeFalse())
+			})
+		})
+
+		Context("when a rerun has a different outcome", func() {
+			It("flags the build and its reruns", func() {
+				firstRerun, err := defaultJob.RerunBuild(build, defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = firstRerun.Finish(db.BuildStatusFailed)
+				Expect(err).ToNot(HaveOccurred())
+
+				secondRerun, err := defaultJob.RerunBuild(firstRerun, defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = secondRerun.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(secondRerun.Flaky()).To(BeTrue())
+				Expect(reload(firstRerun).Flaky()).To(BeTrue())
+				Expect(reload(build).Flaky()).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("RetryableOnFlake", func() {
+		It("is not retryable when no task failed", func() {
+			retryable, err := build.RetryableOnFlake(1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retryable).To(BeFalse())
+		})
+
+		Context("when only flaky tolerant tasks failed", func() {
+			BeforeEach(func() {
+				err := build.SaveTaskFailure(true)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveTaskFailure(true)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is retryable until the build has been rerun enough times", func() {
+				retryable, err := build.RetryableOnFlake(1)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(retryable).To(BeTrue())
+
+				rerun, err := defaultJob.RerunBuild(build, "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rerun.CreatedBy()).To(BeNil())
+
+				err = rerun.SaveTaskFailure(true)
+				Expect(err).ToNot(HaveOccurred())
+
+				retryable, err = rerun.RetryableOnFlake(1)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(retryable).To(BeFalse())
+
+				retryable, err = rerun.RetryableOnFlake(2)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(retryable).To(BeTrue())
+			})
+
+			Context("when a task which isn't flaky tolerant also failed", func() {
+				BeforeEach(func() {
+					err := build.SaveTaskFailure(false)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("is not retryable", func() {
+					retryable, err := build.RetryableOnFlake(1)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(retryable).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("FlakyJobs", func() {
+		var filter db.BuildStatsFilter
+
+		BeforeEach(func() {
+			filter = db.BuildStatsFilter{
+				Since: time.Now().Add(-time.Hour),
+				Until: time.Now().Add(time.Hour),
+			}
+
+			err := build.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns nothing when no builds were flaky", func() {
+			jobs, err := defaultTeam.FlakyJobs(filter)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(jobs).To(BeEmpty())
+		})
+
+		Context("when a build was flaky", func() {
+			BeforeEach(func() {
+				flakyBuild, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = flakyBuild.Finish(db.BuildStatusFailed)
+				Expect(err).ToNot(HaveOccurred())
+
+				rerun, err := defaultJob.RerunBuild(flakyBuild, defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = rerun.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the job, counting a build and its reruns once", func() {
+				jobs, err := defaultTeam.FlakyJobs(filter)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(jobs).To(HaveLen(1))
+
+				Expect(jobs[0].TeamName).To(Equal(defaultTeam.Name()))
+				Expect(jobs[0].PipelineName).To(Equal(defaultPipeline.Name()))
+				Expect(jobs[0].PipelineInstanceVars).To(Equal(defaultPipeline.InstanceVars()))
+				Expect(jobs[0].JobName).To(Equal(defaultJob.Name()))
+				Expect(jobs[0].FlakyBuilds).To(Equal(1))
+				Expect(jobs[0].Builds).To(Equal(2))
+				Expect(jobs[0].LastFlaky).To(BeNumerically("~", time.Now().Unix(), 60))
+			})
+
+			It("leaves out builds created outside of the window", func() {
+				filter.Until = filter.Since.Add(time.Minute)
+
+				jobs, err := defaultTeam.FlakyJobs(filter)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(jobs).To(Equal([]atc.FlakyJob{}))
+			})
+		})
+	})
+})
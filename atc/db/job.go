@@ -78,7 +78,7 @@ type Job interface {
 	Unpause() error
 
 	ScheduleBuild(Build) (bool, error)
-	CreateBuild(createdBy string) (Build, error)
+	CreateBuild(ctx context.Context, createdBy string) (Build, error)
 	RerunBuild(build Build, createdBy string) (Build, error)
 
 	RequestSchedule() error
@@ -771,7 +771,12 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 	return builds, nil
 }
 
-func (j *job) CreateBuild(createdBy string) (Build, error) {
+func (j *job) CreateBuild(ctx context.Context, createdBy string) (Build, error) {
+	spanContextJSON, err := json.Marshal(NewSpanContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -793,6 +798,7 @@ func (j *job) CreateBuild(createdBy string) (Build, error) {
 		"status":             BuildStatusPending,
 		"manually_triggered": true,
 		"created_by":         createdBy,
+		"span_context":       string(spanContextJSON),
 	})
 	if err != nil {
 		return nil, err
@@ -863,7 +869,7 @@ func (j *job) tryRerunBuild(buildToRerun Build, createdBy string) (Build, error)
 		"status":       BuildStatusPending,
 		"rerun_of":     buildToRerunID,
 		"rerun_number": rerunNumber,
-		"created_by":   createdBy,
+		"created_by":   sql.NullString{String: createdBy, Valid: createdBy != ""},
 	})
 	if err != nil {
 		return nil, err
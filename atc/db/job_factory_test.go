@@ -1,6 +1,8 @@
 package db_test
 
 import (
+	"context"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
@@ -203,7 +205,7 @@ var _ = Describe("JobFactory", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				transitionBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+				transitionBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = transitionBuild.Finish(db.BuildStatusSucceeded)
@@ -213,7 +215,7 @@ var _ = Describe("JobFactory", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				finishedBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+				finishedBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = finishedBuild.Finish(db.BuildStatusSucceeded)
@@ -223,7 +225,7 @@ var _ = Describe("JobFactory", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				nextBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+				nextBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				visibleJobs, err := jobFactory.VisibleJobs([]string{"default-team"})
@@ -234,13 +234,13 @@ var _ = Describe("Job", func() {
 			Expect(next).To(BeNil())
 			Expect(finished).To(BeNil())
 
-			finishedBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+			finishedBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			err = finishedBuild.Finish(db.BuildStatusSucceeded)
 			Expect(err).NotTo(HaveOccurred())
 
-			otherFinishedBuild, err := otherJob.CreateBuild(defaultBuildCreatedBy)
+			otherFinishedBuild, err := otherJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			err = otherFinishedBuild.Finish(db.BuildStatusSucceeded)
@@ -252,14 +252,14 @@ var _ = Describe("Job", func() {
 			Expect(next).To(BeNil())
 			Expect(finished.ID()).To(Equal(finishedBuild.ID()))
 
-			nextBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+			nextBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			started, err := nextBuild.Start(atc.Plan{})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(started).To(BeTrue())
 
-			otherNextBuild, err := otherJob.CreateBuild(defaultBuildCreatedBy)
+			otherNextBuild, err := otherJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			otherStarted, err := otherNextBuild.Start(atc.Plan{})
@@ -272,7 +272,7 @@ var _ = Describe("Job", func() {
 			Expect(next.ID()).To(Equal(nextBuild.ID()))
 			Expect(finished.ID()).To(Equal(finishedBuild.ID()))
 
-			anotherRunningBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+			anotherRunningBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			finished, next, err = job.FinishedAndNextBuild()
@@ -349,12 +349,12 @@ var _ = Describe("Job", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err := someJob.CreateBuild(defaultBuildCreatedBy)
+				build, err := someJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(build.CreatedBy()).ToNot(BeNil())
 				Expect(*build.CreatedBy()).To(Equal(defaultBuildCreatedBy))
 
-				_, err = someOtherJob.CreateBuild(defaultBuildCreatedBy)
+				_, err = someOtherJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				builds[i] = build
@@ -453,7 +453,7 @@ var _ = Describe("Job", func() {
 			Expect(found).To(BeTrue())
 
 			for i := range builds {
-				builds[i], err = job.CreateBuild(defaultBuildCreatedBy)
+				builds[i], err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				buildStart := time.Date(2020, 11, i+1, 0, 0, 0, 0, time.UTC)
@@ -532,12 +532,12 @@ var _ = Describe("Job", func() {
 		Context("when a build exists", func() {
 			BeforeEach(func() {
 				var err error
-				firstBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+				firstBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			It("finds the latest build", func() {
-				secondBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+				secondBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				build, found, err := job.Build("latest")
@@ -576,7 +576,7 @@ var _ = Describe("Job", func() {
 			It("requests schedule on the job", func() {
 				requestedSchedule := job.ScheduleRequestedTime()
 
-				_, err := job.CreateBuild(defaultBuildCreatedBy)
+				_, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				found, err := job.Reload()
@@ -601,7 +601,7 @@ var _ = Describe("Job", func() {
 		Context("when the first build exists", func() {
 			BeforeEach(func() {
 				var err error
-				firstBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+				firstBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				buildToRerun = firstBuild
@@ -859,7 +859,7 @@ var _ = Describe("Job", func() {
 		Context("when the scheduling build is created first", func() {
 			BeforeEach(func() {
 				var err error
-				schedulingBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+				schedulingBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -950,7 +950,7 @@ var _ = Describe("Job", func() {
 
 					BeforeEach(func() {
 						var err error
-						startedBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+						startedBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 						Expect(err).ToNot(HaveOccurred())
 						scheduled, err := job.ScheduleBuild(startedBuild)
 						Expect(err).ToNot(HaveOccurred())
@@ -958,7 +958,7 @@ var _ = Describe("Job", func() {
 						_, err = startedBuild.Start(atc.Plan{})
 						Expect(err).NotTo(HaveOccurred())
 
-						scheduledBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+						scheduledBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 						Expect(err).NotTo(HaveOccurred())
 						scheduled, err = job.ScheduleBuild(scheduledBuild)
 						Expect(err).ToNot(HaveOccurred())
@@ -967,7 +967,7 @@ var _ = Describe("Job", func() {
 						Expect(err).NotTo(HaveOccurred())
 
 						for _, s := range []db.BuildStatus{db.BuildStatusSucceeded, db.BuildStatusFailed, db.BuildStatusErrored, db.BuildStatusAborted} {
-							finishedBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+							finishedBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 							Expect(err).NotTo(HaveOccurred())
 
 							scheduled, err = job.ScheduleBuild(finishedBuild)
@@ -982,7 +982,7 @@ var _ = Describe("Job", func() {
 						Expect(err).NotTo(HaveOccurred())
 						Expect(found).To(BeTrue())
 
-						_, err = otherJob.CreateBuild(defaultBuildCreatedBy)
+						_, err = otherJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 						Expect(err).NotTo(HaveOccurred())
 					})
 
@@ -997,7 +997,7 @@ var _ = Describe("Job", func() {
 
 				Context("when there is 1 build running", func() {
 					BeforeEach(func() {
-						startedBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+						startedBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 						Expect(err).NotTo(HaveOccurred())
 						scheduled, err := job.ScheduleBuild(startedBuild)
 						Expect(err).NotTo(HaveOccurred())
@@ -1006,7 +1006,7 @@ var _ = Describe("Job", func() {
 						Expect(err).NotTo(HaveOccurred())
 
 						for _, s := range []db.BuildStatus{db.BuildStatusSucceeded, db.BuildStatusFailed, db.BuildStatusErrored, db.BuildStatusAborted} {
-							finishedBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+							finishedBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 							Expect(err).NotTo(HaveOccurred())
 
 							scheduled, err = job.ScheduleBuild(finishedBuild)
@@ -1035,14 +1035,14 @@ var _ = Describe("Job", func() {
 				Context("when multiple jobs in the serial group is running", func() {
 					BeforeEach(func() {
 						var err error
-						_, err = job.CreateBuild(defaultBuildCreatedBy)
+						_, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 						Expect(err).NotTo(HaveOccurred())
 
 						otherSerialJob, found, err := pipeline.Job("other-serial-group-job")
 						Expect(err).NotTo(HaveOccurred())
 						Expect(found).To(BeTrue())
 
-						serialGroupBuild, err := otherSerialJob.CreateBuild(defaultBuildCreatedBy)
+						serialGroupBuild, err := otherSerialJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 						Expect(err).NotTo(HaveOccurred())
 
 						scheduled, err := otherSerialJob.ScheduleBuild(serialGroupBuild)
@@ -1053,7 +1053,7 @@ var _ = Describe("Job", func() {
 						Expect(err).NotTo(HaveOccurred())
 						Expect(found).To(BeTrue())
 
-						differentSerialGroupBuild, err := differentSerialJob.CreateBuild(defaultBuildCreatedBy)
+						differentSerialGroupBuild, err := differentSerialJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 						Expect(err).NotTo(HaveOccurred())
 
 						scheduled, err = differentSerialJob.ScheduleBuild(differentSerialGroupBuild)
@@ -1075,7 +1075,7 @@ var _ = Describe("Job", func() {
 						Expect(err).NotTo(HaveOccurred())
 						Expect(found).To(BeTrue())
 
-						serialGroupBuild, err := otherSerialJob.CreateBuild(defaultBuildCreatedBy)
+						serialGroupBuild, err := otherSerialJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 						Expect(err).NotTo(HaveOccurred())
 
 						scheduled, err := otherSerialJob.ScheduleBuild(serialGroupBuild)
@@ -1089,7 +1089,7 @@ var _ = Describe("Job", func() {
 						Expect(err).NotTo(HaveOccurred())
 						Expect(found).To(BeTrue())
 
-						differentSerialGroupBuild, err := differentSerialJob.CreateBuild(defaultBuildCreatedBy)
+						differentSerialGroupBuild, err := differentSerialJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 						Expect(err).NotTo(HaveOccurred())
 
 						scheduled, err = differentSerialJob.ScheduleBuild(differentSerialGroupBuild)
@@ -1115,7 +1115,7 @@ var _ = Describe("Job", func() {
 			Context("when the scheduling build has inputs determined as false", func() {
 				BeforeEach(func() {
 					var err error
-					schedulingBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+					schedulingBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).NotTo(HaveOccurred())
 
 					err = job.SaveNextInputMapping(nil, false)
@@ -1137,13 +1137,13 @@ var _ = Describe("Job", func() {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					_, err = otherSerialJob.CreateBuild(defaultBuildCreatedBy)
+					_, err = otherSerialJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).NotTo(HaveOccurred())
 
 					err = otherSerialJob.SaveNextInputMapping(nil, true)
 					Expect(err).NotTo(HaveOccurred())
 
-					schedulingBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+					schedulingBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).NotTo(HaveOccurred())
 
 					err = job.SaveNextInputMapping(nil, true)
@@ -1162,14 +1162,14 @@ var _ = Describe("Job", func() {
 			Context("when the scheduling build has it's inputs determined and created earlier", func() {
 				BeforeEach(func() {
 					var err error
-					schedulingBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+					schedulingBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).NotTo(HaveOccurred())
 
 					otherSerialJob, found, err := pipeline.Job("other-serial-group-job")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					_, err = otherSerialJob.CreateBuild(defaultBuildCreatedBy)
+					_, err = otherSerialJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).NotTo(HaveOccurred())
 
 					err = job.SaveNextInputMapping(nil, true)
@@ -1190,14 +1190,14 @@ var _ = Describe("Job", func() {
 			Context("when the job is paused but has inputs determined", func() {
 				BeforeEach(func() {
 					var err error
-					schedulingBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+					schedulingBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).NotTo(HaveOccurred())
 
 					otherSerialJob, found, err := pipeline.Job("other-serial-group-job")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					_, err = otherSerialJob.CreateBuild(defaultBuildCreatedBy)
+					_, err = otherSerialJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).NotTo(HaveOccurred())
 
 					err = job.SaveNextInputMapping(nil, true)
@@ -1224,7 +1224,7 @@ var _ = Describe("Job", func() {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					succeededBuild, err := otherSerialJob.CreateBuild(defaultBuildCreatedBy)
+					succeededBuild, err := otherSerialJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).NotTo(HaveOccurred())
 
 					err = succeededBuild.Finish(db.BuildStatusSucceeded)
@@ -1235,7 +1235,7 @@ var _ = Describe("Job", func() {
 					err = otherSerialJob.SaveNextInputMapping(nil, true)
 					Expect(err).NotTo(HaveOccurred())
 
-					schedulingBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+					schedulingBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).NotTo(HaveOccurred())
 				})
 
@@ -1254,14 +1254,14 @@ var _ = Describe("Job", func() {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					_, err = otherSerialJob.CreateBuild(defaultBuildCreatedBy)
+					_, err = otherSerialJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).NotTo(HaveOccurred())
 
 					job, found, err = pipeline.Job("other-serial-group-job")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					schedulingBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+					schedulingBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).NotTo(HaveOccurred())
 
 					err = job.SaveNextInputMapping(nil, true)
@@ -1689,7 +1689,7 @@ var _ = Describe("Job", func() {
 			otherPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-other-pipeline"}, pipelineConfig, db.ConfigVersion(1), false)
 			Expect(err).ToNot(HaveOccurred())
 
-			build1DB, err = job.CreateBuild(defaultBuildCreatedBy)
+			build1DB, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(build1DB.ID()).NotTo(BeZero())
@@ -1714,7 +1714,7 @@ var _ = Describe("Job", func() {
 
 		Context("and another build for a different pipeline is created with the same job name", func() {
 			BeforeEach(func() {
-				otherBuild, err := otherJob.CreateBuild(defaultBuildCreatedBy)
+				otherBuild, err := otherJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(otherBuild.ID()).NotTo(BeZero())
@@ -1792,7 +1792,7 @@ var _ = Describe("Job", func() {
 
 			BeforeEach(func() {
 				var err error
-				build2DB, err = job.CreateBuild(defaultBuildCreatedBy)
+				build2DB, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(build2DB.ID()).NotTo(BeZero())
@@ -1819,10 +1819,10 @@ var _ = Describe("Job", func() {
 
 			BeforeEach(func() {
 				var err error
-				newBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+				newBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
-				newerBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+				newerBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				err = newBuild.Finish(db.BuildStatusSucceeded)
@@ -1854,13 +1854,13 @@ var _ = Describe("Job", func() {
 
 			BeforeEach(func() {
 				var err error
-				newBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+				newBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				rerunBuild, err = job.RerunBuild(newBuild, defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
-				newerBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+				newerBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(rerunBuild.ID()).NotTo(BeZero())
@@ -1889,10 +1889,10 @@ var _ = Describe("Job", func() {
 
 			BeforeEach(func() {
 				var err error
-				newBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+				newBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
-				newerBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+				newerBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				rerunBuild3, err = job.RerunBuild(newerBuild, defaultBuildCreatedBy)
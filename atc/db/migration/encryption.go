@@ -9,20 +9,27 @@ import (
 )
 
 var encryptedColumns = []encryptedColumn{
-	{"teams", "legacy_auth", "id"},
-	{"resources", "config", "id"},
-	{"jobs", "config", "id"},
-	{"resource_types", "config", "id"},
-	{"prototypes", "config", "id"},
-	{"builds", "private_plan", "id"},
-	{"cert_cache", "cert", "domain"},
-	{"pipelines", "var_sources", "id"},
+	{"teams", "legacy_auth", "id", "nonce"},
+	{"teams", "notifiers", "id", "notifiers_nonce"},
+	{"resources", "config", "id", "nonce"},
+	{"jobs", "config", "id", "nonce"},
+	{"resource_types", "config", "id", "nonce"},
+	{"prototypes", "config", "id", "nonce"},
+	{"builds", "private_plan", "id", "nonce"},
+	{"cert_cache", "cert", "domain", "nonce"},
+	{"pipelines", "var_sources", "id", "nonce"},
+	{"pipelines", "notifiers", "id", "notifiers_nonce"},
 }
 
 type encryptedColumn struct {
 	Table      string
 	Column     string
 	PrimaryKey string
+
+	// Nonce is the column holding the nonce of the encrypted column, which
+	// is kept apart from the nonce of other columns of the table as a nonce
+	// must never be reused.
+	Nonce string
 }
 
 func (m migrator) encryptPlaintext(key *encryption.Key) error {
@@ -31,7 +38,7 @@ func (m migrator) encryptPlaintext(key *encryption.Key) error {
 		rows, err := m.db.Query(`
 			SELECT ` + ec.PrimaryKey + `, ` + ec.Column + `
 			FROM ` + ec.Table + `
-			WHERE nonce IS NULL
+			WHERE ` + ec.Nonce + ` IS NULL
 			AND ` + ec.Column + ` IS NOT NULL
 		`)
 		if err != nil {
@@ -72,7 +79,7 @@ func (m migrator) encryptPlaintext(key *encryption.Key) error {
 
 			_, err = m.db.Exec(`
 				UPDATE `+ec.Table+`
-				SET `+ec.Column+` = $1, nonce = $2
+				SET `+ec.Column+` = $1, `+ec.Nonce+` = $2
 				WHERE `+ec.PrimaryKey+` = $3
 			`, encrypted, nonce, primaryKey)
 			if err != nil {
@@ -97,9 +104,9 @@ func (m migrator) decryptToPlaintext(oldKey *encryption.Key) error {
 	logger := m.logger.Session("decrypt")
 	for _, ec := range encryptedColumns {
 		rows, err := m.db.Query(`
-			SELECT ` + ec.PrimaryKey + `, nonce, ` + ec.Column + `
+			SELECT ` + ec.PrimaryKey + `, ` + ec.Nonce + `, ` + ec.Column + `
 			FROM ` + ec.Table + `
-			WHERE nonce IS NOT NULL
+			WHERE ` + ec.Nonce + ` IS NOT NULL
 		`)
 		if err != nil {
 			return err
@@ -135,7 +142,7 @@ func (m migrator) decryptToPlaintext(oldKey *encryption.Key) error {
 
 			_, err = m.db.Exec(`
 				UPDATE `+ec.Table+`
-				SET `+ec.Column+` = $1, nonce = NULL
+				SET `+ec.Column+` = $1, `+ec.Nonce+` = NULL
 				WHERE `+ec.PrimaryKey+` = $2
 			`, decrypted, primaryKey)
 			if err != nil {
@@ -162,9 +169,9 @@ func (m migrator) encryptWithNewKey(newKey *encryption.Key, oldKey *encryption.K
 	logger := m.logger.Session("rotate")
 	for _, ec := range encryptedColumns {
 		rows, err := m.db.Query(`
-			SELECT ` + ec.PrimaryKey + `, nonce, ` + ec.Column + `
+			SELECT ` + ec.PrimaryKey + `, ` + ec.Nonce + `, ` + ec.Column + `
 			FROM ` + ec.Table + `
-			WHERE nonce IS NOT NULL
+			WHERE ` + ec.Nonce + ` IS NOT NULL
 		`)
 		if err != nil {
 			return err
@@ -212,7 +219,7 @@ func (m migrator) encryptWithNewKey(newKey *encryption.Key, oldKey *encryption.K
 
 			_, err = m.db.Exec(`
 				UPDATE `+ec.Table+`
-				SET `+ec.Column+` = $1, nonce = $2
+				SET `+ec.Column+` = $1, `+ec.Nonce+` = $2
 				WHERE `+ec.PrimaryKey+` = $3
 			`, encrypted, newNonce, primaryKey)
 			if err != nil {
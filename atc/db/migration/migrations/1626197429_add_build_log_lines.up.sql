@@ -0,0 +1,11 @@
+CREATE TABLE build_log_lines (
+    id bigserial PRIMARY KEY,
+    build_id bigint NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    origin text NOT NULL,
+    "time" timestamp with time zone NOT NULL,
+    line text NOT NULL
+);
+
+CREATE INDEX build_log_lines_build_id_idx ON build_log_lines (build_id);
+
+CREATE INDEX build_log_lines_line_idx ON build_log_lines USING gin (to_tsvector('simple', line));
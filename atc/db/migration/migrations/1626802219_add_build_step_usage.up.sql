@@ -0,0 +1,12 @@
+CREATE TABLE build_step_usage (
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id text NOT NULL,
+    step_name text NOT NULL,
+    cpu_average double precision NOT NULL,
+    cpu_peak double precision NOT NULL,
+    memory_average bigint NOT NULL,
+    memory_peak bigint NOT NULL,
+    samples integer NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (build_id, plan_id)
+);
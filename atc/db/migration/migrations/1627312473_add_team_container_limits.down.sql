@@ -0,0 +1 @@
+ALTER TABLE teams DROP COLUMN container_limits;
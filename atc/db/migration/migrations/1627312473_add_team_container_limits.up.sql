@@ -0,0 +1 @@
+ALTER TABLE teams ADD COLUMN container_limits json;
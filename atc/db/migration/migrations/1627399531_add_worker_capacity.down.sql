@@ -0,0 +1,5 @@
+ALTER TABLE workers
+  DROP COLUMN capacity,
+  DROP COLUMN allocated_cpu,
+  DROP COLUMN allocated_memory,
+  DROP COLUMN allocated_disk;
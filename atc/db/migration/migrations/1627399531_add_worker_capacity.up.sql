@@ -0,0 +1,5 @@
+ALTER TABLE workers
+  ADD COLUMN capacity json,
+  ADD COLUMN allocated_cpu bigint NOT NULL DEFAULT 0,
+  ADD COLUMN allocated_memory bigint NOT NULL DEFAULT 0,
+  ADD COLUMN allocated_disk bigint NOT NULL DEFAULT 0;
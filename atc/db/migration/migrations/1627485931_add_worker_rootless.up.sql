@@ -0,0 +1,2 @@
+ALTER TABLE workers
+  ADD COLUMN rootless boolean NOT NULL DEFAULT false;
@@ -0,0 +1,2 @@
+ALTER TABLE workers
+  DROP COLUMN security_allow_list;
@@ -0,0 +1,2 @@
+ALTER TABLE workers
+  ADD COLUMN security_allow_list json;
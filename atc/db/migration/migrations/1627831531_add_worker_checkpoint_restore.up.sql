@@ -0,0 +1,2 @@
+ALTER TABLE workers
+  ADD COLUMN checkpoint_restore boolean NOT NULL DEFAULT false;
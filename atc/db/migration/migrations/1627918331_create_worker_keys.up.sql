@@ -0,0 +1,10 @@
+CREATE TABLE worker_keys (
+    id serial PRIMARY KEY,
+    team_id integer REFERENCES teams (id) ON DELETE CASCADE,
+    public_key text NOT NULL,
+    fingerprint text NOT NULL UNIQUE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_used_at timestamp with time zone
+);
+
+CREATE INDEX worker_keys_team_id_idx ON worker_keys (team_id);
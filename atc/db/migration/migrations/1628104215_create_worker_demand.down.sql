@@ -0,0 +1 @@
+DROP TABLE worker_demand;
@@ -0,0 +1,11 @@
+CREATE TABLE worker_demand (
+    atc_id text NOT NULL,
+    platform text NOT NULL,
+    tags jsonb NOT NULL DEFAULT '[]',
+    team_id integer REFERENCES teams (id) ON DELETE CASCADE,
+    steps_waiting integer NOT NULL DEFAULT 0,
+    no_fit integer NOT NULL DEFAULT 0,
+    reported_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX worker_demand_atc_id_idx ON worker_demand (atc_id);
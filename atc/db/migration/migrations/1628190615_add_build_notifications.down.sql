@@ -0,0 +1,5 @@
+DROP TABLE build_notifications;
+
+ALTER TABLE teams DROP COLUMN notifiers;
+
+ALTER TABLE pipelines DROP COLUMN notifiers;
@@ -0,0 +1,25 @@
+ALTER TABLE pipelines ADD COLUMN notifiers jsonb;
+
+ALTER TABLE teams ADD COLUMN notifiers jsonb;
+
+CREATE TABLE build_notifications (
+    id serial PRIMARY KEY,
+    build_id bigint NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    notifier text NOT NULL,
+    source text NOT NULL,
+    event text NOT NULL,
+    status text NOT NULL DEFAULT 'pending',
+    attempts integer NOT NULL DEFAULT 0,
+    response_status integer,
+    last_error text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    next_attempt_at timestamp with time zone NOT NULL DEFAULT now(),
+    delivered_at timestamp with time zone
+);
+
+CREATE UNIQUE INDEX build_notifications_uniq ON build_notifications (build_id, source, notifier, event);
+
+CREATE INDEX build_notifications_team_id_idx ON build_notifications (team_id);
+
+CREATE INDEX build_notifications_pending_idx ON build_notifications (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,12 @@
+CREATE TABLE build_step_timings (
+    id serial PRIMARY KEY,
+    build_id bigint NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id text NOT NULL,
+    step_name text NOT NULL,
+    phase text NOT NULL,
+    start_time timestamp with time zone NOT NULL,
+    duration double precision NOT NULL,
+    bytes bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX build_step_timings_build_id_idx ON build_step_timings (build_id);
@@ -0,0 +1,5 @@
+DROP INDEX builds_flaky_idx;
+
+ALTER TABLE builds DROP COLUMN tolerated_failure;
+
+ALTER TABLE builds DROP COLUMN flaky;
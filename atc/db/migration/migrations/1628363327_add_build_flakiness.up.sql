@@ -0,0 +1,5 @@
+ALTER TABLE builds ADD COLUMN flaky boolean NOT NULL DEFAULT false;
+
+ALTER TABLE builds ADD COLUMN tolerated_failure boolean;
+
+CREATE INDEX builds_flaky_idx ON builds (job_id, end_time) WHERE flaky;
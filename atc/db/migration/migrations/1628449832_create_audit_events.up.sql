@@ -0,0 +1,21 @@
+CREATE TABLE audit_events (
+    id serial PRIMARY KEY,
+    time timestamp with time zone NOT NULL DEFAULT now(),
+    action text NOT NULL,
+    user_name text NOT NULL,
+    team_name text,
+    pipeline_name text,
+    pipeline_instance_vars jsonb,
+    request_id text,
+    status integer NOT NULL,
+    diff text,
+    drained boolean NOT NULL DEFAULT false
+);
+
+CREATE INDEX audit_events_time_idx ON audit_events (time);
+
+CREATE INDEX audit_events_user_name_time_idx ON audit_events (user_name, time);
+
+CREATE INDEX audit_events_action_time_idx ON audit_events (action, time);
+
+CREATE INDEX audit_events_undrained_idx ON audit_events (id) WHERE NOT drained;
@@ -0,0 +1,3 @@
+ALTER TABLE build_step_usage
+    DROP COLUMN block_io_read,
+    DROP COLUMN block_io_write;
@@ -0,0 +1,3 @@
+ALTER TABLE build_step_usage
+    ADD COLUMN block_io_read bigint NOT NULL DEFAULT 0,
+    ADD COLUMN block_io_write bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE workers
+  ADD COLUMN network_config boolean NOT NULL DEFAULT false;
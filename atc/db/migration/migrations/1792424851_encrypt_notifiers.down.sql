@@ -0,0 +1,12 @@
+-- encrypted notifiers can't be decrypted here, and would not be valid json
+UPDATE pipelines SET notifiers = NULL WHERE notifiers_nonce IS NOT NULL;
+
+UPDATE teams SET notifiers = NULL WHERE notifiers_nonce IS NOT NULL;
+
+ALTER TABLE pipelines
+  DROP COLUMN notifiers_nonce,
+  ALTER COLUMN notifiers TYPE jsonb USING notifiers::jsonb;
+
+ALTER TABLE teams
+  DROP COLUMN notifiers_nonce,
+  ALTER COLUMN notifiers TYPE jsonb USING notifiers::jsonb;
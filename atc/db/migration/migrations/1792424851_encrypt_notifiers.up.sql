@@ -0,0 +1,7 @@
+ALTER TABLE pipelines
+  ALTER COLUMN notifiers TYPE text,
+  ADD COLUMN notifiers_nonce text;
+
+ALTER TABLE teams
+  ALTER COLUMN notifiers TYPE text,
+  ADD COLUMN notifiers_nonce text;
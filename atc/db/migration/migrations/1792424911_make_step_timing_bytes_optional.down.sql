@@ -0,0 +1,5 @@
+UPDATE build_step_timings SET bytes = 0 WHERE bytes IS NULL;
+
+ALTER TABLE build_step_timings
+  ALTER COLUMN bytes SET DEFAULT 0,
+  ALTER COLUMN bytes SET NOT NULL;
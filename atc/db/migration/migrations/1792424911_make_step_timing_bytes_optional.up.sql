@@ -0,0 +1,6 @@
+ALTER TABLE build_step_timings
+  ALTER COLUMN bytes DROP NOT NULL,
+  ALTER COLUMN bytes DROP DEFAULT;
+
+-- bytes were 0 when none were counted
+UPDATE build_step_timings SET bytes = NULL WHERE bytes = 0;
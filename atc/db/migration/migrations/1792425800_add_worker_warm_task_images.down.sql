@@ -0,0 +1,2 @@
+ALTER TABLE workers
+  DROP COLUMN warm_task_images;
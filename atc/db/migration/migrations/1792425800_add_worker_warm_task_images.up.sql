@@ -0,0 +1,2 @@
+ALTER TABLE workers
+  ADD COLUMN warm_task_images boolean NOT NULL DEFAULT false;
@@ -64,6 +64,7 @@ type Pipeline interface {
 	Groups() atc.GroupConfigs
 	VarSources() atc.VarSourceConfigs
 	Display() *atc.DisplayConfig
+	Notifiers() atc.NotifierConfigs
 	ConfigVersion() ConfigVersion
 	Config() (atc.Config, error)
 	Public() bool
@@ -133,6 +134,7 @@ type pipeline struct {
 	groups        atc.GroupConfigs
 	varSources    atc.VarSourceConfigs
 	display       *atc.DisplayConfig
+	notifiers     atc.NotifierConfigs
 	configVersion ConfigVersion
 	paused        bool
 	public        bool
@@ -152,6 +154,8 @@ var pipelinesQuery = psql.Select(`
 		p.groups,
 		p.var_sources,
 		p.display,
+		p.notifiers,
+		p.notifiers_nonce,
 		p.nonce,
 		p.version,
 		p.team_id,
@@ -185,6 +189,7 @@ func (p *pipeline) Groups() atc.GroupConfigs       { return p.groups }
 
 func (p *pipeline) VarSources() atc.VarSourceConfigs { return p.varSources }
 func (p *pipeline) Display() *atc.DisplayConfig      { return p.display }
+func (p *pipeline) Notifiers() atc.NotifierConfigs   { return p.notifiers }
 func (p *pipeline) ConfigVersion() ConfigVersion     { return p.configVersion }
 func (p *pipeline) Public() bool                     { return p.public }
 func (p *pipeline) Paused() bool                     { return p.paused }
@@ -257,6 +262,7 @@ func (p *pipeline) Config() (atc.Config, error) {
 		Prototypes:    prototypes.Configs(),
 		Jobs:          jobConfigs,
 		Display:       p.Display(),
+		Notifiers:     p.Notifiers(),
 	}
 
 	return config, nil
@@ -986,6 +992,14 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 		return err
 	}
 
+	_, err = tx.Exec(`
+		DELETE FROM build_log_lines
+		WHERE build_id IN (`+strings.Join(indexStrings, ",")+`)
+	`, interfaceBuildIDs...)
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(`
 		UPDATE builds
 		SET reap_time = now()
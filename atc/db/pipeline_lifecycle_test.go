@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/concourse/concourse/atc"
@@ -32,7 +33,7 @@ var _ = Describe("PipelineLifecycle", func() {
 			)
 
 			BeforeEach(func() {
-				build, _ := defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build, _ := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				childPipeline, _, _ = build.SavePipeline(atc.PipelineRef{Name: "child-pipeline"}, defaultTeam.ID(), defaultPipelineConfig, db.ConfigVersion(0), false)
 				build.Finish(db.BuildStatusSucceeded)
 			})
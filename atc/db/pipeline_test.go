@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"time"
@@ -767,7 +768,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(versions.Jobs).To(ConsistOf(jobs))
 
 				By("including outputs of successful builds")
-				build1DB, err := scenarioPipeline1.Job("a-job").CreateBuild(defaultBuildCreatedBy)
+				build1DB, err := scenarioPipeline1.Job("a-job").CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = build1DB.SaveOutput("some-type", atc.Source{"source-config": "some-value"}, atc.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-resource")
@@ -818,7 +819,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(versions.Jobs).To(ConsistOf(jobs))
 
 				By("not including outputs of failed builds")
-				build2DB, err := scenarioPipeline1.Job("a-job").CreateBuild(defaultBuildCreatedBy)
+				build2DB, err := scenarioPipeline1.Job("a-job").CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = build2DB.SaveOutput("some-type", atc.Source{"source-config": "some-value"}, atc.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-resource")
@@ -867,7 +868,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(versions.Jobs).To(ConsistOf(jobs))
 
 				By("not including outputs of builds in other pipelines")
-				otherPipelineBuild, err := scenarioPipeline1.Job("a-job").CreateBuild(defaultBuildCreatedBy)
+				otherPipelineBuild, err := scenarioPipeline1.Job("a-job").CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = otherPipelineBuild.SaveOutput("some-type", atc.Source{"other-source-config": "some-other-value"}, atc.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-other-resource")
@@ -948,7 +949,7 @@ var _ = Describe("Pipeline", func() {
 					}}, true)
 				Expect(err).ToNot(HaveOccurred())
 
-				build1DB, err = scenarioPipeline1.Job("a-job").CreateBuild(defaultBuildCreatedBy)
+				build1DB, err = scenarioPipeline1.Job("a-job").CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				_, found, err = build1DB.AdoptInputsAndPipes()
@@ -1106,7 +1107,7 @@ var _ = Describe("Pipeline", func() {
 			)
 
 			By("populating builds")
-			build, err := scenario.Job("some-job").CreateBuild(defaultBuildCreatedBy)
+			build, err := scenario.Job("some-job").CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			By("populating build inputs")
@@ -1221,7 +1222,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			firstJobBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+			firstJobBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			actualDashboard, err = pipeline.Dashboard()
@@ -1251,7 +1252,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			secondJobBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+			secondJobBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			actualDashboard, err = pipeline.Dashboard()
@@ -1469,15 +1470,15 @@ var _ = Describe("Pipeline", func() {
 				builder.WithResourceVersions("some-resource", atc.Version{"version": "v1"}),
 			)
 
-			build, err := scenario.Job("job-name").CreateBuild(defaultBuildCreatedBy)
+			build, err := scenario.Job("job-name").CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build.ID())
 
-			secondBuild, err := scenario.Job("job-name").CreateBuild(defaultBuildCreatedBy)
+			secondBuild, err := scenario.Job("job-name").CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild.ID())
 
-			_, err = scenario.Job("some-other-job").CreateBuild(defaultBuildCreatedBy)
+			_, err = scenario.Job("some-other-job").CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			dbBuild, found, err := buildFactory.Build(build.ID())
@@ -1627,15 +1628,15 @@ var _ = Describe("Pipeline", func() {
 				builder.WithResourceVersions("some-resource", atc.Version{"version": "v3"}, atc.Version{"version": "v4"}),
 			)
 
-			build, err := scenario.Job("job-name").CreateBuild(defaultBuildCreatedBy)
+			build, err := scenario.Job("job-name").CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err = scenario.Job("job-name").CreateBuild(defaultBuildCreatedBy)
+			secondBuild, err = scenario.Job("job-name").CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
-			_, err = scenario.Job("some-other-job").CreateBuild(defaultBuildCreatedBy)
+			_, err = scenario.Job("some-other-job").CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			dbBuild, found, err := buildFactory.Build(build.ID())
@@ -1717,11 +1718,11 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild(defaultBuildCreatedBy)
+			build, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err := job.CreateBuild(defaultBuildCreatedBy)
+			secondBuild, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
@@ -1729,7 +1730,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			thirdBuild, err := someOtherJob.CreateBuild(defaultBuildCreatedBy)
+			thirdBuild, err := someOtherJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, thirdBuild)
 		})
@@ -1950,7 +1951,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(found).To(BeTrue())
 
 			for i := range builds {
-				builds[i], err = job.CreateBuild(defaultBuildCreatedBy)
+				builds[i], err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				buildStart := time.Date(2020, 11, i+1, 0, 0, 0, 0, time.UTC)
@@ -1969,7 +1970,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			_, err = otherJob.CreateBuild(defaultBuildCreatedBy)
+			_, err = otherJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -29,7 +30,7 @@ var _ = Describe("ResourceCacheLifecycle", func() {
 			}
 
 			resourceCacheForJobBuild := func() (db.UsedResourceCache, db.Build) {
-				build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				return createResourceCacheWithUser(db.ForBuild(build.ID())), build
 			}
@@ -179,7 +180,7 @@ var _ = Describe("ResourceCacheLifecycle", func() {
 							By("creating an image resource cache tied to the job in the second pipeline")
 							job, _, err := secondPipeline.Job("some-job")
 							Expect(err).ToNot(HaveOccurred())
-							build, err := job.CreateBuild(defaultBuildCreatedBy)
+							build, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 							Expect(err).ToNot(HaveOccurred())
 							resourceCache := createResourceCacheWithUser(db.ForBuild(build.ID()))
 
@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -19,7 +20,7 @@ var _ = Describe("ResourceConfigFactory", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(found).To(BeTrue())
 
-		build, err = job.CreateBuild(defaultBuildCreatedBy)
+		build, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
@@ -58,6 +58,9 @@ type Team interface {
 	PrivateAndPublicBuilds(Page) ([]Build, Pagination, error)
 	Builds(page Page) ([]Build, Pagination, error)
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
+	SearchBuildLogs(search BuildLogSearch, page Page) ([]atc.BuildLogMatch, Pagination, error)
+	BuildStats(filter BuildStatsFilter) (atc.BuildStats, error)
+	FlakyJobs(filter BuildStatsFilter) ([]atc.FlakyJob, error)
 
 	SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error)
 	Workers() ([]Worker, error)
@@ -76,6 +79,13 @@ type Team interface {
 	FindWorkersForResourceCache(rcId int) ([]Worker, error)
 
 	UpdateProviderAuth(auth atc.TeamAuth) error
+
+	// Update saves the auth of the team, along with its container limits and
+	// notifiers if they're given, in a single transaction.
+	Update(config atc.Team) error
+
+	ContainerLimits() atc.ContainerLimits
+	Notifiers() atc.NotifierConfigs
 }
 
 type team struct {
@@ -87,6 +97,9 @@ type team struct {
 	admin bool
 
 	auth atc.TeamAuth
+
+	containerLimits atc.ContainerLimits
+	notifiers       atc.NotifierConfigs
 }
 
 func (t *team) ID() int      { return t.id }
@@ -95,6 +108,10 @@ func (t *team) Admin() bool  { return t.admin }
 
 func (t *team) Auth() atc.TeamAuth { return t.auth }
 
+func (t *team) ContainerLimits() atc.ContainerLimits { return t.containerLimits }
+
+func (t *team) Notifiers() atc.NotifierConfigs { return t.notifiers }
+
 func (t *team) Delete() error {
 	_, err := psql.Delete("teams").
 		Where(sq.Eq{
@@ -450,6 +467,11 @@ func savePipeline(
 		return 0, false, err
 	}
 
+	encryptedNotifiersPayload, notifiersNonce, err := encryptNotifiers(tx.EncryptionStrategy(), config.Notifiers)
+	if err != nil {
+		return 0, false, err
+	}
+
 	var pipelineID int
 	if !existingConfig {
 		values := map[string]interface{}{
@@ -457,6 +479,8 @@ func savePipeline(
 			"groups":          groupsPayload,
 			"var_sources":     encryptedVarSourcesPayload,
 			"display":         displayPayload,
+			"notifiers":       encryptedNotifiersPayload,
+			"notifiers_nonce": notifiersNonce,
 			"nonce":           nonce,
 			"version":         sq.Expr("nextval('config_version_seq')"),
 			"paused":          initiallyPaused,
@@ -501,6 +525,8 @@ func savePipeline(
 			Set("groups", groupsPayload).
 			Set("var_sources", encryptedVarSourcesPayload).
 			Set("display", displayPayload).
+			Set("notifiers", encryptedNotifiersPayload).
+			Set("notifiers_nonce", notifiersNonce).
 			Set("nonce", nonce).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
 			Set("last_updated", sq.Expr("now()")).
@@ -929,6 +955,18 @@ func (t *team) Builds(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithPagination(buildsQuery.Where(sq.Eq{"t.id": t.id}), minMaxIdQuery, page, t.conn, t.lockFactory)
 }
 
+func (t *team) SearchBuildLogs(search BuildLogSearch, page Page) ([]atc.BuildLogMatch, Pagination, error) {
+	return searchBuildLogs(t.conn, t.id, search, page)
+}
+
+func (t *team) BuildStats(filter BuildStatsFilter) (atc.BuildStats, error) {
+	return buildStats(t.conn, t.id, filter)
+}
+
+func (t *team) FlakyJobs(filter BuildStatsFilter) ([]atc.FlakyJob, error) {
+	return flakyJobs(t.conn, t.id, filter)
+}
+
 func (t *team) SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -966,7 +1004,7 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 		UPDATE teams
 		SET auth = $1, legacy_auth = NULL, nonce = NULL
 		WHERE id = $2
-		RETURNING id, name, admin, auth, nonce
+		RETURNING id, name, admin, auth, nonce, container_limits, notifiers, notifiers_nonce
 	`
 	err = t.queryTeam(tx, query, jsonEncodedProviderAuth, t.id)
 	if err != nil {
@@ -976,6 +1014,59 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 	return tx.Commit()
 }
 
+func (t *team) Update(config atc.Team) error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer Rollback(tx)
+
+	jsonEncodedProviderAuth, err := json.Marshal(config.Auth)
+	if err != nil {
+		return err
+	}
+
+	update := psql.Update("teams").
+		Set("auth", jsonEncodedProviderAuth).
+		Set("legacy_auth", nil).
+		Set("nonce", nil)
+
+	if config.ContainerLimits != nil {
+		jsonEncodedLimits, err := json.Marshal(config.ContainerLimits)
+		if err != nil {
+			return err
+		}
+
+		update = update.Set("container_limits", jsonEncodedLimits)
+	}
+
+	if config.Notifiers != nil {
+		encryptedNotifiers, nonce, err := encryptNotifiers(tx.EncryptionStrategy(), config.Notifiers)
+		if err != nil {
+			return err
+		}
+
+		update = update.
+			Set("notifiers", encryptedNotifiers).
+			Set("notifiers_nonce", nonce)
+	}
+
+	query, args, err := update.
+		Where(sq.Eq{"id": t.id}).
+		Suffix("RETURNING id, name, admin, auth, nonce, container_limits, notifiers, notifiers_nonce").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	err = t.queryTeam(tx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (t *team) FindCheckContainers(logger lager.Logger, pipelineRef atc.PipelineRef, resourceName string, secretManager creds.Secrets, varSourcePool creds.VarSourcePool) ([]Container, map[int]time.Time, error) {
 	pipeline, found, err := t.Pipeline(pipelineRef)
 	if err != nil {
@@ -1416,17 +1507,19 @@ func (t *team) findContainer(whereClause sq.Sqlizer) (CreatingContainer, Created
 
 func scanPipeline(p *pipeline, scan scannable) error {
 	var (
-		groups        sql.NullString
-		varSources    sql.NullString
-		display       sql.NullString
-		nonce         sql.NullString
-		nonceStr      *string
-		lastUpdated   pq.NullTime
-		parentJobID   sql.NullInt64
-		parentBuildID sql.NullInt64
-		instanceVars  sql.NullString
+		groups         sql.NullString
+		varSources     sql.NullString
+		display        sql.NullString
+		notifiers      sql.NullString
+		notifiersNonce sql.NullString
+		nonce          sql.NullString
+		nonceStr       *string
+		lastUpdated    pq.NullTime
+		parentJobID    sql.NullInt64
+		parentBuildID  sql.NullInt64
+		instanceVars   sql.NullString
 	)
-	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars)
+	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &notifiers, &notifiersNonce, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars)
 	if err != nil {
 		return err
 	}
@@ -1459,6 +1552,11 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		p.display = displayConfig
 	}
 
+	p.notifiers, err = decryptNotifiers(p.conn.EncryptionStrategy(), notifiers, notifiersNonce)
+	if err != nil {
+		return err
+	}
+
 	if varSources.Valid {
 		var pipelineVarSources atc.VarSourceConfigs
 		decryptedVarSource, err := p.conn.EncryptionStrategy().Decrypt(varSources.String, nonceStr)
@@ -1530,7 +1628,7 @@ func scanContainers(rows *sql.Rows, conn Conn, initContainers []Container) ([]Co
 }
 
 func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
-	var providerAuth, nonce sql.NullString
+	var providerAuth, nonce, containerLimits, notifiers, notifiersNonce sql.NullString
 
 	err := tx.QueryRow(query, params...).Scan(
 		&t.id,
@@ -1538,11 +1636,27 @@ func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
 		&t.admin,
 		&providerAuth,
 		&nonce,
+		&containerLimits,
+		&notifiers,
+		&notifiersNonce,
 	)
 	if err != nil {
 		return err
 	}
 
+	t.notifiers, err = decryptNotifiers(t.conn.EncryptionStrategy(), notifiers, notifiersNonce)
+	if err != nil {
+		return err
+	}
+
+	t.containerLimits = atc.ContainerLimits{}
+	if containerLimits.Valid {
+		err = json.Unmarshal([]byte(containerLimits.String), &t.containerLimits)
+		if err != nil {
+			return err
+		}
+	}
+
 	if providerAuth.Valid {
 		var auth atc.TeamAuth
 		err = json.Unmarshal([]byte(providerAuth.String), &auth)
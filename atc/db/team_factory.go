@@ -51,10 +51,23 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	var containerLimits []byte
+	if t.ContainerLimits != nil {
+		containerLimits, err = json.Marshal(t.ContainerLimits)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	notifiers, notifiersNonce, err := encryptNotifiers(tx.EncryptionStrategy(), t.Notifiers)
+	if err != nil {
+		return nil, err
+	}
+
 	row := psql.Insert("teams").
-		Columns("name, auth, admin").
-		Values(t.Name, auth, admin).
-		Suffix("RETURNING id, name, admin, auth").
+		Columns("name, auth, admin, container_limits, notifiers, notifiers_nonce").
+		Values(t.Name, auth, admin, containerLimits, notifiers, notifiersNonce).
+		Suffix("RETURNING id, name, admin, auth, container_limits, notifiers, notifiers_nonce").
 		RunWith(tx).
 		QueryRow()
 
@@ -90,7 +103,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth").
+	row := psql.Select("id, name, admin, auth, container_limits, notifiers, notifiers_nonce").
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -109,7 +122,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth").
+	rows, err := psql.Select("id, name, admin, auth, container_limits, notifiers, notifiers_nonce").
 		From("teams").
 		OrderBy("name ASC").
 		RunWith(factory.conn).
@@ -175,13 +188,16 @@ func (factory *teamFactory) NotifyCacher() error {
 }
 
 func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
-	var providerAuth sql.NullString
+	var providerAuth, containerLimits, notifiers, notifiersNonce sql.NullString
 
 	err := rows.Scan(
 		&t.id,
 		&t.name,
 		&t.admin,
 		&providerAuth,
+		&containerLimits,
+		&notifiers,
+		&notifiersNonce,
 	)
 
 	if providerAuth.Valid {
@@ -191,5 +207,19 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		}
 	}
 
+	if containerLimits.Valid {
+		err = json.Unmarshal([]byte(containerLimits.String), &t.containerLimits)
+		if err != nil {
+			return err
+		}
+	}
+
+	if notifiers.Valid {
+		t.notifiers, err = decryptNotifiers(factory.conn.EncryptionStrategy(), notifiers, notifiersNonce)
+		if err != nil {
+			return err
+		}
+	}
+
 	return err
 }
@@ -287,7 +287,7 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild(defaultBuildCreatedBy)
+			build, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(build.CreatedBy()).ToNot(BeNil())
 			Expect(*build.CreatedBy()).To(Equal(defaultBuildCreatedBy))
@@ -480,7 +480,7 @@ var _ = Describe("Team", func() {
 					builder.WithPrototypeVersions("some-prototype"),
 				)
 
-				build, err := scenario.Job("some-job").CreateBuild(defaultBuildCreatedBy)
+				build, err := scenario.Job("some-job").CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				firstContainerCreating, err = scenario.Workers[0].CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-job"), scenario.Team.ID()), db.ContainerMetadata{Type: "task", StepName: "some-task"})
@@ -660,7 +660,7 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild(defaultBuildCreatedBy)
+			build, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-job"), defaultTeam.ID()), db.ContainerMetadata{Type: "task", StepName: "some-task"})
@@ -970,6 +970,75 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("Update", func() {
+		var (
+			limits    atc.ContainerLimits
+			notifiers atc.NotifierConfigs
+			config    atc.Team
+		)
+
+		BeforeEach(func() {
+			disk := atc.DiskLimit(1024 * 1024)
+			memory := atc.MemoryLimit(1024)
+			limits = atc.ContainerLimits{Memory: &memory, Disk: &disk}
+
+			notifiers = atc.NotifierConfigs{{
+				Name: "chat",
+				Type: atc.NotifierTypeSlack,
+				URL:  "https://hooks.example.com/chat",
+			}}
+
+			config = atc.Team{
+				Auth: atc.TeamAuth{
+					"owner": {"users": []string{"local:username"}},
+				},
+				ContainerLimits: &limits,
+				Notifiers:       notifiers,
+			}
+		})
+
+		It("saves the auth, container limits and notifiers of the team", func() {
+			err := team.Update(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(team.Auth()).To(Equal(config.Auth))
+			Expect(team.ContainerLimits()).To(Equal(limits))
+			Expect(team.Notifiers()).To(Equal(notifiers))
+
+			reloaded, found, err := teamFactory.FindTeam(team.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloaded.ContainerLimits()).To(Equal(limits))
+			Expect(reloaded.Notifiers()).To(Equal(notifiers))
+		})
+
+		It("leaves the container limits and notifiers as they are when they aren't given", func() {
+			err := team.Update(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = team.Update(atc.Team{Auth: config.Auth})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(team.ContainerLimits()).To(Equal(limits))
+			Expect(team.Notifiers()).To(Equal(notifiers))
+		})
+
+		It("clears the container limits and notifiers when given empty ones", func() {
+			err := team.Update(config)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = team.Update(atc.Team{
+				Auth:            config.Auth,
+				ContainerLimits: &atc.ContainerLimits{},
+				Notifiers:       atc.NotifierConfigs{},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(team.ContainerLimits()).To(Equal(atc.ContainerLimits{}))
+			Expect(team.Notifiers()).To(BeEmpty())
+		})
+	})
+
 	Describe("Pipelines", func() {
 		var (
 			pipelines []db.Pipeline
@@ -1293,7 +1362,7 @@ var _ = Describe("Team", func() {
 				Expect(found).To(BeTrue())
 
 				for i := 3; i < 5; i++ {
-					build, err := job.CreateBuild(defaultBuildCreatedBy)
+					build, err := job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 					allBuilds[i] = build
 					pipelineBuilds[i-3] = build
@@ -1444,7 +1513,7 @@ var _ = Describe("Team", func() {
 			Expect(found).To(BeTrue())
 
 			for i := range builds {
-				builds[i], err = job.CreateBuild(defaultBuildCreatedBy)
+				builds[i], err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				buildStart := time.Date(2020, 11, i+1, 0, 0, 0, 0, time.UTC)
@@ -1549,11 +1618,11 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err = job.CreateBuild(defaultBuildCreatedBy)
+			build, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+			secondBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
@@ -1561,7 +1630,7 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			thirdBuild, err = someOtherJob.CreateBuild(defaultBuildCreatedBy)
+			thirdBuild, err = someOtherJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, thirdBuild)
 		})
@@ -3509,7 +3578,7 @@ var _ = Describe("Team", func() {
 				)
 			})()
 
-			build, err := defaultJob.CreateBuild("some-user")
+			build, err := defaultJob.CreateBuild(context.TODO(), "some-user")
 			Expect(err).ToNot(HaveOccurred())
 
 			rt, err := scenario.Pipeline.ResourceTypes()
@@ -3828,7 +3897,7 @@ var _ = Describe("Team", func() {
 					builder.WithBaseWorker(),
 				)
 
-				build, err := scenario.Job("some-job").CreateBuild(defaultBuildCreatedBy)
+				build, err := scenario.Job("some-job").CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				creatingContainer, err := scenario.Workers[0].CreateContainer(
@@ -3950,7 +4019,7 @@ var _ = Describe("Team", func() {
 					builder.WithBaseWorker(),
 				)
 
-				build, err := scenario.Job("some-job").CreateBuild(defaultBuildCreatedBy)
+				build, err := scenario.Job("some-job").CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				creatingContainer, err := scenario.Workers[0].CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-job"), scenario.Team.ID()), db.ContainerMetadata{Type: "task", StepName: "some-task"})
@@ -44,7 +44,7 @@ var _ = Describe("VersionsDB", func() {
 
 			BeforeEach(func() {
 				var err error
-				build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = build.Finish(db.BuildStatusSucceeded)
@@ -71,7 +71,7 @@ var _ = Describe("VersionsDB", func() {
 				builds = []db.Build{}
 
 				for i := 0; i < pageLimit; i++ {
-					build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+					build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 
 					err = build.Finish(db.BuildStatusSucceeded)
@@ -107,17 +107,17 @@ var _ = Describe("VersionsDB", func() {
 
 			BeforeEach(func() {
 				var err error
-				build1Succeeded, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build1Succeeded, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build1Succeeded.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
 
-				build2Failed, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build2Failed, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build2Failed.Finish(db.BuildStatusFailed)
 				Expect(err).ToNot(HaveOccurred())
 
-				build3Succeeded, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build3Succeeded, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build3Succeeded.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
@@ -132,13 +132,13 @@ var _ = Describe("VersionsDB", func() {
 				err = build5Rerun2Succeeded.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
 
-				build6Succeeded, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build6Succeeded, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build6Succeeded.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
 
 				for i := 0; i < pageLimit; i++ {
-					build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+					build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 
 					err = build.Finish(db.BuildStatusSucceeded)
@@ -198,17 +198,17 @@ var _ = Describe("VersionsDB", func() {
 
 			BeforeEach(func() {
 				var err error
-				build1Succeeded, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build1Succeeded, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build1Succeeded.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
 
-				build2Failed, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build2Failed, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build2Failed.Finish(db.BuildStatusFailed)
 				Expect(err).ToNot(HaveOccurred())
 
-				build3Succeeded, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build3Succeeded, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build3Succeeded.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
@@ -223,7 +223,7 @@ var _ = Describe("VersionsDB", func() {
 				err = build5Rerun2Succeeded.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
 
-				build6Succeeded, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build6Succeeded, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build6Succeeded.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
@@ -269,7 +269,7 @@ var _ = Describe("VersionsDB", func() {
 
 			BeforeEach(func() {
 				var err error
-				build1Failed, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build1Failed, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build1Failed.Finish(db.BuildStatusFailed)
 				Expect(err).ToNot(HaveOccurred())
@@ -277,7 +277,7 @@ var _ = Describe("VersionsDB", func() {
 				fillerBuilds = []db.Build{}
 
 				for i := 0; i < pageLimit-1; i++ {
-					build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+					build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 
 					err = build.Finish(db.BuildStatusSucceeded)
@@ -319,7 +319,7 @@ var _ = Describe("VersionsDB", func() {
 
 			BeforeEach(func() {
 				var err error
-				build1Succeeded, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build1Succeeded, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build1Succeeded.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
@@ -327,7 +327,7 @@ var _ = Describe("VersionsDB", func() {
 				fillerBuilds = []db.Build{}
 
 				for i := 0; i < pageLimit-1; i++ {
-					build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+					build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 
 					err = build.Finish(db.BuildStatusSucceeded)
@@ -376,7 +376,7 @@ var _ = Describe("VersionsDB", func() {
 
 			BeforeEach(func() {
 				var err error
-				build1Failed, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build1Failed, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build1Failed.Finish(db.BuildStatusFailed)
 				Expect(err).ToNot(HaveOccurred())
@@ -384,7 +384,7 @@ var _ = Describe("VersionsDB", func() {
 				fillerBuilds = []db.Build{}
 
 				for i := 0; i < pageLimit-1; i++ {
-					build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+					build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 
 					err = build.Finish(db.BuildStatusSucceeded)
@@ -459,7 +459,7 @@ var _ = Describe("VersionsDB", func() {
 
 			BeforeEach(func() {
 				var err error
-				cursorBuild, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				cursorBuild, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = cursorBuild.Finish(db.BuildStatusSucceeded)
@@ -491,7 +491,7 @@ var _ = Describe("VersionsDB", func() {
 			BeforeEach(func() {
 				olderBuilds = []db.Build{}
 				for i := 0; i < pageLimit; i++ {
-					build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+					build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 
 					err = build.Finish(db.BuildStatusSucceeded)
@@ -501,7 +501,7 @@ var _ = Describe("VersionsDB", func() {
 				}
 
 				var err error
-				cursorBuild, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				cursorBuild, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = cursorBuild.Finish(db.BuildStatusSucceeded)
@@ -513,7 +513,7 @@ var _ = Describe("VersionsDB", func() {
 
 				newerBuilds = []db.Build{}
 				for i := 0; i < pageLimit; i++ {
-					build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+					build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 
 					err = build.Finish(db.BuildStatusSucceeded)
@@ -559,7 +559,7 @@ var _ = Describe("VersionsDB", func() {
 			BeforeEach(func() {
 				olderBuilds = []db.Build{}
 				for i := 0; i < pageLimit; i++ {
-					build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+					build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 
 					err = build.Finish(db.BuildStatusSucceeded)
@@ -569,7 +569,7 @@ var _ = Describe("VersionsDB", func() {
 				}
 
 				var err error
-				cursorBuild, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				cursorBuild, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = cursorBuild.Finish(db.BuildStatusSucceeded)
@@ -581,7 +581,7 @@ var _ = Describe("VersionsDB", func() {
 
 				newerBuilds = []db.Build{}
 				for i := 0; i < pageLimit; i++ {
-					build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
+					build, err := defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 
 					err = build.Finish(db.BuildStatusSucceeded)
@@ -640,17 +640,17 @@ var _ = Describe("VersionsDB", func() {
 
 			BeforeEach(func() {
 				var err error
-				build1Succeeded, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build1Succeeded, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build1Succeeded.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
 
-				build2Failed, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build2Failed, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build2Failed.Finish(db.BuildStatusFailed)
 				Expect(err).ToNot(HaveOccurred())
 
-				build3Succeeded, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build3Succeeded, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build3Succeeded.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
@@ -678,7 +678,7 @@ var _ = Describe("VersionsDB", func() {
 				err = build6Rerun2Succeeded.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
 
-				build7Succeeded, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+				build7Succeeded, err = defaultJob.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				err = build7Succeeded.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
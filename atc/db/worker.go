@@ -68,6 +68,14 @@ type Worker interface {
 	StartTime() time.Time
 	ExpiresAt() time.Time
 	Ephemeral() bool
+	Rootless() bool
+	SecurityAllowList() *atc.SecurityAllowList
+	WarmPool() []string
+	CheckpointRestore() bool
+	NetworkConfig() bool
+	WarmTaskImages() bool
+	Capacity() *atc.WorkerResources
+	Allocated() atc.WorkerResources
 
 	Reload() (bool, error)
 
@@ -80,6 +88,9 @@ type Worker interface {
 	IncreaseActiveTasks() (int, error)
 	DecreaseActiveTasks() (int, error)
 
+	AllocateResources(atc.WorkerResources) (atc.WorkerResources, error)
+	ReleaseResources(atc.WorkerResources) (atc.WorkerResources, error)
+
 	FindContainer(owner ContainerOwner) (CreatingContainer, CreatedContainer, error)
 	CreateContainer(owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error)
 }
@@ -107,6 +118,15 @@ type worker struct {
 	expiresAt        time.Time
 	certsPath        *string
 	ephemeral        bool
+	rootless         bool
+	capacity         *atc.WorkerResources
+	allocated        atc.WorkerResources
+
+	securityAllowList *atc.SecurityAllowList
+	warmPool          []string
+	checkpointRestore bool
+	networkConfig     bool
+	warmTaskImages    bool
 }
 
 func (worker *worker) Name() string             { return worker.name }
@@ -127,10 +147,19 @@ func (worker *worker) Tags() []string                          { return worker.t
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
 func (worker *worker) Ephemeral() bool                         { return worker.ephemeral }
+func (worker *worker) Rootless() bool                          { return worker.rootless }
+func (worker *worker) Capacity() *atc.WorkerResources          { return worker.capacity }
+func (worker *worker) Allocated() atc.WorkerResources          { return worker.allocated }
 
 func (worker *worker) StartTime() time.Time { return worker.startTime }
 func (worker *worker) ExpiresAt() time.Time { return worker.expiresAt }
 
+func (worker *worker) SecurityAllowList() *atc.SecurityAllowList { return worker.securityAllowList }
+func (worker *worker) WarmPool() []string                        { return worker.warmPool }
+func (worker *worker) CheckpointRestore() bool                   { return worker.checkpointRestore }
+func (worker *worker) NetworkConfig() bool                       { return worker.networkConfig }
+func (worker *worker) WarmTaskImages() bool                      { return worker.warmTaskImages }
+
 func (worker *worker) Reload() (bool, error) {
 	row := workersQuery.Where(sq.Eq{"w.name": worker.name}).
 		RunWith(worker.conn).
@@ -411,3 +440,39 @@ func (worker *worker) DecreaseActiveTasks() (int, error) {
 	}
 	return worker.activeTasks, nil
 }
+
+// AllocateResources reserves the given resources on the worker, returning
+// the total amount allocated afterwards.
+func (worker *worker) AllocateResources(resources atc.WorkerResources) (atc.WorkerResources, error) {
+	err := psql.Update("workers").
+		Set("allocated_cpu", sq.Expr("allocated_cpu+?", resources.CPU)).
+		Set("allocated_memory", sq.Expr("allocated_memory+?", resources.Memory)).
+		Set("allocated_disk", sq.Expr("allocated_disk+?", resources.Disk)).
+		Where(sq.Eq{"name": worker.name}).
+		Suffix("RETURNING allocated_cpu, allocated_memory, allocated_disk").
+		RunWith(worker.conn).
+		QueryRow().
+		Scan(&worker.allocated.CPU, &worker.allocated.Memory, &worker.allocated.Disk)
+	if err != nil {
+		return atc.WorkerResources{}, err
+	}
+	return worker.allocated, nil
+}
+
+// ReleaseResources releases resources previously reserved with
+// AllocateResources, returning the total amount allocated afterwards.
+func (worker *worker) ReleaseResources(resources atc.WorkerResources) (atc.WorkerResources, error) {
+	err := psql.Update("workers").
+		Set("allocated_cpu", sq.Expr("GREATEST(allocated_cpu-?, 0)", resources.CPU)).
+		Set("allocated_memory", sq.Expr("GREATEST(allocated_memory-?, 0)", resources.Memory)).
+		Set("allocated_disk", sq.Expr("GREATEST(allocated_disk-?, 0)", resources.Disk)).
+		Where(sq.Eq{"name": worker.name}).
+		Suffix("RETURNING allocated_cpu, allocated_memory, allocated_disk").
+		RunWith(worker.conn).
+		QueryRow().
+		Scan(&worker.allocated.CPU, &worker.allocated.Memory, &worker.allocated.Disk)
+	if err != nil {
+		return atc.WorkerResources{}, err
+	}
+	return worker.allocated, nil
+}
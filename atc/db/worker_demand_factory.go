@@ -0,0 +1,150 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// demand reported longer ago than this is pruned, on the assumption that the
+// ATC which reported it is gone
+const workerDemandRetention = 10 * time.Minute
+
+// WorkerDemand is the demand steps have had for workers of a platform, set of
+// tags and team, i.e. for one pool of workers.
+type WorkerDemand struct {
+	Platform string
+	Tags     []string
+	TeamID   int
+	TeamName string
+
+	// StepsWaiting is the number of steps waiting for a worker to fit them.
+	StepsWaiting int
+
+	// NoFit is the number of times no worker fit the container placement
+	// strategy.
+	NoFit int
+}
+
+// WorkerDemandFactory records the demand for workers seen by each ATC, so
+// that it can be summed across the cluster.
+//
+//counterfeiter:generate . WorkerDemandFactory
+type WorkerDemandFactory interface {
+	// ReportWorkerDemand replaces the demand previously reported by the ATC.
+	ReportWorkerDemand(atcID string, demand []WorkerDemand) error
+
+	// WorkerDemand sums the demand reported by every ATC within the given
+	// duration.
+	WorkerDemand(within time.Duration) ([]WorkerDemand, error)
+}
+
+type workerDemandFactory struct {
+	conn Conn
+}
+
+func NewWorkerDemandFactory(conn Conn) WorkerDemandFactory {
+	return &workerDemandFactory{
+		conn: conn,
+	}
+}
+
+func (f *workerDemandFactory) ReportWorkerDemand(atcID string, demand []WorkerDemand) error {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Delete("worker_demand").
+		Where(sq.Or{
+			sq.Eq{"atc_id": atcID},
+			sq.Expr(fmt.Sprintf("reported_at < now() - '%d seconds'::interval", int(workerDemandRetention.Seconds()))),
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	for _, d := range demand {
+		tags := append([]string{}, d.Tags...)
+		sort.Strings(tags)
+
+		tagsPayload, err := json.Marshal(tags)
+		if err != nil {
+			return err
+		}
+
+		var teamID interface{}
+		if d.TeamID != 0 {
+			teamID = d.TeamID
+		}
+
+		_, err = psql.Insert("worker_demand").
+			Columns("atc_id", "platform", "tags", "team_id", "steps_waiting", "no_fit").
+			Values(atcID, d.Platform, tagsPayload, teamID, d.StepsWaiting, d.NoFit).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (f *workerDemandFactory) WorkerDemand(within time.Duration) ([]WorkerDemand, error) {
+	rows, err := psql.Select(
+		"d.platform",
+		"d.tags",
+		"d.team_id",
+		"t.name",
+		"SUM(d.steps_waiting)",
+		"SUM(d.no_fit)",
+	).
+		From("worker_demand d").
+		LeftJoin("teams t ON t.id = d.team_id").
+		Where(sq.Expr(fmt.Sprintf("d.reported_at > now() - '%d seconds'::interval", int(within.Seconds())))).
+		GroupBy("d.platform", "d.tags", "d.team_id", "t.name").
+		OrderBy("d.platform", "d.tags", "d.team_id").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	demand := []WorkerDemand{}
+	for rows.Next() {
+		var (
+			d           WorkerDemand
+			tagsPayload []byte
+			teamID      sql.NullInt64
+			teamName    sql.NullString
+		)
+
+		err := rows.Scan(&d.Platform, &tagsPayload, &teamID, &teamName, &d.StepsWaiting, &d.NoFit)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(tagsPayload, &d.Tags)
+		if err != nil {
+			return nil, err
+		}
+
+		d.TeamID = int(teamID.Int64)
+		d.TeamName = teamName.String
+
+		demand = append(demand, d)
+	}
+
+	return demand, rows.Err()
+}
@@ -0,0 +1,75 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerDemandFactory", func() {
+	var workerDemandFactory db.WorkerDemandFactory
+
+	BeforeEach(func() {
+		workerDemandFactory = db.NewWorkerDemandFactory(dbConn)
+	})
+
+	Describe("ReportWorkerDemand", func() {
+		BeforeEach(func() {
+			err := workerDemandFactory.ReportWorkerDemand("atc-1", []db.WorkerDemand{
+				{Platform: "linux", Tags: []string{"b", "a"}, StepsWaiting: 2},
+				{Platform: "linux", TeamID: defaultTeam.ID(), NoFit: 1},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = workerDemandFactory.ReportWorkerDemand("atc-2", []db.WorkerDemand{
+				{Platform: "linux", Tags: []string{"a", "b"}, StepsWaiting: 3, NoFit: 4},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("sums the demand reported by each ATC", func() {
+			demand, err := workerDemandFactory.WorkerDemand(time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(demand).To(ConsistOf(
+				db.WorkerDemand{Platform: "linux", Tags: []string{"a", "b"}, StepsWaiting: 5, NoFit: 4},
+				db.WorkerDemand{Platform: "linux", Tags: []string{}, TeamID: defaultTeam.ID(), TeamName: defaultTeam.Name(), NoFit: 1},
+			))
+		})
+
+		It("replaces the demand previously reported by the ATC", func() {
+			err := workerDemandFactory.ReportWorkerDemand("atc-1", nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			demand, err := workerDemandFactory.WorkerDemand(time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(demand).To(ConsistOf(
+				db.WorkerDemand{Platform: "linux", Tags: []string{"a", "b"}, StepsWaiting: 3, NoFit: 4},
+			))
+		})
+	})
+
+	Describe("WorkerDemand", func() {
+		BeforeEach(func() {
+			err := workerDemandFactory.ReportWorkerDemand("atc-1", []db.WorkerDemand{
+				{Platform: "linux", StepsWaiting: 1},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE worker_demand SET reported_at = now() - '5 minutes'::interval`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("ignores demand reported before the window", func() {
+			demand, err := workerDemandFactory.WorkerDemand(time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(demand).To(BeEmpty())
+
+			demand, err = workerDemandFactory.WorkerDemand(10 * time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(demand).To(HaveLen(1))
+		})
+	})
+})
@@ -22,6 +22,10 @@ type WorkerFactory interface {
 
 	FindWorkersForContainerByOwner(ContainerOwner) ([]Worker, error)
 	BuildContainersCountPerWorker() (map[string]int, error)
+
+	// BuildCriticalWorkers returns the names of the workers holding
+	// containers or artifact volumes of builds that are still running.
+	BuildCriticalWorkers() (map[string]bool, error)
 }
 
 type workerFactory struct {
@@ -55,7 +59,17 @@ var workersQuery = psql.Select(`
 		w.team_id,
 		w.start_time,
 		w.expires,
-		w.ephemeral
+		w.ephemeral,
+		w.capacity,
+		w.allocated_cpu,
+		w.allocated_memory,
+		w.allocated_disk,
+		w.rootless,
+		w.security_allow_list,
+		w.warm_pool,
+		w.checkpoint_restore,
+		w.network_config,
+		w.warm_task_images
 	`).
 	From("workers w").
 	LeftJoin("teams t ON w.team_id = t.id")
@@ -167,6 +181,9 @@ func scanWorker(worker *worker, row scannable) error {
 		startTime     pq.NullTime
 		expiresAt     pq.NullTime
 		ephemeral     sql.NullBool
+		capacity      sql.NullString
+		allowList     sql.NullString
+		warmPool      sql.NullString
 	)
 
 	err := row.Scan(
@@ -189,6 +206,16 @@ func scanWorker(worker *worker, row scannable) error {
 		&startTime,
 		&expiresAt,
 		&ephemeral,
+		&capacity,
+		&worker.allocated.CPU,
+		&worker.allocated.Memory,
+		&worker.allocated.Disk,
+		&worker.rootless,
+		&allowList,
+		&warmPool,
+		&worker.checkpointRestore,
+		&worker.networkConfig,
+		&worker.warmTaskImages,
 	)
 	if err != nil {
 		return err
@@ -242,6 +269,27 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.ephemeral = ephemeral.Bool
 	}
 
+	if capacity.Valid {
+		err = json.Unmarshal([]byte(capacity.String), &worker.capacity)
+		if err != nil {
+			return err
+		}
+	}
+
+	if allowList.Valid {
+		err = json.Unmarshal([]byte(allowList.String), &worker.securityAllowList)
+		if err != nil {
+			return err
+		}
+	}
+
+	if warmPool.Valid {
+		err = json.Unmarshal([]byte(warmPool.String), &worker.warmPool)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = json.Unmarshal(resourceTypes, &worker.resourceTypes)
 	if err != nil {
 		return err
@@ -279,10 +327,16 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		return nil, err
 	}
 
+	capacity, err := marshalWorkerCapacity(atcWorker.Capacity)
+	if err != nil {
+		return nil, err
+	}
+
 	_, err = psql.Update("workers").
 		Set("expires", sq.Expr(expires)).
 		Set("active_containers", atcWorker.ActiveContainers).
 		Set("active_volumes", atcWorker.ActiveVolumes).
+		Set("capacity", capacity).
 		Set("state", sq.Expr("("+cSQL+")")).
 		Where(sq.Eq{"name": atcWorker.Name}).
 		RunWith(tx).
@@ -365,6 +419,51 @@ func (f *workerFactory) BuildContainersCountPerWorker() (map[string]int, error)
 	return f.cache.WorkerContainerCounts()
 }
 
+func (f *workerFactory) BuildCriticalWorkers() (map[string]bool, error) {
+	rows, err := f.conn.Query(`
+		SELECT c.worker_name
+		FROM containers c
+		JOIN builds b ON b.id = c.build_id
+		WHERE NOT b.completed
+		UNION
+		SELECT v.worker_name
+		FROM volumes v
+		JOIN worker_artifacts a ON a.id = v.worker_artifact_id
+		JOIN builds b ON b.id = a.build_id
+		WHERE NOT b.completed
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	workers := map[string]bool{}
+	for rows.Next() {
+		var name string
+		err := rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+
+		workers[name] = true
+	}
+
+	return workers, rows.Err()
+}
+
+// resetOnRestart returns an expression that keeps a worker's allocation as it
+// is when the worker re-registers, e.g. after reconnecting to the TSA, but
+// resets it when the worker has restarted, as the containers it was allocated
+// to are gone.
+func resetOnRestart(column string, startTime string) string {
+	return fmt.Sprintf(
+		"CASE WHEN workers.start_time = %s THEN workers.%s ELSE 0 END",
+		startTime,
+		column,
+	)
+}
+
 func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, conn Conn) (Worker, error) {
 	resourceTypes, err := json.Marshal(atcWorker.ResourceTypes)
 	if err != nil {
@@ -376,6 +475,21 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		return nil, err
 	}
 
+	capacity, err := marshalWorkerCapacity(atcWorker.Capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	allowList, err := marshalWorkerSecurityAllowList(atcWorker.SecurityAllowList)
+	if err != nil {
+		return nil, err
+	}
+
+	warmPool, err := marshalWorkerWarmPool(atcWorker.WarmPool)
+	if err != nil {
+		return nil, err
+	}
+
 	expires := "NULL"
 	if ttl != 0 {
 		expires = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds()))
@@ -412,6 +526,13 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		string(workerState),
 		teamID,
 		atcWorker.Ephemeral,
+		capacity,
+		atcWorker.Rootless,
+		allowList,
+		warmPool,
+		atcWorker.CheckpointRestore,
+		atcWorker.NetworkConfig,
+		atcWorker.WarmTaskImages,
 	}
 
 	conflictValues := values
@@ -443,6 +564,13 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"state",
 			"team_id",
 			"ephemeral",
+			"capacity",
+			"rootless",
+			"security_allow_list",
+			"warm_pool",
+			"checkpoint_restore",
+			"network_config",
+			"warm_task_images",
 		).
 		Values(append([]interface{}{
 			sq.Expr(expires),
@@ -452,6 +580,9 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			ON CONFLICT (name) DO UPDATE SET
 				expires = `+expires+`,
 				start_time = `+startTime+`,
+				allocated_cpu = `+resetOnRestart("allocated_cpu", startTime)+`,
+				allocated_memory = `+resetOnRestart("allocated_memory", startTime)+`,
+				allocated_disk = `+resetOnRestart("allocated_disk", startTime)+`,
 				addr = ?,
 				active_containers = ?,
 				active_volumes = ?,
@@ -467,7 +598,14 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				version = ?,
 				state = ?,
 				team_id = ?,
-				ephemeral = ?
+				ephemeral = ?,
+				capacity = ?,
+				rootless = ?,
+				security_allow_list = ?,
+				warm_pool = ?,
+				checkpoint_restore = ?,
+				network_config = ?,
+				warm_task_images = ?
 			WHERE `+matchTeamUpsert,
 			conflictValues...,
 		).
@@ -510,7 +648,15 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		teamID:           workerTeamID,
 		startTime:        time.Unix(atcWorker.StartTime, 0),
 		ephemeral:        atcWorker.Ephemeral,
+		capacity:         atcWorker.Capacity,
+		rootless:         atcWorker.Rootless,
 		conn:             conn,
+
+		securityAllowList: atcWorker.SecurityAllowList,
+		warmPool:          atcWorker.WarmPool,
+		checkpointRestore: atcWorker.CheckpointRestore,
+		networkConfig:     atcWorker.NetworkConfig,
+		warmTaskImages:    atcWorker.WarmTaskImages,
 	}
 
 	workerBaseResourceTypeIDs := []int{}
@@ -558,3 +704,45 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 
 	return savedWorker, nil
 }
+
+func marshalWorkerCapacity(capacity *atc.WorkerResources) (*string, error) {
+	if capacity == nil {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := string(payload)
+	return &encoded, nil
+}
+
+func marshalWorkerSecurityAllowList(allowList *atc.SecurityAllowList) (*string, error) {
+	if allowList == nil {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(allowList)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := string(payload)
+	return &encoded, nil
+}
+
+func marshalWorkerWarmPool(warmPool []string) (*string, error) {
+	if len(warmPool) == 0 {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(warmPool)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := string(payload)
+	return &encoded, nil
+}
@@ -785,4 +785,54 @@ var _ = Describe("WorkerFactory", func() {
 			Expect(containersCountByWorker[worker.Name()]).To(Equal(1))
 		})
 	})
+
+	Describe("BuildCriticalWorkers", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			fakeOwner := new(dbfakes.FakeContainerOwner)
+			fakeOwner.FindReturns(sq.Eq{
+				"build_id": build.ID(),
+				"plan_id":  "simple-plan",
+				"team_id":  1,
+			}, true, nil)
+			fakeOwner.CreateReturns(map[string]interface{}{
+				"build_id": build.ID(),
+				"plan_id":  "simple-plan",
+				"team_id":  1,
+			}, nil)
+
+			_, err = defaultWorker.CreateContainer(fakeOwner, db.ContainerMetadata{
+				Type:     "task",
+				StepName: "some-task",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the workers with containers of running builds", func() {
+			workers, err := workerFactory.BuildCriticalWorkers()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(workers).To(Equal(map[string]bool{defaultWorker.Name(): true}))
+		})
+
+		Context("when the build has completed", func() {
+			BeforeEach(func() {
+				err := build.Finish(db.BuildStatusSucceeded)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not return the worker", func() {
+				workers, err := workerFactory.BuildCriticalWorkers()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(workers).To(BeEmpty())
+			})
+		})
+	})
 })
@@ -0,0 +1,166 @@
+package db
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"golang.org/x/crypto/ssh"
+)
+
+// workerKeyUsedInterval limits how often the last use of a worker key is
+// recorded, as workers make a handful of connections every heartbeat.
+const workerKeyUsedInterval = time.Minute
+
+// WorkerKeyCache keeps the worker keys in memory for the TSA to authorize
+// workers with on every handshake. The keys are reloaded whenever they're
+// changed through the API, and the fingerprints of revoked keys are sent on
+// Revocations. Revocations that aren't received in time are dropped, so
+// IsRevoked should also be checked periodically.
+type WorkerKeyCache struct {
+	logger  lager.Logger
+	factory WorkerKeyFactory
+
+	mut    sync.RWMutex
+	loaded bool
+	keys   map[string]atc.WorkerKey
+
+	usedMut  sync.Mutex
+	lastUsed map[string]time.Time
+
+	revocations chan string
+}
+
+func NewWorkerKeyCache(logger lager.Logger, conn Conn) (*WorkerKeyCache, error) {
+	notifs, err := conn.Bus().Listen(atc.WorkerKeysChannel, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := &WorkerKeyCache{
+		logger:  logger,
+		factory: NewWorkerKeyFactory(conn),
+
+		keys:     map[string]atc.WorkerKey{},
+		lastUsed: map[string]time.Time{},
+
+		revocations: make(chan string, 16),
+	}
+
+	go cache.listen(notifs)
+
+	return cache, nil
+}
+
+// Authorize looks up a worker key, returning the team it's for, or "" if it's
+// for global workers.
+func (cache *WorkerKeyCache) Authorize(key ssh.PublicKey) (string, bool, error) {
+	cache.mut.RLock()
+	loaded := cache.loaded
+	cache.mut.RUnlock()
+
+	if !loaded {
+		_, err := cache.reload()
+		if err != nil {
+			return "", false, err
+		}
+	}
+
+	fingerprint := ssh.FingerprintSHA256(key)
+
+	cache.mut.RLock()
+	workerKey, found := cache.keys[fingerprint]
+	cache.mut.RUnlock()
+
+	if !found {
+		return "", false, nil
+	}
+
+	cache.markUsed(fingerprint)
+
+	return workerKey.TeamName, true, nil
+}
+
+// Revocations receives the fingerprints of worker keys as they're revoked.
+func (cache *WorkerKeyCache) Revocations() <-chan string {
+	return cache.revocations
+}
+
+// IsRevoked returns whether a key that was loaded before is now gone.
+func (cache *WorkerKeyCache) IsRevoked(fingerprint string) bool {
+	cache.mut.RLock()
+	defer cache.mut.RUnlock()
+
+	if !cache.loaded {
+		return false
+	}
+
+	_, found := cache.keys[fingerprint]
+	return !found
+}
+
+func (cache *WorkerKeyCache) listen(notifs chan Notification) {
+	for range notifs {
+		revoked, err := cache.reload()
+		if err != nil {
+			cache.logger.Error("failed-to-reload-worker-keys", err)
+			continue
+		}
+
+		for _, fingerprint := range revoked {
+			select {
+			case cache.revocations <- fingerprint:
+			default:
+				cache.logger.Info("dropped-worker-key-revocation", lager.Data{
+					"fingerprint": fingerprint,
+				})
+			}
+		}
+	}
+}
+
+// reload loads the worker keys, returning the fingerprints of the ones that
+// are gone since they were last loaded.
+func (cache *WorkerKeyCache) reload() ([]string, error) {
+	workerKeys, err := cache.factory.WorkerKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]atc.WorkerKey, len(workerKeys))
+	for _, workerKey := range workerKeys {
+		keys[workerKey.Fingerprint] = workerKey
+	}
+
+	cache.mut.Lock()
+	defer cache.mut.Unlock()
+
+	var revoked []string
+	for fingerprint := range cache.keys {
+		if _, found := keys[fingerprint]; !found {
+			revoked = append(revoked, fingerprint)
+		}
+	}
+
+	cache.keys = keys
+	cache.loaded = true
+
+	return revoked, nil
+}
+
+func (cache *WorkerKeyCache) markUsed(fingerprint string) {
+	cache.usedMut.Lock()
+	if time.Since(cache.lastUsed[fingerprint]) < workerKeyUsedInterval {
+		cache.usedMut.Unlock()
+		return
+	}
+
+	cache.lastUsed[fingerprint] = time.Now()
+	cache.usedMut.Unlock()
+
+	err := cache.factory.MarkWorkerKeyUsed(fingerprint)
+	if err != nil {
+		cache.logger.Error("failed-to-mark-worker-key-used", err)
+	}
+}
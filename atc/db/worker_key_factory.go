@@ -0,0 +1,228 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+	"golang.org/x/crypto/ssh"
+)
+
+var ErrWorkerKeyExists = errors.New("worker key already exists")
+
+// ErrWorkerKeyUnavailable is returned when a key is already used by other
+// workers, which keys being unique to workers doesn't allow for. The workers
+// it is used by aren't told, as they may be another team's.
+var ErrWorkerKeyUnavailable = errors.New("worker key cannot be added")
+
+// WorkerKeyFactory manages the SSH keys that workers register with through
+// the TSA, in addition to the ones the TSA is configured with.
+//
+//counterfeiter:generate . WorkerKeyFactory
+type WorkerKeyFactory interface {
+	// CreateWorkerKey adds a key for the workers of a team, or for global
+	// workers if teamID is 0.
+	CreateWorkerKey(teamID int, publicKey ssh.PublicKey) (atc.WorkerKey, error)
+
+	WorkerKeys() ([]atc.WorkerKey, error)
+	TeamWorkerKeys(teamID int) ([]atc.WorkerKey, error)
+
+	RevokeWorkerKey(id int) (bool, error)
+	RevokeTeamWorkerKey(teamID int, id int) (bool, error)
+
+	MarkWorkerKeyUsed(fingerprint string) error
+}
+
+var workerKeysQuery = psql.Select(
+	"k.id",
+	"t.name",
+	"k.public_key",
+	"k.fingerprint",
+	"k.created_at",
+	"k.last_used_at",
+).
+	From("worker_keys k").
+	LeftJoin("teams t ON t.id = k.team_id")
+
+type workerKeyFactory struct {
+	conn Conn
+}
+
+func NewWorkerKeyFactory(conn Conn) WorkerKeyFactory {
+	return &workerKeyFactory{
+		conn: conn,
+	}
+}
+
+func (f *workerKeyFactory) CreateWorkerKey(teamID int, publicKey ssh.PublicKey) (atc.WorkerKey, error) {
+	var teamIDValue interface{}
+	if teamID != 0 {
+		teamIDValue = teamID
+	}
+
+	var id int
+	err := psql.Insert("worker_keys").
+		Columns("team_id", "public_key", "fingerprint").
+		Values(
+			teamIDValue,
+			strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))),
+			ssh.FingerprintSHA256(publicKey),
+		).
+		Suffix("RETURNING id").
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pqUniqueViolationErrCode {
+			return atc.WorkerKey{}, f.existingKeyError(teamIDValue, publicKey)
+		}
+
+		return atc.WorkerKey{}, err
+	}
+
+	err = f.conn.Bus().Notify(atc.WorkerKeysChannel)
+	if err != nil {
+		return atc.WorkerKey{}, err
+	}
+
+	key, err := scanWorkerKey(workerKeysQuery.
+		Where(sq.Eq{"k.id": id}).
+		RunWith(f.conn).
+		QueryRow())
+	if err != nil {
+		return atc.WorkerKey{}, err
+	}
+
+	return key, nil
+}
+
+// existingKeyError tells whether a key that couldn't be added already exists
+// for the same workers, or is used by others.
+func (f *workerKeyFactory) existingKeyError(teamIDValue interface{}, publicKey ssh.PublicKey) error {
+	var sameWorkers bool
+	err := psql.Select().
+		Column(sq.Expr("team_id IS NOT DISTINCT FROM ?", teamIDValue)).
+		From("worker_keys").
+		Where(sq.Eq{"fingerprint": ssh.FingerprintSHA256(publicKey)}).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&sameWorkers)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrWorkerKeyUnavailable
+		}
+
+		return err
+	}
+
+	if !sameWorkers {
+		return ErrWorkerKeyUnavailable
+	}
+
+	return ErrWorkerKeyExists
+}
+
+func (f *workerKeyFactory) WorkerKeys() ([]atc.WorkerKey, error) {
+	return f.workerKeys(workerKeysQuery)
+}
+
+func (f *workerKeyFactory) TeamWorkerKeys(teamID int) ([]atc.WorkerKey, error) {
+	return f.workerKeys(workerKeysQuery.Where(sq.Eq{"k.team_id": teamID}))
+}
+
+func (f *workerKeyFactory) workerKeys(query sq.SelectBuilder) ([]atc.WorkerKey, error) {
+	rows, err := query.
+		OrderBy("k.id ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	keys := []atc.WorkerKey{}
+	for rows.Next() {
+		key, err := scanWorkerKey(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+func (f *workerKeyFactory) RevokeWorkerKey(id int) (bool, error) {
+	return f.revoke(sq.Eq{"id": id})
+}
+
+func (f *workerKeyFactory) RevokeTeamWorkerKey(teamID int, id int) (bool, error) {
+	return f.revoke(sq.Eq{"id": id, "team_id": teamID})
+}
+
+func (f *workerKeyFactory) revoke(where sq.Eq) (bool, error) {
+	result, err := psql.Delete("worker_keys").
+		Where(where).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	// lets the TSA disconnect the workers using the key
+	err = f.conn.Bus().Notify(atc.WorkerKeysChannel)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (f *workerKeyFactory) MarkWorkerKeyUsed(fingerprint string) error {
+	_, err := psql.Update("worker_keys").
+		Set("last_used_at", sq.Expr("now()")).
+		Where(sq.Eq{"fingerprint": fingerprint}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+func scanWorkerKey(scan scannable) (atc.WorkerKey, error) {
+	var (
+		key        atc.WorkerKey
+		teamName   sql.NullString
+		createdAt  sql.NullTime
+		lastUsedAt sql.NullTime
+	)
+
+	err := scan.Scan(&key.ID, &teamName, &key.PublicKey, &key.Fingerprint, &createdAt, &lastUsedAt)
+	if err != nil {
+		return atc.WorkerKey{}, err
+	}
+
+	key.TeamName = teamName.String
+
+	if createdAt.Valid {
+		key.CreatedAt = createdAt.Time.Unix()
+	}
+
+	if lastUsedAt.Valid {
+		key.LastUsedAt = lastUsedAt.Time.Unix()
+	}
+
+	return key, nil
+}
@@ -0,0 +1,165 @@
+package db_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerKeyFactory", func() {
+	var (
+		workerKeyFactory db.WorkerKeyFactory
+		publicKey        ssh.PublicKey
+	)
+
+	BeforeEach(func() {
+		workerKeyFactory = db.NewWorkerKeyFactory(dbConn)
+
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		publicKey, err = ssh.NewPublicKey(pub)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("CreateWorkerKey", func() {
+		It("creates a key for the team", func() {
+			key, err := workerKeyFactory.CreateWorkerKey(defaultTeam.ID(), publicKey)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(key.ID).ToNot(BeZero())
+			Expect(key.TeamName).To(Equal(defaultTeam.Name()))
+			Expect(key.Fingerprint).To(Equal(ssh.FingerprintSHA256(publicKey)))
+			Expect(key.CreatedAt).ToNot(BeZero())
+			Expect(key.LastUsedAt).To(BeZero())
+
+			parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key.PublicKey))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Marshal()).To(Equal(publicKey.Marshal()))
+		})
+
+		It("creates a global key without a team", func() {
+			key, err := workerKeyFactory.CreateWorkerKey(0, publicKey)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(key.TeamName).To(BeEmpty())
+		})
+
+		It("notifies the TSA", func() {
+			notifs, err := dbConn.Bus().Listen(atc.WorkerKeysChannel, 1)
+			Expect(err).ToNot(HaveOccurred())
+			defer dbConn.Bus().Unlisten(atc.WorkerKeysChannel, notifs)
+
+			_, err = workerKeyFactory.CreateWorkerKey(0, publicKey)
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(notifs).Should(Receive())
+		})
+
+		Context("when the key already exists", func() {
+			BeforeEach(func() {
+				_, err := workerKeyFactory.CreateWorkerKey(defaultTeam.ID(), publicKey)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("errors", func() {
+				_, err := workerKeyFactory.CreateWorkerKey(defaultTeam.ID(), publicKey)
+				Expect(err).To(Equal(db.ErrWorkerKeyExists))
+			})
+		})
+
+		Context("when the key is used by other workers", func() {
+			BeforeEach(func() {
+				_, err := workerKeyFactory.CreateWorkerKey(0, publicKey)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("errors without saying whose it is", func() {
+				_, err := workerKeyFactory.CreateWorkerKey(defaultTeam.ID(), publicKey)
+				Expect(err).To(Equal(db.ErrWorkerKeyUnavailable))
+			})
+		})
+	})
+
+	Describe("listing keys", func() {
+		var teamKey, globalKey atc.WorkerKey
+
+		BeforeEach(func() {
+			var err error
+			teamKey, err = workerKeyFactory.CreateWorkerKey(defaultTeam.ID(), publicKey)
+			Expect(err).ToNot(HaveOccurred())
+
+			pub, _, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			otherKey, err := ssh.NewPublicKey(pub)
+			Expect(err).ToNot(HaveOccurred())
+
+			globalKey, err = workerKeyFactory.CreateWorkerKey(0, otherKey)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("lists all keys", func() {
+			keys, err := workerKeyFactory.WorkerKeys()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(Equal([]atc.WorkerKey{teamKey, globalKey}))
+		})
+
+		It("lists the keys of a team", func() {
+			keys, err := workerKeyFactory.TeamWorkerKeys(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(Equal([]atc.WorkerKey{teamKey}))
+		})
+
+		It("records when keys were last used", func() {
+			err := workerKeyFactory.MarkWorkerKeyUsed(teamKey.Fingerprint)
+			Expect(err).ToNot(HaveOccurred())
+
+			keys, err := workerKeyFactory.TeamWorkerKeys(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys[0].LastUsedAt).ToNot(BeZero())
+		})
+	})
+
+	Describe("revoking keys", func() {
+		var key atc.WorkerKey
+
+		BeforeEach(func() {
+			var err error
+			key, err = workerKeyFactory.CreateWorkerKey(defaultTeam.ID(), publicKey)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("revokes the key", func() {
+			revoked, err := workerKeyFactory.RevokeWorkerKey(key.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+
+			keys, err := workerKeyFactory.WorkerKeys()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(BeEmpty())
+		})
+
+		It("does not revoke the keys of other teams", func() {
+			revoked, err := workerKeyFactory.RevokeTeamWorkerKey(defaultTeam.ID()+1, key.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+		})
+
+		It("revokes the keys of the team", func() {
+			revoked, err := workerKeyFactory.RevokeTeamWorkerKey(defaultTeam.ID(), key.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
+
+		It("reports unknown keys", func() {
+			revoked, err := workerKeyFactory.RevokeWorkerKey(key.ID + 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revoked).To(BeFalse())
+		})
+	})
+})
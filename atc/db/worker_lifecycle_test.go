@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
@@ -239,7 +240,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					dbBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+					dbBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -271,7 +272,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					dbBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+					dbBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -486,7 +487,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					dbBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+					dbBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -518,7 +519,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					dbBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+					dbBuild, err = job.CreateBuild(context.TODO(), defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 				})
 
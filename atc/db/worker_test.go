@@ -441,4 +441,165 @@ var _ = Describe("Worker", func() {
 			})
 		})
 	})
+	Describe("SecurityAllowList", func() {
+		BeforeEach(func() {
+			atcWorker.SecurityAllowList = &atc.SecurityAllowList{
+				Capabilities:    []string{"CAP_SYS_PTRACE"},
+				Devices:         []string{"/dev/fuse"},
+				SeccompProfiles: []string{"some-profile"},
+			}
+
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("has the advertised allow-list after reloading", func() {
+			found, err := worker.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(worker.SecurityAllowList()).To(Equal(atcWorker.SecurityAllowList))
+		})
+	})
+
+	Describe("WarmPool", func() {
+		BeforeEach(func() {
+			atcWorker.WarmPool = []string{"git", "registry-image"}
+
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("has the advertised warm pool after reloading", func() {
+			found, err := worker.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(worker.WarmPool()).To(Equal(atcWorker.WarmPool))
+		})
+	})
+
+	Describe("CheckpointRestore", func() {
+		BeforeEach(func() {
+			atcWorker.CheckpointRestore = true
+
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("can checkpoint and restore after reloading", func() {
+			found, err := worker.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(worker.CheckpointRestore()).To(BeTrue())
+		})
+	})
+
+	Describe("NetworkConfig", func() {
+		BeforeEach(func() {
+			atcWorker.NetworkConfig = true
+
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("honors network configs after reloading", func() {
+			found, err := worker.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(worker.NetworkConfig()).To(BeTrue())
+		})
+	})
+
+	Describe("WarmTaskImages", func() {
+		BeforeEach(func() {
+			atcWorker.WarmTaskImages = true
+
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("keeps a warm pool of task images after reloading", func() {
+			found, err := worker.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(worker.WarmTaskImages()).To(BeTrue())
+		})
+	})
+
+	Describe("Allocated resources", func() {
+		BeforeEach(func() {
+			atcWorker.Capacity = &atc.WorkerResources{CPU: 4096, Memory: 8192}
+
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("has the registered capacity and nothing allocated", func() {
+			Expect(worker.Capacity()).To(Equal(&atc.WorkerResources{CPU: 4096, Memory: 8192}))
+			Expect(worker.Allocated()).To(BeZero())
+		})
+
+		Context("when resources are allocated", func() {
+			BeforeEach(func() {
+				allocated, err := worker.AllocateResources(atc.WorkerResources{CPU: 1024, Memory: 2048})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(allocated).To(Equal(atc.WorkerResources{CPU: 1024, Memory: 2048}))
+
+				allocated, err = worker.AllocateResources(atc.WorkerResources{Memory: 1024, Disk: 512})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(allocated).To(Equal(atc.WorkerResources{CPU: 1024, Memory: 3072, Disk: 512}))
+			})
+
+			It("adds up the allocations", func() {
+				found, err := worker.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(worker.Allocated()).To(Equal(atc.WorkerResources{CPU: 1024, Memory: 3072, Disk: 512}))
+			})
+
+			Context("when the resources are released", func() {
+				It("subtracts them without going below zero", func() {
+					allocated, err := worker.ReleaseResources(atc.WorkerResources{CPU: 1024, Memory: 2048, Disk: 1024})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(allocated).To(Equal(atc.WorkerResources{Memory: 1024}))
+				})
+			})
+
+			Context("when the worker re-registers", func() {
+				It("keeps the allocations", func() {
+					_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+					Expect(err).ToNot(HaveOccurred())
+
+					found, err := worker.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(worker.Allocated()).To(Equal(atc.WorkerResources{CPU: 1024, Memory: 3072, Disk: 512}))
+				})
+			})
+
+			Context("when the worker registers after restarting", func() {
+				It("resets the allocations", func() {
+					atcWorker.StartTime++
+
+					_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+					Expect(err).ToNot(HaveOccurred())
+
+					found, err := worker.Reload()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(worker.Allocated()).To(BeZero())
+				})
+			})
+		})
+	})
 })
@@ -16,7 +16,9 @@ import (
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
@@ -214,6 +216,18 @@ func (delegate *buildStepDelegate) SelectedWorker(logger lager.Logger, worker st
 	}
 }
 
+func (delegate *buildStepDelegate) PhaseTimed(logger lager.Logger, timing atc.StepTiming) {
+	err := delegate.build.SaveStepTiming(timing)
+	if err != nil {
+		logger.Error("failed-to-save-step-timing", err)
+	}
+
+	metric.StepPhaseTimed{
+		Build:  delegate.build,
+		Timing: timing,
+	}.Emit(logger)
+}
+
 func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string) {
 	err := delegate.build.SaveEvent(event.Error{
 		Message: message,
@@ -277,7 +291,9 @@ func (delegate *buildStepDelegate) FetchImage(
 			return worker.ImageSpec{}, fmt.Errorf("save image check event: %w", err)
 		}
 
+		_, phase := runtime.StartPhase(ctx, atc.StepPhaseImageCheck)
 		ok, err := fetchState.Run(ctx, checkPlan)
+		phase.Finish(lagerctx.FromContext(ctx))
 		if err != nil {
 			return worker.ImageSpec{}, err
 		}
@@ -319,7 +335,9 @@ func (delegate *buildStepDelegate) FetchImage(
 		return worker.ImageSpec{}, fmt.Errorf("save image get event: %w", err)
 	}
 
+	_, phase := runtime.StartPhase(ctx, atc.StepPhaseImageGet)
 	ok, err := fetchState.Run(ctx, getPlan)
+	phase.Finish(lagerctx.FromContext(ctx))
 	if err != nil {
 		return worker.ImageSpec{}, err
 	}
@@ -351,6 +369,7 @@ func (delegate *buildStepDelegate) FetchImage(
 	return worker.ImageSpec{
 		ImageArtifactSource: source,
 		Privileged:          privileged,
+		Warm:                image.Warm,
 	}, nil
 }
 
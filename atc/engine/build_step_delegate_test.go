@@ -23,9 +23,11 @@ import (
 	"github.com/concourse/concourse/atc/exec/execfakes"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/policy/policyfakes"
+	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimefakes"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
 )
 
@@ -94,6 +96,44 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 	})
 
+	Describe("PhaseTimed", func() {
+		var timing atc.StepTiming
+
+		BeforeEach(func() {
+			streamedBytes := int64(1024)
+
+			timing = atc.StepTiming{
+				PlanID:    planID,
+				StepName:  "some-step",
+				Phase:     atc.StepPhaseStreamingInputs,
+				StartTime: now.Unix(),
+				Duration:  1.5,
+				Bytes:     &streamedBytes,
+			}
+
+			fakeBuild.TracingAttrsReturns(tracing.Attrs{"team_name": "some-team"})
+		})
+
+		JustBeforeEach(func() {
+			delegate.PhaseTimed(logger, timing)
+		})
+
+		It("saves the timing of the step", func() {
+			Expect(fakeBuild.SaveStepTimingCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveStepTimingArgsForCall(0)).To(Equal(timing))
+		})
+
+		Context("when saving the timing fails", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveStepTimingReturns(errors.New("nope"))
+			})
+
+			It("logs an error", func() {
+				Expect(logger.LogMessages()).To(ContainElement("test.failed-to-save-step-timing"))
+			})
+		})
+	})
+
 	Describe("FetchImage", func() {
 		var expectedCheckPlan, expectedGetPlan atc.Plan
 		var fakeArtifact *runtimefakes.FakeArtifact
@@ -105,10 +145,13 @@ var _ = Describe("BuildStepDelegate", func() {
 		var types atc.VersionedResourceTypes
 		var privileged bool
 
+		var fetchCtx context.Context
 		var imageSpec worker.ImageSpec
 		var fetchErr error
 
 		BeforeEach(func() {
+			fetchCtx = context.TODO()
+
 			repo := build.NewRepository()
 			runState.ArtifactRepositoryReturns(repo)
 
@@ -210,7 +253,7 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 
 		JustBeforeEach(func() {
-			imageSpec, fetchErr = delegate.FetchImage(context.TODO(), imageResource, types, privileged)
+			imageSpec, fetchErr = delegate.FetchImage(fetchCtx, imageResource, types, privileged)
 		})
 
 		It("succeeds", func() {
@@ -245,6 +288,27 @@ var _ = Describe("BuildStepDelegate", func() {
 			Expect(artifact).To(Equal(fakeArtifact))
 		})
 
+		Context("when the phases of the step are timed", func() {
+			var fakeTimingDelegate *runtimefakes.FakePhaseTimingDelegate
+
+			BeforeEach(func() {
+				fakeTimingDelegate = new(runtimefakes.FakePhaseTimingDelegate)
+				fetchCtx = runtime.WithPhaseTiming(context.TODO(), planID, "some-step", fakeTimingDelegate)
+			})
+
+			It("times checking for and fetching the image", func() {
+				Expect(fakeTimingDelegate.PhaseTimedCallCount()).To(Equal(2))
+
+				_, timing := fakeTimingDelegate.PhaseTimedArgsForCall(0)
+				Expect(timing.PlanID).To(Equal(planID))
+				Expect(timing.StepName).To(Equal("some-step"))
+				Expect(timing.Phase).To(Equal(atc.StepPhaseImageCheck))
+
+				_, timing = fakeTimingDelegate.PhaseTimedArgsForCall(1)
+				Expect(timing.Phase).To(Equal(atc.StepPhaseImageGet))
+			})
+		})
+
 		Context("when privileged", func() {
 			BeforeEach(func() {
 				privileged = true
@@ -258,6 +322,24 @@ var _ = Describe("BuildStepDelegate", func() {
 			})
 		})
 
+		Context("when the image is marked warm", func() {
+			BeforeEach(func() {
+				imageResource.Warm = true
+			})
+
+			It("returns a warm image spec", func() {
+				Expect(imageSpec).To(Equal(worker.ImageSpec{
+					ImageArtifactSource: fakeSource,
+					Warm:                true,
+				}))
+			})
+
+			It("fetches the image as usual", func() {
+				_, plan := childState.RunArgsForCall(1)
+				Expect(plan).To(Equal(expectedGetPlan))
+			})
+		})
+
 		Describe("policy checking", func() {
 			BeforeEach(func() {
 				fakeBuild.TeamNameReturns("some-team")
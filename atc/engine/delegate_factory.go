@@ -30,7 +30,7 @@ func (delegate DelegateFactory) PutDelegate(state exec.RunState) exec.PutDelegat
 }
 
 func (delegate DelegateFactory) TaskDelegate(state exec.RunState) exec.TaskDelegate {
-	return NewTaskDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock(), delegate.policyChecker, delegate.artifactSourcer, delegate.dbWorkerFactory, delegate.lockFactory)
+	return NewTaskDelegate(delegate.build, delegate.plan, state, clock.NewClock(), delegate.policyChecker, delegate.artifactSourcer, delegate.dbWorkerFactory, delegate.lockFactory)
 }
 
 func (delegate DelegateFactory) RunDelegate(state exec.RunState) exec.RunDelegate {
@@ -37,6 +37,7 @@ func NewEngine(
 	stepperFactory StepperFactory,
 	secrets creds.Secrets,
 	varSourcePool creds.VarSourcePool,
+	notificationFactory db.BuildNotificationFactory,
 ) Engine {
 	return &engine{
 		stepperFactory: stepperFactory,
@@ -46,6 +47,8 @@ func NewEngine(
 
 		globalSecrets: secrets,
 		varSourcePool: varSourcePool,
+
+		notificationFactory: notificationFactory,
 	}
 }
 
@@ -57,6 +60,8 @@ type engine struct {
 
 	globalSecrets creds.Secrets
 	varSourcePool creds.VarSourcePool
+
+	notificationFactory db.BuildNotificationFactory
 }
 
 func (engine *engine) Drain(ctx context.Context) {
@@ -78,6 +83,7 @@ func (engine *engine) NewBuild(build db.Build) Runnable {
 		engine.stepperFactory,
 		engine.globalSecrets,
 		engine.varSourcePool,
+		engine.notificationFactory,
 		engine.release,
 		engine.trackedStates,
 		engine.waitGroup,
@@ -89,6 +95,7 @@ func NewBuild(
 	builder StepperFactory,
 	globalSecrets creds.Secrets,
 	varSourcePool creds.VarSourcePool,
+	notificationFactory db.BuildNotificationFactory,
 	release chan bool,
 	trackedStates *sync.Map,
 	waitGroup *sync.WaitGroup,
@@ -100,6 +107,8 @@ func NewBuild(
 		globalSecrets: globalSecrets,
 		varSourcePool: varSourcePool,
 
+		notificationFactory: notificationFactory,
+
 		release:       release,
 		trackedStates: trackedStates,
 		waitGroup:     waitGroup,
@@ -113,6 +122,8 @@ type engineBuild struct {
 	globalSecrets creds.Secrets
 	varSourcePool creds.VarSourcePool
 
+	notificationFactory db.BuildNotificationFactory
+
 	release       chan bool
 	trackedStates *sync.Map
 	waitGroup     *sync.WaitGroup
@@ -273,14 +284,69 @@ func (b *engineBuild) finish(logger lager.Logger, err error, succeeded bool) {
 func (b *engineBuild) saveStatus(logger lager.Logger, status atc.BuildStatus) {
 	if err := b.build.Finish(db.BuildStatus(status)); err != nil {
 		logger.Error("failed-to-finish-build", err)
+		return
+	}
+
+	b.queueNotifications(logger, string(status))
+
+	if status == atc.StatusFailed {
+		b.retryOnFlake(logger)
 	}
 }
 
+// retryOnFlake reruns a build that failed only in flaky tolerant tasks, as
+// many times as the retry_on_flake policy of its job allows.
+func (b *engineBuild) retryOnFlake(logger lager.Logger) {
+	if b.build.JobID() == 0 {
+		return
+	}
+
+	job, found, err := b.build.Job()
+	if err != nil {
+		logger.Error("failed-to-get-job", err)
+		return
+	}
+
+	if !found {
+		return
+	}
+
+	config, err := job.Config()
+	if err != nil {
+		logger.Error("failed-to-get-job-config", err)
+		return
+	}
+
+	if config.RetryOnFlake == 0 {
+		return
+	}
+
+	retryable, err := b.build.RetryableOnFlake(config.RetryOnFlake)
+	if err != nil {
+		logger.Error("failed-to-check-if-retryable-on-flake", err)
+		return
+	}
+
+	if !retryable {
+		return
+	}
+
+	rerun, err := job.RerunBuild(b.build, "")
+	if err != nil {
+		logger.Error("failed-to-rerun-build", err)
+		return
+	}
+
+	logger.Info("retrying-on-flake", lager.Data{"rerun": rerun.Name()})
+}
+
 func (b *engineBuild) trackStarted(logger lager.Logger) {
 	if b.build.Name() != db.CheckBuildName {
 		metric.BuildStarted{
 			Build: b.build,
 		}.Emit(logger)
+
+		b.queueNotifications(logger, atc.NotificationEventStarted)
 	} else {
 		metric.CheckBuildStarted{
 			Build: b.build,
@@ -313,6 +379,17 @@ func (b *engineBuild) trackFinished(logger lager.Logger) {
 	}
 }
 
+func (b *engineBuild) queueNotifications(logger lager.Logger, event string) {
+	if b.build.Name() == db.CheckBuildName {
+		return
+	}
+
+	err := b.notificationFactory.QueueBuildNotifications(b.build, event)
+	if err != nil {
+		logger.Error("failed-to-queue-build-notifications", err, lager.Data{"event": event})
+	}
+}
+
 func (b *engineBuild) runState(logger lager.Logger, stepper exec.Stepper) (exec.RunState, error) {
 	id := fmt.Sprintf("build:%v", b.build.ID())
 	existingState, ok := b.trackedStates.Load(id)
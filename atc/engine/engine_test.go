@@ -33,6 +33,8 @@ var _ = Describe("Engine", func() {
 
 		fakeGlobalCreds   *credsfakes.FakeSecrets
 		fakeVarSourcePool *credsfakes.FakeVarSourcePool
+
+		fakeNotificationFactory *dbfakes.FakeBuildNotificationFactory
 	)
 
 	BeforeEach(func() {
@@ -43,6 +45,8 @@ var _ = Describe("Engine", func() {
 
 		fakeGlobalCreds = new(credsfakes.FakeSecrets)
 		fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
+
+		fakeNotificationFactory = new(dbfakes.FakeBuildNotificationFactory)
 	})
 
 	Describe("NewBuild", func() {
@@ -52,7 +56,7 @@ var _ = Describe("Engine", func() {
 		)
 
 		BeforeEach(func() {
-			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, fakeVarSourcePool)
+			engine = NewEngine(fakeStepperFactory, fakeGlobalCreds, fakeVarSourcePool, fakeNotificationFactory)
 		})
 
 		JustBeforeEach(func() {
@@ -82,6 +86,7 @@ var _ = Describe("Engine", func() {
 				fakeStepperFactory,
 				fakeGlobalCreds,
 				fakeVarSourcePool,
+				fakeNotificationFactory,
 				release,
 				trackedStates,
 				waitGroup,
@@ -245,6 +250,41 @@ var _ = Describe("Engine", func() {
 										Expect(fakeBuild.FinishCallCount()).To(Equal(1))
 										Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusSucceeded))
 									})
+
+									It("queues notifications for the build starting and finishing", func() {
+										waitGroup.Wait()
+										Expect(fakeNotificationFactory.QueueBuildNotificationsCallCount()).To(Equal(2))
+
+										build, event := fakeNotificationFactory.QueueBuildNotificationsArgsForCall(0)
+										Expect(build).To(Equal(fakeBuild))
+										Expect(event).To(Equal(atc.NotificationEventStarted))
+
+										build, event = fakeNotificationFactory.QueueBuildNotificationsArgsForCall(1)
+										Expect(build).To(Equal(fakeBuild))
+										Expect(event).To(Equal("succeeded"))
+									})
+
+									Context("when finishing the build fails", func() {
+										BeforeEach(func() {
+											fakeBuild.FinishReturns(errors.New("nope"))
+										})
+
+										It("does not queue notifications for the build finishing", func() {
+											waitGroup.Wait()
+											Expect(fakeNotificationFactory.QueueBuildNotificationsCallCount()).To(Equal(1))
+										})
+									})
+
+									Context("when the build is a check build", func() {
+										BeforeEach(func() {
+											fakeBuild.NameReturns(db.CheckBuildName)
+										})
+
+										It("does not queue notifications", func() {
+											waitGroup.Wait()
+											Expect(fakeNotificationFactory.QueueBuildNotificationsCallCount()).To(BeZero())
+										})
+									})
 								})
 
 								Context("when the build finishes woefully", func() {
@@ -257,6 +297,52 @@ var _ = Describe("Engine", func() {
 										Expect(fakeBuild.FinishCallCount()).To(Equal(1))
 										Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusFailed))
 									})
+
+									It("does not rerun the build", func() {
+										waitGroup.Wait()
+										Expect(fakeBuild.RetryableOnFlakeCallCount()).To(BeZero())
+									})
+
+									Context("when the job retries on flakes", func() {
+										var fakeJob *dbfakes.FakeJob
+
+										BeforeEach(func() {
+											fakeJob = new(dbfakes.FakeJob)
+											fakeJob.ConfigReturns(atc.JobConfig{Name: "some-job", RetryOnFlake: 2}, nil)
+											fakeJob.RerunBuildReturns(new(dbfakes.FakeBuild), nil)
+
+											fakeBuild.JobIDReturns(1)
+											fakeBuild.JobReturns(fakeJob, true, nil)
+										})
+
+										Context("when the build failed only in flaky tolerant tasks", func() {
+											BeforeEach(func() {
+												fakeBuild.RetryableOnFlakeReturns(true, nil)
+											})
+
+											It("reruns the build", func() {
+												waitGroup.Wait()
+												Expect(fakeBuild.RetryableOnFlakeCallCount()).To(Equal(1))
+												Expect(fakeBuild.RetryableOnFlakeArgsForCall(0)).To(Equal(2))
+
+												Expect(fakeJob.RerunBuildCallCount()).To(Equal(1))
+												rerunBuild, createdBy := fakeJob.RerunBuildArgsForCall(0)
+												Expect(rerunBuild).To(Equal(fakeBuild))
+												Expect(createdBy).To(BeEmpty())
+											})
+										})
+
+										Context("when the build is not retryable", func() {
+											BeforeEach(func() {
+												fakeBuild.RetryableOnFlakeReturns(false, nil)
+											})
+
+											It("does not rerun the build", func() {
+												waitGroup.Wait()
+												Expect(fakeJob.RerunBuildCallCount()).To(BeZero())
+											})
+										})
+									})
 								})
 
 								Context("when the build finishes with error", func() {
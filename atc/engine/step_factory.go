@@ -23,6 +23,7 @@ type coreStepFactory struct {
 	resourceCacheFactory  db.ResourceCacheFactory
 	resourceConfigFactory db.ResourceConfigFactory
 	defaultLimits         atc.ContainerLimits
+	usageInterval         time.Duration
 	strategy              worker.ContainerPlacementStrategy
 	defaultCheckTimeout   time.Duration
 }
@@ -37,6 +38,7 @@ func NewCoreStepFactory(
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
 	defaultLimits atc.ContainerLimits,
+	usageInterval time.Duration,
 	strategy worker.ContainerPlacementStrategy,
 	defaultCheckTimeout time.Duration,
 ) CoreStepFactory {
@@ -50,6 +52,7 @@ func NewCoreStepFactory(
 		resourceCacheFactory:  resourceCacheFactory,
 		resourceConfigFactory: resourceConfigFactory,
 		defaultLimits:         defaultLimits,
+		usageInterval:         usageInterval,
 		strategy:              strategy,
 		defaultCheckTimeout:   defaultCheckTimeout,
 	}
@@ -172,6 +175,8 @@ func (factory *coreStepFactory) TaskStep(
 		plan.ID,
 		*plan.Task,
 		factory.defaultLimits,
+		factory.teamFactory,
+		factory.usageInterval,
 		stepMetadata,
 		containerMetadata,
 		factory.strategy,
@@ -10,13 +10,14 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/worker"
 )
 
 func NewTaskDelegate(
 	build db.Build,
-	planID atc.PlanID,
+	plan atc.Plan,
 	state exec.RunState,
 	clock clock.Clock,
 	policyChecker policy.Checker,
@@ -25,11 +26,12 @@ func NewTaskDelegate(
 	lockFactory lock.LockFactory,
 ) exec.TaskDelegate {
 	return &taskDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker, artifactSourcer),
+		buildStepDelegate: NewBuildStepDelegate(build, plan.ID, state, clock, policyChecker, artifactSourcer),
 
-		eventOrigin: event.Origin{ID: event.OriginID(planID)},
-		build:       build,
-		clock:       clock,
+		eventOrigin:   event.Origin{ID: event.OriginID(plan.ID)},
+		build:         build,
+		clock:         clock,
+		flakyTolerant: plan.Task != nil && plan.Task.FlakyTolerant,
 
 		dbWorkerFactory: dbWorkerFactory,
 		lockFactory:     lockFactory,
@@ -37,13 +39,16 @@ func NewTaskDelegate(
 }
 
 type taskDelegate struct {
-	exec.BuildStepDelegate
+	*buildStepDelegate
 
 	config      atc.TaskConfig
+	usage       *atc.ContainerUsage
 	build       db.Build
 	eventOrigin event.Origin
 	clock       clock.Clock
 
+	flakyTolerant bool
+
 	dbWorkerFactory db.WorkerFactory
 	lockFactory     lock.LockFactory
 }
@@ -52,6 +57,19 @@ func (d *taskDelegate) SetTaskConfig(config atc.TaskConfig) {
 	d.config = config
 }
 
+func (d *taskDelegate) CheckSecurityPolicy(security atc.SecurityConfig) error {
+	if !d.policyChecker.ShouldCheckAction(policy.ActionUseSecurityConfig) {
+		return nil
+	}
+
+	return d.checkPolicy(policy.PolicyCheckInput{
+		Action:   policy.ActionUseSecurityConfig,
+		Team:     d.build.TeamName(),
+		Pipeline: d.build.PipelineName(),
+		Data:     security,
+	})
+}
+
 func (d *taskDelegate) Initializing(logger lager.Logger) {
 	err := d.build.SaveEvent(event.InitializeTask{
 		Origin:     d.eventOrigin,
@@ -80,6 +98,43 @@ func (d *taskDelegate) Starting(logger lager.Logger) {
 	logger.Debug("starting")
 }
 
+// UsageUpdated saves the usage of a task that is still running, so that it is
+// known even if the task never finishes.
+func (d *taskDelegate) UsageUpdated(logger lager.Logger, usage atc.StepUsage) {
+	err := d.build.SaveStepUsage(usage)
+	if err != nil {
+		logger.Error("failed-to-save-step-usage", err)
+	}
+}
+
+func (d *taskDelegate) UsageSampled(logger lager.Logger, usage atc.StepUsage) {
+	d.usage = &usage.Usage
+
+	err := d.build.SaveStepUsage(usage)
+	if err != nil {
+		logger.Error("failed-to-save-step-usage", err)
+	}
+
+	metric.TaskUsage{
+		Build: d.build,
+		Usage: usage,
+	}.Emit(logger)
+}
+
+func (d *taskDelegate) Killed(logger lager.Logger, reason atc.ExitReason) {
+	err := d.build.SaveEvent(event.TaskKilled{
+		Origin: d.eventOrigin,
+		Time:   d.clock.Now().Unix(),
+		Reason: reason,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-task-killed-event", err)
+		return
+	}
+
+	logger.Info("killed", lager.Data{"signal": reason.Signal, "oom-killed": reason.OOMKilled})
+}
+
 func (d *taskDelegate) Finished(
 	logger lager.Logger,
 	exitStatus exec.ExitStatus,
@@ -94,11 +149,20 @@ func (d *taskDelegate) Finished(
 		ExitStatus: int(exitStatus),
 		Time:       d.clock.Now().Unix(),
 		Origin:     d.eventOrigin,
+		Usage:      d.usage,
 	})
 	if err != nil {
 		logger.Error("failed-to-save-finish-event", err)
 		return
 	}
 
+	if exitStatus != 0 {
+		err = d.build.SaveTaskFailure(d.flakyTolerant)
+		if err != nil {
+			logger.Error("failed-to-save-task-failure", err)
+			return
+		}
+	}
+
 	logger.Info("finished", lager.Data{"exit-status": exitStatus})
 }
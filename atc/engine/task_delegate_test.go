@@ -13,10 +13,13 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/policy/policyfakes"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
 )
 
@@ -60,7 +63,12 @@ var _ = Describe("TaskDelegate", func() {
 		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
 		fakeLockFactory = new(lockfakes.FakeLockFactory)
 
-		delegate = NewTaskDelegate(fakeBuild, "some-plan-id", state, fakeClock, fakePolicyChecker, fakeArtifactSourcer, fakeWorkerFactory, fakeLockFactory).(*taskDelegate)
+		plan := atc.Plan{
+			ID:   "some-plan-id",
+			Task: &atc.TaskPlan{Name: "some-task"},
+		}
+
+		delegate = NewTaskDelegate(fakeBuild, plan, state, fakeClock, fakePolicyChecker, fakeArtifactSourcer, fakeWorkerFactory, fakeLockFactory).(*taskDelegate)
 
 		delegate.SetTaskConfig(atc.TaskConfig{
 			Platform: "some-platform",
@@ -133,6 +141,97 @@ var _ = Describe("TaskDelegate", func() {
 		})
 	})
 
+	Describe("CheckSecurityPolicy", func() {
+		var (
+			fakePolicyCheckResult *policyfakes.FakePolicyCheckResult
+			security              atc.SecurityConfig
+			checkErr              error
+		)
+
+		BeforeEach(func() {
+			fakeBuild.TeamNameReturns("some-team")
+			fakeBuild.PipelineNameReturns("some-pipeline")
+
+			fakePolicyCheckResult = new(policyfakes.FakePolicyCheckResult)
+			fakePolicyCheckResult.AllowedReturns(true)
+			fakePolicyChecker.CheckReturns(fakePolicyCheckResult, nil)
+
+			security = atc.SecurityConfig{
+				Capabilities: atc.CapabilitiesConfig{Add: []string{"CAP_SYS_PTRACE"}},
+			}
+		})
+
+		JustBeforeEach(func() {
+			checkErr = delegate.CheckSecurityPolicy(security)
+		})
+
+		Context("when the action does not need to be checked", func() {
+			BeforeEach(func() {
+				fakePolicyChecker.ShouldCheckActionReturns(false)
+			})
+
+			It("succeeds without checking policy", func() {
+				Expect(checkErr).ToNot(HaveOccurred())
+				Expect(fakePolicyChecker.CheckCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the action needs to be checked", func() {
+			BeforeEach(func() {
+				fakePolicyChecker.ShouldCheckActionReturns(true)
+			})
+
+			It("checks policy with the security config", func() {
+				Expect(checkErr).ToNot(HaveOccurred())
+				Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+				Expect(fakePolicyChecker.CheckArgsForCall(0)).To(Equal(policy.PolicyCheckInput{
+					Action:   policy.ActionUseSecurityConfig,
+					Team:     "some-team",
+					Pipeline: "some-pipeline",
+					Data:     security,
+				}))
+			})
+
+			Context("when the policy check does not pass and should block", func() {
+				BeforeEach(func() {
+					fakePolicyCheckResult.AllowedReturns(false)
+					fakePolicyCheckResult.ShouldBlockReturns(true)
+					fakePolicyCheckResult.MessagesReturns([]string{"no ptrace"})
+				})
+
+				It("fails", func() {
+					Expect(checkErr).To(MatchError(ContainSubstring("no ptrace")))
+				})
+			})
+		})
+	})
+
+	Describe("Killed", func() {
+		var reason atc.ExitReason
+
+		BeforeEach(func() {
+			reason = atc.ExitReason{
+				Signal:      "SIGKILL",
+				OOMKilled:   true,
+				MemoryLimit: 2048,
+				MemoryPeak:  2048,
+			}
+		})
+
+		JustBeforeEach(func() {
+			delegate.Killed(logger, reason)
+		})
+
+		It("saves a task-killed event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.TaskKilled{
+				Time:   now.Unix(),
+				Origin: event.Origin{ID: "some-plan-id"},
+				Reason: reason,
+			}))
+		})
+	})
+
 	Describe("Finished", func() {
 		var fakeClient *workerfakes.FakeClient
 		var fakeStrategy *workerfakes.FakeContainerPlacementStrategy
@@ -140,6 +239,7 @@ var _ = Describe("TaskDelegate", func() {
 		BeforeEach(func() {
 			fakeClient = new(workerfakes.FakeClient)
 			fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+			exitStatus = 0
 		})
 
 		JustBeforeEach(func() {
@@ -151,6 +251,102 @@ var _ = Describe("TaskDelegate", func() {
 			event := fakeBuild.SaveEventArgsForCall(0)
 			Expect(event.EventType()).To(Equal(atc.EventType("finish-task")))
 		})
+
+		It("does not save a task failure", func() {
+			Expect(fakeBuild.SaveTaskFailureCallCount()).To(BeZero())
+		})
+
+		Context("when the task exited non-zero", func() {
+			BeforeEach(func() {
+				exitStatus = 1
+			})
+
+			It("saves a failure which is not tolerated", func() {
+				Expect(fakeBuild.SaveTaskFailureCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveTaskFailureArgsForCall(0)).To(BeFalse())
+			})
+
+			Context("when the task is flaky tolerant", func() {
+				BeforeEach(func() {
+					plan := atc.Plan{
+						ID:   "some-plan-id",
+						Task: &atc.TaskPlan{Name: "some-task", FlakyTolerant: true},
+					}
+
+					delegate = NewTaskDelegate(fakeBuild, plan, state, fakeClock, fakePolicyChecker, fakeArtifactSourcer, fakeWorkerFactory, fakeLockFactory).(*taskDelegate)
+				})
+
+				It("saves a tolerated failure", func() {
+					Expect(fakeBuild.SaveTaskFailureCallCount()).To(Equal(1))
+					Expect(fakeBuild.SaveTaskFailureArgsForCall(0)).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the usage of the task was sampled", func() {
+			var usage atc.StepUsage
+
+			BeforeEach(func() {
+				usage = atc.StepUsage{
+					PlanID:   "some-plan-id",
+					StepName: "some-task",
+					Usage: atc.ContainerUsage{
+						CPUAverage:    0.5,
+						CPUPeak:       1.5,
+						MemoryAverage: 1024,
+						MemoryPeak:    2048,
+						Samples:       3,
+					},
+				}
+
+				fakeBuild.TracingAttrsReturns(tracing.Attrs{"team_name": "some-team"})
+
+				delegate.UsageSampled(logger, usage)
+			})
+
+			It("saves the usage of the step", func() {
+				Expect(fakeBuild.SaveStepUsageCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveStepUsageArgsForCall(0)).To(Equal(usage))
+			})
+
+			It("includes the usage in the finish event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.FinishTask{
+					ExitStatus: int(exitStatus),
+					Time:       now.Unix(),
+					Origin:     event.Origin{ID: "some-plan-id"},
+					Usage:      &usage.Usage,
+				}))
+			})
+		})
+	})
+
+	Describe("UsageUpdated", func() {
+		var usage atc.StepUsage
+
+		JustBeforeEach(func() {
+			usage = atc.StepUsage{
+				PlanID:   "some-plan-id",
+				StepName: "some-task",
+				Usage: atc.ContainerUsage{
+					MemoryAverage: 1024,
+					MemoryPeak:    2048,
+					BlockIORead:   4096,
+					Samples:       1,
+				},
+			}
+
+			delegate.UsageUpdated(logger, usage)
+		})
+
+		It("saves the usage of the step so far", func() {
+			Expect(fakeBuild.SaveStepUsageCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveStepUsageArgsForCall(0)).To(Equal(usage))
+		})
+
+		It("does not save an event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(BeZero())
+		})
 	})
 })
 
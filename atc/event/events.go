@@ -16,13 +16,23 @@ func (Error) EventType() atc.EventType  { return EventTypeError }
 func (Error) Version() atc.EventVersion { return "4.1" }
 
 type FinishTask struct {
-	Time       int64  `json:"time"`
-	ExitStatus int    `json:"exit_status"`
-	Origin     Origin `json:"origin"`
+	Time       int64               `json:"time"`
+	ExitStatus int                 `json:"exit_status"`
+	Origin     Origin              `json:"origin"`
+	Usage      *atc.ContainerUsage `json:"usage,omitempty"`
 }
 
 func (FinishTask) EventType() atc.EventType  { return EventTypeFinishTask }
-func (FinishTask) Version() atc.EventVersion { return "4.0" }
+func (FinishTask) Version() atc.EventVersion { return "4.1" }
+
+type TaskKilled struct {
+	Time   int64          `json:"time"`
+	Origin Origin         `json:"origin"`
+	Reason atc.ExitReason `json:"reason"`
+}
+
+func (TaskKilled) EventType() atc.EventType  { return EventTypeTaskKilled }
+func (TaskKilled) Version() atc.EventVersion { return "1.0" }
 
 type InitializeTask struct {
 	Time       int64      `json:"time"`
@@ -37,6 +37,7 @@ func init() {
 	RegisterEvent(InitializeTask{})
 	RegisterEvent(StartTask{})
 	RegisterEvent(FinishTask{})
+	RegisterEvent(TaskKilled{})
 	RegisterEvent(InitializeGet{})
 	RegisterEvent(StartGet{})
 	RegisterEvent(FinishGet{})
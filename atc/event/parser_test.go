@@ -59,6 +59,7 @@ var _ = Describe("ParseEvent", func() {
 		Entry("InitializeTask", event.InitializeTask{}),
 		Entry("StartTask", event.StartTask{}),
 		Entry("FinishTask", event.FinishTask{}),
+		Entry("TaskKilled", event.TaskKilled{}),
 		Entry("InitializeGet", event.InitializeGet{}),
 		Entry("StartGet", event.StartGet{}),
 		Entry("FinishGet", event.FinishGet{}),
@@ -24,6 +24,9 @@ const (
 	// task execution finished
 	EventTypeFinishTask atc.EventType = "finish-task"
 
+	// task process killed by a signal (e.g. out of memory)
+	EventTypeTaskKilled atc.EventType = "task-killed"
+
 	// initialize getting something
 	EventTypeInitializeGet atc.EventType = "initialize-get"
 
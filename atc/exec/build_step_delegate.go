@@ -33,6 +33,7 @@ type BuildStepDelegate interface {
 
 	WaitingForWorker(lager.Logger)
 	SelectedWorker(lager.Logger, string)
+	PhaseTimed(lager.Logger, atc.StepTiming)
 
 	ConstructAcrossSubsteps([]byte, []atc.AcrossVar, [][]interface{}) ([]atc.VarScopedPlan, error)
 }
@@ -78,6 +78,12 @@ type FakeCheckDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	PhaseTimedStub        func(lager.Logger, atc.StepTiming)
+	phaseTimedMutex       sync.RWMutex
+	phaseTimedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.StepTiming
+	}
 	PointToCheckedConfigStub        func(db.ResourceConfigScope) error
 	pointToCheckedConfigMutex       sync.RWMutex
 	pointToCheckedConfigArgsForCall []struct {
@@ -470,6 +476,39 @@ func (fake *FakeCheckDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeCheckDelegate) PhaseTimed(arg1 lager.Logger, arg2 atc.StepTiming) {
+	fake.phaseTimedMutex.Lock()
+	fake.phaseTimedArgsForCall = append(fake.phaseTimedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.StepTiming
+	}{arg1, arg2})
+	stub := fake.PhaseTimedStub
+	fake.recordInvocation("PhaseTimed", []interface{}{arg1, arg2})
+	fake.phaseTimedMutex.Unlock()
+	if stub != nil {
+		fake.PhaseTimedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) PhaseTimedCallCount() int {
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
+	return len(fake.phaseTimedArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) PhaseTimedCalls(stub func(lager.Logger, atc.StepTiming)) {
+	fake.phaseTimedMutex.Lock()
+	defer fake.phaseTimedMutex.Unlock()
+	fake.PhaseTimedStub = stub
+}
+
+func (fake *FakeCheckDelegate) PhaseTimedArgsForCall(i int) (lager.Logger, atc.StepTiming) {
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
+	argsForCall := fake.phaseTimedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCheckDelegate) PointToCheckedConfig(arg1 db.ResourceConfigScope) error {
 	fake.pointToCheckedConfigMutex.Lock()
 	ret, specificReturn := fake.pointToCheckedConfigReturnsOnCall[len(fake.pointToCheckedConfigArgsForCall)]
@@ -883,6 +922,8 @@ func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	defer fake.finishedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
 	fake.pointToCheckedConfigMutex.RLock()
 	defer fake.pointToCheckedConfigMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
@@ -50,6 +50,12 @@ type FakeGetDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	PhaseTimedStub        func(lager.Logger, atc.StepTiming)
+	phaseTimedMutex       sync.RWMutex
+	phaseTimedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.StepTiming
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
@@ -278,6 +284,39 @@ func (fake *FakeGetDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeGetDelegate) PhaseTimed(arg1 lager.Logger, arg2 atc.StepTiming) {
+	fake.phaseTimedMutex.Lock()
+	fake.phaseTimedArgsForCall = append(fake.phaseTimedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.StepTiming
+	}{arg1, arg2})
+	stub := fake.PhaseTimedStub
+	fake.recordInvocation("PhaseTimed", []interface{}{arg1, arg2})
+	fake.phaseTimedMutex.Unlock()
+	if stub != nil {
+		fake.PhaseTimedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeGetDelegate) PhaseTimedCallCount() int {
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
+	return len(fake.phaseTimedArgsForCall)
+}
+
+func (fake *FakeGetDelegate) PhaseTimedCalls(stub func(lager.Logger, atc.StepTiming)) {
+	fake.phaseTimedMutex.Lock()
+	defer fake.phaseTimedMutex.Unlock()
+	fake.PhaseTimedStub = stub
+}
+
+func (fake *FakeGetDelegate) PhaseTimedArgsForCall(i int) (lager.Logger, atc.StepTiming) {
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
+	argsForCall := fake.phaseTimedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeGetDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -592,6 +631,8 @@ func (fake *FakeGetDelegate) Invocations() map[string][][]interface{} {
 	defer fake.finishedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.startSpanMutex.RLock()
@@ -50,6 +50,12 @@ type FakePutDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	PhaseTimedStub        func(lager.Logger, atc.StepTiming)
+	phaseTimedMutex       sync.RWMutex
+	phaseTimedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.StepTiming
+	}
 	SaveOutputStub        func(lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, runtime.VersionResult)
 	saveOutputMutex       sync.RWMutex
 	saveOutputArgsForCall []struct {
@@ -280,6 +286,39 @@ func (fake *FakePutDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakePutDelegate) PhaseTimed(arg1 lager.Logger, arg2 atc.StepTiming) {
+	fake.phaseTimedMutex.Lock()
+	fake.phaseTimedArgsForCall = append(fake.phaseTimedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.StepTiming
+	}{arg1, arg2})
+	stub := fake.PhaseTimedStub
+	fake.recordInvocation("PhaseTimed", []interface{}{arg1, arg2})
+	fake.phaseTimedMutex.Unlock()
+	if stub != nil {
+		fake.PhaseTimedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakePutDelegate) PhaseTimedCallCount() int {
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
+	return len(fake.phaseTimedArgsForCall)
+}
+
+func (fake *FakePutDelegate) PhaseTimedCalls(stub func(lager.Logger, atc.StepTiming)) {
+	fake.phaseTimedMutex.Lock()
+	defer fake.phaseTimedMutex.Unlock()
+	fake.PhaseTimedStub = stub
+}
+
+func (fake *FakePutDelegate) PhaseTimedArgsForCall(i int) (lager.Logger, atc.StepTiming) {
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
+	argsForCall := fake.phaseTimedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakePutDelegate) SaveOutput(arg1 lager.Logger, arg2 atc.PutPlan, arg3 atc.Source, arg4 atc.VersionedResourceTypes, arg5 runtime.VersionResult) {
 	fake.saveOutputMutex.Lock()
 	fake.saveOutputArgsForCall = append(fake.saveOutputArgsForCall, struct {
@@ -596,6 +635,8 @@ func (fake *FakePutDelegate) Invocations() map[string][][]interface{} {
 	defer fake.finishedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
@@ -74,6 +74,12 @@ type FakeSetPipelineStepDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	PhaseTimedStub        func(lager.Logger, atc.StepTiming)
+	phaseTimedMutex       sync.RWMutex
+	phaseTimedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.StepTiming
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
@@ -442,6 +448,39 @@ func (fake *FakeSetPipelineStepDelegate) InitializingArgsForCall(i int) lager.Lo
 	return argsForCall.arg1
 }
 
+func (fake *FakeSetPipelineStepDelegate) PhaseTimed(arg1 lager.Logger, arg2 atc.StepTiming) {
+	fake.phaseTimedMutex.Lock()
+	fake.phaseTimedArgsForCall = append(fake.phaseTimedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.StepTiming
+	}{arg1, arg2})
+	stub := fake.PhaseTimedStub
+	fake.recordInvocation("PhaseTimed", []interface{}{arg1, arg2})
+	fake.phaseTimedMutex.Unlock()
+	if stub != nil {
+		fake.PhaseTimedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeSetPipelineStepDelegate) PhaseTimedCallCount() int {
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
+	return len(fake.phaseTimedArgsForCall)
+}
+
+func (fake *FakeSetPipelineStepDelegate) PhaseTimedCalls(stub func(lager.Logger, atc.StepTiming)) {
+	fake.phaseTimedMutex.Lock()
+	defer fake.phaseTimedMutex.Unlock()
+	fake.PhaseTimedStub = stub
+}
+
+func (fake *FakeSetPipelineStepDelegate) PhaseTimedArgsForCall(i int) (lager.Logger, atc.StepTiming) {
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
+	argsForCall := fake.phaseTimedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeSetPipelineStepDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -759,6 +798,8 @@ func (fake *FakeSetPipelineStepDelegate) Invocations() map[string][][]interface{
 	defer fake.finishedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.setPipelineChangedMutex.RLock()
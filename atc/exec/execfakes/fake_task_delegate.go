@@ -15,6 +15,17 @@ import (
 )
 
 type FakeTaskDelegate struct {
+	CheckSecurityPolicyStub        func(atc.SecurityConfig) error
+	checkSecurityPolicyMutex       sync.RWMutex
+	checkSecurityPolicyArgsForCall []struct {
+		arg1 atc.SecurityConfig
+	}
+	checkSecurityPolicyReturns struct {
+		result1 error
+	}
+	checkSecurityPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ErroredStub        func(lager.Logger, string)
 	erroredMutex       sync.RWMutex
 	erroredArgsForCall []struct {
@@ -50,6 +61,18 @@ type FakeTaskDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	KilledStub        func(lager.Logger, atc.ExitReason)
+	killedMutex       sync.RWMutex
+	killedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.ExitReason
+	}
+	PhaseTimedStub        func(lager.Logger, atc.StepTiming)
+	phaseTimedMutex       sync.RWMutex
+	phaseTimedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.StepTiming
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
@@ -101,6 +124,18 @@ type FakeTaskDelegate struct {
 	stdoutReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	UsageSampledStub        func(lager.Logger, atc.StepUsage)
+	usageSampledMutex       sync.RWMutex
+	usageSampledArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.StepUsage
+	}
+	UsageUpdatedStub        func(lager.Logger, atc.StepUsage)
+	usageUpdatedMutex       sync.RWMutex
+	usageUpdatedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.StepUsage
+	}
 	WaitingForWorkerStub        func(lager.Logger)
 	waitingForWorkerMutex       sync.RWMutex
 	waitingForWorkerArgsForCall []struct {
@@ -110,6 +145,67 @@ type FakeTaskDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeTaskDelegate) CheckSecurityPolicy(arg1 atc.SecurityConfig) error {
+	fake.checkSecurityPolicyMutex.Lock()
+	ret, specificReturn := fake.checkSecurityPolicyReturnsOnCall[len(fake.checkSecurityPolicyArgsForCall)]
+	fake.checkSecurityPolicyArgsForCall = append(fake.checkSecurityPolicyArgsForCall, struct {
+		arg1 atc.SecurityConfig
+	}{arg1})
+	stub := fake.CheckSecurityPolicyStub
+	fakeReturns := fake.checkSecurityPolicyReturns
+	fake.recordInvocation("CheckSecurityPolicy", []interface{}{arg1})
+	fake.checkSecurityPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) CheckSecurityPolicyCallCount() int {
+	fake.checkSecurityPolicyMutex.RLock()
+	defer fake.checkSecurityPolicyMutex.RUnlock()
+	return len(fake.checkSecurityPolicyArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) CheckSecurityPolicyCalls(stub func(atc.SecurityConfig) error) {
+	fake.checkSecurityPolicyMutex.Lock()
+	defer fake.checkSecurityPolicyMutex.Unlock()
+	fake.CheckSecurityPolicyStub = stub
+}
+
+func (fake *FakeTaskDelegate) CheckSecurityPolicyArgsForCall(i int) atc.SecurityConfig {
+	fake.checkSecurityPolicyMutex.RLock()
+	defer fake.checkSecurityPolicyMutex.RUnlock()
+	argsForCall := fake.checkSecurityPolicyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTaskDelegate) CheckSecurityPolicyReturns(result1 error) {
+	fake.checkSecurityPolicyMutex.Lock()
+	defer fake.checkSecurityPolicyMutex.Unlock()
+	fake.CheckSecurityPolicyStub = nil
+	fake.checkSecurityPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) CheckSecurityPolicyReturnsOnCall(i int, result1 error) {
+	fake.checkSecurityPolicyMutex.Lock()
+	defer fake.checkSecurityPolicyMutex.Unlock()
+	fake.CheckSecurityPolicyStub = nil
+	if fake.checkSecurityPolicyReturnsOnCall == nil {
+		fake.checkSecurityPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkSecurityPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTaskDelegate) Errored(arg1 lager.Logger, arg2 string) {
 	fake.erroredMutex.Lock()
 	fake.erroredArgsForCall = append(fake.erroredArgsForCall, struct {
@@ -277,6 +373,72 @@ func (fake *FakeTaskDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeTaskDelegate) Killed(arg1 lager.Logger, arg2 atc.ExitReason) {
+	fake.killedMutex.Lock()
+	fake.killedArgsForCall = append(fake.killedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.ExitReason
+	}{arg1, arg2})
+	stub := fake.KilledStub
+	fake.recordInvocation("Killed", []interface{}{arg1, arg2})
+	fake.killedMutex.Unlock()
+	if stub != nil {
+		fake.KilledStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTaskDelegate) KilledCallCount() int {
+	fake.killedMutex.RLock()
+	defer fake.killedMutex.RUnlock()
+	return len(fake.killedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) KilledCalls(stub func(lager.Logger, atc.ExitReason)) {
+	fake.killedMutex.Lock()
+	defer fake.killedMutex.Unlock()
+	fake.KilledStub = stub
+}
+
+func (fake *FakeTaskDelegate) KilledArgsForCall(i int) (lager.Logger, atc.ExitReason) {
+	fake.killedMutex.RLock()
+	defer fake.killedMutex.RUnlock()
+	argsForCall := fake.killedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTaskDelegate) PhaseTimed(arg1 lager.Logger, arg2 atc.StepTiming) {
+	fake.phaseTimedMutex.Lock()
+	fake.phaseTimedArgsForCall = append(fake.phaseTimedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.StepTiming
+	}{arg1, arg2})
+	stub := fake.PhaseTimedStub
+	fake.recordInvocation("PhaseTimed", []interface{}{arg1, arg2})
+	fake.phaseTimedMutex.Unlock()
+	if stub != nil {
+		fake.PhaseTimedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTaskDelegate) PhaseTimedCallCount() int {
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
+	return len(fake.phaseTimedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) PhaseTimedCalls(stub func(lager.Logger, atc.StepTiming)) {
+	fake.phaseTimedMutex.Lock()
+	defer fake.phaseTimedMutex.Unlock()
+	fake.PhaseTimedStub = stub
+}
+
+func (fake *FakeTaskDelegate) PhaseTimedArgsForCall(i int) (lager.Logger, atc.StepTiming) {
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
+	argsForCall := fake.phaseTimedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeTaskDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -546,6 +708,72 @@ func (fake *FakeTaskDelegate) StdoutReturnsOnCall(i int, result1 io.Writer) {
 	}{result1}
 }
 
+func (fake *FakeTaskDelegate) UsageSampled(arg1 lager.Logger, arg2 atc.StepUsage) {
+	fake.usageSampledMutex.Lock()
+	fake.usageSampledArgsForCall = append(fake.usageSampledArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.StepUsage
+	}{arg1, arg2})
+	stub := fake.UsageSampledStub
+	fake.recordInvocation("UsageSampled", []interface{}{arg1, arg2})
+	fake.usageSampledMutex.Unlock()
+	if stub != nil {
+		fake.UsageSampledStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTaskDelegate) UsageSampledCallCount() int {
+	fake.usageSampledMutex.RLock()
+	defer fake.usageSampledMutex.RUnlock()
+	return len(fake.usageSampledArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) UsageSampledCalls(stub func(lager.Logger, atc.StepUsage)) {
+	fake.usageSampledMutex.Lock()
+	defer fake.usageSampledMutex.Unlock()
+	fake.UsageSampledStub = stub
+}
+
+func (fake *FakeTaskDelegate) UsageSampledArgsForCall(i int) (lager.Logger, atc.StepUsage) {
+	fake.usageSampledMutex.RLock()
+	defer fake.usageSampledMutex.RUnlock()
+	argsForCall := fake.usageSampledArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTaskDelegate) UsageUpdated(arg1 lager.Logger, arg2 atc.StepUsage) {
+	fake.usageUpdatedMutex.Lock()
+	fake.usageUpdatedArgsForCall = append(fake.usageUpdatedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.StepUsage
+	}{arg1, arg2})
+	stub := fake.UsageUpdatedStub
+	fake.recordInvocation("UsageUpdated", []interface{}{arg1, arg2})
+	fake.usageUpdatedMutex.Unlock()
+	if stub != nil {
+		fake.UsageUpdatedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTaskDelegate) UsageUpdatedCallCount() int {
+	fake.usageUpdatedMutex.RLock()
+	defer fake.usageUpdatedMutex.RUnlock()
+	return len(fake.usageUpdatedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) UsageUpdatedCalls(stub func(lager.Logger, atc.StepUsage)) {
+	fake.usageUpdatedMutex.Lock()
+	defer fake.usageUpdatedMutex.Unlock()
+	fake.UsageUpdatedStub = stub
+}
+
+func (fake *FakeTaskDelegate) UsageUpdatedArgsForCall(i int) (lager.Logger, atc.StepUsage) {
+	fake.usageUpdatedMutex.RLock()
+	defer fake.usageUpdatedMutex.RUnlock()
+	argsForCall := fake.usageUpdatedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeTaskDelegate) WaitingForWorker(arg1 lager.Logger) {
 	fake.waitingForWorkerMutex.Lock()
 	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct {
@@ -581,6 +809,8 @@ func (fake *FakeTaskDelegate) WaitingForWorkerArgsForCall(i int) lager.Logger {
 func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.checkSecurityPolicyMutex.RLock()
+	defer fake.checkSecurityPolicyMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
 	fake.fetchImageMutex.RLock()
@@ -589,6 +819,10 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.finishedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.killedMutex.RLock()
+	defer fake.killedMutex.RUnlock()
+	fake.phaseTimedMutex.RLock()
+	defer fake.phaseTimedMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.setTaskConfigMutex.RLock()
@@ -601,6 +835,10 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stderrMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
+	fake.usageSampledMutex.RLock()
+	defer fake.usageSampledMutex.RUnlock()
+	fake.usageUpdatedMutex.RLock()
+	defer fake.usageUpdatedMutex.RUnlock()
 	fake.waitingForWorkerMutex.RLock()
 	defer fake.waitingForWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -58,6 +58,7 @@ type GetDelegate interface {
 
 	WaitingForWorker(lager.Logger)
 	SelectedWorker(lager.Logger, string)
+	PhaseTimed(lager.Logger, atc.StepTiming)
 
 	UpdateVersion(lager.Logger, atc.GetPlan, runtime.VersionResult)
 }
@@ -107,6 +108,8 @@ func (step *GetStep) Run(ctx context.Context, state RunState) (bool, error) {
 		"resource": step.plan.Resource,
 	})
 
+	ctx = runtime.WithPhaseTiming(ctx, step.planID, step.plan.Name, delegate)
+
 	ok, err := step.run(ctx, state, delegate)
 	tracing.End(span, err)
 
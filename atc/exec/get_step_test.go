@@ -189,7 +189,7 @@ var _ = Describe("GetStep", func() {
 	})
 
 	It("propagates span context to the worker client", func() {
-		Expect(runCtx).To(Equal(rewrapLogger(spanCtx)))
+		Expect(runCtx).To(Equal(rewrapLogger(runtime.WithPhaseTiming(spanCtx, atc.PlanID(planID), getPlan.Name, fakeDelegate))))
 	})
 
 	It("constructs the resource cache correctly", func() {
@@ -238,7 +238,7 @@ var _ = Describe("GetStep", func() {
 		})
 
 		It("propagates span context to the worker client", func() {
-			Expect(runCtx).To(Equal(rewrapLogger(spanCtx)))
+			Expect(runCtx).To(Equal(rewrapLogger(runtime.WithPhaseTiming(spanCtx, atc.PlanID(planID), getPlan.Name, fakeDelegate))))
 		})
 
 		It("populates the TRACEPARENT env var", func() {
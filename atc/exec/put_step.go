@@ -38,6 +38,7 @@ type PutDelegate interface {
 
 	WaitingForWorker(lager.Logger)
 	SelectedWorker(lager.Logger, string)
+	PhaseTimed(lager.Logger, atc.StepTiming)
 
 	SaveOutput(lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, runtime.VersionResult)
 }
@@ -98,6 +99,8 @@ func (step *PutStep) Run(ctx context.Context, state RunState) (bool, error) {
 		"resource": step.plan.Resource,
 	})
 
+	ctx = runtime.WithPhaseTiming(ctx, step.planID, step.plan.Name, delegate)
+
 	ok, err := step.run(ctx, state, delegate)
 	tracing.End(span, err)
 
@@ -270,7 +273,9 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 	// step.plan.Resource maps to an actual resource that may have been used outside of a pipeline context.
 	// Hence, if it was used outside the pipeline context, we don't want to save the output.
 	if step.plan.Resource != "" {
+		_, phase := runtime.StartPhase(ctx, atc.StepPhaseRegisteringOutputs)
 		delegate.SaveOutput(logger, step.plan, source, resourceTypes, versionResult)
+		phase.Finish(logger)
 	}
 
 	state.StoreResult(step.planID, versionResult)
@@ -427,7 +427,7 @@ var _ = Describe("PutStep", func() {
 	})
 
 	It("calls workerClient -> RunPutStep with the appropriate arguments", func() {
-		Expect(runCtx).To(Equal(rewrapLogger(spanCtx)))
+		Expect(runCtx).To(Equal(rewrapLogger(runtime.WithPhaseTiming(spanCtx, atc.PlanID(planID), putPlan.Name, fakeDelegate))))
 		Expect(owner).To(Equal(db.NewBuildStepContainerOwner(42, atc.PlanID(planID), 123)))
 		Expect(containerSpec.ImageSpec).To(Equal(worker.ImageSpec{
 			ResourceType: "some-resource-type",
@@ -624,7 +624,7 @@ var _ = Describe("PutStep", func() {
 		})
 
 		It("propagates span context to the worker client", func() {
-			Expect(runCtx).To(Equal(rewrapLogger(spanCtx)))
+			Expect(runCtx).To(Equal(rewrapLogger(runtime.WithPhaseTiming(spanCtx, atc.PlanID(planID), putPlan.Name, fakeDelegate))))
 		})
 
 		It("populates the TRACEPARENT env var", func() {
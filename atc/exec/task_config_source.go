@@ -156,6 +156,10 @@ func (configSource *OverrideContainerLimitsSource) FetchConfig(ctx context.Conte
 		taskConfig.Limits.Memory = configSource.Limits.Memory
 	}
 
+	if configSource.Limits.Disk != nil {
+		taskConfig.Limits.Disk = configSource.Limits.Disk
+	}
+
 	return taskConfig, nil
 }
 
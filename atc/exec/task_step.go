@@ -66,14 +66,19 @@ type TaskDelegate interface {
 	Stderr() io.Writer
 
 	SetTaskConfig(config atc.TaskConfig)
+	CheckSecurityPolicy(atc.SecurityConfig) error
 
 	Initializing(lager.Logger)
 	Starting(lager.Logger)
+	UsageUpdated(lager.Logger, atc.StepUsage)
+	UsageSampled(lager.Logger, atc.StepUsage)
+	Killed(lager.Logger, atc.ExitReason)
 	Finished(lager.Logger, ExitStatus, worker.ContainerPlacementStrategy, worker.Client)
 	Errored(lager.Logger, string)
 
 	WaitingForWorker(lager.Logger)
 	SelectedWorker(lager.Logger, string)
+	PhaseTimed(lager.Logger, atc.StepTiming)
 }
 
 // TaskStep executes a TaskConfig, whose inputs will be fetched from the
@@ -82,6 +87,8 @@ type TaskStep struct {
 	planID            atc.PlanID
 	plan              atc.TaskPlan
 	defaultLimits     atc.ContainerLimits
+	teamFactory       db.TeamFactory
+	usageInterval     time.Duration
 	metadata          StepMetadata
 	containerMetadata db.ContainerMetadata
 	strategy          worker.ContainerPlacementStrategy
@@ -95,6 +102,8 @@ func NewTaskStep(
 	planID atc.PlanID,
 	plan atc.TaskPlan,
 	defaultLimits atc.ContainerLimits,
+	teamFactory db.TeamFactory,
+	usageInterval time.Duration,
 	metadata StepMetadata,
 	containerMetadata db.ContainerMetadata,
 	strategy worker.ContainerPlacementStrategy,
@@ -107,6 +116,8 @@ func NewTaskStep(
 		planID:            planID,
 		plan:              plan,
 		defaultLimits:     defaultLimits,
+		teamFactory:       teamFactory,
+		usageInterval:     usageInterval,
 		metadata:          metadata,
 		containerMetadata: containerMetadata,
 		strategy:          strategy,
@@ -138,6 +149,8 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) (bool, error) {
 		"name": step.plan.Name,
 	})
 
+	ctx = runtime.WithPhaseTiming(ctx, step.planID, step.plan.Name, delegate)
+
 	ok, err := step.run(ctx, state, delegate)
 	tracing.End(span, err)
 
@@ -212,14 +225,29 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		return false, err
 	}
 
+	defaultLimits, err := step.teamDefaultLimits()
+	if err != nil {
+		return false, err
+	}
+
 	if config.Limits == nil {
 		config.Limits = &atc.ContainerLimits{}
 	}
 	if config.Limits.CPU == nil {
-		config.Limits.CPU = step.defaultLimits.CPU
+		config.Limits.CPU = defaultLimits.CPU
 	}
 	if config.Limits.Memory == nil {
-		config.Limits.Memory = step.defaultLimits.Memory
+		config.Limits.Memory = defaultLimits.Memory
+	}
+	if config.Limits.Disk == nil {
+		config.Limits.Disk = defaultLimits.Disk
+	}
+
+	if config.Security != nil {
+		err = delegate.CheckSecurityPolicy(*config.Security)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	delegate.Initializing(logger)
@@ -241,6 +269,15 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		Dir:          config.Run.Dir,
 		StdoutWriter: delegate.Stdout(),
 		StderrWriter: delegate.Stderr(),
+
+		UsageInterval: step.usageInterval,
+		UsageReporter: func(usage atc.ContainerUsage) {
+			delegate.UsageUpdated(logger, atc.StepUsage{
+				PlanID:   step.planID,
+				StepName: step.plan.Name,
+				Usage:    usage,
+			})
+		},
 	}
 
 	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, step.planID, step.metadata.TeamID)
@@ -260,6 +297,11 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 	delegate.SelectedWorker(logger, chosenWorker.Name())
 
 	defer func() {
+		// a task that failed to migrate has no worker left to release
+		if chosenWorker == nil {
+			return
+		}
+
 		step.workerPool.ReleaseWorker(
 			lagerctx.NewContext(ctx, logger),
 			containerSpec,
@@ -289,6 +331,41 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		delegate,
 	)
 
+	// the task was checkpointed as its worker is going away; carry on with
+	// it elsewhere
+	for runErr == nil && result.Checkpoint != nil {
+		step.workerPool.ReleaseWorker(
+			lagerctx.NewContext(ctx, logger),
+			containerSpec,
+			chosenWorker,
+			step.strategy,
+		)
+
+		chosenWorker, result, runErr = step.migrate(
+			lagerctx.NewContext(processCtx, logger),
+			delegate,
+			owner,
+			containerSpec,
+			step.workerSpec(config),
+			processSpec,
+			result,
+		)
+	}
+
+	if result.Usage != nil {
+		delegate.UsageSampled(logger, atc.StepUsage{
+			PlanID:   step.planID,
+			StepName: step.plan.Name,
+			Usage:    *result.Usage,
+		})
+	}
+
+	if result.ExitReason != nil {
+		delegate.Killed(logger, *result.ExitReason)
+	}
+
+	_, phase := runtime.StartPhase(ctx, atc.StepPhaseRegisteringOutputs)
+
 	step.registerOutputs(logger, repository, config, result.VolumeMounts, step.containerMetadata)
 
 	// Do not initialize caches for one-off builds
@@ -298,6 +375,8 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		}
 	}
 
+	phase.Finish(logger)
+
 	if runErr != nil {
 		if errors.Is(runErr, context.DeadlineExceeded) {
 			delegate.Errored(logger, TimeoutLogMessage)
@@ -312,6 +391,92 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 	return result.ExitStatus == 0, nil
 }
 
+// migrate restores a checkpointed task on another worker that supports
+// checkpoint/restore. The volumes of the checkpointed container are streamed
+// over as inputs at the same paths, along with the checkpoint itself.
+func (step *TaskStep) migrate(
+	ctx context.Context,
+	delegate TaskDelegate,
+	owner db.ContainerOwner,
+	containerSpec worker.ContainerSpec,
+	workerSpec worker.WorkerSpec,
+	processSpec runtime.ProcessSpec,
+	checkpointed worker.TaskResult,
+) (worker.Client, worker.TaskResult, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	inputs := map[string]runtime.Artifact{}
+	for _, mount := range checkpointed.VolumeMounts {
+		// the rootfs is recreated from the image, and volumes that aren't
+		// mounted (e.g. the checkpoint) don't have an absolute path
+		if mount.MountPath == "/" || !path.IsAbs(mount.MountPath) {
+			continue
+		}
+
+		inputs[mount.MountPath] = &runtime.TaskArtifact{VolumeHandle: mount.Volume.Handle()}
+	}
+
+	var err error
+	containerSpec.Inputs, err = step.artifactSourcer.SourceInputsAndCaches(logger, step.metadata.TeamID, inputs)
+	if err != nil {
+		return nil, worker.TaskResult{}, err
+	}
+
+	containerSpec.Checkpoint, err = step.artifactSourcer.SourceImage(logger, &runtime.TaskArtifact{
+		VolumeHandle: checkpointed.Checkpoint.Handle(),
+	})
+	if err != nil {
+		return nil, worker.TaskResult{}, err
+	}
+
+	workerSpec.CheckpointRestore = true
+
+	chosenWorker, _, err := step.workerPool.SelectWorker(
+		ctx,
+		owner,
+		containerSpec,
+		workerSpec,
+		step.strategy,
+		delegate,
+	)
+	if err != nil {
+		return nil, worker.TaskResult{}, err
+	}
+
+	fmt.Fprintf(delegate.Stderr(), "\x1b[1;36mINFO: worker is going away; migrating task to %s\x1b[0m\n", chosenWorker.Name())
+
+	delegate.SelectedWorker(logger, chosenWorker.Name())
+
+	result, err := chosenWorker.RunTaskStep(
+		ctx,
+		owner,
+		containerSpec,
+		step.containerMetadata,
+		processSpec,
+		migratingDelegate{TaskDelegate: delegate, checkpointed: checkpointed.Container},
+	)
+
+	return chosenWorker, result, err
+}
+
+// migratingDelegate gives up on the container that a task was checkpointed
+// in once the task is starting on another worker, by which point the volumes
+// of the container have been streamed over. Marking it as destroying lets its
+// worker finish landing.
+type migratingDelegate struct {
+	TaskDelegate
+	checkpointed worker.Container
+}
+
+func (delegate migratingDelegate) Starting(logger lager.Logger) {
+	err := delegate.checkpointed.MarkAsDestroying()
+	if err != nil {
+		logger.Error("failed-to-mark-checkpointed-container-as-destroying", err)
+	}
+
+	delegate.TaskDelegate.Starting(logger)
+}
+
 func (step *TaskStep) imageSpec(ctx context.Context, logger lager.Logger, state RunState, delegate TaskDelegate, config atc.TaskConfig) (worker.ImageSpec, error) {
 	imageSpec := worker.ImageSpec{
 		Privileged: bool(step.plan.Privileged),
@@ -406,11 +571,41 @@ func (step *TaskStep) containerInputs(logger lager.Logger, repository *build.Rep
 	return containerInputs, nil
 }
 
+// teamDefaultLimits returns the default container limits of the team running
+// the step, falling back to the cluster-wide defaults for any that the team
+// does not configure.
+func (step *TaskStep) teamDefaultLimits() (atc.ContainerLimits, error) {
+	limits := step.defaultLimits
+
+	team, found, err := step.teamFactory.FindTeam(step.metadata.TeamName)
+	if err != nil {
+		return atc.ContainerLimits{}, err
+	}
+
+	if !found {
+		return limits, nil
+	}
+
+	teamLimits := team.ContainerLimits()
+	if teamLimits.CPU != nil {
+		limits.CPU = teamLimits.CPU
+	}
+	if teamLimits.Memory != nil {
+		limits.Memory = teamLimits.Memory
+	}
+	if teamLimits.Disk != nil {
+		limits.Disk = teamLimits.Disk
+	}
+
+	return limits, nil
+}
+
 func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSpec worker.ImageSpec, config atc.TaskConfig, metadata db.ContainerMetadata) (worker.ContainerSpec, error) {
 	var limits worker.ContainerLimits
 	if config.Limits != nil {
 		limits.CPU = (*uint64)(config.Limits.CPU)
 		limits.Memory = (*uint64)(config.Limits.Memory)
+		limits.Disk = (*uint64)(config.Limits.Disk)
 	}
 
 	containerSpec := worker.ContainerSpec{
@@ -419,10 +614,14 @@ func (step *TaskStep) containerSpec(logger lager.Logger, state RunState, imageSp
 		TeamName:  step.metadata.TeamName,
 		Type:      metadata.Type,
 
-		Dir:    metadata.WorkingDirectory,
-		Env:    config.Params.Env(),
-		Limits: limits,
-		User:   config.Run.User,
+		Dir:      metadata.WorkingDirectory,
+		Env:      config.Params.Env(),
+		Limits:   limits,
+		Network:  step.plan.Network,
+		Security: config.Security,
+		User:     config.Run.User,
+
+		Migratable: step.plan.Migratable,
 
 		Outputs: worker.OutputPaths{},
 	}
@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
@@ -39,6 +41,8 @@ var _ = Describe("TaskStep", func() {
 		fakeArtifactStreamer *workerfakes.FakeArtifactStreamer
 		fakeArtifactSourcer  *workerfakes.FakeArtifactSourcer
 		fakeStrategy         *workerfakes.FakeContainerPlacementStrategy
+		fakeTeamFactory      *dbfakes.FakeTeamFactory
+		defaultLimits        atc.ContainerLimits
 
 		spanCtx      context.Context
 		fakeDelegate *execfakes.FakeTaskDelegate
@@ -88,6 +92,8 @@ var _ = Describe("TaskStep", func() {
 		fakeArtifactStreamer = new(workerfakes.FakeArtifactStreamer)
 		fakeArtifactSourcer = new(workerfakes.FakeArtifactSourcer)
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		defaultLimits = atc.ContainerLimits{}
 
 		fakeDelegate = new(execfakes.FakeTaskDelegate)
 		fakeDelegate.StdoutReturns(stdoutBuf)
@@ -138,7 +144,9 @@ var _ = Describe("TaskStep", func() {
 		taskStep = exec.NewTaskStep(
 			plan.ID,
 			*plan.Task,
-			atc.ContainerLimits{},
+			defaultLimits,
+			fakeTeamFactory,
+			10*time.Second,
 			stepMetadata,
 			containerMetadata,
 			fakeStrategy,
@@ -256,6 +264,20 @@ var _ = Describe("TaskStep", func() {
 			Expect(processSpec.StderrWriter).To(Equal(stderrBuf))
 			Expect(processSpec.Path).To(Equal("ls"))
 			Expect(processSpec.Args).To(Equal([]string{"some", "args"}))
+			Expect(processSpec.UsageInterval).To(Equal(10 * time.Second))
+		})
+
+		It("reports the usage of the running task to the delegate", func() {
+			usage := atc.ContainerUsage{MemoryPeak: 2048, Samples: 1}
+			processSpec.UsageReporter(usage)
+
+			Expect(fakeDelegate.UsageUpdatedCallCount()).To(Equal(1))
+			_, stepUsage := fakeDelegate.UsageUpdatedArgsForCall(0)
+			Expect(stepUsage).To(Equal(atc.StepUsage{
+				PlanID:   planID,
+				StepName: "some-task",
+				Usage:    usage,
+			}))
 		})
 
 		It("sets the config on the TaskDelegate", func() {
@@ -291,6 +313,54 @@ var _ = Describe("TaskStep", func() {
 			Expect(atc.MemoryLimit(*containerSpec.Limits.Memory)).To(Equal(atc.MemoryLimit(1024)))
 		})
 
+		Context("when default limits are configured", func() {
+			var (
+				defaultDisk atc.DiskLimit
+				teamDisk    atc.DiskLimit
+				teamMemory  atc.MemoryLimit
+			)
+
+			BeforeEach(func() {
+				defaultDisk = atc.DiskLimit(4096)
+				defaultLimits = atc.ContainerLimits{Disk: &defaultDisk}
+			})
+
+			It("applies the cluster-wide defaults to unset limits", func() {
+				Expect(*containerSpec.Limits.Disk).To(Equal(uint64(4096)))
+				Expect(atc.MemoryLimit(*containerSpec.Limits.Memory)).To(Equal(atc.MemoryLimit(1024)))
+			})
+
+			Context("when the team has default limits", func() {
+				BeforeEach(func() {
+					teamDisk = atc.DiskLimit(8192)
+					teamMemory = atc.MemoryLimit(2048)
+
+					fakeTeam := new(dbfakes.FakeTeam)
+					fakeTeam.ContainerLimitsReturns(atc.ContainerLimits{Disk: &teamDisk, Memory: &teamMemory})
+					fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("prefers the team's defaults over the cluster-wide defaults", func() {
+					Expect(*containerSpec.Limits.Disk).To(Equal(uint64(8192)))
+				})
+
+				It("does not override limits set in the config", func() {
+					Expect(atc.MemoryLimit(*containerSpec.Limits.Memory)).To(Equal(atc.MemoryLimit(1024)))
+				})
+			})
+
+			Context("when looking up the team fails", func() {
+				BeforeEach(func() {
+					fakeTeamFactory.FindTeamReturns(nil, false, errors.New("nope"))
+					shouldRunTaskStep = false
+				})
+
+				It("errors", func() {
+					Expect(stepErr).To(MatchError("nope"))
+				})
+			})
+		})
+
 		Context("when toplevel limits are set", func() {
 			BeforeEach(func() {
 				cpu := atc.CPULimit(2048)
@@ -307,6 +377,155 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when network config is set", func() {
+			BeforeEach(func() {
+				taskPlan.Network = &atc.NetworkConfig{None: true}
+			})
+
+			It("configures the container's network", func() {
+				Expect(containerSpec.Network).To(Equal(&atc.NetworkConfig{None: true}))
+			})
+		})
+
+		Context("when a security config is set", func() {
+			BeforeEach(func() {
+				taskPlan.Config.Security = &atc.SecurityConfig{
+					Devices: []string{"/dev/fuse"},
+				}
+			})
+
+			It("checks the security policy", func() {
+				Expect(fakeDelegate.CheckSecurityPolicyCallCount()).To(Equal(1))
+				Expect(fakeDelegate.CheckSecurityPolicyArgsForCall(0)).To(Equal(atc.SecurityConfig{
+					Devices: []string{"/dev/fuse"},
+				}))
+			})
+
+			It("configures the container's security", func() {
+				Expect(containerSpec.Security).To(Equal(&atc.SecurityConfig{
+					Devices: []string{"/dev/fuse"},
+				}))
+			})
+
+			Context("when the policy check fails", func() {
+				BeforeEach(func() {
+					fakeDelegate.CheckSecurityPolicyReturns(errors.New("policy-check-error"))
+					shouldRunTaskStep = false
+				})
+
+				It("errors without running the task", func() {
+					Expect(stepErr).To(MatchError("policy-check-error"))
+					Expect(fakePool.SelectWorkerCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the task is migratable", func() {
+			BeforeEach(func() {
+				taskPlan.Migratable = true
+			})
+
+			It("creates a migratable container", func() {
+				Expect(containerSpec.Migratable).To(BeTrue())
+			})
+
+			Context("when the task is checkpointed", func() {
+				var (
+					fakeCheckpoint *workerfakes.FakeVolume
+					fakeScratch    *workerfakes.FakeVolume
+					fakeContainer  *workerfakes.FakeContainer
+					otherClient    *workerfakes.FakeClient
+				)
+
+				BeforeEach(func() {
+					fakeCheckpoint = new(workerfakes.FakeVolume)
+					fakeCheckpoint.HandleReturns("checkpoint-handle")
+					fakeScratch = new(workerfakes.FakeVolume)
+					fakeScratch.HandleReturns("scratch-handle")
+					fakeContainer = new(workerfakes.FakeContainer)
+
+					fakeClient.RunTaskStepReturns(worker.TaskResult{
+						VolumeMounts: []worker.VolumeMount{
+							{Volume: new(workerfakes.FakeVolume), MountPath: "/"},
+							{Volume: fakeScratch, MountPath: "/scratch"},
+							{Volume: fakeCheckpoint, MountPath: "checkpoint-no-mount:"},
+						},
+						Checkpoint: fakeCheckpoint,
+						Container:  fakeContainer,
+					}, nil)
+
+					otherClient = new(workerfakes.FakeClient)
+					otherClient.NameReturns("other-worker")
+					otherClient.RunTaskStepStub = func(ctx context.Context, owner db.ContainerOwner, spec worker.ContainerSpec, metadata db.ContainerMetadata, processSpec runtime.ProcessSpec, delegate runtime.StartingEventDelegate) (worker.TaskResult, error) {
+						delegate.Starting(lagertest.NewTestLogger("test"))
+						return worker.TaskResult{ExitStatus: 0}, nil
+					}
+					fakePool.SelectWorkerReturnsOnCall(1, otherClient, 0, nil)
+
+					fakeArtifactSourcer.SourceImageReturns(new(workerfakes.FakeStreamableArtifactSource), nil)
+				})
+
+				It("restores the task on a worker supporting checkpoint/restore", func() {
+					Expect(fakePool.SelectWorkerCallCount()).To(Equal(2))
+					_, selectOwner, _, workerSpec, _, _ := fakePool.SelectWorkerArgsForCall(1)
+					Expect(selectOwner).To(Equal(owner))
+					Expect(workerSpec.CheckpointRestore).To(BeTrue())
+
+					Expect(otherClient.RunTaskStepCallCount()).To(Equal(1))
+					_, _, restoredSpec, _, _, _ := otherClient.RunTaskStepArgsForCall(0)
+					Expect(restoredSpec.Checkpoint).ToNot(BeNil())
+
+					Expect(stepOk).To(BeTrue())
+					Expect(stepErr).ToNot(HaveOccurred())
+				})
+
+				It("streams the mounted volumes over as inputs", func() {
+					_, _, inputMap := fakeArtifactSourcer.SourceInputsAndCachesArgsForCall(1)
+					Expect(inputMap).To(Equal(map[string]runtime.Artifact{
+						"/scratch": &runtime.TaskArtifact{VolumeHandle: "scratch-handle"},
+					}))
+
+					_, checkpoint := fakeArtifactSourcer.SourceImageArgsForCall(0)
+					Expect(checkpoint).To(Equal(&runtime.TaskArtifact{VolumeHandle: "checkpoint-handle"}))
+				})
+
+				It("releases the worker it was checkpointed on", func() {
+					Expect(fakePool.ReleaseWorkerCallCount()).To(Equal(2))
+					_, _, released, _ := fakePool.ReleaseWorkerArgsForCall(0)
+					Expect(released).To(Equal(fakeClient))
+					_, _, released, _ = fakePool.ReleaseWorkerArgsForCall(1)
+					Expect(released).To(Equal(otherClient))
+				})
+
+				It("gives up on the checkpointed container once restored", func() {
+					Expect(fakeContainer.MarkAsDestroyingCallCount()).To(Equal(1))
+					Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+				})
+
+				It("tells the user", func() {
+					Expect(stderrBuf).To(gbytes.Say("migrating task to other-worker"))
+					Expect(fakeDelegate.SelectedWorkerCallCount()).To(Equal(2))
+				})
+
+				It("finishes on the worker it was restored on", func() {
+					Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+					_, _, _, finishedWorker := fakeDelegate.FinishedArgsForCall(0)
+					Expect(finishedWorker).To(Equal(otherClient))
+				})
+
+				Context("when no other worker can restore it", func() {
+					BeforeEach(func() {
+						fakePool.SelectWorkerReturnsOnCall(1, nil, 0, errors.New("no workers"))
+					})
+
+					It("errors", func() {
+						Expect(stepErr).To(MatchError("no workers"))
+						Expect(fakePool.ReleaseWorkerCallCount()).To(Equal(1))
+					})
+				})
+			})
+		})
+
 		Context("when a timeout is configured", func() {
 			BeforeEach(func() {
 				taskPlan.Timeout = "1h"
@@ -378,7 +597,7 @@ var _ = Describe("TaskStep", func() {
 			})
 
 			It("propagates span context to the worker client", func() {
-				Expect(runCtx).To(Equal(rewrapLogger(spanCtx)))
+				Expect(runCtx).To(Equal(rewrapLogger(runtime.WithPhaseTiming(spanCtx, planID, taskPlan.Name, fakeDelegate))))
 			})
 
 			It("populates the TRACEPARENT env var", func() {
@@ -996,6 +1215,70 @@ var _ = Describe("TaskStep", func() {
 					Expect(stepErr).ToNot(HaveOccurred())
 				})
 
+				It("does not report any usage", func() {
+					Expect(fakeDelegate.UsageSampledCallCount()).To(BeZero())
+				})
+
+				Context("when the usage of the container was sampled", func() {
+					BeforeEach(func() {
+						fakeClient.RunTaskStepReturns(worker.TaskResult{
+							ExitStatus: taskStepStatus,
+							Usage: &atc.ContainerUsage{
+								CPUAverage: 0.5,
+								MemoryPeak: 1024,
+								Samples:    2,
+							},
+						}, nil)
+					})
+
+					It("reports the usage to the delegate", func() {
+						Expect(fakeDelegate.UsageSampledCallCount()).To(Equal(1))
+						_, usage := fakeDelegate.UsageSampledArgsForCall(0)
+						Expect(usage).To(Equal(atc.StepUsage{
+							PlanID:   planID,
+							StepName: "some-task",
+							Usage: atc.ContainerUsage{
+								CPUAverage: 0.5,
+								MemoryPeak: 1024,
+								Samples:    2,
+							},
+						}))
+					})
+				})
+
+				It("does not report the task as killed", func() {
+					Expect(fakeDelegate.KilledCallCount()).To(BeZero())
+				})
+
+				Context("when the worker reported why the process was killed", func() {
+					BeforeEach(func() {
+						fakeClient.RunTaskStepReturns(worker.TaskResult{
+							ExitStatus: 137,
+							ExitReason: &atc.ExitReason{
+								Signal:      "SIGKILL",
+								OOMKilled:   true,
+								MemoryLimit: 1024,
+								MemoryPeak:  1024,
+							},
+						}, nil)
+					})
+
+					It("reports the exit reason to the delegate", func() {
+						Expect(fakeDelegate.KilledCallCount()).To(Equal(1))
+						_, reason := fakeDelegate.KilledArgsForCall(0)
+						Expect(reason).To(Equal(atc.ExitReason{
+							Signal:      "SIGKILL",
+							OOMKilled:   true,
+							MemoryLimit: 1024,
+							MemoryPeak:  1024,
+						}))
+
+						Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+						_, status, _, _ := fakeDelegate.FinishedArgsForCall(0)
+						Expect(status).To(Equal(exec.ExitStatus(137)))
+					})
+				})
+
 				Describe("the registered artifacts", func() {
 					var (
 						artifact1 runtime.Artifact
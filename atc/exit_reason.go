@@ -0,0 +1,14 @@
+package atc
+
+// ExitReason explains why a step's process was killed by a signal, as
+// reported by workers which detect it (i.e. the containerd runtime).
+//
+// When the kernel killed the process for exceeding the memory limit of its
+// container, OOMKilled is set along with the limit and the peak memory usage
+// in bytes, if known.
+type ExitReason struct {
+	Signal      string `json:"signal"`
+	OOMKilled   bool   `json:"oom_killed,omitempty"`
+	MemoryLimit uint64 `json:"memory_limit,omitempty"`
+	MemoryPeak  uint64 `json:"memory_peak,omitempty"`
+}
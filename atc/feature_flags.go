@@ -8,4 +8,5 @@ var (
 	EnablePipelineInstances              bool
 	EnableCacheStreamedVolumes           bool
 	EnableResourceCausality              bool
+	EnableBuildLogSearch                 bool
 )
@@ -0,0 +1,20 @@
+package atc
+
+// FlakyJob summarizes a job whose builds were found to be flaky within a
+// window of time. A build is flaky when it and its reruns, which all run with
+// the same inputs, didn't all succeed or all fail.
+type FlakyJob struct {
+	TeamName             string       `json:"team_name"`
+	PipelineName         string       `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	JobName              string       `json:"job_name"`
+
+	// FlakyBuilds is the number of builds that were flaky, counting a build
+	// and its reruns once, out of the number of Builds of the job.
+	FlakyBuilds int `json:"flaky_builds"`
+	Builds      int `json:"builds"`
+
+	// LastFlaky is when a flaky build last finished, in seconds since the
+	// epoch.
+	LastFlaky int64 `json:"last_flaky,omitempty"`
+}
@@ -0,0 +1,47 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type auditLogCollector struct {
+	auditLog  db.AuditLog
+	retention time.Duration
+}
+
+// NewAuditLogCollector removes the events of the audit log once they are
+// older than the retention. A retention of 0 keeps every event.
+func NewAuditLogCollector(auditLog db.AuditLog, retention time.Duration) *auditLogCollector {
+	return &auditLogCollector{
+		auditLog:  auditLog,
+		retention: retention,
+	}
+}
+
+func (c *auditLogCollector) Run(ctx context.Context) error {
+	if c.retention == 0 {
+		return nil
+	}
+
+	logger := lagerctx.FromContext(ctx).Session("audit-log-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	removed, err := c.auditLog.RemoveEventsBefore(time.Now().Add(-c.retention))
+	if err != nil {
+		logger.Error("failed-to-remove-audit-events", err)
+		return err
+	}
+
+	if removed > 0 {
+		logger.Debug("removed-audit-events", lager.Data{"count": removed})
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditLogCollector", func() {
+	var (
+		collector    GcCollector
+		fakeAuditLog *dbfakes.FakeAuditLog
+		retention    time.Duration
+	)
+
+	BeforeEach(func() {
+		fakeAuditLog = new(dbfakes.FakeAuditLog)
+		retention = 24 * time.Hour
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewAuditLogCollector(fakeAuditLog, retention)
+	})
+
+	Describe("Run", func() {
+		It("removes the events older than the retention", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeAuditLog.RemoveEventsBeforeCallCount()).To(Equal(1))
+			before := fakeAuditLog.RemoveEventsBeforeArgsForCall(0)
+			Expect(before).To(BeTemporally("~", time.Now().Add(-retention), time.Minute))
+		})
+
+		Context("when removing the events fails", func() {
+			BeforeEach(func() {
+				fakeAuditLog.RemoveEventsBeforeReturns(0, errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).To(MatchError("nope"))
+			})
+		})
+
+		Context("when the retention is 0", func() {
+			BeforeEach(func() {
+				retention = 0
+			})
+
+			It("keeps every event", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeAuditLog.RemoveEventsBeforeCallCount()).To(BeZero())
+			})
+		})
+	})
+})
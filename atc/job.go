@@ -12,6 +12,7 @@ type Job struct {
 
 	Paused       bool `json:"paused,omitempty"`
 	HasNewInputs bool `json:"has_new_inputs,omitempty"`
+	Flaky        bool `json:"flaky,omitempty"`
 
 	Groups []string `json:"groups,omitempty"`
 
@@ -8,6 +8,8 @@ type JobConfig struct {
 	DisableManualTrigger bool     `json:"disable_manual_trigger,omitempty"`
 	Serial               bool     `json:"serial,omitempty"`
 	Interruptible        bool     `json:"interruptible,omitempty"`
+	Migratable           bool     `json:"migratable,omitempty"`
+	RetryOnFlake         int      `json:"retry_on_flake,omitempty"`
 	SerialGroups         []string `json:"serial_groups,omitempty"`
 	RawMaxInFlight       int      `json:"max_in_flight,omitempty"`
 	BuildLogsToRetain    int      `json:"build_logs_to_retain,omitempty"`
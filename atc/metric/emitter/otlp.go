@@ -0,0 +1,572 @@
+package emitter
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/metric"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/lastvalue"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/sum"
+	"go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/unit"
+	"google.golang.org/grpc/credentials"
+)
+
+// gauges which have not been emitted for this long are no longer exported,
+// e.g. those of workers which have gone away
+const otlpStaleGaugeTimeout = 5 * time.Minute
+
+type OTLPConfig struct {
+	Address  string            `long:"otlp-metrics-address" description:"OTLP address to send metrics to."`
+	Headers  map[string]string `long:"otlp-metrics-header" description:"Header to attach to each metrics export. Can be specified multiple times." value-name:"NAME:VALUE"`
+	UseTLS   bool              `long:"otlp-metrics-use-tls" description:"Whether to use TLS when sending metrics."`
+	Interval time.Duration     `long:"otlp-metrics-interval" default:"15s" description:"Interval on which metrics are exported."`
+
+	// Resource describes the process emitting the metrics. The ATC sets it
+	// to the resource its traces are exported with.
+	Resource *resource.Resource `no-flag:"true"`
+}
+
+// OTLP is the registered OTLP emitter configuration, so that the ATC can
+// set its Resource.
+var OTLP = &OTLPConfig{}
+
+func init() {
+	metric.Metrics.RegisterEmitter(OTLP)
+}
+
+func (config *OTLPConfig) Description() string { return "OTLP" }
+func (config *OTLPConfig) IsConfigured() bool  { return config.Address != "" }
+
+func (config *OTLPConfig) security() otlpgrpc.Option {
+	if config.UseTLS {
+		return otlpgrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, ""))
+	}
+
+	return otlpgrpc.WithInsecure()
+}
+
+func (config *OTLPConfig) NewEmitter(attributes map[string]string) (metric.Emitter, error) {
+	driver := otlpgrpc.NewDriver(
+		otlpgrpc.WithEndpoint(config.Address),
+		otlpgrpc.WithHeaders(config.Headers),
+		config.security(),
+	)
+
+	exporter, err := otlp.NewExporter(context.TODO(), driver)
+	if err != nil {
+		return nil, err
+	}
+
+	// the metrics attributes describe the whole process, so they are sent
+	// once with the resource rather than with every measurement
+	var resourceAttributes []attribute.KeyValue
+	for key, value := range attributes {
+		resourceAttributes = append(resourceAttributes, attribute.String(key, value))
+	}
+
+	controller := basic.New(
+		processor.New(OTLPAggregatorSelector(), exporter),
+		basic.WithExporter(exporter),
+		basic.WithResource(resource.Merge(config.Resource, resource.NewWithAttributes(resourceAttributes...))),
+		basic.WithCollectPeriod(config.Interval),
+	)
+
+	err = controller.Start(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOTLPEmitter(controller.MeterProvider().Meter("concourse"), attributes), nil
+}
+
+type otlpInstrumentKind int
+
+const (
+	otlpGauge otlpInstrumentKind = iota
+	otlpCounter
+	otlpUpDownCounter
+	otlpHistogram
+)
+
+type otlpInstrument struct {
+	name        string
+	kind        otlpInstrumentKind
+	unit        unit.Unit
+	description string
+
+	// convert turns the event's value into a measurement in the instrument's
+	// unit; values are measured as-is if it is nil
+	convert func(float64) float64
+
+	// boundaries of the buckets of histograms
+	boundaries []float64
+}
+
+var (
+	msToSeconds = func(value float64) float64 { return value / 1000 }
+
+	// lock held events are 1 when acquired and 0 when released
+	lockDelta = func(value float64) float64 {
+		if value == 1 {
+			return 1
+		}
+
+		return -1
+	}
+
+	buildDurationBoundaries = []float64{1, 60, 180, 300, 600, 900, 1200, 1800, 2700, 3600, 7200, 18000, 36000}
+	gcDurationBoundaries    = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+	cpuBoundaries           = []float64{0.1, 0.25, 0.5, 1, 2, 4, 8, 16, 32}
+	memoryBoundaries        = []float64{64 << 20, 128 << 20, 256 << 20, 512 << 20, 1 << 30, 2 << 30, 4 << 30, 8 << 30, 16 << 30, 32 << 30}
+
+	seconds = unit.Unit("s")
+)
+
+// otlpInstruments maps the names of events to the instruments they are
+// measured with. Events which are not listed are exported as gauges.
+var otlpInstruments = map[string]otlpInstrument{
+	// the values of these are build IDs, and builds are already counted by
+	// "builds started"
+	"build started":       {},
+	"check build started": {},
+
+	"jobs scheduled":  {name: "concourse.jobs.scheduled", kind: otlpCounter, description: "Number of jobs scheduled"},
+	"jobs scheduling": {name: "concourse.jobs.scheduling", kind: otlpGauge, description: "Number of jobs currently being scheduled"},
+
+	"builds started":       {name: "concourse.builds.started", kind: otlpCounter, description: "Number of builds started"},
+	"builds running":       {name: "concourse.builds.running", kind: otlpGauge, description: "Number of builds currently running"},
+	"check builds started": {name: "concourse.check_builds.started", kind: otlpCounter, description: "Number of check builds started"},
+	"check builds running": {name: "concourse.check_builds.running", kind: otlpGauge, description: "Number of check builds currently running"},
+
+	"build finished": {
+		name:        "concourse.builds.duration",
+		kind:        otlpHistogram,
+		unit:        seconds,
+		description: "Duration of finished builds",
+		convert:     msToSeconds,
+		boundaries:  buildDurationBoundaries,
+	},
+	"check build finished": {
+		name:        "concourse.check_builds.duration",
+		kind:        otlpHistogram,
+		unit:        seconds,
+		description: "Duration of finished check builds",
+		convert:     msToSeconds,
+		boundaries:  []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+	},
+
+	"steps waiting": {name: "concourse.steps.waiting", kind: otlpGauge, description: "Number of build steps currently waiting for a worker"},
+	"steps waiting duration": {
+		name:        "concourse.steps.wait_duration",
+		kind:        otlpHistogram,
+		unit:        seconds,
+		description: "Time build steps waited for a worker",
+		boundaries:  []float64{10, 30, 60, 120, 300, 600, 1800, 2400, 3000, 3600},
+	},
+	"step phase duration": {
+		name:        "concourse.steps.phase_duration",
+		kind:        otlpHistogram,
+		unit:        seconds,
+		description: "Time taken by each phase of build steps, e.g. waiting for a worker or streaming inputs",
+		boundaries:  []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600},
+	},
+	"step streamed bytes": {
+		name:        "concourse.steps.streamed_bytes",
+		kind:        otlpCounter,
+		unit:        unit.Bytes,
+		description: "Number of bytes streamed through the ATC for build steps",
+	},
+
+	"task peak cpu usage": {
+		name:        "concourse.tasks.cpu_peak",
+		kind:        otlpHistogram,
+		description: "Peak CPU usage of task containers in cores",
+		boundaries:  cpuBoundaries,
+	},
+	"task average cpu usage": {
+		name:        "concourse.tasks.cpu_average",
+		kind:        otlpHistogram,
+		description: "Average CPU usage of task containers in cores",
+		boundaries:  cpuBoundaries,
+	},
+	"task peak memory usage": {
+		name:        "concourse.tasks.memory_peak",
+		kind:        otlpHistogram,
+		unit:        unit.Bytes,
+		description: "Peak memory usage of task containers",
+		boundaries:  memoryBoundaries,
+	},
+	"task average memory usage": {
+		name:        "concourse.tasks.memory_average",
+		kind:        otlpHistogram,
+		unit:        unit.Bytes,
+		description: "Average memory usage of task containers",
+		boundaries:  memoryBoundaries,
+	},
+
+	"http response time": {
+		name:        "concourse.http_responses.duration",
+		kind:        otlpHistogram,
+		unit:        seconds,
+		description: "Response time of API requests",
+		convert:     msToSeconds,
+		boundaries:  []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	},
+	"concurrent requests":           {name: "concourse.concurrent_requests", kind: otlpGauge, description: "Number of requests being served by endpoints with a concurrency limit"},
+	"concurrent requests limit hit": {name: "concourse.concurrent_requests.limit_hit", kind: otlpCounter, description: "Number of requests rejected for exceeding the concurrency limit"},
+
+	"database queries":     {name: "concourse.db.queries", kind: otlpCounter, description: "Number of database queries"},
+	"database connections": {name: "concourse.db.connections", kind: otlpGauge, description: "Number of open database connections"},
+
+	"lock held": {name: "concourse.locks.held", kind: otlpUpDownCounter, description: "Number of database locks held", convert: lockDelta},
+
+	"error log": {name: "concourse.error.logs", kind: otlpCounter, description: "Number of errors logged"},
+
+	"checks started":  {name: "concourse.checks.started", kind: otlpCounter, description: "Number of checks started"},
+	"checks finished": {name: "concourse.checks.finished", kind: otlpCounter, description: "Number of checks finished"},
+	"checks enqueued": {name: "concourse.checks.enqueued", kind: otlpCounter, description: "Number of checks enqueued"},
+
+	"containers created": {name: "concourse.containers.created", kind: otlpCounter, description: "Number of containers created"},
+	"containers deleted": {name: "concourse.containers.deleted", kind: otlpCounter, description: "Number of containers deleted"},
+	"failed containers":  {name: "concourse.containers.failed", kind: otlpCounter, description: "Number of containers which failed to be created"},
+	"volumes created":    {name: "concourse.volumes.created", kind: otlpCounter, description: "Number of volumes created"},
+	"volumes deleted":    {name: "concourse.volumes.deleted", kind: otlpCounter, description: "Number of volumes deleted"},
+	"failed volumes":     {name: "concourse.volumes.failed", kind: otlpCounter, description: "Number of volumes which failed to be created"},
+	"volumes streamed":   {name: "concourse.volumes.streamed", kind: otlpCounter, description: "Number of volumes streamed between workers"},
+
+	"get step cache hits":      {name: "concourse.caches.get_step_cache_hits", kind: otlpCounter, description: "Number of get steps which hit a cache"},
+	"streamed resource caches": {name: "concourse.caches.streamed_resource_caches", kind: otlpCounter, description: "Number of resource caches streamed between workers"},
+
+	"GC container collector job dropped": {name: "concourse.gc.container_collector.jobs_dropped", kind: otlpCounter, description: "Number of container collector jobs dropped"},
+
+	"scheduling: job duration (ms)":                             gcDuration("concourse.scheduling.job_duration", "Time taken to schedule a job"),
+	"gc: build collector duration (ms)":                         gcDuration("concourse.gc.build_collector.duration", "Time taken by the build collector"),
+	"gc: worker collector duration (ms)":                        gcDuration("concourse.gc.worker_collector.duration", "Time taken by the worker collector"),
+	"gc: resource cache use collector duration (ms)":            gcDuration("concourse.gc.resource_cache_use_collector.duration", "Time taken by the resource cache use collector"),
+	"gc: resource config collector duration (ms)":               gcDuration("concourse.gc.resource_config_collector.duration", "Time taken by the resource config collector"),
+	"gc: resource cache collector duration (ms)":                gcDuration("concourse.gc.resource_cache_collector.duration", "Time taken by the resource cache collector"),
+	"gc: task cache collector duration (ms)":                    gcDuration("concourse.gc.task_cache_collector.duration", "Time taken by the task cache collector"),
+	"gc: resource config check session collector duration (ms)": gcDuration("concourse.gc.resource_config_check_session_collector.duration", "Time taken by the resource config check session collector"),
+	"gc: artifact collector duration (ms)":                      gcDuration("concourse.gc.artifact_collector.duration", "Time taken by the artifact collector"),
+	"gc: container collector duration (ms)":                     gcDuration("concourse.gc.container_collector.duration", "Time taken by the container collector"),
+	"gc: volume collector duration (ms)":                        gcDuration("concourse.gc.volume_collector.duration", "Time taken by the volume collector"),
+
+	"worker containers":            {name: "concourse.workers.containers", kind: otlpGauge, description: "Number of containers per worker"},
+	"worker volumes":               {name: "concourse.workers.volumes", kind: otlpGauge, description: "Number of volumes per worker"},
+	"worker tasks":                 {name: "concourse.workers.tasks", kind: otlpGauge, description: "Number of active tasks per worker"},
+	"worker unknown containers":    {name: "concourse.workers.unknown_containers", kind: otlpGauge, description: "Number of unknown containers found on workers"},
+	"worker unknown volumes":       {name: "concourse.workers.unknown_volumes", kind: otlpGauge, description: "Number of unknown volumes found on workers"},
+	"worker state":                 {name: "concourse.workers.registered", kind: otlpGauge, description: "Number of workers per state"},
+	"worker pool workers":          {name: "concourse.workers.pool_workers", kind: otlpGauge, description: "Number of running workers per pool"},
+	"worker pool desired workers":  {name: "concourse.workers.pool_desired_workers", kind: otlpGauge, description: "Number of workers each pool should have to fit its steps"},
+	"worker pool landable workers": {name: "concourse.workers.pool_landable_workers", kind: otlpGauge, description: "Number of workers per pool which can be landed without interrupting builds"},
+}
+
+func gcDuration(name string, description string) otlpInstrument {
+	return otlpInstrument{
+		name:        name,
+		kind:        otlpHistogram,
+		unit:        seconds,
+		description: description,
+		convert:     msToSeconds,
+		boundaries:  gcDurationBoundaries,
+	}
+}
+
+// attributes which identify a single build, which would give every build its
+// own time series
+var otlpIgnoredAttributes = map[string]bool{
+	"build_id": true,
+	"build":    true,
+}
+
+type otlpAggregatorSelector struct {
+	boundaries map[string][]float64
+}
+
+// OTLPAggregatorSelector aggregates histograms using the buckets configured
+// for their instrument, gauges by their last value and counters by their sum.
+func OTLPAggregatorSelector() export.AggregatorSelector {
+	boundaries := map[string][]float64{}
+	for _, instrument := range otlpInstruments {
+		if instrument.kind == otlpHistogram {
+			boundaries[instrument.name] = instrument.boundaries
+		}
+	}
+
+	return otlpAggregatorSelector{boundaries: boundaries}
+}
+
+func (selector otlpAggregatorSelector) AggregatorFor(descriptor *otelmetric.Descriptor, aggPtrs ...*export.Aggregator) {
+	switch descriptor.InstrumentKind() {
+	case otelmetric.ValueObserverInstrumentKind:
+		aggs := lastvalue.New(len(aggPtrs))
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	case otelmetric.ValueRecorderInstrumentKind:
+		aggs := histogram.New(len(aggPtrs), descriptor,
+			histogram.WithExplicitBoundaries(selector.boundaries[descriptor.Name()]))
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	default:
+		aggs := sum.New(len(aggPtrs))
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	}
+}
+
+type OTLPEmitter struct {
+	meter otelmetric.Meter
+
+	// attributes sent with the resource, rather than with each measurement
+	resourceAttributes map[string]string
+
+	instrumentsL   sync.Mutex
+	counters       map[string]otelmetric.Float64Counter
+	upDownCounters map[string]otelmetric.Float64UpDownCounter
+	histograms     map[string]otelmetric.Float64ValueRecorder
+	gauges         map[string]*otlpGaugeValues
+}
+
+type otlpGaugeValues struct {
+	valuesL sync.Mutex
+	values  map[attribute.Distinct]otlpGaugeValue
+}
+
+type otlpGaugeValue struct {
+	labels  []attribute.KeyValue
+	value   float64
+	updated time.Time
+}
+
+// NewOTLPEmitter returns an emitter measuring events with instruments from
+// the meter.
+func NewOTLPEmitter(meter otelmetric.Meter, resourceAttributes map[string]string) *OTLPEmitter {
+	return &OTLPEmitter{
+		meter:              meter,
+		resourceAttributes: resourceAttributes,
+
+		counters:       map[string]otelmetric.Float64Counter{},
+		upDownCounters: map[string]otelmetric.Float64UpDownCounter{},
+		histograms:     map[string]otelmetric.Float64ValueRecorder{},
+		gauges:         map[string]*otlpGaugeValues{},
+	}
+}
+
+func (emitter *OTLPEmitter) Emit(logger lager.Logger, event metric.Event) {
+	instrument, found := otlpInstruments[event.Name]
+	if !found {
+		instrument = otlpInstrument{
+			name: "concourse." + specialChars.ReplaceAllString(strings.Replace(strings.ToLower(event.Name), " ", "_", -1), ""),
+			kind: otlpGauge,
+		}
+	}
+
+	if instrument.name == "" {
+		return
+	}
+
+	value := event.Value
+	if instrument.convert != nil {
+		value = instrument.convert(value)
+	}
+
+	labels := emitter.labels(event)
+
+	ctx := context.Background()
+
+	switch instrument.kind {
+	case otlpCounter:
+		counter, err := emitter.counter(instrument)
+		if err != nil {
+			logger.Error("failed-to-create-counter", err, lager.Data{"name": instrument.name})
+			return
+		}
+
+		counter.Add(ctx, value, labels...)
+
+	case otlpUpDownCounter:
+		counter, err := emitter.upDownCounter(instrument)
+		if err != nil {
+			logger.Error("failed-to-create-up-down-counter", err, lager.Data{"name": instrument.name})
+			return
+		}
+
+		counter.Add(ctx, value, labels...)
+
+	case otlpHistogram:
+		recorder, err := emitter.histogram(instrument)
+		if err != nil {
+			logger.Error("failed-to-create-histogram", err, lager.Data{"name": instrument.name})
+			return
+		}
+
+		recorder.Record(ctx, value, labels...)
+
+	case otlpGauge:
+		gauge, err := emitter.gauge(instrument)
+		if err != nil {
+			logger.Error("failed-to-create-gauge", err, lager.Data{"name": instrument.name})
+			return
+		}
+
+		gauge.set(labels, value)
+	}
+}
+
+func (emitter *OTLPEmitter) labels(event metric.Event) []attribute.KeyValue {
+	var labels []attribute.KeyValue
+	if event.Host != "" {
+		labels = append(labels, attribute.String("host", event.Host))
+	}
+
+	for key, value := range event.Attributes {
+		if otlpIgnoredAttributes[key] {
+			continue
+		}
+
+		if resourceValue, found := emitter.resourceAttributes[key]; found && resourceValue == value {
+			continue
+		}
+
+		labels = append(labels, attribute.String(key, value))
+	}
+
+	return labels
+}
+
+func (emitter *OTLPEmitter) counter(instrument otlpInstrument) (otelmetric.Float64Counter, error) {
+	emitter.instrumentsL.Lock()
+	defer emitter.instrumentsL.Unlock()
+
+	counter, found := emitter.counters[instrument.name]
+	if found {
+		return counter, nil
+	}
+
+	counter, err := emitter.meter.NewFloat64Counter(instrument.name, instrument.options()...)
+	if err != nil {
+		return otelmetric.Float64Counter{}, err
+	}
+
+	emitter.counters[instrument.name] = counter
+
+	return counter, nil
+}
+
+func (emitter *OTLPEmitter) upDownCounter(instrument otlpInstrument) (otelmetric.Float64UpDownCounter, error) {
+	emitter.instrumentsL.Lock()
+	defer emitter.instrumentsL.Unlock()
+
+	counter, found := emitter.upDownCounters[instrument.name]
+	if found {
+		return counter, nil
+	}
+
+	counter, err := emitter.meter.NewFloat64UpDownCounter(instrument.name, instrument.options()...)
+	if err != nil {
+		return otelmetric.Float64UpDownCounter{}, err
+	}
+
+	emitter.upDownCounters[instrument.name] = counter
+
+	return counter, nil
+}
+
+func (emitter *OTLPEmitter) histogram(instrument otlpInstrument) (otelmetric.Float64ValueRecorder, error) {
+	emitter.instrumentsL.Lock()
+	defer emitter.instrumentsL.Unlock()
+
+	recorder, found := emitter.histograms[instrument.name]
+	if found {
+		return recorder, nil
+	}
+
+	recorder, err := emitter.meter.NewFloat64ValueRecorder(instrument.name, instrument.options()...)
+	if err != nil {
+		return otelmetric.Float64ValueRecorder{}, err
+	}
+
+	emitter.histograms[instrument.name] = recorder
+
+	return recorder, nil
+}
+
+// gauge returns the values of a gauge, which are observed whenever metrics
+// are collected.
+func (emitter *OTLPEmitter) gauge(instrument otlpInstrument) (*otlpGaugeValues, error) {
+	emitter.instrumentsL.Lock()
+	defer emitter.instrumentsL.Unlock()
+
+	gauge, found := emitter.gauges[instrument.name]
+	if found {
+		return gauge, nil
+	}
+
+	gauge = &otlpGaugeValues{
+		values: map[attribute.Distinct]otlpGaugeValue{},
+	}
+
+	_, err := emitter.meter.NewFloat64ValueObserver(instrument.name, gauge.observe, instrument.options()...)
+	if err != nil {
+		return nil, err
+	}
+
+	emitter.gauges[instrument.name] = gauge
+
+	return gauge, nil
+}
+
+func (instrument otlpInstrument) options() []otelmetric.InstrumentOption {
+	options := []otelmetric.InstrumentOption{
+		otelmetric.WithDescription(instrument.description),
+	}
+
+	if instrument.unit != "" {
+		options = append(options, otelmetric.WithUnit(instrument.unit))
+	}
+
+	return options
+}
+
+func (gauge *otlpGaugeValues) set(labels []attribute.KeyValue, value float64) {
+	gauge.valuesL.Lock()
+	defer gauge.valuesL.Unlock()
+
+	set := attribute.NewSet(labels...)
+
+	gauge.values[set.Equivalent()] = otlpGaugeValue{
+		labels:  labels,
+		value:   value,
+		updated: time.Now(),
+	}
+}
+
+func (gauge *otlpGaugeValues) observe(_ context.Context, result otelmetric.Float64ObserverResult) {
+	gauge.valuesL.Lock()
+	defer gauge.valuesL.Unlock()
+
+	for key, value := range gauge.values {
+		if time.Since(value.updated) > otlpStaleGaugeTimeout {
+			delete(gauge.values, key)
+			continue
+		}
+
+		result.Observe(value.value, value.labels...)
+	}
+}
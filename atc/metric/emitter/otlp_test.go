@@ -0,0 +1,227 @@
+package emitter_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/metric/emitter"
+	"go.opentelemetry.io/otel/attribute"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OTLPEmitter", func() {
+	var (
+		controller  *basic.Controller
+		otlpEmitter *emitter.OTLPEmitter
+		logger      *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		controller = basic.New(processor.New(
+			emitter.OTLPAggregatorSelector(),
+			export.CumulativeExportKindSelector(),
+		))
+
+		otlpEmitter = emitter.NewOTLPEmitter(
+			controller.MeterProvider().Meter("test"),
+			map[string]string{"cluster": "some-cluster"},
+		)
+
+		logger = lagertest.NewTestLogger("test")
+	})
+
+	collect := func() map[string][]export.Record {
+		Expect(controller.Collect(context.Background())).To(Succeed())
+
+		records := map[string][]export.Record{}
+		err := controller.ForEach(export.CumulativeExportKindSelector(), func(record export.Record) error {
+			name := record.Descriptor().Name()
+			records[name] = append(records[name], record)
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		return records
+	}
+
+	label := func(record export.Record, key string) string {
+		value, _ := record.Labels().Value(attribute.Key(key))
+		return value.AsString()
+	}
+
+	hasLabel := func(record export.Record, key string) bool {
+		return record.Labels().HasValue(attribute.Key(key))
+	}
+
+	It("records build durations as a histogram in seconds", func() {
+		otlpEmitter.Emit(logger, metric.Event{
+			Name:  "build finished",
+			Value: 120000,
+			Host:  "some-host",
+			Attributes: map[string]string{
+				"build_id":     "42",
+				"build":        "7",
+				"team_name":    "some-team",
+				"pipeline":     "some-pipeline",
+				"job":          "some-job",
+				"build_status": "succeeded",
+				"cluster":      "some-cluster",
+			},
+		})
+
+		records := collect()
+		Expect(records["concourse.builds.duration"]).To(HaveLen(1))
+
+		record := records["concourse.builds.duration"][0]
+		Expect(string(record.Descriptor().Unit())).To(Equal("s"))
+
+		histogram, ok := record.Aggregation().(aggregation.Histogram)
+		Expect(ok).To(BeTrue())
+
+		count, err := histogram.Count()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(uint64(1)))
+
+		sum, err := histogram.Sum()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sum.AsFloat64()).To(Equal(120.0))
+
+		buckets, err := histogram.Histogram()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buckets.Boundaries).To(ContainElement(3600.0))
+
+		Expect(label(record, "team_name")).To(Equal("some-team"))
+		Expect(label(record, "pipeline")).To(Equal("some-pipeline"))
+		Expect(label(record, "job")).To(Equal("some-job"))
+		Expect(label(record, "build_status")).To(Equal("succeeded"))
+		Expect(label(record, "host")).To(Equal("some-host"))
+
+		By("leaving out attributes identifying the build or sent with the resource")
+		Expect(hasLabel(record, "build_id")).To(BeFalse())
+		Expect(hasLabel(record, "build")).To(BeFalse())
+		Expect(hasLabel(record, "cluster")).To(BeFalse())
+	})
+
+	It("records the phases of steps as a histogram in seconds", func() {
+		otlpEmitter.Emit(logger, metric.Event{
+			Name:  "step phase duration",
+			Value: 2.5,
+			Attributes: map[string]string{
+				"team_name": "some-team",
+				"step_name": "some-task",
+				"phase":     "streaming-inputs",
+			},
+		})
+		otlpEmitter.Emit(logger, metric.Event{
+			Name:  "step streamed bytes",
+			Value: 1024,
+			Attributes: map[string]string{
+				"team_name": "some-team",
+				"step_name": "some-task",
+				"phase":     "streaming-inputs",
+			},
+		})
+
+		records := collect()
+		Expect(records["concourse.steps.phase_duration"]).To(HaveLen(1))
+
+		record := records["concourse.steps.phase_duration"][0]
+		Expect(string(record.Descriptor().Unit())).To(Equal("s"))
+		Expect(label(record, "phase")).To(Equal("streaming-inputs"))
+
+		sum, err := record.Aggregation().(aggregation.Histogram).Sum()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sum.AsFloat64()).To(Equal(2.5))
+
+		Expect(records["concourse.steps.streamed_bytes"]).To(HaveLen(1))
+
+		bytes, err := records["concourse.steps.streamed_bytes"][0].Aggregation().(aggregation.Sum).Sum()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bytes.AsFloat64()).To(Equal(1024.0))
+	})
+
+	It("counts locks held up and down as they are acquired and released", func() {
+		for _, value := range []float64{1, 1, 0} {
+			otlpEmitter.Emit(logger, metric.Event{
+				Name:       "lock held",
+				Value:      value,
+				Attributes: map[string]string{"type": "batch"},
+			})
+		}
+
+		records := collect()
+		Expect(records["concourse.locks.held"]).To(HaveLen(1))
+
+		sum, err := records["concourse.locks.held"][0].Aggregation().(aggregation.Sum).Sum()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sum.AsFloat64()).To(Equal(1.0))
+	})
+
+	It("sums counters", func() {
+		otlpEmitter.Emit(logger, metric.Event{Name: "builds started", Value: 2})
+		otlpEmitter.Emit(logger, metric.Event{Name: "builds started", Value: 3})
+
+		records := collect()
+		Expect(records["concourse.builds.started"]).To(HaveLen(1))
+
+		sum, err := records["concourse.builds.started"][0].Aggregation().(aggregation.Sum).Sum()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sum.AsFloat64()).To(Equal(5.0))
+	})
+
+	It("reports the last value of gauges for each set of attributes", func() {
+		otlpEmitter.Emit(logger, metric.Event{
+			Name:       "worker containers",
+			Value:      3,
+			Attributes: map[string]string{"worker": "worker-a"},
+		})
+		otlpEmitter.Emit(logger, metric.Event{
+			Name:       "worker containers",
+			Value:      5,
+			Attributes: map[string]string{"worker": "worker-a"},
+		})
+		otlpEmitter.Emit(logger, metric.Event{
+			Name:       "worker containers",
+			Value:      1,
+			Attributes: map[string]string{"worker": "worker-b"},
+		})
+
+		records := collect()
+		Expect(records["concourse.workers.containers"]).To(HaveLen(2))
+
+		values := map[string]float64{}
+		for _, record := range records["concourse.workers.containers"] {
+			worker := label(record, "worker")
+
+			value, _, err := record.Aggregation().(aggregation.LastValue).LastValue()
+			Expect(err).ToNot(HaveOccurred())
+
+			values[worker] = value.AsFloat64()
+		}
+
+		Expect(values).To(Equal(map[string]float64{
+			"worker-a": 5,
+			"worker-b": 1,
+		}))
+	})
+
+	It("reports events without a known instrument as gauges", func() {
+		otlpEmitter.Emit(logger, metric.Event{Name: "goroutines", Value: 42})
+
+		records := collect()
+		Expect(records["concourse.goroutines"]).To(HaveLen(1))
+	})
+
+	It("ignores build started events, whose values are build IDs", func() {
+		otlpEmitter.Emit(logger, metric.Event{Name: "build started", Value: 1234})
+
+		Expect(collect()).To(BeEmpty())
+	})
+})
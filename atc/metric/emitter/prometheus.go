@@ -34,6 +34,11 @@ type PrometheusEmitter struct {
 
 	stepsWaiting         *prometheus.GaugeVec
 	stepsWaitingDuration *prometheus.HistogramVec
+	stepPhaseDuration    *prometheus.HistogramVec
+	stepStreamedBytes    *prometheus.CounterVec
+
+	taskCPUPeak    *prometheus.HistogramVec
+	taskMemoryPeak *prometheus.HistogramVec
 
 	buildDurationsVec *prometheus.HistogramVec
 	buildsAborted     prometheus.Counter
@@ -76,6 +81,10 @@ type PrometheusEmitter struct {
 	workerTasks             *prometheus.GaugeVec
 	workersRegistered       *prometheus.GaugeVec
 
+	workerPoolWorkers         *prometheus.GaugeVec
+	workerPoolDesiredWorkers  *prometheus.GaugeVec
+	workerPoolLandableWorkers *prometheus.GaugeVec
+
 	workerContainersLabels map[string]map[string]prometheus.Labels
 	workerVolumesLabels    map[string]map[string]prometheus.Labels
 	workerTasksLabels      map[string]map[string]prometheus.Labels
@@ -234,6 +243,45 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 	}, []string{"platform", "teamId", "teamName", "type", "workerTags"})
 	prometheus.MustRegister(stepsWaitingDuration)
 
+	stepPhaseDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace:   "concourse",
+		Subsystem:   "steps",
+		Name:        "phase_duration_seconds",
+		Help:        "Time taken by each phase of build steps, e.g. waiting for a worker or streaming inputs",
+		ConstLabels: attributes,
+		Buckets:     []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600},
+	}, []string{"team", "pipeline", "job", "phase"})
+	prometheus.MustRegister(stepPhaseDuration)
+
+	stepStreamedBytes := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   "concourse",
+		Subsystem:   "steps",
+		Name:        "streamed_bytes_total",
+		Help:        "Total number of bytes streamed through the ATC for build steps",
+		ConstLabels: attributes,
+	}, []string{"team", "pipeline", "job", "phase"})
+	prometheus.MustRegister(stepStreamedBytes)
+
+	taskCPUPeak := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace:   "concourse",
+		Subsystem:   "tasks",
+		Name:        "cpu_peak_cores",
+		Help:        "Peak CPU usage of task containers in cores",
+		ConstLabels: attributes,
+		Buckets:     []float64{0.1, 0.25, 0.5, 1, 2, 4, 8, 16, 32},
+	}, []string{"team", "pipeline", "job", "step"})
+	prometheus.MustRegister(taskCPUPeak)
+
+	taskMemoryPeak := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace:   "concourse",
+		Subsystem:   "tasks",
+		Name:        "memory_peak_bytes",
+		Help:        "Peak memory usage of task containers in bytes",
+		ConstLabels: attributes,
+		Buckets:     prometheus.ExponentialBuckets(64*1024*1024, 2, 10),
+	}, []string{"team", "pipeline", "job", "step"})
+	prometheus.MustRegister(taskMemoryPeak)
+
 	buildsFinished := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace:   "concourse",
 		Subsystem:   "builds",
@@ -422,6 +470,42 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 	)
 	prometheus.MustRegister(workersRegistered)
 
+	workerPoolWorkers := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   "concourse",
+			Subsystem:   "workers",
+			Name:        "pool_workers",
+			Help:        "Number of running workers per pool of workers",
+			ConstLabels: attributes,
+		},
+		[]string{"platform", "tags", "team_name"},
+	)
+	prometheus.MustRegister(workerPoolWorkers)
+
+	workerPoolDesiredWorkers := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   "concourse",
+			Subsystem:   "workers",
+			Name:        "pool_desired_workers",
+			Help:        "Number of workers each pool of workers should have to fit its steps",
+			ConstLabels: attributes,
+		},
+		[]string{"platform", "tags", "team_name"},
+	)
+	prometheus.MustRegister(workerPoolDesiredWorkers)
+
+	workerPoolLandableWorkers := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   "concourse",
+			Subsystem:   "workers",
+			Name:        "pool_landable_workers",
+			Help:        "Number of workers per pool of workers that can be landed without interrupting builds",
+			ConstLabels: attributes,
+		},
+		[]string{"platform", "tags", "team_name"},
+	)
+	prometheus.MustRegister(workerPoolLandableWorkers)
+
 	// http metrics
 	httpRequestsDuration := prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -545,6 +629,11 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 
 		stepsWaiting:         stepsWaiting,
 		stepsWaitingDuration: stepsWaitingDuration,
+		stepPhaseDuration:    stepPhaseDuration,
+		stepStreamedBytes:    stepStreamedBytes,
+
+		taskCPUPeak:    taskCPUPeak,
+		taskMemoryPeak: taskMemoryPeak,
 
 		buildDurationsVec: buildDurationsVec,
 		buildsAborted:     buildsAborted,
@@ -586,6 +675,10 @@ func (config *PrometheusConfig) NewEmitter(attributes map[string]string) (metric
 		workerUnknownContainers: workerUnknownContainers,
 		workerUnknownVolumes:    workerUnknownVolumes,
 
+		workerPoolWorkers:         workerPoolWorkers,
+		workerPoolDesiredWorkers:  workerPoolDesiredWorkers,
+		workerPoolLandableWorkers: workerPoolLandableWorkers,
+
 		volumesStreamed: volumesStreamed,
 
 		getStepCacheHits:       getStepCacheHits,
@@ -645,8 +738,16 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 				event.Attributes["type"],
 				event.Attributes["workerTags"],
 			).Observe(event.Value)
+	case "step phase duration":
+		emitter.stepPhaseDurationMetric(logger, event)
+	case "step streamed bytes":
+		emitter.stepStreamedBytesMetric(logger, event)
 	case "build finished":
 		emitter.buildFinishedMetrics(logger, event)
+	case "task peak cpu usage":
+		emitter.taskUsageMetric(logger, emitter.taskCPUPeak, event)
+	case "task peak memory usage":
+		emitter.taskUsageMetric(logger, emitter.taskMemoryPeak, event)
 	case "check build finished":
 		emitter.checkBuildFinishedMetrics(logger, event)
 	case "worker containers":
@@ -661,6 +762,12 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.workerTasksMetric(logger, event)
 	case "worker state":
 		emitter.workersRegisteredMetric(logger, event)
+	case "worker pool workers":
+		emitter.workerPoolMetric(emitter.workerPoolWorkers, event)
+	case "worker pool desired workers":
+		emitter.workerPoolMetric(emitter.workerPoolDesiredWorkers, event)
+	case "worker pool landable workers":
+		emitter.workerPoolMetric(emitter.workerPoolLandableWorkers, event)
 	case "http response time":
 		emitter.httpResponseTimeMetrics(logger, event)
 	case "database queries":
@@ -762,6 +869,57 @@ func (emitter *PrometheusEmitter) buildFinishedMetrics(logger lager.Logger, even
 	emitter.buildDurationsVec.WithLabelValues(team, pipeline, job).Observe(duration)
 }
 
+func (emitter *PrometheusEmitter) taskUsageMetric(logger lager.Logger, histogram *prometheus.HistogramVec, event metric.Event) {
+	team, exists := event.Attributes["team_name"]
+	if !exists {
+		logger.Error("failed-to-find-team-name-in-event", fmt.Errorf("expected team_name to exist in event.Attributes"))
+		return
+	}
+
+	// one-off builds have neither a pipeline nor a job
+	histogram.WithLabelValues(
+		team,
+		event.Attributes["pipeline"],
+		event.Attributes["job"],
+		event.Attributes["step_name"],
+	).Observe(event.Value)
+}
+
+func (emitter *PrometheusEmitter) stepPhaseDurationMetric(logger lager.Logger, event metric.Event) {
+	labels, ok := stepPhaseLabels(logger, event)
+	if !ok {
+		return
+	}
+
+	emitter.stepPhaseDuration.WithLabelValues(labels...).Observe(event.Value)
+}
+
+func (emitter *PrometheusEmitter) stepStreamedBytesMetric(logger lager.Logger, event metric.Event) {
+	labels, ok := stepPhaseLabels(logger, event)
+	if !ok {
+		return
+	}
+
+	emitter.stepStreamedBytes.WithLabelValues(labels...).Add(event.Value)
+}
+
+func stepPhaseLabels(logger lager.Logger, event metric.Event) ([]string, bool) {
+	team, exists := event.Attributes["team_name"]
+	if !exists {
+		logger.Error("failed-to-find-team-name-in-event", fmt.Errorf("expected team_name to exist in event.Attributes"))
+		return nil, false
+	}
+
+	phase, exists := event.Attributes["phase"]
+	if !exists {
+		logger.Error("failed-to-find-phase-in-event", fmt.Errorf("expected phase to exist in event.Attributes"))
+		return nil, false
+	}
+
+	// one-off builds have neither a pipeline nor a job
+	return []string{team, event.Attributes["pipeline"], event.Attributes["job"], phase}, true
+}
+
 func (emitter *PrometheusEmitter) checkBuildFinishedMetrics(logger lager.Logger, event metric.Event) {
 	// concourse_builds_finished_total
 	emitter.checkBuildsFinished.Inc()
@@ -901,6 +1059,14 @@ func (emitter *PrometheusEmitter) workerUnknownVolumesMetric(logger lager.Logger
 	emitter.workerUnknownVolumes.With(emitter.workerVolumesLabels[worker][key]).Set(event.Value)
 }
 
+func (emitter *PrometheusEmitter) workerPoolMetric(gauge *prometheus.GaugeVec, event metric.Event) {
+	gauge.WithLabelValues(
+		event.Attributes["platform"],
+		event.Attributes["tags"],
+		event.Attributes["team_name"],
+	).Set(event.Value)
+}
+
 func (emitter *PrometheusEmitter) workerTasksMetric(logger lager.Logger, event metric.Event) {
 	worker, exists := event.Attributes["worker"]
 	if !exists {
@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -307,6 +308,50 @@ func (event WorkerTasks) Emit(logger lager.Logger) {
 	)
 }
 
+type WorkerPoolCapacity struct {
+	Platform        string
+	Tags            []string
+	TeamName        string
+	Workers         int
+	DesiredWorkers  int
+	LandableWorkers int
+}
+
+func (event WorkerPoolCapacity) Emit(logger lager.Logger) {
+	attributes := map[string]string{
+		"platform":  event.Platform,
+		"tags":      strings.Join(event.Tags, "/"),
+		"team_name": event.TeamName,
+	}
+
+	Metrics.emit(
+		logger.Session("worker-pool-workers"),
+		Event{
+			Name:       "worker pool workers",
+			Value:      float64(event.Workers),
+			Attributes: attributes,
+		},
+	)
+
+	Metrics.emit(
+		logger.Session("worker-pool-desired-workers"),
+		Event{
+			Name:       "worker pool desired workers",
+			Value:      float64(event.DesiredWorkers),
+			Attributes: attributes,
+		},
+	)
+
+	Metrics.emit(
+		logger.Session("worker-pool-landable-workers"),
+		Event{
+			Name:       "worker pool landable workers",
+			Value:      float64(event.LandableWorkers),
+			Attributes: attributes,
+		},
+	)
+}
+
 type VolumesToBeGarbageCollected struct {
 	Volumes int
 }
@@ -477,6 +522,83 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 	)
 }
 
+type TaskUsage struct {
+	Build db.Build
+	Usage atc.StepUsage
+}
+
+func (event TaskUsage) Emit(logger lager.Logger) {
+	attrs := event.Build.TracingAttrs()
+	attrs["step_name"] = event.Usage.StepName
+
+	Metrics.emit(
+		logger.Session("task-cpu-usage"),
+		Event{
+			Name:       "task peak cpu usage",
+			Value:      event.Usage.Usage.CPUPeak,
+			Attributes: attrs,
+		},
+	)
+
+	Metrics.emit(
+		logger.Session("task-cpu-usage"),
+		Event{
+			Name:       "task average cpu usage",
+			Value:      event.Usage.Usage.CPUAverage,
+			Attributes: attrs,
+		},
+	)
+
+	Metrics.emit(
+		logger.Session("task-memory-usage"),
+		Event{
+			Name:       "task peak memory usage",
+			Value:      float64(event.Usage.Usage.MemoryPeak),
+			Attributes: attrs,
+		},
+	)
+
+	Metrics.emit(
+		logger.Session("task-memory-usage"),
+		Event{
+			Name:       "task average memory usage",
+			Value:      float64(event.Usage.Usage.MemoryAverage),
+			Attributes: attrs,
+		},
+	)
+}
+
+type StepPhaseTimed struct {
+	Build  db.Build
+	Timing atc.StepTiming
+}
+
+func (event StepPhaseTimed) Emit(logger lager.Logger) {
+	attrs := event.Build.TracingAttrs()
+	attrs["step_name"] = event.Timing.StepName
+	attrs["phase"] = string(event.Timing.Phase)
+
+	Metrics.emit(
+		logger.Session("step-phase-duration"),
+		Event{
+			Name:       "step phase duration",
+			Value:      event.Timing.Duration,
+			Attributes: attrs,
+		},
+	)
+
+	if event.Timing.Bytes != nil {
+		Metrics.emit(
+			logger.Session("step-streamed-bytes"),
+			Event{
+				Name:       "step streamed bytes",
+				Value:      float64(*event.Timing.Bytes),
+				Attributes: attrs,
+			},
+		)
+	}
+}
+
 type CheckBuildStarted struct {
 	Build db.Build
 }
@@ -0,0 +1,178 @@
+package atc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const NetworkNone = "none"
+
+// NetworkConfig configures the network access of a task's container.
+//
+// It is configured either as the string "none", in which case the container
+// is fully isolated with only a loopback interface, or as an allow-list of
+// egress destinations, in which case all other outbound traffic is rejected.
+type NetworkConfig struct {
+	None   bool
+	Egress []EgressRule
+}
+
+type networkConfig struct {
+	Egress []EgressRule `json:"egress,omitempty"`
+}
+
+func (config NetworkConfig) MarshalJSON() ([]byte, error) {
+	if config.None {
+		return json.Marshal(NetworkNone)
+	}
+
+	return json.Marshal(networkConfig{Egress: config.Egress})
+}
+
+func (config *NetworkConfig) UnmarshalJSON(data []byte) error {
+	var mode string
+	if err := json.Unmarshal(data, &mode); err == nil {
+		if mode != NetworkNone {
+			return fmt.Errorf("unknown network mode '%s' (expected '%s')", mode, NetworkNone)
+		}
+
+		*config = NetworkConfig{None: true}
+		return nil
+	}
+
+	var cfg networkConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+
+	*config = NetworkConfig{Egress: cfg.Egress}
+	return nil
+}
+
+func (config NetworkConfig) Validate() []string {
+	if config.None {
+		return nil
+	}
+
+	if len(config.Egress) == 0 {
+		return []string{fmt.Sprintf("must specify `egress:` rules or be '%s'", NetworkNone)}
+	}
+
+	var errs []string
+	for i, rule := range config.Egress {
+		for _, err := range rule.Validate() {
+			errs = append(errs, fmt.Sprintf("egress[%d]: %s", i, err))
+		}
+	}
+
+	return errs
+}
+
+// EgressRule permits traffic to a destination, given either as a CIDR or as a
+// host name which the worker resolves when it creates the container.
+type EgressRule struct {
+	CIDR     string         `json:"cidr,omitempty"`
+	Host     string         `json:"host,omitempty"`
+	Ports    []PortRange    `json:"ports,omitempty"`
+	Protocol EgressProtocol `json:"protocol,omitempty"`
+}
+
+type EgressProtocol string
+
+const (
+	EgressProtocolAll  EgressProtocol = ""
+	EgressProtocolTCP  EgressProtocol = "tcp"
+	EgressProtocolUDP  EgressProtocol = "udp"
+	EgressProtocolICMP EgressProtocol = "icmp"
+)
+
+func (rule EgressRule) Validate() []string {
+	var errs []string
+
+	switch {
+	case rule.CIDR == "" && rule.Host == "":
+		errs = append(errs, "must specify one of `cidr:` or `host:`")
+	case rule.CIDR != "" && rule.Host != "":
+		errs = append(errs, "must specify one of `cidr:` or `host:`, not both")
+	case rule.CIDR != "":
+		if _, _, err := net.ParseCIDR(rule.CIDR); err != nil {
+			errs = append(errs, fmt.Sprintf("invalid cidr '%s'", rule.CIDR))
+		}
+	}
+
+	switch rule.Protocol {
+	case EgressProtocolAll, EgressProtocolTCP, EgressProtocolUDP:
+	case EgressProtocolICMP:
+		if len(rule.Ports) != 0 {
+			errs = append(errs, "cannot specify `ports:` for protocol 'icmp'")
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("unknown protocol '%s'", rule.Protocol))
+	}
+
+	for _, ports := range rule.Ports {
+		if ports.Start == 0 || ports.End < ports.Start {
+			errs = append(errs, fmt.Sprintf("invalid port range '%s'", ports))
+		}
+	}
+
+	return errs
+}
+
+// PortRange is an inclusive range of ports, configured either as a single
+// port number or as a string of the form "start-end".
+type PortRange struct {
+	Start uint16
+	End   uint16
+}
+
+func (ports PortRange) String() string {
+	if ports.Start == ports.End {
+		return strconv.Itoa(int(ports.Start))
+	}
+
+	return fmt.Sprintf("%d-%d", ports.Start, ports.End)
+}
+
+func (ports PortRange) MarshalJSON() ([]byte, error) {
+	if ports.Start == ports.End {
+		return json.Marshal(ports.Start)
+	}
+
+	return json.Marshal(ports.String())
+}
+
+func (ports *PortRange) UnmarshalJSON(data []byte) error {
+	var port uint16
+	if err := json.Unmarshal(data, &port); err == nil {
+		*ports = PortRange{Start: port, End: port}
+		return nil
+	}
+
+	var portRange string
+	if err := json.Unmarshal(data, &portRange); err != nil {
+		return errors.New("port must be a number or a range of the form 'start-end'")
+	}
+
+	bounds := strings.SplitN(portRange, "-", 2)
+
+	start, err := strconv.ParseUint(strings.TrimSpace(bounds[0]), 10, 16)
+	if err != nil {
+		return fmt.Errorf("invalid port range '%s'", portRange)
+	}
+
+	end := start
+	if len(bounds) == 2 {
+		end, err = strconv.ParseUint(strings.TrimSpace(bounds[1]), 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid port range '%s'", portRange)
+		}
+	}
+
+	*ports = PortRange{Start: uint16(start), End: uint16(end)}
+	return nil
+}
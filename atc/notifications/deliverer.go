@@ -0,0 +1,251 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/component"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/vars"
+)
+
+const (
+	// notifications delivered per run; the rest wait for the next run
+	deliveryBatchSize = 100
+
+	// a notification is given up on after this many failed attempts, which
+	// are spaced out exponentially from the retry interval
+	maxDeliveryAttempts = 5
+	retryInterval       = 30 * time.Second
+
+	// enough of a failed response's body to say what went wrong
+	maxErrorBodySize = 1024
+)
+
+type deliverer struct {
+	notificationFactory db.BuildNotificationFactory
+	buildFactory        db.BuildFactory
+	teamFactory         db.TeamFactory
+
+	secrets       creds.Secrets
+	varSourcePool creds.VarSourcePool
+
+	externalURL string
+	httpClient  *http.Client
+	clock       clock.Clock
+}
+
+// NewDeliverer returns a component delivering the notifications queued for
+// build events, retrying the ones that fail.
+func NewDeliverer(
+	notificationFactory db.BuildNotificationFactory,
+	buildFactory db.BuildFactory,
+	teamFactory db.TeamFactory,
+	secrets creds.Secrets,
+	varSourcePool creds.VarSourcePool,
+	externalURL string,
+	httpClient *http.Client,
+	clock clock.Clock,
+) component.Runnable {
+	return &deliverer{
+		notificationFactory: notificationFactory,
+		buildFactory:        buildFactory,
+		teamFactory:         teamFactory,
+
+		secrets:       secrets,
+		varSourcePool: varSourcePool,
+
+		externalURL: externalURL,
+		httpClient:  httpClient,
+		clock:       clock,
+	}
+}
+
+func (d *deliverer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("notifier")
+
+	notifications, err := d.notificationFactory.DueBuildNotifications(deliveryBatchSize)
+	if err != nil {
+		logger.Error("failed-to-get-due-notifications", err)
+		return err
+	}
+
+	for _, notification := range notifications {
+		err := d.deliver(ctx, logger.Session("deliver", lager.Data{
+			"notification": notification.ID,
+			"build":        notification.BuildID,
+			"notifier":     notification.Notifier,
+			"source":       notification.Source,
+			"event":        notification.Event,
+		}), notification)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deliver attempts to deliver the notification. Failed attempts are recorded
+// rather than returned; only errors recording them are returned.
+func (d *deliverer) deliver(ctx context.Context, logger lager.Logger, notification atc.BuildNotification) error {
+	build, found, err := d.buildFactory.Build(notification.BuildID)
+	if err != nil {
+		logger.Error("failed-to-find-build", err)
+		return err
+	}
+
+	if !found {
+		return d.giveUp(logger, notification, "build not found")
+	}
+
+	config, found, err := d.notifierConfig(build, notification.Source, notification.Notifier)
+	if err != nil {
+		logger.Error("failed-to-find-notifier", err)
+		return err
+	}
+
+	if !found {
+		return d.giveUp(logger, notification, "notifier is no longer configured")
+	}
+
+	// the credentials resolved for the request, which are redacted from
+	// failures as they're shown to viewers of the team
+	credentials := vars.NewTracker(true)
+
+	request, err := d.request(logger, build, config, notification.Event, credentials)
+	if err != nil {
+		return d.failed(logger, notification, 0, redactedError(err, credentials))
+	}
+
+	response, err := d.httpClient.Do(request.WithContext(ctx))
+	if err != nil {
+		return d.failed(logger, notification, 0, redactedError(err, credentials))
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+
+		message := fmt.Sprintf("unexpected response: %s", response.Status)
+		if len(body) > 0 {
+			message += ": " + strings.TrimSpace(string(body))
+		}
+
+		return d.failed(logger, notification, response.StatusCode, redactedError(fmt.Errorf("%s", message), credentials))
+	}
+
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+
+	logger.Info("delivered", lager.Data{"status": response.StatusCode})
+
+	return d.notificationFactory.BuildNotificationDelivered(notification.ID, response.StatusCode)
+}
+
+func (d *deliverer) notifierConfig(build db.Build, source string, name string) (atc.NotifierConfig, bool, error) {
+	switch source {
+	case atc.NotificationSourcePipeline:
+		pipeline, found, err := build.Pipeline()
+		if err != nil || !found {
+			return atc.NotifierConfig{}, false, err
+		}
+
+		config, found := pipeline.Notifiers().Lookup(name)
+		return config, found, nil
+
+	case atc.NotificationSourceTeam:
+		team, found, err := d.teamFactory.FindTeam(build.TeamName())
+		if err != nil || !found {
+			return atc.NotifierConfig{}, false, err
+		}
+
+		config, found := team.Notifiers().Lookup(name)
+		return config, found, nil
+	}
+
+	return atc.NotifierConfig{}, false, nil
+}
+
+func (d *deliverer) request(logger lager.Logger, build db.Build, config atc.NotifierConfig, event string, credentials *vars.Tracker) (*http.Request, error) {
+	metadata, err := buildMetadata(build, event, d.externalURL)
+	if err != nil {
+		return nil, fmt.Errorf("build metadata: %w", err)
+	}
+
+	variables, err := build.Variables(logger, d.secrets, d.varSourcePool)
+	if err != nil {
+		return nil, fmt.Errorf("build variables: %w", err)
+	}
+
+	return newRequest(config, metadata, &vars.CredVarsTracker{
+		Tracker:  credentials,
+		CredVars: variables,
+	})
+}
+
+// redactedError returns the error without the URL of the request, which is
+// resolved from ((vars)) and may carry a credential, e.g. the token in the
+// path of a Slack webhook, and with the values of the credentials resolved
+// for the request redacted, as they are from build logs.
+func redactedError(err error, credentials *vars.Tracker) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = fmt.Errorf("%s: %w", urlErr.Op, urlErr.Err)
+	}
+
+	redactor := &secretRedactor{message: err.Error()}
+	credentials.IterateInterpolatedCreds(redactor)
+
+	return errors.New(redactor.message)
+}
+
+type secretRedactor struct {
+	message string
+}
+
+func (redactor *secretRedactor) YieldCred(_, value string) {
+	for _, lineValue := range strings.Split(value, "\n") {
+		lineValue = strings.TrimSpace(lineValue)
+		// Don't consider a single char as a secret.
+		if len(lineValue) > 1 {
+			redactor.message = strings.Replace(redactor.message, lineValue, "((redacted))", -1)
+		}
+	}
+}
+
+func (d *deliverer) failed(logger lager.Logger, notification atc.BuildNotification, responseStatus int, deliveryErr error) error {
+	attempt := notification.Attempts + 1
+
+	var retryAt time.Time
+	if attempt < maxDeliveryAttempts {
+		retryAt = d.clock.Now().Add(retryInterval << (attempt - 1))
+	}
+
+	logger.Info("failed-to-deliver", lager.Data{
+		"attempt":  attempt,
+		"status":   responseStatus,
+		"error":    deliveryErr.Error(),
+		"retry-at": retryAt,
+	})
+
+	return d.notificationFactory.BuildNotificationFailed(notification.ID, responseStatus, deliveryErr.Error(), retryAt)
+}
+
+func (d *deliverer) giveUp(logger lager.Logger, notification atc.BuildNotification, reason string) error {
+	logger.Info("giving-up", lager.Data{"reason": reason})
+
+	return d.notificationFactory.BuildNotificationFailed(notification.ID, 0, reason, time.Time{})
+}
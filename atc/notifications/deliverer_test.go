@@ -0,0 +1,395 @@
+package notifications_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/component"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/notifications"
+	"github.com/concourse/concourse/vars"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Deliverer", func() {
+	var (
+		fakeNotificationFactory *dbfakes.FakeBuildNotificationFactory
+		fakeBuildFactory        *dbfakes.FakeBuildFactory
+		fakeTeamFactory         *dbfakes.FakeTeamFactory
+		fakeBuild               *dbfakes.FakeBuild
+		fakePipeline            *dbfakes.FakePipeline
+		fakeTeam                *dbfakes.FakeTeam
+		fakeClock               *fakeclock.FakeClock
+
+		server *ghttp.Server
+		logger *lagertest.TestLogger
+
+		notification atc.BuildNotification
+		notifier     atc.NotifierConfig
+
+		deliverer component.Runnable
+		dueErr    error
+		runErr    error
+	)
+
+	BeforeEach(func() {
+		fakeNotificationFactory = new(dbfakes.FakeBuildNotificationFactory)
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(1000, 0))
+		dueErr = nil
+
+		server = ghttp.NewServer()
+
+		createdBy := "some-user"
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.NameReturns("7")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.CreatedByReturns(&createdBy)
+		fakeBuild.StartTimeReturns(time.Unix(100, 0))
+		fakeBuild.EndTimeReturns(time.Unix(200, 0))
+		fakeBuild.ResourcesReturns([]db.BuildInput{
+			{Name: "repo", Version: atc.Version{"ref": "abc123"}},
+		}, nil, nil)
+		fakeBuild.VariablesReturns(vars.StaticVariables{"token": "secret-token"}, nil)
+		fakeBuildFactory.BuildReturns(fakeBuild, true, nil)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		notifier = atc.NotifierConfig{
+			Name: "some-notifier",
+			Type: atc.NotifierTypeWebhook,
+			URL:  server.URL() + "/hook",
+		}
+
+		notification = atc.BuildNotification{
+			ID:       1,
+			BuildID:  42,
+			Notifier: "some-notifier",
+			Source:   atc.NotificationSourcePipeline,
+			Event:    "succeeded",
+			Status:   atc.NotificationStatusPending,
+		}
+
+		deliverer = notifications.NewDeliverer(
+			fakeNotificationFactory,
+			fakeBuildFactory,
+			fakeTeamFactory,
+			new(credsfakes.FakeSecrets),
+			new(credsfakes.FakeVarSourcePool),
+			"https://ci.example.com/",
+			http.DefaultClient,
+			fakeClock,
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		fakePipeline.NotifiersReturns(atc.NotifierConfigs{notifier})
+		fakeNotificationFactory.DueBuildNotificationsReturns([]atc.BuildNotification{notification}, dueErr)
+
+		logger = lagertest.NewTestLogger("test")
+		runErr = deliverer.Run(lagerctx.NewContext(context.Background(), logger))
+	})
+
+	Context("with a webhook without a body", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/hook"),
+				ghttp.VerifyContentType("application/json"),
+				ghttp.VerifyJSONRepresenting(notifications.Metadata{
+					Event:        "succeeded",
+					BuildID:      42,
+					BuildName:    "7",
+					BuildURL:     "https://ci.example.com/builds/42",
+					TeamName:     "some-team",
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+					CreatedBy:    "some-user",
+					StartTime:    100,
+					EndTime:      200,
+					Inputs:       map[string]atc.Version{"repo": {"ref": "abc123"}},
+					ExternalURL:  "https://ci.example.com",
+				}),
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+		})
+
+		It("sends the build metadata", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeBuildFactory.BuildArgsForCall(0)).To(Equal(42))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("records the delivery", func() {
+			Expect(fakeNotificationFactory.BuildNotificationDeliveredCallCount()).To(Equal(1))
+			id, status := fakeNotificationFactory.BuildNotificationDeliveredArgsForCall(0)
+			Expect(id).To(Equal(1))
+			Expect(status).To(Equal(http.StatusNoContent))
+		})
+	})
+
+	Context("with a webhook with a templated body, headers and vars", func() {
+		BeforeEach(func() {
+			notifier.Method = "put"
+			notifier.Headers = map[string]string{"Authorization": "Bearer ((token))"}
+			notifier.Body = `{{.JobName}} {{.Event}} at {{index .Inputs "repo" "ref"}}`
+
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/hook"),
+				ghttp.VerifyHeaderKV("Authorization", "Bearer secret-token"),
+				ghttp.VerifyBody([]byte("some-job succeeded at abc123")),
+				ghttp.RespondWith(http.StatusOK, nil),
+			))
+		})
+
+		It("renders the request", func() {
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+			Expect(fakeNotificationFactory.BuildNotificationDeliveredCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("with a slack notifier", func() {
+		BeforeEach(func() {
+			notifier.Type = atc.NotifierTypeSlack
+
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/hook"),
+				ghttp.VerifyJSONRepresenting(map[string]string{
+					"text": "some-pipeline/some-job #7 succeeded: https://ci.example.com/builds/42",
+				}),
+				ghttp.RespondWith(http.StatusOK, "ok"),
+			))
+		})
+
+		It("posts the default message", func() {
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("with a github notifier", func() {
+		BeforeEach(func() {
+			notifier = atc.NotifierConfig{
+				Name:       "some-notifier",
+				Type:       atc.NotifierTypeGitHub,
+				URL:        server.URL(),
+				Repository: "some-org/some-repo",
+				Commit:     `{{index .Inputs "repo" "ref"}}`,
+				Token:      "((token))",
+			}
+
+			notification.Event = "failed"
+
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/repos/some-org/some-repo/statuses/abc123"),
+				ghttp.VerifyHeaderKV("Authorization", "token secret-token"),
+				ghttp.VerifyJSONRepresenting(map[string]string{
+					"state":       "failure",
+					"target_url":  "https://ci.example.com/builds/42",
+					"description": "build failed",
+					"context":     "concourse/some-pipeline/some-job",
+				}),
+				ghttp.RespondWith(http.StatusCreated, "{}"),
+			))
+		})
+
+		It("sets the commit status", func() {
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("with a gitlab notifier", func() {
+		BeforeEach(func() {
+			notifier = atc.NotifierConfig{
+				Name:       "some-notifier",
+				Type:       atc.NotifierTypeGitLab,
+				URL:        server.URL(),
+				Repository: "some-group/some-project",
+				Commit:     "abc123",
+				Context:    "ci",
+				Token:      "some-token",
+			}
+
+			notification.Event = atc.NotificationEventStarted
+
+			server.AppendHandlers(ghttp.CombineHandlers(
+				func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.Method).To(Equal("POST"))
+					Expect(r.RequestURI).To(Equal("/api/v4/projects/some-group%2Fsome-project/statuses/abc123"))
+				},
+				ghttp.VerifyHeaderKV("PRIVATE-TOKEN", "some-token"),
+				ghttp.VerifyJSONRepresenting(map[string]string{
+					"state":       "running",
+					"target_url":  "https://ci.example.com/builds/42",
+					"description": "build started",
+					"name":        "ci",
+				}),
+				ghttp.RespondWith(http.StatusCreated, "{}"),
+			))
+		})
+
+		It("sets the commit status", func() {
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("with a team notifier", func() {
+		BeforeEach(func() {
+			notification.Source = atc.NotificationSourceTeam
+
+			fakeTeam.NotifiersReturns(atc.NotifierConfigs{{
+				Name: "some-notifier",
+				Type: atc.NotifierTypeWebhook,
+				URL:  server.URL() + "/team-hook",
+			}})
+
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/team-hook"),
+				ghttp.RespondWith(http.StatusOK, nil),
+			))
+		})
+
+		It("uses the team's notifier", func() {
+			Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Context("when the endpoint responds with an error", func() {
+		BeforeEach(func() {
+			notification.Attempts = 2
+
+			server.AppendHandlers(ghttp.RespondWith(http.StatusBadGateway, "try again"))
+		})
+
+		It("records the failure and retries later", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeNotificationFactory.BuildNotificationFailedCallCount()).To(Equal(1))
+
+			id, status, message, retryAt := fakeNotificationFactory.BuildNotificationFailedArgsForCall(0)
+			Expect(id).To(Equal(1))
+			Expect(status).To(Equal(http.StatusBadGateway))
+			Expect(message).To(Equal("unexpected response: 502 Bad Gateway: try again"))
+			Expect(retryAt).To(Equal(fakeClock.Now().Add(2 * time.Minute)))
+		})
+
+		Context("on the last attempt", func() {
+			BeforeEach(func() {
+				notification.Attempts = 4
+			})
+
+			It("gives up", func() {
+				_, _, _, retryAt := fakeNotificationFactory.BuildNotificationFailedArgsForCall(0)
+				Expect(retryAt).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the endpoint can not be reached", func() {
+		BeforeEach(func() {
+			notifier.Type = atc.NotifierTypeSlack
+			notifier.URL = "http://127.0.0.1:1/services/((token))"
+		})
+
+		It("records the failure without the credential in the url", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeNotificationFactory.BuildNotificationFailedCallCount()).To(Equal(1))
+
+			_, status, message, retryAt := fakeNotificationFactory.BuildNotificationFailedArgsForCall(0)
+			Expect(status).To(BeZero())
+			Expect(message).To(ContainSubstring("connection refused"))
+			Expect(message).ToNot(ContainSubstring("secret-token"))
+			Expect(retryAt).ToNot(BeZero())
+
+			Expect(logger.Buffer()).ToNot(gbytes.Say("secret-token"))
+		})
+	})
+
+	Context("when the endpoint responds with a credential", func() {
+		BeforeEach(func() {
+			notifier.URL = server.URL() + "/hook/((token))"
+
+			server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, "no such hook: /hook/secret-token"))
+		})
+
+		It("records the failure with the credential redacted", func() {
+			_, _, message, _ := fakeNotificationFactory.BuildNotificationFailedArgsForCall(0)
+			Expect(message).To(Equal("unexpected response: 404 Not Found: no such hook: /hook/((redacted))"))
+		})
+	})
+
+	Context("when a var can not be resolved", func() {
+		BeforeEach(func() {
+			notifier.Headers = map[string]string{"Authorization": "((missing))"}
+		})
+
+		It("records the failure without sending anything", func() {
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+
+			_, status, message, retryAt := fakeNotificationFactory.BuildNotificationFailedArgsForCall(0)
+			Expect(status).To(BeZero())
+			Expect(message).To(ContainSubstring("missing"))
+			Expect(retryAt).ToNot(BeZero())
+		})
+	})
+
+	Context("when the notifier is no longer configured", func() {
+		BeforeEach(func() {
+			notification.Notifier = "removed-notifier"
+		})
+
+		It("gives up", func() {
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+
+			_, _, message, retryAt := fakeNotificationFactory.BuildNotificationFailedArgsForCall(0)
+			Expect(message).To(Equal("notifier is no longer configured"))
+			Expect(retryAt).To(BeZero())
+		})
+	})
+
+	Context("when the build is gone", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.BuildReturns(nil, false, nil)
+		})
+
+		It("gives up", func() {
+			_, _, message, retryAt := fakeNotificationFactory.BuildNotificationFailedArgsForCall(0)
+			Expect(message).To(Equal("build not found"))
+			Expect(retryAt).To(BeZero())
+		})
+	})
+
+	Context("when getting the due notifications fails", func() {
+		BeforeEach(func() {
+			dueErr = errors.New("nope")
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+			Expect(fakeBuildFactory.BuildCallCount()).To(BeZero())
+		})
+	})
+})
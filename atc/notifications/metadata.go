@@ -0,0 +1,77 @@
+package notifications
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Metadata describes the build a notification is about. Notifier templates
+// are executed with it, and webhooks without a body send it as JSON.
+type Metadata struct {
+	Event string `json:"event"`
+
+	BuildID   int    `json:"build_id"`
+	BuildName string `json:"build_name"`
+	BuildURL  string `json:"build_url"`
+
+	TeamName             string           `json:"team_name"`
+	PipelineName         string           `json:"pipeline_name,omitempty"`
+	PipelineInstanceVars atc.InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	JobName              string           `json:"job_name,omitempty"`
+
+	CreatedBy string `json:"created_by,omitempty"`
+	StartTime int64  `json:"start_time,omitempty"`
+	EndTime   int64  `json:"end_time,omitempty"`
+
+	// Inputs are the versions of the build's inputs, by input name.
+	Inputs map[string]atc.Version `json:"inputs,omitempty"`
+
+	ExternalURL string `json:"external_url"`
+}
+
+func buildMetadata(build db.Build, event string, externalURL string) (Metadata, error) {
+	externalURL = strings.TrimSuffix(externalURL, "/")
+
+	metadata := Metadata{
+		Event: event,
+
+		BuildID:   build.ID(),
+		BuildName: build.Name(),
+		BuildURL:  fmt.Sprintf("%s/builds/%d", externalURL, build.ID()),
+
+		TeamName:             build.TeamName(),
+		PipelineName:         build.PipelineName(),
+		PipelineInstanceVars: build.PipelineInstanceVars(),
+		JobName:              build.JobName(),
+
+		Inputs: map[string]atc.Version{},
+
+		ExternalURL: externalURL,
+	}
+
+	if createdBy := build.CreatedBy(); createdBy != nil {
+		metadata.CreatedBy = *createdBy
+	}
+
+	if !build.StartTime().IsZero() {
+		metadata.StartTime = build.StartTime().Unix()
+	}
+
+	if event != atc.NotificationEventStarted && !build.EndTime().IsZero() {
+		metadata.EndTime = build.EndTime().Unix()
+	}
+
+	inputs, _, err := build.Resources()
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	for _, input := range inputs {
+		metadata.Inputs[input.Name] = input.Version
+	}
+
+	return metadata, nil
+}
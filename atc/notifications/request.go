@@ -0,0 +1,215 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultGitHubURL = "https://api.github.com"
+	defaultGitLabURL = "https://gitlab.com"
+
+	defaultSlackText   = "{{.PipelineName}}/{{.JobName}} #{{.BuildName}} {{.Event}}: {{.BuildURL}}"
+	defaultContext     = "concourse/{{.PipelineName}}/{{.JobName}}"
+	defaultDescription = "build {{.Event}}"
+)
+
+var gitHubStates = map[string]string{
+	atc.NotificationEventStarted: "pending",
+	string(atc.StatusSucceeded):  "success",
+	string(atc.StatusFailed):     "failure",
+	string(atc.StatusErrored):    "error",
+	string(atc.StatusAborted):    "error",
+}
+
+var gitLabStates = map[string]string{
+	atc.NotificationEventStarted: "running",
+	string(atc.StatusSucceeded):  "success",
+	string(atc.StatusFailed):     "failed",
+	string(atc.StatusErrored):    "failed",
+	string(atc.StatusAborted):    "canceled",
+}
+
+// newRequest builds the request delivering a notification.
+//
+// ((vars)) are resolved before the templates are executed, so that build
+// metadata rendered into the templates can not reference credentials.
+func newRequest(config atc.NotifierConfig, metadata Metadata, variables vars.Variables) (*http.Request, error) {
+	config, err := evaluateVars(config, variables)
+	if err != nil {
+		return nil, fmt.Errorf("resolve vars: %w", err)
+	}
+
+	var (
+		method   = http.MethodPost
+		endpoint string
+		payload  []byte
+		headers  = http.Header{}
+	)
+
+	headers.Set("Content-Type", "application/json")
+
+	switch config.Type {
+	case atc.NotifierTypeWebhook:
+		endpoint = config.URL
+
+		if config.Method != "" {
+			method = strings.ToUpper(config.Method)
+		}
+
+		if config.Body != "" {
+			body, err := render("body", config.Body, metadata)
+			if err != nil {
+				return nil, err
+			}
+
+			payload = []byte(body)
+		} else {
+			payload, err = json.Marshal(metadata)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+	case atc.NotifierTypeSlack:
+		endpoint = config.URL
+
+		text, err := render("text", defaulted(config.Text, defaultSlackText), metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		payload, err = json.Marshal(map[string]string{"text": text})
+		if err != nil {
+			return nil, err
+		}
+
+	case atc.NotifierTypeGitHub, atc.NotifierTypeGitLab:
+		commit, err := render("commit", config.Commit, metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		if commit == "" {
+			return nil, fmt.Errorf("commit rendered empty")
+		}
+
+		statusContext, err := render("context", defaulted(config.Context, defaultContext), metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		description, err := render("description", defaulted(config.Description, defaultDescription), metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		var status map[string]string
+		if config.Type == atc.NotifierTypeGitHub {
+			endpoint = fmt.Sprintf("%s/repos/%s/statuses/%s",
+				strings.TrimSuffix(defaulted(config.URL, defaultGitHubURL), "/"),
+				config.Repository,
+				url.PathEscape(commit),
+			)
+
+			headers.Set("Accept", "application/vnd.github.v3+json")
+			headers.Set("Authorization", "token "+config.Token)
+
+			status = map[string]string{
+				"state":       gitHubStates[metadata.Event],
+				"target_url":  metadata.BuildURL,
+				"description": description,
+				"context":     statusContext,
+			}
+		} else {
+			endpoint = fmt.Sprintf("%s/api/v4/projects/%s/statuses/%s",
+				strings.TrimSuffix(defaulted(config.URL, defaultGitLabURL), "/"),
+				url.PathEscape(config.Repository),
+				url.PathEscape(commit),
+			)
+
+			headers.Set("PRIVATE-TOKEN", config.Token)
+
+			status = map[string]string{
+				"state":       gitLabStates[metadata.Event],
+				"target_url":  metadata.BuildURL,
+				"description": description,
+				"name":        statusContext,
+			}
+		}
+
+		payload, err = json.Marshal(status)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown notifier type '%s'", config.Type)
+	}
+
+	request, err := http.NewRequest(method, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header = headers
+	for name, value := range config.Headers {
+		request.Header.Set(name, value)
+	}
+
+	return request, nil
+}
+
+func evaluateVars(config atc.NotifierConfig, variables vars.Variables) (atc.NotifierConfig, error) {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return atc.NotifierConfig{}, err
+	}
+
+	evaluated, err := vars.NewTemplate(payload).Evaluate(variables, vars.EvaluateOpts{
+		ExpectAllKeys: true,
+	})
+	if err != nil {
+		return atc.NotifierConfig{}, err
+	}
+
+	var evaluatedConfig atc.NotifierConfig
+	err = yaml.Unmarshal(evaluated, &evaluatedConfig)
+	if err != nil {
+		return atc.NotifierConfig{}, err
+	}
+
+	return evaluatedConfig, nil
+}
+
+func render(field string, text string, metadata Metadata) (string, error) {
+	tmpl, err := template.New(field).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parse %s: %w", field, err)
+	}
+
+	buf := new(bytes.Buffer)
+	err = tmpl.Execute(buf, metadata)
+	if err != nil {
+		return "", fmt.Errorf("render %s: %w", field, err)
+	}
+
+	return buf.String(), nil
+}
+
+func defaulted(value string, def string) string {
+	if value == "" {
+		return def
+	}
+
+	return value
+}
@@ -0,0 +1,215 @@
+package atc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+const (
+	NotifierTypeWebhook = "webhook"
+	NotifierTypeSlack   = "slack"
+	NotifierTypeGitHub  = "github"
+	NotifierTypeGitLab  = "gitlab"
+)
+
+// NotificationEventStarted is sent when a build starts running. Builds
+// finishing send their status (succeeded, failed, errored or aborted) as the
+// event.
+const NotificationEventStarted = "started"
+
+const (
+	NotificationSourcePipeline = "pipeline"
+	NotificationSourceTeam     = "team"
+)
+
+const (
+	NotificationStatusPending   = "pending"
+	NotificationStatusDelivered = "delivered"
+	NotificationStatusFailed    = "failed"
+)
+
+var NotificationEvents = []string{
+	NotificationEventStarted,
+	string(StatusSucceeded),
+	string(StatusFailed),
+	string(StatusErrored),
+	string(StatusAborted),
+}
+
+// NotifierConfig configures a notification the ATC sends itself when builds of
+// a pipeline or team start or finish, without running a resource.
+//
+// The body, text, commit, description and context fields are Go templates
+// executed with the build's metadata. Fields may use ((vars)), which are
+// resolved through the team's credential manager when delivering.
+type NotifierConfig struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	// Events lists the events to notify for. All events are notified if
+	// empty.
+	Events []string `json:"events,omitempty"`
+
+	// URL is the endpoint for webhooks and Slack, or the API base URL for
+	// GitHub and GitLab.
+	URL     string            `json:"url,omitempty"`
+	Method  string            `json:"method,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+
+	// Text is the message posted to Slack.
+	Text string `json:"text,omitempty"`
+
+	// Repository is the GitHub repository (owner/name) or the GitLab project
+	// (ID or path) to set the commit status in.
+	Repository  string `json:"repository,omitempty"`
+	Commit      string `json:"commit,omitempty"`
+	Context     string `json:"context,omitempty"`
+	Description string `json:"description,omitempty"`
+	Token       string `json:"token,omitempty"`
+}
+
+type NotifierConfigs []NotifierConfig
+
+func (configs NotifierConfigs) Lookup(name string) (NotifierConfig, bool) {
+	for _, config := range configs {
+		if config.Name == name {
+			return config, true
+		}
+	}
+
+	return NotifierConfig{}, false
+}
+
+// Validate validates each notifier and that their names are unique.
+func (configs NotifierConfigs) Validate() error {
+	names := map[string]bool{}
+	for _, config := range configs {
+		err := config.Validate()
+		if err != nil {
+			if config.Name == "" {
+				return fmt.Errorf("invalid notifier: %w", err)
+			}
+
+			return fmt.Errorf("invalid notifier '%s': %w", config.Name, err)
+		}
+
+		if names[config.Name] {
+			return fmt.Errorf("duplicate notifier name '%s'", config.Name)
+		}
+
+		names[config.Name] = true
+	}
+
+	return nil
+}
+
+// Notifies returns whether the notifier should be sent for the event.
+func (config NotifierConfig) Notifies(event string) bool {
+	if len(config.Events) == 0 {
+		return true
+	}
+
+	for _, e := range config.Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (config NotifierConfig) Validate() error {
+	var errorMessages []string
+
+	if config.Name == "" {
+		errorMessages = append(errorMessages, "name is missing")
+	}
+
+	switch config.Type {
+	case NotifierTypeWebhook, NotifierTypeSlack:
+		if config.URL == "" {
+			errorMessages = append(errorMessages, "url is missing")
+		}
+
+	case NotifierTypeGitHub, NotifierTypeGitLab:
+		if config.Repository == "" {
+			errorMessages = append(errorMessages, "repository is missing")
+		}
+
+		if config.Commit == "" {
+			errorMessages = append(errorMessages, "commit is missing")
+		}
+
+		if config.Token == "" {
+			errorMessages = append(errorMessages, "token is missing")
+		}
+
+	case "":
+		errorMessages = append(errorMessages, "type is missing")
+
+	default:
+		errorMessages = append(errorMessages, fmt.Sprintf("unknown type '%s'", config.Type))
+	}
+
+	for _, e := range config.Events {
+		known := false
+		for _, event := range NotificationEvents {
+			if e == event {
+				known = true
+			}
+		}
+
+		if !known {
+			errorMessages = append(errorMessages, fmt.Sprintf("unknown event '%s' (must be one of %s)", e, strings.Join(NotificationEvents, ", ")))
+		}
+	}
+
+	templates := []struct {
+		field string
+		text  string
+	}{
+		{"body", config.Body},
+		{"text", config.Text},
+		{"commit", config.Commit},
+		{"context", config.Context},
+		{"description", config.Description},
+	}
+
+	for _, t := range templates {
+		_, err := template.New(t.field).Parse(t.text)
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s is not a valid template: %s", t.field, err))
+		}
+	}
+
+	if len(errorMessages) > 0 {
+		return errors.New(strings.Join(errorMessages, "; "))
+	}
+
+	return nil
+}
+
+// BuildNotification is the delivery of a notification for a build event.
+type BuildNotification struct {
+	ID       int    `json:"id"`
+	BuildID  int    `json:"build_id"`
+	Notifier string `json:"notifier"`
+
+	// Source is where the notifier is configured: "pipeline" or "team".
+	Source string `json:"source"`
+	Event  string `json:"event"`
+
+	// Status is "pending" until the notification is "delivered", or "failed"
+	// once all attempts at delivering it have failed.
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	ResponseStatus int    `json:"response_status,omitempty"`
+	Error          string `json:"error,omitempty"`
+
+	CreatedAt     int64 `json:"created_at"`
+	NextAttemptAt int64 `json:"next_attempt_at,omitempty"`
+	DeliveredAt   int64 `json:"delivered_at,omitempty"`
+}
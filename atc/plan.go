@@ -280,6 +280,9 @@ type TaskPlan struct {
 	// Limits to set on the Task Container
 	Limits *ContainerLimits `json:"container_limits,omitempty"`
 
+	// Network access of the Task Container. Unrestricted if not set.
+	Network *NetworkConfig `json:"network,omitempty"`
+
 	// An artifact in the build plan to use as the task's image. Overrides any
 	// image set in the task's config.
 	ImageArtifactName string `json:"image,omitempty"`
@@ -299,6 +302,15 @@ type TaskPlan struct {
 	// image does not count towards the timeout.
 	Timeout string `json:"timeout,omitempty"`
 
+	// Checkpoint the task's container when its worker is landed or retired,
+	// and restore it on another worker, rather than holding up the worker or
+	// being aborted. Set on the tasks of jobs configured as migratable.
+	Migratable bool `json:"migratable,omitempty"`
+
+	// Failures of the task are attributed to flakiness, allowing the job's
+	// retry_on_flake policy to rerun the build.
+	FlakyTolerant bool `json:"flaky_tolerant,omitempty"`
+
 	// Resource types to have available for use when fetching the task's image.
 	//
 	// XXX(check-refactor): Eliminating this would be great - if we can replace
@@ -8,6 +8,8 @@ const (
 
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
+	GetBuildUsage       = "GetBuildUsage"
+	GetBuildTimeline    = "GetBuildTimeline"
 	CreateBuild         = "CreateBuild"
 	ListBuilds          = "ListBuilds"
 	BuildEvents         = "BuildEvents"
@@ -16,6 +18,9 @@ const (
 	GetBuildPreparation = "GetBuildPreparation"
 	SetBuildComment     = "SetBuildComment"
 
+	ListBuildNotifications = "ListBuildNotifications"
+	ListTeamNotifications  = "ListTeamNotifications"
+
 	GetJob         = "GetJob"
 	CreateJobBuild = "CreateJobBuild"
 	RerunJobBuild  = "RerunJobBuild"
@@ -81,6 +86,15 @@ const (
 	ListWorkers     = "ListWorkers"
 	DeleteWorker    = "DeleteWorker"
 
+	GetWorkerCapacity = "GetWorkerCapacity"
+
+	ListWorkerKeys      = "ListWorkerKeys"
+	CreateWorkerKey     = "CreateWorkerKey"
+	RevokeWorkerKey     = "RevokeWorkerKey"
+	ListTeamWorkerKeys  = "ListTeamWorkerKeys"
+	CreateTeamWorkerKey = "CreateTeamWorkerKey"
+	RevokeTeamWorkerKey = "RevokeTeamWorkerKey"
+
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
 
@@ -105,6 +119,10 @@ const (
 	DestroyTeam    = "DestroyTeam"
 	ListTeamBuilds = "ListTeamBuilds"
 
+	SearchBuildLogs = "SearchBuildLogs"
+	GetBuildStats   = "GetBuildStats"
+	ListFlakyJobs   = "ListFlakyJobs"
+
 	CreateArtifact     = "CreateArtifact"
 	GetArtifact        = "GetArtifact"
 	ListBuildArtifacts = "ListBuildArtifacts"
@@ -115,11 +133,31 @@ const (
 	SetWall   = "SetWall"
 	GetWall   = "GetWall"
 	ClearWall = "ClearWall"
+
+	ListAuditEvents = "ListAuditEvents"
 )
 
 const (
 	ClearTaskCacheQueryPath = "cache_path"
 	SaveConfigCheckCreds    = "check_creds"
+
+	SearchBuildLogsQueryText     = "query"
+	SearchBuildLogsQueryPipeline = "pipeline_name"
+	SearchBuildLogsQueryJob      = "job_name"
+	SearchBuildLogsQuerySince    = "since"
+	SearchBuildLogsQueryUntil    = "until"
+	SearchBuildLogsQueryContext  = "context"
+
+	BuildStatsQueryPipeline = "pipeline_name"
+	BuildStatsQueryJob      = "job_name"
+	BuildStatsQuerySince    = "since"
+	BuildStatsQueryUntil    = "until"
+
+	AuditLogQueryUser   = "user"
+	AuditLogQueryAction = "action"
+	AuditLogQueryTeam   = "team_name"
+	AuditLogQuerySince  = "since"
+	AuditLogQueryUntil  = "until"
 )
 
 var Routes = rata.Routes([]rata.Route{
@@ -131,12 +169,15 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds", Method: "GET", Name: ListBuilds},
 	{Path: "/api/v1/builds/:build_id", Method: "GET", Name: GetBuild},
 	{Path: "/api/v1/builds/:build_id/plan", Method: "GET", Name: GetBuildPlan},
+	{Path: "/api/v1/builds/:build_id/usage", Method: "GET", Name: GetBuildUsage},
+	{Path: "/api/v1/builds/:build_id/timeline", Method: "GET", Name: GetBuildTimeline},
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
 	{Path: "/api/v1/builds/:build_id/comment", Method: "PUT", Name: SetBuildComment},
+	{Path: "/api/v1/builds/:build_id/notifications", Method: "GET", Name: ListBuildNotifications},
 
 	{Path: "/api/v1/jobs", Method: "GET", Name: ListAllJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
@@ -204,6 +245,17 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/workers/:worker_name/heartbeat", Method: "PUT", Name: HeartbeatWorker},
 	{Path: "/api/v1/workers/:worker_name", Method: "DELETE", Name: DeleteWorker},
 
+	{Path: "/api/v1/worker-capacity", Method: "GET", Name: GetWorkerCapacity},
+
+	{Path: "/api/v1/worker-keys", Method: "GET", Name: ListWorkerKeys},
+	{Path: "/api/v1/worker-keys", Method: "POST", Name: CreateWorkerKey},
+	{Path: "/api/v1/worker-keys/:worker_key_id", Method: "DELETE", Name: RevokeWorkerKey},
+	{Path: "/api/v1/teams/:team_name/worker-keys", Method: "GET", Name: ListTeamWorkerKeys},
+	{Path: "/api/v1/teams/:team_name/worker-keys", Method: "POST", Name: CreateTeamWorkerKey},
+	{Path: "/api/v1/teams/:team_name/worker-keys/:worker_key_id", Method: "DELETE", Name: RevokeTeamWorkerKey},
+
+	{Path: "/api/v1/teams/:team_name/notifications", Method: "GET", Name: ListTeamNotifications},
+
 	{Path: "/api/v1/log-level", Method: "GET", Name: GetLogLevel},
 	{Path: "/api/v1/log-level", Method: "PUT", Name: SetLogLevel},
 
@@ -230,6 +282,9 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
+	{Path: "/api/v1/teams/:team_name/logs", Method: "GET", Name: SearchBuildLogs},
+	{Path: "/api/v1/teams/:team_name/stats", Method: "GET", Name: GetBuildStats},
+	{Path: "/api/v1/teams/:team_name/flaky_jobs", Method: "GET", Name: ListFlakyJobs},
 
 	{Path: "/api/v1/teams/:team_name/artifacts", Method: "POST", Name: CreateArtifact},
 	{Path: "/api/v1/teams/:team_name/artifacts/:artifact_id", Method: "GET", Name: GetArtifact},
@@ -237,4 +292,6 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/wall", Method: "GET", Name: GetWall},
 	{Path: "/api/v1/wall", Method: "PUT", Name: SetWall},
 	{Path: "/api/v1/wall", Method: "DELETE", Name: ClearWall},
+
+	{Path: "/api/v1/audit-log", Method: "GET", Name: ListAuditEvents},
 })
@@ -0,0 +1,120 @@
+package runtime
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+//counterfeiter:generate . PhaseTimingDelegate
+type PhaseTimingDelegate interface {
+	PhaseTimed(lager.Logger, atc.StepTiming)
+}
+
+type timedStepKey struct{}
+type phaseTimerKey struct{}
+
+type timedStep struct {
+	planID   atc.PlanID
+	stepName string
+	delegate PhaseTimingDelegate
+}
+
+// WithPhaseTiming returns a context in which the phases of the given step
+// are timed, e.g. by the worker pool and the worker, and reported to the
+// delegate.
+func WithPhaseTiming(ctx context.Context, planID atc.PlanID, stepName string, delegate PhaseTimingDelegate) context.Context {
+	ctx = context.WithValue(ctx, timedStepKey{}, timedStep{
+		planID:   planID,
+		stepName: stepName,
+		delegate: delegate,
+	})
+
+	// phases of the enclosing step, e.g. the step fetching an image for it,
+	// don't carry on into this one
+	return context.WithValue(ctx, phaseTimerKey{}, (*PhaseTimer)(nil))
+}
+
+// PhaseTimer times a phase of a step. A nil *PhaseTimer, as started outside
+// of a context with phase timing, does nothing.
+type PhaseTimer struct {
+	step  timedStep
+	phase atc.StepPhase
+	start time.Time
+
+	bytes     int64
+	counted   int32
+	uncounted int32
+}
+
+// StartPhase starts timing a phase of the step that the context is for. The
+// returned context carries the timer, so that the bytes streamed during the
+// phase can be counted through PhaseFromContext.
+func StartPhase(ctx context.Context, phase atc.StepPhase) (context.Context, *PhaseTimer) {
+	step, ok := ctx.Value(timedStepKey{}).(timedStep)
+	if !ok {
+		return ctx, nil
+	}
+
+	timer := &PhaseTimer{
+		step:  step,
+		phase: phase,
+		start: time.Now(),
+	}
+
+	return context.WithValue(ctx, phaseTimerKey{}, timer), timer
+}
+
+// PhaseFromContext returns the innermost phase timed within the context, if
+// any.
+func PhaseFromContext(ctx context.Context) *PhaseTimer {
+	timer, _ := ctx.Value(phaseTimerKey{}).(*PhaseTimer)
+	return timer
+}
+
+// AddBytes counts bytes streamed during the phase. It may be called
+// concurrently, e.g. when inputs are streamed in parallel.
+func (timer *PhaseTimer) AddBytes(bytes int64) {
+	if timer == nil {
+		return
+	}
+
+	atomic.AddInt64(&timer.bytes, bytes)
+	atomic.StoreInt32(&timer.counted, 1)
+}
+
+// AddUncountedBytes notes that bytes were streamed during the phase without
+// going through the ATC, e.g. a volume streamed directly between workers.
+// The bytes of the phase are then left out rather than under-reported.
+func (timer *PhaseTimer) AddUncountedBytes() {
+	if timer == nil {
+		return
+	}
+
+	atomic.StoreInt32(&timer.uncounted, 1)
+}
+
+// Finish reports how long the phase took to the delegate.
+func (timer *PhaseTimer) Finish(logger lager.Logger) {
+	if timer == nil {
+		return
+	}
+
+	var bytes *int64
+	if atomic.LoadInt32(&timer.counted) == 1 && atomic.LoadInt32(&timer.uncounted) == 0 {
+		counted := atomic.LoadInt64(&timer.bytes)
+		bytes = &counted
+	}
+
+	timer.step.delegate.PhaseTimed(logger, atc.StepTiming{
+		PlanID:    timer.step.planID,
+		StepName:  timer.step.stepName,
+		Phase:     timer.phase,
+		StartTime: timer.start.Unix(),
+		Duration:  time.Since(timer.start).Seconds(),
+		Bytes:     bytes,
+	})
+}
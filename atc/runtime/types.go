@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
+	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 )
@@ -14,8 +16,41 @@ import (
 const (
 	ResourceResultPropertyName = "concourse:resource-result"
 	ResourceProcessID          = "resource"
+
+	// BlockIOPropertyName is a read-only container property through which
+	// workers report the bytes read from and written to block devices by the
+	// container, as "<read> <written>". Garden's metrics have no field for
+	// block IO.
+	BlockIOPropertyName = "concourse:block-io"
+
+	// SecurityPropertyName is the container property under which the
+	// security config of a container is passed to the worker, encoded as
+	// JSON.
+	SecurityPropertyName = "concourse:security"
+
+	// EgressHostsPropertyName is the container property under which the
+	// egress rules to host names are passed to the worker, encoded as JSON.
+	// The worker resolves the host names when it creates the container.
+	EgressHostsPropertyName = "concourse:egress-hosts"
+
+	// ExitReasonPropertyName is the container property under which workers
+	// record why a process was killed by a signal, as an atc.ExitReason
+	// encoded as JSON.
+	ExitReasonPropertyName = "concourse:exit-reason"
+
+	// WarmPoolPropertyName is the container property naming the image that a
+	// container is to be claimed from the warm pool of the worker for,
+	// instead of being created. Containers waiting in the pool carry it too.
+	WarmPoolPropertyName = "concourse:warm-pool"
 )
 
+// EgressHostRule is an egress rule to the addresses that a host name resolves
+// to on the worker.
+type EgressHostRule struct {
+	Host string            `json:"host"`
+	Rule garden.NetOutRule `json:"rule"`
+}
+
 //counterfeiter:generate . StartingEventDelegate
 type StartingEventDelegate interface {
 	Starting(lager.Logger)
@@ -90,4 +125,12 @@ type ProcessSpec struct {
 	User         string
 	StdoutWriter io.Writer
 	StderrWriter io.Writer
+
+	// UsageInterval is how often the resource usage of the container is
+	// sampled while the process runs. Usage is not sampled when it is zero.
+	UsageInterval time.Duration
+
+	// UsageReporter, if set, is called with the usage sampled so far after
+	// every sample.
+	UsageReporter func(atc.ContainerUsage)
 }
@@ -0,0 +1,41 @@
+package runtime
+
+import "strings"
+
+// WarmPoolHandlePrefix prefixes the handles of the containers in the warm
+// pool of a worker, and of their volumes, which are only known to the worker
+// and so must never be reported to the ATC, lest it destroys them for being
+// unknown.
+const WarmPoolHandlePrefix = "warm-pool-"
+
+// The images of tasks are told apart from base resource types by a colon,
+// which the names of resource types can't have.
+const (
+	warmTaskImagePrefix           = "task:"
+	warmPrivilegedTaskImagePrefix = "privileged-task:"
+)
+
+// WarmTaskImage names the image of a task for the warm pool of a worker,
+// given the handle of the volume that the ATC fetched the image into on the
+// worker. Containers of the same image differ in whether they're privileged.
+func WarmTaskImage(volumeHandle string, privileged bool) string {
+	if privileged {
+		return warmPrivilegedTaskImagePrefix + volumeHandle
+	}
+
+	return warmTaskImagePrefix + volumeHandle
+}
+
+// ParseWarmTaskImage is the inverse of WarmTaskImage. It returns false if
+// the image isn't the image of a task, i.e. it's a base resource type.
+func ParseWarmTaskImage(image string) (volumeHandle string, privileged bool, ok bool) {
+	if strings.HasPrefix(image, warmPrivilegedTaskImagePrefix) {
+		return strings.TrimPrefix(image, warmPrivilegedTaskImagePrefix), true, true
+	}
+
+	if strings.HasPrefix(image, warmTaskImagePrefix) {
+		return strings.TrimPrefix(image, warmTaskImagePrefix), false, true
+	}
+
+	return "", false, false
+}
@@ -211,6 +211,14 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		}, nil
 	}
 
+	if config.Migratable {
+		plan.Each(func(p *atc.Plan) {
+			if p.Task != nil {
+				p.Task.Migratable = true
+			}
+		})
+	}
+
 	started, err := nextPendingBuild.Start(plan)
 	if err != nil {
 		logger.Error("failed-to-mark-build-as-started", err)
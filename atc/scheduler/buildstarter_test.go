@@ -781,6 +781,33 @@ var _ = Describe("BuildStarter", func() {
 											Expect(rerunBuild.StartArgsForCall(0)).To(Equal(plannedPlan))
 										})
 									})
+
+									Context("when the job is migratable", func() {
+										BeforeEach(func() {
+											migratableConfig := jobConfig
+											migratableConfig.Migratable = true
+											job.ConfigReturns(migratableConfig, nil)
+
+											fakePlanner.CreateStub = func(atc.StepConfig, db.SchedulerResources, atc.VersionedResourceTypes, atc.Prototypes, []db.BuildInput) (atc.Plan, error) {
+												return atc.Plan{
+													Do: &atc.DoPlan{
+														{Get: &atc.GetPlan{Name: "some-input"}},
+														{Task: &atc.TaskPlan{Name: "some-task"}},
+													},
+												}, nil
+											}
+										})
+
+										It("starts the builds with migratable tasks", func() {
+											Expect(pendingBuild1.StartCallCount()).To(Equal(1))
+											Expect(pendingBuild1.StartArgsForCall(0)).To(Equal(atc.Plan{
+												Do: &atc.DoPlan{
+													{Get: &atc.GetPlan{Name: "some-input"}},
+													{Task: &atc.TaskPlan{Name: "some-task", Migratable: true}},
+												},
+											}))
+										})
+									})
 								})
 							})
 						})
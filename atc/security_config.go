@@ -0,0 +1,112 @@
+package atc
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// CapabilityAll may be given as a capability to drop in order to drop every
+// capability not explicitly added.
+const CapabilityAll = "ALL"
+
+var (
+	capabilityRegex     = regexp.MustCompile(`^CAP_[A-Z_]+$`)
+	seccompProfileRegex = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+)
+
+// SecurityConfig grants a task's container specific privileges, as an
+// alternative to running it fully privileged.
+//
+// Capabilities and devices are granted only if every one of them is in the
+// allow-list of the worker running the task, and the seccomp profile must be
+// one of those the worker provides. Dropping capabilities and a read-only
+// rootfs only ever restrict the container, and so are always allowed.
+type SecurityConfig struct {
+	Capabilities   CapabilitiesConfig `json:"capabilities"`
+	Devices        []string           `json:"devices,omitempty"`
+	Seccomp        string             `json:"seccomp,omitempty"`
+	ReadOnlyRootfs bool               `json:"readonly_rootfs,omitempty"`
+}
+
+// CapabilitiesConfig lists Linux capabilities (e.g. CAP_SYS_PTRACE) to add to
+// or drop from the default set of a container.
+type CapabilitiesConfig struct {
+	Add  []string `json:"add,omitempty"`
+	Drop []string `json:"drop,omitempty"`
+}
+
+func (config SecurityConfig) Validate() []string {
+	var errs []string
+
+	for _, capability := range config.Capabilities.Add {
+		if !capabilityRegex.MatchString(capability) {
+			errs = append(errs, fmt.Sprintf("invalid capability '%s' to add (expected a name of the form CAP_NAME)", capability))
+		}
+	}
+
+	for _, capability := range config.Capabilities.Drop {
+		if capability != CapabilityAll && !capabilityRegex.MatchString(capability) {
+			errs = append(errs, fmt.Sprintf("invalid capability '%s' to drop (expected a name of the form CAP_NAME or '%s')", capability, CapabilityAll))
+		}
+	}
+
+	for _, device := range config.Devices {
+		if !ValidDevicePath(device) {
+			errs = append(errs, fmt.Sprintf("invalid device '%s' (expected an absolute path under /dev)", device))
+		}
+	}
+
+	if config.Seccomp != "" && !seccompProfileRegex.MatchString(config.Seccomp) {
+		errs = append(errs, fmt.Sprintf("invalid seccomp profile name '%s'", config.Seccomp))
+	}
+
+	return errs
+}
+
+// ValidDevicePath returns whether the path is a clean, absolute path to a
+// device node under /dev.
+func ValidDevicePath(path string) bool {
+	return filepath.Clean(path) == path && strings.HasPrefix(path, "/dev/")
+}
+
+// SecurityAllowList is advertised by workers which support task security
+// configs, listing the privileges that tasks may be granted on them.
+type SecurityAllowList struct {
+	Capabilities    []string `json:"capabilities,omitempty"`
+	Devices         []string `json:"devices,omitempty"`
+	SeccompProfiles []string `json:"seccomp_profiles,omitempty"`
+}
+
+// Permits returns whether every privilege granted by the config is in the
+// allow-list.
+func (allowList SecurityAllowList) Permits(config SecurityConfig) bool {
+	for _, capability := range config.Capabilities.Add {
+		if !containsString(allowList.Capabilities, capability) {
+			return false
+		}
+	}
+
+	for _, device := range config.Devices {
+		if !containsString(allowList.Devices, device) {
+			return false
+		}
+	}
+
+	if config.Seccomp != "" && !containsString(allowList.SeccompProfiles, config.Seccomp) {
+		return false
+	}
+
+	return true
+}
+
+func containsString(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
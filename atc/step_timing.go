@@ -0,0 +1,51 @@
+package atc
+
+// StepPhase is a phase of running a step that is timed separately, so that
+// it's clear where the time of a slow build went.
+type StepPhase string
+
+const (
+	// StepPhaseWaitingForWorker is the time taken to find a worker for the
+	// step, including any time spent waiting for one to become available.
+	StepPhaseWaitingForWorker StepPhase = "waiting-for-worker"
+
+	// StepPhaseImageCheck and StepPhaseImageGet are the time taken to check
+	// for and fetch the image of the step, from an image_resource or a custom
+	// resource type.
+	StepPhaseImageCheck StepPhase = "image-check"
+	StepPhaseImageGet   StepPhase = "image-get"
+
+	// StepPhaseCreatingContainer is the time taken to create the container of
+	// the step on its worker, which includes streaming its inputs.
+	StepPhaseCreatingContainer StepPhase = "creating-container"
+
+	// StepPhaseStreamingInputs is the time taken to stream the inputs of the
+	// step from the workers they were produced on.
+	StepPhaseStreamingInputs StepPhase = "streaming-inputs"
+
+	// StepPhaseRunning is the time the process of the step ran for.
+	StepPhaseRunning StepPhase = "running"
+
+	// StepPhaseRegisteringOutputs is the time taken to register the outputs
+	// of the step, e.g. its artifacts, caches and resource versions.
+	StepPhaseRegisteringOutputs StepPhase = "registering-outputs"
+)
+
+// StepTiming is how long a phase of a step of a build took. Phases may
+// overlap, as inputs are streamed while the container is being created.
+type StepTiming struct {
+	PlanID   PlanID    `json:"plan_id"`
+	StepName string    `json:"step_name"`
+	Phase    StepPhase `json:"phase"`
+
+	// StartTime is when the phase started, in seconds since the epoch.
+	StartTime int64 `json:"start_time"`
+
+	// Duration is how long the phase took, in seconds.
+	Duration float64 `json:"duration"`
+
+	// Bytes is the number of bytes streamed through the ATC during the phase.
+	// It's left out for phases that didn't stream anything, and for those
+	// that streamed volumes directly between workers, which can't be counted.
+	Bytes *int64 `json:"bytes,omitempty"`
+}
@@ -87,6 +87,16 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 		})
 	}
 
+	if plan.Network != nil {
+		validator.pushContext(".network")
+
+		for _, msg := range plan.Network.Validate() {
+			validator.recordError(msg)
+		}
+
+		validator.popContext()
+	}
+
 	if plan.Config != nil {
 		validator.pushContext(".config")
 
@@ -342,6 +342,7 @@ type TaskStep struct {
 	Privileged        bool              `json:"privileged,omitempty"`
 	ConfigPath        string            `json:"file,omitempty"`
 	Limits            *ContainerLimits  `json:"container_limits,omitempty"`
+	Network           *NetworkConfig    `json:"network,omitempty"`
 	Config            *TaskConfig       `json:"config,omitempty"`
 	Params            TaskEnv           `json:"params,omitempty"`
 	Vars              Params            `json:"vars,omitempty"`
@@ -350,6 +351,7 @@ type TaskStep struct {
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
 	Timeout           string            `json:"timeout,omitempty"`
+	FlakyTolerant     bool              `json:"flaky_tolerant,omitempty"`
 }
 
 func (step *TaskStep) Visit(v StepVisitor) error {
@@ -141,6 +141,69 @@ var factoryTests = []StepTest{
 			Timeout:           "1h",
 		},
 	},
+	{
+		Title: "task step with egress rules",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-task-file
+			network:
+			  egress:
+			  - host: github.com
+			    ports: [22, 443]
+			    protocol: tcp
+			  - cidr: 10.0.0.0/8
+			    ports: [8000-8080]
+		`,
+
+		StepConfig: &atc.TaskStep{
+			Name:       "some-task",
+			ConfigPath: "some-task-file",
+			Network: &atc.NetworkConfig{
+				Egress: []atc.EgressRule{
+					{
+						Host:     "github.com",
+						Ports:    []atc.PortRange{{Start: 22, End: 22}, {Start: 443, End: 443}},
+						Protocol: atc.EgressProtocolTCP,
+					},
+					{
+						CIDR:  "10.0.0.0/8",
+						Ports: []atc.PortRange{{Start: 8000, End: 8080}},
+					},
+				},
+			},
+		},
+	},
+	{
+		Title: "flaky tolerant task step",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-task-file
+			flaky_tolerant: true
+		`,
+
+		StepConfig: &atc.TaskStep{
+			Name:          "some-task",
+			ConfigPath:    "some-task-file",
+			FlakyTolerant: true,
+		},
+	},
+	{
+		Title: "task step without network",
+
+		ConfigYAML: `
+			task: some-task
+			file: some-task-file
+			network: none
+		`,
+
+		StepConfig: &atc.TaskStep{
+			Name:       "some-task",
+			ConfigPath: "some-task-file",
+			Network:    &atc.NetworkConfig{None: true},
+		},
+	},
 	{
 		Title: "task step with non-string params",
 
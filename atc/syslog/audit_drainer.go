@@ -0,0 +1,97 @@
+package syslog
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// auditDrainBatchSize is the number of audit events read from the database
+// and written to the syslog server at a time.
+const auditDrainBatchSize = 500
+
+const auditTag = "audit"
+
+type auditDrainer struct {
+	hostname  string
+	transport string
+	address   string
+	caCerts   []string
+	auditLog  db.AuditLog
+}
+
+// NewAuditDrainer forwards the events of the audit log to a syslog server as
+// JSON, each once.
+func NewAuditDrainer(transport string, address string, hostname string, caCerts []string, auditLog db.AuditLog) Drainer {
+	return &auditDrainer{
+		hostname:  hostname,
+		transport: transport,
+		address:   address,
+		caCerts:   caCerts,
+		auditLog:  auditLog,
+	}
+}
+
+func (d *auditDrainer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("syslog-audit")
+
+	var syslog *Syslog
+	for {
+		events, err := d.auditLog.UndrainedEvents(auditDrainBatchSize)
+		if err != nil {
+			logger.Error("failed-to-get-undrained-audit-events", err)
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		if syslog == nil {
+			syslog, err = Dial(d.transport, d.address, d.caCerts)
+			if err != nil {
+				logger.Error("failed-to-connect", err)
+				return err
+			}
+
+			// ignore any errors coming from syslog.Close()
+			defer db.Close(syslog)
+		}
+
+		var (
+			drained  []int
+			writeErr error
+		)
+		for _, event := range events {
+			message, _ := json.Marshal(event)
+
+			writeErr = syslog.Write(d.hostname, auditTag, time.Unix(event.Time, 0), string(message), strconv.Itoa(event.ID))
+			if writeErr != nil {
+				logger.Error("failed-to-write-to-server", writeErr)
+				break
+			}
+
+			drained = append(drained, event.ID)
+		}
+
+		// mark the events written so far even if one failed, so that they
+		// aren't sent twice
+		err = d.auditLog.MarkDrained(drained)
+		if err != nil {
+			logger.Error("failed-to-mark-audit-events-drained", err)
+			return err
+		}
+
+		if writeErr != nil {
+			return writeErr
+		}
+
+		if len(events) < auditDrainBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package syslog_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/syslog"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditDrainer", func() {
+	var (
+		fakeAuditLog *dbfakes.FakeAuditLog
+		server       *testServer
+	)
+
+	BeforeEach(func() {
+		fakeAuditLog = new(dbfakes.FakeAuditLog)
+		server = newTestServer(nil)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when there are events that have not been drained", func() {
+		BeforeEach(func() {
+			fakeAuditLog.UndrainedEventsReturns([]atc.AuditEvent{
+				{ID: 1, Time: 1533744538, Action: atc.SaveConfig, User: "some-user", TeamName: "main", Status: 200, Diff: "jobs: +some-job"},
+				{ID: 2, Time: 1533744539, Action: atc.SetTeam, User: "other-user", TeamName: "main", Status: 403},
+			}, nil)
+		})
+
+		It("writes them as JSON and marks them drained", func() {
+			testDrainer := syslog.NewAuditDrainer("tcp", server.Addr, "test", []string{}, fakeAuditLog)
+			err := testDrainer.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			got := <-server.Messages
+			Expect(got).To(ContainSubstring(`test audit - - [concourse@0 eventId="1"] {"id":1,"time":1533744538,"action":"SaveConfig","user":"some-user","team_name":"main","status":200,"diff":"jobs: +some-job"}`))
+			Expect(got).To(ContainSubstring(`test audit - - [concourse@0 eventId="2"] {"id":2,"time":1533744539,"action":"SetTeam","user":"other-user","team_name":"main","status":403}`))
+
+			Expect(fakeAuditLog.MarkDrainedCallCount()).To(Equal(1))
+			Expect(fakeAuditLog.MarkDrainedArgsForCall(0)).To(Equal([]int{1, 2}))
+		}, 0.2)
+	})
+
+	Context("when there are no events to drain", func() {
+		It("does not connect", func() {
+			testDrainer := syslog.NewAuditDrainer("tcp", "127.0.0.1:1", "test", []string{}, fakeAuditLog)
+			err := testDrainer.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeAuditLog.MarkDrainedCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting the events fails", func() {
+		BeforeEach(func() {
+			fakeAuditLog.UndrainedEventsReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			testDrainer := syslog.NewAuditDrainer("tcp", server.Addr, "test", []string{}, fakeAuditLog)
+			err := testDrainer.Run(context.TODO())
+			Expect(err).To(MatchError("nope"))
+		})
+	})
+})
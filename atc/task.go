@@ -22,6 +22,9 @@ type TaskConfig struct {
 	// Limits to set on the Task Container
 	Limits *ContainerLimits `json:"container_limits,omitempty"`
 
+	// Fine-grained privileges to grant the Task Container
+	Security *SecurityConfig `json:"security,omitempty"`
+
 	// Parameters to pass to the task via environment variables.
 	Params TaskEnv `json:"params,omitempty"`
 
@@ -45,6 +48,11 @@ type ImageResource struct {
 	Version Version `json:"version,omitempty"`
 	Params  Params  `json:"params,omitempty"`
 	Tags    Tags    `json:"tags,omitempty"`
+
+	// Warm asks workers which keep a warm pool of task images to keep
+	// started containers of the image, for the task to claim instead of
+	// creating its own.
+	Warm bool `json:"warm,omitempty"`
 }
 
 func (ir *ImageResource) ApplySourceDefaults(resourceTypes VersionedResourceTypes) {
@@ -100,6 +108,12 @@ func (config TaskConfig) Validate() error {
 	errors = append(errors, config.validateInputContainsNames()...)
 	errors = append(errors, config.validateOutputContainsNames()...)
 
+	if config.Security != nil {
+		for _, err := range config.Security.Validate() {
+			errors = append(errors, "  security: "+err)
+		}
+	}
+
 	if len(errors) > 0 {
 		return TaskValidationError{
 			Errors: errors,
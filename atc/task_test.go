@@ -281,6 +281,34 @@ run: {path: a/file}
 				})
 			})
 
+			Context("when a disk limit is specified", func() {
+				It("parses the provided disk limit with units", func() {
+					data := []byte(`
+platform: beos
+container_limits: { disk: 10GB }
+
+run: {path: a/file}
+`)
+					task, err := NewTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					disk := DiskLimit(10 * 1024 * 1024 * 1024)
+					Expect(task.Limits).To(Equal(&ContainerLimits{
+						Disk: &disk,
+					}))
+				})
+
+				It("throws an error for an invalid disk limit", func() {
+					data := []byte(`
+platform: beos
+container_limits: { disk: lots }
+
+run: {path: a/file}
+`)
+					_, err := NewTaskConfig(data)
+					Expect(err).To(MatchError(ContainSubstring("could not parse container disk limit")))
+				})
+			})
+
 			Context("when invalid memory limit value is provided", func() {
 				It("throws an error and does not continue", func() {
 					data := []byte(`
@@ -385,6 +413,55 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when a security config is specified", func() {
+			It("parses it", func() {
+				data := []byte(`
+platform: linux
+security:
+  capabilities: {add: [CAP_SYS_PTRACE], drop: [ALL]}
+  devices: [/dev/fuse]
+  seccomp: some-profile
+  readonly_rootfs: true
+
+run: {path: a/file}
+`)
+				task, err := NewTaskConfig(data)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(task.Security).To(Equal(&SecurityConfig{
+					Capabilities: CapabilitiesConfig{
+						Add:  []string{"CAP_SYS_PTRACE"},
+						Drop: []string{"ALL"},
+					},
+					Devices:        []string{"/dev/fuse"},
+					Seccomp:        "some-profile",
+					ReadOnlyRootfs: true,
+				}))
+			})
+
+			Context("when it is invalid", func() {
+				BeforeEach(func() {
+					invalidConfig.Security = &SecurityConfig{
+						Capabilities: CapabilitiesConfig{
+							Add:  []string{"sys_ptrace", "ALL"},
+							Drop: []string{"CAP_NET_RAW", "ALL"},
+						},
+						Devices: []string{"/dev/../etc/shadow"},
+						Seccomp: "../profile",
+					}
+				})
+
+				It("returns an error", func() {
+					err := invalidConfig.Validate()
+
+					Expect(err).To(MatchError(ContainSubstring("invalid capability 'sys_ptrace' to add")))
+					Expect(err).To(MatchError(ContainSubstring("invalid capability 'ALL' to add")))
+					Expect(err).ToNot(MatchError(ContainSubstring("to drop")))
+					Expect(err).To(MatchError(ContainSubstring("invalid device '/dev/../etc/shadow'")))
+					Expect(err).To(MatchError(ContainSubstring("invalid seccomp profile name '../profile'")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
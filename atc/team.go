@@ -2,6 +2,8 @@ package atc
 
 import (
 	"errors"
+	"sort"
+	"strings"
 )
 
 var (
@@ -13,10 +15,25 @@ type Team struct {
 	ID   int      `json:"id,omitempty"`
 	Name string   `json:"name,omitempty"`
 	Auth TeamAuth `json:"auth,omitempty"`
+
+	// Default limits for the team's task containers, taking precedence over
+	// the cluster-wide defaults. Setting a team without them leaves them as
+	// they are.
+	ContainerLimits *ContainerLimits `json:"container_limits,omitempty"`
+
+	// Notifiers sent for the builds of every pipeline of the team, in
+	// addition to those configured by each pipeline. Setting a team without
+	// them leaves them as they are.
+	Notifiers NotifierConfigs `json:"notifiers,omitempty"`
 }
 
 func (team Team) Validate() error {
-	return team.Auth.Validate()
+	err := team.Auth.Validate()
+	if err != nil {
+		return err
+	}
+
+	return team.Notifiers.Validate()
 }
 
 type TeamAuth map[string]map[string][]string
@@ -37,3 +54,68 @@ func (auth TeamAuth) Validate() error {
 
 	return nil
 }
+
+// DiffSummary describes the changes from team to newTeam on a single line,
+// listing the users and groups added (+) to or removed (-) from each role,
+// e.g. "owner: +user:some-user -group:some-org".
+func (team Team) DiffSummary(newTeam Team) string {
+	var summary []string
+
+	roles := map[string]bool{}
+	for role := range team.Auth {
+		roles[role] = true
+	}
+	for role := range newTeam.Auth {
+		roles[role] = true
+	}
+
+	var sortedRoles []string
+	for role := range roles {
+		sortedRoles = append(sortedRoles, role)
+	}
+	sort.Strings(sortedRoles)
+
+	for _, role := range sortedRoles {
+		var changes []string
+		for _, kind := range []string{"users", "groups"} {
+			before := team.Auth[role][kind]
+			after := newTeam.Auth[role][kind]
+
+			prefix := strings.TrimSuffix(kind, "s") + ":"
+			for _, subject := range after {
+				if !containsString(before, subject) {
+					changes = append(changes, "+"+prefix+subject)
+				}
+			}
+			for _, subject := range before {
+				if !containsString(after, subject) {
+					changes = append(changes, "-"+prefix+subject)
+				}
+			}
+		}
+
+		if len(changes) > 0 {
+			summary = append(summary, role+": "+strings.Join(changes, " "))
+		}
+	}
+
+	if newTeam.ContainerLimits != nil {
+		var oldLimits ContainerLimits
+		if team.ContainerLimits != nil {
+			oldLimits = *team.ContainerLimits
+		}
+
+		if practicallyDifferent(oldLimits, *newTeam.ContainerLimits) {
+			summary = append(summary, "container_limits: ~")
+		}
+	}
+
+	if newTeam.Notifiers != nil {
+		notifierDiffs := diffIndices(NotifierIndex(team.Notifiers), NotifierIndex(newTeam.Notifiers))
+		if len(notifierDiffs) > 0 {
+			summary = append(summary, "notifiers: "+notifierDiffs.Summary())
+		}
+	}
+
+	return strings.Join(summary, "; ")
+}
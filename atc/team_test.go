@@ -0,0 +1,70 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Team", func() {
+	Describe("DiffSummary", func() {
+		var team atc.Team
+
+		cpu := atc.CPULimit(512)
+
+		BeforeEach(func() {
+			team = atc.Team{
+				Name: "some-team",
+				Auth: atc.TeamAuth{
+					"owner": {
+						"users":  {"local:some-user"},
+						"groups": {"github:some-org"},
+					},
+				},
+			}
+		})
+
+		It("is empty when nothing changed", func() {
+			Expect(team.DiffSummary(team)).To(BeEmpty())
+		})
+
+		It("lists the users and groups added to and removed from each role", func() {
+			newTeam := atc.Team{
+				Name: "some-team",
+				Auth: atc.TeamAuth{
+					"owner": {
+						"users": {"local:some-user", "local:other-user"},
+					},
+					"viewer": {
+						"groups": {"github:some-org"},
+					},
+				},
+				ContainerLimits: &atc.ContainerLimits{
+					CPU: &cpu,
+				},
+			}
+
+			Expect(team.DiffSummary(newTeam)).To(Equal(
+				"owner: +user:local:other-user -group:github:some-org; " +
+					"viewer: +group:github:some-org; " +
+					"container_limits: ~",
+			))
+		})
+
+		It("leaves out the container limits and notifiers that aren't given", func() {
+			team.ContainerLimits = &atc.ContainerLimits{CPU: &cpu}
+			team.Notifiers = atc.NotifierConfigs{{
+				Name: "chat",
+				Type: atc.NotifierTypeSlack,
+				URL:  "https://hooks.example.com/chat",
+			}}
+
+			newTeam := team
+			newTeam.ContainerLimits = nil
+			newTeam.Notifiers = nil
+
+			Expect(team.DiffSummary(newTeam)).To(BeEmpty())
+		})
+	})
+})
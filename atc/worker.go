@@ -20,6 +20,15 @@ type Worker struct {
 	ActiveVolumes    int `json:"active_volumes"`
 	ActiveTasks      int `json:"active_tasks"`
 
+	// Capacity is the amount of each resource the worker has for containers,
+	// as reported by its runtime. It is omitted if the runtime does not
+	// report it.
+	Capacity *WorkerResources `json:"capacity,omitempty"`
+
+	// Allocated is the amount of each resource reserved by the limits of
+	// tasks placed on the worker by the resource-aware placement strategy.
+	Allocated *WorkerResources `json:"allocated,omitempty"`
+
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
 	Platform  string   `json:"platform"`
@@ -30,6 +39,32 @@ type Worker struct {
 	StartTime int64    `json:"start_time"`
 	Ephemeral bool     `json:"ephemeral"`
 	State     string   `json:"state"`
+
+	// Rootless workers run their containers as an unprivileged user, and so
+	// can't run privileged containers. Their containers are networked through
+	// slirp4netns, which can't restrict egress to an allow-list.
+	Rootless bool `json:"rootless,omitempty"`
+
+	// SecurityAllowList lists the privileges that tasks may be granted
+	// through their security config. Workers which can't honor security
+	// configs do not advertise one.
+	SecurityAllowList *SecurityAllowList `json:"security_allow_list,omitempty"`
+
+	// WarmPool lists the base resource types that the worker keeps started
+	// containers of, for checks to claim instead of creating their own.
+	WarmPool []string `json:"warm_pool,omitempty"`
+
+	// WarmTaskImages workers also keep started containers of the images of
+	// tasks marked `warm: true`, for their steps to claim.
+	WarmTaskImages bool `json:"warm_task_images,omitempty"`
+
+	// CheckpointRestore workers can checkpoint the containers of migratable
+	// tasks, and restore them from checkpoints taken on other workers.
+	CheckpointRestore bool `json:"checkpoint_restore,omitempty"`
+
+	// NetworkConfig workers honor the network configs of tasks, cutting
+	// their containers off the network or restricting their egress.
+	NetworkConfig bool `json:"network_config,omitempty"`
 }
 
 var ErrInvalidWorkerVersion = errors.New("invalid worker version, only numeric characters are allowed")
@@ -48,6 +83,27 @@ func (w Worker) Validate() error {
 	return nil
 }
 
+// WorkerResources is an amount of CPU (in shares, 1024 per core), memory and
+// disk (in bytes). A zero value for a resource's capacity means that it is
+// unknown.
+type WorkerResources struct {
+	CPU    uint64 `json:"cpu"`
+	Memory uint64 `json:"memory"`
+	Disk   uint64 `json:"disk"`
+}
+
+// Fits returns whether the resources are within the given capacity, ignoring
+// any resource whose capacity is unknown.
+func (resources WorkerResources) Fits(capacity WorkerResources) bool {
+	fits := func(amount, capacity uint64) bool {
+		return capacity == 0 || amount <= capacity
+	}
+
+	return fits(resources.CPU, capacity.CPU) &&
+		fits(resources.Memory, capacity.Memory) &&
+		fits(resources.Disk, capacity.Disk)
+}
+
 type WorkerResourceType struct {
 	Type                 string `json:"type"`
 	Image                string `json:"image"`
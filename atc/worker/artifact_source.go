@@ -222,7 +222,23 @@ func (source *artifactSource) streamTo(
 
 	defer out.Close()
 
-	return destination.StreamIn(ctx, ".", source.compression.Encoding(), out)
+	counted := &countingReader{Reader: out}
+	defer func() {
+		runtime.PhaseFromContext(ctx).AddBytes(counted.bytes)
+	}()
+
+	return destination.StreamIn(ctx, ".", source.compression.Encoding(), counted)
+}
+
+type countingReader struct {
+	io.Reader
+	bytes int64
+}
+
+func (reader *countingReader) Read(p []byte) (int, error) {
+	n, err := reader.Reader.Read(p)
+	reader.bytes += int64(n)
+	return n, err
 }
 
 func (source *artifactSource) p2pStreamTo(
@@ -249,6 +265,10 @@ func (source *artifactSource) p2pStreamTo(
 	})
 	defer outSpan.End()
 
+	// the volume is streamed between the workers without going through the
+	// ATC, so there's no counting its bytes
+	runtime.PhaseFromContext(ctx).AddUncountedBytes()
+
 	putCtx, putCancel := context.WithTimeout(ctx, source.p2pStreamingTimeout)
 	defer putCancel()
 	return source.volume.StreamP2pOut(putCtx, ".", streamInUrl, source.compression.Encoding())
@@ -147,10 +147,17 @@ var _ = Describe("StreamableArtifactSource", func() {
 	})
 
 	Context("StreamTo", func() {
-		var streamToErr error
+		var (
+			streamCtx   context.Context
+			streamToErr error
+		)
+
+		BeforeEach(func() {
+			streamCtx = context.TODO()
+		})
 
 		JustBeforeEach(func() {
-			streamToErr = artifactSource.StreamTo(context.TODO(), fakeDestination)
+			streamToErr = artifactSource.StreamTo(streamCtx, fakeDestination)
 		})
 
 		Context("via atc", func() {
@@ -162,6 +169,18 @@ var _ = Describe("StreamableArtifactSource", func() {
 			})
 
 			Context("when ArtifactSource can successfully stream to ArtifactDestination", func() {
+				var streamedIn []byte
+
+				BeforeEach(func() {
+					outStream.Write([]byte("some-bits"))
+
+					streamedIn = nil
+					fakeDestination.StreamInStub = func(_ context.Context, _ string, _ baggageclaim.Encoding, in io.Reader) error {
+						var err error
+						streamedIn, err = ioutil.ReadAll(in)
+						return err
+					}
+				})
 
 				It("calls StreamOut and StreamIn with the correct params", func() {
 					Expect(fakeVolume.StreamOutCallCount()).To(Equal(1))
@@ -170,15 +189,44 @@ var _ = Describe("StreamableArtifactSource", func() {
 					Expect(actualPath).To(Equal("."))
 					Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
 
-					_, actualPath, encoding, actualStreamedOutBits := fakeDestination.StreamInArgsForCall(0)
+					_, actualPath, encoding, _ = fakeDestination.StreamInArgsForCall(0)
 					Expect(actualPath).To(Equal("."))
-					Expect(actualStreamedOutBits).To(Equal(outStream))
 					Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
 				})
 
+				It("streams the bits out of the source into the destination", func() {
+					Expect(streamedIn).To(Equal([]byte("some-bits")))
+				})
+
 				It("does not return an err", func() {
 					Expect(streamToErr).ToNot(HaveOccurred())
 				})
+
+				Context("while timing a phase of a step", func() {
+					var (
+						fakeTimingDelegate *runtimefakes.FakePhaseTimingDelegate
+						phase              *runtime.PhaseTimer
+					)
+
+					BeforeEach(func() {
+						fakeTimingDelegate = new(runtimefakes.FakePhaseTimingDelegate)
+
+						streamCtx = runtime.WithPhaseTiming(context.TODO(), "some-plan-id", "some-step", fakeTimingDelegate)
+						streamCtx, phase = runtime.StartPhase(streamCtx, atc.StepPhaseStreamingInputs)
+					})
+
+					It("counts the bytes streamed in the phase", func() {
+						phase.Finish(testLogger)
+
+						Expect(fakeTimingDelegate.PhaseTimedCallCount()).To(Equal(1))
+						_, timing := fakeTimingDelegate.PhaseTimedArgsForCall(0)
+						Expect(timing.PlanID).To(Equal(atc.PlanID("some-plan-id")))
+						Expect(timing.StepName).To(Equal("some-step"))
+						Expect(timing.Phase).To(Equal(atc.StepPhaseStreamingInputs))
+						Expect(timing.Bytes).ToNot(BeNil())
+						Expect(*timing.Bytes).To(Equal(int64(len("some-bits"))))
+					})
+				})
 			})
 
 			Context("when streaming out of source fails ", func() {
@@ -263,6 +311,31 @@ var _ = Describe("StreamableArtifactSource", func() {
 						Expect(streamToErr).ToNot(HaveOccurred())
 					})
 				})
+
+				Context("while timing a phase of a step", func() {
+					var (
+						fakeTimingDelegate *runtimefakes.FakePhaseTimingDelegate
+						phase              *runtime.PhaseTimer
+					)
+
+					BeforeEach(func() {
+						fakeTimingDelegate = new(runtimefakes.FakePhaseTimingDelegate)
+
+						streamCtx = runtime.WithPhaseTiming(context.TODO(), "some-plan-id", "some-step", fakeTimingDelegate)
+						streamCtx, phase = runtime.StartPhase(streamCtx, atc.StepPhaseStreamingInputs)
+
+						// another input of the step streamed through the ATC
+						runtime.PhaseFromContext(streamCtx).AddBytes(1024)
+					})
+
+					It("leaves out the bytes of the phase, as they can't be counted", func() {
+						phase.Finish(testLogger)
+
+						Expect(fakeTimingDelegate.PhaseTimedCallCount()).To(Equal(1))
+						_, timing := fakeTimingDelegate.PhaseTimedArgsForCall(0)
+						Expect(timing.Bytes).To(BeNil())
+					})
+				})
 			})
 		})
 
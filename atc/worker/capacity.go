@@ -0,0 +1,346 @@
+package worker
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/component"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+// demand is reported by every ATC far more often than this, so anything
+// older is from an ATC that is gone
+const capacityDemandWindow = time.Minute
+
+// CapacityPlanner plans how many workers each pool of workers should have,
+// from the running workers and the demand steps have had for them across the
+// cluster.
+//
+// Workers are pooled by platform, tags and team. Demand goes to the pool of
+// the workers that would be chosen for it: team workers are preferred over
+// global workers, and demand that no worker satisfies goes to a global pool of
+// its own.
+//
+//counterfeiter:generate . CapacityPlanner
+type CapacityPlanner interface {
+	Plan(lager.Logger) (atc.WorkerCapacity, error)
+}
+
+type capacityPlanner struct {
+	workerFactory db.WorkerFactory
+	demandFactory db.WorkerDemandFactory
+
+	maxActiveTasksPerWorker int
+}
+
+func NewCapacityPlanner(
+	workerFactory db.WorkerFactory,
+	demandFactory db.WorkerDemandFactory,
+	opts ContainerPlacementStrategyOptions,
+) CapacityPlanner {
+	planner := &capacityPlanner{
+		workerFactory: workerFactory,
+		demandFactory: demandFactory,
+	}
+
+	// the task limit only bounds the tasks on a worker when it is enforced
+	for _, strategy := range opts.ContainerPlacementStrategy {
+		if strategy == "limit-active-tasks" {
+			planner.maxActiveTasksPerWorker = opts.MaxActiveTasksPerWorker
+		}
+	}
+
+	return planner
+}
+
+type poolKey struct {
+	platform string
+	tags     string
+	teamID   int
+}
+
+func newPoolKey(platform string, tags []string, teamID int) poolKey {
+	return poolKey{
+		platform: platform,
+		tags:     strings.Join(normalizeTags(tags), demandTagSeparator),
+		teamID:   teamID,
+	}
+}
+
+func (key poolKey) tagList() []string {
+	if key.tags == "" {
+		return []string{}
+	}
+
+	return strings.Split(key.tags, demandTagSeparator)
+}
+
+func (key poolKey) less(other poolKey) bool {
+	if key.platform != other.platform {
+		return key.platform < other.platform
+	}
+
+	if key.tags != other.tags {
+		return key.tags < other.tags
+	}
+
+	return key.teamID < other.teamID
+}
+
+// satisfies mirrors Worker.Satisfies for the workers of the pool.
+func (key poolKey) satisfies(demand poolKey) bool {
+	if key.teamID != 0 && key.teamID != demand.teamID {
+		return false
+	}
+
+	if demand.platform != "" && demand.platform != key.platform {
+		return false
+	}
+
+	if demand.tags == "" {
+		return key.tags == ""
+	}
+
+	tags := map[string]bool{}
+	for _, tag := range key.tagList() {
+		tags[tag] = true
+	}
+
+	for _, tag := range demand.tagList() {
+		if !tags[tag] {
+			return false
+		}
+	}
+
+	return true
+}
+
+type plannedPool struct {
+	key     poolKey
+	workers []plannedWorker
+
+	capacity atc.WorkerPoolCapacity
+}
+
+type plannedWorker struct {
+	name     string
+	critical bool
+}
+
+func (planner *capacityPlanner) Plan(logger lager.Logger) (atc.WorkerCapacity, error) {
+	workers, err := planner.workerFactory.Workers()
+	if err != nil {
+		logger.Error("failed-to-get-workers", err)
+		return atc.WorkerCapacity{}, err
+	}
+
+	criticalWorkers, err := planner.workerFactory.BuildCriticalWorkers()
+	if err != nil {
+		logger.Error("failed-to-get-build-critical-workers", err)
+		return atc.WorkerCapacity{}, err
+	}
+
+	demand, err := planner.demandFactory.WorkerDemand(capacityDemandWindow)
+	if err != nil {
+		logger.Error("failed-to-get-worker-demand", err)
+		return atc.WorkerCapacity{}, err
+	}
+
+	pools := map[poolKey]*plannedPool{}
+	poolFor := func(key poolKey, teamName string) *plannedPool {
+		pool, found := pools[key]
+		if !found {
+			pool = &plannedPool{
+				key: key,
+				capacity: atc.WorkerPoolCapacity{
+					Platform:        key.platform,
+					Tags:            key.tagList(),
+					Team:            teamName,
+					LandableWorkers: []string{},
+				},
+			}
+
+			pools[key] = pool
+		}
+
+		return pool
+	}
+
+	for _, worker := range workers {
+		if worker.State() != db.WorkerStateRunning {
+			continue
+		}
+
+		activeTasks, err := worker.ActiveTasks()
+		if err != nil {
+			logger.Error("failed-to-get-active-tasks", err, lager.Data{"worker": worker.Name()})
+			return atc.WorkerCapacity{}, err
+		}
+
+		pool := poolFor(newPoolKey(worker.Platform(), worker.Tags(), worker.TeamID()), worker.TeamName())
+		pool.workers = append(pool.workers, plannedWorker{
+			name:     worker.Name(),
+			critical: criticalWorkers[worker.Name()],
+		})
+
+		pool.capacity.Workers++
+		pool.capacity.ActiveTasks += activeTasks
+	}
+
+	for _, d := range demand {
+		key := planner.demandPool(pools, newPoolKey(d.Platform, d.Tags, d.TeamID))
+
+		teamName := ""
+		if key.teamID != 0 {
+			teamName = d.TeamName
+		}
+
+		pool := poolFor(key, teamName)
+		pool.capacity.StepsWaiting += d.StepsWaiting
+		pool.capacity.NoFit += d.NoFit
+	}
+
+	capacity := atc.WorkerCapacity{
+		Pools: []atc.WorkerPoolCapacity{},
+	}
+
+	keys := []poolKey{}
+	for key := range pools {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].less(keys[j])
+	})
+
+	for _, key := range keys {
+		pool := pools[key]
+		planner.plan(pool)
+		capacity.Pools = append(capacity.Pools, pool.capacity)
+	}
+
+	return capacity, nil
+}
+
+// demandPool finds the pool of workers that steps with the given demand would
+// be placed on.
+func (planner *capacityPlanner) demandPool(pools map[poolKey]*plannedPool, demand poolKey) poolKey {
+	var chosen *poolKey
+	for key, pool := range pools {
+		if len(pool.workers) == 0 || !key.satisfies(demand) {
+			continue
+		}
+
+		key := key
+		if chosen == nil || preferPool(key, *chosen) {
+			chosen = &key
+		}
+	}
+
+	if chosen != nil {
+		return *chosen
+	}
+
+	// no worker satisfies the demand yet, so steps from any team would be
+	// placed on global workers once there are some
+	demand.teamID = 0
+	return demand
+}
+
+// preferPool orders the pools satisfying some demand, preferring team workers
+// like the pool does, and then the pool with the fewest extra tags.
+func preferPool(key poolKey, other poolKey) bool {
+	if (key.teamID != 0) != (other.teamID != 0) {
+		return key.teamID != 0
+	}
+
+	if len(key.tagList()) != len(other.tagList()) {
+		return len(key.tagList()) < len(other.tagList())
+	}
+
+	return key.less(other)
+}
+
+func (planner *capacityPlanner) plan(pool *plannedPool) {
+	criticalWorkers := 0
+	for _, worker := range pool.workers {
+		if worker.critical {
+			criticalWorkers++
+		}
+	}
+
+	desired := 0
+	if planner.maxActiveTasksPerWorker > 0 {
+		tasks := pool.capacity.ActiveTasks + pool.capacity.StepsWaiting
+		desired = (tasks + planner.maxActiveTasksPerWorker - 1) / planner.maxActiveTasksPerWorker
+	}
+
+	// workers holding the state of running builds cannot be landed without
+	// interrupting them
+	if desired < criticalWorkers {
+		desired = criticalWorkers
+	}
+
+	// steps only wait, or fail to fit, when the workers there are cannot take
+	// them
+	if (pool.capacity.StepsWaiting > 0 || pool.capacity.NoFit > 0) && desired <= len(pool.workers) {
+		desired = len(pool.workers) + 1
+	}
+
+	pool.capacity.DesiredWorkers = desired
+
+	landable := len(pool.workers) - desired
+
+	names := []string{}
+	for _, worker := range pool.workers {
+		if !worker.critical {
+			names = append(names, worker.name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for i := 0; i < landable && i < len(names); i++ {
+		pool.capacity.LandableWorkers = append(pool.capacity.LandableWorkers, names[i])
+	}
+}
+
+type capacityEmitter struct {
+	planner CapacityPlanner
+}
+
+// NewCapacityEmitter emits the planned capacity of each pool of workers as
+// metrics.
+func NewCapacityEmitter(planner CapacityPlanner) component.Runnable {
+	return &capacityEmitter{
+		planner: planner,
+	}
+}
+
+func (emitter *capacityEmitter) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("worker-capacity")
+
+	capacity, err := emitter.planner.Plan(logger)
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range capacity.Pools {
+		metric.WorkerPoolCapacity{
+			Platform:        pool.Platform,
+			Tags:            pool.Tags,
+			TeamName:        pool.Team,
+			Workers:         pool.Workers,
+			DesiredWorkers:  pool.DesiredWorkers,
+			LandableWorkers: len(pool.LandableWorkers),
+		}.Emit(logger)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package worker_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/worker"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CapacityPlanner", func() {
+	var (
+		logger            *lagertest.TestLogger
+		fakeWorkerFactory *dbfakes.FakeWorkerFactory
+		fakeDemandFactory *dbfakes.FakeWorkerDemandFactory
+		opts              ContainerPlacementStrategyOptions
+
+		capacity atc.WorkerCapacity
+		planErr  error
+	)
+
+	dbWorker := func(name string, platform string, tags []string, teamID int, teamName string, activeTasks int) *dbfakes.FakeWorker {
+		worker := new(dbfakes.FakeWorker)
+		worker.NameReturns(name)
+		worker.StateReturns(db.WorkerStateRunning)
+		worker.PlatformReturns(platform)
+		worker.TagsReturns(tags)
+		worker.TeamIDReturns(teamID)
+		worker.TeamNameReturns(teamName)
+		worker.ActiveTasksReturns(activeTasks, nil)
+		return worker
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeDemandFactory = new(dbfakes.FakeWorkerDemandFactory)
+		opts = ContainerPlacementStrategyOptions{
+			ContainerPlacementStrategy: []string{"volume-locality"},
+		}
+
+		fakeWorkerFactory.BuildCriticalWorkersReturns(map[string]bool{}, nil)
+	})
+
+	JustBeforeEach(func() {
+		planner := NewCapacityPlanner(fakeWorkerFactory, fakeDemandFactory, opts)
+		capacity, planErr = planner.Plan(logger)
+	})
+
+	Context("when the workers are idle", func() {
+		BeforeEach(func() {
+			stalled := dbWorker("stalled", "linux", nil, 0, "", 0)
+			stalled.StateReturns(db.WorkerStateStalled)
+
+			fakeWorkerFactory.WorkersReturns([]db.Worker{
+				dbWorker("worker-b", "linux", nil, 0, "", 0),
+				dbWorker("worker-a", "linux", []string{""}, 0, "", 0),
+				dbWorker("worker-c", "linux", nil, 0, "", 0),
+				stalled,
+			}, nil)
+
+			fakeWorkerFactory.BuildCriticalWorkersReturns(map[string]bool{"worker-b": true}, nil)
+		})
+
+		It("only keeps the workers holding running builds", func() {
+			Expect(planErr).ToNot(HaveOccurred())
+			Expect(capacity.Pools).To(Equal([]atc.WorkerPoolCapacity{
+				{
+					Platform:        "linux",
+					Tags:            []string{},
+					Workers:         3,
+					DesiredWorkers:  1,
+					LandableWorkers: []string{"worker-a", "worker-c"},
+				},
+			}))
+		})
+
+		It("considers the demand reported within the last minute", func() {
+			Expect(fakeDemandFactory.WorkerDemandCallCount()).To(Equal(1))
+			Expect(fakeDemandFactory.WorkerDemandArgsForCall(0)).To(Equal(time.Minute))
+		})
+	})
+
+	Context("when steps are waiting", func() {
+		BeforeEach(func() {
+			fakeWorkerFactory.WorkersReturns([]db.Worker{
+				dbWorker("worker-a", "linux", nil, 0, "", 2),
+				dbWorker("worker-b", "linux", []string{"gpu"}, 0, "", 1),
+				dbWorker("team-worker", "linux", nil, 1, "some-team", 0),
+			}, nil)
+
+			fakeDemandFactory.WorkerDemandReturns([]db.WorkerDemand{
+				{Platform: "linux", Tags: []string{}, TeamID: 2, TeamName: "other-team", StepsWaiting: 3},
+				{Platform: "linux", Tags: []string{}, TeamID: 1, TeamName: "some-team", NoFit: 1},
+				{Platform: "linux", Tags: []string{"gpu"}, TeamID: 1, TeamName: "some-team", StepsWaiting: 1},
+				{Platform: "darwin", Tags: []string{}, TeamID: 1, TeamName: "some-team", StepsWaiting: 1},
+			}, nil)
+		})
+
+		Context("without a limit on active tasks", func() {
+			It("wants one more worker in each pool steps are waiting for", func() {
+				Expect(planErr).ToNot(HaveOccurred())
+				Expect(capacity.Pools).To(Equal([]atc.WorkerPoolCapacity{
+					{
+						Platform:        "darwin",
+						Tags:            []string{},
+						StepsWaiting:    1,
+						DesiredWorkers:  1,
+						LandableWorkers: []string{},
+					},
+					{
+						Platform:        "linux",
+						Tags:            []string{},
+						Workers:         1,
+						ActiveTasks:     2,
+						StepsWaiting:    3,
+						DesiredWorkers:  2,
+						LandableWorkers: []string{},
+					},
+					{
+						Platform:        "linux",
+						Tags:            []string{},
+						Team:            "some-team",
+						Workers:         1,
+						NoFit:           1,
+						DesiredWorkers:  2,
+						LandableWorkers: []string{},
+					},
+					{
+						Platform:        "linux",
+						Tags:            []string{"gpu"},
+						Workers:         1,
+						ActiveTasks:     1,
+						StepsWaiting:    1,
+						DesiredWorkers:  2,
+						LandableWorkers: []string{},
+					},
+				}))
+			})
+		})
+
+		Context("with a limit on active tasks", func() {
+			BeforeEach(func() {
+				opts = ContainerPlacementStrategyOptions{
+					ContainerPlacementStrategy: []string{"volume-locality", "limit-active-tasks"},
+					MaxActiveTasksPerWorker:    2,
+				}
+			})
+
+			It("wants enough workers to run the active and waiting tasks", func() {
+				Expect(planErr).ToNot(HaveOccurred())
+				Expect(capacity.Pools[1].Platform).To(Equal("linux"))
+				Expect(capacity.Pools[1].Team).To(BeEmpty())
+				Expect(capacity.Pools[1].DesiredWorkers).To(Equal(3))
+			})
+		})
+	})
+
+	Context("when listing the workers fails", func() {
+		BeforeEach(func() {
+			fakeWorkerFactory.WorkersReturns(nil, errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(planErr).To(HaveOccurred())
+		})
+	})
+
+	Context("when getting the demand fails", func() {
+		BeforeEach(func() {
+			fakeDemandFactory.WorkerDemandReturns(nil, errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(planErr).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,123 @@
+package worker
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+const migratablePropertyName = "concourse:migratable"
+const checkpointPropertyName = "concourse:checkpoint"
+const restorePropertyName = "concourse:restore"
+
+// checkpointVolumePath distinguishes the volume that the process of a
+// migratable container is checkpointed to, or restored from. It isn't
+// mounted into the container.
+const checkpointVolumePath = "checkpoint-no-mount:"
+
+// DefaultRetirementInterval is how often the worker of a migratable task is
+// checked for being landed or retired.
+const DefaultRetirementInterval = 10 * time.Second
+
+// retirementWatcher periodically checks whether a worker is being landed or
+// retired. It gives up if the worker cannot be reloaded.
+type retirementWatcher struct {
+	logger   lager.Logger
+	worker   Worker
+	interval time.Duration
+
+	retiring chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func watchRetirement(logger lager.Logger, worker Worker, interval time.Duration) *retirementWatcher {
+	watcher := &retirementWatcher{
+		logger:   logger.Session("retirement-watcher"),
+		worker:   worker,
+		interval: interval,
+
+		retiring: make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	go watcher.run()
+
+	return watcher
+}
+
+func (watcher *retirementWatcher) run() {
+	defer close(watcher.done)
+
+	ticker := time.NewTicker(watcher.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-watcher.stop:
+			return
+		case <-ticker.C:
+		}
+
+		retiring, err := watcher.worker.Retiring()
+		if err != nil {
+			watcher.logger.Debug("failed-to-reload-worker", lager.Data{"error": err.Error()})
+			return
+		}
+
+		if retiring {
+			watcher.logger.Info("retiring")
+			watcher.retiring <- struct{}{}
+			return
+		}
+	}
+}
+
+// Retiring returns a channel which receives once the worker is being landed
+// or retired. It is nil (and so never receives) for a nil watcher.
+func (watcher *retirementWatcher) Retiring() <-chan struct{} {
+	if watcher == nil {
+		return nil
+	}
+
+	return watcher.retiring
+}
+
+func (watcher *retirementWatcher) Stop() {
+	if watcher == nil {
+		return
+	}
+
+	close(watcher.stop)
+	<-watcher.done
+}
+
+// checkpointVolume returns the volume that the process of a migratable
+// container is checkpointed to, if the container is migratable.
+func checkpointVolume(container Container) (Volume, bool) {
+	for _, mount := range container.VolumeMounts() {
+		if mount.MountPath == checkpointVolumePath {
+			return mount.Volume, true
+		}
+	}
+
+	return nil, false
+}
+
+// checkpointTask checkpoints the process of a migratable container into its
+// checkpoint volume, which exits the process.
+func checkpointTask(container Container) (Volume, error) {
+	volume, found := checkpointVolume(container)
+	if !found {
+		return nil, errors.New("container is not migratable")
+	}
+
+	err := container.SetProperty(checkpointPropertyName, volume.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	return volume, nil
+}
@@ -3,6 +3,7 @@ package worker
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"path"
 	"strconv"
@@ -79,6 +80,22 @@ type client struct {
 type TaskResult struct {
 	ExitStatus   int
 	VolumeMounts []VolumeMount
+
+	// Usage is the resource usage of the task's container, if it was sampled.
+	Usage *atc.ContainerUsage
+
+	// ExitReason explains why the task's process was killed by a signal, if
+	// it was and the worker reported it.
+	ExitReason *atc.ExitReason
+
+	// Checkpoint is the volume that the task's process was checkpointed to
+	// when its worker was landed or retired. The task is to be restored from
+	// it on another worker, with the VolumeMounts at the same paths.
+	Checkpoint Volume
+
+	// Container is the container that was checkpointed, set along with
+	// Checkpoint. It's to be destroyed once the task has been restored.
+	Container Container
 }
 
 type CheckResult struct {
@@ -133,7 +150,9 @@ func (client *client) RunCheckStep(
 
 	eventDelegate.Starting(logger)
 
+	_, phase := runtime.StartPhase(ctx, atc.StepPhaseRunning)
 	versions, err := checkable.Check(ctx, processSpec, container)
+	phase.Finish(logger)
 	if err != nil {
 		return CheckResult{}, fmt.Errorf("check: %w", err)
 	}
@@ -165,6 +184,19 @@ func (client *client) RunTaskStep(
 
 	// container already exited
 	exitStatusProp, _ := container.Properties()
+
+	// process already checkpointed
+	if exitStatusProp[checkpointPropertyName] != "" {
+		logger.Info("already-checkpointed")
+
+		checkpoint, _ := checkpointVolume(container)
+		return TaskResult{
+			VolumeMounts: container.VolumeMounts(),
+			Checkpoint:   checkpoint,
+			Container:    container,
+		}, nil
+	}
+
 	code := exitStatusProp[taskExitStatusPropertyName]
 	if code != "" {
 		logger.Info("already-exited", lager.Data{"status": taskExitStatusPropertyName})
@@ -177,6 +209,7 @@ func (client *client) RunTaskStep(
 		return TaskResult{
 			ExitStatus:   status,
 			VolumeMounts: container.VolumeMounts(),
+			ExitReason:   taskExitReason(logger, exitStatusProp[runtime.ExitReasonPropertyName]),
 		}, err
 	}
 
@@ -219,7 +252,39 @@ func (client *client) RunTaskStep(
 
 	logger.Info("attached")
 
-	exitStatusChan := make(chan processStatus)
+	_, phase := runtime.StartPhase(ctx, atc.StepPhaseRunning)
+	defer phase.Finish(logger)
+
+	var sampler *usageSampler
+	if processSpec.UsageInterval > 0 {
+		sampler = startUsageSampler(logger, container, processSpec.UsageInterval, processSpec.UsageReporter)
+	}
+
+	var quota *diskQuotaWatcher
+	if containerSpec.Limits.Disk != nil && *containerSpec.Limits.Disk > 0 {
+		interval := processSpec.UsageInterval
+		if interval <= 0 {
+			interval = DefaultDiskQuotaInterval
+		}
+
+		quota = watchDiskQuota(logger, container, *containerSpec.Limits.Disk, interval)
+	}
+	defer quota.Stop()
+
+	var retirement *retirementWatcher
+	if _, migratable := checkpointVolume(container); migratable {
+		interval := processSpec.UsageInterval
+		if interval <= 0 {
+			interval = DefaultRetirementInterval
+		}
+
+		retirement = watchRetirement(logger, client.worker, interval)
+	}
+	defer retirement.Stop()
+
+	// buffered so that waiting for the process doesn't leak when the task is
+	// given up on before it exits
+	exitStatusChan := make(chan processStatus, 1)
 
 	go func() {
 		status := processStatus{}
@@ -227,39 +292,122 @@ func (client *client) RunTaskStep(
 		exitStatusChan <- status
 	}()
 
-	select {
-	case <-ctx.Done():
-		err = container.Stop(false)
-		if err != nil {
-			logger.Error("stopping-container", err)
-		}
+	retiring := retirement.Retiring()
 
-		status := <-exitStatusChan
-		return TaskResult{
-			ExitStatus:   status.processStatus,
-			VolumeMounts: container.VolumeMounts(),
-		}, ctx.Err()
+	for {
+		select {
+		case <-ctx.Done():
+			err = container.Stop(false)
+			if err != nil {
+				logger.Error("stopping-container", err)
+			}
 
-	case status := <-exitStatusChan:
-		if status.processErr != nil {
+			status := <-exitStatusChan
 			return TaskResult{
-				ExitStatus: status.processStatus,
-			}, status.processErr
-		}
+				ExitStatus:   status.processStatus,
+				VolumeMounts: container.VolumeMounts(),
+				Usage:        sampler.Stop(),
+			}, ctx.Err()
+
+		case quotaErr := <-quota.Exceeded():
+			err = container.Stop(true)
+			if err != nil {
+				logger.Error("stopping-container", err)
+			}
+
+			result := TaskResult{
+				VolumeMounts: container.VolumeMounts(),
+				Usage:        sampler.Stop(),
+			}
+
+			select {
+			case status := <-exitStatusChan:
+				result.ExitStatus = status.processStatus
+			case <-ctx.Done():
+			}
+
+			return result, quotaErr
+
+		case <-retiring:
+			retiring = nil
+
+			checkpoint, err := checkpointTask(container)
+			if err != nil {
+				// the task carries on, holding up the worker as usual
+				logger.Error("failed-to-checkpoint-task", err)
+				continue
+			}
+
+			logger.Info("checkpointed")
+
+			// the process exits once checkpointed, with a status of no
+			// consequence
+			select {
+			case <-exitStatusChan:
+			case <-ctx.Done():
+				return TaskResult{
+					VolumeMounts: container.VolumeMounts(),
+					Usage:        sampler.Stop(),
+				}, ctx.Err()
+			}
 
-		err = container.SetProperty(taskExitStatusPropertyName, fmt.Sprintf("%d", status.processStatus))
-		if err != nil {
 			return TaskResult{
-				ExitStatus: status.processStatus,
+				VolumeMounts: container.VolumeMounts(),
+				Usage:        sampler.Stop(),
+				Checkpoint:   checkpoint,
+				Container:    container,
+			}, nil
+
+		case status := <-exitStatusChan:
+			usage := sampler.Stop()
+
+			if status.processErr != nil {
+				return TaskResult{
+					ExitStatus: status.processStatus,
+					Usage:      usage,
+				}, status.processErr
+			}
+
+			var exitReason *atc.ExitReason
+			if reason, err := container.Property(runtime.ExitReasonPropertyName); err == nil {
+				exitReason = taskExitReason(logger, reason)
+			}
+
+			err = container.SetProperty(taskExitStatusPropertyName, fmt.Sprintf("%d", status.processStatus))
+			if err != nil {
+				return TaskResult{
+					ExitStatus: status.processStatus,
+					Usage:      usage,
+					ExitReason: exitReason,
+				}, err
+			}
+			return TaskResult{
+				ExitStatus:   status.processStatus,
+				VolumeMounts: container.VolumeMounts(),
+				Usage:        usage,
+				ExitReason:   exitReason,
 			}, err
 		}
-		return TaskResult{
-			ExitStatus:   status.processStatus,
-			VolumeMounts: container.VolumeMounts(),
-		}, err
 	}
 }
 
+// taskExitReason parses the exit reason recorded by the worker, if any.
+// Failing to parse it only loses the diagnostics, and so isn't an error.
+func taskExitReason(logger lager.Logger, property string) *atc.ExitReason {
+	if property == "" {
+		return nil
+	}
+
+	var reason atc.ExitReason
+	err := json.Unmarshal([]byte(property), &reason)
+	if err != nil {
+		logger.Error("failed-to-parse-exit-reason", err)
+		return nil
+	}
+
+	return &reason
+}
+
 func (client *client) RunGetStep(
 	ctx context.Context,
 	owner db.ContainerOwner,
@@ -337,7 +485,9 @@ func (client *client) RunPutStep(
 
 	eventDelegate.Starting(logger)
 
+	_, phase := runtime.StartPhase(ctx, atc.StepPhaseRunning)
 	vr, err := resource.Put(ctx, spec, container)
+	phase.Finish(logger)
 	if err != nil {
 		if failErr, ok := err.(runtime.ErrResourceScriptFailed); ok {
 			return PutResult{
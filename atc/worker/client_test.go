@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"time"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden/gardenfakes"
@@ -359,6 +360,30 @@ var _ = Describe("Client", func() {
 				Expect(status).To(Equal(8))
 			})
 
+			It("returns no exit reason", func() {
+				Expect(taskResult.ExitReason).To(BeNil())
+			})
+
+			Context("when the worker recorded why the process was killed", func() {
+				BeforeEach(func() {
+					fakeContainer.PropertiesReturns(garden.Properties{
+						"concourse:exit-status": "137",
+						"concourse:exit-reason": `{"signal":"SIGKILL","oom_killed":true,"memory_limit":1024,"memory_peak":1024}`,
+					}, nil)
+				})
+
+				It("returns the exit reason", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(status).To(Equal(137))
+					Expect(taskResult.ExitReason).To(Equal(&atc.ExitReason{
+						Signal:      "SIGKILL",
+						OOMKilled:   true,
+						MemoryLimit: 1024,
+						MemoryPeak:  1024,
+					}))
+				})
+			})
+
 			Context("when volumes are configured and present on the container", func() {
 				var (
 					fakeMountPath1 = "some-artifact-root/some-output-configured-path/"
@@ -603,6 +628,282 @@ var _ = Describe("Client", func() {
 					Expect(fakeEventDelegate.StartingCallCount()).Should((Equal(1)))
 				})
 
+				It("does not sample the container's usage", func() {
+					Expect(fakeContainer.MetricsCallCount()).To(BeZero())
+					Expect(taskResult.Usage).To(BeNil())
+				})
+
+				Context("when a usage interval is configured", func() {
+					BeforeEach(func() {
+						fakeTaskProcessSpec.UsageInterval = time.Millisecond
+
+						samples := []garden.Metrics{
+							{
+								CPUStat:    garden.ContainerCPUStat{Usage: uint64(time.Second)},
+								MemoryStat: garden.ContainerMemoryStat{TotalUsageTowardLimit: 100},
+							},
+							{
+								CPUStat:    garden.ContainerCPUStat{Usage: uint64(2 * time.Second)},
+								MemoryStat: garden.ContainerMemoryStat{TotalUsageTowardLimit: 300},
+							},
+						}
+
+						sampled := make(chan struct{})
+						fakeContainer.MetricsStub = func() (garden.Metrics, error) {
+							call := fakeContainer.MetricsCallCount() - 1
+							if call < len(samples) {
+								if call == len(samples)-1 {
+									close(sampled)
+								}
+
+								return samples[call], nil
+							}
+
+							return garden.Metrics{}, errors.New("no more samples")
+						}
+
+						fakeProcess.WaitStub = func() (int, error) {
+							<-sampled
+							return 0, nil
+						}
+
+						fakeContainer.PropertyStub = func(name string) (string, error) {
+							if name == runtime.BlockIOPropertyName {
+								return "4096 512", nil
+							}
+
+							return "", errors.New("unknown property")
+						}
+					})
+
+					It("returns the peak and average usage of the container", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(taskResult.Usage).ToNot(BeNil())
+						Expect(taskResult.Usage.Samples).To(Equal(2))
+						Expect(taskResult.Usage.MemoryPeak).To(Equal(uint64(300)))
+						Expect(taskResult.Usage.MemoryAverage).To(Equal(uint64(200)))
+						Expect(taskResult.Usage.CPUPeak).To(BeNumerically(">", 0))
+						Expect(taskResult.Usage.CPUAverage).To(Equal(taskResult.Usage.CPUPeak))
+					})
+
+					It("returns the block IO of the container", func() {
+						Expect(taskResult.Usage.BlockIORead).To(Equal(uint64(4096)))
+						Expect(taskResult.Usage.BlockIOWrite).To(Equal(uint64(512)))
+					})
+
+					Context("when the worker does not report block IO", func() {
+						BeforeEach(func() {
+							fakeContainer.PropertyStub = nil
+							fakeContainer.PropertyReturns("", errors.New("unknown property"))
+						})
+
+						It("stops asking for it", func() {
+							Expect(taskResult.Usage.Samples).To(Equal(2))
+							Expect(taskResult.Usage.BlockIORead).To(BeZero())
+
+							blockIOCalls := 0
+							for i := 0; i < fakeContainer.PropertyCallCount(); i++ {
+								if fakeContainer.PropertyArgsForCall(i) == runtime.BlockIOPropertyName {
+									blockIOCalls++
+								}
+							}
+
+							Expect(blockIOCalls).To(Equal(1))
+						})
+					})
+
+					Context("when a usage reporter is given", func() {
+						var reported []atc.ContainerUsage
+
+						BeforeEach(func() {
+							reported = nil
+							fakeTaskProcessSpec.UsageReporter = func(usage atc.ContainerUsage) {
+								reported = append(reported, usage)
+							}
+						})
+
+						It("reports the usage so far after every sample", func() {
+							Expect(reported).To(HaveLen(2))
+							Expect(reported[0].Samples).To(Equal(1))
+							Expect(reported[0].MemoryPeak).To(Equal(uint64(100)))
+							Expect(reported[1]).To(Equal(*taskResult.Usage))
+						})
+					})
+				})
+
+				Context("when the container exceeds its disk limit", func() {
+					var stopped chan struct{}
+
+					BeforeEach(func() {
+						disk := uint64(1024)
+						fakeContainerSpec.Limits.Disk = &disk
+						fakeTaskProcessSpec.UsageInterval = time.Millisecond
+
+						fakeContainer.MetricsReturns(garden.Metrics{
+							DiskStat: garden.ContainerDiskStat{TotalBytesUsed: 2048},
+						}, nil)
+
+						stopped = make(chan struct{})
+						fakeProcess.WaitStub = func() (int, error) {
+							<-stopped
+							return 128 + 9, nil
+						}
+
+						fakeContainer.StopStub = func(bool) error {
+							close(stopped)
+							return nil
+						}
+					})
+
+					It("kills the container", func() {
+						Expect(fakeContainer.StopCallCount()).To(Equal(1))
+						Expect(fakeContainer.StopArgsForCall(0)).To(BeTrue())
+						Expect(status).To(Equal(128 + 9))
+					})
+
+					It("returns a disk quota exceeded error", func() {
+						Expect(err).To(Equal(worker.DiskQuotaExceededError{Limit: 1024, Used: 2048}))
+						Expect(err.Error()).To(HavePrefix("disk quota exceeded"))
+					})
+
+					Context("when the usage is within a block of the limit", func() {
+						BeforeEach(func() {
+							disk := uint64(8192)
+							fakeContainerSpec.Limits.Disk = &disk
+
+							fakeContainer.MetricsReturns(garden.Metrics{
+								DiskStat: garden.ContainerDiskStat{TotalBytesUsed: 4097},
+							}, nil)
+						})
+
+						It("returns a disk quota exceeded error", func() {
+							Expect(err).To(Equal(worker.DiskQuotaExceededError{Limit: 8192, Used: 4097}))
+						})
+					})
+
+					Context("when the process doesn't exit before the context is canceled", func() {
+						BeforeEach(func() {
+							fakeContainer.StopStub = func(bool) error {
+								cancel()
+								return nil
+							}
+						})
+
+						AfterEach(func() {
+							close(stopped)
+						})
+
+						It("returns the disk quota exceeded error anyway", func() {
+							Expect(err).To(Equal(worker.DiskQuotaExceededError{Limit: 1024, Used: 2048}))
+						})
+					})
+				})
+
+				Context("when the container is migratable", func() {
+					var (
+						checkpointVolume *workerfakes.FakeVolume
+						checkpointed     chan struct{}
+					)
+
+					BeforeEach(func() {
+						fakeTaskProcessSpec.UsageInterval = time.Millisecond
+
+						checkpointVolume = new(workerfakes.FakeVolume)
+						checkpointVolume.PathReturns("/volumes/checkpoint")
+
+						fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+							{Volume: checkpointVolume, MountPath: "checkpoint-no-mount:"},
+						})
+
+						checkpointed = make(chan struct{})
+						fakeProcess.WaitStub = func() (int, error) {
+							<-checkpointed
+							return 128 + 9, nil
+						}
+
+						fakeContainer.SetPropertyStub = func(name string, value string) error {
+							if name == "concourse:checkpoint" {
+								close(checkpointed)
+							}
+							return nil
+						}
+					})
+
+					Context("when its worker is retiring", func() {
+						BeforeEach(func() {
+							fakeWorker.RetiringReturns(true, nil)
+						})
+
+						It("checkpoints the process into the checkpoint volume", func() {
+							Expect(err).ToNot(HaveOccurred())
+							Expect(fakeContainer.SetPropertyCallCount()).To(Equal(1))
+							name, value := fakeContainer.SetPropertyArgsForCall(0)
+							Expect(name).To(Equal("concourse:checkpoint"))
+							Expect(value).To(Equal("/volumes/checkpoint"))
+						})
+
+						It("returns the checkpoint", func() {
+							Expect(taskResult.Checkpoint).To(Equal(checkpointVolume))
+							Expect(taskResult.Container).To(Equal(fakeContainer))
+							Expect(taskResult.VolumeMounts).To(HaveLen(1))
+						})
+
+						Context("when checkpointing fails", func() {
+							BeforeEach(func() {
+								fakeContainer.SetPropertyStub = func(name string, value string) error {
+									if name == "concourse:checkpoint" {
+										defer close(checkpointed)
+										return errors.New("criu failed")
+									}
+									return nil
+								}
+							})
+
+							It("waits for the process to exit", func() {
+								Expect(err).ToNot(HaveOccurred())
+								Expect(status).To(Equal(128 + 9))
+								Expect(taskResult.Checkpoint).To(BeNil())
+							})
+						})
+
+						Context("when the process doesn't exit before the context is canceled", func() {
+							BeforeEach(func() {
+								fakeContainer.SetPropertyStub = func(name string, value string) error {
+									if name == "concourse:checkpoint" {
+										cancel()
+									}
+									return nil
+								}
+							})
+
+							AfterEach(func() {
+								close(checkpointed)
+							})
+
+							It("returns the context's error", func() {
+								Expect(err).To(Equal(context.Canceled))
+								Expect(taskResult.Checkpoint).To(BeNil())
+							})
+						})
+					})
+
+					Context("when its worker is not retiring", func() {
+						BeforeEach(func() {
+							fakeWorker.RetiringStub = func() (bool, error) {
+								if fakeWorker.RetiringCallCount() == 3 {
+									close(checkpointed)
+								}
+								return false, nil
+							}
+						})
+
+						It("does not checkpoint the process", func() {
+							Expect(err).ToNot(HaveOccurred())
+							Expect(taskResult.Checkpoint).To(BeNil())
+						})
+					})
+				})
+
 				Context("when the process is interrupted", func() {
 					var stopped chan struct{}
 					BeforeEach(func() {
@@ -756,6 +1057,37 @@ var _ = Describe("Client", func() {
 						Expect(err).ToNot(HaveOccurred())
 					})
 
+					It("returns no exit reason", func() {
+						Expect(taskResult.ExitReason).To(BeNil())
+					})
+
+					Context("when the worker recorded why the process was killed", func() {
+						BeforeEach(func() {
+							fakeContainer.PropertyStub = func(name string) (string, error) {
+								if name == "concourse:exit-reason" {
+									return `{"signal":"SIGTERM"}`, nil
+								}
+
+								return "", errors.New("property not found")
+							}
+						})
+
+						It("returns the exit reason", func() {
+							Expect(taskResult.ExitReason).To(Equal(&atc.ExitReason{Signal: "SIGTERM"}))
+						})
+					})
+
+					Context("when the exit reason is malformed", func() {
+						BeforeEach(func() {
+							fakeContainer.PropertyReturns("{", nil)
+						})
+
+						It("ignores it", func() {
+							Expect(err).ToNot(HaveOccurred())
+							Expect(taskResult.ExitReason).To(BeNil())
+						})
+					})
+
 					It("saves the exit status property", func() {
 						Expect(fakeContainer.SetPropertyCallCount()).To(Equal(1))
 
@@ -31,6 +31,10 @@ type Container interface {
 	WorkerName() string
 
 	UpdateLastHijack() error
+
+	// MarkAsDestroying leaves the container, along with its volumes, to be
+	// destroyed by the garbage collector.
+	MarkAsDestroying() error
 }
 
 type gardenWorkerContainer struct {
@@ -103,6 +107,11 @@ func (container *gardenWorkerContainer) UpdateLastHijack() error {
 	return container.dbContainer.UpdateLastHijack()
 }
 
+func (container *gardenWorkerContainer) MarkAsDestroying() error {
+	_, err := container.dbContainer.Destroying()
+	return err
+}
+
 func (container *gardenWorkerContainer) Run(ctx context.Context, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
 	spec.User = container.user
 	return container.Container.Run(ctx, spec, io)
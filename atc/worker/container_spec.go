@@ -2,10 +2,13 @@ package worker
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
 	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
 	"go.opentelemetry.io/otel/propagation"
 )
 
@@ -14,6 +17,20 @@ type WorkerSpec struct {
 	ResourceType string
 	Tags         []string
 	TeamID       int
+
+	// Privileged excludes rootless workers, which can't run privileged
+	// containers.
+	Privileged bool
+
+	// Security excludes workers whose security allow-list doesn't permit
+	// the privileges granted by the config.
+	Security *atc.SecurityConfig
+
+	// CheckpointRestore excludes workers which can't restore checkpoints.
+	CheckpointRestore bool
+
+	// Network excludes workers which don't honor network configs.
+	Network *atc.NetworkConfig
 }
 
 type ContainerSpec struct {
@@ -37,6 +54,21 @@ type ContainerSpec struct {
 	// Resource limits to be set on the container when creating in garden.
 	Limits ContainerLimits
 
+	// Network access of the container. Unrestricted if not set.
+	Network *atc.NetworkConfig
+
+	// Fine-grained privileges to grant the container.
+	Security *atc.SecurityConfig
+
+	// Migratable containers can have their process checkpointed when their
+	// worker is landed or retired, to be restored on another worker. They
+	// are only migratable on workers which support checkpoint/restore.
+	Migratable bool
+
+	// Checkpoint of the process of a migratable container to restore rather
+	// than starting it.
+	Checkpoint StreamableArtifactSource
+
 	// Local volumes to bind mount directly to the container when creating in garden.
 	BindMounts []BindMountSource
 
@@ -51,8 +83,8 @@ var _ propagation.TextMapCarrier = new(ContainerSpec)
 
 func (cs *ContainerSpec) Get(key string) string {
 	for _, env := range cs.Env {
-		assignment := strings.SplitN("=", env, 2)
-		if assignment[0] == strings.ToUpper(key) {
+		assignment := strings.SplitN(env, "=", 2)
+		if len(assignment) == 2 && assignment[0] == strings.ToUpper(key) {
 			return assignment[1]
 		}
 	}
@@ -63,7 +95,7 @@ func (cs *ContainerSpec) Set(key string, value string) {
 	varName := strings.ToUpper(key)
 	envVar := varName + "=" + value
 	for i, env := range cs.Env {
-		if strings.SplitN("=", env, 2)[0] == varName {
+		if strings.SplitN(env, "=", 2)[0] == varName {
 			cs.Env[i] = envVar
 			return
 		}
@@ -79,7 +111,7 @@ func (cs *ContainerSpec) Keys() []string {
 	// matter right now (but may in the future...)
 	keys := make([]string, len(cs.Env))
 	for i, env := range cs.Env {
-		envName := strings.SplitN("=", env, 2)[0]
+		envName := strings.SplitN(env, "=", 2)[0]
 		keys[i] = strings.ToLower(envName)
 	}
 	return keys
@@ -104,11 +136,16 @@ type ImageSpec struct {
 	ImageURL            string
 	ImageArtifactSource StreamableArtifactSource
 	Privileged          bool
+
+	// Warm containers of the image may be claimed from the warm pool of the
+	// worker, if it keeps one of task images.
+	Warm bool
 }
 
 type ContainerLimits struct {
 	CPU    *uint64
 	Memory *uint64
+	Disk   *uint64
 }
 
 type inputSource struct {
@@ -138,9 +175,67 @@ func (cl ContainerLimits) ToGardenLimits() garden.Limits {
 	} else {
 		gardenLimits.Memory = garden.MemoryLimits{LimitInBytes: *cl.Memory}
 	}
+	if cl.Disk != nil {
+		gardenLimits.Disk = garden.DiskLimits{ByteHard: *cl.Disk}
+	}
 	return gardenLimits
 }
 
+// ToGardenNetwork converts the network config into the network and egress
+// rules of a Garden container spec. Containers without a network are
+// attached to the atc.NetworkNone network.
+//
+// Egress rules to host names are returned separately, to be passed to the
+// worker, which resolves them when it creates the container.
+func ToGardenNetwork(config *atc.NetworkConfig) (string, []garden.NetOutRule, []runtime.EgressHostRule, error) {
+	if config == nil {
+		return "", nil, nil, nil
+	}
+
+	if config.None {
+		return atc.NetworkNone, nil, nil, nil
+	}
+
+	var rules []garden.NetOutRule
+	var hostRules []runtime.EgressHostRule
+	for _, egress := range config.Egress {
+		rule := garden.NetOutRule{}
+
+		switch egress.Protocol {
+		case atc.EgressProtocolAll:
+			rule.Protocol = garden.ProtocolAll
+		case atc.EgressProtocolTCP:
+			rule.Protocol = garden.ProtocolTCP
+		case atc.EgressProtocolUDP:
+			rule.Protocol = garden.ProtocolUDP
+		case atc.EgressProtocolICMP:
+			rule.Protocol = garden.ProtocolICMP
+		default:
+			return "", nil, nil, fmt.Errorf("unknown egress protocol '%s'", egress.Protocol)
+		}
+
+		for _, ports := range egress.Ports {
+			rule.Ports = append(rule.Ports, garden.PortRange{Start: ports.Start, End: ports.End})
+		}
+
+		if egress.Host != "" {
+			hostRules = append(hostRules, runtime.EgressHostRule{Host: egress.Host, Rule: rule})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(egress.CIDR)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("parse egress cidr: %w", err)
+		}
+
+		rule.Networks = []garden.IPRange{garden.IPRangeFromIPNet(ipNet)}
+
+		rules = append(rules, rule)
+	}
+
+	return "", rules, hostRules, nil
+}
+
 func (spec WorkerSpec) Description() string {
 	var attrs []string
 
@@ -156,5 +251,21 @@ func (spec WorkerSpec) Description() string {
 		attrs = append(attrs, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	if spec.Privileged {
+		attrs = append(attrs, "privileged")
+	}
+
+	if spec.Security != nil {
+		attrs = append(attrs, "security config")
+	}
+
+	if spec.CheckpointRestore {
+		attrs = append(attrs, "checkpoint/restore")
+	}
+
+	if spec.Network != nil {
+		attrs = append(attrs, "network config")
+	}
+
 	return strings.Join(attrs, ", ")
 }
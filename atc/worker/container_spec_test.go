@@ -0,0 +1,46 @@
+package worker_test
+
+import (
+	"github.com/concourse/concourse/atc/worker"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerSpec", func() {
+	var spec *worker.ContainerSpec
+
+	BeforeEach(func() {
+		spec = &worker.ContainerSpec{
+			Env: []string{"FOO=bar", "TRACEPARENT=some-traceparent"},
+		}
+	})
+
+	Describe("Get", func() {
+		It("returns the value of the env var named by the key", func() {
+			Expect(spec.Get("traceparent")).To(Equal("some-traceparent"))
+		})
+
+		It("returns an empty string for unset env vars", func() {
+			Expect(spec.Get("tracestate")).To(BeEmpty())
+		})
+	})
+
+	Describe("Set", func() {
+		It("replaces an existing env var", func() {
+			spec.Set("traceparent", "other-traceparent")
+			Expect(spec.Env).To(Equal([]string{"FOO=bar", "TRACEPARENT=other-traceparent"}))
+		})
+
+		It("appends new env vars", func() {
+			spec.Set("tracestate", "some-state")
+			Expect(spec.Env).To(Equal([]string{"FOO=bar", "TRACEPARENT=some-traceparent", "TRACESTATE=some-state"}))
+		})
+	})
+
+	Describe("Keys", func() {
+		It("returns the lowercased names of the env vars", func() {
+			Expect(spec.Keys()).To(Equal([]string{"foo", "traceparent"}))
+		})
+	})
+})
@@ -0,0 +1,155 @@
+package worker
+
+import (
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/ifrit"
+)
+
+// Demand tracks the demand that the steps placed by this ATC have for
+// workers. It is periodically reported to the database so that the capacity
+// of the cluster can be planned with the demand seen by every ATC.
+type Demand struct {
+	lock sync.Mutex
+
+	waiting map[poolKey]int
+	noFit   map[poolKey]int
+}
+
+// tags are joined to make the pools comparable, with a separator tags are
+// unlikely to contain
+const demandTagSeparator = "\x00"
+
+func NewDemand() *Demand {
+	return &Demand{
+		waiting: map[poolKey]int{},
+		noFit:   map[poolKey]int{},
+	}
+}
+
+// StepWaiting records that a step is waiting for a worker satisfying the
+// spec. The returned function must be called once it stops waiting.
+func (demand *Demand) StepWaiting(spec WorkerSpec) func() {
+	key := newDemandKey(spec)
+
+	demand.lock.Lock()
+	demand.waiting[key]++
+	demand.lock.Unlock()
+
+	return func() {
+		demand.lock.Lock()
+		defer demand.lock.Unlock()
+
+		demand.waiting[key]--
+		if demand.waiting[key] <= 0 {
+			delete(demand.waiting, key)
+		}
+	}
+}
+
+// NoFit records that no worker satisfying the spec fit the container
+// placement strategy.
+func (demand *Demand) NoFit(spec WorkerSpec) {
+	demand.lock.Lock()
+	demand.noFit[newDemandKey(spec)]++
+	demand.lock.Unlock()
+}
+
+// Snapshot returns the steps currently waiting and the no-fit occurrences
+// since the previous snapshot.
+func (demand *Demand) Snapshot() []db.WorkerDemand {
+	demand.lock.Lock()
+	defer demand.lock.Unlock()
+
+	byKey := map[poolKey]*db.WorkerDemand{}
+	keys := []poolKey{}
+	entry := func(key poolKey) *db.WorkerDemand {
+		d, found := byKey[key]
+		if !found {
+			d = &db.WorkerDemand{
+				Platform: key.platform,
+				Tags:     key.tagList(),
+				TeamID:   key.teamID,
+			}
+
+			byKey[key] = d
+			keys = append(keys, key)
+		}
+
+		return d
+	}
+
+	for key, waiting := range demand.waiting {
+		entry(key).StepsWaiting = waiting
+	}
+
+	for key, noFit := range demand.noFit {
+		entry(key).NoFit = noFit
+	}
+
+	demand.noFit = map[poolKey]int{}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].less(keys[j])
+	})
+
+	snapshot := []db.WorkerDemand{}
+	for _, key := range keys {
+		snapshot = append(snapshot, *byKey[key])
+	}
+
+	return snapshot
+}
+
+// ReportDemand periodically reports the demand tracked by this ATC, so that
+// it can be summed with the demand of the other ATCs in the cluster.
+func ReportDemand(
+	logger lager.Logger,
+	demand *Demand,
+	demandFactory db.WorkerDemandFactory,
+	atcID string,
+	interval time.Duration,
+) ifrit.Runner {
+	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		close(ready)
+
+		for {
+			select {
+			case <-signals:
+				return nil
+			case <-ticker.C:
+				err := demandFactory.ReportWorkerDemand(atcID, demand.Snapshot())
+				if err != nil {
+					logger.Error("failed-to-report-worker-demand", err)
+				}
+			}
+		}
+	})
+}
+
+func newDemandKey(spec WorkerSpec) poolKey {
+	return newPoolKey(spec.Platform, spec.Tags, spec.TeamID)
+}
+
+// normalizeTags sorts the tags, dropping empty ones which some workers are
+// registered with in place of no tags.
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	for _, tag := range tags {
+		if tag != "" {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	sort.Strings(normalized)
+
+	return normalized
+}
@@ -0,0 +1,55 @@
+package worker_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/concourse/concourse/atc/worker"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Demand", func() {
+	var demand *Demand
+
+	BeforeEach(func() {
+		demand = NewDemand()
+	})
+
+	It("tracks the steps waiting for each pool of workers", func() {
+		done1 := demand.StepWaiting(WorkerSpec{Platform: "linux", Tags: []string{"b", "a"}, TeamID: 1})
+		done2 := demand.StepWaiting(WorkerSpec{Platform: "linux", Tags: []string{"a", "b"}, TeamID: 1})
+		done3 := demand.StepWaiting(WorkerSpec{Platform: "windows", TeamID: 2})
+
+		Expect(demand.Snapshot()).To(Equal([]db.WorkerDemand{
+			{Platform: "linux", Tags: []string{"a", "b"}, TeamID: 1, StepsWaiting: 2},
+			{Platform: "windows", Tags: []string{}, TeamID: 2, StepsWaiting: 1},
+		}))
+
+		done1()
+		done3()
+
+		Expect(demand.Snapshot()).To(Equal([]db.WorkerDemand{
+			{Platform: "linux", Tags: []string{"a", "b"}, TeamID: 1, StepsWaiting: 1},
+		}))
+
+		done2()
+
+		Expect(demand.Snapshot()).To(BeEmpty())
+	})
+
+	It("counts the times no worker fit since the previous snapshot", func() {
+		demand.NoFit(WorkerSpec{Platform: "linux", Tags: []string{""}, TeamID: 1})
+		demand.NoFit(WorkerSpec{Platform: "linux", TeamID: 1})
+
+		done := demand.StepWaiting(WorkerSpec{Platform: "linux", TeamID: 1})
+		defer done()
+
+		Expect(demand.Snapshot()).To(Equal([]db.WorkerDemand{
+			{Platform: "linux", Tags: []string{}, TeamID: 1, StepsWaiting: 1, NoFit: 2},
+		}))
+
+		Expect(demand.Snapshot()).To(Equal([]db.WorkerDemand{
+			{Platform: "linux", Tags: []string{}, TeamID: 1, StepsWaiting: 1},
+		}))
+	})
+})
@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// DefaultDiskQuotaInterval is how often the disk usage of a task's container
+// is checked against its limit when usage is not otherwise being sampled.
+const DefaultDiskQuotaInterval = 10 * time.Second
+
+// diskQuotaSlack is how close to its limit the disk usage of a container has
+// to be for it to have hit the limit. Workers enforce disk limits in the
+// filesystem, which allocates disk space in whole blocks, so usage can stop
+// short of the limit by up to a block.
+const diskQuotaSlack = 4096
+
+// DiskQuotaExceededError is returned when a task is stopped because its
+// container used up its disk limit.
+type DiskQuotaExceededError struct {
+	Limit uint64
+	Used  uint64
+}
+
+func (err DiskQuotaExceededError) Error() string {
+	return fmt.Sprintf("disk quota exceeded: used %d bytes of %d byte limit", err.Used, err.Limit)
+}
+
+// diskQuotaWatcher periodically compares the disk usage reported for a
+// container against its limit. The limit itself is enforced by the worker,
+// so that writes past it fail; the watcher is only there to stop the task
+// with a clear error rather than leave it to fail on its own.
+//
+// Like the usageSampler, it gives up if the metrics of the container cannot
+// be retrieved. Workers that don't enforce disk limits report no usage.
+type diskQuotaWatcher struct {
+	logger    lager.Logger
+	container metricsSource
+	limit     uint64
+	interval  time.Duration
+
+	exceeded chan DiskQuotaExceededError
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func watchDiskQuota(logger lager.Logger, container metricsSource, limit uint64, interval time.Duration) *diskQuotaWatcher {
+	watcher := &diskQuotaWatcher{
+		logger:    logger.Session("disk-quota-watcher"),
+		container: container,
+		limit:     limit,
+		interval:  interval,
+
+		exceeded: make(chan DiskQuotaExceededError, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	go watcher.run()
+
+	return watcher
+}
+
+func (watcher *diskQuotaWatcher) run() {
+	defer close(watcher.done)
+
+	ticker := time.NewTicker(watcher.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-watcher.stop:
+			return
+		case <-ticker.C:
+		}
+
+		metrics, err := watcher.container.Metrics()
+		if err != nil {
+			watcher.logger.Debug("failed-to-get-metrics", lager.Data{"error": err.Error()})
+			return
+		}
+
+		used := metrics.DiskStat.TotalBytesUsed
+		if used+diskQuotaSlack > watcher.limit {
+			watcher.logger.Info("exceeded", lager.Data{"limit": watcher.limit, "used": used})
+			watcher.exceeded <- DiskQuotaExceededError{Limit: watcher.limit, Used: used}
+			return
+		}
+	}
+}
+
+// Exceeded returns a channel which receives an error once the limit has been
+// exceeded. It is nil (and so never receives) for a nil watcher.
+func (watcher *diskQuotaWatcher) Exceeded() <-chan DiskQuotaExceededError {
+	if watcher == nil {
+		return nil
+	}
+
+	return watcher.exceeded
+}
+
+func (watcher *diskQuotaWatcher) Stop() {
+	if watcher == nil {
+		return
+	}
+
+	close(watcher.stop)
+	<-watcher.done
+}
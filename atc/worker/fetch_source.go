@@ -155,7 +155,9 @@ func (s *fetchSource) Create(ctx context.Context) (GetResult, Volume, error) {
 		return GetResult{}, nil, err
 	}
 
+	_, phase := runtime.StartPhase(ctx, atc.StepPhaseRunning)
 	vr, err := s.resource.Get(ctx, s.processSpec, container)
+	phase.Finish(s.logger)
 	if err != nil {
 		sLog.Error("failed-to-fetch-resource", err)
 		// TODO: Is this compatible with previous behaviour of returning a nil when error type is NOT ErrResourceScriptFailed
@@ -168,6 +170,9 @@ func (s *fetchSource) Create(ctx context.Context) (GetResult, Volume, error) {
 		return GetResult{}, nil, err
 	}
 
+	_, phase = runtime.StartPhase(ctx, atc.StepPhaseRegisteringOutputs)
+	defer phase.Finish(s.logger)
+
 	volume = volumeWithFetchedBits(s.processSpec.Args[0], container)
 
 	err = volume.SetPrivileged(false)
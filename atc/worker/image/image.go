@@ -16,8 +16,6 @@ import (
 
 const RawRootFSScheme = "raw"
 
-const ImageMetadataFile = "metadata.json"
-
 type imageProvidedByPreviousStepOnSameWorker struct {
 	artifactVolume worker.Volume
 	imageSpec      worker.ImageSpec
@@ -46,7 +44,7 @@ func (i *imageProvidedByPreviousStepOnSameWorker) FetchForContainer(
 		return worker.FetchedImage{}, err
 	}
 
-	imageMetadataReader, err := i.imageSpec.ImageArtifactSource.StreamFile(ctx, ImageMetadataFile)
+	imageMetadataReader, err := i.imageSpec.ImageArtifactSource.StreamFile(ctx, worker.ImageMetadataFile)
 	if err != nil {
 		logger.Error("failed-to-stream-metadata-file", err)
 		return worker.FetchedImage{}, err
@@ -125,7 +123,7 @@ func (i *imageProvidedByPreviousStepOnDifferentWorker) FetchForContainer(
 		return worker.FetchedImage{}, err
 	}
 
-	imageMetadataReader, err := i.imageSpec.ImageArtifactSource.StreamFile(ctx, ImageMetadataFile)
+	imageMetadataReader, err := i.imageSpec.ImageArtifactSource.StreamFile(ctx, worker.ImageMetadataFile)
 	if err != nil {
 		logger.Error("failed-to-stream-metadata-file", err)
 		return worker.FetchedImage{}, err
@@ -36,6 +36,10 @@ type Image interface {
 	) (FetchedImage, error)
 }
 
+// ImageMetadataFile is the file next to the rootfs of an image artifact which
+// holds its ImageMetadata.
+const ImageMetadataFile = "metadata.json"
+
 type ImageMetadata struct {
 	Env  []string `json:"env"`
 	User string   `json:"user"`
@@ -8,11 +8,12 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
 type ContainerPlacementStrategyOptions struct {
-	ContainerPlacementStrategy   []string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" choice:"limit-active-containers" choice:"limit-active-volumes" description:"Method by which a worker is selected during container placement. If multiple methods are specified, they will be applied in order. Random strategy should only be used alone."`
+	ContainerPlacementStrategy   []string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" choice:"limit-active-containers" choice:"limit-active-volumes" choice:"resource-aware" description:"Method by which a worker is selected during container placement. If multiple methods are specified, they will be applied in order. Random strategy should only be used alone."`
 	MaxActiveTasksPerWorker      int      `long:"max-active-tasks-per-worker" default:"0" description:"Maximum allowed number of active build tasks per worker. Has effect only when used with limit-active-tasks placement strategy. 0 means no limit."`
 	MaxActiveContainersPerWorker int      `long:"max-active-containers-per-worker" default:"0" description:"Maximum allowed number of active containers per worker. Has effect only when used with limit-active-containers placement strategy. 0 means no limit."`
 	MaxActiveVolumesPerWorker    int      `long:"max-active-volumes-per-worker" default:"0" description:"Maximum allowed number of active volumes per worker. Has effect only when used with limit-active-volumes placement strategy. 0 means no limit."`
@@ -22,6 +23,7 @@ var (
 	ErrTooManyActiveTasks = errors.New("worker has too many active tasks")
 	ErrTooManyContainers  = errors.New("worker has too many containers")
 	ErrTooManyVolumes     = errors.New("worker has too many volumes")
+	ErrNotEnoughCapacity  = errors.New("worker does not have enough capacity")
 )
 
 type NoWorkerFitContainerPlacementStrategyError struct {
@@ -95,6 +97,9 @@ func NewChainPlacementStrategy(opts ContainerPlacementStrategyOptions) (*ChainPl
 		case "volume-locality":
 			cps.nodes = append(cps.nodes, newVolumeLocalityStrategy(strategy))
 
+		case "resource-aware":
+			cps.nodes = append(cps.nodes, newResourceAwareStrategy(strategy))
+
 		default:
 			return nil, fmt.Errorf("invalid container placement strategy %s", strategy)
 		}
@@ -400,3 +405,108 @@ func (strategy *LimitActiveVolumesStrategy) Approve(logger lager.Logger, worker
 
 func (strategy *LimitActiveVolumesStrategy) Release(logger lager.Logger, worker Worker, spec ContainerSpec) {
 }
+
+// Strategy which reserves the CPU, memory and disk limits of task containers
+// on the chosen worker, refusing to place them on workers whose capacity has
+// already been allocated. Candidate workers are ordered by their amount of
+// unallocated memory.
+type ResourceAwareStrategy struct {
+	NamedPlacementStrategy
+}
+
+func newResourceAwareStrategy(name string) ContainerPlacementStrategy {
+	return &ResourceAwareStrategy{
+		NamedPlacementStrategy{name},
+	}
+}
+
+func (strategy *ResourceAwareStrategy) Order(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	if spec.Type != db.ContainerTypeTask {
+		return workers, nil
+	}
+
+	requested := requestedResources(spec)
+
+	candidates := []Worker{}
+	freeMemory := map[Worker]uint64{}
+
+	for _, worker := range workers {
+		capacity := worker.Capacity()
+		if capacity == nil {
+			candidates = append(candidates, worker)
+			continue
+		}
+
+		if !requested.Fits(*capacity) {
+			// the worker could never fit the container, even when idle
+			continue
+		}
+
+		candidates = append(candidates, worker)
+
+		allocated := worker.Allocated()
+		if capacity.Memory > allocated.Memory {
+			freeMemory[worker] = capacity.Memory - allocated.Memory
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return freeMemory[candidates[i]] > freeMemory[candidates[j]]
+	})
+
+	return candidates, nil
+}
+
+func (strategy *ResourceAwareStrategy) Approve(logger lager.Logger, worker Worker, spec ContainerSpec) error {
+	requested := requestedResources(spec)
+	if spec.Type != db.ContainerTypeTask || requested == (atc.WorkerResources{}) {
+		return nil
+	}
+
+	allocated, err := worker.AllocateResources(requested)
+	if err != nil {
+		return err
+	}
+
+	capacity := worker.Capacity()
+	if capacity != nil && !allocated.Fits(*capacity) {
+		_, err := worker.ReleaseResources(requested)
+		if err != nil {
+			logger.Error("failed-to-release-resources", err)
+		}
+
+		return ErrNotEnoughCapacity
+	}
+
+	return nil
+}
+
+func (strategy *ResourceAwareStrategy) Release(logger lager.Logger, worker Worker, spec ContainerSpec) {
+	requested := requestedResources(spec)
+	if spec.Type != db.ContainerTypeTask || requested == (atc.WorkerResources{}) {
+		return
+	}
+
+	_, err := worker.ReleaseResources(requested)
+	if err != nil {
+		logger.Error("failed-to-release-resources", err)
+	}
+}
+
+func requestedResources(spec ContainerSpec) atc.WorkerResources {
+	var resources atc.WorkerResources
+
+	if spec.Limits.CPU != nil {
+		resources.CPU = *spec.Limits.CPU
+	}
+
+	if spec.Limits.Memory != nil {
+		resources.Memory = *spec.Limits.Memory
+	}
+
+	if spec.Limits.Disk != nil {
+		resources.Disk = *spec.Limits.Disk
+	}
+
+	return resources
+}
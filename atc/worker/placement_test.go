@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
 	. "github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 
@@ -676,6 +677,113 @@ var _ = Describe("ContainerPlacementStrategy", func() {
 		})
 	})
 
+	Describe("resource-aware", func() {
+		BeforeEach(func() {
+			strategy, strategyErr = NewChainPlacementStrategy(ContainerPlacementStrategyOptions{
+				ContainerPlacementStrategy: []string{"resource-aware"},
+			})
+			Expect(strategyErr).ToNot(HaveOccurred())
+
+			cpu := uint64(1024)
+			memory := uint64(1024)
+			containerSpec.Type = "task"
+			containerSpec.Limits = ContainerLimits{CPU: &cpu, Memory: &memory}
+
+			workerFakes[0].CapacityReturns(&atc.WorkerResources{CPU: 4096, Memory: 4096})
+			workerFakes[0].AllocatedReturns(atc.WorkerResources{CPU: 1024, Memory: 3072})
+			workerFakes[1].CapacityReturns(&atc.WorkerResources{CPU: 4096, Memory: 4096})
+			workerFakes[1].AllocatedReturns(atc.WorkerResources{CPU: 1024, Memory: 1024})
+			workerFakes[2].CapacityReturns(&atc.WorkerResources{CPU: 512, Memory: 8192})
+		})
+
+		Describe("strategy.Order", func() {
+			JustBeforeEach(func() {
+				order(true)
+			})
+
+			It("removes workers which can never fit the container", func() {
+				Expect(orderedWorkers).ToNot(ContainElement(workers[2]))
+			})
+
+			It("orders workers by unallocated memory", func() {
+				Expect(orderedWorkers).To(Equal([]Worker{workers[1], workers[0]}))
+			})
+
+			Context("when a worker does not report its capacity", func() {
+				BeforeEach(func() {
+					workerFakes[2].CapacityReturns(nil)
+				})
+
+				It("returns it last", func() {
+					Expect(orderedWorkers).To(Equal([]Worker{workers[1], workers[0], workers[2]}))
+				})
+			})
+
+			Context("for a non-task step", func() {
+				BeforeEach(func() {
+					containerSpec.Type = "check"
+				})
+
+				It("returns all workers", func() {
+					Expect(orderedWorkers).To(ConsistOf(workers))
+				})
+			})
+		})
+
+		Describe("strategy.Approve and strategy.Release", func() {
+			BeforeEach(func() {
+				workerFakes[0].AllocateResourcesReturns(atc.WorkerResources{CPU: 2048, Memory: 4097}, nil)
+				workerFakes[1].AllocateResourcesReturns(atc.WorkerResources{CPU: 2048, Memory: 2048}, nil)
+
+				orderedWorkers = workers
+			})
+
+			JustBeforeEach(func() {
+				pickAndRelease()
+			})
+
+			It("refuses to overcommit a worker", func() {
+				Expect(pickedWorker).To(Equal(workers[1]))
+
+				Expect(workerFakes[0].AllocateResourcesCallCount()).To(Equal(1))
+				Expect(workerFakes[0].ReleaseResourcesCallCount()).To(Equal(1))
+			})
+
+			It("allocates and releases the container's limits on the picked worker", func() {
+				requested := atc.WorkerResources{CPU: 1024, Memory: 1024}
+
+				Expect(workerFakes[1].AllocateResourcesCallCount()).To(Equal(1))
+				Expect(workerFakes[1].AllocateResourcesArgsForCall(0)).To(Equal(requested))
+				Expect(workerFakes[1].ReleaseResourcesCallCount()).To(Equal(1))
+				Expect(workerFakes[1].ReleaseResourcesArgsForCall(0)).To(Equal(requested))
+			})
+
+			Context("when no workers have enough capacity", func() {
+				BeforeEach(func() {
+					workerFakes[1].AllocateResourcesReturns(atc.WorkerResources{CPU: 8192, Memory: 2048}, nil)
+					workers = workers[:2]
+					orderedWorkers = workers
+				})
+
+				It("fails to pick a worker", func() {
+					Expect(pickedWorker).To(BeNil())
+					Expect(pickErr).To(Equal(ErrNotEnoughCapacity))
+				})
+			})
+
+			Context("when the container has no limits", func() {
+				BeforeEach(func() {
+					containerSpec.Limits = ContainerLimits{}
+				})
+
+				It("picks the first worker without allocating anything", func() {
+					Expect(pickedWorker).To(Equal(workers[0]))
+					Expect(workerFakes[0].AllocateResourcesCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+
 	Describe("Chained placement strategy", func() {
 		Describe("strategy.Order", func() {
 			Context("fewest-build-containers,volume-locality", func() {
@@ -11,8 +11,10 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/runtime"
 	"github.com/hashicorp/go-multierror"
 )
 
@@ -68,12 +70,14 @@ type VolumeFinder interface {
 
 type pool struct {
 	provider WorkerProvider
+	demand   *Demand
 	waker    chan bool
 }
 
-func NewPool(provider WorkerProvider) Pool {
+func NewPool(provider WorkerProvider, demand *Demand) Pool {
 	return &pool{
 		provider: provider,
+		demand:   demand,
 		waker:    make(chan bool),
 	}
 }
@@ -184,6 +188,25 @@ func (pool *pool) findWorker(
 	}
 
 	if len(compatibleWorkers) == 0 {
+		if workerSpec.Privileged || workerSpec.Security != nil || workerSpec.Network != nil {
+			// rather than waiting for a worker that may never come, fail when
+			// the only workers that would otherwise do are rootless or don't
+			// permit the security or network config
+			unprivilegedSpec := workerSpec
+			unprivilegedSpec.Privileged = false
+			unprivilegedSpec.Security = nil
+			unprivilegedSpec.Network = nil
+
+			unprivilegedWorkers, err := pool.allSatisfying(logger, unprivilegedSpec)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(unprivilegedWorkers) != 0 {
+				return nil, NoCompatibleWorkersError{Spec: workerSpec}
+			}
+		}
+
 		return nil, nil
 	}
 
@@ -204,6 +227,10 @@ func (pool *pool) findWorker(
 			strategy,
 		)
 		if err != nil {
+			if _, ok := err.(NoWorkerFitContainerPlacementStrategyError); ok {
+				pool.demand.NoFit(workerSpec)
+			}
+
 			return nil, err
 		}
 	}
@@ -277,6 +304,20 @@ func (pool *pool) SelectWorker(
 ) (Client, time.Duration, error) {
 	logger := lagerctx.FromContext(ctx)
 
+	if containerSpec.ImageSpec.Privileged {
+		workerSpec.Privileged = true
+	}
+
+	if containerSpec.Security != nil {
+		workerSpec.Security = containerSpec.Security
+	}
+
+	if containerSpec.Network != nil {
+		workerSpec.Network = containerSpec.Network
+	}
+
+	ctx, phase := runtime.StartPhase(ctx, atc.StepPhaseWaitingForWorker)
+
 	started := time.Now()
 	labels := metric.StepsWaitingLabels{
 		Platform:   workerSpec.Platform,
@@ -314,6 +355,8 @@ func (pool *pool) SelectWorker(
 			metric.Metrics.StepsWaiting[labels].Inc()
 			defer metric.Metrics.StepsWaiting[labels].Dec()
 
+			defer pool.demand.StepWaiting(workerSpec)()
+
 			if callbacks != nil {
 				callbacks.WaitingForWorker(logger)
 			}
@@ -334,6 +377,8 @@ func (pool *pool) SelectWorker(
 		Duration: elapsed,
 	}.Emit(logger)
 
+	phase.Finish(logger)
+
 	return worker, elapsed, nil
 }
 
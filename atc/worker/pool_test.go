@@ -13,6 +13,8 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/runtime/runtimefakes"
 	. "github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 	. "github.com/onsi/ginkgo"
@@ -31,7 +33,7 @@ var _ = Describe("Pool", func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
 
-		pool = NewPool(fakeProvider)
+		pool = NewPool(fakeProvider, NewDemand())
 	})
 
 	Describe("FindContainer", func() {
@@ -212,8 +214,9 @@ var _ = Describe("Pool", func() {
 			containerSpec ContainerSpec
 			workerSpec    WorkerSpec
 
-			fakeStrategy  *workerfakes.FakeContainerPlacementStrategy
-			fakeCallbacks *workerfakes.FakePoolCallbacks
+			fakeStrategy       *workerfakes.FakeContainerPlacementStrategy
+			fakeCallbacks      *workerfakes.FakePoolCallbacks
+			fakeTimingDelegate *runtimefakes.FakePhaseTimingDelegate
 
 			workerFakes []*workerfakes.FakeWorker
 			workers     []Worker
@@ -246,6 +249,7 @@ var _ = Describe("Pool", func() {
 
 			fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
 			fakeCallbacks = new(workerfakes.FakePoolCallbacks)
+			fakeTimingDelegate = new(runtimefakes.FakePhaseTimingDelegate)
 
 			workerFakes = []*workerfakes.FakeWorker{
 				new(workerfakes.FakeWorker),
@@ -269,6 +273,7 @@ var _ = Describe("Pool", func() {
 		Context("when it should return immediately", func() {
 			JustBeforeEach(func(done Done) {
 				selectCtx = lagerctx.NewContext(context.Background(), logger)
+				selectCtx = runtime.WithPhaseTiming(selectCtx, "some-plan-id", "some-step", fakeTimingDelegate)
 
 				selectedWorker, _, selectErr = pool.SelectWorker(
 					selectCtx,
@@ -295,6 +300,69 @@ var _ = Describe("Pool", func() {
 				})
 			})
 
+			Context("when the container is privileged and only rootless workers would do", func() {
+				BeforeEach(func() {
+					containerSpec.ImageSpec.Privileged = true
+
+					workerFakes = workerFakes[:1]
+					updateWorkersFromFakes()
+
+					workerFakes[0].SatisfiesCalls(func(_ lager.Logger, spec WorkerSpec) bool {
+						return !spec.Privileged
+					})
+					fakeProvider.RunningWorkersReturns(workers, nil)
+				})
+
+				It("returns a no compatible workers error", func() {
+					expectedSpec := workerSpec
+					expectedSpec.Privileged = true
+					Expect(selectErr).To(Equal(NoCompatibleWorkersError{Spec: expectedSpec}))
+					Expect(selectErr).To(MatchError(ContainSubstring("privileged")))
+				})
+			})
+
+			Context("when the container has a security config that only other workers would permit", func() {
+				BeforeEach(func() {
+					containerSpec.Security = &atc.SecurityConfig{Devices: []string{"/dev/kvm"}}
+
+					workerFakes = workerFakes[:1]
+					updateWorkersFromFakes()
+
+					workerFakes[0].SatisfiesCalls(func(_ lager.Logger, spec WorkerSpec) bool {
+						return spec.Security == nil
+					})
+					fakeProvider.RunningWorkersReturns(workers, nil)
+				})
+
+				It("returns a no compatible workers error", func() {
+					expectedSpec := workerSpec
+					expectedSpec.Security = containerSpec.Security
+					Expect(selectErr).To(Equal(NoCompatibleWorkersError{Spec: expectedSpec}))
+					Expect(selectErr).To(MatchError(ContainSubstring("security config")))
+				})
+			})
+
+			Context("when the container has a network config that only other workers would honor", func() {
+				BeforeEach(func() {
+					containerSpec.Network = &atc.NetworkConfig{None: true}
+
+					workerFakes = workerFakes[:1]
+					updateWorkersFromFakes()
+
+					workerFakes[0].SatisfiesCalls(func(_ lager.Logger, spec WorkerSpec) bool {
+						return spec.Network == nil
+					})
+					fakeProvider.RunningWorkersReturns(workers, nil)
+				})
+
+				It("returns a no compatible workers error", func() {
+					expectedSpec := workerSpec
+					expectedSpec.Network = containerSpec.Network
+					Expect(selectErr).To(Equal(NoCompatibleWorkersError{Spec: expectedSpec}))
+					Expect(selectErr).To(MatchError(ContainSubstring("network config")))
+				})
+			})
+
 			Context("when workers are found with the container", func() {
 				BeforeEach(func() {
 					fakeProvider.RunningWorkersReturns(workers, nil)
@@ -330,6 +398,14 @@ var _ = Describe("Pool", func() {
 						Expect(selectErr).NotTo(HaveOccurred())
 						Expect(selectedWorker.Name()).To(Equal(workers[0].Name()))
 					})
+
+					It("times finding the worker for the step", func() {
+						Expect(fakeTimingDelegate.PhaseTimedCallCount()).To(Equal(1))
+						_, timing := fakeTimingDelegate.PhaseTimedArgsForCall(0)
+						Expect(timing.PlanID).To(Equal(atc.PlanID("some-plan-id")))
+						Expect(timing.StepName).To(Equal("some-step"))
+						Expect(timing.Phase).To(Equal(atc.StepPhaseWaitingForWorker))
+					})
 				})
 
 				Context("when multiple workers satisfy the spec", func() {
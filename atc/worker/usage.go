@@ -0,0 +1,186 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/runtime"
+)
+
+type metricsSource interface {
+	Metrics() (garden.Metrics, error)
+	Property(name string) (string, error)
+}
+
+type usageSample struct {
+	time time.Time
+	cpu  uint64
+	age  time.Duration
+}
+
+// usageSampler samples the resource usage of a container on an interval until
+// it is stopped. Sampling stops early if the metrics of the container cannot
+// be retrieved, e.g. if the worker's runtime does not support them. Block IO
+// is only sampled as long as the worker reports it.
+//
+// If a reporter is given, it is called with the usage so far after every
+// sample, so that the usage of a task is known while it is still running.
+type usageSampler struct {
+	logger    lager.Logger
+	container metricsSource
+	interval  time.Duration
+	reporter  func(atc.ContainerUsage)
+
+	first   usageSample
+	last    usageSample
+	samples int
+
+	cpuPeak     float64
+	memoryPeak  uint64
+	memoryTotal uint64
+
+	blockIO      bool
+	blockIORead  uint64
+	blockIOWrite uint64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func startUsageSampler(logger lager.Logger, container metricsSource, interval time.Duration, reporter func(atc.ContainerUsage)) *usageSampler {
+	sampler := &usageSampler{
+		logger:    logger.Session("usage-sampler"),
+		container: container,
+		interval:  interval,
+		reporter:  reporter,
+		blockIO:   true,
+
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	go sampler.run()
+
+	return sampler
+}
+
+func (sampler *usageSampler) run() {
+	defer close(sampler.done)
+
+	ticker := time.NewTicker(sampler.interval)
+	defer ticker.Stop()
+
+	for {
+		if !sampler.sample() {
+			return
+		}
+
+		if sampler.reporter != nil {
+			sampler.reporter(sampler.usage())
+		}
+
+		select {
+		case <-sampler.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (sampler *usageSampler) sample() bool {
+	metrics, err := sampler.container.Metrics()
+	if err != nil {
+		sampler.logger.Debug("failed-to-get-metrics", lager.Data{"error": err.Error()})
+		return false
+	}
+
+	sample := usageSample{
+		time: time.Now(),
+		cpu:  metrics.CPUStat.Usage,
+		age:  metrics.Age,
+	}
+
+	if sampler.samples == 0 {
+		sampler.first = sample
+	} else if elapsed := sample.time.Sub(sampler.last.time); elapsed > 0 && sample.cpu >= sampler.last.cpu {
+		cpu := float64(sample.cpu-sampler.last.cpu) / float64(elapsed)
+		if cpu > sampler.cpuPeak {
+			sampler.cpuPeak = cpu
+		}
+	}
+
+	sampler.last = sample
+	sampler.samples++
+
+	memory := metrics.MemoryStat.TotalUsageTowardLimit
+	if memory > sampler.memoryPeak {
+		sampler.memoryPeak = memory
+	}
+
+	sampler.memoryTotal += memory
+
+	if sampler.blockIO {
+		sampler.sampleBlockIO()
+	}
+
+	return true
+}
+
+func (sampler *usageSampler) sampleBlockIO() {
+	value, err := sampler.container.Property(runtime.BlockIOPropertyName)
+	if err == nil {
+		_, err = fmt.Sscanf(value, "%d %d", &sampler.blockIORead, &sampler.blockIOWrite)
+	}
+
+	if err != nil {
+		sampler.logger.Debug("failed-to-get-block-io", lager.Data{"error": err.Error()})
+		sampler.blockIO = false
+	}
+}
+
+// Stop stops sampling and returns the usage of the container, or nil if it
+// could not be sampled at all.
+func (sampler *usageSampler) Stop() *atc.ContainerUsage {
+	if sampler == nil {
+		return nil
+	}
+
+	close(sampler.stop)
+	<-sampler.done
+
+	if sampler.samples == 0 {
+		return nil
+	}
+
+	usage := sampler.usage()
+	return &usage
+}
+
+func (sampler *usageSampler) usage() atc.ContainerUsage {
+	usage := atc.ContainerUsage{
+		CPUPeak:       sampler.cpuPeak,
+		MemoryPeak:    sampler.memoryPeak,
+		MemoryAverage: sampler.memoryTotal / uint64(sampler.samples),
+		BlockIORead:   sampler.blockIORead,
+		BlockIOWrite:  sampler.blockIOWrite,
+		Samples:       sampler.samples,
+	}
+
+	elapsed := sampler.last.time.Sub(sampler.first.time)
+	if elapsed > 0 && sampler.last.cpu >= sampler.first.cpu {
+		usage.CPUAverage = float64(sampler.last.cpu-sampler.first.cpu) / float64(elapsed)
+	} else if sampler.last.age > 0 {
+		// with a single sample, the average is taken over the lifetime of the
+		// container instead
+		usage.CPUAverage = float64(sampler.last.cpu) / float64(sampler.last.age)
+	}
+
+	if usage.CPUAverage > usage.CPUPeak {
+		usage.CPUPeak = usage.CPUAverage
+	}
+
+	return usage
+}
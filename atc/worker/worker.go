@@ -6,6 +6,7 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -45,6 +46,7 @@ type Worker interface {
 	Uptime() time.Duration
 	IsOwnedByTeam() bool
 	Ephemeral() bool
+	Retiring() (bool, error)
 	IsVersionCompatible(lager.Logger, version.Version) bool
 	Satisfies(lager.Logger, WorkerSpec) bool
 	FindContainerByHandle(lager.Logger, int, string) (Container, bool, error)
@@ -83,6 +85,11 @@ type Worker interface {
 	IncreaseActiveTasks() (int, error)
 	DecreaseActiveTasks() (int, error)
 
+	Capacity() *atc.WorkerResources
+	Allocated() atc.WorkerResources
+	AllocateResources(atc.WorkerResources) (atc.WorkerResources, error)
+	ReleaseResources(atc.WorkerResources) (atc.WorkerResources, error)
+
 	ActiveContainers() int
 	ActiveVolumes() int
 }
@@ -294,9 +301,24 @@ func (worker *gardenWorker) findOrCreateContainer(
 	}
 
 	// we now have a creatingContainer. If a gardenContainer does not exist, we
-	// will create one. If it does exist, we will transition the creatingContainer
-	// to created and return a worker.Container
+	// will claim or create one. If it does exist, we will transition the
+	// creatingContainer to created and return a worker.Container
+	if gardenContainer == nil && worker.canClaimWarmContainer(containerSpec) {
+		logger.Debug("claiming-warm-garden-container")
+
+		gardenContainer, err = worker.claimWarmContainer(ctx, logger, creatingContainer, containerSpec)
+		if err != nil {
+			// the pool may just be empty, so carry on creating the container
+			logger.Info("failed-to-claim-warm-container", lager.Data{"error": err.Error()})
+			gardenContainer = nil
+		}
+	}
+
 	if gardenContainer == nil {
+		var phase *runtime.PhaseTimer
+		ctx, phase = runtime.StartPhase(ctx, atc.StepPhaseCreatingContainer)
+		defer phase.Finish(logger)
+
 		fetchedImage, err := worker.fetchImageForContainer(
 			ctx,
 			logger,
@@ -323,9 +345,16 @@ func (worker *gardenWorker) findOrCreateContainer(
 			return nil, err
 		}
 
+		checkpointVolume, err := worker.createCheckpointVolume(ctx, logger, creatingContainer, containerSpec)
+		if err != nil {
+			creatingContainer.Failed()
+			logger.Error("failed-to-create-checkpoint-volume-for-container", err)
+			return nil, err
+		}
+
 		logger.Debug("creating-garden-container")
 
-		gardenContainer, err = worker.helper.createGardenContainer(containerSpec, fetchedImage, creatingContainer.Handle(), bindMounts)
+		gardenContainer, err = worker.helper.createGardenContainer(containerSpec, fetchedImage, creatingContainer.Handle(), bindMounts, checkpointVolume)
 		if err != nil {
 			_, failedErr := creatingContainer.Failed()
 			if failedErr != nil {
@@ -360,6 +389,147 @@ func (worker *gardenWorker) findOrCreateContainer(
 	)
 }
 
+// createCheckpointVolume creates the volume that the process of a migratable
+// container is checkpointed to, streaming in the checkpoint it's to be
+// restored from, if any. Containers are only migratable on workers which
+// support checkpoint/restore, and get no volume otherwise.
+//
+// The volume belongs to the container, but isn't mounted into it. It's
+// privileged, as checkpoints are owned by the host's root.
+func (worker *gardenWorker) createCheckpointVolume(
+	ctx context.Context,
+	logger lager.Logger,
+	creatingContainer db.CreatingContainer,
+	spec ContainerSpec,
+) (Volume, error) {
+	if !spec.Migratable || !worker.dbWorker.CheckpointRestore() {
+		return nil, nil
+	}
+
+	volume, err := worker.volumeClient.FindOrCreateVolumeForContainer(
+		logger,
+		VolumeSpec{
+			Strategy:   baggageclaim.EmptyStrategy{},
+			Privileged: true,
+		},
+		creatingContainer,
+		spec.TeamID,
+		checkpointVolumePath,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.Checkpoint != nil {
+		err = spec.Checkpoint.StreamTo(ctx, volume)
+		if err != nil {
+			return nil, fmt.Errorf("stream checkpoint: %w", err)
+		}
+	}
+
+	return volume, nil
+}
+
+// canClaimWarmContainer returns whether the container can be claimed from
+// the warm pool of the worker: either a container of a base resource type
+// that needs no volumes, or a container of the image of a task marked warm.
+//
+// Pooled containers already have the certs of the worker, but nothing else
+// that's only set up when creating a container: its network, security
+// config, disk limit or checkpoints. Containers claimed for tasks get their
+// volumes mounted by the worker once claimed.
+func (worker *gardenWorker) canClaimWarmContainer(spec ContainerSpec) bool {
+	if spec.Network != nil || spec.Security != nil || spec.Migratable {
+		return false
+	}
+
+	for _, mount := range spec.BindMounts {
+		if _, ok := mount.(*CertsVolumeMount); !ok {
+			return false
+		}
+	}
+
+	image := spec.ImageSpec
+	if image.ImageURL != "" {
+		return false
+	}
+
+	if image.ImageArtifactSource != nil {
+		return image.Warm && spec.Limits.Disk == nil && worker.dbWorker.WarmTaskImages()
+	}
+
+	if image.ResourceType == "" || len(spec.Inputs) > 0 || len(spec.Outputs) > 0 || spec.Dir != "" {
+		return false
+	}
+
+	for _, pooled := range worker.dbWorker.WarmPool() {
+		if pooled == image.ResourceType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// claimWarmContainer claims a container from the warm pool of the worker.
+//
+// The image of a task is pooled by the volume it was fetched into on the
+// worker, so it's only claimed when the image is already on the worker. Its
+// volumes are created just as for a new container, for the worker to mount
+// them into the claimed one.
+func (worker *gardenWorker) claimWarmContainer(
+	ctx context.Context,
+	logger lager.Logger,
+	creatingContainer db.CreatingContainer,
+	spec ContainerSpec,
+) (gclient.Container, error) {
+	image := spec.ImageSpec
+	if image.ImageArtifactSource == nil {
+		return worker.helper.claimGardenContainer(spec, image.ResourceType, creatingContainer.Handle(), nil, ImageMetadata{})
+	}
+
+	imageVolume, found, err := image.ImageArtifactSource.ExistsOn(logger, worker)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("image is not on worker %s", worker.Name())
+	}
+
+	metadataReader, err := image.ImageArtifactSource.StreamFile(ctx, ImageMetadataFile)
+	if err != nil {
+		return nil, fmt.Errorf("stream image metadata: %w", err)
+	}
+
+	defer metadataReader.Close()
+
+	var metadata ImageMetadata
+	err = json.NewDecoder(metadataReader).Decode(&metadata)
+	if err != nil {
+		return nil, fmt.Errorf("decode image metadata: %w", err)
+	}
+
+	volumeMounts, err := worker.createVolumes(ctx, logger, image.Privileged, creatingContainer, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// the certs are left out, as pooled containers already have them
+	bindMounts, err := worker.getBindMounts(volumeMounts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return worker.helper.claimGardenContainer(
+		spec,
+		runtime.WarmTaskImage(imageVolume.Handle(), image.Privileged),
+		creatingContainer.Handle(),
+		bindMounts,
+		metadata,
+	)
+}
+
 func (worker *gardenWorker) getBindMounts(volumeMounts []VolumeMount, bindMountSources []BindMountSource) ([]garden.BindMount, error) {
 	bindMounts := []garden.BindMount{}
 
@@ -434,26 +604,30 @@ func (worker *gardenWorker) createVolumes(
 ) ([]VolumeMount, error) {
 	var volumeMounts []VolumeMount
 	var ioVolumeMounts []VolumeMount
-	scratchVolume, err := worker.volumeClient.FindOrCreateVolumeForContainer(
-		logger,
-		VolumeSpec{
-			Strategy:   baggageclaim.EmptyStrategy{},
-			Privileged: isPrivileged,
-		},
-		creatingContainer,
-		spec.TeamID,
-		"/scratch",
-	)
-	if err != nil {
-		return nil, err
-	}
 
-	scratchMount := VolumeMount{
-		Volume:    scratchVolume,
-		MountPath: "/scratch",
-	}
+	// the scratch volume of a migrated container is one of its inputs
+	if !anyMountTo("/scratch", getDestinationPathsFromInputs(spec.Inputs)) {
+		scratchVolume, err := worker.volumeClient.FindOrCreateVolumeForContainer(
+			logger,
+			VolumeSpec{
+				Strategy:   baggageclaim.EmptyStrategy{},
+				Privileged: isPrivileged,
+			},
+			creatingContainer,
+			spec.TeamID,
+			"/scratch",
+		)
+		if err != nil {
+			return nil, err
+		}
 
-	volumeMounts = append(volumeMounts, scratchMount)
+		scratchMount := VolumeMount{
+			Volume:    scratchVolume,
+			MountPath: "/scratch",
+		}
+
+		volumeMounts = append(volumeMounts, scratchMount)
+	}
 
 	hasSpecDirInInputs := anyMountTo(spec.Dir, getDestinationPathsFromInputs(spec.Inputs))
 	hasSpecDirInOutputs := anyMountTo(spec.Dir, getDestinationPathsFromOutputs(spec.Outputs))
@@ -645,6 +819,9 @@ func (worker *gardenWorker) cloneRemoteVolumes(
 	ctx, span := tracing.StartSpan(ctx, "worker.cloneRemoteVolumes", tracing.Attrs{"container_id": container.Handle()})
 	defer span.End()
 
+	ctx, phase := runtime.StartPhase(ctx, atc.StepPhaseStreamingInputs)
+	defer phase.Finish(logger)
+
 	g, groupCtx := errgroup.WithContext(ctx)
 
 	for i, nonLocalInput := range nonLocals {
@@ -780,6 +957,23 @@ func (worker *gardenWorker) Ephemeral() bool {
 	return worker.dbWorker.Ephemeral()
 }
 
+// Retiring reloads the worker and returns whether it's being landed or
+// retired. Workers that have disappeared are not retiring, as their
+// containers went with them.
+func (worker *gardenWorker) Retiring() (bool, error) {
+	found, err := worker.dbWorker.Reload()
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	state := worker.dbWorker.State()
+	return state == db.WorkerStateLanding || state == db.WorkerStateRetiring, nil
+}
+
 func (worker *gardenWorker) BuildContainers() int {
 	return worker.buildContainers
 }
@@ -816,6 +1010,29 @@ func (worker *gardenWorker) Satisfies(logger lager.Logger, spec WorkerSpec) bool
 		return false
 	}
 
+	if spec.Privileged && worker.dbWorker.Rootless() {
+		return false
+	}
+
+	if spec.Security != nil {
+		allowList := worker.dbWorker.SecurityAllowList()
+		if allowList == nil || !allowList.Permits(*spec.Security) {
+			return false
+		}
+	}
+
+	if spec.CheckpointRestore && !worker.dbWorker.CheckpointRestore() {
+		return false
+	}
+
+	if spec.Network != nil && !worker.dbWorker.NetworkConfig() {
+		return false
+	}
+
+	if spec.Network != nil && len(spec.Network.Egress) != 0 && worker.dbWorker.Rootless() {
+		return false
+	}
+
 	return true
 }
 
@@ -876,6 +1093,22 @@ func (worker *gardenWorker) DecreaseActiveTasks() (int, error) {
 	return worker.dbWorker.DecreaseActiveTasks()
 }
 
+func (worker *gardenWorker) Capacity() *atc.WorkerResources {
+	return worker.dbWorker.Capacity()
+}
+
+func (worker *gardenWorker) Allocated() atc.WorkerResources {
+	return worker.dbWorker.Allocated()
+}
+
+func (worker *gardenWorker) AllocateResources(resources atc.WorkerResources) (atc.WorkerResources, error) {
+	return worker.dbWorker.AllocateResources(resources)
+}
+
+func (worker *gardenWorker) ReleaseResources(resources atc.WorkerResources) (atc.WorkerResources, error) {
+	return worker.dbWorker.ReleaseResources(resources)
+}
+
 func (worker *gardenWorker) ActiveContainers() int {
 	return worker.dbWorker.ActiveContainers()
 }
@@ -1,12 +1,14 @@
 package worker
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker/gclient"
 )
 
@@ -23,28 +25,48 @@ func (w workerHelper) createGardenContainer(
 	fetchedImage FetchedImage,
 	handleToCreate string,
 	bindMounts []garden.BindMount,
+	checkpointVolume Volume,
 ) (gclient.Container, error) {
 
 	gardenProperties := garden.Properties{}
 
+	if checkpointVolume != nil {
+		gardenProperties[migratablePropertyName] = "true"
+
+		if containerSpec.Checkpoint != nil {
+			gardenProperties[restorePropertyName] = checkpointVolume.Path()
+		}
+	}
+
 	if containerSpec.User != "" {
 		gardenProperties[userPropertyName] = containerSpec.User
 	} else {
 		gardenProperties[userPropertyName] = fetchedImage.Metadata.User
 	}
 
-	env := append(fetchedImage.Metadata.Env, containerSpec.Env...)
+	if containerSpec.Security != nil {
+		security, err := json.Marshal(containerSpec.Security)
+		if err != nil {
+			return nil, fmt.Errorf("marshal security config: %w", err)
+		}
 
-	if w.dbWorker.HTTPProxyURL() != "" {
-		env = append(env, fmt.Sprintf("http_proxy=%s", w.dbWorker.HTTPProxyURL()))
+		gardenProperties[runtime.SecurityPropertyName] = string(security)
 	}
 
-	if w.dbWorker.HTTPSProxyURL() != "" {
-		env = append(env, fmt.Sprintf("https_proxy=%s", w.dbWorker.HTTPSProxyURL()))
+	env := w.proxyEnv(append(fetchedImage.Metadata.Env, containerSpec.Env...))
+
+	network, netOut, hostRules, err := ToGardenNetwork(containerSpec.Network)
+	if err != nil {
+		return nil, err
 	}
 
-	if w.dbWorker.NoProxy() != "" {
-		env = append(env, fmt.Sprintf("no_proxy=%s", w.dbWorker.NoProxy()))
+	if len(hostRules) != 0 {
+		egressHosts, err := json.Marshal(hostRules)
+		if err != nil {
+			return nil, fmt.Errorf("marshal egress host rules: %w", err)
+		}
+
+		gardenProperties[runtime.EgressHostsPropertyName] = string(egressHosts)
 	}
 
 	return w.gardenClient.Create(
@@ -54,11 +76,60 @@ func (w workerHelper) createGardenContainer(
 			Privileged: fetchedImage.Privileged,
 			BindMounts: bindMounts,
 			Limits:     containerSpec.Limits.ToGardenLimits(),
+			Network:    network,
+			NetOut:     netOut,
 			Env:        env,
 			Properties: gardenProperties,
 		})
 }
 
+// claimGardenContainer claims a started container of the image from the
+// warm pool of the worker, rather than creating one. The worker fails to
+// create it if the pool is empty.
+func (w workerHelper) claimGardenContainer(
+	containerSpec ContainerSpec,
+	image string,
+	handleToCreate string,
+	bindMounts []garden.BindMount,
+	metadata ImageMetadata,
+) (gclient.Container, error) {
+	gardenProperties := garden.Properties{
+		runtime.WarmPoolPropertyName: image,
+	}
+
+	if containerSpec.User != "" {
+		gardenProperties[userPropertyName] = containerSpec.User
+	} else if metadata.User != "" {
+		gardenProperties[userPropertyName] = metadata.User
+	}
+
+	return w.gardenClient.Create(
+		garden.ContainerSpec{
+			Handle:     handleToCreate,
+			Privileged: containerSpec.ImageSpec.Privileged,
+			BindMounts: bindMounts,
+			Limits:     containerSpec.Limits.ToGardenLimits(),
+			Env:        w.proxyEnv(append(metadata.Env, containerSpec.Env...)),
+			Properties: gardenProperties,
+		})
+}
+
+func (w workerHelper) proxyEnv(env []string) []string {
+	if w.dbWorker.HTTPProxyURL() != "" {
+		env = append(env, fmt.Sprintf("http_proxy=%s", w.dbWorker.HTTPProxyURL()))
+	}
+
+	if w.dbWorker.HTTPSProxyURL() != "" {
+		env = append(env, fmt.Sprintf("https_proxy=%s", w.dbWorker.HTTPSProxyURL()))
+	}
+
+	if w.dbWorker.NoProxy() != "" {
+		env = append(env, fmt.Sprintf("no_proxy=%s", w.dbWorker.NoProxy()))
+	}
+
+	return env
+}
+
 func (w workerHelper) constructGardenWorkerContainer(
 	logger lager.Logger,
 	createdContainer db.CreatedContainer,
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
@@ -672,6 +673,130 @@ var _ = Describe("Worker", func() {
 			})
 		})
 
+		Context("when the spec is privileged", func() {
+			BeforeEach(func() {
+				spec.Platform = "some-platform"
+				spec.Privileged = true
+			})
+
+			It("returns true", func() {
+				Expect(satisfies).To(BeTrue())
+			})
+
+			Context("when the worker is rootless", func() {
+				BeforeEach(func() {
+					fakeDBWorker.RootlessReturns(true)
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the spec has a security config", func() {
+			BeforeEach(func() {
+				spec.Platform = "some-platform"
+				spec.Security = &atc.SecurityConfig{
+					Capabilities: atc.CapabilitiesConfig{Add: []string{"CAP_SYS_PTRACE"}},
+				}
+			})
+
+			Context("when the worker does not advertise an allow-list", func() {
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+
+			Context("when the worker's allow-list permits it", func() {
+				BeforeEach(func() {
+					fakeDBWorker.SecurityAllowListReturns(&atc.SecurityAllowList{
+						Capabilities: []string{"CAP_SYS_PTRACE"},
+					})
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+
+			Context("when the worker's allow-list does not permit it", func() {
+				BeforeEach(func() {
+					fakeDBWorker.SecurityAllowListReturns(&atc.SecurityAllowList{
+						Devices: []string{"/dev/fuse"},
+					})
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the spec requires checkpoint/restore", func() {
+			BeforeEach(func() {
+				spec.Platform = "some-platform"
+				spec.CheckpointRestore = true
+			})
+
+			It("returns false", func() {
+				Expect(satisfies).To(BeFalse())
+			})
+
+			Context("when the worker supports it", func() {
+				BeforeEach(func() {
+					fakeDBWorker.CheckpointRestoreReturns(true)
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the spec has a network config", func() {
+			BeforeEach(func() {
+				spec.Platform = "some-platform"
+				spec.Network = &atc.NetworkConfig{None: true}
+			})
+
+			It("returns false", func() {
+				Expect(satisfies).To(BeFalse())
+			})
+
+			Context("when the worker honors network configs", func() {
+				BeforeEach(func() {
+					fakeDBWorker.NetworkConfigReturns(true)
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+
+				Context("when the worker is rootless", func() {
+					BeforeEach(func() {
+						fakeDBWorker.RootlessReturns(true)
+					})
+
+					It("returns true", func() {
+						Expect(satisfies).To(BeTrue())
+					})
+
+					Context("when the config has egress rules", func() {
+						BeforeEach(func() {
+							spec.Network = &atc.NetworkConfig{
+								Egress: []atc.EgressRule{{CIDR: "10.0.0.0/8"}},
+							}
+						})
+
+						It("returns false", func() {
+							Expect(satisfies).To(BeFalse())
+						})
+					})
+				})
+			})
+		})
+
 		Context("when the resource type is supported by the worker", func() {
 			BeforeEach(func() {
 				spec.ResourceType = "some-base-type"
@@ -1347,6 +1472,338 @@ var _ = Describe("Worker", func() {
 					})
 				})
 
+				Context("when the container has no network", func() {
+					BeforeEach(func() {
+						containerSpec.Network = &atc.NetworkConfig{None: true}
+					})
+
+					It("creates the container without a network", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Network).To(Equal("none"))
+						Expect(actualSpec.NetOut).To(BeEmpty())
+					})
+				})
+
+				Context("when the container has egress rules", func() {
+					BeforeEach(func() {
+						containerSpec.Network = &atc.NetworkConfig{
+							Egress: []atc.EgressRule{
+								{
+									CIDR:     "10.0.0.0/8",
+									Protocol: atc.EgressProtocolTCP,
+									Ports:    []atc.PortRange{{Start: 443, End: 443}, {Start: 8000, End: 8080}},
+								},
+								{
+									Host:     "example.com",
+									Protocol: atc.EgressProtocolICMP,
+								},
+							},
+						}
+					})
+
+					It("creates the container with the egress rules to cidrs", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+						_, cidr, err := net.ParseCIDR("10.0.0.0/8")
+						Expect(err).ToNot(HaveOccurred())
+
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Network).To(BeEmpty())
+						Expect(actualSpec.NetOut).To(Equal([]garden.NetOutRule{
+							{
+								Protocol: garden.ProtocolTCP,
+								Networks: []garden.IPRange{garden.IPRangeFromIPNet(cidr)},
+								Ports:    []garden.PortRange{{Start: 443, End: 443}, {Start: 8000, End: 8080}},
+							},
+						}))
+					})
+
+					It("passes the egress rules to host names for the worker to resolve", func() {
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Properties[runtime.EgressHostsPropertyName]).To(MatchJSON(`[{
+							"host": "example.com",
+							"rule": {"protocol": 3}
+						}]`))
+					})
+				})
+
+				Context("when the container has a security config", func() {
+					BeforeEach(func() {
+						containerSpec.Security = &atc.SecurityConfig{
+							Capabilities:   atc.CapabilitiesConfig{Add: []string{"CAP_SYS_PTRACE"}},
+							ReadOnlyRootfs: true,
+						}
+					})
+
+					It("passes it to garden as a property", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Properties).To(HaveKeyWithValue("user", "some-user"))
+						Expect(actualSpec.Properties["concourse:security"]).To(MatchJSON(`{
+							"capabilities": {"add": ["CAP_SYS_PTRACE"]},
+							"readonly_rootfs": true
+						}`))
+					})
+				})
+
+				Context("when the container is migratable", func() {
+					var fakeCheckpointVolume *workerfakes.FakeVolume
+
+					BeforeEach(func() {
+						containerSpec.Migratable = true
+
+						fakeCheckpointVolume = new(workerfakes.FakeVolume)
+						fakeCheckpointVolume.PathReturns("/volumes/checkpoint")
+						stubbedVolumes["checkpoint-no-mount:"] = fakeCheckpointVolume
+					})
+
+					It("creates the container normally", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+						Expect(fakeGardenClient.CreateArgsForCall(0).Properties).ToNot(HaveKey("concourse:migratable"))
+					})
+
+					Context("when the worker supports checkpoint/restore", func() {
+						BeforeEach(func() {
+							fakeDBWorker.CheckpointRestoreReturns(true)
+						})
+
+						It("creates it with a privileged checkpoint volume", func() {
+							Expect(volumeSpecs["checkpoint-no-mount:"]).To(Equal(VolumeSpec{
+								Strategy:   baggageclaim.EmptyStrategy{},
+								Privileged: true,
+							}))
+
+							actualSpec := fakeGardenClient.CreateArgsForCall(0)
+							Expect(actualSpec.Properties).To(HaveKeyWithValue("concourse:migratable", "true"))
+							Expect(actualSpec.Properties).ToNot(HaveKey("concourse:restore"))
+							for _, mount := range actualSpec.BindMounts {
+								Expect(mount.SrcPath).ToNot(Equal("/volumes/checkpoint"))
+							}
+						})
+
+						Context("when it's to be restored from a checkpoint", func() {
+							var fakeCheckpoint *workerfakes.FakeStreamableArtifactSource
+
+							BeforeEach(func() {
+								fakeCheckpoint = new(workerfakes.FakeStreamableArtifactSource)
+								containerSpec.Checkpoint = fakeCheckpoint
+							})
+
+							It("streams the checkpoint into the checkpoint volume", func() {
+								Expect(fakeCheckpoint.StreamToCallCount()).To(Equal(1))
+								_, dest := fakeCheckpoint.StreamToArgsForCall(0)
+								Expect(dest).To(Equal(fakeCheckpointVolume))
+							})
+
+							It("restores the container from it", func() {
+								actualSpec := fakeGardenClient.CreateArgsForCall(0)
+								Expect(actualSpec.Properties).To(HaveKeyWithValue("concourse:restore", "/volumes/checkpoint"))
+							})
+
+							Context("when streaming the checkpoint fails", func() {
+								BeforeEach(func() {
+									fakeCheckpoint.StreamToReturns(errors.New("nope"))
+								})
+
+								It("marks the container as failed", func() {
+									Expect(findOrCreateErr).To(HaveOccurred())
+									Expect(fakeCreatingContainer.FailedCallCount()).To(Equal(1))
+									Expect(fakeGardenClient.CreateCallCount()).To(Equal(0))
+								})
+							})
+						})
+					})
+				})
+
+				Context("when the container can be claimed from the warm pool", func() {
+					BeforeEach(func() {
+						memory := uint64(1024)
+
+						containerSpec = ContainerSpec{
+							TeamID:    73410,
+							ImageSpec: ImageSpec{ResourceType: "some-base-type"},
+							Env:       []string{"SOME=ENV"},
+							BindMounts: []BindMountSource{
+								&CertsVolumeMount{Logger: logger},
+							},
+							Limits: ContainerLimits{Memory: &memory},
+						}
+
+						fakeDBWorker.WarmPoolReturns([]string{"some-base-type"})
+					})
+
+					It("claims the container instead of fetching its image", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+						Expect(fakeImage.FetchForContainerCallCount()).To(Equal(0))
+
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec).To(Equal(garden.ContainerSpec{
+							Handle:     "some-handle",
+							Properties: garden.Properties{"concourse:warm-pool": "some-base-type"},
+							Limits: garden.Limits{
+								Memory: garden.MemoryLimits{LimitInBytes: 1024},
+							},
+							Env: []string{
+								"SOME=ENV",
+								"http_proxy=http://proxy.com",
+								"https_proxy=https://proxy.com",
+								"no_proxy=http://noproxy.com",
+							},
+						}))
+					})
+
+					It("marks container as created", func() {
+						Expect(fakeCreatingContainer.CreatedCallCount()).To(Equal(1))
+					})
+
+					Context("when the worker fails to claim one", func() {
+						BeforeEach(func() {
+							fakeGardenClient.CreateReturnsOnCall(0, nil, errors.New("warm pool empty"))
+						})
+
+						It("creates the container instead", func() {
+							Expect(findOrCreateErr).ToNot(HaveOccurred())
+							Expect(fakeGardenClient.CreateCallCount()).To(Equal(2))
+							Expect(fakeImage.FetchForContainerCallCount()).To(Equal(1))
+
+							actualSpec := fakeGardenClient.CreateArgsForCall(1)
+							Expect(actualSpec.RootFSPath).To(Equal("some-image-url"))
+							Expect(actualSpec.Properties).ToNot(HaveKey("concourse:warm-pool"))
+						})
+					})
+
+					Context("when the worker does not pool the resource type", func() {
+						BeforeEach(func() {
+							fakeDBWorker.WarmPoolReturns([]string{"some-other-type"})
+						})
+
+						It("creates the container", func() {
+							Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+							Expect(fakeGardenClient.CreateArgsForCall(0).RootFSPath).To(Equal("some-image-url"))
+						})
+					})
+
+					Context("when the container needs volumes", func() {
+						BeforeEach(func() {
+							containerSpec.Outputs = OutputPaths{"some-output": "/some/work-dir/output"}
+						})
+
+						It("creates the container", func() {
+							Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+							Expect(fakeGardenClient.CreateArgsForCall(0).RootFSPath).To(Equal("some-image-url"))
+						})
+					})
+				})
+
+				Context("when the container of a warm task image can be claimed from the warm pool", func() {
+					var fakeImageSource *workerfakes.FakeStreamableArtifactSource
+
+					BeforeEach(func() {
+						fakeImageVolume := new(workerfakes.FakeVolume)
+						fakeImageVolume.HandleReturns("some-image-volume")
+
+						fakeImageSource = new(workerfakes.FakeStreamableArtifactSource)
+						fakeImageSource.ExistsOnReturns(fakeImageVolume, true, nil)
+						fakeImageSource.StreamFileReturns(ioutil.NopCloser(bytes.NewBufferString(`{"env":["IMAGE=ENV"],"user":"image-user"}`)), nil)
+
+						containerSpec.ImageSpec = ImageSpec{
+							ImageArtifactSource: fakeImageSource,
+							Privileged:          true,
+							Warm:                true,
+						}
+						containerSpec.BindMounts = []BindMountSource{
+							&CertsVolumeMount{Logger: logger},
+						}
+
+						fakeDBWorker.WarmTaskImagesReturns(true)
+					})
+
+					It("claims the container of the image volume instead of fetching the image", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+						Expect(fakeImage.FetchForContainerCallCount()).To(Equal(0))
+
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.RootFSPath).To(BeEmpty())
+						Expect(actualSpec.Privileged).To(BeTrue())
+						Expect(actualSpec.Properties).To(Equal(garden.Properties{
+							"concourse:warm-pool": runtime.WarmTaskImage("some-image-volume", true),
+							"user":                "some-user",
+						}))
+						Expect(actualSpec.Env).To(Equal([]string{
+							"IMAGE=ENV",
+							"SOME=ENV",
+							"http_proxy=http://proxy.com",
+							"https_proxy=https://proxy.com",
+							"no_proxy=http://noproxy.com",
+						}))
+					})
+
+					It("mounts the volumes of the container, leaving out the certs", func() {
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.BindMounts).To(ContainElement(garden.BindMount{
+							SrcPath: "/fake/scratch/volume",
+							DstPath: "/scratch",
+							Mode:    garden.BindMountModeRW,
+						}))
+						for _, mount := range actualSpec.BindMounts {
+							Expect(mount.DstPath).ToNot(Equal("/etc/ssl/certs"))
+						}
+					})
+
+					It("creates the volumes as privileged as the image", func() {
+						Expect(volumeSpecs["/scratch"].Privileged).To(BeTrue())
+					})
+
+					Context("when the image is not on the worker", func() {
+						BeforeEach(func() {
+							fakeImageSource.ExistsOnReturns(nil, false, nil)
+						})
+
+						It("creates the container", func() {
+							Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+							Expect(fakeGardenClient.CreateArgsForCall(0).RootFSPath).To(Equal("some-image-url"))
+						})
+					})
+
+					Context("when the worker does not pool task images", func() {
+						BeforeEach(func() {
+							fakeDBWorker.WarmTaskImagesReturns(false)
+						})
+
+						It("creates the container", func() {
+							Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+							Expect(fakeGardenClient.CreateArgsForCall(0).RootFSPath).To(Equal("some-image-url"))
+						})
+					})
+
+					Context("when the container has a disk limit", func() {
+						BeforeEach(func() {
+							disk := uint64(1024)
+							containerSpec.Limits.Disk = &disk
+						})
+
+						It("creates the container", func() {
+							Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+							Expect(fakeGardenClient.CreateArgsForCall(0).RootFSPath).To(Equal("some-image-url"))
+						})
+					})
+
+					Context("when the worker fails to claim one", func() {
+						BeforeEach(func() {
+							fakeGardenClient.CreateReturnsOnCall(0, nil, errors.New("warm pool empty"))
+						})
+
+						It("creates the container instead", func() {
+							Expect(findOrCreateErr).ToNot(HaveOccurred())
+							Expect(fakeGardenClient.CreateCallCount()).To(Equal(2))
+							Expect(fakeGardenClient.CreateArgsForCall(1).RootFSPath).To(Equal("some-image-url"))
+						})
+					})
+				})
+
 				Context("when an input has the path set to the workdir itself", func() {
 					BeforeEach(func() {
 						fakeLocalInput.DestinationPathReturns("/some/work-dir")
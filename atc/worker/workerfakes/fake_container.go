@@ -118,6 +118,16 @@ type FakeContainer struct {
 		result1 garden.ContainerInfo
 		result2 error
 	}
+	MarkAsDestroyingStub        func() error
+	markAsDestroyingMutex       sync.RWMutex
+	markAsDestroyingArgsForCall []struct {
+	}
+	markAsDestroyingReturns struct {
+		result1 error
+	}
+	markAsDestroyingReturnsOnCall map[int]struct {
+		result1 error
+	}
 	MetricsStub        func() (garden.Metrics, error)
 	metricsMutex       sync.RWMutex
 	metricsArgsForCall []struct {
@@ -835,6 +845,59 @@ func (fake *FakeContainer) InfoReturnsOnCall(i int, result1 garden.ContainerInfo
 	}{result1, result2}
 }
 
+func (fake *FakeContainer) MarkAsDestroying() error {
+	fake.markAsDestroyingMutex.Lock()
+	ret, specificReturn := fake.markAsDestroyingReturnsOnCall[len(fake.markAsDestroyingArgsForCall)]
+	fake.markAsDestroyingArgsForCall = append(fake.markAsDestroyingArgsForCall, struct {
+	}{})
+	stub := fake.MarkAsDestroyingStub
+	fakeReturns := fake.markAsDestroyingReturns
+	fake.recordInvocation("MarkAsDestroying", []interface{}{})
+	fake.markAsDestroyingMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeContainer) MarkAsDestroyingCallCount() int {
+	fake.markAsDestroyingMutex.RLock()
+	defer fake.markAsDestroyingMutex.RUnlock()
+	return len(fake.markAsDestroyingArgsForCall)
+}
+
+func (fake *FakeContainer) MarkAsDestroyingCalls(stub func() error) {
+	fake.markAsDestroyingMutex.Lock()
+	defer fake.markAsDestroyingMutex.Unlock()
+	fake.MarkAsDestroyingStub = stub
+}
+
+func (fake *FakeContainer) MarkAsDestroyingReturns(result1 error) {
+	fake.markAsDestroyingMutex.Lock()
+	defer fake.markAsDestroyingMutex.Unlock()
+	fake.MarkAsDestroyingStub = nil
+	fake.markAsDestroyingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainer) MarkAsDestroyingReturnsOnCall(i int, result1 error) {
+	fake.markAsDestroyingMutex.Lock()
+	defer fake.markAsDestroyingMutex.Unlock()
+	fake.MarkAsDestroyingStub = nil
+	if fake.markAsDestroyingReturnsOnCall == nil {
+		fake.markAsDestroyingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markAsDestroyingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeContainer) Metrics() (garden.Metrics, error) {
 	fake.metricsMutex.Lock()
 	ret, specificReturn := fake.metricsReturnsOnCall[len(fake.metricsArgsForCall)]
@@ -1833,6 +1896,8 @@ func (fake *FakeContainer) Invocations() map[string][][]interface{} {
 	defer fake.handleMutex.RUnlock()
 	fake.infoMutex.RLock()
 	defer fake.infoMutex.RUnlock()
+	fake.markAsDestroyingMutex.RLock()
+	defer fake.markAsDestroyingMutex.RUnlock()
 	fake.metricsMutex.RLock()
 	defer fake.metricsMutex.RUnlock()
 	fake.netInMutex.RLock()
@@ -49,6 +49,29 @@ type FakeWorker struct {
 	activeVolumesReturnsOnCall map[int]struct {
 		result1 int
 	}
+	AllocateResourcesStub        func(atc.WorkerResources) (atc.WorkerResources, error)
+	allocateResourcesMutex       sync.RWMutex
+	allocateResourcesArgsForCall []struct {
+		arg1 atc.WorkerResources
+	}
+	allocateResourcesReturns struct {
+		result1 atc.WorkerResources
+		result2 error
+	}
+	allocateResourcesReturnsOnCall map[int]struct {
+		result1 atc.WorkerResources
+		result2 error
+	}
+	AllocatedStub        func() atc.WorkerResources
+	allocatedMutex       sync.RWMutex
+	allocatedArgsForCall []struct {
+	}
+	allocatedReturns struct {
+		result1 atc.WorkerResources
+	}
+	allocatedReturnsOnCall map[int]struct {
+		result1 atc.WorkerResources
+	}
 	BuildContainersStub        func() int
 	buildContainersMutex       sync.RWMutex
 	buildContainersArgsForCall []struct {
@@ -59,6 +82,16 @@ type FakeWorker struct {
 	buildContainersReturnsOnCall map[int]struct {
 		result1 int
 	}
+	CapacityStub        func() *atc.WorkerResources
+	capacityMutex       sync.RWMutex
+	capacityArgsForCall []struct {
+	}
+	capacityReturns struct {
+		result1 *atc.WorkerResources
+	}
+	capacityReturnsOnCall map[int]struct {
+		result1 *atc.WorkerResources
+	}
 	CertsVolumeStub        func(lager.Logger) (worker.Volume, bool, error)
 	certsVolumeMutex       sync.RWMutex
 	certsVolumeArgsForCall []struct {
@@ -300,6 +333,19 @@ type FakeWorker struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ReleaseResourcesStub        func(atc.WorkerResources) (atc.WorkerResources, error)
+	releaseResourcesMutex       sync.RWMutex
+	releaseResourcesArgsForCall []struct {
+		arg1 atc.WorkerResources
+	}
+	releaseResourcesReturns struct {
+		result1 atc.WorkerResources
+		result2 error
+	}
+	releaseResourcesReturnsOnCall map[int]struct {
+		result1 atc.WorkerResources
+		result2 error
+	}
 	ResourceTypesStub        func() []atc.WorkerResourceType
 	resourceTypesMutex       sync.RWMutex
 	resourceTypesArgsForCall []struct {
@@ -310,6 +356,18 @@ type FakeWorker struct {
 	resourceTypesReturnsOnCall map[int]struct {
 		result1 []atc.WorkerResourceType
 	}
+	RetiringStub        func() (bool, error)
+	retiringMutex       sync.RWMutex
+	retiringArgsForCall []struct {
+	}
+	retiringReturns struct {
+		result1 bool
+		result2 error
+	}
+	retiringReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	SatisfiesStub        func(lager.Logger, worker.WorkerSpec) bool
 	satisfiesMutex       sync.RWMutex
 	satisfiesArgsForCall []struct {
@@ -508,6 +566,123 @@ func (fake *FakeWorker) ActiveVolumesReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeWorker) AllocateResources(arg1 atc.WorkerResources) (atc.WorkerResources, error) {
+	fake.allocateResourcesMutex.Lock()
+	ret, specificReturn := fake.allocateResourcesReturnsOnCall[len(fake.allocateResourcesArgsForCall)]
+	fake.allocateResourcesArgsForCall = append(fake.allocateResourcesArgsForCall, struct {
+		arg1 atc.WorkerResources
+	}{arg1})
+	stub := fake.AllocateResourcesStub
+	fakeReturns := fake.allocateResourcesReturns
+	fake.recordInvocation("AllocateResources", []interface{}{arg1})
+	fake.allocateResourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) AllocateResourcesCallCount() int {
+	fake.allocateResourcesMutex.RLock()
+	defer fake.allocateResourcesMutex.RUnlock()
+	return len(fake.allocateResourcesArgsForCall)
+}
+
+func (fake *FakeWorker) AllocateResourcesCalls(stub func(atc.WorkerResources) (atc.WorkerResources, error)) {
+	fake.allocateResourcesMutex.Lock()
+	defer fake.allocateResourcesMutex.Unlock()
+	fake.AllocateResourcesStub = stub
+}
+
+func (fake *FakeWorker) AllocateResourcesArgsForCall(i int) atc.WorkerResources {
+	fake.allocateResourcesMutex.RLock()
+	defer fake.allocateResourcesMutex.RUnlock()
+	argsForCall := fake.allocateResourcesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorker) AllocateResourcesReturns(result1 atc.WorkerResources, result2 error) {
+	fake.allocateResourcesMutex.Lock()
+	defer fake.allocateResourcesMutex.Unlock()
+	fake.AllocateResourcesStub = nil
+	fake.allocateResourcesReturns = struct {
+		result1 atc.WorkerResources
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) AllocateResourcesReturnsOnCall(i int, result1 atc.WorkerResources, result2 error) {
+	fake.allocateResourcesMutex.Lock()
+	defer fake.allocateResourcesMutex.Unlock()
+	fake.AllocateResourcesStub = nil
+	if fake.allocateResourcesReturnsOnCall == nil {
+		fake.allocateResourcesReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerResources
+			result2 error
+		})
+	}
+	fake.allocateResourcesReturnsOnCall[i] = struct {
+		result1 atc.WorkerResources
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) Allocated() atc.WorkerResources {
+	fake.allocatedMutex.Lock()
+	ret, specificReturn := fake.allocatedReturnsOnCall[len(fake.allocatedArgsForCall)]
+	fake.allocatedArgsForCall = append(fake.allocatedArgsForCall, struct {
+	}{})
+	stub := fake.AllocatedStub
+	fakeReturns := fake.allocatedReturns
+	fake.recordInvocation("Allocated", []interface{}{})
+	fake.allocatedMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) AllocatedCallCount() int {
+	fake.allocatedMutex.RLock()
+	defer fake.allocatedMutex.RUnlock()
+	return len(fake.allocatedArgsForCall)
+}
+
+func (fake *FakeWorker) AllocatedCalls(stub func() atc.WorkerResources) {
+	fake.allocatedMutex.Lock()
+	defer fake.allocatedMutex.Unlock()
+	fake.AllocatedStub = stub
+}
+
+func (fake *FakeWorker) AllocatedReturns(result1 atc.WorkerResources) {
+	fake.allocatedMutex.Lock()
+	defer fake.allocatedMutex.Unlock()
+	fake.AllocatedStub = nil
+	fake.allocatedReturns = struct {
+		result1 atc.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) AllocatedReturnsOnCall(i int, result1 atc.WorkerResources) {
+	fake.allocatedMutex.Lock()
+	defer fake.allocatedMutex.Unlock()
+	fake.AllocatedStub = nil
+	if fake.allocatedReturnsOnCall == nil {
+		fake.allocatedReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerResources
+		})
+	}
+	fake.allocatedReturnsOnCall[i] = struct {
+		result1 atc.WorkerResources
+	}{result1}
+}
+
 func (fake *FakeWorker) BuildContainers() int {
 	fake.buildContainersMutex.Lock()
 	ret, specificReturn := fake.buildContainersReturnsOnCall[len(fake.buildContainersArgsForCall)]
@@ -561,6 +736,59 @@ func (fake *FakeWorker) BuildContainersReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Capacity() *atc.WorkerResources {
+	fake.capacityMutex.Lock()
+	ret, specificReturn := fake.capacityReturnsOnCall[len(fake.capacityArgsForCall)]
+	fake.capacityArgsForCall = append(fake.capacityArgsForCall, struct {
+	}{})
+	stub := fake.CapacityStub
+	fakeReturns := fake.capacityReturns
+	fake.recordInvocation("Capacity", []interface{}{})
+	fake.capacityMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) CapacityCallCount() int {
+	fake.capacityMutex.RLock()
+	defer fake.capacityMutex.RUnlock()
+	return len(fake.capacityArgsForCall)
+}
+
+func (fake *FakeWorker) CapacityCalls(stub func() *atc.WorkerResources) {
+	fake.capacityMutex.Lock()
+	defer fake.capacityMutex.Unlock()
+	fake.CapacityStub = stub
+}
+
+func (fake *FakeWorker) CapacityReturns(result1 *atc.WorkerResources) {
+	fake.capacityMutex.Lock()
+	defer fake.capacityMutex.Unlock()
+	fake.CapacityStub = nil
+	fake.capacityReturns = struct {
+		result1 *atc.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) CapacityReturnsOnCall(i int, result1 *atc.WorkerResources) {
+	fake.capacityMutex.Lock()
+	defer fake.capacityMutex.Unlock()
+	fake.CapacityStub = nil
+	if fake.capacityReturnsOnCall == nil {
+		fake.capacityReturnsOnCall = make(map[int]struct {
+			result1 *atc.WorkerResources
+		})
+	}
+	fake.capacityReturnsOnCall[i] = struct {
+		result1 *atc.WorkerResources
+	}{result1}
+}
+
 func (fake *FakeWorker) CertsVolume(arg1 lager.Logger) (worker.Volume, bool, error) {
 	fake.certsVolumeMutex.Lock()
 	ret, specificReturn := fake.certsVolumeReturnsOnCall[len(fake.certsVolumeArgsForCall)]
@@ -1621,6 +1849,70 @@ func (fake *FakeWorker) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) ReleaseResources(arg1 atc.WorkerResources) (atc.WorkerResources, error) {
+	fake.releaseResourcesMutex.Lock()
+	ret, specificReturn := fake.releaseResourcesReturnsOnCall[len(fake.releaseResourcesArgsForCall)]
+	fake.releaseResourcesArgsForCall = append(fake.releaseResourcesArgsForCall, struct {
+		arg1 atc.WorkerResources
+	}{arg1})
+	stub := fake.ReleaseResourcesStub
+	fakeReturns := fake.releaseResourcesReturns
+	fake.recordInvocation("ReleaseResources", []interface{}{arg1})
+	fake.releaseResourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) ReleaseResourcesCallCount() int {
+	fake.releaseResourcesMutex.RLock()
+	defer fake.releaseResourcesMutex.RUnlock()
+	return len(fake.releaseResourcesArgsForCall)
+}
+
+func (fake *FakeWorker) ReleaseResourcesCalls(stub func(atc.WorkerResources) (atc.WorkerResources, error)) {
+	fake.releaseResourcesMutex.Lock()
+	defer fake.releaseResourcesMutex.Unlock()
+	fake.ReleaseResourcesStub = stub
+}
+
+func (fake *FakeWorker) ReleaseResourcesArgsForCall(i int) atc.WorkerResources {
+	fake.releaseResourcesMutex.RLock()
+	defer fake.releaseResourcesMutex.RUnlock()
+	argsForCall := fake.releaseResourcesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorker) ReleaseResourcesReturns(result1 atc.WorkerResources, result2 error) {
+	fake.releaseResourcesMutex.Lock()
+	defer fake.releaseResourcesMutex.Unlock()
+	fake.ReleaseResourcesStub = nil
+	fake.releaseResourcesReturns = struct {
+		result1 atc.WorkerResources
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ReleaseResourcesReturnsOnCall(i int, result1 atc.WorkerResources, result2 error) {
+	fake.releaseResourcesMutex.Lock()
+	defer fake.releaseResourcesMutex.Unlock()
+	fake.ReleaseResourcesStub = nil
+	if fake.releaseResourcesReturnsOnCall == nil {
+		fake.releaseResourcesReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerResources
+			result2 error
+		})
+	}
+	fake.releaseResourcesReturnsOnCall[i] = struct {
+		result1 atc.WorkerResources
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) ResourceTypes() []atc.WorkerResourceType {
 	fake.resourceTypesMutex.Lock()
 	ret, specificReturn := fake.resourceTypesReturnsOnCall[len(fake.resourceTypesArgsForCall)]
@@ -1674,6 +1966,62 @@ func (fake *FakeWorker) ResourceTypesReturnsOnCall(i int, result1 []atc.WorkerRe
 	}{result1}
 }
 
+func (fake *FakeWorker) Retiring() (bool, error) {
+	fake.retiringMutex.Lock()
+	ret, specificReturn := fake.retiringReturnsOnCall[len(fake.retiringArgsForCall)]
+	fake.retiringArgsForCall = append(fake.retiringArgsForCall, struct {
+	}{})
+	stub := fake.RetiringStub
+	fakeReturns := fake.retiringReturns
+	fake.recordInvocation("Retiring", []interface{}{})
+	fake.retiringMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) RetiringCallCount() int {
+	fake.retiringMutex.RLock()
+	defer fake.retiringMutex.RUnlock()
+	return len(fake.retiringArgsForCall)
+}
+
+func (fake *FakeWorker) RetiringCalls(stub func() (bool, error)) {
+	fake.retiringMutex.Lock()
+	defer fake.retiringMutex.Unlock()
+	fake.RetiringStub = stub
+}
+
+func (fake *FakeWorker) RetiringReturns(result1 bool, result2 error) {
+	fake.retiringMutex.Lock()
+	defer fake.retiringMutex.Unlock()
+	fake.RetiringStub = nil
+	fake.retiringReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) RetiringReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.retiringMutex.Lock()
+	defer fake.retiringMutex.Unlock()
+	fake.RetiringStub = nil
+	if fake.retiringReturnsOnCall == nil {
+		fake.retiringReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.retiringReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) Satisfies(arg1 lager.Logger, arg2 worker.WorkerSpec) bool {
 	fake.satisfiesMutex.Lock()
 	ret, specificReturn := fake.satisfiesReturnsOnCall[len(fake.satisfiesArgsForCall)]
@@ -1851,8 +2199,14 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.activeTasksMutex.RUnlock()
 	fake.activeVolumesMutex.RLock()
 	defer fake.activeVolumesMutex.RUnlock()
+	fake.allocateResourcesMutex.RLock()
+	defer fake.allocateResourcesMutex.RUnlock()
+	fake.allocatedMutex.RLock()
+	defer fake.allocatedMutex.RUnlock()
 	fake.buildContainersMutex.RLock()
 	defer fake.buildContainersMutex.RUnlock()
+	fake.capacityMutex.RLock()
+	defer fake.capacityMutex.RUnlock()
 	fake.certsVolumeMutex.RLock()
 	defer fake.certsVolumeMutex.RUnlock()
 	fake.createVolumeMutex.RLock()
@@ -1887,8 +2241,12 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.lookupVolumeMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
+	fake.releaseResourcesMutex.RLock()
+	defer fake.releaseResourcesMutex.RUnlock()
 	fake.resourceTypesMutex.RLock()
 	defer fake.resourceTypesMutex.RUnlock()
+	fake.retiringMutex.RLock()
+	defer fake.retiringMutex.RUnlock()
 	fake.satisfiesMutex.RLock()
 	defer fake.satisfiesMutex.RUnlock()
 	fake.tagsMutex.RLock()
@@ -0,0 +1,26 @@
+package atc
+
+// WorkerCapacity is the number of workers each pool of workers should have
+// to fit the steps placed on it, for autoscalers to act on.
+type WorkerCapacity struct {
+	Pools []WorkerPoolCapacity `json:"pools"`
+}
+
+// WorkerPoolCapacity is the capacity of the workers of one platform, set of
+// tags and team. Pools without a team are made up of global workers.
+type WorkerPoolCapacity struct {
+	Platform string   `json:"platform"`
+	Tags     []string `json:"tags"`
+	Team     string   `json:"team,omitempty"`
+
+	Workers      int `json:"workers"`
+	ActiveTasks  int `json:"active_tasks"`
+	StepsWaiting int `json:"steps_waiting"`
+	NoFit        int `json:"no_fit"`
+
+	DesiredWorkers int `json:"desired_workers"`
+
+	// LandableWorkers are the workers that can be landed to scale the pool in
+	// to its desired size without interrupting any build.
+	LandableWorkers []string `json:"landable_workers"`
+}
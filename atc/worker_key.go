@@ -0,0 +1,12 @@
+package atc
+
+// WorkerKey is an SSH public key that workers can register with through the
+// TSA. Keys without a team are for global workers.
+type WorkerKey struct {
+	ID          int    `json:"id"`
+	TeamName    string `json:"team_name,omitempty"`
+	PublicKey   string `json:"public_key"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	CreatedAt   int64  `json:"created_at,omitempty"`
+	LastUsedAt  int64  `json:"last_used_at,omitempty"`
+}
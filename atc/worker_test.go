@@ -61,4 +61,56 @@ var _ = Describe("Worker", func() {
 			})
 		})
 	})
+
+	Describe("WorkerResources.Fits", func() {
+		capacity := atc.WorkerResources{CPU: 2048, Memory: 1024, Disk: 0}
+
+		It("fits resources within the capacity", func() {
+			Expect(atc.WorkerResources{CPU: 2048, Memory: 512}.Fits(capacity)).To(BeTrue())
+		})
+
+		It("does not fit resources exceeding the capacity", func() {
+			Expect(atc.WorkerResources{CPU: 4096}.Fits(capacity)).To(BeFalse())
+			Expect(atc.WorkerResources{Memory: 1025}.Fits(capacity)).To(BeFalse())
+		})
+
+		It("ignores resources with an unknown capacity", func() {
+			Expect(atc.WorkerResources{Disk: 1 << 40}.Fits(capacity)).To(BeTrue())
+		})
+	})
+
+	Describe("SecurityAllowList.Permits", func() {
+		allowList := atc.SecurityAllowList{
+			Capabilities:    []string{"CAP_SYS_PTRACE"},
+			Devices:         []string{"/dev/fuse"},
+			SeccompProfiles: []string{"some-profile"},
+		}
+
+		It("permits privileges in the allow-list", func() {
+			Expect(allowList.Permits(atc.SecurityConfig{
+				Capabilities: atc.CapabilitiesConfig{Add: []string{"CAP_SYS_PTRACE"}},
+				Devices:      []string{"/dev/fuse"},
+				Seccomp:      "some-profile",
+			})).To(BeTrue())
+		})
+
+		It("always permits dropping capabilities and a read-only rootfs", func() {
+			Expect(atc.SecurityAllowList{}.Permits(atc.SecurityConfig{
+				Capabilities:   atc.CapabilitiesConfig{Drop: []string{"ALL"}},
+				ReadOnlyRootfs: true,
+			})).To(BeTrue())
+		})
+
+		It("does not permit privileges missing from the allow-list", func() {
+			Expect(allowList.Permits(atc.SecurityConfig{
+				Capabilities: atc.CapabilitiesConfig{Add: []string{"CAP_SYS_ADMIN"}},
+			})).To(BeFalse())
+			Expect(allowList.Permits(atc.SecurityConfig{
+				Devices: []string{"/dev/kvm"},
+			})).To(BeFalse())
+			Expect(allowList.Permits(atc.SecurityConfig{
+				Seccomp: "other-profile",
+			})).To(BeFalse())
+		})
+	})
 })
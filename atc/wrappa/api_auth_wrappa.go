@@ -47,12 +47,15 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetBuildPreparation,
 			atc.BuildEvents,
 			atc.GetBuildPlan,
+			atc.GetBuildUsage,
+			atc.GetBuildTimeline,
 			atc.ListBuildArtifacts:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 			// resource belongs to authorized team
 		case atc.AbortBuild,
-			atc.SetBuildComment:
+			atc.SetBuildComment,
+			atc.ListBuildNotifications:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
@@ -115,7 +118,12 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.SetLogLevel,
 			atc.GetInfoCreds,
 			atc.SetWall,
-			atc.ClearWall:
+			atc.ClearWall,
+			atc.GetWorkerCapacity,
+			atc.ListWorkerKeys,
+			atc.CreateWorkerKey,
+			atc.RevokeWorkerKey,
+			atc.ListAuditEvents:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team and has required role, or is admin)
@@ -158,7 +166,14 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ClearResourceCache,
 			atc.CreateArtifact,
 			atc.ScheduleJob,
-			atc.GetArtifact:
+			atc.GetArtifact,
+			atc.SearchBuildLogs,
+			atc.GetBuildStats,
+			atc.ListFlakyJobs,
+			atc.ListTeamNotifications,
+			atc.ListTeamWorkerKeys,
+			atc.CreateTeamWorkerKey,
+			atc.RevokeTeamWorkerKey:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
 		// think about it!
@@ -49,8 +49,11 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListBuildArtifacts,
 			atc.GetBuildPreparation,
 			atc.GetBuildPlan,
+			atc.GetBuildUsage,
+			atc.GetBuildTimeline,
 			atc.AbortBuild,
 			atc.SetBuildComment,
+			atc.ListBuildNotifications,
 			atc.PruneWorker,
 			atc.LandWorker,
 			atc.ReportWorkerContainers,
@@ -81,6 +84,10 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListContainers,
 			atc.ListVolumes,
 			atc.ListTeamBuilds,
+			atc.SearchBuildLogs,
+			atc.GetBuildStats,
+			atc.ListFlakyJobs,
+			atc.ListTeamNotifications,
 			atc.ListWorkers,
 			atc.RegisterWorker,
 			atc.HeartbeatWorker,
@@ -107,6 +114,14 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListActiveUsersSince,
 			atc.SetWall,
 			atc.ClearWall,
+			atc.GetWorkerCapacity,
+			atc.ListWorkerKeys,
+			atc.CreateWorkerKey,
+			atc.RevokeWorkerKey,
+			atc.ListTeamWorkerKeys,
+			atc.CreateTeamWorkerKey,
+			atc.RevokeTeamWorkerKey,
+			atc.ListAuditEvents,
 			atc.DeletePipeline,
 			atc.GetCC,
 			atc.GetVersionsDB,
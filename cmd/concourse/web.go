@@ -64,6 +64,11 @@ func (cmd *WebCommand) Runner(args []string) (ifrit.Runner, error) {
 		return nil, err
 	}
 
+	cmd.TSACommand.WorkerKeys, err = cmd.RunCommand.NewWorkerKeyCache()
+	if err != nil {
+		return nil, err
+	}
+
 	tsaRunner, err := cmd.TSACommand.Runner(args)
 	if err != nil {
 		return nil, err
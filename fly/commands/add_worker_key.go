@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type AddWorkerKeyCommand struct {
+	PublicKey string `short:"k" long:"public-key" required:"true" description:"File containing the public key of the workers, in authorized_keys format"`
+	Team      string `long:"team" description:"Name of the team whose workers will use the key, if different from the target default"`
+	Global    bool   `long:"global" description:"Add the key for global workers instead of a team's (admin only)"`
+}
+
+func (command *AddWorkerKeyCommand) Execute([]string) error {
+	if command.Global && command.Team != "" {
+		displayhelpers.Failf("--global and --team are mutually exclusive")
+	}
+
+	publicKey, err := ioutil.ReadFile(command.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var key atc.WorkerKey
+	if command.Global {
+		key, err = target.Client().CreateWorkerKey(atc.WorkerKey{PublicKey: string(publicKey)})
+	} else {
+		var team concourse.Team
+		team, err = workerKeysTeam(target, command.Team)
+		if err != nil {
+			return err
+		}
+
+		key, err = team.CreateWorkerKey(string(publicKey))
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("added worker key %d (%s)\n", key.ID, key.Fingerprint)
+
+	return nil
+}
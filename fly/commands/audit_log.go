@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type AuditLogCommand struct {
+	User   string `short:"u" long:"user" description:"Only list actions taken by this user"`
+	Action string `short:"a" long:"action" description:"Only list actions of this kind, e.g. SaveConfig"`
+	Team   string `long:"team" description:"Only list actions taken against this team"`
+	Since  string `long:"since" description:"Only list actions taken after this time"`
+	Until  string `long:"until" description:"Only list actions taken before this time"`
+	Count  int    `short:"c" long:"count" default:"50" description:"Maximum number of actions to list"`
+	Json   bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *AuditLogCommand) Execute([]string) error {
+	filter := concourse.AuditLogFilter{
+		User:     command.User,
+		Action:   command.Action,
+		TeamName: command.Team,
+	}
+
+	var err error
+	filter.Since, err = parseSearchTime(command.Since, "Since")
+	if err != nil {
+		return err
+	}
+
+	filter.Until, err = parseSearchTime(command.Until, "Until")
+	if err != nil {
+		return err
+	}
+
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && filter.Since.After(filter.Until) {
+		return errors.New("Cannot have --since after --until")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	events := []atc.AuditEvent{}
+	page := concourse.Page{Limit: command.Count}
+	for len(events) < command.Count {
+		pageEvents, pagination, err := target.Client().ListAuditEvents(filter, page)
+		if err != nil {
+			if err == concourse.ErrForbidden {
+				return errors.New("the audit log is only available to admins")
+			}
+
+			return err
+		}
+
+		events = append(events, pageEvents...)
+
+		if pagination.Next == nil {
+			break
+		}
+
+		page = *pagination.Next
+	}
+
+	if len(events) > command.Count {
+		events = events[:command.Count]
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(events)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "time", Color: color.New(color.Bold)},
+			{Contents: "user", Color: color.New(color.Bold)},
+			{Contents: "action", Color: color.New(color.Bold)},
+			{Contents: "team", Color: color.New(color.Bold)},
+			{Contents: "pipeline", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+			{Contents: "diff", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, event := range events {
+		var pipeline string
+		if event.PipelineName != "" {
+			pipeline = atc.PipelineRef{
+				Name:         event.PipelineName,
+				InstanceVars: event.PipelineInstanceVars,
+			}.String()
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: time.Unix(event.Time, 0).Local().Format(timeDateLayout)},
+			{Contents: event.User},
+			{Contents: event.Action},
+			optionalCell(event.TeamName),
+			optionalCell(pipeline),
+			{Contents: strconv.Itoa(event.Status)},
+			optionalCell(event.Diff),
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func optionalCell(contents string) ui.TableCell {
+	if contents == "" {
+		return ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+	}
+
+	return ui.TableCell{Contents: contents}
+}
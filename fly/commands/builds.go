@@ -30,6 +30,7 @@ type BuildsCommand struct {
 	Teams       []string                  `short:"n"  long:"team" description:"Show builds for these teams"`
 	Since       string                    `long:"since" description:"Start of the range to filter builds"`
 	Until       string                    `long:"until" description:"End of the range to filter builds"`
+	Timeline    int                       `long:"timeline" value-name:"BUILD_ID" description:"Show how long each phase of the steps of a build took"`
 }
 
 func (command *BuildsCommand) Execute([]string) error {
@@ -52,6 +53,10 @@ func (command *BuildsCommand) Execute([]string) error {
 		return err
 	}
 
+	if command.Timeline != 0 {
+		return command.displayTimeline(target.Client())
+	}
+
 	page, err = command.validateBuildArguments(timeSince, page, timeUntil)
 	if err != nil {
 		return err
@@ -243,6 +248,50 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 	return table.Render(os.Stdout, Fly.PrintTableHeaders)
 }
 
+func (command *BuildsCommand) displayTimeline(client concourse.Client) error {
+	timings, found, err := client.BuildTimeline(command.Timeline)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("build not found")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(timings)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "step", Color: color.New(color.Bold)},
+			{Contents: "phase", Color: color.New(color.Bold)},
+			{Contents: "start", Color: color.New(color.Bold)},
+			{Contents: "duration", Color: color.New(color.Bold)},
+			{Contents: "streamed", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, timing := range timings {
+		duration := time.Duration(timing.Duration * float64(time.Second))
+
+		streamedCell := ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+		if timing.Bytes != nil {
+			streamedCell = ui.TableCell{Contents: fmt.Sprintf("%d bytes", *timing.Bytes)}
+		}
+
+		table.Data = append(table.Data, []ui.TableCell{
+			{Contents: timing.StepName},
+			{Contents: string(timing.Phase)},
+			{Contents: time.Unix(timing.StartTime, 0).Local().Format(timeDateLayout)},
+			{Contents: duration.Round(time.Millisecond).String()},
+			streamedCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
 func (command *BuildsCommand) validateBuildArguments(timeSince time.Time, page concourse.Page, timeUntil time.Time) (concourse.Page, error) {
 	var err error
 	if command.Since != "" {
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/dashboard"
+	"github.com/concourse/concourse/fly/pty"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+type DashboardCommand struct {
+	Team     string        `long:"team" description:"Name of the team to show, if different from the target default"`
+	Interval time.Duration `long:"interval" default:"5s" description:"How often to refresh the dashboard"`
+}
+
+func (command *DashboardCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	if !pty.IsTerminal() {
+		return errors.New("the dashboard can only be shown in a terminal")
+	}
+
+	dash := dashboard.New(target.Client(), team)
+
+	for {
+		term, err := pty.OpenRawTerm()
+		if err != nil {
+			return err
+		}
+
+		build, err := dash.Run(term, command.Interval, terminalSize)
+
+		restoreErr := term.Restore()
+		if err != nil {
+			return err
+		}
+
+		if restoreErr != nil {
+			return restoreErr
+		}
+
+		if build == nil {
+			return nil
+		}
+
+		// hijacking exits the process when it's done, so run it separately
+		// and come back to the dashboard afterwards
+		hijack := exec.Command(os.Args[0], "-t", string(Fly.Target), "hijack", "-b", strconv.Itoa(build.ID), "--team", team.Name())
+		hijack.Stdin = os.Stdin
+		hijack.Stdout = os.Stdout
+		hijack.Stderr = os.Stderr
+
+		err = hijack.Run()
+		if err != nil {
+			fmt.Fprintf(ui.Stderr, "hijack failed: %s\n", err)
+			time.Sleep(2 * time.Second)
+		}
+	}
+}
+
+func terminalSize() (int, int) {
+	width, height, err := terminal.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width == 0 || height == 0 {
+		return 80, 24
+	}
+
+	return width, height
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/teamarchive"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+)
+
+type ExportTeamCommand struct {
+	Team   flaghelpers.TeamFlag `short:"n" long:"team-name" required:"true" description:"The team to export"`
+	Output string               `short:"o" long:"output" value-name:"PATH" description:"File to write the archive to. Defaults to stdout"`
+	Builds int                  `long:"builds" default:"0" value-name:"COUNT" description:"Include metadata of up to this many of the most recent builds of each job"`
+}
+
+func (command *ExportTeamCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team, err := target.FindTeam(command.Team.Name())
+	if err != nil {
+		return err
+	}
+
+	archive, err := teamarchive.Export(team, command.Builds)
+	if err != nil {
+		return err
+	}
+
+	if command.Output == "" {
+		return teamarchive.Write(os.Stdout, archive)
+	}
+
+	file, err := os.Create(command.Output)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	err = teamarchive.Write(file, archive)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("exported %d pipelines of team %s to %s\n", len(archive.Pipelines), ui.Embolden("%s", team.Name()), command.Output)
+
+	return nil
+}
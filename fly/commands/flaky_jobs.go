@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type FlakyJobsCommand struct {
+	Pipeline *flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Only report jobs of this pipeline"`
+	Team     string                    `long:"team" description:"Name of the team to report on, if different from the target default"`
+	Since    string                    `long:"since" description:"Start of the range of builds to report on (default: 30 days before --until)"`
+	Until    string                    `long:"until" description:"End of the range of builds to report on (default: now)"`
+	Json     bool                      `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *FlakyJobsCommand) Execute([]string) error {
+	var filter concourse.BuildStatsFilter
+
+	if command.Pipeline != nil {
+		_, err := command.Pipeline.Validate()
+		if err != nil {
+			return err
+		}
+
+		filter.PipelineRef = command.Pipeline.Ref()
+	}
+
+	var err error
+	filter.Since, err = parseSearchTime(command.Since, "Since")
+	if err != nil {
+		return err
+	}
+
+	filter.Until, err = parseSearchTime(command.Until, "Until")
+	if err != nil {
+		return err
+	}
+
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && filter.Since.After(filter.Until) {
+		return errors.New("Cannot have --since after --until")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	jobs, found, err := team.FlakyJobs(filter)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("pipeline not found")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(jobs)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "pipeline/job", Color: color.New(color.Bold)},
+			{Contents: "flaky builds", Color: color.New(color.Bold)},
+			{Contents: "builds", Color: color.New(color.Bold)},
+			{Contents: "flakiness", Color: color.New(color.Bold)},
+			{Contents: "last flaky", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, job := range jobs {
+		pipelineRef := atc.PipelineRef{
+			Name:         job.PipelineName,
+			InstanceVars: job.PipelineInstanceVars,
+		}
+
+		var flakiness float64
+		if job.Builds > 0 {
+			flakiness = float64(job.FlakyBuilds) / float64(job.Builds)
+		}
+
+		lastFlakyCell := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		if job.LastFlaky != 0 {
+			lastFlakyCell = ui.TableCell{Contents: time.Unix(job.LastFlaky, 0).Local().Format(timeDateLayout)}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: pipelineRef.String() + "/" + job.JobName},
+			{Contents: strconv.Itoa(job.FlakyBuilds)},
+			{Contents: strconv.Itoa(job.Builds)},
+			{Contents: percentage(flakiness)},
+			lastFlakyCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
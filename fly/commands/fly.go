@@ -24,6 +24,7 @@ type FlyCommand struct {
 	Sync   SyncCommand   `command:"sync"  alias:"s" description:"Download and replace the current fly from the target"`
 
 	ActiveUsers ActiveUsersCommand `command:"active-users" alias:"au" description:"List the active users since a date or for the past 2 months"`
+	AuditLog    AuditLogCommand    `command:"audit-log" alias:"al" description:"List the actions taken through the API"`
 	Userinfo    UserinfoCommand    `command:"userinfo" description:"User information"`
 
 	Teams       TeamsCommand       `command:"teams" alias:"t" description:"List the configured teams"`
@@ -31,9 +32,13 @@ type FlyCommand struct {
 	SetTeam     SetTeamCommand     `command:"set-team"  alias:"st" description:"Create or modify a team to have the given credentials"`
 	RenameTeam  RenameTeamCommand  `command:"rename-team"   alias:"rt" description:"Rename a team"`
 	DestroyTeam DestroyTeamCommand `command:"destroy-team"  alias:"dt" description:"Destroy a team and delete all of its data"`
+	ExportTeam  ExportTeamCommand  `command:"export-team" description:"Write a team's pipelines and their state to an archive"`
+	ImportTeam  ImportTeamCommand  `command:"import-team" description:"Recreate a team's pipelines and their state from an archive"`
 
 	Checklist ChecklistCommand `command:"checklist" alias:"cl" description:"Print a Checkfile of the given pipeline"`
 
+	Dashboard DashboardCommand `command:"dashboard" alias:"dash" description:"Show an interactive dashboard of the team's pipelines"`
+
 	Execute ExecuteCommand `command:"execute" alias:"e" description:"Execute a one-off build using local bits"`
 	Watch   WatchCommand   `command:"watch"   alias:"w" description:"Stream a build's output"`
 
@@ -57,6 +62,7 @@ type FlyCommand struct {
 	RenamePipeline            RenamePipelineCommand          `command:"rename-pipeline"           alias:"rp"   description:"Rename a pipeline"`
 	ValidatePipeline          ValidatePipelineCommand        `command:"validate-pipeline"         alias:"vp"   description:"Validate a pipeline config"`
 	FormatPipeline            FormatPipelineCommand          `command:"format-pipeline"           alias:"fp"   description:"Format a pipeline config"`
+	TestPipeline              TestPipelineCommand            `command:"test-pipeline"             alias:"tp"   description:"Run assertions against a pipeline config and the build plans of its jobs"`
 	OrderPipelines            OrderPipelinesCommand          `command:"order-pipelines"           alias:"op"   description:"Orders pipelines"`
 	OrderPipelinesWithinGroup OrderInstancedPipelinesCommand `command:"order-instanced-pipelines" alias:"oip"  description:"Orders instanced pipelines within an instance group"`
 
@@ -76,6 +82,9 @@ type FlyCommand struct {
 	Builds     BuildsCommand     `command:"builds"      alias:"bs" description:"List builds data"`
 	AbortBuild AbortBuildCommand `command:"abort-build" alias:"ab" description:"Abort a build"`
 	RerunBuild RerunBuildCommand `command:"rerun-build" alias:"rb" description:"Rerun a build"`
+	SearchLogs SearchLogsCommand `command:"search-logs" alias:"sl" description:"Search the logs of builds"`
+	JobStats   JobStatsCommand   `command:"job-stats"   alias:"jst" description:"Summarize the success rate, durations, recoveries and flakiness of builds"`
+	FlakyJobs  FlakyJobsCommand  `command:"flaky-jobs"  alias:"fj" description:"List jobs with builds that both failed and succeeded when rerun"`
 
 	TriggerJob TriggerJobCommand `command:"trigger-job" alias:"tj" description:"Start a job in a pipeline"`
 
@@ -85,6 +94,10 @@ type FlyCommand struct {
 	LandWorker  LandWorkerCommand  `command:"land-worker" alias:"lw" description:"Land a worker"`
 	PruneWorker PruneWorkerCommand `command:"prune-worker" alias:"pw" description:"Prune a stalled, landing, landed, or retiring worker"`
 
+	WorkerKeys      WorkerKeysCommand      `command:"worker-keys" alias:"wks" description:"List the keys workers can register with"`
+	AddWorkerKey    AddWorkerKeyCommand    `command:"add-worker-key" alias:"awk" description:"Allow workers to register with a public key"`
+	RevokeWorkerKey RevokeWorkerKeyCommand `command:"revoke-worker-key" alias:"rwk" description:"Revoke a worker key, disconnecting the workers using it"`
+
 	Curl CurlCommand `command:"curl" alias:"c" description:"curl the api"`
 
 	Completion CompletionCommand `command:"completion" description:"generate shell completion code"`
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/teamarchive"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/vito/go-interact/interact"
+)
+
+type ImportTeamCommand struct {
+	Input           string               `short:"i" long:"input" required:"true" value-name:"PATH" description:"Archive written by export-team"`
+	Team            flaghelpers.TeamFlag `short:"n" long:"team-name" description:"Import into this team instead of the team the archive was exported from"`
+	SkipAuth        bool                 `long:"skip-auth" description:"Leave the auth, container limits and notifiers of an existing team as they are instead of applying the archived ones"`
+	VersionTimeout  time.Duration        `long:"version-timeout" default:"1m" description:"How long to wait for a resource version to be checked before giving up on pinning or disabling it"`
+	SkipInteractive bool                 `long:"non-interactive" description:"Force apply configuration"`
+}
+
+func (command *ImportTeamCommand) Execute([]string) error {
+	file, err := os.Open(command.Input)
+	if err != nil {
+		return err
+	}
+
+	archive, err := teamarchive.Read(file)
+	file.Close()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	teamName := archive.Team.Name
+	if command.Team != "" {
+		teamName = command.Team.Name()
+	}
+
+	fmt.Printf("importing %d pipelines into team %s\n", len(archive.Pipelines), ui.Embolden("%s", teamName))
+	if command.SkipAuth {
+		fmt.Println("the team's auth, container limits and notifiers will not be changed")
+	}
+
+	confirm := true
+	if !command.SkipInteractive {
+		confirm = false
+		err = interact.NewInteraction("\napply team archive?").Resolve(&confirm)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !confirm {
+		displayhelpers.Failf("bailing out")
+	}
+
+	team := target.Client().Team(teamName)
+
+	if !command.SkipAuth {
+		_, created, updated, warnings, err := team.CreateOrUpdate(atc.Team{
+			Auth:            archive.Team.Auth,
+			ContainerLimits: archive.Team.ContainerLimits,
+			Notifiers:       archive.Team.Notifiers,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(warnings) > 0 {
+			displayhelpers.ShowWarnings(warnings)
+		}
+
+		if created {
+			fmt.Println("team created")
+		} else if updated {
+			fmt.Println("team updated")
+		}
+	}
+
+	importer := teamarchive.Importer{
+		Team:           team,
+		VersionTimeout: command.VersionTimeout,
+		Log: func(format string, args ...interface{}) {
+			fmt.Printf(format, args...)
+		},
+	}
+
+	warnings, err := importer.Import(archive)
+	if err != nil {
+		return err
+	}
+
+	if len(warnings) > 0 {
+		displayhelpers.ShowErrors("some state could not be restored", warnings)
+		os.Exit(1)
+	}
+
+	fmt.Println("team imported")
+
+	return nil
+}
@@ -0,0 +1,561 @@
+package dashboard
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/pty"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+const inputPollInterval = 100 * time.Millisecond
+
+// Dashboard is a full-screen view of a team's pipelines which can be drilled
+// down into jobs, builds and their logs, and resources and their versions.
+type Dashboard struct {
+	Client concourse.Client
+	Team   concourse.Team
+
+	views   []*view
+	tail    *logTail
+	loadErr error
+	status  string
+	height  int
+
+	quit   bool
+	hijack *atc.Build
+}
+
+func New(client concourse.Client, team concourse.Team) *Dashboard {
+	return &Dashboard{
+		Client: client,
+		Team:   team,
+		views:  []*view{{kind: pipelinesView}},
+	}
+}
+
+// Run draws the dashboard on the terminal until the user quits, refreshing
+// it every interval. When the user asks to hijack a build, Run returns the
+// build so that the caller can hand the terminal over to it.
+func (dashboard *Dashboard) Run(term pty.Term, interval time.Duration, size func() (int, int)) (*atc.Build, error) {
+	io.WriteString(term, enterScreen)
+	defer io.WriteString(term, leaveScreen)
+
+	keyPresses := make(chan []Key)
+	readErrs := make(chan error, 1)
+	stop := make(chan struct{})
+
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		readKeys(term, keyPresses, readErrs, stop)
+	}()
+
+	defer wg.Wait()
+	defer close(stop)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	resized := pty.ResizeNotifier()
+
+	dashboard.Refresh()
+
+	for {
+		width, height := size()
+		err := dashboard.draw(term, width, height)
+		if err != nil {
+			return nil, err
+		}
+
+		select {
+		case keys := <-keyPresses:
+			for _, key := range keys {
+				dashboard.HandleKey(key)
+
+				if dashboard.quit {
+					dashboard.closeTail()
+					return nil, nil
+				}
+
+				if dashboard.hijack != nil {
+					build := dashboard.hijack
+					dashboard.hijack = nil
+					return build, nil
+				}
+			}
+
+		case err := <-readErrs:
+			return nil, err
+
+		case <-ticker.C:
+			dashboard.Refresh()
+
+		case <-resized:
+
+		case <-dashboard.tailUpdates():
+		}
+	}
+}
+
+// readKeys polls for input rather than blocking on a read so that it can
+// stop before the terminal is handed over for hijacking.
+func readKeys(term pty.Term, keyPresses chan<- []Key, readErrs chan<- error, stop <-chan struct{}) {
+	buf := make([]byte, 64)
+
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		ready, err := pty.WaitForInput(inputPollInterval)
+		if err != nil {
+			readErrs <- err
+			return
+		}
+
+		if !ready {
+			continue
+		}
+
+		n, err := term.Read(buf)
+		if err != nil {
+			readErrs <- err
+			return
+		}
+
+		select {
+		case keyPresses <- ParseKeys(buf[:n]):
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (dashboard *Dashboard) draw(dst io.Writer, width int, height int) error {
+	var b strings.Builder
+	b.WriteString(cursorHome)
+
+	for i, line := range dashboard.Render(width, height) {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+
+		b.WriteString(line)
+		b.WriteString(clearLine)
+	}
+
+	b.WriteString(clearToEnd)
+
+	_, err := io.WriteString(dst, b.String())
+	return err
+}
+
+func (dashboard *Dashboard) current() *view {
+	return dashboard.views[len(dashboard.views)-1]
+}
+
+// Refresh reloads the data shown by the current view.
+func (dashboard *Dashboard) Refresh() {
+	dashboard.loadErr = dashboard.load(dashboard.current())
+}
+
+func (dashboard *Dashboard) push(v *view) {
+	dashboard.views = append(dashboard.views, v)
+
+	if v.kind == buildView {
+		dashboard.closeTail()
+
+		var err error
+		dashboard.tail, err = tailBuild(dashboard.Client, v.build.ID)
+		if err != nil {
+			dashboard.status = "failed to stream build events: " + err.Error()
+		}
+	}
+
+	dashboard.Refresh()
+}
+
+func (dashboard *Dashboard) back() {
+	if len(dashboard.views) == 1 {
+		return
+	}
+
+	if dashboard.current().kind == buildView {
+		dashboard.closeTail()
+	}
+
+	dashboard.views = dashboard.views[:len(dashboard.views)-1]
+	dashboard.Refresh()
+}
+
+func (dashboard *Dashboard) closeTail() {
+	if dashboard.tail != nil {
+		dashboard.tail.Close()
+		dashboard.tail = nil
+	}
+}
+
+func (dashboard *Dashboard) tailUpdates() <-chan struct{} {
+	if dashboard.tail == nil {
+		return nil
+	}
+
+	return dashboard.tail.updated
+}
+
+func (dashboard *Dashboard) pageSize() int {
+	if dashboard.height > 4 {
+		return dashboard.height - 4
+	}
+
+	return 1
+}
+
+// HandleKey applies a key press to the current view.
+func (dashboard *Dashboard) HandleKey(key Key) {
+	dashboard.status = ""
+
+	v := dashboard.current()
+
+	switch key {
+	case KeyCtrlC, "q":
+		dashboard.quit = true
+		return
+	case KeyEscape, KeyLeft, KeyBackspace:
+		dashboard.back()
+		return
+	}
+
+	if v.kind == buildView {
+		dashboard.handleBuildKey(v, key)
+		return
+	}
+
+	selected, hasSelection := v.selected()
+
+	switch key {
+	case KeyUp, "k":
+		v.move(-1)
+	case KeyDown, "j":
+		v.move(1)
+	case KeyPageUp:
+		v.move(-dashboard.pageSize())
+	case KeyPageDown:
+		v.move(dashboard.pageSize())
+	case KeyHome:
+		v.cursor = 0
+	case KeyEnd:
+		v.cursor = len(v.rows) - 1
+		v.move(0)
+
+	case KeyEnter, KeyRight, "l":
+		if hasSelection {
+			dashboard.open(v, selected)
+		}
+
+	case "r":
+		switch v.kind {
+		case pipelinesView:
+			if hasSelection {
+				dashboard.push(&view{kind: resourcesView, pipeline: selected.pipeline})
+			}
+		case jobsView:
+			dashboard.push(&view{kind: resourcesView, pipeline: v.pipeline})
+		}
+
+	case "p":
+		switch {
+		case v.kind == pipelinesView && hasSelection:
+			dashboard.togglePipelinePaused(selected.pipeline)
+		case v.kind == jobsView && hasSelection:
+			dashboard.toggleJobPaused(v.pipeline, selected.job)
+		}
+
+	case "t":
+		switch {
+		case v.kind == jobsView && hasSelection:
+			dashboard.trigger(v.pipeline, selected.job)
+		case v.kind == buildsView:
+			dashboard.trigger(v.pipeline, v.job)
+		}
+
+	case "a":
+		if v.kind == buildsView && hasSelection {
+			dashboard.abort(selected.build)
+		}
+
+	case "i":
+		if v.kind == buildsView && hasSelection {
+			dashboard.hijack = &selected.build
+		}
+
+	case "P":
+		if v.kind == versionsView && hasSelection {
+			dashboard.pin(v.pipeline, v.resource, selected.version)
+		}
+
+	case "u":
+		switch {
+		case v.kind == resourcesView && hasSelection:
+			dashboard.unpin(v.pipeline, selected.resource)
+		case v.kind == versionsView:
+			dashboard.unpin(v.pipeline, v.resource)
+		}
+	}
+}
+
+func (dashboard *Dashboard) handleBuildKey(v *view, key Key) {
+	switch key {
+	case KeyUp, "k":
+		v.cursor++
+	case KeyDown, "j":
+		v.cursor--
+	case KeyPageUp:
+		v.cursor += dashboard.pageSize()
+	case KeyPageDown:
+		v.cursor -= dashboard.pageSize()
+	case KeyHome:
+		v.cursor = maxLogLines
+	case KeyEnd:
+		v.cursor = 0
+	case "t":
+		dashboard.trigger(v.pipeline, v.job)
+	case "a":
+		dashboard.abort(v.build)
+	case "i":
+		dashboard.hijack = &v.build
+	}
+
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+}
+
+func (v *view) move(delta int) {
+	v.cursor += delta
+
+	if v.cursor >= len(v.rows) {
+		v.cursor = len(v.rows) - 1
+	}
+
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+}
+
+func (dashboard *Dashboard) open(v *view, selected row) {
+	switch v.kind {
+	case pipelinesView:
+		dashboard.push(&view{kind: jobsView, pipeline: selected.pipeline})
+	case jobsView:
+		dashboard.push(&view{kind: buildsView, pipeline: v.pipeline, job: selected.job})
+	case resourcesView:
+		dashboard.push(&view{kind: versionsView, pipeline: v.pipeline, resource: selected.resource})
+	case buildsView:
+		dashboard.push(&view{kind: buildView, pipeline: v.pipeline, job: v.job, build: selected.build})
+	}
+}
+
+func (dashboard *Dashboard) togglePipelinePaused(pipeline atc.Pipeline) {
+	var err error
+	if pipeline.Paused {
+		_, err = dashboard.Team.UnpausePipeline(pipeline.Ref())
+		dashboard.status = "unpaused " + pipeline.Ref().String()
+	} else {
+		_, err = dashboard.Team.PausePipeline(pipeline.Ref())
+		dashboard.status = "paused " + pipeline.Ref().String()
+	}
+
+	dashboard.afterAction(err)
+}
+
+func (dashboard *Dashboard) toggleJobPaused(pipeline atc.Pipeline, job atc.Job) {
+	var err error
+	if job.Paused {
+		_, err = dashboard.Team.UnpauseJob(pipeline.Ref(), job.Name)
+		dashboard.status = "unpaused " + job.Name
+	} else {
+		_, err = dashboard.Team.PauseJob(pipeline.Ref(), job.Name)
+		dashboard.status = "paused " + job.Name
+	}
+
+	dashboard.afterAction(err)
+}
+
+func (dashboard *Dashboard) trigger(pipeline atc.Pipeline, job atc.Job) {
+	build, err := dashboard.Team.CreateJobBuild(pipeline.Ref(), job.Name)
+	if err != nil {
+		dashboard.afterAction(err)
+		return
+	}
+
+	switch dashboard.current().kind {
+	case buildView:
+		dashboard.back()
+	case jobsView:
+		dashboard.push(&view{kind: buildsView, pipeline: pipeline, job: job})
+	}
+
+	dashboard.push(&view{kind: buildView, pipeline: pipeline, job: job, build: build})
+	dashboard.status = "started " + job.Name + " #" + build.Name
+}
+
+func (dashboard *Dashboard) abort(build atc.Build) {
+	err := dashboard.Client.AbortBuild(strconv.Itoa(build.ID))
+	dashboard.status = "aborted #" + build.Name
+	dashboard.afterAction(err)
+}
+
+func (dashboard *Dashboard) pin(pipeline atc.Pipeline, resource atc.Resource, version atc.ResourceVersion) {
+	_, err := dashboard.Team.PinResourceVersion(pipeline.Ref(), resource.Name, version.ID)
+	dashboard.status = "pinned " + resource.Name + " to " + ui.PresentVersion(version.Version)
+	dashboard.afterAction(err)
+}
+
+func (dashboard *Dashboard) unpin(pipeline atc.Pipeline, resource atc.Resource) {
+	_, err := dashboard.Team.UnpinResource(pipeline.Ref(), resource.Name)
+	dashboard.status = "unpinned " + resource.Name
+	dashboard.afterAction(err)
+}
+
+func (dashboard *Dashboard) afterAction(err error) {
+	if err != nil {
+		dashboard.status = err.Error()
+		return
+	}
+
+	dashboard.Refresh()
+}
+
+// Render lays out the current view as lines of exactly the given width.
+func (dashboard *Dashboard) Render(width int, height int) []string {
+	dashboard.height = height
+
+	v := dashboard.current()
+
+	breadcrumbs := []string{dashboard.Team.Name()}
+	for _, parent := range dashboard.views {
+		if title := parent.title(); title != "" {
+			breadcrumbs = append(breadcrumbs, title)
+		}
+	}
+
+	lines := []string{fit(color.New(color.Bold).Sprint(strings.Join(breadcrumbs, " › ")), width)}
+
+	bodyHeight := height - 3
+	if bodyHeight < 0 {
+		bodyHeight = 0
+	}
+
+	if v.kind == buildView {
+		lines = append(lines, fit(dashboard.buildSummary(v), width))
+		lines = append(lines, dashboard.renderLog(v, width, bodyHeight)...)
+	} else {
+		lines = append(lines, dashboard.renderRows(v, width, bodyHeight)...)
+	}
+
+	var footer string
+	switch {
+	case dashboard.loadErr != nil:
+		footer = ui.ErroredColor.Sprint(dashboard.loadErr.Error())
+	case dashboard.status != "":
+		footer = dashboard.status
+	default:
+		footer = ui.OffColor.Sprint(v.help())
+	}
+
+	lines = append(lines, fit(footer, width))
+
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+
+	return lines
+}
+
+func (dashboard *Dashboard) renderRows(v *view, width int, bodyHeight int) []string {
+	rows := make([]ui.TableRow, len(v.rows))
+	for i, r := range v.rows {
+		rows[i] = r.cells
+	}
+
+	header, rowLines := tableLines(v.headers, rows)
+
+	lines := []string{fit(header, width)}
+
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+
+	if bodyHeight > 0 && v.cursor >= v.offset+bodyHeight {
+		v.offset = v.cursor - bodyHeight + 1
+	}
+
+	for i := v.offset; i < len(rowLines) && i < v.offset+bodyHeight; i++ {
+		if i == v.cursor {
+			lines = append(lines, highlight(rowLines[i], width))
+		} else {
+			lines = append(lines, fit(rowLines[i], width))
+		}
+	}
+
+	for len(lines) < bodyHeight+1 {
+		lines = append(lines, fit("", width))
+	}
+
+	return lines
+}
+
+func (dashboard *Dashboard) buildSummary(v *view) string {
+	status := ui.BuildStatusCell(v.build.Status)
+
+	summary := status.Color.Sprint(status.Contents) + "  " + buildDuration(v.build)
+	if v.cursor > 0 {
+		summary += "  " + ui.OffColor.Sprint("(scrolled, end to follow)")
+	}
+
+	return summary
+}
+
+func (dashboard *Dashboard) renderLog(v *view, width int, bodyHeight int) []string {
+	var logLines []string
+	if dashboard.tail != nil {
+		logLines = dashboard.tail.Lines()
+	}
+
+	maxScroll := len(logLines) - bodyHeight
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+
+	if v.cursor > maxScroll {
+		v.cursor = maxScroll
+	}
+
+	end := len(logLines) - v.cursor
+	start := end - bodyHeight
+	if start < 0 {
+		start = 0
+	}
+
+	var lines []string
+	for _, line := range logLines[start:end] {
+		lines = append(lines, fit(line, width))
+	}
+
+	for len(lines) < bodyHeight {
+		lines = append(lines, fit("", width))
+	}
+
+	return lines
+}
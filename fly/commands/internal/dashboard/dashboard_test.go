@@ -0,0 +1,228 @@
+package dashboard_test
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	. "github.com/concourse/concourse/fly/commands/internal/dashboard"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/concourse/concourse/go-concourse/concourse/concoursefakes"
+	"github.com/concourse/concourse/go-concourse/concourse/eventstream/eventstreamfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var escapeSequence = regexp.MustCompile("\x1b\\[[0-9;?]*[@-~]")
+
+func screen(dashboard *Dashboard) []string {
+	lines := dashboard.Render(60, 8)
+	Expect(lines).To(HaveLen(8))
+
+	for i, line := range lines {
+		line = strings.TrimRight(escapeSequence.ReplaceAllString(line, ""), " ")
+		Expect(utf8.RuneCountInString(line)).To(BeNumerically("<=", 60))
+		lines[i] = line
+	}
+
+	return lines
+}
+
+var _ = Describe("Dashboard", func() {
+	var (
+		fakeClient *concoursefakes.FakeClient
+		fakeTeam   *concoursefakes.FakeTeam
+
+		dashboard *Dashboard
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(concoursefakes.FakeClient)
+		fakeTeam = new(concoursefakes.FakeTeam)
+		fakeTeam.NameReturns("main")
+
+		fakeTeam.ListPipelinesReturns([]atc.Pipeline{
+			{ID: 1, Name: "some-pipeline"},
+			{ID: 2, Name: "paused-pipeline", Paused: true},
+			{ID: 3, Name: "archived-pipeline", Archived: true},
+		}, nil)
+
+		fakeClient.ListAllJobsReturns([]atc.Job{
+			{Name: "passing", TeamName: "main", PipelineID: 1, FinishedBuild: &atc.Build{Status: atc.StatusSucceeded}},
+			{Name: "failing", TeamName: "main", PipelineID: 1, FinishedBuild: &atc.Build{Status: atc.StatusFailed}},
+			{Name: "other-team", TeamName: "other", PipelineID: 1, NextBuild: &atc.Build{Status: atc.StatusStarted}},
+		}, nil)
+
+		fakeTeam.ListJobsReturns([]atc.Job{
+			{Name: "some-job", FinishedBuild: &atc.Build{Name: "3", Status: atc.StatusSucceeded}},
+			{Name: "paused-job", Paused: true},
+		}, nil)
+
+		dashboard = New(fakeClient, fakeTeam)
+		dashboard.Refresh()
+	})
+
+	It("shows the team's pipelines with a summary of their jobs", func() {
+		Expect(screen(dashboard)).To(Equal([]string{
+			"main",
+			"pipeline         status  jobs",
+			"some-pipeline    failed  ■■",
+			"paused-pipeline  paused",
+			"",
+			"",
+			"",
+			"enter: jobs  r: resources  p: pause/unpause  q: quit",
+		}))
+	})
+
+	It("pauses the selected pipeline", func() {
+		dashboard.HandleKey("p")
+		Expect(fakeTeam.PausePipelineCallCount()).To(Equal(1))
+		Expect(fakeTeam.PausePipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+		Expect(screen(dashboard)[7]).To(Equal("paused some-pipeline"))
+
+		dashboard.HandleKey(KeyDown)
+		dashboard.HandleKey("p")
+		Expect(fakeTeam.UnpausePipelineCallCount()).To(Equal(1))
+		Expect(fakeTeam.UnpausePipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "paused-pipeline"}))
+	})
+
+	Context("when a pipeline is opened", func() {
+		BeforeEach(func() {
+			dashboard.HandleKey(KeyEnter)
+		})
+
+		It("shows its jobs", func() {
+			Expect(fakeTeam.ListJobsArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+			Expect(screen(dashboard)[:4]).To(Equal([]string{
+				"main › some-pipeline",
+				"job         status     build",
+				"some-job    succeeded  #3",
+				"paused-job  paused     n/a",
+			}))
+		})
+
+		It("goes back to the pipelines", func() {
+			dashboard.HandleKey(KeyEscape)
+			Expect(screen(dashboard)[0]).To(Equal("main"))
+		})
+
+		It("unpauses the selected job", func() {
+			dashboard.HandleKey(KeyDown)
+			dashboard.HandleKey("p")
+
+			Expect(fakeTeam.UnpauseJobCallCount()).To(Equal(1))
+			ref, job := fakeTeam.UnpauseJobArgsForCall(0)
+			Expect(ref).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+			Expect(job).To(Equal("paused-job"))
+		})
+
+		Context("when a job is triggered", func() {
+			var events *eventstreamfakes.FakeEventStream
+
+			BeforeEach(func() {
+				fakeTeam.CreateJobBuildReturns(atc.Build{ID: 42, Name: "4"}, nil)
+				fakeTeam.JobBuildsReturns([]atc.Build{{ID: 42, Name: "4", Status: atc.StatusStarted}}, concourse.Pagination{}, true, nil)
+				fakeClient.BuildReturns(atc.Build{ID: 42, Name: "4", Status: atc.StatusStarted}, true, nil)
+
+				events = new(eventstreamfakes.FakeEventStream)
+				events.NextEventReturnsOnCall(0, event.Log{Payload: "hello\nworld\n"}, nil)
+				events.NextEventReturns(nil, io.EOF)
+				fakeClient.BuildEventsReturns(events, nil)
+
+				dashboard.HandleKey("t")
+			})
+
+			It("tails the new build's log", func() {
+				ref, job := fakeTeam.CreateJobBuildArgsForCall(0)
+				Expect(ref).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+				Expect(job).To(Equal("some-job"))
+
+				Expect(fakeClient.BuildEventsArgsForCall(0)).To(Equal("42"))
+
+				Eventually(func() []string { return screen(dashboard)[:4] }).Should(Equal([]string{
+					"main › some-pipeline › some-job › #4",
+					"started  n/a",
+					"hello",
+					"world",
+				}))
+			})
+
+			It("aborts the build", func() {
+				dashboard.HandleKey("a")
+				Expect(fakeClient.AbortBuildCallCount()).To(Equal(1))
+				Expect(fakeClient.AbortBuildArgsForCall(0)).To(Equal("42"))
+			})
+
+			It("stops tailing when going back to the job's builds", func() {
+				dashboard.HandleKey(KeyEscape)
+				Expect(events.CloseCallCount()).To(Equal(1))
+				Expect(screen(dashboard)[:3]).To(Equal([]string{
+					"main › some-pipeline › some-job",
+					"build  status   start  duration",
+					"#4     started  n/a    n/a",
+				}))
+			})
+		})
+	})
+
+	Context("when a pipeline's resources are opened", func() {
+		BeforeEach(func() {
+			resource := atc.Resource{Name: "some-resource", Type: "git", PinnedVersion: atc.Version{"ref": "v1"}}
+
+			fakeTeam.ListResourcesReturns([]atc.Resource{resource}, nil)
+			fakeTeam.ResourceReturns(resource, true, nil)
+			fakeTeam.ResourceVersionsReturns([]atc.ResourceVersion{
+				{ID: 2, Version: atc.Version{"ref": "v2"}, Enabled: true},
+				{ID: 1, Version: atc.Version{"ref": "v1"}, Enabled: false},
+			}, concourse.Pagination{}, true, nil)
+
+			dashboard.HandleKey("r")
+		})
+
+		It("shows the resources", func() {
+			Expect(screen(dashboard)[:3]).To(Equal([]string{
+				"main › some-pipeline resources",
+				"resource       type  pinned  check",
+				"some-resource  git   ref:v1  n/a",
+			}))
+		})
+
+		It("unpins the selected resource", func() {
+			dashboard.HandleKey("u")
+			Expect(fakeTeam.UnpinResourceCallCount()).To(Equal(1))
+		})
+
+		It("pins the selected version", func() {
+			dashboard.HandleKey(KeyEnter)
+			Expect(screen(dashboard)[:4]).To(Equal([]string{
+				"main › some-pipeline resources › some-resource",
+				"version  state",
+				"ref:v2   enabled",
+				"ref:v1   disabled  pinned",
+			}))
+
+			dashboard.HandleKey("P")
+			Expect(fakeTeam.PinResourceVersionCallCount()).To(Equal(1))
+			ref, resource, versionID := fakeTeam.PinResourceVersionArgsForCall(0)
+			Expect(ref).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+			Expect(resource).To(Equal("some-resource"))
+			Expect(versionID).To(Equal(2))
+		})
+	})
+
+	Context("when loading fails", func() {
+		BeforeEach(func() {
+			fakeTeam.ListPipelinesReturns(nil, io.ErrUnexpectedEOF)
+			dashboard.Refresh()
+		})
+
+		It("shows the error", func() {
+			Expect(screen(dashboard)[7]).To(Equal("unexpected EOF"))
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package dashboard
+
+import "unicode/utf8"
+
+type Key string
+
+const (
+	KeyUp        Key = "up"
+	KeyDown      Key = "down"
+	KeyLeft      Key = "left"
+	KeyRight     Key = "right"
+	KeyHome      Key = "home"
+	KeyEnd       Key = "end"
+	KeyPageUp    Key = "pgup"
+	KeyPageDown  Key = "pgdn"
+	KeyEnter     Key = "enter"
+	KeyEscape    Key = "esc"
+	KeyBackspace Key = "backspace"
+	KeyCtrlC     Key = "ctrl-c"
+)
+
+var escapeSequences = map[string]Key{
+	"A":  KeyUp,
+	"B":  KeyDown,
+	"C":  KeyRight,
+	"D":  KeyLeft,
+	"H":  KeyHome,
+	"F":  KeyEnd,
+	"1~": KeyHome,
+	"4~": KeyEnd,
+	"5~": KeyPageUp,
+	"6~": KeyPageDown,
+}
+
+// ParseKeys splits input read from a raw terminal into key presses. Printable
+// characters are returned as themselves.
+func ParseKeys(input []byte) []Key {
+	var keys []Key
+
+	for len(input) > 0 {
+		switch input[0] {
+		case 0x1b:
+			if len(input) < 3 || (input[1] != '[' && input[1] != 'O') {
+				keys = append(keys, KeyEscape)
+				input = input[1:]
+				continue
+			}
+
+			end := 2
+			for end < len(input) && (input[end] < 0x40 || input[end] > 0x7e) {
+				end++
+			}
+
+			if end == len(input) {
+				return keys
+			}
+
+			if key, found := escapeSequences[string(input[2:end+1])]; found {
+				keys = append(keys, key)
+			}
+
+			input = input[end+1:]
+
+		case '\r', '\n':
+			keys = append(keys, KeyEnter)
+			input = input[1:]
+
+		case '\b', 127:
+			keys = append(keys, KeyBackspace)
+			input = input[1:]
+
+		case 3:
+			keys = append(keys, KeyCtrlC)
+			input = input[1:]
+
+		default:
+			r, size := utf8.DecodeRune(input)
+			if r >= 32 && r != utf8.RuneError {
+				keys = append(keys, Key(string(r)))
+			}
+
+			input = input[size:]
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,25 @@
+package dashboard_test
+
+import (
+	. "github.com/concourse/concourse/fly/commands/internal/dashboard"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseKeys", func() {
+	DescribeTable("parsing raw input",
+		func(input string, keys []Key) {
+			Expect(ParseKeys([]byte(input))).To(Equal(keys))
+		},
+		Entry("printable characters", "jkP", []Key{"j", "k", "P"}),
+		Entry("arrow keys", "\x1b[A\x1b[B\x1b[C\x1b[D", []Key{KeyUp, KeyDown, KeyRight, KeyLeft}),
+		Entry("application mode arrow keys", "\x1bOA", []Key{KeyUp}),
+		Entry("paging keys", "\x1b[5~\x1b[6~\x1b[H\x1b[4~", []Key{KeyPageUp, KeyPageDown, KeyHome, KeyEnd}),
+		Entry("a lone escape", "\x1b", []Key{KeyEscape}),
+		Entry("control keys", "\r\x7f\x03", []Key{KeyEnter, KeyBackspace, KeyCtrlC}),
+		Entry("unknown escape sequences", "\x1b[15~q", []Key{"q"}),
+		Entry("multi-byte characters", "é", []Key{"é"}),
+	)
+})
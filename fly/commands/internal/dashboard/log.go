@@ -0,0 +1,93 @@
+package dashboard
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/concourse/concourse/fly/eventstream"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+// maxLogLines bounds how much of a build's output is kept in memory.
+const maxLogLines = 10000
+
+// logTail collects the rendered output of a build's event stream.
+type logTail struct {
+	events  concourse.Events
+	updated chan struct{}
+
+	lock    sync.Mutex
+	lines   []string
+	partial string
+}
+
+func tailBuild(client concourse.Client, buildID int) (*logTail, error) {
+	events, err := client.BuildEvents(strconv.Itoa(buildID))
+	if err != nil {
+		return nil, err
+	}
+
+	tail := &logTail{
+		events:  events,
+		updated: make(chan struct{}, 1),
+	}
+
+	go eventstream.Render(tail, events, eventstream.RenderOptions{})
+
+	return tail, nil
+}
+
+func (tail *logTail) Write(p []byte) (int, error) {
+	tail.lock.Lock()
+
+	parts := strings.Split(tail.partial+strings.ReplaceAll(string(p), "\t", "    "), "\n")
+	for _, line := range parts[:len(parts)-1] {
+		tail.lines = append(tail.lines, overwritten(line))
+	}
+
+	if len(tail.lines) > maxLogLines {
+		tail.lines = tail.lines[len(tail.lines)-maxLogLines:]
+	}
+
+	tail.partial = parts[len(parts)-1]
+
+	tail.lock.Unlock()
+
+	select {
+	case tail.updated <- struct{}{}:
+	default:
+	}
+
+	return len(p), nil
+}
+
+func (tail *logTail) Lines() []string {
+	tail.lock.Lock()
+	defer tail.lock.Unlock()
+
+	lines := make([]string, len(tail.lines), len(tail.lines)+1)
+	copy(lines, tail.lines)
+
+	if tail.partial != "" {
+		lines = append(lines, overwritten(tail.partial))
+	}
+
+	return lines
+}
+
+func (tail *logTail) Close() error {
+	return tail.events.Close()
+}
+
+// overwritten returns what is left visible of a line after carriage returns,
+// e.g. from progress bars.
+func overwritten(line string) string {
+	line = strings.TrimSuffix(line, "\r")
+
+	if i := strings.LastIndex(line, "\r"); i >= 0 {
+		return line[i+1:]
+	}
+
+	return line
+}
@@ -0,0 +1,132 @@
+package dashboard
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/concourse/concourse/fly/ui"
+)
+
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+
+	cursorHome   = "\x1b[H"
+	clearLine    = "\x1b[K"
+	clearToEnd   = "\x1b[J"
+	reverseVideo = "\x1b[7m"
+	resetStyle   = "\x1b[0m"
+)
+
+// escapeSequenceLength returns the length of the escape sequence at the
+// start of s, or 0 if s does not start with one.
+func escapeSequenceLength(s string) int {
+	if len(s) < 2 || s[0] != 0x1b || s[1] != '[' {
+		return 0
+	}
+
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+	}
+
+	return len(s)
+}
+
+func visibleLength(s string) int {
+	length := 0
+	for i := 0; i < len(s); {
+		if n := escapeSequenceLength(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		length++
+	}
+
+	return length
+}
+
+// fit truncates or pads s to exactly width visible characters, leaving any
+// escape sequences intact.
+func fit(s string, width int) string {
+	var b strings.Builder
+
+	visible := 0
+	styled := false
+	for i := 0; i < len(s); {
+		if n := escapeSequenceLength(s[i:]); n > 0 {
+			b.WriteString(s[i : i+n])
+			styled = true
+			i += n
+			continue
+		}
+
+		if visible == width {
+			break
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		b.WriteString(s[i : i+size])
+		visible++
+		i += size
+	}
+
+	if styled {
+		b.WriteString(resetStyle)
+	}
+
+	if visible < width {
+		b.WriteString(strings.Repeat(" ", width-visible))
+	}
+
+	return b.String()
+}
+
+// highlight renders the line in reverse video, re-applying it after any
+// style resets within the line.
+func highlight(s string, width int) string {
+	s = strings.ReplaceAll(fit(s, width), resetStyle, resetStyle+reverseVideo)
+	return reverseVideo + s + resetStyle
+}
+
+// tableLines renders the rows in aligned columns, in the same way as ui.Table.
+// Cell contents may contain their own escape sequences.
+func tableLines(headers ui.TableRow, rows []ui.TableRow) (string, []string) {
+	widths := map[int]int{}
+	for _, row := range append([]ui.TableRow{headers}, rows...) {
+		for i, cell := range row {
+			if width := visibleLength(cell.Contents); width > widths[i] {
+				widths[i] = width
+			}
+		}
+	}
+
+	renderRow := func(row ui.TableRow) string {
+		var b strings.Builder
+		for i, cell := range row {
+			contents := cell.Contents
+			if cell.Color != nil {
+				contents = cell.Color.Sprint(contents)
+			}
+
+			b.WriteString(contents)
+
+			if i+1 < len(row) {
+				b.WriteString(strings.Repeat(" ", widths[i]-visibleLength(cell.Contents)+2))
+			}
+		}
+
+		return b.String()
+	}
+
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = renderRow(row)
+	}
+
+	return renderRow(headers), lines
+}
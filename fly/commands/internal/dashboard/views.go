@@ -0,0 +1,423 @@
+package dashboard
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+const (
+	buildsLimit   = 50
+	versionsLimit = 50
+)
+
+type viewKind int
+
+const (
+	pipelinesView viewKind = iota
+	jobsView
+	resourcesView
+	versionsView
+	buildsView
+	buildView
+)
+
+type view struct {
+	kind viewKind
+
+	pipeline atc.Pipeline
+	job      atc.Job
+	resource atc.Resource
+	build    atc.Build
+
+	headers ui.TableRow
+	rows    []row
+
+	// cursor is the selected row. For the build view, which has no rows, it
+	// is instead how many lines the log is scrolled up by.
+	cursor int
+	offset int
+}
+
+type row struct {
+	key   string
+	cells ui.TableRow
+
+	pipeline atc.Pipeline
+	job      atc.Job
+	resource atc.Resource
+	version  atc.ResourceVersion
+	build    atc.Build
+}
+
+func (v *view) selected() (row, bool) {
+	if v.cursor < 0 || v.cursor >= len(v.rows) {
+		return row{}, false
+	}
+
+	return v.rows[v.cursor], true
+}
+
+func (v *view) setRows(headers ui.TableRow, rows []row) {
+	previous, hadSelection := v.selected()
+
+	v.headers = headers
+	v.rows = rows
+
+	if hadSelection {
+		for i, r := range rows {
+			if r.key == previous.key {
+				v.cursor = i
+				return
+			}
+		}
+	}
+
+	if v.cursor >= len(rows) {
+		v.cursor = len(rows) - 1
+	}
+
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+}
+
+func (v *view) title() string {
+	switch v.kind {
+	case jobsView:
+		return v.pipeline.Ref().String()
+	case resourcesView:
+		return v.pipeline.Ref().String() + " resources"
+	case versionsView:
+		return v.resource.Name
+	case buildsView:
+		return v.job.Name
+	case buildView:
+		return "#" + v.build.Name
+	default:
+		return ""
+	}
+}
+
+func (v *view) help() string {
+	switch v.kind {
+	case pipelinesView:
+		return "enter: jobs  r: resources  p: pause/unpause  q: quit"
+	case jobsView:
+		return "enter: builds  t: trigger  p: pause/unpause  r: resources  esc: back  q: quit"
+	case resourcesView:
+		return "enter: versions  u: unpin  esc: back  q: quit"
+	case versionsView:
+		return "P: pin  u: unpin  esc: back  q: quit"
+	case buildsView:
+		return "enter: log  t: trigger  a: abort  i: hijack  esc: back  q: quit"
+	case buildView:
+		return "up/down: scroll  end: follow  t: trigger  a: abort  i: hijack  esc: back  q: quit"
+	default:
+		return ""
+	}
+}
+
+func headers(names ...string) ui.TableRow {
+	row := ui.TableRow{}
+	for _, name := range names {
+		row = append(row, ui.TableCell{Contents: name, Color: color.New(color.Bold)})
+	}
+
+	return row
+}
+
+func (dashboard *Dashboard) load(v *view) error {
+	switch v.kind {
+	case pipelinesView:
+		return dashboard.loadPipelines(v)
+	case jobsView:
+		return dashboard.loadJobs(v)
+	case resourcesView:
+		return dashboard.loadResources(v)
+	case versionsView:
+		return dashboard.loadVersions(v)
+	case buildsView:
+		return dashboard.loadBuilds(v)
+	case buildView:
+		return dashboard.loadBuild(v)
+	}
+
+	return nil
+}
+
+func (dashboard *Dashboard) loadPipelines(v *view) error {
+	pipelines, err := dashboard.Team.ListPipelines()
+	if err != nil {
+		return err
+	}
+
+	allJobs, err := dashboard.Client.ListAllJobs()
+	if err != nil {
+		return err
+	}
+
+	jobs := map[int][]atc.Job{}
+	for _, job := range allJobs {
+		if job.TeamName == dashboard.Team.Name() {
+			jobs[job.PipelineID] = append(jobs[job.PipelineID], job)
+		}
+	}
+
+	var rows []row
+	for _, pipeline := range pipelines {
+		if pipeline.Archived {
+			continue
+		}
+
+		strip := ""
+		for _, job := range jobs[pipeline.ID] {
+			cell := jobStatusCell(job)
+			strip += cell.Color.Sprint("■")
+		}
+
+		rows = append(rows, row{
+			key:      strconv.Itoa(pipeline.ID),
+			pipeline: pipeline,
+			cells: ui.TableRow{
+				{Contents: pipeline.Ref().String()},
+				pipelineStatusCell(pipeline, jobs[pipeline.ID]),
+				{Contents: strip},
+			},
+		})
+	}
+
+	v.setRows(headers("pipeline", "status", "jobs"), rows)
+
+	return nil
+}
+
+func (dashboard *Dashboard) loadJobs(v *view) error {
+	jobs, err := dashboard.Team.ListJobs(v.pipeline.Ref())
+	if err != nil {
+		return err
+	}
+
+	var rows []row
+	for _, job := range jobs {
+		build := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		if job.NextBuild != nil {
+			build.Contents = "#" + job.NextBuild.Name
+			build.Color = nil
+		} else if job.FinishedBuild != nil {
+			build.Contents = "#" + job.FinishedBuild.Name
+			build.Color = nil
+		}
+
+		rows = append(rows, row{
+			key: job.Name,
+			job: job,
+			cells: ui.TableRow{
+				{Contents: job.Name},
+				jobStatusCell(job),
+				build,
+			},
+		})
+	}
+
+	v.setRows(headers("job", "status", "build"), rows)
+
+	return nil
+}
+
+func (dashboard *Dashboard) loadResources(v *view) error {
+	resources, err := dashboard.Team.ListResources(v.pipeline.Ref())
+	if err != nil {
+		return err
+	}
+
+	var rows []row
+	for _, resource := range resources {
+		pinned := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		if resource.PinnedVersion != nil {
+			pinned = ui.TableCell{Contents: ui.PresentVersion(resource.PinnedVersion), Color: ui.PausedColor}
+		}
+
+		check := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		if resource.Build != nil {
+			check = ui.BuildStatusCell(resource.Build.Status)
+		}
+
+		rows = append(rows, row{
+			key:      resource.Name,
+			resource: resource,
+			cells: ui.TableRow{
+				{Contents: resource.Name},
+				{Contents: resource.Type},
+				pinned,
+				check,
+			},
+		})
+	}
+
+	v.setRows(headers("resource", "type", "pinned", "check"), rows)
+
+	return nil
+}
+
+func (dashboard *Dashboard) loadVersions(v *view) error {
+	ref := v.pipeline.Ref()
+
+	resource, found, err := dashboard.Team.Resource(ref, v.resource.Name)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("resource '%s' not found", v.resource.Name)
+	}
+
+	v.resource = resource
+
+	versions, _, _, err := dashboard.Team.ResourceVersions(ref, v.resource.Name, concourse.Page{Limit: versionsLimit}, nil)
+	if err != nil {
+		return err
+	}
+
+	var rows []row
+	for _, version := range versions {
+		state := ui.TableCell{Contents: "enabled"}
+		if !version.Enabled {
+			state = ui.TableCell{Contents: "disabled", Color: ui.OffColor}
+		}
+
+		pinned := ui.TableCell{}
+		if resource.PinnedVersion != nil && ui.PresentVersion(resource.PinnedVersion) == ui.PresentVersion(version.Version) {
+			pinned = ui.TableCell{Contents: "pinned", Color: ui.PausedColor}
+		}
+
+		rows = append(rows, row{
+			key:     strconv.Itoa(version.ID),
+			version: version,
+			cells: ui.TableRow{
+				{Contents: ui.PresentVersion(version.Version)},
+				state,
+				pinned,
+			},
+		})
+	}
+
+	v.setRows(headers("version", "state", ""), rows)
+
+	return nil
+}
+
+func (dashboard *Dashboard) loadBuilds(v *view) error {
+	builds, _, found, err := dashboard.Team.JobBuilds(v.pipeline.Ref(), v.job.Name, concourse.Page{Limit: buildsLimit})
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("job '%s' not found", v.job.Name)
+	}
+
+	var rows []row
+	for _, build := range builds {
+		rows = append(rows, row{
+			key:   strconv.Itoa(build.ID),
+			build: build,
+			cells: ui.TableRow{
+				{Contents: "#" + build.Name},
+				ui.BuildStatusCell(build.Status),
+				{Contents: buildStartTime(build)},
+				{Contents: buildDuration(build)},
+			},
+		})
+	}
+
+	v.setRows(headers("build", "status", "start", "duration"), rows)
+
+	return nil
+}
+
+func (dashboard *Dashboard) loadBuild(v *view) error {
+	build, found, err := dashboard.Client.Build(strconv.Itoa(v.build.ID))
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("build %d not found", v.build.ID)
+	}
+
+	v.build = build
+
+	return nil
+}
+
+func jobStatusCell(job atc.Job) ui.TableCell {
+	switch {
+	case job.Paused:
+		return ui.TableCell{Contents: "paused", Color: ui.PausedColor}
+	case job.NextBuild != nil:
+		return ui.BuildStatusCell(job.NextBuild.Status)
+	case job.FinishedBuild != nil:
+		return ui.BuildStatusCell(job.FinishedBuild.Status)
+	default:
+		return ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+	}
+}
+
+var statusSeverity = map[atc.BuildStatus]int{
+	atc.StatusSucceeded: 1,
+	atc.StatusAborted:   2,
+	atc.StatusErrored:   3,
+	atc.StatusFailed:    4,
+}
+
+// pipelineStatusCell summarizes the jobs of a pipeline the same way as the
+// web dashboard: running if any job is running, otherwise the most severe
+// status of the jobs' latest builds.
+func pipelineStatusCell(pipeline atc.Pipeline, jobs []atc.Job) ui.TableCell {
+	if pipeline.Paused {
+		return ui.TableCell{Contents: "paused", Color: ui.PausedColor}
+	}
+
+	var worst atc.BuildStatus
+	for _, job := range jobs {
+		if job.NextBuild != nil && job.NextBuild.Status == atc.StatusStarted {
+			return ui.BuildStatusCell(atc.StatusStarted)
+		}
+
+		if job.FinishedBuild != nil && statusSeverity[job.FinishedBuild.Status] > statusSeverity[worst] {
+			worst = job.FinishedBuild.Status
+		}
+	}
+
+	if worst == "" {
+		return ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+	}
+
+	return ui.BuildStatusCell(worst)
+}
+
+func buildStartTime(build atc.Build) string {
+	if build.StartTime == 0 {
+		return "n/a"
+	}
+
+	return time.Unix(build.StartTime, 0).Local().Format("2006-01-02@15:04:05-0700")
+}
+
+func buildDuration(build atc.Build) string {
+	if build.StartTime == 0 {
+		return "n/a"
+	}
+
+	if build.EndTime == 0 {
+		return fmt.Sprintf("%v+", time.Since(time.Unix(build.StartTime, 0)).Truncate(time.Second))
+	}
+
+	return time.Unix(build.EndTime, 0).Sub(time.Unix(build.StartTime, 0)).String()
+}
@@ -0,0 +1,336 @@
+package pipelinetest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Selector picks nodes out of a tree of JSON values. It is a list of
+// dot-separated segments, each of which is a map key, '*' for every child of
+// a map or array, or '**' for a node and all of its descendants. Each segment
+// may be followed by filters in brackets, and the first segment may consist
+// of only filters to filter the root:
+//
+//	[0]           the element of an array at the given index
+//	[key]         maps which have the key
+//	[key=value]   maps whose value at key is the given scalar
+//
+// Filters applied to an array apply to its elements, so
+// 'jobs[name=deploy].plan.**[get=repo]' selects every get of the 'repo'
+// resource in the 'deploy' job.
+type Selector struct {
+	source   string
+	segments []segment
+}
+
+type segment struct {
+	key     string
+	filters []filter
+}
+
+type filter struct {
+	index    int
+	key      string
+	value    string
+	hasValue bool
+}
+
+// Node is a value in the tree along with the path it was found at. Paths are
+// selectors themselves, naming array elements by their 'name' field when
+// they have one.
+type Node struct {
+	Path  string
+	Value interface{}
+}
+
+func ParseSelector(source string) (Selector, error) {
+	parts, err := splitSegments(source)
+	if err != nil {
+		return Selector{}, err
+	}
+
+	selector := Selector{source: source}
+	for _, part := range parts {
+		segment, err := parseSegment(part)
+		if err != nil {
+			return Selector{}, fmt.Errorf("invalid selector '%s': %w", source, err)
+		}
+
+		selector.segments = append(selector.segments, segment)
+	}
+
+	return selector, nil
+}
+
+func (selector Selector) String() string {
+	return selector.source
+}
+
+func (selector Selector) Select(root interface{}) []Node {
+	nodes := []Node{{Value: root}}
+
+	for _, segment := range selector.segments {
+		var next []Node
+		for _, node := range nodes {
+			next = append(next, segment.step(node)...)
+		}
+
+		for _, filter := range segment.filters {
+			var filtered []Node
+			for _, node := range next {
+				filtered = append(filtered, filter.apply(node)...)
+			}
+
+			next = filtered
+		}
+
+		nodes = unique(next)
+	}
+
+	return nodes
+}
+
+func (segment segment) step(node Node) []Node {
+	switch segment.key {
+	case "":
+		return []Node{node}
+	case "*":
+		return children(node)
+	case "**":
+		return descendants(node)
+	}
+
+	object, ok := node.Value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	value, found := object[segment.key]
+	if !found {
+		return nil
+	}
+
+	return []Node{{Path: keyPath(node.Path, segment.key), Value: value}}
+}
+
+func (filter filter) apply(node Node) []Node {
+	if elements, ok := node.Value.([]interface{}); ok {
+		if filter.key == "" {
+			if filter.index >= len(elements) {
+				return nil
+			}
+
+			return []Node{element(node.Path, filter.index, elements[filter.index])}
+		}
+
+		var matching []Node
+		for i, value := range elements {
+			if filter.matches(value) {
+				matching = append(matching, element(node.Path, i, value))
+			}
+		}
+
+		return matching
+	}
+
+	if filter.key != "" && filter.matches(node.Value) {
+		return []Node{node}
+	}
+
+	return nil
+}
+
+func (filter filter) matches(value interface{}) bool {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	field, found := object[filter.key]
+	if !found {
+		return false
+	}
+
+	if !filter.hasValue {
+		return true
+	}
+
+	scalar, ok := scalarString(field)
+	return ok && scalar == filter.value
+}
+
+func children(node Node) []Node {
+	var nodes []Node
+
+	switch value := node.Value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			nodes = append(nodes, Node{Path: keyPath(node.Path, key), Value: value[key]})
+		}
+	case []interface{}:
+		for i, child := range value {
+			nodes = append(nodes, element(node.Path, i, child))
+		}
+	}
+
+	return nodes
+}
+
+func descendants(node Node) []Node {
+	nodes := []Node{node}
+	for _, child := range children(node) {
+		nodes = append(nodes, descendants(child)...)
+	}
+
+	return nodes
+}
+
+func unique(nodes []Node) []Node {
+	seen := map[string]bool{}
+
+	var result []Node
+	for _, node := range nodes {
+		if seen[node.Path] {
+			continue
+		}
+
+		seen[node.Path] = true
+		result = append(result, node)
+	}
+
+	return result
+}
+
+func keyPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func element(path string, index int, value interface{}) Node {
+	if object, ok := value.(map[string]interface{}); ok {
+		if name, ok := object["name"].(string); ok {
+			return Node{Path: fmt.Sprintf("%s[name=%s]", path, name), Value: value}
+		}
+	}
+
+	return Node{Path: fmt.Sprintf("%s[%d]", path, index), Value: value}
+}
+
+func scalarString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case nil:
+		return "null", true
+	default:
+		return "", false
+	}
+}
+
+func splitSegments(source string) ([]string, error) {
+	if source == "" {
+		return nil, nil
+	}
+
+	var parts []string
+
+	depth := 0
+	start := 0
+	for i, c := range source {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("invalid selector '%s': unexpected ']'", source)
+			}
+		case '.':
+			if depth == 0 {
+				parts = append(parts, source[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid selector '%s': missing ']'", source)
+	}
+
+	return append(parts, source[start:]), nil
+}
+
+func parseSegment(part string) (segment, error) {
+	if part == "" {
+		return segment{}, fmt.Errorf("empty segment")
+	}
+
+	key := part
+	rest := ""
+	if i := strings.IndexByte(part, '['); i != -1 {
+		key = part[:i]
+		rest = part[i:]
+	}
+
+	segment := segment{key: key}
+
+	for rest != "" {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end == -1 {
+			return segment, fmt.Errorf("unexpected '%s'", rest)
+		}
+
+		filter, err := parseFilter(rest[1:end])
+		if err != nil {
+			return segment, err
+		}
+
+		segment.filters = append(segment.filters, filter)
+		rest = rest[end+1:]
+	}
+
+	return segment, nil
+}
+
+func parseFilter(source string) (filter, error) {
+	if source == "" {
+		return filter{}, fmt.Errorf("empty filter")
+	}
+
+	if index, err := strconv.Atoi(source); err == nil {
+		if index < 0 {
+			return filter{}, fmt.Errorf("negative index %d", index)
+		}
+
+		return filter{index: index}, nil
+	}
+
+	filter := filter{key: source}
+	if i := strings.IndexByte(source, '='); i != -1 {
+		filter.key = source[:i]
+		filter.value = source[i+1:]
+		filter.hasValue = true
+	}
+
+	if filter.key == "" {
+		return filter, fmt.Errorf("missing key in filter '%s'", source)
+	}
+
+	return filter, nil
+}
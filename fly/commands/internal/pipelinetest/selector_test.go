@@ -0,0 +1,79 @@
+package pipelinetest_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/fly/commands/internal/pipelinetest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Selector", func() {
+	var tree interface{}
+
+	BeforeEach(func() {
+		err := json.Unmarshal([]byte(`{
+			"jobs": [
+				{
+					"name": "unit",
+					"plan": [{"get": "repo", "trigger": true}, {"task": "test"}]
+				},
+				{
+					"name": "deploy",
+					"plan": [
+						{"get": "repo", "passed": ["unit"]},
+						{"in_parallel": {"steps": [{"task": "build", "privileged": true}, {"get": "other"}]}},
+						{"put": "image", "attempts": 2}
+					]
+				}
+			]
+		}`), &tree)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	DescribeTable("selecting paths",
+		func(source string, paths ...string) {
+			selector, err := pipelinetest.ParseSelector(source)
+			Expect(err).ToNot(HaveOccurred())
+
+			selected := []string{}
+			for _, node := range selector.Select(tree) {
+				selected = append(selected, node.Path)
+			}
+
+			Expect(selected).To(Equal(paths))
+		},
+		Entry("the root", "", ""),
+		Entry("keys", "jobs", "jobs"),
+		Entry("missing keys", "pipelines"),
+		Entry("array indexes", "jobs[1].plan[0]", "jobs[name=deploy].plan[0]"),
+		Entry("out of range indexes", "jobs[2]"),
+		Entry("wildcards", "jobs.*.name", "jobs[name=unit].name", "jobs[name=deploy].name"),
+		Entry("filters by value", "jobs[name=deploy].plan[put]", "jobs[name=deploy].plan[2]"),
+		Entry("filters by numeric value", "jobs.*.plan[attempts=2]", "jobs[name=deploy].plan[2]"),
+		Entry("filters by boolean value", "jobs.*.plan[trigger=true]", "jobs[name=unit].plan[0]"),
+		Entry("multiple filters", "jobs.*.plan[get][passed]", "jobs[name=deploy].plan[0]"),
+		Entry("descendants", "jobs[name=deploy].plan.**[get]",
+			"jobs[name=deploy].plan[0]",
+			"jobs[name=deploy].plan[1].in_parallel.steps[1]",
+		),
+		Entry("descendants by value", "**[task][privileged=true].task",
+			"jobs[name=deploy].plan[1].in_parallel.steps[0].task",
+		),
+	)
+
+	DescribeTable("invalid selectors",
+		func(source string) {
+			_, err := pipelinetest.ParseSelector(source)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty segments", "jobs..plan"),
+		Entry("unterminated filters", "jobs[name=deploy"),
+		Entry("unopened filters", "jobs]"),
+		Entry("empty filters", "jobs[]"),
+		Entry("filters without a key", "jobs[=deploy]"),
+		Entry("trailing characters", "jobs[0]x"),
+	)
+})
@@ -0,0 +1,221 @@
+package pipelinetest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	InConfig = "config"
+	InPlan   = "plan"
+)
+
+type Suite struct {
+	Assertions []Assertion `json:"assertions"`
+}
+
+// Assertion selects nodes from either the pipeline config or the build plans
+// of its jobs and checks them. Every check that is specified must pass.
+type Assertion struct {
+	Name string `json:"name"`
+
+	// In is the tree to select from: 'config' (the default) for the pipeline
+	// config, or 'plan' for a map of job names to the plans their builds
+	// would run.
+	In     string `json:"in,omitempty"`
+	Select string `json:"select"`
+
+	Count    *int `json:"count,omitempty"`
+	MinCount *int `json:"min_count,omitempty"`
+	MaxCount *int `json:"max_count,omitempty"`
+
+	// Has lists fields every selected node must have.
+	Has []string `json:"has,omitempty"`
+
+	// Match is compared against every selected node. Maps match if the node
+	// has all of their fields with matching values, and any other value
+	// must be equal.
+	Match map[string]interface{} `json:"match,omitempty"`
+
+	selector Selector
+}
+
+type Result struct {
+	Assertion Assertion
+	Failures  []string
+}
+
+func (result Result) Passed() bool {
+	return len(result.Failures) == 0
+}
+
+func ParseSuite(payload []byte) (Suite, error) {
+	var suite Suite
+	err := yaml.UnmarshalStrict(payload, &suite)
+	if err != nil {
+		return Suite{}, err
+	}
+
+	if len(suite.Assertions) == 0 {
+		return Suite{}, errors.New("no assertions defined")
+	}
+
+	for i, assertion := range suite.Assertions {
+		if assertion.Name == "" {
+			return Suite{}, fmt.Errorf("assertions[%d]: missing name", i)
+		}
+
+		switch assertion.In {
+		case "":
+			assertion.In = InConfig
+		case InConfig, InPlan:
+		default:
+			return Suite{}, fmt.Errorf("assertion '%s': unknown tree '%s', must be '%s' or '%s'", assertion.Name, assertion.In, InConfig, InPlan)
+		}
+
+		assertion.selector, err = ParseSelector(assertion.Select)
+		if err != nil {
+			return Suite{}, fmt.Errorf("assertion '%s': %w", assertion.Name, err)
+		}
+
+		suite.Assertions[i] = assertion
+	}
+
+	return suite, nil
+}
+
+func (suite Suite) Run(trees Trees) []Result {
+	var results []Result
+	for _, assertion := range suite.Assertions {
+		results = append(results, assertion.Run(trees))
+	}
+
+	return results
+}
+
+func (assertion Assertion) Run(trees Trees) Result {
+	root := trees.Config
+	if assertion.In == InPlan {
+		root = trees.Plans
+	}
+
+	nodes := assertion.selector.Select(root)
+
+	result := Result{Assertion: assertion}
+	fail := func(format string, args ...interface{}) {
+		result.Failures = append(result.Failures, fmt.Sprintf(format, args...))
+	}
+
+	if assertion.Count != nil && len(nodes) != *assertion.Count {
+		fail("%s: expected %d matches, found %d%s", assertion.selector, *assertion.Count, len(nodes), paths(nodes))
+	}
+
+	if assertion.MinCount != nil && len(nodes) < *assertion.MinCount {
+		fail("%s: expected at least %d matches, found %d", assertion.selector, *assertion.MinCount, len(nodes))
+	}
+
+	if assertion.MaxCount != nil && len(nodes) > *assertion.MaxCount {
+		fail("%s: expected at most %d matches, found %d%s", assertion.selector, *assertion.MaxCount, len(nodes), paths(nodes))
+	}
+
+	for _, node := range nodes {
+		for _, field := range assertion.Has {
+			object, ok := node.Value.(map[string]interface{})
+			if !ok {
+				fail("%s: expected a map with '%s', found %s", node.Path, field, present(node.Value))
+				break
+			}
+
+			if _, found := object[field]; !found {
+				fail("%s: missing '%s'", node.Path, field)
+			}
+		}
+
+		if assertion.Match != nil {
+			result.Failures = append(result.Failures, match(node.Path, normalize(assertion.Match), node.Value)...)
+		}
+	}
+
+	return result
+}
+
+func match(path string, expected interface{}, actual interface{}) []string {
+	expectedObject, ok := expected.(map[string]interface{})
+	if !ok {
+		if !reflect.DeepEqual(expected, actual) {
+			return []string{fmt.Sprintf("%s: expected %s, found %s", path, present(expected), present(actual))}
+		}
+
+		return nil
+	}
+
+	actualObject, ok := actual.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%s: expected a map, found %s", path, present(actual))}
+	}
+
+	keys := make([]string, 0, len(expectedObject))
+	for key := range expectedObject {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var failures []string
+	for _, key := range keys {
+		value, found := actualObject[key]
+		if !found {
+			failures = append(failures, fmt.Sprintf("%s: missing '%s'", path, key))
+			continue
+		}
+
+		failures = append(failures, match(keyPath(path, key), expectedObject[key], value)...)
+	}
+
+	return failures
+}
+
+func paths(nodes []Node) string {
+	if len(nodes) == 0 {
+		return ""
+	}
+
+	list := make([]string, len(nodes))
+	for i, node := range nodes {
+		list[i] = node.Path
+	}
+
+	return " at " + strings.Join(list, ", ")
+}
+
+// normalize round-trips a value through JSON so that it can be compared with
+// the values of the trees.
+func normalize(value interface{}) interface{} {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var normalized interface{}
+	err = json.Unmarshal(payload, &normalized)
+	if err != nil {
+		return value
+	}
+
+	return normalized
+}
+
+func present(value interface{}) string {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(payload)
+}
@@ -0,0 +1,197 @@
+package pipelinetest_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/pipelinetest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Suite", func() {
+	var (
+		config atc.Config
+		trees  pipelinetest.Trees
+	)
+
+	BeforeEach(func() {
+		config = atc.Config{
+			Resources: atc.ResourceConfigs{
+				{Name: "repo", Type: "git", Source: atc.Source{"uri": "https://example.com/repo.git"}},
+				{Name: "image", Type: "registry-image", Source: atc.Source{"repository": "busybox"}},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "unit",
+					PlanSequence: []atc.Step{
+						{Config: &atc.GetStep{Name: "repo", Trigger: true}},
+						{Config: &atc.TaskStep{Name: "test", ConfigPath: "repo/ci/test.yml"}},
+					},
+				},
+				{
+					Name: "deploy",
+					PlanSequence: []atc.Step{
+						{Config: &atc.GetStep{Name: "repo", Passed: []string{"unit"}}},
+						{Config: &atc.TaskStep{Name: "build", Privileged: true, ConfigPath: "repo/ci/build.yml"}},
+						{Config: &atc.PutStep{Name: "image"}},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		trees, err = pipelinetest.NewTrees(config)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	run := func(assertions string) []pipelinetest.Result {
+		suite, err := pipelinetest.ParseSuite([]byte(assertions))
+		Expect(err).ToNot(HaveOccurred())
+
+		return suite.Run(trees)
+	}
+
+	failures := func(results []pipelinetest.Result) []string {
+		all := []string{}
+		for _, result := range results {
+			all = append(all, result.Failures...)
+		}
+
+		return all
+	}
+
+	Describe("ParseSuite", func() {
+		It("requires assertions", func() {
+			_, err := pipelinetest.ParseSuite([]byte(`assertions: []`))
+			Expect(err).To(MatchError("no assertions defined"))
+		})
+
+		It("requires a name", func() {
+			_, err := pipelinetest.ParseSuite([]byte(`assertions: [{select: jobs}]`))
+			Expect(err).To(MatchError("assertions[0]: missing name"))
+		})
+
+		It("rejects unknown trees", func() {
+			_, err := pipelinetest.ParseSuite([]byte(`assertions: [{name: a, in: builds, select: jobs}]`))
+			Expect(err).To(MatchError(ContainSubstring("unknown tree 'builds'")))
+		})
+
+		It("rejects invalid selectors", func() {
+			_, err := pipelinetest.ParseSuite([]byte(`assertions: [{name: a, select: "jobs["}]`))
+			Expect(err).To(MatchError(ContainSubstring("invalid selector 'jobs['")))
+		})
+
+		It("rejects unknown fields", func() {
+			_, err := pipelinetest.ParseSuite([]byte(`assertions: [{name: a, select: jobs, equals: 1}]`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("counts", func() {
+		It("passes when the number of matches is as expected", func() {
+			results := run(`
+assertions:
+- name: one deploy
+  select: jobs[name=deploy]
+  count: 1
+  min_count: 1
+  max_count: 1
+`)
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Passed()).To(BeTrue())
+		})
+
+		It("fails with the matching paths", func() {
+			results := run(`
+assertions:
+- name: no privileged tasks
+  select: jobs.*.plan.**[privileged=true]
+  count: 0
+- name: two puts
+  select: jobs.*.plan.**[put]
+  min_count: 2
+`)
+			Expect(failures(results)).To(Equal([]string{
+				"jobs.*.plan.**[privileged=true]: expected 0 matches, found 1 at jobs[name=deploy].plan[1]",
+				"jobs.*.plan.**[put]: expected at least 2 matches, found 1",
+			}))
+		})
+	})
+
+	Describe("has", func() {
+		It("fails for every node missing a field", func() {
+			results := run(`
+assertions:
+- name: every put has an ensure
+  select: jobs.*.plan.**[put]
+  has: [ensure]
+`)
+			Expect(failures(results)).To(Equal([]string{
+				"jobs[name=deploy].plan[2]: missing 'ensure'",
+			}))
+		})
+	})
+
+	Describe("match", func() {
+		It("passes when every node has the fields", func() {
+			results := run(`
+assertions:
+- name: deploy gets repo after unit
+  select: jobs[name=deploy].plan.**[get=repo]
+  match:
+    passed: [unit]
+`)
+			Expect(failures(results)).To(BeEmpty())
+		})
+
+		It("fails with the paths of mismatched fields", func() {
+			results := run(`
+assertions:
+- name: repos are triggered after unit
+  select: jobs.*.plan.**[get=repo]
+  match:
+    passed: [unit]
+    trigger: true
+`)
+			Expect(failures(results)).To(Equal([]string{
+				"jobs[name=unit].plan[0]: missing 'passed'",
+				`jobs[name=deploy].plan[0]: missing 'trigger'`,
+			}))
+		})
+	})
+
+	Describe("plans", func() {
+		It("selects from the plans of each job", func() {
+			results := run(`
+assertions:
+- name: deploy puts and then gets the image
+  in: plan
+  select: deploy.do[2].on_success
+  match:
+    step: {put: {resource: image, type: registry-image}}
+    on_success: {get: {resource: image, type: registry-image}}
+- name: repo is fetched with its source
+  in: plan
+  select: "*.**.get[resource=repo]"
+  count: 2
+  match:
+    source: {uri: https://example.com/repo.git}
+    version: {version: placeholder}
+`)
+			Expect(failures(results)).To(BeEmpty())
+		})
+
+		Context("when a job cannot be planned", func() {
+			It("errors", func() {
+				config.Jobs[0].PlanSequence = append(config.Jobs[0].PlanSequence, atc.Step{
+					Config: &atc.PutStep{Name: "bogus"},
+				})
+
+				_, err := pipelinetest.NewTrees(config)
+				Expect(err).To(MatchError(ContainSubstring("failed to plan job 'unit'")))
+			})
+		})
+	})
+})
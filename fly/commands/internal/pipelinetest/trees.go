@@ -0,0 +1,100 @@
+package pipelinetest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/builds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// placeholderVersion is the version every get step is planned with, as the
+// versions a build would actually fetch can only be known by the ATC.
+var placeholderVersion = atc.Version{"version": "placeholder"}
+
+// Trees are the documents assertions are run against, as decoded from JSON.
+type Trees struct {
+	Config interface{}
+	Plans  interface{}
+}
+
+func NewTrees(config atc.Config) (Trees, error) {
+	plans, err := Plans(config)
+	if err != nil {
+		return Trees{}, err
+	}
+
+	configTree, err := decode(config)
+	if err != nil {
+		return Trees{}, err
+	}
+
+	plansTree, err := decode(plans)
+	if err != nil {
+		return Trees{}, err
+	}
+
+	return Trees{
+		Config: configTree,
+		Plans:  plansTree,
+	}, nil
+}
+
+// Plans builds the plan of every job the same way the scheduler does when
+// starting a build.
+func Plans(config atc.Config) (map[string]atc.Plan, error) {
+	var resources db.SchedulerResources
+	for _, resource := range config.Resources {
+		resources = append(resources, db.SchedulerResource{
+			Name:                 resource.Name,
+			Type:                 resource.Type,
+			Source:               resource.Source,
+			ExposeBuildCreatedBy: resource.ExposeBuildCreatedBy,
+		})
+	}
+
+	var resourceTypes atc.VersionedResourceTypes
+	for _, resourceType := range config.ResourceTypes {
+		resourceTypes = append(resourceTypes, atc.VersionedResourceType{
+			ResourceType: resourceType,
+		})
+	}
+
+	planner := builds.NewPlanner(atc.NewPlanFactory(0))
+
+	plans := map[string]atc.Plan{}
+	for _, job := range config.Jobs {
+		var inputs []db.BuildInput
+		for _, input := range job.Inputs() {
+			inputs = append(inputs, db.BuildInput{
+				Name:    input.Name,
+				Version: placeholderVersion,
+			})
+		}
+
+		plan, err := planner.Create(job.StepConfig(), resources, resourceTypes, config.Prototypes, inputs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plan job '%s': %w", job.Name, err)
+		}
+
+		plans[job.Name] = plan
+	}
+
+	return plans, nil
+}
+
+func decode(value interface{}) (interface{}, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var tree interface{}
+	err = json.Unmarshal(payload, &tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
@@ -0,0 +1,90 @@
+package teamarchive
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// Version is the version of the archive format written by Export. Archives
+// written by a newer fly are refused by Read so that state is never silently
+// dropped.
+//
+// Version 2 added the container limits and notifiers of the team.
+const Version = 2
+
+type Archive struct {
+	Version    int        `json:"version"`
+	ExportedAt int64      `json:"exported_at"`
+	Team       Team       `json:"team"`
+	Pipelines  []Pipeline `json:"pipelines"`
+}
+
+type Team struct {
+	Name string       `json:"name"`
+	Auth atc.TeamAuth `json:"auth,omitempty"`
+
+	ContainerLimits *atc.ContainerLimits `json:"container_limits,omitempty"`
+	Notifiers       atc.NotifierConfigs  `json:"notifiers,omitempty"`
+}
+
+// Pipeline captures the state of a pipeline that is not part of its config.
+// Pipelines are listed in the order they are shown in the UI.
+type Pipeline struct {
+	Name         string           `json:"name"`
+	InstanceVars atc.InstanceVars `json:"instance_vars,omitempty"`
+	Paused       bool             `json:"paused"`
+	Public       bool             `json:"public"`
+	Archived     bool             `json:"archived"`
+
+	// Config is nil for archived pipelines, whose config is discarded when
+	// they are archived.
+	Config *atc.Config `json:"config,omitempty"`
+
+	PausedJobs []string   `json:"paused_jobs,omitempty"`
+	Resources  []Resource `json:"resources,omitempty"`
+
+	// Builds is only populated when build history was requested. It is kept
+	// for reference and is not replayed by Import.
+	Builds []atc.Build `json:"builds,omitempty"`
+}
+
+func (pipeline Pipeline) Ref() atc.PipelineRef {
+	return atc.PipelineRef{
+		Name:         pipeline.Name,
+		InstanceVars: pipeline.InstanceVars,
+	}
+}
+
+type Resource struct {
+	Name             string        `json:"name"`
+	PinnedVersion    atc.Version   `json:"pinned_version,omitempty"`
+	PinComment       string        `json:"pin_comment,omitempty"`
+	DisabledVersions []atc.Version `json:"disabled_versions,omitempty"`
+}
+
+func Write(w io.Writer, archive Archive) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(archive)
+}
+
+func Read(r io.Reader) (Archive, error) {
+	var archive Archive
+	err := json.NewDecoder(r).Decode(&archive)
+	if err != nil {
+		return Archive{}, fmt.Errorf("invalid team archive: %w", err)
+	}
+
+	if archive.Version == 0 {
+		return Archive{}, fmt.Errorf("invalid team archive: missing version")
+	}
+
+	if archive.Version > Version {
+		return Archive{}, fmt.Errorf("team archive version %d is not supported by this fly (supports up to %d), please upgrade fly", archive.Version, Version)
+	}
+
+	return archive, nil
+}
@@ -0,0 +1,78 @@
+package teamarchive_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/fly/commands/internal/teamarchive"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Archive", func() {
+	It("round-trips through Write and Read", func() {
+		cpu := atc.CPULimit(512)
+
+		archive := Archive{
+			Version:    Version,
+			ExportedAt: 1234,
+			Team: Team{
+				Name:            "some-team",
+				Auth:            atc.TeamAuth{"owner": {"users": []string{"local:some-user"}}},
+				ContainerLimits: &atc.ContainerLimits{CPU: &cpu},
+				Notifiers: atc.NotifierConfigs{{
+					Name: "chat",
+					Type: atc.NotifierTypeSlack,
+					URL:  "https://hooks.example.com/chat",
+				}},
+			},
+			Pipelines: []Pipeline{
+				{
+					Name:         "some-pipeline",
+					InstanceVars: atc.InstanceVars{"branch": "master"},
+					Paused:       true,
+					Config:       &atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}},
+					PausedJobs:   []string{"some-job"},
+					Resources: []Resource{
+						{
+							Name:             "some-resource",
+							PinnedVersion:    atc.Version{"ref": "v1"},
+							PinComment:       "hold on",
+							DisabledVersions: []atc.Version{{"ref": "v2"}},
+						},
+					},
+				},
+			},
+		}
+
+		buf := new(bytes.Buffer)
+		Expect(Write(buf, archive)).To(Succeed())
+
+		read, err := Read(buf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(read).To(Equal(archive))
+	})
+
+	It("reads archives of older versions", func() {
+		archive, err := Read(strings.NewReader(`{"version":1,"team":{"name":"some-team"}}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(archive.Team).To(Equal(Team{Name: "some-team"}))
+	})
+
+	It("refuses archives without a version", func() {
+		_, err := Read(strings.NewReader(`{"pipelines":[]}`))
+		Expect(err).To(MatchError("invalid team archive: missing version"))
+	})
+
+	It("refuses archives written by a newer fly", func() {
+		_, err := Read(strings.NewReader(`{"version":99}`))
+		Expect(err).To(MatchError(ContainSubstring("team archive version 99 is not supported")))
+	})
+
+	It("refuses invalid archives", func() {
+		_, err := Read(strings.NewReader(`nope`))
+		Expect(err).To(MatchError(ContainSubstring("invalid team archive")))
+	})
+})
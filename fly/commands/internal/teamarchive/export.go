@@ -0,0 +1,145 @@
+package teamarchive
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+const versionsPageLimit = 100
+
+// Export collects the state of every pipeline of the team. When builds is
+// greater than zero, the metadata of up to that many of the most recent
+// builds of each job is included as well.
+func Export(team concourse.Team, builds int) (Archive, error) {
+	atcTeam := team.ATCTeam()
+
+	archive := Archive{
+		Version:    Version,
+		ExportedAt: time.Now().Unix(),
+		Team: Team{
+			Name:            team.Name(),
+			Auth:            team.Auth(),
+			ContainerLimits: atcTeam.ContainerLimits,
+			Notifiers:       atcTeam.Notifiers,
+		},
+		Pipelines: []Pipeline{},
+	}
+
+	pipelines, err := team.ListPipelines()
+	if err != nil {
+		return Archive{}, err
+	}
+
+	for _, p := range pipelines {
+		pipeline, err := exportPipeline(team, p, builds)
+		if err != nil {
+			return Archive{}, fmt.Errorf("failed to export pipeline '%s': %w", p.Ref(), err)
+		}
+
+		archive.Pipelines = append(archive.Pipelines, pipeline)
+	}
+
+	return archive, nil
+}
+
+func exportPipeline(team concourse.Team, p atc.Pipeline, builds int) (Pipeline, error) {
+	pipeline := Pipeline{
+		Name:         p.Name,
+		InstanceVars: p.InstanceVars,
+		Paused:       p.Paused,
+		Public:       p.Public,
+		Archived:     p.Archived,
+	}
+
+	if p.Archived {
+		return pipeline, nil
+	}
+
+	ref := p.Ref()
+
+	config, _, found, err := team.PipelineConfig(ref)
+	if err != nil {
+		return Pipeline{}, err
+	}
+
+	if !found {
+		return Pipeline{}, fmt.Errorf("pipeline not found")
+	}
+
+	pipeline.Config = &config
+
+	jobs, err := team.ListJobs(ref)
+	if err != nil {
+		return Pipeline{}, err
+	}
+
+	for _, job := range jobs {
+		if job.Paused {
+			pipeline.PausedJobs = append(pipeline.PausedJobs, job.Name)
+		}
+
+		if builds > 0 {
+			jobBuilds, _, _, err := team.JobBuilds(ref, job.Name, concourse.Page{Limit: builds})
+			if err != nil {
+				return Pipeline{}, err
+			}
+
+			pipeline.Builds = append(pipeline.Builds, jobBuilds...)
+		}
+	}
+
+	resources, err := team.ListResources(ref)
+	if err != nil {
+		return Pipeline{}, err
+	}
+
+	for _, r := range resources {
+		resource := Resource{Name: r.Name}
+
+		// versions pinned through the config are restored along with it
+		if !r.PinnedInConfig {
+			resource.PinnedVersion = r.PinnedVersion
+			resource.PinComment = r.PinComment
+		}
+
+		resource.DisabledVersions, err = disabledVersions(team, ref, r.Name)
+		if err != nil {
+			return Pipeline{}, err
+		}
+
+		if resource.PinnedVersion == nil && len(resource.DisabledVersions) == 0 {
+			continue
+		}
+
+		pipeline.Resources = append(pipeline.Resources, resource)
+	}
+
+	return pipeline, nil
+}
+
+func disabledVersions(team concourse.Team, ref atc.PipelineRef, resourceName string) ([]atc.Version, error) {
+	var disabled []atc.Version
+
+	page := concourse.Page{Limit: versionsPageLimit}
+	for {
+		versions, pagination, _, err := team.ResourceVersions(ref, resourceName, page, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, version := range versions {
+			if !version.Enabled {
+				disabled = append(disabled, version.Version)
+			}
+		}
+
+		if pagination.Next == nil {
+			return disabled, nil
+		}
+
+		page = *pagination.Next
+	}
+}
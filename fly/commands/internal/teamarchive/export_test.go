@@ -0,0 +1,164 @@
+package teamarchive_test
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/fly/commands/internal/teamarchive"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/concourse/concourse/go-concourse/concourse/concoursefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Export", func() {
+	var (
+		fakeTeam *concoursefakes.FakeTeam
+		builds   int
+
+		archive   Archive
+		exportErr error
+	)
+
+	BeforeEach(func() {
+		fakeTeam = new(concoursefakes.FakeTeam)
+		fakeTeam.NameReturns("some-team")
+		fakeTeam.AuthReturns(atc.TeamAuth{"owner": {"users": []string{"local:some-user"}}})
+
+		cpu := atc.CPULimit(512)
+		fakeTeam.ATCTeamReturns(atc.Team{
+			Name:            "some-team",
+			Auth:            atc.TeamAuth{"owner": {"users": []string{"local:some-user"}}},
+			ContainerLimits: &atc.ContainerLimits{CPU: &cpu},
+			Notifiers: atc.NotifierConfigs{{
+				Name: "chat",
+				Type: atc.NotifierTypeSlack,
+				URL:  "https://hooks.example.com/chat",
+			}},
+		})
+
+		fakeTeam.ListPipelinesReturns([]atc.Pipeline{
+			{Name: "some-pipeline", Paused: true, Public: true},
+			{Name: "old-pipeline", InstanceVars: atc.InstanceVars{"branch": "old"}, Archived: true, Paused: true},
+		}, nil)
+
+		fakeTeam.PipelineConfigReturns(atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}, {Name: "other-job"}}}, "1", true, nil)
+		fakeTeam.ListJobsReturns([]atc.Job{
+			{Name: "some-job", Paused: true},
+			{Name: "other-job"},
+		}, nil)
+
+		fakeTeam.ListResourcesReturns([]atc.Resource{
+			{Name: "pinned-resource", PinnedVersion: atc.Version{"ref": "v1"}, PinComment: "hold on"},
+			{Name: "config-pinned-resource", PinnedVersion: atc.Version{"ref": "v2"}, PinnedInConfig: true},
+			{Name: "plain-resource"},
+		}, nil)
+
+		fakeTeam.ResourceVersionsStub = func(_ atc.PipelineRef, resourceName string, page concourse.Page, _ atc.Version) ([]atc.ResourceVersion, concourse.Pagination, bool, error) {
+			if resourceName != "pinned-resource" {
+				return []atc.ResourceVersion{{ID: 1, Version: atc.Version{"ref": "v1"}, Enabled: true}}, concourse.Pagination{}, true, nil
+			}
+
+			if page.To == 0 {
+				return []atc.ResourceVersion{
+					{ID: 4, Version: atc.Version{"ref": "v4"}, Enabled: false},
+					{ID: 3, Version: atc.Version{"ref": "v3"}, Enabled: true},
+				}, concourse.Pagination{
+					Next: &concourse.Page{To: 2, Limit: page.Limit},
+				}, true, nil
+			}
+
+			return []atc.ResourceVersion{
+				{ID: 2, Version: atc.Version{"ref": "v2"}, Enabled: false},
+			}, concourse.Pagination{}, true, nil
+		}
+
+		builds = 0
+	})
+
+	JustBeforeEach(func() {
+		archive, exportErr = Export(fakeTeam, builds)
+	})
+
+	It("captures the team and the state of its pipelines", func() {
+		Expect(exportErr).ToNot(HaveOccurred())
+		Expect(archive.Version).To(Equal(Version))
+		cpu := atc.CPULimit(512)
+		Expect(archive.Team).To(Equal(Team{
+			Name:            "some-team",
+			Auth:            atc.TeamAuth{"owner": {"users": []string{"local:some-user"}}},
+			ContainerLimits: &atc.ContainerLimits{CPU: &cpu},
+			Notifiers: atc.NotifierConfigs{{
+				Name: "chat",
+				Type: atc.NotifierTypeSlack,
+				URL:  "https://hooks.example.com/chat",
+			}},
+		}))
+
+		Expect(archive.Pipelines).To(Equal([]Pipeline{
+			{
+				Name:       "some-pipeline",
+				Paused:     true,
+				Public:     true,
+				Config:     &atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}, {Name: "other-job"}}},
+				PausedJobs: []string{"some-job"},
+				Resources: []Resource{
+					{
+						Name:             "pinned-resource",
+						PinnedVersion:    atc.Version{"ref": "v1"},
+						PinComment:       "hold on",
+						DisabledVersions: []atc.Version{{"ref": "v4"}, {"ref": "v2"}},
+					},
+				},
+			},
+			{
+				Name:         "old-pipeline",
+				InstanceVars: atc.InstanceVars{"branch": "old"},
+				Paused:       true,
+				Archived:     true,
+			},
+		}))
+	})
+
+	It("does not fetch the config of archived pipelines", func() {
+		Expect(fakeTeam.PipelineConfigCallCount()).To(Equal(1))
+		Expect(fakeTeam.PipelineConfigArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+	})
+
+	It("does not include build history", func() {
+		Expect(fakeTeam.JobBuildsCallCount()).To(BeZero())
+	})
+
+	Context("when build history is requested", func() {
+		BeforeEach(func() {
+			builds = 2
+			fakeTeam.JobBuildsStub = func(_ atc.PipelineRef, jobName string, _ concourse.Page) ([]atc.Build, concourse.Pagination, bool, error) {
+				return []atc.Build{{ID: len(jobName), JobName: jobName}}, concourse.Pagination{}, true, nil
+			}
+		})
+
+		It("includes the most recent builds of each job", func() {
+			Expect(exportErr).ToNot(HaveOccurred())
+			Expect(fakeTeam.JobBuildsCallCount()).To(Equal(2))
+
+			_, _, page := fakeTeam.JobBuildsArgsForCall(0)
+			Expect(page).To(Equal(concourse.Page{Limit: 2}))
+
+			Expect(archive.Pipelines[0].Builds).To(Equal([]atc.Build{
+				{ID: 8, JobName: "some-job"},
+				{ID: 9, JobName: "other-job"},
+			}))
+		})
+	})
+
+	Context("when fetching a pipeline's config fails", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelineConfigReturns(atc.Config{}, "", false, errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(exportErr).To(MatchError("failed to export pipeline 'some-pipeline': nope"))
+		})
+	})
+})
@@ -0,0 +1,287 @@
+package teamarchive
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+// archivedPipelineConfig is set for archived pipelines before archiving them
+// again, as a pipeline can only be created with at least one job and
+// archiving discards the config anyway.
+const archivedPipelineConfig = "jobs:\n- name: archived\n  plan: []\n"
+
+const defaultPollInterval = time.Second
+
+type Importer struct {
+	Team concourse.Team
+
+	// VersionTimeout is how long to wait for a resource version to be
+	// checked before giving up on pinning or disabling it.
+	VersionTimeout time.Duration
+	PollInterval   time.Duration
+
+	Log func(format string, args ...interface{})
+}
+
+// Import sets every pipeline in the archive and restores its state. Problems
+// restoring the state of individual resources, e.g. a version that no longer
+// exists upstream, do not stop the import and are returned as warnings.
+func (importer Importer) Import(archive Archive) ([]string, error) {
+	var warnings []string
+
+	for _, pipeline := range archive.Pipelines {
+		pipelineWarnings, err := importer.importPipeline(pipeline)
+		if err != nil {
+			return warnings, fmt.Errorf("failed to import pipeline '%s': %w", pipeline.Ref(), err)
+		}
+
+		warnings = append(warnings, pipelineWarnings...)
+	}
+
+	err := importer.orderPipelines(archive.Pipelines)
+	if err != nil {
+		return warnings, fmt.Errorf("failed to order pipelines: %w", err)
+	}
+
+	return warnings, nil
+}
+
+func (importer Importer) importPipeline(pipeline Pipeline) ([]string, error) {
+	ref := pipeline.Ref()
+	team := importer.Team
+
+	var configBytes []byte
+	if pipeline.Archived || pipeline.Config == nil {
+		configBytes = []byte(archivedPipelineConfig)
+	} else {
+		var err error
+		configBytes, err = yaml.Marshal(pipeline.Config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, existingConfigVersion, _, err := team.PipelineConfig(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, _, err = team.CreateOrUpdatePipelineConfig(ref, existingConfigVersion, configBytes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	importer.log("set pipeline %s\n", ref)
+
+	if pipeline.Archived {
+		_, err = team.ArchivePipeline(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		importer.log("  archived\n")
+
+		return nil, nil
+	}
+
+	if pipeline.Public {
+		_, err = team.ExposePipeline(ref)
+	} else {
+		_, err = team.HidePipeline(ref)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, job := range pipeline.PausedJobs {
+		_, err = team.PauseJob(ref, job)
+		if err != nil {
+			return nil, err
+		}
+
+		importer.log("  paused job %s\n", job)
+	}
+
+	var warnings []string
+	for _, resource := range pipeline.Resources {
+		warnings = append(warnings, importer.importResource(ref, resource)...)
+	}
+
+	if pipeline.Paused {
+		_, err = team.PausePipeline(ref)
+	} else {
+		_, err = team.UnpausePipeline(ref)
+	}
+	if err != nil {
+		return warnings, err
+	}
+
+	return warnings, nil
+}
+
+func (importer Importer) importResource(ref atc.PipelineRef, resource Resource) []string {
+	team := importer.Team
+
+	var warnings []string
+	warn := func(format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf("%s/%s: ", ref, resource.Name)+fmt.Sprintf(format, args...))
+	}
+
+	for _, version := range resource.DisabledVersions {
+		versionID, err := importer.resourceVersionID(ref, resource.Name, version)
+		if err != nil {
+			warn("could not disable version %s: %s", versionString(version), err)
+			continue
+		}
+
+		_, err = team.DisableResourceVersion(ref, resource.Name, versionID)
+		if err != nil {
+			warn("could not disable version %s: %s", versionString(version), err)
+			continue
+		}
+
+		importer.log("  disabled %s version %s\n", resource.Name, versionString(version))
+	}
+
+	if resource.PinnedVersion == nil {
+		return warnings
+	}
+
+	versionID, err := importer.resourceVersionID(ref, resource.Name, resource.PinnedVersion)
+	if err != nil {
+		warn("could not pin version %s: %s", versionString(resource.PinnedVersion), err)
+		return warnings
+	}
+
+	_, err = team.PinResourceVersion(ref, resource.Name, versionID)
+	if err != nil {
+		warn("could not pin version %s: %s", versionString(resource.PinnedVersion), err)
+		return warnings
+	}
+
+	importer.log("  pinned %s to version %s\n", resource.Name, versionString(resource.PinnedVersion))
+
+	if resource.PinComment != "" {
+		_, err = team.SetPinComment(ref, resource.Name, resource.PinComment)
+		if err != nil {
+			warn("could not save pin comment: %s", err)
+		}
+	}
+
+	return warnings
+}
+
+// resourceVersionID finds the ID of the given version on the target. Versions
+// are only known once they have been checked, so if the version is missing a
+// check is run from it and its result is waited for.
+func (importer Importer) resourceVersionID(ref atc.PipelineRef, resourceName string, version atc.Version) (int, error) {
+	versionID, found, err := importer.findResourceVersion(ref, resourceName, version)
+	if err != nil {
+		return 0, err
+	}
+
+	if found {
+		return versionID, nil
+	}
+
+	_, found, err = importer.Team.CheckResource(ref, resourceName, version)
+	if err != nil {
+		return 0, err
+	}
+
+	if !found {
+		return 0, fmt.Errorf("resource not found")
+	}
+
+	pollInterval := importer.PollInterval
+	if pollInterval == 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	deadline := time.Now().Add(importer.VersionTimeout)
+	for {
+		versionID, found, err := importer.findResourceVersion(ref, resourceName, version)
+		if err != nil {
+			return 0, err
+		}
+
+		if found {
+			return versionID, nil
+		}
+
+		if time.Now().After(deadline) {
+			return 0, fmt.Errorf("timed out waiting for the version to be checked")
+		}
+
+		time.Sleep(pollInterval)
+	}
+}
+
+func (importer Importer) findResourceVersion(ref atc.PipelineRef, resourceName string, version atc.Version) (int, bool, error) {
+	versions, _, found, err := importer.Team.ResourceVersions(ref, resourceName, concourse.Page{Limit: 1}, version)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if !found || len(versions) == 0 {
+		return 0, false, nil
+	}
+
+	return versions[0].ID, true, nil
+}
+
+func (importer Importer) orderPipelines(pipelines []Pipeline) error {
+	var names []string
+	instanceVars := map[string][]atc.InstanceVars{}
+
+	for _, pipeline := range pipelines {
+		if _, seen := instanceVars[pipeline.Name]; !seen {
+			names = append(names, pipeline.Name)
+		}
+
+		instanceVars[pipeline.Name] = append(instanceVars[pipeline.Name], pipeline.InstanceVars)
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	err := importer.Team.OrderingPipelines(names)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if len(instanceVars[name]) < 2 {
+			continue
+		}
+
+		err = importer.Team.OrderingPipelinesWithinGroup(name, instanceVars[name])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (importer Importer) log(format string, args ...interface{}) {
+	if importer.Log != nil {
+		importer.Log(format, args...)
+	}
+}
+
+func versionString(version atc.Version) string {
+	versionBytes, err := json.Marshal(version)
+	if err != nil {
+		return fmt.Sprintf("%v", version)
+	}
+
+	return string(versionBytes)
+}
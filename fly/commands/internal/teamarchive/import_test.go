@@ -0,0 +1,209 @@
+package teamarchive_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/fly/commands/internal/teamarchive"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/concourse/concourse/go-concourse/concourse/concoursefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Importer", func() {
+	var (
+		fakeTeam *concoursefakes.FakeTeam
+		archive  Archive
+
+		warnings  []string
+		importErr error
+	)
+
+	BeforeEach(func() {
+		fakeTeam = new(concoursefakes.FakeTeam)
+		fakeTeam.PipelineConfigReturns(atc.Config{}, "", false, nil)
+		fakeTeam.CreateOrUpdatePipelineConfigReturns(true, false, nil, nil)
+
+		archive = Archive{
+			Version: Version,
+			Pipelines: []Pipeline{
+				{
+					Name:       "some-pipeline",
+					Paused:     true,
+					Public:     true,
+					Config:     &atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}},
+					PausedJobs: []string{"some-job"},
+				},
+				{
+					Name:         "instanced",
+					InstanceVars: atc.InstanceVars{"branch": "b"},
+					Config:       &atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}},
+				},
+				{
+					Name:         "instanced",
+					InstanceVars: atc.InstanceVars{"branch": "a"},
+					Archived:     true,
+					Paused:       true,
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		importer := Importer{
+			Team:           fakeTeam,
+			VersionTimeout: 50 * time.Millisecond,
+			PollInterval:   time.Millisecond,
+		}
+
+		warnings, importErr = importer.Import(archive)
+	})
+
+	It("sets each pipeline", func() {
+		Expect(importErr).ToNot(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		Expect(fakeTeam.CreateOrUpdatePipelineConfigCallCount()).To(Equal(3))
+
+		ref, version, config, checkCredentials := fakeTeam.CreateOrUpdatePipelineConfigArgsForCall(0)
+		Expect(ref).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+		Expect(version).To(BeEmpty())
+		Expect(config).To(MatchYAML("jobs: [{name: some-job, plan: null}]"))
+		Expect(checkCredentials).To(BeFalse())
+
+		ref, _, _, _ = fakeTeam.CreateOrUpdatePipelineConfigArgsForCall(1)
+		Expect(ref).To(Equal(atc.PipelineRef{Name: "instanced", InstanceVars: atc.InstanceVars{"branch": "b"}}))
+	})
+
+	It("restores the pipelines' pause and visibility state", func() {
+		Expect(fakeTeam.PausePipelineCallCount()).To(Equal(1))
+		Expect(fakeTeam.PausePipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+		Expect(fakeTeam.ExposePipelineCallCount()).To(Equal(1))
+		Expect(fakeTeam.ExposePipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+
+		Expect(fakeTeam.UnpausePipelineCallCount()).To(Equal(1))
+		Expect(fakeTeam.UnpausePipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "instanced", InstanceVars: atc.InstanceVars{"branch": "b"}}))
+		Expect(fakeTeam.HidePipelineCallCount()).To(Equal(1))
+
+		Expect(fakeTeam.PauseJobCallCount()).To(Equal(1))
+		ref, job := fakeTeam.PauseJobArgsForCall(0)
+		Expect(ref).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+		Expect(job).To(Equal("some-job"))
+	})
+
+	It("recreates and archives archived pipelines", func() {
+		ref, _, config, _ := fakeTeam.CreateOrUpdatePipelineConfigArgsForCall(2)
+		Expect(ref).To(Equal(atc.PipelineRef{Name: "instanced", InstanceVars: atc.InstanceVars{"branch": "a"}}))
+		Expect(config).To(MatchYAML("jobs: [{name: archived, plan: []}]"))
+
+		Expect(fakeTeam.ArchivePipelineCallCount()).To(Equal(1))
+		Expect(fakeTeam.ArchivePipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "instanced", InstanceVars: atc.InstanceVars{"branch": "a"}}))
+	})
+
+	It("restores the ordering of pipelines and instance groups", func() {
+		Expect(fakeTeam.OrderingPipelinesCallCount()).To(Equal(1))
+		Expect(fakeTeam.OrderingPipelinesArgsForCall(0)).To(Equal([]string{"some-pipeline", "instanced"}))
+
+		Expect(fakeTeam.OrderingPipelinesWithinGroupCallCount()).To(Equal(1))
+		group, instanceVars := fakeTeam.OrderingPipelinesWithinGroupArgsForCall(0)
+		Expect(group).To(Equal("instanced"))
+		Expect(instanceVars).To(Equal([]atc.InstanceVars{{"branch": "b"}, {"branch": "a"}}))
+	})
+
+	Context("when the pipeline already exists", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelineConfigReturns(atc.Config{}, "42", true, nil)
+		})
+
+		It("updates it", func() {
+			_, version, _, _ := fakeTeam.CreateOrUpdatePipelineConfigArgsForCall(0)
+			Expect(version).To(Equal("42"))
+		})
+	})
+
+	Context("when resources have pinned and disabled versions", func() {
+		var checked bool
+
+		BeforeEach(func() {
+			archive.Pipelines = archive.Pipelines[:1]
+			archive.Pipelines[0].Resources = []Resource{
+				{
+					Name:             "some-resource",
+					PinnedVersion:    atc.Version{"ref": "v1"},
+					PinComment:       "hold on",
+					DisabledVersions: []atc.Version{{"ref": "v2"}},
+				},
+			}
+
+			checked = false
+			fakeTeam.CheckResourceStub = func(atc.PipelineRef, string, atc.Version) (atc.Build, bool, error) {
+				checked = true
+				return atc.Build{}, true, nil
+			}
+
+			fakeTeam.ResourceVersionsStub = func(_ atc.PipelineRef, _ string, _ concourse.Page, filter atc.Version) ([]atc.ResourceVersion, concourse.Pagination, bool, error) {
+				switch filter["ref"] {
+				case "v1":
+					return []atc.ResourceVersion{{ID: 1, Version: filter}}, concourse.Pagination{}, true, nil
+				case "v2":
+					if checked {
+						return []atc.ResourceVersion{{ID: 2, Version: filter}}, concourse.Pagination{}, true, nil
+					}
+				}
+
+				return nil, concourse.Pagination{}, true, nil
+			}
+		})
+
+		It("checks for missing versions before disabling them", func() {
+			Expect(warnings).To(BeEmpty())
+
+			Expect(fakeTeam.CheckResourceCallCount()).To(Equal(1))
+			_, resource, version := fakeTeam.CheckResourceArgsForCall(0)
+			Expect(resource).To(Equal("some-resource"))
+			Expect(version).To(Equal(atc.Version{"ref": "v2"}))
+
+			Expect(fakeTeam.DisableResourceVersionCallCount()).To(Equal(1))
+			_, resource, versionID := fakeTeam.DisableResourceVersionArgsForCall(0)
+			Expect(resource).To(Equal("some-resource"))
+			Expect(versionID).To(Equal(2))
+		})
+
+		It("pins the version with its comment", func() {
+			Expect(fakeTeam.PinResourceVersionCallCount()).To(Equal(1))
+			_, resource, versionID := fakeTeam.PinResourceVersionArgsForCall(0)
+			Expect(resource).To(Equal("some-resource"))
+			Expect(versionID).To(Equal(1))
+
+			Expect(fakeTeam.SetPinCommentCallCount()).To(Equal(1))
+			_, resource, comment := fakeTeam.SetPinCommentArgsForCall(0)
+			Expect(resource).To(Equal("some-resource"))
+			Expect(comment).To(Equal("hold on"))
+		})
+
+		It("pauses the pipeline only once the versions are restored", func() {
+			Expect(fakeTeam.PausePipelineCallCount()).To(Equal(1))
+		})
+
+		Context("when a version never shows up", func() {
+			BeforeEach(func() {
+				fakeTeam.CheckResourceReturns(atc.Build{}, true, nil)
+				fakeTeam.ResourceVersionsReturns(nil, concourse.Pagination{}, true, nil)
+				fakeTeam.ResourceVersionsStub = nil
+			})
+
+			It("gives up and warns", func() {
+				Expect(importErr).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf(
+					`some-pipeline/some-resource: could not disable version {"ref":"v2"}: timed out waiting for the version to be checked`,
+					`some-pipeline/some-resource: could not pin version {"ref":"v1"}: timed out waiting for the version to be checked`,
+				))
+
+				Expect(fakeTeam.DisableResourceVersionCallCount()).To(BeZero())
+				Expect(fakeTeam.PinResourceVersionCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type JobStatsCommand struct {
+	Pipeline *flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Only summarize builds of this pipeline"`
+	Job      flaghelpers.JobFlag       `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Only summarize builds of this job"`
+	Team     string                    `long:"team" description:"Name of the team to summarize, if different from the target default"`
+	Since    string                    `long:"since" description:"Start of the range of builds to summarize (default: 30 days before --until)"`
+	Until    string                    `long:"until" description:"End of the range of builds to summarize (default: now)"`
+	Json     bool                      `long:"json" description:"Print command result as JSON"`
+}
+
+var buildStatsStatuses = []atc.BuildStatus{
+	atc.StatusSucceeded,
+	atc.StatusFailed,
+	atc.StatusErrored,
+	atc.StatusAborted,
+	atc.StatusStarted,
+	atc.StatusPending,
+}
+
+func (command *JobStatsCommand) Execute([]string) error {
+	if command.Pipeline != nil && command.Job.JobName != "" {
+		return errors.New("Cannot specify both --pipeline and --job")
+	}
+
+	var filter concourse.BuildStatsFilter
+
+	if command.Pipeline != nil {
+		_, err := command.Pipeline.Validate()
+		if err != nil {
+			return err
+		}
+
+		filter.PipelineRef = command.Pipeline.Ref()
+	}
+
+	if command.Job.JobName != "" {
+		filter.PipelineRef = command.Job.PipelineRef
+		filter.JobName = command.Job.JobName
+	}
+
+	var err error
+	filter.Since, err = parseSearchTime(command.Since, "Since")
+	if err != nil {
+		return err
+	}
+
+	filter.Until, err = parseSearchTime(command.Until, "Until")
+	if err != nil {
+		return err
+	}
+
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && filter.Since.After(filter.Until) {
+		return errors.New("Cannot have --since after --until")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	stats, found, err := team.BuildStats(filter)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("pipeline/job not found")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(stats)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "stat", Color: color.New(color.Bold)},
+			{Contents: "value", Color: color.New(color.Bold)},
+		},
+		Data: []ui.TableRow{
+			{
+				{Contents: "window"},
+				{Contents: fmt.Sprintf(
+					"%s - %s",
+					time.Unix(stats.Since, 0).Local().Format(timeDateLayout),
+					time.Unix(stats.Until, 0).Local().Format(timeDateLayout),
+				)},
+			},
+			{{Contents: "builds"}, {Contents: buildStatsCounts(stats.Builds)}},
+			{{Contents: "success rate"}, {Contents: percentage(stats.SuccessRate)}},
+			{{Contents: "duration"}, {Contents: durationPercentiles(stats.Duration)}},
+			{{Contents: "queue time"}, {Contents: durationPercentiles(stats.QueueTime)}},
+			{{Contents: "recoveries"}, {Contents: fmt.Sprintf("%d", stats.Recoveries)}},
+			{{Contents: "mean time to recovery"}, {Contents: statsDuration(stats.MeanTimeToRecovery).String()}},
+			{{Contents: "reruns"}, {Contents: fmt.Sprintf("%d (%d flipped)", stats.Reruns, stats.FlippedReruns)}},
+			{{Contents: "flakiness"}, {Contents: percentage(stats.Flakiness)}},
+		},
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func buildStatsCounts(builds map[atc.BuildStatus]int) string {
+	total := 0
+	var counts []string
+	for _, status := range buildStatsStatuses {
+		count, ok := builds[status]
+		if !ok {
+			continue
+		}
+
+		total += count
+		counts = append(counts, fmt.Sprintf("%s: %d", status, count))
+	}
+
+	if len(counts) == 0 {
+		return "0"
+	}
+
+	return fmt.Sprintf("%d (%s)", total, strings.Join(counts, ", "))
+}
+
+func durationPercentiles(percentiles atc.DurationPercentiles) string {
+	return fmt.Sprintf(
+		"p50 %s, p90 %s, p99 %s",
+		statsDuration(percentiles.P50),
+		statsDuration(percentiles.P90),
+		statsDuration(percentiles.P99),
+	)
+}
+
+func statsDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second)
+}
+
+func percentage(fraction float64) string {
+	return fmt.Sprintf("%.1f%%", fraction*100)
+}
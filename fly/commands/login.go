@@ -33,6 +33,7 @@ type LoginCommand struct {
 	ClientCertPath atc.PathFlag `long:"client-cert" description:"Path to a PEM-encoded client certificate file."`
 	ClientKeyPath  atc.PathFlag `long:"client-key" description:"Path to a PEM-encoded client key file."`
 	OpenBrowser    bool         `short:"b" long:"open-browser" description:"Open browser to the auth endpoint"`
+	NoRefresh      bool         `long:"no-refresh" description:"Do not request a refresh token, so that the session ends when the token expires"`
 
 	BrowserOnly bool
 }
@@ -100,6 +101,7 @@ func (command *LoginCommand) Execute(args []string) error {
 
 	var tokenType string
 	var tokenValue string
+	var refreshToken string
 
 	version, err := target.Version()
 	if err != nil {
@@ -139,9 +141,9 @@ func (command *LoginCommand) Execute(args []string) error {
 		tokenType, tokenValue, err = command.legacyAuth(target, command.BrowserOnly, isRawMode)
 	} else {
 		if command.Username != "" && command.Password != "" {
-			tokenType, tokenValue, err = command.passwordGrant(client, command.Username, command.Password)
+			tokenType, tokenValue, refreshToken, err = command.passwordGrant(client, command.Username, command.Password)
 		} else {
-			tokenType, tokenValue, err = command.authCodeGrant(client.URL(), command.BrowserOnly, isRawMode)
+			tokenType, tokenValue, refreshToken, err = command.authCodeGrant(client.URL(), command.BrowserOnly, isRawMode)
 		}
 	}
 
@@ -168,8 +170,9 @@ func (command *LoginCommand) Execute(args []string) error {
 	return command.saveTarget(
 		client.URL(),
 		&rc.TargetToken{
-			Type:  tokenType,
-			Value: tokenValue,
+			Type:         tokenType,
+			Value:        tokenValue,
+			RefreshToken: refreshToken,
 		},
 		target.CACert(),
 		target.ClientCertPath(),
@@ -177,30 +180,30 @@ func (command *LoginCommand) Execute(args []string) error {
 	)
 }
 
-func (command *LoginCommand) passwordGrant(client concourse.Client, username, password string) (string, string, error) {
-
-	oauth2Config := oauth2.Config{
-		ClientID:     "fly",
-		ClientSecret: "Zmx5",
-		Endpoint:     oauth2.Endpoint{TokenURL: client.URL() + "/sky/issuer/token"},
-		Scopes:       []string{"openid", "profile", "email", "federated:id", "groups"},
+func (command *LoginCommand) passwordGrant(client concourse.Client, username, password string) (string, string, string, error) {
+	scopes := []string{"openid", "profile", "email", "federated:id", "groups"}
+	if !command.NoRefresh {
+		scopes = append(scopes, "offline_access")
 	}
 
+	oauth2Config := rc.OAuth2Config(client.URL(), scopes...)
+
 	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client.HTTPClient())
 
 	token, err := oauth2Config.PasswordCredentialsToken(ctx, username, password)
 	if err != nil {
-		return "", "", err
+		return "", "", "", err
 	}
 
-	return token.TokenType, token.AccessToken, nil
+	return token.TokenType, token.AccessToken, token.RefreshToken, nil
 }
 
-func (command *LoginCommand) authCodeGrant(targetUrl string, browserOnly bool, isRawMode bool) (string, string, error) {
+func (command *LoginCommand) authCodeGrant(targetUrl string, browserOnly bool, isRawMode bool) (string, string, string, error) {
 	var tokenStr string
+	var refreshToken string
 
 	stdinChannel := make(chan string)
-	tokenChannel := make(chan string)
+	tokenChannel := make(chan callbackToken)
 	errorChannel := make(chan error)
 	portChannel := make(chan string)
 
@@ -214,6 +217,11 @@ func (command *LoginCommand) authCodeGrant(targetUrl string, browserOnly bool, i
 	fmt.Println("\r")
 
 	openURL = fmt.Sprintf("%s/login?fly_port=%s", targetUrl, port)
+	if !command.NoRefresh {
+		// the refresh token is only handed to fly directly, so a token
+		// entered manually can't be refreshed
+		openURL += "&fly_refresh=true"
+	}
 
 	fmt.Printf("  %s\r\n", openURL)
 
@@ -228,29 +236,41 @@ func (command *LoginCommand) authCodeGrant(targetUrl string, browserOnly bool, i
 	}
 
 	select {
-	case tokenStrMsg := <-tokenChannel:
-		tokenStr = tokenStrMsg
+	case tokenMsg := <-tokenChannel:
+		tokenStr = tokenMsg.token
+		refreshToken = tokenMsg.refreshToken
 	case tokenStrMsg := <-stdinChannel:
 		tokenStr = tokenStrMsg
 	case errorMsg := <-errorChannel:
-		return "", "", errorMsg
+		return "", "", "", errorMsg
 	}
 
 	segments := strings.SplitN(tokenStr, " ", 2)
 
 	if len(segments) > 1 {
-		return segments[0], segments[1], nil
+		return segments[0], segments[1], refreshToken, nil
 	} else {
-		return "", "", fmt.Errorf("invalid token: %v", tokenStr)
+		return "", "", "", fmt.Errorf("invalid token: %v", tokenStr)
 	}
 }
 
-func listenForTokenCallback(tokenChannel chan string, errorChannel chan error, portChannel chan string, targetUrl string) {
+// callbackToken is what the browser sends to fly once logged in. Only
+// logins which asked for it come with a refresh token.
+type callbackToken struct {
+	token        string
+	refreshToken string
+}
+
+func listenForTokenCallback(tokenChannel chan callbackToken, errorChannel chan error, portChannel chan string, targetUrl string) {
 	s := &http.Server{
 		Addr: "127.0.0.1:0",
 		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Access-Control-Allow-Origin", targetUrl)
-			tokenChannel <- r.FormValue("token")
+			// the refresh token is only ever posted, never sent in a URL
+			tokenChannel <- callbackToken{
+				token:        r.FormValue("token"),
+				refreshToken: r.PostFormValue("refresh_token"),
+			}
 			if r.Header.Get("Upgrade-Insecure-Requests") != "" {
 				http.Redirect(w, r, fmt.Sprintf("%s/fly_success?noop=true", targetUrl), http.StatusFound)
 			}
@@ -322,8 +342,9 @@ func (command *LoginCommand) saveTarget(url string, token *rc.TargetToken, caCer
 		command.Insecure,
 		command.TeamName,
 		&rc.TargetToken{
-			Type:  token.Type,
-			Value: token.Value,
+			Type:         token.Type,
+			Value:        token.Value,
+			RefreshToken: token.RefreshToken,
 		},
 		caCert,
 		clientCertPath,
@@ -406,7 +427,7 @@ func (command *LoginCommand) legacyAuth(target rc.Target, browserOnly bool, isRa
 		var tokenStr string
 
 		stdinChannel := make(chan string)
-		tokenChannel := make(chan string)
+		tokenChannel := make(chan callbackToken)
 		errorChannel := make(chan error)
 		portChannel := make(chan string)
 
@@ -431,8 +452,8 @@ func (command *LoginCommand) legacyAuth(target rc.Target, browserOnly bool, isRa
 		}
 
 		select {
-		case tokenStrMsg := <-tokenChannel:
-			tokenStr = tokenStrMsg
+		case tokenMsg := <-tokenChannel:
+			tokenStr = tokenMsg.token
 		case tokenStrMsg := <-stdinChannel:
 			tokenStr = tokenStrMsg
 		case errorMsg := <-errorChannel:
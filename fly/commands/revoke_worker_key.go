@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type RevokeWorkerKeyCommand struct {
+	ID     int    `short:"i" long:"id" required:"true" description:"ID of the key to revoke, as shown by worker-keys"`
+	Team   string `long:"team" description:"Name of the team the key belongs to, if different from the target default"`
+	Global bool   `long:"global" description:"Revoke a key of global workers, or of any team (admin only)"`
+}
+
+func (command *RevokeWorkerKeyCommand) Execute([]string) error {
+	if command.Global && command.Team != "" {
+		displayhelpers.Failf("--global and --team are mutually exclusive")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var found bool
+	if command.Global {
+		found, err = target.Client().RevokeWorkerKey(command.ID)
+	} else {
+		var team concourse.Team
+		team, err = workerKeysTeam(target, command.Team)
+		if err != nil {
+			return err
+		}
+
+		found, err = team.RevokeWorkerKey(command.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("worker key %d not found", command.ID)
+	}
+
+	fmt.Printf("revoked worker key %d\n", command.ID)
+
+	return nil
+}
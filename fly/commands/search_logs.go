@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type SearchLogsCommand struct {
+	Args struct {
+		Query string `positional-arg-name:"QUERY" required:"true" description:"Text to search for. Each word matches the start of a word in the log"`
+	} `positional-args:"yes"`
+
+	Pipeline *flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Only search builds of this pipeline"`
+	Job      flaghelpers.JobFlag       `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Only search builds of this job"`
+	Team     string                    `long:"team" description:"Name of the team to search, if different from the target default"`
+	Since    string                    `long:"since" description:"Only search lines logged at or after this time"`
+	Until    string                    `long:"until" description:"Only search lines logged at or before this time"`
+	Context  int                       `short:"C" long:"context" default:"0" description:"Number of lines to print before and after each match, at most 10"`
+	Count    int                       `short:"c" long:"count" default:"50" description:"Maximum number of matching lines to print"`
+	Json     bool                      `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *SearchLogsCommand) Execute([]string) error {
+	if command.Pipeline != nil && command.Job.JobName != "" {
+		return errors.New("Cannot specify both --pipeline and --job")
+	}
+
+	search := concourse.BuildLogSearch{
+		Query:   command.Args.Query,
+		Context: command.Context,
+	}
+
+	if command.Pipeline != nil {
+		_, err := command.Pipeline.Validate()
+		if err != nil {
+			return err
+		}
+
+		search.PipelineRef = command.Pipeline.Ref()
+	}
+
+	if command.Job.JobName != "" {
+		search.PipelineRef = command.Job.PipelineRef
+		search.JobName = command.Job.JobName
+	}
+
+	var err error
+	search.Since, err = parseSearchTime(command.Since, "Since")
+	if err != nil {
+		return err
+	}
+
+	search.Until, err = parseSearchTime(command.Until, "Until")
+	if err != nil {
+		return err
+	}
+
+	if !search.Since.IsZero() && !search.Until.IsZero() && search.Since.After(search.Until) {
+		return errors.New("Cannot have --since after --until")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	matches := []atc.BuildLogMatch{}
+	page := concourse.Page{Limit: command.Count}
+	for len(matches) < command.Count {
+		pageMatches, pagination, found, err := team.SearchBuildLogs(search, page)
+		if err != nil {
+			if err == concourse.ErrForbidden {
+				return errors.New("build log search is disabled or you are not authorized to search this team's logs")
+			}
+
+			return err
+		}
+
+		if !found {
+			displayhelpers.Failf("pipeline/job not found")
+		}
+
+		matches = append(matches, pageMatches...)
+
+		if pagination.Next == nil {
+			break
+		}
+
+		page = *pagination.Next
+	}
+
+	if len(matches) > command.Count {
+		matches = matches[:command.Count]
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(matches)
+	}
+
+	lastBuildID := 0
+	for _, match := range matches {
+		if match.BuildID != lastBuildID {
+			if lastBuildID != 0 {
+				fmt.Println()
+			}
+
+			fmt.Printf("%s %s/builds/%d\n", ui.Embolden("%s", buildLogMatchName(match)), target.URL(), match.BuildID)
+			lastBuildID = match.BuildID
+		}
+
+		for _, line := range match.Before {
+			fmt.Printf("  %s\n", ui.OffColor.Sprint(line))
+		}
+
+		fmt.Printf("> %s\n", match.Line)
+
+		for _, line := range match.After {
+			fmt.Printf("  %s\n", ui.OffColor.Sprint(line))
+		}
+	}
+
+	return nil
+}
+
+func buildLogMatchName(match atc.BuildLogMatch) string {
+	var names []string
+
+	if match.PipelineName != "" {
+		names = append(names, atc.PipelineRef{
+			Name:         match.PipelineName,
+			InstanceVars: match.PipelineInstanceVars,
+		}.String())
+	}
+
+	if match.JobName != "" {
+		names = append(names, match.JobName)
+	}
+
+	if len(names) == 0 {
+		return "#" + match.BuildName
+	}
+
+	return strings.Join(names, "/") + " #" + match.BuildName
+}
+
+func parseSearchTime(value string, name string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.ParseInLocation(inputTimeLayout, value, time.Now().Location())
+	if err != nil {
+		return time.Time{}, errors.New(name + " time should be in the format: " + inputTimeLayout)
+	}
+
+	return t, nil
+}
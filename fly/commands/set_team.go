@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"sort"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/concourse/concourse/skymarshal/skycmd"
 	"github.com/jessevdk/go-flags"
 	"github.com/vito/go-interact/interact"
+	"sigs.k8s.io/yaml"
 )
 
 func WireTeamConnectors(command *flags.Command) {
@@ -29,6 +31,12 @@ type SetTeamCommand struct {
 	Team            flaghelpers.TeamFlag `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
 	SkipInteractive bool                 `long:"non-interactive" description:"Force apply configuration"`
 	AuthFlags       skycmd.AuthTeamFlags `group:"Authentication"`
+
+	DefaultCPULimit    *uint64 `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task in this team, overriding the cluster default"`
+	DefaultMemoryLimit string  `long:"default-task-memory-limit" description:"Default maximum memory per task in this team, overriding the cluster default"`
+	DefaultDiskLimit   string  `long:"default-task-disk-limit" description:"Default maximum disk usage per task in this team, overriding the cluster default"`
+
+	Notifiers atc.PathFlag `long:"notifiers" description:"File of notifiers to send for the builds of every pipeline in this team"`
 }
 
 func (command *SetTeamCommand) Validate() ([]concourse.ConfigWarning, error) {
@@ -46,6 +54,61 @@ func (command *SetTeamCommand) Validate() ([]concourse.ConfigWarning, error) {
 	return warnings, nil
 }
 
+func (command *SetTeamCommand) containerLimits() (*atc.ContainerLimits, error) {
+	var limits atc.ContainerLimits
+
+	if command.DefaultCPULimit != nil {
+		cpu := atc.CPULimit(*command.DefaultCPULimit)
+		limits.CPU = &cpu
+	}
+
+	if command.DefaultMemoryLimit != "" {
+		memory, err := atc.ParseMemoryLimit(command.DefaultMemoryLimit)
+		if err != nil {
+			return nil, err
+		}
+		limits.Memory = &memory
+	}
+
+	if command.DefaultDiskLimit != "" {
+		disk, err := atc.ParseDiskLimit(command.DefaultDiskLimit)
+		if err != nil {
+			return nil, err
+		}
+		limits.Disk = &disk
+	}
+
+	if limits == (atc.ContainerLimits{}) {
+		return nil, nil
+	}
+
+	return &limits, nil
+}
+
+func (command *SetTeamCommand) notifiers() (atc.NotifierConfigs, error) {
+	if command.Notifiers == "" {
+		return nil, nil
+	}
+
+	payload, err := ioutil.ReadFile(string(command.Notifiers))
+	if err != nil {
+		return nil, err
+	}
+
+	var notifiers atc.NotifierConfigs
+	err = yaml.Unmarshal(payload, &notifiers)
+	if err != nil {
+		return nil, fmt.Errorf("malformed notifiers: %w", err)
+	}
+
+	err = notifiers.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return notifiers, nil
+}
+
 func (command *SetTeamCommand) Execute([]string) error {
 	warnings, err := command.Validate()
 	if err != nil {
@@ -68,6 +131,16 @@ func (command *SetTeamCommand) Execute([]string) error {
 		os.Exit(1)
 	}
 
+	limits, err := command.containerLimits()
+	if err != nil {
+		return err
+	}
+
+	notifiers, err := command.notifiers()
+	if err != nil {
+		return err
+	}
+
 	roles := []string{}
 	for role := range authRoles {
 		roles = append(roles, role)
@@ -103,6 +176,28 @@ func (command *SetTeamCommand) Execute([]string) error {
 		}
 	}
 
+	if limits != nil {
+		fmt.Println()
+		fmt.Println("default task limits:")
+		if limits.CPU != nil {
+			fmt.Printf("  cpu: %d\n", *limits.CPU)
+		}
+		if limits.Memory != nil {
+			fmt.Printf("  memory: %d\n", *limits.Memory)
+		}
+		if limits.Disk != nil {
+			fmt.Printf("  disk: %d\n", *limits.Disk)
+		}
+	}
+
+	if len(notifiers) > 0 {
+		fmt.Println()
+		fmt.Println("notifiers:")
+		for _, notifier := range notifiers {
+			fmt.Printf("  - %s (%s)\n", notifier.Name, notifier.Type)
+		}
+	}
+
 	if len(warnings) > 0 {
 		displayhelpers.ShowWarnings(warnings)
 	}
@@ -120,7 +215,7 @@ func (command *SetTeamCommand) Execute([]string) error {
 		displayhelpers.Failf("bailing out")
 	}
 
-	team := atc.Team{Auth: authRoles}
+	team := atc.Team{Auth: authRoles, ContainerLimits: limits, Notifiers: notifiers}
 
 	_, created, updated, warnings, err := target.Client().Team(teamName).CreateOrUpdate(team)
 	if err != nil {
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configvalidate"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/commands/internal/pipelinetest"
+	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
+	"github.com/concourse/concourse/fly/ui"
+)
+
+type TestPipelineCommand struct {
+	Config           atc.PathFlag   `short:"c" long:"config"      required:"true" description:"Pipeline configuration file"`
+	Assertions       []atc.PathFlag `short:"a" long:"assertions"  required:"true" description:"File of assertions to run against the pipeline. Can be specified multiple times."`
+	EnableAcrossStep bool           `long:"enable-across-step"                    description:"Enable the experimental across step to be used in jobs. The API is subject to change."`
+
+	Var     []flaghelpers.VariablePairFlag     `short:"v"  long:"var"       unquote:"false"  value-name:"[NAME=STRING]"  description:"Specify a string value to set for a variable in the pipeline"`
+	YAMLVar []flaghelpers.YAMLVariablePairFlag `short:"y"  long:"yaml-var"  unquote:"false"  value-name:"[NAME=YAML]"    description:"Specify a YAML value to set for a variable in the pipeline"`
+
+	VarsFrom []atc.PathFlag `short:"l"  long:"load-vars-from"  description:"Variable flag that can be used for filling in template values in configuration from a YAML file"`
+}
+
+func (command *TestPipelineCommand) Execute(args []string) error {
+	var suites []pipelinetest.Suite
+	for _, path := range command.Assertions {
+		payload, err := ioutil.ReadFile(string(path))
+		if err != nil {
+			return fmt.Errorf("could not read assertions file: %s", err)
+		}
+
+		suite, err := pipelinetest.ParseSuite(payload)
+		if err != nil {
+			return fmt.Errorf("invalid assertions file '%s': %s", path, err)
+		}
+
+		suites = append(suites, suite)
+	}
+
+	yamlTemplate := templatehelpers.NewYamlTemplateWithParams(command.Config, command.VarsFrom, command.Var, command.YAMLVar, nil)
+	evaluatedTemplate, err := yamlTemplate.Evaluate(true, false)
+	if err != nil {
+		return err
+	}
+
+	var config atc.Config
+	err = yaml.Unmarshal(evaluatedTemplate, &config)
+	if err != nil {
+		return err
+	}
+
+	if command.EnableAcrossStep {
+		atc.EnableAcrossStep = true
+	}
+
+	_, errorMessages := configvalidate.Validate(config)
+	if len(errorMessages) > 0 {
+		displayhelpers.ShowErrors("Error loading config", errorMessages)
+		return errors.New("configuration invalid")
+	}
+
+	trees, err := pipelinetest.NewTrees(config)
+	if err != nil {
+		return err
+	}
+
+	var total, failed int
+	for _, suite := range suites {
+		for _, result := range suite.Run(trees) {
+			total++
+
+			if result.Passed() {
+				fmt.Printf("%s %s\n", ui.SucceededColor.Sprint("✓"), result.Assertion.Name)
+				continue
+			}
+
+			failed++
+
+			fmt.Printf("%s %s\n", ui.FailedColor.Sprint("✗"), result.Assertion.Name)
+			for _, failure := range result.Failures {
+				fmt.Printf("    %s\n", failure)
+			}
+		}
+	}
+
+	fmt.Println()
+
+	if failed > 0 {
+		displayhelpers.Failf("%d of %d assertions failed", failed, total)
+	}
+
+	fmt.Printf("%d of %d assertions passed\n", total, total)
+
+	return nil
+}
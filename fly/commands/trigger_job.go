@@ -15,9 +15,10 @@ import (
 )
 
 type TriggerJobCommand struct {
-	Job   flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to trigger"`
-	Watch bool                `short:"w" long:"watch" description:"Start watching the build output"`
-	Team  string              `long:"team" description:"Name of the team to which the job belongs, if different from the target default"`
+	Job         flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to trigger"`
+	Watch       bool                `short:"w" long:"watch" description:"Start watching the build output"`
+	Team        string              `long:"team" description:"Name of the team to which the job belongs, if different from the target default"`
+	Traceparent string              `long:"traceparent" env:"TRACEPARENT" value-name:"TRACEPARENT" description:"W3C traceparent of a trace to run the build as part of, e.g. that of a put step or deployment triggering it"`
 }
 
 func (command *TriggerJobCommand) Execute(args []string) error {
@@ -47,7 +48,7 @@ func (command *TriggerJobCommand) Execute(args []string) error {
 		team = target.Team()
 	}
 
-	build, err = team.CreateJobBuild(pipelineRef, jobName)
+	build, err = team.CreateJobBuildInTrace(pipelineRef, jobName, command.Traceparent)
 	if err != nil {
 		return err
 	} else {
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type WorkerKeysCommand struct {
+	Team string `long:"team" description:"Name of the team to list keys of, if different from the target default"`
+	All  bool   `short:"a" long:"all" description:"List the keys of all teams and of global workers (admin only)"`
+	Json bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *WorkerKeysCommand) Execute([]string) error {
+	if command.All && command.Team != "" {
+		displayhelpers.Failf("--all and --team are mutually exclusive")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var keys []atc.WorkerKey
+	if command.All {
+		keys, err = target.Client().ListWorkerKeys()
+	} else {
+		var team concourse.Team
+		team, err = workerKeysTeam(target, command.Team)
+		if err != nil {
+			return err
+		}
+
+		keys, err = team.ListWorkerKeys()
+	}
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(keys)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "id", Color: color.New(color.Bold)},
+			{Contents: "team", Color: color.New(color.Bold)},
+			{Contents: "fingerprint", Color: color.New(color.Bold)},
+			{Contents: "created", Color: color.New(color.Bold)},
+			{Contents: "last used", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, key := range keys {
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: strconv.Itoa(key.ID)},
+			stringOrDefault(key.TeamName),
+			{Contents: key.Fingerprint},
+			unixTimeCell(key.CreatedAt),
+			unixTimeCell(key.LastUsedAt),
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func workerKeysTeam(target rc.Target, teamName string) (concourse.Team, error) {
+	if teamName == "" {
+		return target.Team(), nil
+	}
+
+	return target.FindTeam(teamName)
+}
+
+func unixTimeCell(unix int64) ui.TableCell {
+	if unix == 0 {
+		return ui.TableCell{Contents: "never", Color: color.New(color.Faint)}
+	}
+
+	return ui.TableCell{Contents: time.Unix(unix, 0).Format(timeDateLayout)}
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/concourse/concourse/go-concourse/concourse/eventstream"
@@ -62,6 +63,32 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 		case event.FinishTask:
 			exitStatus = e.ExitStatus
 
+			if e.Usage != nil {
+				blockIO := ""
+				if e.Usage.BlockIORead > 0 || e.Usage.BlockIOWrite > 0 {
+					blockIO = fmt.Sprintf(
+						", block io %s read / %s written",
+						formatBytes(e.Usage.BlockIORead),
+						formatBytes(e.Usage.BlockIOWrite),
+					)
+				}
+
+				dstImpl.SetTimestamp(e.Time)
+				fmt.Fprintf(
+					dstImpl,
+					"\x1b[1mresource usage:\x1b[0m cpu %.2f peak / %.2f avg cores, memory %s peak / %s avg%s\n",
+					e.Usage.CPUPeak,
+					e.Usage.CPUAverage,
+					formatBytes(e.Usage.MemoryPeak),
+					formatBytes(e.Usage.MemoryAverage),
+					blockIO,
+				)
+			}
+
+		case event.TaskKilled:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "%s\n", ui.ErroredColor.Sprintf("killed: %s", formatExitReason(e.Reason)))
+
 		case event.Error:
 			errCol := ui.ErroredColor.SprintFunc()
 			dstImpl.SetTimestamp(0)
@@ -115,3 +142,39 @@ func isEventParseError(err error) bool {
 	}
 	return false
 }
+
+func formatExitReason(reason atc.ExitReason) string {
+	if !reason.OOMKilled {
+		return reason.Signal
+	}
+
+	var details []string
+	if reason.MemoryLimit > 0 {
+		details = append(details, "limit "+formatBytes(reason.MemoryLimit))
+	}
+
+	if reason.MemoryPeak > 0 {
+		details = append(details, "peak "+formatBytes(reason.MemoryPeak))
+	}
+
+	if len(details) == 0 {
+		return "out of memory"
+	}
+
+	return fmt.Sprintf("out of memory (%s)", strings.Join(details, ", "))
+}
+
+func formatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%dB", bytes)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f%ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -148,6 +148,53 @@ var _ = Describe("V1.0 Renderer", func() {
 		})
 	})
 
+	Context("when a TaskKilled event is received", func() {
+		Context("for a task killed by a signal", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.TaskKilled{
+					Reason: atc.ExitReason{Signal: "SIGTERM"},
+				}
+			})
+
+			It("prints the signal", func() {
+				Expect(out).To(gbytes.Say(`killed: SIGTERM`))
+			})
+		})
+
+		Context("for a task killed for running out of memory", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.TaskKilled{
+					Reason: atc.ExitReason{
+						Signal:      "SIGKILL",
+						OOMKilled:   true,
+						MemoryLimit: 2 * 1024 * 1024 * 1024,
+						MemoryPeak:  2 * 1024 * 1024 * 1024,
+					},
+				}
+			})
+
+			It("prints the memory limit and peak usage", func() {
+				Expect(out).To(gbytes.Say(`killed: out of memory \(limit 2.0GiB, peak 2.0GiB\)`))
+			})
+		})
+
+		Context("for a task killed for running out of memory with an unknown peak", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.TaskKilled{
+					Reason: atc.ExitReason{
+						Signal:      "SIGKILL",
+						OOMKilled:   true,
+						MemoryLimit: 512 * 1024 * 1024,
+					},
+				}
+			})
+
+			It("prints only the memory limit", func() {
+				Expect(out).To(gbytes.Say(`killed: out of memory \(limit 512.0MiB\)`))
+			})
+		})
+	})
+
 	Context("when a FinishTask event is received", func() {
 		BeforeEach(func() {
 			receivedEvents <- event.FinishTask{
@@ -159,6 +206,50 @@ var _ = Describe("V1.0 Renderer", func() {
 			Expect(exitStatus).To(Equal(42))
 		})
 
+		It("does not print any resource usage", func() {
+			Expect(out.Contents()).ToNot(ContainSubstring("resource usage"))
+		})
+
+		Context("with the resource usage of the task", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.FinishTask{
+					ExitStatus: 0,
+					Usage: &atc.ContainerUsage{
+						CPUAverage:    0.5,
+						CPUPeak:       1.25,
+						MemoryAverage: 512 * 1024 * 1024,
+						MemoryPeak:    3 * 1024 * 1024 * 1024 / 2,
+						Samples:       3,
+					},
+				}
+			})
+
+			It("prints the peak and average usage", func() {
+				Expect(out).To(gbytes.Say(`resource usage:.* cpu 1.25 peak / 0.50 avg cores, memory 1.5GiB peak / 512.0MiB avg\n`))
+			})
+		})
+
+		Context("with the block IO of the task", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.FinishTask{
+					ExitStatus: 0,
+					Usage: &atc.ContainerUsage{
+						CPUAverage:    0.5,
+						CPUPeak:       1.25,
+						MemoryAverage: 512 * 1024 * 1024,
+						MemoryPeak:    512 * 1024 * 1024,
+						BlockIORead:   2 * 1024 * 1024,
+						BlockIOWrite:  512,
+						Samples:       3,
+					},
+				}
+			})
+
+			It("prints the bytes read and written", func() {
+				Expect(out).To(gbytes.Say(`memory 512.0MiB peak / 512.0MiB avg, block io 2.0MiB read / 512B written`))
+			})
+		})
+
 		Context("and a Status event is received", func() {
 			BeforeEach(func() {
 				receivedEvents <- event.Status{
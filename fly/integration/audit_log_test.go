@@ -0,0 +1,128 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("audit-log", func() {
+		var (
+			flyCmd *exec.Cmd
+			events []atc.AuditEvent
+		)
+
+		BeforeEach(func() {
+			events = []atc.AuditEvent{
+				{
+					ID:                   2,
+					Time:                 time.Date(2021, time.August, 9, 0, 0, 0, 0, time.UTC).Unix(),
+					Action:               atc.SaveConfig,
+					User:                 "some-user",
+					TeamName:             "main",
+					PipelineName:         "some-pipeline",
+					PipelineInstanceVars: atc.InstanceVars{"branch": "main"},
+					RequestID:            "some-request-id",
+					Status:               http.StatusOK,
+					Diff:                 "jobs: +some-job",
+				},
+				{
+					ID:     1,
+					Time:   time.Date(2021, time.August, 8, 0, 0, 0, 0, time.UTC).Unix(),
+					Action: atc.ListAllPipelines,
+					User:   "some-user",
+					Status: http.StatusOK,
+				},
+			}
+		})
+
+		Context("when no filters are given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "audit-log")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/audit-log", "limit=50"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, events),
+					),
+				)
+			})
+
+			It("lists the events", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say(`2021-08-\S+\s+some-user\s+SaveConfig\s+main\s+some-pipeline/branch:main\s+200\s+jobs: \+some-job`))
+				Expect(sess.Out).To(gbytes.Say(`2021-08-\S+\s+some-user\s+ListAllPipelines\s+n/a\s+n/a\s+200\s+n/a`))
+			})
+		})
+
+		Context("when filters are given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "audit-log",
+					"-u", "some-user",
+					"-a", "SaveConfig",
+					"--team", "main",
+					"-c", "1",
+					"--json",
+				)
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/audit-log", "user=some-user&action=SaveConfig&team_name=main&limit=1"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, events[:1]),
+					),
+				)
+			})
+
+			It("prints the matching events as JSON", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out.Contents()).To(MatchJSON(`[{
+					"id": 2,
+					"time": 1628467200,
+					"action": "SaveConfig",
+					"user": "some-user",
+					"team_name": "main",
+					"pipeline_name": "some-pipeline",
+					"pipeline_instance_vars": {"branch": "main"},
+					"request_id": "some-request-id",
+					"status": 200,
+					"diff": "jobs: +some-job"
+				}]`))
+			})
+		})
+
+		Context("when the user is not an admin", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "audit-log")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/audit-log"),
+						ghttp.RespondWith(http.StatusForbidden, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("the audit log is only available to admins"))
+			})
+		})
+	})
+})
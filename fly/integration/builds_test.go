@@ -1026,4 +1026,96 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 	})
+
+	Describe("builds --timeline", func() {
+		var (
+			session          *gexec.Session
+			returnedTimings  []atc.StepTiming
+			returnedStatus   int
+			streamStartTime  time.Time
+			runningStartTime time.Time
+		)
+
+		BeforeEach(func() {
+			streamStartTime = time.Date(2015, time.December, 1, 1, 20, 15, 0, time.UTC)
+			runningStartTime = time.Date(2015, time.December, 1, 1, 20, 25, 0, time.UTC)
+
+			streamedBytes := int64(1024)
+
+			returnedStatus = http.StatusOK
+			returnedTimings = []atc.StepTiming{
+				{
+					PlanID:    "some-plan-id",
+					StepName:  "some-task",
+					Phase:     atc.StepPhaseStreamingInputs,
+					StartTime: streamStartTime.Unix(),
+					Duration:  9.25,
+					Bytes:     &streamedBytes,
+				},
+				{
+					PlanID:    "some-plan-id",
+					StepName:  "some-task",
+					Phase:     atc.StepPhaseRunning,
+					StartTime: runningStartTime.Unix(),
+					Duration:  42.5,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/42/timeline"),
+					ghttp.RespondWithJSONEncoded(returnedStatus, returnedTimings),
+				),
+			)
+
+			var err error
+			cmd := exec.Command(flyPath, "-t", targetName, "builds", "--timeline", "42")
+			session, err = gexec.Start(cmd, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("shows how long each phase of the steps took", func() {
+			Eventually(session.Out).Should(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "step", Color: color.New(color.Bold)},
+					{Contents: "phase", Color: color.New(color.Bold)},
+					{Contents: "start", Color: color.New(color.Bold)},
+					{Contents: "duration", Color: color.New(color.Bold)},
+					{Contents: "streamed", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: "some-task"},
+						{Contents: "streaming-inputs"},
+						{Contents: streamStartTime.Local().Format(timeDateLayout)},
+						{Contents: "9.25s"},
+						{Contents: "1024 bytes"},
+					},
+					{
+						{Contents: "some-task"},
+						{Contents: "running"},
+						{Contents: runningStartTime.Local().Format(timeDateLayout)},
+						{Contents: "42.5s"},
+						{Contents: "n/a", Color: color.New(color.Faint)},
+					},
+				},
+			}))
+
+			Eventually(session).Should(gexec.Exit(0))
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				returnedStatus = http.StatusNotFound
+				returnedTimings = nil
+			})
+
+			It("writes an error message to stderr", func() {
+				Eventually(session.Err).Should(gbytes.Say("build not found"))
+				Eventually(session).Should(gexec.Exit(1))
+			})
+		})
+	})
 })
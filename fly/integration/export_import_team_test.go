@@ -0,0 +1,175 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	var tmpdir string
+
+	BeforeEach(func() {
+		var err error
+		tmpdir, err = ioutil.TempDir("", "fly-team-archive")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpdir)
+	})
+
+	Describe("export-team", func() {
+		BeforeEach(func() {
+			cpu := atc.CPULimit(512)
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/myTeam"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+						ID:              1,
+						Name:            "myTeam",
+						Auth:            atc.TeamAuth{"owner": {"users": []string{"local:username"}}},
+						ContainerLimits: &atc.ContainerLimits{CPU: &cpu},
+						Notifiers: atc.NotifierConfigs{{
+							Name: "chat",
+							Type: atc.NotifierTypeSlack,
+							URL:  "https://hooks.example.com/chat",
+						}},
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/myTeam/pipelines"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.Pipeline{
+						{Name: "old-pipeline", Archived: true, Paused: true},
+					}),
+				),
+			)
+		})
+
+		It("writes the archive to the given file", func() {
+			archivePath := filepath.Join(tmpdir, "team.json")
+			flyCmd := exec.Command(flyPath, "-t", targetName, "export-team", "-n", "myTeam", "-o", archivePath)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say("exported 1 pipelines of team myTeam to " + archivePath))
+
+			contents, err := ioutil.ReadFile(archivePath)
+			Expect(err).NotTo(HaveOccurred())
+			var archive map[string]interface{}
+			Expect(json.Unmarshal(contents, &archive)).To(Succeed())
+			Expect(archive["exported_at"]).ToNot(BeZero())
+			delete(archive, "exported_at")
+
+			Expect(json.Marshal(archive)).To(MatchJSON(`{
+				"version": 2,
+				"team": {
+					"name": "myTeam",
+					"auth": {"owner": {"users": ["local:username"]}},
+					"container_limits": {"cpu": 512},
+					"notifiers": [{"name": "chat", "type": "slack", "url": "https://hooks.example.com/chat"}]
+				},
+				"pipelines": [
+					{"name": "old-pipeline", "paused": true, "public": false, "archived": true}
+				]
+			}`))
+		})
+	})
+
+	Describe("import-team", func() {
+		var archivePath string
+
+		BeforeEach(func() {
+			archivePath = filepath.Join(tmpdir, "team.json")
+		})
+
+		Context("when the archive is valid", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(archivePath, []byte(`{
+					"version": 2,
+					"team": {
+						"name": "myTeam",
+						"auth": {"owner": {"users": ["local:username"]}},
+						"container_limits": {"cpu": 512},
+						"notifiers": [{"name": "chat", "type": "slack", "url": "https://hooks.example.com/chat"}]
+					},
+					"pipelines": [{"name": "old-pipeline", "paused": true, "archived": true}]
+				}`), 0644)
+				Expect(err).NotTo(HaveOccurred())
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/otherTeam"),
+						ghttp.VerifyJSON(`{
+							"auth": {"owner": {"users": ["local:username"]}},
+							"container_limits": {"cpu": 512},
+							"notifiers": [{"name": "chat", "type": "slack", "url": "https://hooks.example.com/chat"}]
+						}`),
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.Team{Name: "otherTeam"}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/otherTeam/pipelines/old-pipeline/config"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/otherTeam/pipelines/old-pipeline/config"),
+						ghttp.RespondWith(http.StatusCreated, "{}"),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/otherTeam/pipelines/old-pipeline/archive"),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/otherTeam/pipelines/ordering"),
+						ghttp.VerifyJSON(`["old-pipeline"]`),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+				)
+			})
+
+			It("recreates the team and its pipelines", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "import-team", "-i", archivePath, "-n", "otherTeam", "--non-interactive")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("importing 1 pipelines into team otherTeam"))
+				Expect(sess.Out).To(gbytes.Say("team created"))
+				Expect(sess.Out).To(gbytes.Say("set pipeline old-pipeline"))
+				Expect(sess.Out).To(gbytes.Say("archived"))
+				Expect(sess.Out).To(gbytes.Say("team imported"))
+			})
+		})
+
+		Context("when the archive was written by a newer fly", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(archivePath, []byte(`{"version": 99}`), 0644)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "import-team", "-i", archivePath, "--non-interactive")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("please upgrade fly"))
+			})
+		})
+	})
+})
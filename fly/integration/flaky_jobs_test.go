@@ -0,0 +1,115 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("flaky-jobs", func() {
+		var (
+			flyCmd *exec.Cmd
+			jobs   []atc.FlakyJob
+		)
+
+		BeforeEach(func() {
+			jobs = []atc.FlakyJob{
+				{
+					TeamName:     "main",
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+					FlakyBuilds:  2,
+					Builds:       8,
+					LastFlaky:    time.Date(2021, time.July, 20, 0, 0, 0, 0, time.UTC).Unix(),
+				},
+				{
+					TeamName:             "main",
+					PipelineName:         "other-pipeline",
+					PipelineInstanceVars: atc.InstanceVars{"branch": "main"},
+					JobName:              "other-job",
+					FlakyBuilds:          1,
+					Builds:               10,
+				},
+			}
+		})
+
+		Context("when no filters are given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "flaky-jobs")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/flaky_jobs", ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, jobs),
+					),
+				)
+			})
+
+			It("lists the flaky jobs", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say(`some-pipeline/some-job\s+2\s+8\s+25\.0%\s+2021-07-`))
+				Expect(sess.Out).To(gbytes.Say(`other-pipeline/branch:main/other-job\s+1\s+10\s+10\.0%\s+n/a`))
+			})
+		})
+
+		Context("when a pipeline is given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "flaky-jobs", "-p", "some-pipeline", "--json")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/flaky_jobs", "pipeline_name=some-pipeline"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, jobs[:1]),
+					),
+				)
+			})
+
+			It("prints the flaky jobs of the pipeline as JSON", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out.Contents()).To(MatchJSON(`[{
+					"team_name": "main",
+					"pipeline_name": "some-pipeline",
+					"job_name": "some-job",
+					"flaky_builds": 2,
+					"builds": 8,
+					"last_flaky": 1626739200
+				}]`))
+			})
+		})
+
+		Context("when the pipeline is not found", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "flaky-jobs", "-p", "some-pipeline")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/flaky_jobs"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("pipeline not found"))
+			})
+		})
+	})
+})
@@ -0,0 +1,149 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("job-stats", func() {
+		var (
+			flyCmd *exec.Cmd
+			stats  atc.BuildStats
+		)
+
+		BeforeEach(func() {
+			stats = atc.BuildStats{
+				Since:       time.Date(2021, time.July, 1, 0, 0, 0, 0, time.UTC).Unix(),
+				Until:       time.Date(2021, time.July, 31, 0, 0, 0, 0, time.UTC).Unix(),
+				Builds:      map[atc.BuildStatus]int{atc.StatusSucceeded: 3, atc.StatusFailed: 1},
+				SuccessRate: 0.75,
+				Duration: atc.DurationPercentiles{
+					P50: 60,
+					P90: 90.4,
+					P99: 120,
+				},
+				QueueTime: atc.DurationPercentiles{
+					P50: 1,
+					P90: 2,
+					P99: 3,
+				},
+				Recoveries:         1,
+				MeanTimeToRecovery: 300,
+				Reruns:             2,
+				FlippedReruns:      1,
+				Flakiness:          0.5,
+			}
+		})
+
+		Context("when no filters are given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "job-stats")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/stats", ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, stats),
+					),
+				)
+			})
+
+			It("prints the stats of the team's builds", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say(`builds\s+4 \(succeeded: 3, failed: 1\)`))
+				Expect(sess.Out).To(gbytes.Say(`success rate\s+75\.0%`))
+				Expect(sess.Out).To(gbytes.Say(`duration\s+p50 1m0s, p90 1m30s, p99 2m0s`))
+				Expect(sess.Out).To(gbytes.Say(`queue time\s+p50 1s, p90 2s, p99 3s`))
+				Expect(sess.Out).To(gbytes.Say(`recoveries\s+1`))
+				Expect(sess.Out).To(gbytes.Say(`mean time to recovery\s+5m0s`))
+				Expect(sess.Out).To(gbytes.Say(`reruns\s+2 \(1 flipped\)`))
+				Expect(sess.Out).To(gbytes.Say(`flakiness\s+50\.0%`))
+			})
+		})
+
+		Context("when filters are given", func() {
+			BeforeEach(func() {
+				since := time.Date(2021, time.July, 1, 0, 0, 0, 0, time.Now().Location())
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "job-stats", "-j", "some-pipeline/some-job", "--since", since.Format("2006-01-02 15:04:05"), "--json")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/stats"),
+						ghttp.VerifyFormKV("pipeline_name", "some-pipeline"),
+						ghttp.VerifyFormKV("job_name", "some-job"),
+						ghttp.VerifyFormKV("since", strconv.FormatInt(since.Unix(), 10)),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, stats),
+					),
+				)
+			})
+
+			It("passes them along", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out.Contents()).To(MatchJSON(`{
+					"since": 1625097600,
+					"until": 1627689600,
+					"builds": {"succeeded": 3, "failed": 1},
+					"success_rate": 0.75,
+					"duration": {"p50": 60, "p90": 90.4, "p99": 120},
+					"queue_time": {"p50": 1, "p90": 2, "p99": 3},
+					"recoveries": 1,
+					"mean_time_to_recovery": 300,
+					"reruns": 2,
+					"flipped_reruns": 1,
+					"flakiness": 0.5
+				}`))
+			})
+		})
+
+		Context("when the job is not found", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "job-stats", "-j", "some-pipeline/some-job")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/stats"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("pipeline/job not found"))
+			})
+		})
+
+		Context("when both --pipeline and --job are given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "job-stats", "-p", "some-pipeline", "-j", "some-pipeline/some-job")
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("Cannot specify both --pipeline and --job"))
+			})
+		})
+	})
+})
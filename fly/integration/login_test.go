@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"regexp"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -331,7 +333,7 @@ var _ = Describe("login Command", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				Eventually(sess.Out).Should(gbytes.Say("navigate to the following URL in your browser:"))
-				Eventually(sess.Out).Should(gbytes.Say("http://127.0.0.1:(\\d+)/login\\?fly_port=(\\d+)"))
+				Eventually(sess.Out).Should(gbytes.Say("http://127.0.0.1:(\\d+)/login\\?fly_port=(\\d+)&fly_refresh=true"))
 				Eventually(sess.Out).Should(gbytes.Say("or enter token manually"))
 
 				_, err = fmt.Fprintf(stdin, "Bearer some-token\n")
@@ -344,6 +346,30 @@ var _ = Describe("login Command", func() {
 				Expect(sess.ExitCode()).To(Equal(0))
 			})
 
+			Context("with --no-refresh", func() {
+				It("does not ask for a refresh token", func() {
+					flyCmd = exec.Command(flyPath, "-t", "some-target", "login", "-c", loginATCServer.URL(), "--no-refresh")
+
+					stdin, err := flyCmd.StdinPipe()
+					Expect(err).NotTo(HaveOccurred())
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess.Out).Should(gbytes.Say("or enter token manually"))
+					Expect(string(sess.Out.Contents())).NotTo(ContainSubstring("fly_refresh"))
+
+					_, err = fmt.Fprintf(stdin, "Bearer some-token\n")
+					Expect(err).NotTo(HaveOccurred())
+
+					err = stdin.Close()
+					Expect(err).NotTo(HaveOccurred())
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(0))
+				})
+			})
+
 			Context("when the token from stdin is malformed", func() {
 				It("logs an error and accepts further input", func() {
 					flyCmd = exec.Command(flyPath, "-t", "some-target", "login", "-c", loginATCServer.URL())
@@ -465,6 +491,38 @@ var _ = Describe("login Command", func() {
 					Expect(resp.StatusCode).To(Equal(http.StatusOK))
 				})
 
+				Context("when the request posts a refresh token", func() {
+					BeforeEach(func() {
+						form := url.Values{
+							"token":         {"Bearer some-token"},
+							"refresh_token": {"some-refresh-token"},
+						}
+
+						var err error
+						req, err = http.NewRequest("POST", "http://"+req.URL.Host+"/", strings.NewReader(form.Encode()))
+						Expect(err).NotTo(HaveOccurred())
+						req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+					})
+
+					It("saves the refresh token", func() {
+						flyRcContents, err := ioutil.ReadFile(homeDir + "/.flyrc")
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(flyRcContents)).To(ContainSubstring("refresh_token: some-refresh-token"))
+					})
+				})
+
+				Context("when the refresh token is in the URL", func() {
+					BeforeEach(func() {
+						req.URL.RawQuery += "&refresh_token=some-refresh-token"
+					})
+
+					It("ignores it", func() {
+						flyRcContents, err := ioutil.ReadFile(homeDir + "/.flyrc")
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(flyRcContents)).NotTo(ContainSubstring("refresh_token"))
+					})
+				})
+
 				Context("when the request comes from a human operating a browser", func() {
 					BeforeEach(func() {
 						req.Header.Add("Upgrade-Insecure-Requests", "1")
@@ -479,6 +537,39 @@ var _ = Describe("login Command", func() {
 			})
 		})
 
+		Context("with password grant and --no-refresh", func() {
+			BeforeEach(func() {
+				loginATCServer.AppendHandlers(
+					infoHandler(),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/sky/issuer/token"),
+						ghttp.VerifyFormKV("grant_type", "password"),
+						ghttp.VerifyFormKV("scope", "openid profile email federated:id groups"),
+						ghttp.RespondWithJSONEncoded(200, map[string]string{
+							"token_type":   "Bearer",
+							"access_token": "access-token",
+						}),
+					),
+					userInfoHandler(),
+				)
+			})
+
+			It("does not request a refresh token", func() {
+				flyCmd = exec.Command(flyPath, "-t", "some-target", "login", "-c", loginATCServer.URL(), "-u", "some_username", "-p", "some_password", "--no-refresh")
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("target saved"))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(0))
+
+				flyRcContents, err := ioutil.ReadFile(homeDir + "/.flyrc")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(flyRcContents)).NotTo(ContainSubstring("refresh_token"))
+			})
+		})
+
 		Context("with password grant", func() {
 			BeforeEach(func() {
 				credentials := base64.StdEncoding.EncodeToString([]byte("fly:Zmx5"))
@@ -491,10 +582,11 @@ var _ = Describe("login Command", func() {
 						ghttp.VerifyFormKV("grant_type", "password"),
 						ghttp.VerifyFormKV("username", "some_username"),
 						ghttp.VerifyFormKV("password", "some_password"),
-						ghttp.VerifyFormKV("scope", "openid profile email federated:id groups"),
+						ghttp.VerifyFormKV("scope", "openid profile email federated:id groups offline_access"),
 						ghttp.RespondWithJSONEncoded(200, map[string]string{
-							"token_type":   "Bearer",
-							"access_token": "access-token",
+							"token_type":    "Bearer",
+							"access_token":  "access-token",
+							"refresh_token": "refresh-token",
 						}),
 					),
 					userInfoHandler(),
@@ -562,6 +654,63 @@ var _ = Describe("login Command", func() {
 					})
 				})
 
+				It("saves the refresh token", func() {
+					flyRcContents, err := ioutil.ReadFile(homeDir + "/.flyrc")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(flyRcContents)).To(ContainSubstring("refresh_token: refresh-token"))
+				})
+
+				Describe("running other commands after the token has expired", func() {
+					BeforeEach(func() {
+						credentials := base64.StdEncoding.EncodeToString([]byte("fly:Zmx5"))
+
+						loginATCServer.AppendHandlers(
+							infoHandler(),
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines"),
+								ghttp.VerifyHeaderKV("Authorization", "Bearer access-token"),
+								ghttp.RespondWith(401, ""),
+							),
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("POST", "/sky/issuer/token"),
+								ghttp.VerifyHeaderKV("Authorization", fmt.Sprintf("Basic %s", credentials)),
+								ghttp.VerifyFormKV("grant_type", "refresh_token"),
+								ghttp.VerifyFormKV("refresh_token", "refresh-token"),
+								ghttp.RespondWithJSONEncoded(200, map[string]string{
+									"token_type":    "Bearer",
+									"access_token":  "refreshed-token",
+									"refresh_token": "new-refresh-token",
+								}),
+							),
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines"),
+								ghttp.VerifyHeaderKV("Authorization", "Bearer refreshed-token"),
+								ghttp.RespondWithJSONEncoded(200, []atc.Pipeline{
+									{Name: "pipeline-1"},
+								}),
+							),
+						)
+					})
+
+					It("refreshes the token and retries", func() {
+						otherCmd := exec.Command(flyPath, "-t", "some-target", "pipelines")
+
+						sess, err := gexec.Start(otherCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						<-sess.Exited
+
+						Expect(sess).To(gbytes.Say("pipeline-1"))
+
+						Expect(sess.ExitCode()).To(Equal(0))
+
+						flyRcContents, err := ioutil.ReadFile(homeDir + "/.flyrc")
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(flyRcContents)).To(ContainSubstring("value: refreshed-token"))
+						Expect(string(flyRcContents)).To(ContainSubstring("refresh_token: new-refresh-token"))
+					})
+				})
+
 				Describe("logging in again with the same target", func() {
 					BeforeEach(func() {
 						credentials := base64.StdEncoding.EncodeToString([]byte("fly:Zmx5"))
@@ -575,7 +724,7 @@ var _ = Describe("login Command", func() {
 								ghttp.VerifyFormKV("grant_type", "password"),
 								ghttp.VerifyFormKV("username", "some_other_user"),
 								ghttp.VerifyFormKV("password", "some_other_pass"),
-								ghttp.VerifyFormKV("scope", "openid profile email federated:id groups"),
+								ghttp.VerifyFormKV("scope", "openid profile email federated:id groups offline_access"),
 								ghttp.RespondWithJSONEncoded(200, map[string]string{
 									"token_type":   "Bearer",
 									"access_token": "some-new-token",
@@ -0,0 +1,175 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("search-logs", func() {
+		var (
+			flyCmd  *exec.Cmd
+			matches []atc.BuildLogMatch
+		)
+
+		BeforeEach(func() {
+			matches = []atc.BuildLogMatch{
+				{
+					ID:           12,
+					TeamName:     "main",
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+					BuildID:      3,
+					BuildName:    "4",
+					Origin:       "some-origin",
+					Time:         100,
+					Line:         "dial tcp: connection refused",
+					Before:       []string{"connecting to db"},
+					After:        []string{"retrying"},
+				},
+				{
+					ID:        9,
+					TeamName:  "main",
+					BuildID:   1,
+					BuildName: "1",
+					Origin:    "other-origin",
+					Time:      50,
+					Line:      "connection refused again",
+				},
+			}
+		})
+
+		Context("when only a query is given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "connection refused")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/logs", "query=connection+refused&limit=50"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, matches),
+					),
+				)
+			})
+
+			It("prints each matching line under a link to its build", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say(`some-pipeline/some-job #4 ` + atcServer.URL() + `/builds/3\n`))
+				Expect(sess.Out).To(gbytes.Say(`  connecting to db\n`))
+				Expect(sess.Out).To(gbytes.Say(`> dial tcp: connection refused\n`))
+				Expect(sess.Out).To(gbytes.Say(`  retrying\n`))
+				Expect(sess.Out).To(gbytes.Say(`\n#1 ` + atcServer.URL() + `/builds/1\n`))
+				Expect(sess.Out).To(gbytes.Say(`> connection refused again\n`))
+			})
+		})
+
+		Context("when filters are given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "oops", "-j", "some-pipeline/some-job", "-C", "2", "-c", "1", "--json")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/logs", "query=oops&pipeline_name=some-pipeline&job_name=some-job&context=2&limit=1"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, matches[:1], http.Header{
+							"Link": []string{`<` + atcServer.URL() + `/api/v1/teams/main/logs?query=oops&to=11&limit=1>; rel="next"`},
+						}),
+					),
+				)
+			})
+
+			It("passes them along and stops once enough lines are found", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out.Contents()).To(MatchJSON(`[
+					{
+						"id": 12,
+						"team_name": "main",
+						"pipeline_name": "some-pipeline",
+						"job_name": "some-job",
+						"build_id": 3,
+						"build_name": "4",
+						"origin": "some-origin",
+						"time": 100,
+						"line": "dial tcp: connection refused",
+						"before": ["connecting to db"],
+						"after": ["retrying"]
+					}
+				]`))
+			})
+		})
+
+		Context("when the results span several pages", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "connection", "-c", "2")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/logs", "query=connection&limit=2"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, matches[:1], http.Header{
+							"Link": []string{`<` + atcServer.URL() + `/api/v1/teams/main/logs?query=connection&to=11&limit=2>; rel="next"`},
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/logs", "query=connection&to=11&limit=2"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, matches[1:]),
+					),
+				)
+			})
+
+			It("follows the pages", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say(`> dial tcp: connection refused\n`))
+				Expect(sess.Out).To(gbytes.Say(`> connection refused again\n`))
+			})
+		})
+
+		Context("when build log search is disabled", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "oops")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/logs"),
+						ghttp.RespondWith(http.StatusForbidden, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("build log search is disabled"))
+			})
+		})
+
+		Context("when both --pipeline and --job are given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "oops", "-p", "some-pipeline", "-j", "some-pipeline/some-job")
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("Cannot specify both --pipeline and --job"))
+			})
+		})
+	})
+})
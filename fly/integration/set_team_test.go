@@ -403,6 +403,74 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Describe("sending notifiers", func() {
+			BeforeEach(func() {
+				cmdParams = []string{
+					"--local-user", "brock-obama",
+					"--notifiers", "fixtures/team_notifiers.yml",
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+						ghttp.VerifyJSON(`{
+							"auth": {
+								"owner":{
+									"users": ["local:brock-obama"],
+									"groups": []
+								}
+							},
+							"notifiers": [
+								{
+									"name": "chat",
+									"type": "slack",
+									"url": "((slack_webhook))",
+									"events": ["failed", "errored"]
+								}
+							]
+						}`),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+							Name: "venture",
+							ID:   8,
+						}),
+					),
+				)
+			})
+
+			It("shows and sends the notifiers", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("notifiers:"))
+				Eventually(sess.Out).Should(gbytes.Say(`- chat \(slack\)`))
+
+				Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+
+			Context("when a notifier is invalid", func() {
+				BeforeEach(func() {
+					cmdParams = []string{
+						"--local-user", "brock-obama",
+						"--notifiers", "fixtures/team_notifiers_invalid.yml",
+					}
+				})
+
+				It("fails without setting the team", func() {
+					sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(sess.Err).Should(gbytes.Say("invalid notifier 'chat': url is missing"))
+					Eventually(sess).Should(gexec.Exit(1))
+				})
+			})
+		})
+
 		Describe("handling server response", func() {
 			BeforeEach(func() {
 				cmdParams = []string{"-c", "fixtures/team_config_mixed.yml"}
@@ -717,6 +785,58 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Describe("sending default task limits", func() {
+			BeforeEach(func() {
+				cmdParams = []string{
+					"--local-user", "brock-obama",
+					"--default-task-cpu-limit", "512",
+					"--default-task-memory-limit", "1GB",
+					"--default-task-disk-limit", "10GB",
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+						ghttp.VerifyJSON(`{
+							"auth": {
+								"owner":{
+									"users": ["local:brock-obama"],
+									"groups": []
+								}
+							},
+							"container_limits": {
+								"cpu": 512,
+								"memory": 1073741824,
+								"disk": 10737418240
+							}
+						}`),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Team{
+							Name: "venture",
+							ID:   8,
+						}),
+					),
+				)
+			})
+
+			It("shows and sends the limits", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("default task limits:"))
+				Eventually(sess.Out).Should(gbytes.Say("cpu: 512"))
+				Eventually(sess.Out).Should(gbytes.Say("memory: 1073741824"))
+				Eventually(sess.Out).Should(gbytes.Say("disk: 10737418240"))
+
+				Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
 		Describe("handling server response", func() {
 			BeforeEach(func() {
 				cmdParams = []string{"--local-user", "brock-obama"}
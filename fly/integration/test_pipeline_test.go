@@ -0,0 +1,73 @@
+package integration_test
+
+import (
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("test-pipeline", func() {
+		It("reports the assertions which failed", func() {
+			flyCmd := exec.Command(
+				flyPath,
+				"test-pipeline",
+				"-c", "fixtures/test-pipeline.yml",
+				"-a", "fixtures/test-pipeline-assertions.yml",
+				"-v", "uri=https://example.com/repo.git",
+			)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(1))
+
+			Expect(sess.Out).To(gbytes.Say("✓ deploy gets repo after unit"))
+			Expect(sess.Out).To(gbytes.Say("✗ every put has an ensure"))
+			Expect(sess.Out).To(gbytes.Say(`jobs\[name=deploy\]\.plan\[2\]: missing 'ensure'`))
+			Expect(sess.Out).To(gbytes.Say("✗ no task runs privileged"))
+			Expect(sess.Out).To(gbytes.Say(`expected 0 matches, found 1 at deploy\.do\[1\]\.task`))
+			Expect(sess.Out).To(gbytes.Say("✓ repo is fetched from the configured uri"))
+			Expect(sess.Err).To(gbytes.Say("2 of 4 assertions failed"))
+		})
+
+		It("passes when every assertion passes", func() {
+			flyCmd := exec.Command(
+				flyPath,
+				"test-pipeline",
+				"-c", "fixtures/testConfigValid.yml",
+				"-a", "fixtures/test-pipeline-assertions-valid.yml",
+			)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(0))
+
+			Expect(sess.Out).To(gbytes.Say("1 of 1 assertions passed"))
+		})
+
+		It("fails on invalid configuration", func() {
+			flyCmd := exec.Command(
+				flyPath,
+				"test-pipeline",
+				"-c", "fixtures/testConfigError.yml",
+				"-a", "fixtures/test-pipeline-assertions.yml",
+			)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(1))
+
+			Expect(sess.Err).To(gbytes.Say("configuration invalid"))
+		})
+	})
+})
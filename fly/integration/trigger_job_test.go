@@ -65,6 +65,45 @@ var _ = Describe("Fly CLI", func() {
 						})
 					})
 
+					Context("when a traceparent is provided", func() {
+						const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+						BeforeEach(func() {
+							atcServer.AppendHandlers(
+								ghttp.CombineHandlers(
+									ghttp.VerifyRequest("POST", mainPath),
+									ghttp.VerifyHeaderKV("traceparent", traceparent),
+									ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 57, Name: "42"}),
+								),
+							)
+						})
+
+						It("starts the build as part of the trace", func() {
+							flyCmd := exec.Command(flyPath, "-t", targetName, "trigger-job", "-j", "awesome-pipeline/awesome-job", "--traceparent", traceparent)
+
+							sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+							Expect(err).NotTo(HaveOccurred())
+
+							Eventually(sess).Should(gbytes.Say(`started awesome-pipeline/awesome-job #42`))
+
+							<-sess.Exited
+							Expect(sess.ExitCode()).To(Equal(0))
+						})
+
+						It("reads the traceparent from the environment, as set in put steps", func() {
+							flyCmd := exec.Command(flyPath, "-t", targetName, "trigger-job", "-j", "awesome-pipeline/awesome-job")
+							flyCmd.Env = append(os.Environ(), "TRACEPARENT="+traceparent)
+
+							sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+							Expect(err).NotTo(HaveOccurred())
+
+							Eventually(sess).Should(gbytes.Say(`started awesome-pipeline/awesome-job #42`))
+
+							<-sess.Exited
+							Expect(sess.ExitCode()).To(Equal(0))
+						})
+					})
+
 					Context("user is NOT targeting the same team that the pipeline belongs to", func() {
 
 						BeforeEach(func() {
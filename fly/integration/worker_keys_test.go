@@ -0,0 +1,312 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	const publicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKb5CMmqALodQnrtwOEAxZhDT2Hn/3zi5mJcq88w9NSr worker@example.com"
+
+	Describe("worker-keys", func() {
+		var (
+			flyCmd *exec.Cmd
+			keys   []atc.WorkerKey
+		)
+
+		BeforeEach(func() {
+			keys = []atc.WorkerKey{
+				{ID: 1, PublicKey: publicKey, Fingerprint: "SHA256:global", CreatedAt: 1600000000},
+				{ID: 2, TeamName: "main", PublicKey: publicKey, Fingerprint: "SHA256:main", CreatedAt: 1600000000, LastUsedAt: 1600003600},
+			}
+		})
+
+		Context("without flags", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "worker-keys")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/worker-keys"),
+						ghttp.RespondWithJSONEncoded(200, keys[1:]),
+					),
+				)
+			})
+
+			It("lists the keys of the target team", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "id", Color: color.New(color.Bold)},
+						{Contents: "team", Color: color.New(color.Bold)},
+						{Contents: "fingerprint", Color: color.New(color.Bold)},
+						{Contents: "created", Color: color.New(color.Bold)},
+						{Contents: "last used", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "2"},
+							{Contents: "main"},
+							{Contents: "SHA256:main"},
+							{Contents: time.Unix(1600000000, 0).Format("2006-01-02@15:04:05-0700")},
+							{Contents: time.Unix(1600003600, 0).Format("2006-01-02@15:04:05-0700")},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("with --all", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "worker-keys", "--all")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/worker-keys"),
+						ghttp.RespondWithJSONEncoded(200, keys),
+					),
+				)
+			})
+
+			It("lists all the keys", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "id", Color: color.New(color.Bold)},
+						{Contents: "team", Color: color.New(color.Bold)},
+						{Contents: "fingerprint", Color: color.New(color.Bold)},
+						{Contents: "created", Color: color.New(color.Bold)},
+						{Contents: "last used", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "1"},
+							{Contents: "none", Color: color.New(color.Faint)},
+							{Contents: "SHA256:global"},
+							{Contents: time.Unix(1600000000, 0).Format("2006-01-02@15:04:05-0700")},
+							{Contents: "never", Color: color.New(color.Faint)},
+						},
+						{
+							{Contents: "2"},
+							{Contents: "main"},
+							{Contents: "SHA256:main"},
+							{Contents: time.Unix(1600000000, 0).Format("2006-01-02@15:04:05-0700")},
+							{Contents: time.Unix(1600003600, 0).Format("2006-01-02@15:04:05-0700")},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("with --json", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "worker-keys", "--json")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/worker-keys"),
+						ghttp.RespondWithJSONEncoded(200, keys[1:]),
+					),
+				)
+			})
+
+			It("prints the keys as JSON", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out.Contents()).To(MatchJSON(`[{
+					"id": 2,
+					"team_name": "main",
+					"public_key": "` + publicKey + `",
+					"fingerprint": "SHA256:main",
+					"created_at": 1600000000,
+					"last_used_at": 1600003600
+				}]`))
+			})
+		})
+
+		Context("with both --all and --team", func() {
+			It("fails", func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "worker-keys", "--all", "--team", "other-team")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("--all and --team are mutually exclusive"))
+			})
+		})
+	})
+
+	Describe("add-worker-key", func() {
+		var (
+			flyCmd  *exec.Cmd
+			keyFile string
+		)
+
+		BeforeEach(func() {
+			dir, err := ioutil.TempDir("", "fly-worker-key")
+			Expect(err).NotTo(HaveOccurred())
+
+			keyFile = filepath.Join(dir, "worker_key.pub")
+			Expect(ioutil.WriteFile(keyFile, []byte(publicKey+"\n"), 0644)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(filepath.Dir(keyFile))).To(Succeed())
+		})
+
+		Context("for the target team", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "add-worker-key", "-k", keyFile)
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v1/teams/main/worker-keys"),
+						ghttp.VerifyJSON(`{"id":0,"public_key":"`+publicKey+`\n"}`),
+						ghttp.RespondWithJSONEncoded(201, atc.WorkerKey{ID: 3, TeamName: "main", Fingerprint: "SHA256:main"}),
+					),
+				)
+			})
+
+			It("adds the key", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say(`added worker key 3 \(SHA256:main\)`))
+			})
+		})
+
+		Context("with --global", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "add-worker-key", "-k", keyFile, "--global")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v1/worker-keys"),
+						ghttp.VerifyJSON(`{"id":0,"public_key":"`+publicKey+`\n"}`),
+						ghttp.RespondWithJSONEncoded(201, atc.WorkerKey{ID: 4, Fingerprint: "SHA256:global"}),
+					),
+				)
+			})
+
+			It("adds a key for global workers", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say(`added worker key 4 \(SHA256:global\)`))
+			})
+		})
+
+		Context("when the key already exists", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "add-worker-key", "-k", keyFile)
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v1/teams/main/worker-keys"),
+						ghttp.RespondWith(409, "worker key already exists"),
+					),
+				)
+			})
+
+			It("fails with the message", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("worker key already exists"))
+			})
+		})
+	})
+
+	Describe("revoke-worker-key", func() {
+		var flyCmd *exec.Cmd
+
+		Context("for the target team", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "revoke-worker-key", "-i", "2")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/main/worker-keys/2"),
+						ghttp.RespondWith(204, nil),
+					),
+				)
+			})
+
+			It("revokes the key", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("revoked worker key 2"))
+			})
+		})
+
+		Context("with --global", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "revoke-worker-key", "-i", "1", "--global")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/worker-keys/1"),
+						ghttp.RespondWith(204, nil),
+					),
+				)
+			})
+
+			It("revokes the key", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("revoked worker key 1"))
+			})
+		})
+
+		Context("when the key does not exist", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "revoke-worker-key", "-i", "5")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/main/worker-keys/5"),
+						ghttp.RespondWith(404, nil),
+					),
+				)
+			})
+
+			It("fails", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("worker key 5 not found"))
+			})
+		})
+	})
+})
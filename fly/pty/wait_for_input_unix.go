@@ -0,0 +1,27 @@
+// +build !windows
+
+package pty
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// WaitForInput reports whether there is input to read from stdin, waiting up
+// to the given timeout for some to arrive.
+func WaitForInput(timeout time.Duration) (bool, error) {
+	fds := []unix.PollFd{{Fd: int32(os.Stdin.Fd()), Events: unix.POLLIN}}
+
+	n, err := unix.Poll(fds, int(timeout/time.Millisecond))
+	if err != nil {
+		if err == unix.EINTR {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return n > 0, nil
+}
@@ -0,0 +1,11 @@
+// +build windows
+
+package pty
+
+import "time"
+
+// WaitForInput always reports input as available, as reads from the console
+// block until some arrives.
+func WaitForInput(time.Duration) (bool, error) {
+	return true, nil
+}
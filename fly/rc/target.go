@@ -17,7 +17,6 @@ import (
 	"github.com/concourse/concourse/fly/version"
 	"github.com/concourse/concourse/go-concourse/concourse"
 	semisemanticversion "github.com/cppforlife/go-semi-semantic/version"
-	"golang.org/x/oauth2"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -152,7 +151,7 @@ func LoadTarget(selectedTarget TargetName, tracing bool) (Target, error) {
 		return nil, err
 	}
 
-	httpClient := defaultHttpClient(targetProps.Token, targetProps.Insecure, caCertPool, clientCertificate)
+	httpClient := defaultHttpClient(selectedTarget, targetProps.API, targetProps.Token, targetProps.Insecure, caCertPool, clientCertificate)
 	client := concourse.NewClient(targetProps.API, httpClient, tracing)
 
 	return NewTarget(
@@ -290,7 +289,7 @@ func NewAuthenticatedTarget(
 		return nil, err
 	}
 
-	httpClient := defaultHttpClient(token, insecure, caCertPool, clientCertificate)
+	httpClient := defaultHttpClient(name, url, token, insecure, caCertPool, clientCertificate)
 	client := concourse.NewClient(url, httpClient, tracing)
 
 	return NewTarget(
@@ -500,21 +499,15 @@ func (t *target) getInfo() (atc.Info, error) {
 	return t.info, err
 }
 
-func defaultHttpClient(token *TargetToken, insecure bool, caCertPool *x509.CertPool, clientCertificate []tls.Certificate) *http.Client {
-	var oAuthToken *oauth2.Token
-	if token != nil {
-		oAuthToken = &oauth2.Token{
-			TokenType:   token.Type,
-			AccessToken: token.Value,
-		}
-	}
-
+func defaultHttpClient(targetName TargetName, api string, token *TargetToken, insecure bool, caCertPool *x509.CertPool, clientCertificate []tls.Certificate) *http.Client {
 	transport := transport(insecure, caCertPool, clientCertificate)
 
 	if token != nil {
-		transport = &oauth2.Transport{
-			Source: oauth2.StaticTokenSource(oAuthToken),
-			Base:   transport,
+		transport = &TokenTransport{
+			TargetName: targetName,
+			API:        api,
+			Token:      token,
+			Base:       transport,
 		}
 	}
 
@@ -11,7 +11,6 @@ import (
 
 	"github.com/concourse/concourse/fly/rc"
 	fakes "github.com/concourse/concourse/go-concourse/concourse/concoursefakes"
-	"golang.org/x/oauth2"
 	"sigs.k8s.io/yaml"
 
 	. "github.com/onsi/ginkgo"
@@ -146,12 +145,12 @@ Lfkzl8ebb+tt0XFMUFc42WNr
 			It("loads target with correct transport", func() {
 				target, err := rc.LoadTarget("some-target", false)
 				Expect(err).NotTo(HaveOccurred())
-				transport, ok := target.Client().HTTPClient().Transport.(*oauth2.Transport)
+				transport, ok := target.Client().HTTPClient().Transport.(*rc.TokenTransport)
 				Expect(ok).To(BeTrue())
-				Expect((*transport).Source).To(Equal(oauth2.StaticTokenSource(&oauth2.Token{
-					TokenType:   "Bearer",
-					AccessToken: "some-token",
-				})))
+				Expect((*transport).Token).To(Equal(&rc.TargetToken{
+					Type:  "Bearer",
+					Value: "some-token",
+				}))
 				base, ok := (*transport).Base.(*http.Transport)
 				Expect(ok).To(BeTrue())
 				Expect((*base).TLSClientConfig).To(Equal(&tls.Config{
@@ -186,7 +185,7 @@ Lfkzl8ebb+tt0XFMUFc42WNr
 			It("loads target with correct transport", func() {
 				target, err := rc.LoadTarget("some-target", false)
 				Expect(err).NotTo(HaveOccurred())
-				transport, ok := target.Client().HTTPClient().Transport.(*oauth2.Transport)
+				transport, ok := target.Client().HTTPClient().Transport.(*rc.TokenTransport)
 				Expect(ok).To(BeTrue())
 				base, ok := (*transport).Base.(*http.Transport)
 				Expect(ok).To(BeTrue())
@@ -244,7 +243,7 @@ Lfkzl8ebb+tt0XFMUFc42WNr
 			It("loads target with correct transport", func() {
 				target, err := rc.LoadTarget("some-target", false)
 				Expect(err).NotTo(HaveOccurred())
-				transport, ok := target.Client().HTTPClient().Transport.(*oauth2.Transport)
+				transport, ok := target.Client().HTTPClient().Transport.(*rc.TokenTransport)
 				Expect(ok).To(BeTrue())
 				base, ok := (*transport).Base.(*http.Transport)
 				Expect(ok).To(BeTrue())
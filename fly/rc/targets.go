@@ -43,8 +43,9 @@ type TargetProps struct {
 }
 
 type TargetToken struct {
-	Type  string `json:"type"`
-	Value string `json:"value"`
+	Type         string `json:"type"`
+	Value        string `json:"value"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 func flyrcPath() string {
@@ -102,6 +103,25 @@ func UpdateTargetProps(targetName TargetName, targetProps TargetProps) error {
 	return writeTargets(flyrcPath(), flyTargets)
 }
 
+// UpdateTargetToken replaces the token of an existing target, e.g. after it
+// has been refreshed. Unknown targets are left alone.
+func UpdateTargetToken(targetName TargetName, token *TargetToken) error {
+	flyTargets, err := LoadTargets()
+	if err != nil {
+		return err
+	}
+
+	target, ok := flyTargets[targetName]
+	if !ok {
+		return UnknownTargetError{targetName}
+	}
+
+	target.Token = token
+	flyTargets[targetName] = target
+
+	return writeTargets(flyrcPath(), flyTargets)
+}
+
 func UpdateTargetName(targetName TargetName, newTargetName TargetName) error {
 	flyTargets, err := LoadTargets()
 	if err != nil {
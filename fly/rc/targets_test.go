@@ -166,6 +166,36 @@ var _ = Describe("Targets", func() {
 				}))
 			})
 		})
+
+		Context("when a token is provided for update", func() {
+			It("should replace the token and keep the other attributes", func() {
+				err := rc.UpdateTargetToken("some-target", &rc.TargetToken{
+					Type:         "Bearer",
+					Value:        "new-token",
+					RefreshToken: "new-refresh-token",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				targets, err := rc.LoadTargets()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(targets).To(Equal(rc.Targets{
+					"some-target": {
+						API:      "http://concourse.com",
+						TeamName: "main",
+						Token: &rc.TargetToken{
+							Type:         "Bearer",
+							Value:        "new-token",
+							RefreshToken: "new-refresh-token",
+						},
+					},
+				}))
+			})
+
+			It("errors for an unknown target", func() {
+				err := rc.UpdateTargetToken("bogus-target", &rc.TargetToken{})
+				Expect(err).To(Equal(rc.UnknownTargetError{TargetName: "bogus-target"}))
+			})
+		})
 	})
 
 	Describe("SaveTarget", func() {
@@ -0,0 +1,123 @@
+package rc
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// OAuth2Config returns the config fly uses to request tokens from the
+// target's token endpoint.
+func OAuth2Config(api string, scopes ...string) oauth2.Config {
+	return oauth2.Config{
+		ClientID:     "fly",
+		ClientSecret: "Zmx5",
+		Endpoint:     oauth2.Endpoint{TokenURL: api + "/sky/issuer/token"},
+		Scopes:       scopes,
+	}
+}
+
+// TokenTransport authorizes requests with the target's token. When the token
+// has expired and a refresh token was saved along with it, the token is
+// refreshed, saved to the .flyrc, and the request is retried once.
+type TokenTransport struct {
+	TargetName TargetName
+	API        string
+	Token      *TargetToken
+	Base       http.RoundTripper
+
+	lock sync.Mutex
+}
+
+func (t *TokenTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	token := t.currentToken()
+
+	response, err := t.Base.RoundTrip(authorize(r, token))
+	if err != nil || response.StatusCode != http.StatusUnauthorized {
+		return response, err
+	}
+
+	if token.RefreshToken == "" {
+		return response, nil
+	}
+
+	// requests with a body can only be retried if it can be read again
+	retry := r.Clone(r.Context())
+	if r.Body != nil && r.Body != http.NoBody {
+		if r.GetBody == nil {
+			return response, nil
+		}
+
+		retry.Body, err = r.GetBody()
+		if err != nil {
+			return response, nil
+		}
+	}
+
+	refreshed, err := t.refresh(token)
+	if err != nil {
+		// the refresh token may have expired or been revoked as well, in
+		// which case the original response tells the user to log in again
+		return response, nil
+	}
+
+	response.Body.Close()
+
+	return t.Base.RoundTrip(authorize(retry, refreshed))
+}
+
+func (t *TokenTransport) currentToken() TargetToken {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return *t.Token
+}
+
+func (t *TokenTransport) refresh(expired TargetToken) (TargetToken, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// another request may have refreshed the token in the meantime
+	if t.Token.Value != expired.Value {
+		return *t.Token, nil
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: t.Base})
+
+	config := OAuth2Config(t.API)
+	newToken, err := config.TokenSource(ctx, &oauth2.Token{RefreshToken: expired.RefreshToken}).Token()
+	if err != nil {
+		return TargetToken{}, err
+	}
+
+	refreshToken := newToken.RefreshToken
+	if refreshToken == "" {
+		refreshToken = expired.RefreshToken
+	}
+
+	*t.Token = TargetToken{
+		Type:         newToken.TokenType,
+		Value:        newToken.AccessToken,
+		RefreshToken: refreshToken,
+	}
+
+	// the refreshed token is still used for this invocation if it cannot be
+	// saved, e.g. for targets that only exist in memory
+	_ = UpdateTargetToken(t.TargetName, t.Token)
+
+	return *t.Token, nil
+}
+
+func authorize(r *http.Request, token TargetToken) *http.Request {
+	authorized := r.Clone(r.Context())
+
+	oAuthToken := &oauth2.Token{
+		TokenType:   token.Type,
+		AccessToken: token.Value,
+	}
+	oAuthToken.SetAuthHeader(authorized)
+
+	return authorized
+}
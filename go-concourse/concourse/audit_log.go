@@ -0,0 +1,70 @@
+package concourse
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+)
+
+type AuditLogFilter struct {
+	User     string
+	Action   string
+	TeamName string
+
+	Since time.Time
+	Until time.Time
+}
+
+func (filter AuditLogFilter) QueryParams() url.Values {
+	queryParams := url.Values{}
+
+	if filter.User != "" {
+		queryParams.Set(atc.AuditLogQueryUser, filter.User)
+	}
+
+	if filter.Action != "" {
+		queryParams.Set(atc.AuditLogQueryAction, filter.Action)
+	}
+
+	if filter.TeamName != "" {
+		queryParams.Set(atc.AuditLogQueryTeam, filter.TeamName)
+	}
+
+	if !filter.Since.IsZero() {
+		queryParams.Set(atc.AuditLogQuerySince, strconv.FormatInt(filter.Since.Unix(), 10))
+	}
+
+	if !filter.Until.IsZero() {
+		queryParams.Set(atc.AuditLogQueryUntil, strconv.FormatInt(filter.Until.Unix(), 10))
+	}
+
+	return queryParams
+}
+
+func (client *client) ListAuditEvents(filter AuditLogFilter, page Page) ([]atc.AuditEvent, Pagination, error) {
+	var events []atc.AuditEvent
+
+	headers := http.Header{}
+
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListAuditEvents,
+		Query:       merge(filter.QueryParams(), page.QueryParams()),
+	}, &internal.Response{
+		Result:  &events,
+		Headers: &headers,
+	})
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	pagination, err := paginationFromHeaders(headers)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	return events, pagination, nil
+}
@@ -0,0 +1,130 @@
+package concourse_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Audit Log", func() {
+	Describe("ListAuditEvents", func() {
+		expectedURL := "/api/v1/audit-log"
+
+		var (
+			filter concourse.AuditLogFilter
+			page   concourse.Page
+
+			expectedEvents []atc.AuditEvent
+
+			events     []atc.AuditEvent
+			pagination concourse.Pagination
+			listErr    error
+		)
+
+		BeforeEach(func() {
+			filter = concourse.AuditLogFilter{}
+			page = concourse.Page{}
+
+			expectedEvents = []atc.AuditEvent{
+				{
+					ID:           12,
+					Time:         100,
+					Action:       atc.SaveConfig,
+					User:         "some-user",
+					TeamName:     "some-team",
+					PipelineName: "some-pipeline",
+					Status:       http.StatusOK,
+					Diff:         "jobs: +some-job",
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			events, pagination, listErr = client.ListAuditEvents(filter, page)
+		})
+
+		Context("when no filters are given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedEvents),
+					),
+				)
+			})
+
+			It("returns the events", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(events).To(Equal(expectedEvents))
+			})
+		})
+
+		Context("when every filter is given", func() {
+			BeforeEach(func() {
+				filter = concourse.AuditLogFilter{
+					User:     "some-user",
+					Action:   atc.SaveConfig,
+					TeamName: "some-team",
+					Since:    time.Unix(10, 0),
+					Until:    time.Unix(20, 0),
+				}
+				page = concourse.Page{To: 15, Limit: 2}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "action=SaveConfig&limit=2&since=10&team_name=some-team&to=15&until=20&user=some-user"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedEvents),
+					),
+				)
+			})
+
+			It("passes them along as query params", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(events).To(Equal(expectedEvents))
+			})
+		})
+
+		Context("when there are more pages", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedEvents, http.Header{
+							"Link": []string{
+								`<` + atcServer.URL() + `/api/v1/audit-log?to=11&limit=1>; rel="next"`,
+							},
+						}),
+					),
+				)
+			})
+
+			It("returns the pagination", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(pagination).To(Equal(concourse.Pagination{
+					Next: &concourse.Page{To: 11, Limit: 1},
+				}))
+			})
+		})
+
+		Context("when the user is not an admin", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusForbidden, ""),
+					),
+				)
+			})
+
+			It("returns forbidden", func() {
+				Expect(listErr).To(Equal(concourse.ErrForbidden))
+			})
+		})
+	})
+})
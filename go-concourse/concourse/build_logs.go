@@ -0,0 +1,85 @@
+package concourse
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+type BuildLogSearch struct {
+	Query string
+
+	PipelineRef atc.PipelineRef
+	JobName     string
+
+	Since time.Time
+	Until time.Time
+
+	Context int
+}
+
+func (search BuildLogSearch) QueryParams() url.Values {
+	queryParams := url.Values{}
+	queryParams.Set(atc.SearchBuildLogsQueryText, search.Query)
+
+	if search.PipelineRef.Name != "" {
+		queryParams.Set(atc.SearchBuildLogsQueryPipeline, search.PipelineRef.Name)
+		queryParams = merge(queryParams, search.PipelineRef.QueryParams())
+	}
+
+	if search.JobName != "" {
+		queryParams.Set(atc.SearchBuildLogsQueryJob, search.JobName)
+	}
+
+	if !search.Since.IsZero() {
+		queryParams.Set(atc.SearchBuildLogsQuerySince, strconv.FormatInt(search.Since.Unix(), 10))
+	}
+
+	if !search.Until.IsZero() {
+		queryParams.Set(atc.SearchBuildLogsQueryUntil, strconv.FormatInt(search.Until.Unix(), 10))
+	}
+
+	if search.Context > 0 {
+		queryParams.Set(atc.SearchBuildLogsQueryContext, strconv.Itoa(search.Context))
+	}
+
+	return queryParams
+}
+
+func (team *team) SearchBuildLogs(search BuildLogSearch, page Page) ([]atc.BuildLogMatch, Pagination, bool, error) {
+	var matches []atc.BuildLogMatch
+
+	headers := http.Header{}
+
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.SearchBuildLogs,
+		Params:      params,
+		Query:       merge(search.QueryParams(), page.QueryParams()),
+	}, &internal.Response{
+		Result:  &matches,
+		Headers: &headers,
+	})
+
+	switch err.(type) {
+	case nil:
+		pagination, err := paginationFromHeaders(headers)
+		if err != nil {
+			return nil, Pagination{}, false, err
+		}
+
+		return matches, pagination, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, Pagination{}, false, nil
+	default:
+		return nil, Pagination{}, false, err
+	}
+}
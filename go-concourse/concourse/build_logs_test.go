@@ -0,0 +1,150 @@
+package concourse_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Logs", func() {
+	Describe("SearchBuildLogs", func() {
+		expectedURL := "/api/v1/teams/some-team/logs"
+
+		var (
+			search concourse.BuildLogSearch
+			page   concourse.Page
+
+			expectedMatches []atc.BuildLogMatch
+
+			matches    []atc.BuildLogMatch
+			pagination concourse.Pagination
+			found      bool
+			searchErr  error
+		)
+
+		BeforeEach(func() {
+			search = concourse.BuildLogSearch{Query: "connection refused"}
+			page = concourse.Page{}
+
+			expectedMatches = []atc.BuildLogMatch{
+				{
+					ID:           12,
+					TeamName:     "some-team",
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+					BuildID:      3,
+					BuildName:    "4",
+					Origin:       "some-origin",
+					Time:         100,
+					Line:         "dial tcp: connection refused",
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			matches, pagination, found, searchErr = team.SearchBuildLogs(search, page)
+		})
+
+		Context("when only the query is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "query=connection+refused"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedMatches),
+					),
+				)
+			})
+
+			It("returns the matches", func() {
+				Expect(searchErr).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(matches).To(Equal(expectedMatches))
+			})
+		})
+
+		Context("when every filter is given", func() {
+			BeforeEach(func() {
+				search = concourse.BuildLogSearch{
+					Query:       "oops",
+					PipelineRef: atc.PipelineRef{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "master"}},
+					JobName:     "some-job",
+					Since:       time.Unix(10, 0),
+					Until:       time.Unix(20, 0),
+					Context:     3,
+				}
+				page = concourse.Page{To: 15, Limit: 2}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "context=3&job_name=some-job&limit=2&pipeline_name=some-pipeline&query=oops&since=10&to=15&until=20&vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedMatches),
+					),
+				)
+			})
+
+			It("passes them along as query params", func() {
+				Expect(searchErr).NotTo(HaveOccurred())
+				Expect(matches).To(Equal(expectedMatches))
+			})
+		})
+
+		Context("when there are more pages", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedMatches, http.Header{
+							"Link": []string{
+								`<` + atcServer.URL() + `/api/v1/teams/some-team/logs?query=oops&to=11&limit=1>; rel="next"`,
+							},
+						}),
+					),
+				)
+			})
+
+			It("returns the pagination", func() {
+				Expect(searchErr).NotTo(HaveOccurred())
+				Expect(pagination).To(Equal(concourse.Pagination{
+					Next: &concourse.Page{To: 11, Limit: 1},
+				}))
+			})
+		})
+
+		Context("when the pipeline or job is not found", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns not found", func() {
+				Expect(searchErr).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when build log search is disabled", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusForbidden, ""),
+					),
+				)
+			})
+
+			It("returns forbidden", func() {
+				Expect(searchErr).To(Equal(concourse.ErrForbidden))
+			})
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+type BuildStatsFilter struct {
+	PipelineRef atc.PipelineRef
+	JobName     string
+
+	Since time.Time
+	Until time.Time
+}
+
+func (filter BuildStatsFilter) QueryParams() url.Values {
+	queryParams := url.Values{}
+
+	if filter.PipelineRef.Name != "" {
+		queryParams.Set(atc.BuildStatsQueryPipeline, filter.PipelineRef.Name)
+		queryParams = merge(queryParams, filter.PipelineRef.QueryParams())
+	}
+
+	if filter.JobName != "" {
+		queryParams.Set(atc.BuildStatsQueryJob, filter.JobName)
+	}
+
+	if !filter.Since.IsZero() {
+		queryParams.Set(atc.BuildStatsQuerySince, strconv.FormatInt(filter.Since.Unix(), 10))
+	}
+
+	if !filter.Until.IsZero() {
+		queryParams.Set(atc.BuildStatsQueryUntil, strconv.FormatInt(filter.Until.Unix(), 10))
+	}
+
+	return queryParams
+}
+
+func (team *team) BuildStats(filter BuildStatsFilter) (atc.BuildStats, bool, error) {
+	var stats atc.BuildStats
+
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetBuildStats,
+		Params:      params,
+		Query:       filter.QueryParams(),
+	}, &internal.Response{
+		Result: &stats,
+	})
+
+	switch err.(type) {
+	case nil:
+		return stats, true, nil
+	case internal.ResourceNotFoundError:
+		return atc.BuildStats{}, false, nil
+	default:
+		return atc.BuildStats{}, false, err
+	}
+}
+
+func (team *team) FlakyJobs(filter BuildStatsFilter) ([]atc.FlakyJob, bool, error) {
+	var jobs []atc.FlakyJob
+
+	params := rata.Params{
+		"team_name": team.Name(),
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListFlakyJobs,
+		Params:      params,
+		Query:       filter.QueryParams(),
+	}, &internal.Response{
+		Result: &jobs,
+	})
+
+	switch err.(type) {
+	case nil:
+		return jobs, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
@@ -0,0 +1,172 @@
+package concourse_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Stats", func() {
+	Describe("BuildStats", func() {
+		expectedURL := "/api/v1/teams/some-team/stats"
+
+		var (
+			filter        concourse.BuildStatsFilter
+			expectedStats atc.BuildStats
+
+			stats    atc.BuildStats
+			found    bool
+			statsErr error
+		)
+
+		BeforeEach(func() {
+			filter = concourse.BuildStatsFilter{}
+
+			expectedStats = atc.BuildStats{
+				Since:       10,
+				Until:       20,
+				Builds:      map[atc.BuildStatus]int{atc.StatusSucceeded: 3, atc.StatusFailed: 1},
+				SuccessRate: 0.75,
+				Duration:    atc.DurationPercentiles{P50: 60, P90: 90, P99: 120},
+				Recoveries:  1,
+				Reruns:      2,
+				Flakiness:   0.5,
+			}
+		})
+
+		JustBeforeEach(func() {
+			stats, found, statsErr = team.BuildStats(filter)
+		})
+
+		Context("when no filter is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedStats),
+					),
+				)
+			})
+
+			It("returns the stats", func() {
+				Expect(statsErr).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(stats).To(Equal(expectedStats))
+			})
+		})
+
+		Context("when every filter is given", func() {
+			BeforeEach(func() {
+				filter = concourse.BuildStatsFilter{
+					PipelineRef: atc.PipelineRef{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "master"}},
+					JobName:     "some-job",
+					Since:       time.Unix(10, 0),
+					Until:       time.Unix(20, 0),
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "job_name=some-job&pipeline_name=some-pipeline&since=10&until=20&vars.branch=%22master%22"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedStats),
+					),
+				)
+			})
+
+			It("passes them along as query params", func() {
+				Expect(statsErr).NotTo(HaveOccurred())
+				Expect(stats).To(Equal(expectedStats))
+			})
+		})
+
+		Context("when the pipeline or job is not found", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns not found", func() {
+				Expect(statsErr).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+	Describe("FlakyJobs", func() {
+		expectedURL := "/api/v1/teams/some-team/flaky_jobs"
+
+		var (
+			filter       concourse.BuildStatsFilter
+			expectedJobs []atc.FlakyJob
+
+			jobs    []atc.FlakyJob
+			found   bool
+			jobsErr error
+		)
+
+		BeforeEach(func() {
+			filter = concourse.BuildStatsFilter{}
+
+			expectedJobs = []atc.FlakyJob{
+				{
+					TeamName:     "some-team",
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+					FlakyBuilds:  2,
+					Builds:       10,
+					LastFlaky:    20,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			jobs, found, jobsErr = team.FlakyJobs(filter)
+		})
+
+		Context("when a pipeline is given", func() {
+			BeforeEach(func() {
+				filter = concourse.BuildStatsFilter{
+					PipelineRef: atc.PipelineRef{Name: "some-pipeline"},
+					Since:       time.Unix(10, 0),
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "pipeline_name=some-pipeline&since=10"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedJobs),
+					),
+				)
+			})
+
+			It("returns the flaky jobs", func() {
+				Expect(jobsErr).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(jobs).To(Equal(expectedJobs))
+			})
+		})
+
+		Context("when the pipeline is not found", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns not found", func() {
+				Expect(jobsErr).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
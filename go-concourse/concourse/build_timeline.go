@@ -0,0 +1,32 @@
+package concourse
+
+import (
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) BuildTimeline(buildID int) ([]atc.StepTiming, bool, error) {
+	params := rata.Params{
+		"build_id": strconv.Itoa(buildID),
+	}
+
+	var timings []atc.StepTiming
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.GetBuildTimeline,
+		Params:      params,
+	}, &internal.Response{
+		Result: &timings,
+	})
+
+	switch err.(type) {
+	case nil:
+		return timings, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
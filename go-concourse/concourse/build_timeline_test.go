@@ -0,0 +1,71 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Timeline", func() {
+	Describe("BuildTimeline", func() {
+		expectedURL := "/api/v1/builds/1234/timeline"
+
+		Context("when the build exists", func() {
+			streamedBytes := int64(1024)
+
+			expectedTimings := []atc.StepTiming{
+				{
+					PlanID:    "some-plan-id",
+					StepName:  "some-task",
+					Phase:     atc.StepPhaseStreamingInputs,
+					StartTime: 1600000000,
+					Duration:  9.25,
+					Bytes:     &streamedBytes,
+				},
+				{
+					PlanID:    "some-plan-id",
+					StepName:  "some-task",
+					Phase:     atc.StepPhaseRunning,
+					StartTime: 1600000010,
+					Duration:  42.5,
+				},
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedTimings),
+					),
+				)
+			})
+
+			It("returns the timing of each phase of the steps", func() {
+				timings, found, err := client.BuildTimeline(1234)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(timings).To(Equal(expectedTimings))
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := client.BuildTimeline(1234)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -0,0 +1,32 @@
+package concourse
+
+import (
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) BuildUsage(buildID int) ([]atc.StepUsage, bool, error) {
+	params := rata.Params{
+		"build_id": strconv.Itoa(buildID),
+	}
+
+	var usage []atc.StepUsage
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.GetBuildUsage,
+		Params:      params,
+	}, &internal.Response{
+		Result: &usage,
+	})
+
+	switch err.(type) {
+	case nil:
+		return usage, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
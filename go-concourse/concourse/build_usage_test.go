@@ -0,0 +1,65 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Usage", func() {
+	Describe("BuildUsage", func() {
+		expectedURL := "/api/v1/builds/1234/usage"
+
+		Context("when the build exists", func() {
+			expectedUsage := []atc.StepUsage{
+				{
+					PlanID:   "some-plan-id",
+					StepName: "some-task",
+					Usage: atc.ContainerUsage{
+						CPUAverage:    0.5,
+						CPUPeak:       1.5,
+						MemoryAverage: 1024,
+						MemoryPeak:    2048,
+						Samples:       3,
+					},
+				},
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedUsage),
+					),
+				)
+			})
+
+			It("returns the usage of each step", func() {
+				usage, found, err := client.BuildUsage(1234)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(usage).To(Equal(expectedUsage))
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := client.BuildUsage(1234)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
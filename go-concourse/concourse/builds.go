@@ -36,17 +36,30 @@ func (team *team) CreateBuild(plan atc.Plan) (atc.Build, error) {
 }
 
 func (team *team) CreateJobBuild(pipelineRef atc.PipelineRef, jobName string) (atc.Build, error) {
+	return team.CreateJobBuildInTrace(pipelineRef, jobName, "")
+}
+
+// CreateJobBuildInTrace creates a build of the job as part of the trace
+// identified by the given W3C traceparent, e.g. that of a CI system or
+// deployment tool triggering it. An empty traceparent starts a new trace.
+func (team *team) CreateJobBuildInTrace(pipelineRef atc.PipelineRef, jobName string, traceparent string) (atc.Build, error) {
 	params := rata.Params{
 		"job_name":      jobName,
 		"pipeline_name": pipelineRef.Name,
 		"team_name":     team.Name(),
 	}
 
+	header := http.Header{}
+	if traceparent != "" {
+		header.Set("traceparent", traceparent)
+	}
+
 	var build atc.Build
 	err := team.connection.Send(internal.Request{
 		RequestName: atc.CreateJobBuild,
 		Params:      params,
 		Query:       pipelineRef.QueryParams(),
+		Header:      header,
 	}, &internal.Response{
 		Result: &build,
 	})
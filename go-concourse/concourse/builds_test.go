@@ -98,6 +98,34 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("CreateJobBuildInTrace", func() {
+		var expectedBuild atc.Build
+
+		BeforeEach(func() {
+			expectedBuild = atc.Build{
+				ID:      123,
+				Name:    "mybuild",
+				Status:  "started",
+				JobName: "myjob",
+				APIURL:  "api/v1/builds/123",
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/builds"),
+					ghttp.VerifyHeaderKV("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedBuild),
+				),
+			)
+		})
+
+		It("sends the traceparent along with the request", func() {
+			build, err := team.CreateJobBuildInTrace(atc.PipelineRef{Name: "mypipeline"}, "myjob", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(expectedBuild))
+		})
+	})
+
 	Describe("RerunJobBuild", func() {
 		var (
 			pipelineRef   atc.PipelineRef
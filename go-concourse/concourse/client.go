@@ -22,10 +22,15 @@ type Client interface {
 	ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact, error)
 	AbortBuild(buildID string) error
 	BuildPlan(buildID int) (atc.PublicBuildPlan, bool, error)
+	BuildUsage(buildID int) ([]atc.StepUsage, bool, error)
+	BuildTimeline(buildID int) ([]atc.StepTiming, bool, error)
 	SaveWorker(atc.Worker, *time.Duration) (*atc.Worker, error)
 	ListWorkers() ([]atc.Worker, error)
 	PruneWorker(workerName string) error
 	LandWorker(workerName string) error
+	ListWorkerKeys() ([]atc.WorkerKey, error)
+	CreateWorkerKey(key atc.WorkerKey) (atc.WorkerKey, error)
+	RevokeWorkerKey(id int) (bool, error)
 	GetInfo() (atc.Info, error)
 	GetCLIReader(arch, platform string) (io.ReadCloser, http.Header, error)
 	ListPipelines() ([]atc.Pipeline, error)
@@ -35,6 +40,7 @@ type Client interface {
 	Team(teamName string) Team
 	UserInfo() (atc.UserInfo, error)
 	ListActiveUsersSince(since time.Time) ([]atc.User, error)
+	ListAuditEvents(filter AuditLogFilter, page Page) ([]atc.AuditEvent, Pagination, error)
 }
 
 type client struct {
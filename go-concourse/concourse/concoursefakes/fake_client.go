@@ -81,6 +81,36 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	BuildTimelineStub        func(int) ([]atc.StepTiming, bool, error)
+	buildTimelineMutex       sync.RWMutex
+	buildTimelineArgsForCall []struct {
+		arg1 int
+	}
+	buildTimelineReturns struct {
+		result1 []atc.StepTiming
+		result2 bool
+		result3 error
+	}
+	buildTimelineReturnsOnCall map[int]struct {
+		result1 []atc.StepTiming
+		result2 bool
+		result3 error
+	}
+	BuildUsageStub        func(int) ([]atc.StepUsage, bool, error)
+	buildUsageMutex       sync.RWMutex
+	buildUsageArgsForCall []struct {
+		arg1 int
+	}
+	buildUsageReturns struct {
+		result1 []atc.StepUsage
+		result2 bool
+		result3 error
+	}
+	buildUsageReturnsOnCall map[int]struct {
+		result1 []atc.StepUsage
+		result2 bool
+		result3 error
+	}
 	BuildsStub        func(concourse.Page) ([]atc.Build, concourse.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -96,6 +126,19 @@ type FakeClient struct {
 		result2 concourse.Pagination
 		result3 error
 	}
+	CreateWorkerKeyStub        func(atc.WorkerKey) (atc.WorkerKey, error)
+	createWorkerKeyMutex       sync.RWMutex
+	createWorkerKeyArgsForCall []struct {
+		arg1 atc.WorkerKey
+	}
+	createWorkerKeyReturns struct {
+		result1 atc.WorkerKey
+		result2 error
+	}
+	createWorkerKeyReturnsOnCall map[int]struct {
+		result1 atc.WorkerKey
+		result2 error
+	}
 	FindTeamStub        func(string) (concourse.Team, error)
 	findTeamMutex       sync.RWMutex
 	findTeamArgsForCall []struct {
@@ -183,6 +226,22 @@ type FakeClient struct {
 		result1 []atc.Job
 		result2 error
 	}
+	ListAuditEventsStub        func(concourse.AuditLogFilter, concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error)
+	listAuditEventsMutex       sync.RWMutex
+	listAuditEventsArgsForCall []struct {
+		arg1 concourse.AuditLogFilter
+		arg2 concourse.Page
+	}
+	listAuditEventsReturns struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}
+	listAuditEventsReturnsOnCall map[int]struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}
 	ListBuildArtifactsStub        func(string) ([]atc.WorkerArtifact, error)
 	listBuildArtifactsMutex       sync.RWMutex
 	listBuildArtifactsArgsForCall []struct {
@@ -220,6 +279,18 @@ type FakeClient struct {
 		result1 []atc.Team
 		result2 error
 	}
+	ListWorkerKeysStub        func() ([]atc.WorkerKey, error)
+	listWorkerKeysMutex       sync.RWMutex
+	listWorkerKeysArgsForCall []struct {
+	}
+	listWorkerKeysReturns struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}
+	listWorkerKeysReturnsOnCall map[int]struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}
 	ListWorkersStub        func() ([]atc.Worker, error)
 	listWorkersMutex       sync.RWMutex
 	listWorkersArgsForCall []struct {
@@ -243,6 +314,19 @@ type FakeClient struct {
 	pruneWorkerReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RevokeWorkerKeyStub        func(int) (bool, error)
+	revokeWorkerKeyMutex       sync.RWMutex
+	revokeWorkerKeyArgsForCall []struct {
+		arg1 int
+	}
+	revokeWorkerKeyReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeWorkerKeyReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	SaveWorkerStub        func(atc.Worker, *time.Duration) (*atc.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -620,6 +704,140 @@ func (fake *FakeClient) BuildResourcesReturnsOnCall(i int, result1 atc.BuildInpu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) BuildTimeline(arg1 int) ([]atc.StepTiming, bool, error) {
+	fake.buildTimelineMutex.Lock()
+	ret, specificReturn := fake.buildTimelineReturnsOnCall[len(fake.buildTimelineArgsForCall)]
+	fake.buildTimelineArgsForCall = append(fake.buildTimelineArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.BuildTimelineStub
+	fakeReturns := fake.buildTimelineReturns
+	fake.recordInvocation("BuildTimeline", []interface{}{arg1})
+	fake.buildTimelineMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildTimelineCallCount() int {
+	fake.buildTimelineMutex.RLock()
+	defer fake.buildTimelineMutex.RUnlock()
+	return len(fake.buildTimelineArgsForCall)
+}
+
+func (fake *FakeClient) BuildTimelineCalls(stub func(int) ([]atc.StepTiming, bool, error)) {
+	fake.buildTimelineMutex.Lock()
+	defer fake.buildTimelineMutex.Unlock()
+	fake.BuildTimelineStub = stub
+}
+
+func (fake *FakeClient) BuildTimelineArgsForCall(i int) int {
+	fake.buildTimelineMutex.RLock()
+	defer fake.buildTimelineMutex.RUnlock()
+	argsForCall := fake.buildTimelineArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildTimelineReturns(result1 []atc.StepTiming, result2 bool, result3 error) {
+	fake.buildTimelineMutex.Lock()
+	defer fake.buildTimelineMutex.Unlock()
+	fake.BuildTimelineStub = nil
+	fake.buildTimelineReturns = struct {
+		result1 []atc.StepTiming
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildTimelineReturnsOnCall(i int, result1 []atc.StepTiming, result2 bool, result3 error) {
+	fake.buildTimelineMutex.Lock()
+	defer fake.buildTimelineMutex.Unlock()
+	fake.BuildTimelineStub = nil
+	if fake.buildTimelineReturnsOnCall == nil {
+		fake.buildTimelineReturnsOnCall = make(map[int]struct {
+			result1 []atc.StepTiming
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildTimelineReturnsOnCall[i] = struct {
+		result1 []atc.StepTiming
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildUsage(arg1 int) ([]atc.StepUsage, bool, error) {
+	fake.buildUsageMutex.Lock()
+	ret, specificReturn := fake.buildUsageReturnsOnCall[len(fake.buildUsageArgsForCall)]
+	fake.buildUsageArgsForCall = append(fake.buildUsageArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.BuildUsageStub
+	fakeReturns := fake.buildUsageReturns
+	fake.recordInvocation("BuildUsage", []interface{}{arg1})
+	fake.buildUsageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildUsageCallCount() int {
+	fake.buildUsageMutex.RLock()
+	defer fake.buildUsageMutex.RUnlock()
+	return len(fake.buildUsageArgsForCall)
+}
+
+func (fake *FakeClient) BuildUsageCalls(stub func(int) ([]atc.StepUsage, bool, error)) {
+	fake.buildUsageMutex.Lock()
+	defer fake.buildUsageMutex.Unlock()
+	fake.BuildUsageStub = stub
+}
+
+func (fake *FakeClient) BuildUsageArgsForCall(i int) int {
+	fake.buildUsageMutex.RLock()
+	defer fake.buildUsageMutex.RUnlock()
+	argsForCall := fake.buildUsageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildUsageReturns(result1 []atc.StepUsage, result2 bool, result3 error) {
+	fake.buildUsageMutex.Lock()
+	defer fake.buildUsageMutex.Unlock()
+	fake.BuildUsageStub = nil
+	fake.buildUsageReturns = struct {
+		result1 []atc.StepUsage
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildUsageReturnsOnCall(i int, result1 []atc.StepUsage, result2 bool, result3 error) {
+	fake.buildUsageMutex.Lock()
+	defer fake.buildUsageMutex.Unlock()
+	fake.BuildUsageStub = nil
+	if fake.buildUsageReturnsOnCall == nil {
+		fake.buildUsageReturnsOnCall = make(map[int]struct {
+			result1 []atc.StepUsage
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildUsageReturnsOnCall[i] = struct {
+		result1 []atc.StepUsage
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) Builds(arg1 concourse.Page) ([]atc.Build, concourse.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -687,6 +905,70 @@ func (fake *FakeClient) BuildsReturnsOnCall(i int, result1 []atc.Build, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) CreateWorkerKey(arg1 atc.WorkerKey) (atc.WorkerKey, error) {
+	fake.createWorkerKeyMutex.Lock()
+	ret, specificReturn := fake.createWorkerKeyReturnsOnCall[len(fake.createWorkerKeyArgsForCall)]
+	fake.createWorkerKeyArgsForCall = append(fake.createWorkerKeyArgsForCall, struct {
+		arg1 atc.WorkerKey
+	}{arg1})
+	stub := fake.CreateWorkerKeyStub
+	fakeReturns := fake.createWorkerKeyReturns
+	fake.recordInvocation("CreateWorkerKey", []interface{}{arg1})
+	fake.createWorkerKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) CreateWorkerKeyCallCount() int {
+	fake.createWorkerKeyMutex.RLock()
+	defer fake.createWorkerKeyMutex.RUnlock()
+	return len(fake.createWorkerKeyArgsForCall)
+}
+
+func (fake *FakeClient) CreateWorkerKeyCalls(stub func(atc.WorkerKey) (atc.WorkerKey, error)) {
+	fake.createWorkerKeyMutex.Lock()
+	defer fake.createWorkerKeyMutex.Unlock()
+	fake.CreateWorkerKeyStub = stub
+}
+
+func (fake *FakeClient) CreateWorkerKeyArgsForCall(i int) atc.WorkerKey {
+	fake.createWorkerKeyMutex.RLock()
+	defer fake.createWorkerKeyMutex.RUnlock()
+	argsForCall := fake.createWorkerKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) CreateWorkerKeyReturns(result1 atc.WorkerKey, result2 error) {
+	fake.createWorkerKeyMutex.Lock()
+	defer fake.createWorkerKeyMutex.Unlock()
+	fake.CreateWorkerKeyStub = nil
+	fake.createWorkerKeyReturns = struct {
+		result1 atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) CreateWorkerKeyReturnsOnCall(i int, result1 atc.WorkerKey, result2 error) {
+	fake.createWorkerKeyMutex.Lock()
+	defer fake.createWorkerKeyMutex.Unlock()
+	fake.CreateWorkerKeyStub = nil
+	if fake.createWorkerKeyReturnsOnCall == nil {
+		fake.createWorkerKeyReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerKey
+			result2 error
+		})
+	}
+	fake.createWorkerKeyReturnsOnCall[i] = struct {
+		result1 atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) FindTeam(arg1 string) (concourse.Team, error) {
 	fake.findTeamMutex.Lock()
 	ret, specificReturn := fake.findTeamReturnsOnCall[len(fake.findTeamArgsForCall)]
@@ -1109,6 +1391,74 @@ func (fake *FakeClient) ListAllJobsReturnsOnCall(i int, result1 []atc.Job, resul
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListAuditEvents(arg1 concourse.AuditLogFilter, arg2 concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error) {
+	fake.listAuditEventsMutex.Lock()
+	ret, specificReturn := fake.listAuditEventsReturnsOnCall[len(fake.listAuditEventsArgsForCall)]
+	fake.listAuditEventsArgsForCall = append(fake.listAuditEventsArgsForCall, struct {
+		arg1 concourse.AuditLogFilter
+		arg2 concourse.Page
+	}{arg1, arg2})
+	stub := fake.ListAuditEventsStub
+	fakeReturns := fake.listAuditEventsReturns
+	fake.recordInvocation("ListAuditEvents", []interface{}{arg1, arg2})
+	fake.listAuditEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) ListAuditEventsCallCount() int {
+	fake.listAuditEventsMutex.RLock()
+	defer fake.listAuditEventsMutex.RUnlock()
+	return len(fake.listAuditEventsArgsForCall)
+}
+
+func (fake *FakeClient) ListAuditEventsCalls(stub func(concourse.AuditLogFilter, concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error)) {
+	fake.listAuditEventsMutex.Lock()
+	defer fake.listAuditEventsMutex.Unlock()
+	fake.ListAuditEventsStub = stub
+}
+
+func (fake *FakeClient) ListAuditEventsArgsForCall(i int) (concourse.AuditLogFilter, concourse.Page) {
+	fake.listAuditEventsMutex.RLock()
+	defer fake.listAuditEventsMutex.RUnlock()
+	argsForCall := fake.listAuditEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) ListAuditEventsReturns(result1 []atc.AuditEvent, result2 concourse.Pagination, result3 error) {
+	fake.listAuditEventsMutex.Lock()
+	defer fake.listAuditEventsMutex.Unlock()
+	fake.ListAuditEventsStub = nil
+	fake.listAuditEventsReturns = struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) ListAuditEventsReturnsOnCall(i int, result1 []atc.AuditEvent, result2 concourse.Pagination, result3 error) {
+	fake.listAuditEventsMutex.Lock()
+	defer fake.listAuditEventsMutex.Unlock()
+	fake.ListAuditEventsStub = nil
+	if fake.listAuditEventsReturnsOnCall == nil {
+		fake.listAuditEventsReturnsOnCall = make(map[int]struct {
+			result1 []atc.AuditEvent
+			result2 concourse.Pagination
+			result3 error
+		})
+	}
+	fake.listAuditEventsReturnsOnCall[i] = struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) ListBuildArtifacts(arg1 string) ([]atc.WorkerArtifact, error) {
 	fake.listBuildArtifactsMutex.Lock()
 	ret, specificReturn := fake.listBuildArtifactsReturnsOnCall[len(fake.listBuildArtifactsArgsForCall)]
@@ -1285,6 +1635,62 @@ func (fake *FakeClient) ListTeamsReturnsOnCall(i int, result1 []atc.Team, result
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListWorkerKeys() ([]atc.WorkerKey, error) {
+	fake.listWorkerKeysMutex.Lock()
+	ret, specificReturn := fake.listWorkerKeysReturnsOnCall[len(fake.listWorkerKeysArgsForCall)]
+	fake.listWorkerKeysArgsForCall = append(fake.listWorkerKeysArgsForCall, struct {
+	}{})
+	stub := fake.ListWorkerKeysStub
+	fakeReturns := fake.listWorkerKeysReturns
+	fake.recordInvocation("ListWorkerKeys", []interface{}{})
+	fake.listWorkerKeysMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListWorkerKeysCallCount() int {
+	fake.listWorkerKeysMutex.RLock()
+	defer fake.listWorkerKeysMutex.RUnlock()
+	return len(fake.listWorkerKeysArgsForCall)
+}
+
+func (fake *FakeClient) ListWorkerKeysCalls(stub func() ([]atc.WorkerKey, error)) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = stub
+}
+
+func (fake *FakeClient) ListWorkerKeysReturns(result1 []atc.WorkerKey, result2 error) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = nil
+	fake.listWorkerKeysReturns = struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListWorkerKeysReturnsOnCall(i int, result1 []atc.WorkerKey, result2 error) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = nil
+	if fake.listWorkerKeysReturnsOnCall == nil {
+		fake.listWorkerKeysReturnsOnCall = make(map[int]struct {
+			result1 []atc.WorkerKey
+			result2 error
+		})
+	}
+	fake.listWorkerKeysReturnsOnCall[i] = struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListWorkers() ([]atc.Worker, error) {
 	fake.listWorkersMutex.Lock()
 	ret, specificReturn := fake.listWorkersReturnsOnCall[len(fake.listWorkersArgsForCall)]
@@ -1402,6 +1808,70 @@ func (fake *FakeClient) PruneWorkerReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) RevokeWorkerKey(arg1 int) (bool, error) {
+	fake.revokeWorkerKeyMutex.Lock()
+	ret, specificReturn := fake.revokeWorkerKeyReturnsOnCall[len(fake.revokeWorkerKeyArgsForCall)]
+	fake.revokeWorkerKeyArgsForCall = append(fake.revokeWorkerKeyArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.RevokeWorkerKeyStub
+	fakeReturns := fake.revokeWorkerKeyReturns
+	fake.recordInvocation("RevokeWorkerKey", []interface{}{arg1})
+	fake.revokeWorkerKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RevokeWorkerKeyCallCount() int {
+	fake.revokeWorkerKeyMutex.RLock()
+	defer fake.revokeWorkerKeyMutex.RUnlock()
+	return len(fake.revokeWorkerKeyArgsForCall)
+}
+
+func (fake *FakeClient) RevokeWorkerKeyCalls(stub func(int) (bool, error)) {
+	fake.revokeWorkerKeyMutex.Lock()
+	defer fake.revokeWorkerKeyMutex.Unlock()
+	fake.RevokeWorkerKeyStub = stub
+}
+
+func (fake *FakeClient) RevokeWorkerKeyArgsForCall(i int) int {
+	fake.revokeWorkerKeyMutex.RLock()
+	defer fake.revokeWorkerKeyMutex.RUnlock()
+	argsForCall := fake.revokeWorkerKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) RevokeWorkerKeyReturns(result1 bool, result2 error) {
+	fake.revokeWorkerKeyMutex.Lock()
+	defer fake.revokeWorkerKeyMutex.Unlock()
+	fake.RevokeWorkerKeyStub = nil
+	fake.revokeWorkerKeyReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RevokeWorkerKeyReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeWorkerKeyMutex.Lock()
+	defer fake.revokeWorkerKeyMutex.Unlock()
+	fake.RevokeWorkerKeyStub = nil
+	if fake.revokeWorkerKeyReturnsOnCall == nil {
+		fake.revokeWorkerKeyReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeWorkerKeyReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) SaveWorker(arg1 atc.Worker, arg2 *time.Duration) (*atc.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -1650,8 +2120,14 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.buildPlanMutex.RUnlock()
 	fake.buildResourcesMutex.RLock()
 	defer fake.buildResourcesMutex.RUnlock()
+	fake.buildTimelineMutex.RLock()
+	defer fake.buildTimelineMutex.RUnlock()
+	fake.buildUsageMutex.RLock()
+	defer fake.buildUsageMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
+	fake.createWorkerKeyMutex.RLock()
+	defer fake.createWorkerKeyMutex.RUnlock()
 	fake.findTeamMutex.RLock()
 	defer fake.findTeamMutex.RUnlock()
 	fake.getCLIReaderMutex.RLock()
@@ -1666,16 +2142,22 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listActiveUsersSinceMutex.RUnlock()
 	fake.listAllJobsMutex.RLock()
 	defer fake.listAllJobsMutex.RUnlock()
+	fake.listAuditEventsMutex.RLock()
+	defer fake.listAuditEventsMutex.RUnlock()
 	fake.listBuildArtifactsMutex.RLock()
 	defer fake.listBuildArtifactsMutex.RUnlock()
 	fake.listPipelinesMutex.RLock()
 	defer fake.listPipelinesMutex.RUnlock()
 	fake.listTeamsMutex.RLock()
 	defer fake.listTeamsMutex.RUnlock()
+	fake.listWorkerKeysMutex.RLock()
+	defer fake.listWorkerKeysMutex.RUnlock()
 	fake.listWorkersMutex.RLock()
 	defer fake.listWorkersMutex.RUnlock()
 	fake.pruneWorkerMutex.RLock()
 	defer fake.pruneWorkerMutex.RUnlock()
+	fake.revokeWorkerKeyMutex.RLock()
+	defer fake.revokeWorkerKeyMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
 	fake.teamMutex.RLock()
@@ -59,6 +59,21 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	BuildStatsStub        func(concourse.BuildStatsFilter) (atc.BuildStats, bool, error)
+	buildStatsMutex       sync.RWMutex
+	buildStatsArgsForCall []struct {
+		arg1 concourse.BuildStatsFilter
+	}
+	buildStatsReturns struct {
+		result1 atc.BuildStats
+		result2 bool
+		result3 error
+	}
+	buildStatsReturnsOnCall map[int]struct {
+		result1 atc.BuildStats
+		result2 bool
+		result3 error
+	}
 	BuildsStub        func(concourse.Page) ([]atc.Build, concourse.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -232,6 +247,21 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
+	CreateJobBuildInTraceStub        func(atc.PipelineRef, string, string) (atc.Build, error)
+	createJobBuildInTraceMutex       sync.RWMutex
+	createJobBuildInTraceArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 string
+	}
+	createJobBuildInTraceReturns struct {
+		result1 atc.Build
+		result2 error
+	}
+	createJobBuildInTraceReturnsOnCall map[int]struct {
+		result1 atc.Build
+		result2 error
+	}
 	CreateOrUpdateStub        func(atc.Team) (atc.Team, bool, bool, []concourse.ConfigWarning, error)
 	createOrUpdateMutex       sync.RWMutex
 	createOrUpdateArgsForCall []struct {
@@ -285,6 +315,19 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
+	CreateWorkerKeyStub        func(string) (atc.WorkerKey, error)
+	createWorkerKeyMutex       sync.RWMutex
+	createWorkerKeyArgsForCall []struct {
+		arg1 string
+	}
+	createWorkerKeyReturns struct {
+		result1 atc.WorkerKey
+		result2 error
+	}
+	createWorkerKeyReturnsOnCall map[int]struct {
+		result1 atc.WorkerKey
+		result2 error
+	}
 	DeletePipelineStub        func(atc.PipelineRef) (bool, error)
 	deletePipelineMutex       sync.RWMutex
 	deletePipelineArgsForCall []struct {
@@ -352,6 +395,21 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	FlakyJobsStub        func(concourse.BuildStatsFilter) ([]atc.FlakyJob, bool, error)
+	flakyJobsMutex       sync.RWMutex
+	flakyJobsArgsForCall []struct {
+		arg1 concourse.BuildStatsFilter
+	}
+	flakyJobsReturns struct {
+		result1 []atc.FlakyJob
+		result2 bool
+		result3 error
+	}
+	flakyJobsReturnsOnCall map[int]struct {
+		result1 []atc.FlakyJob
+		result2 bool
+		result3 error
+	}
 	GetArtifactStub        func(int) (io.ReadCloser, error)
 	getArtifactMutex       sync.RWMutex
 	getArtifactArgsForCall []struct {
@@ -516,6 +574,18 @@ type FakeTeam struct {
 		result1 []atc.Volume
 		result2 error
 	}
+	ListWorkerKeysStub        func() ([]atc.WorkerKey, error)
+	listWorkerKeysMutex       sync.RWMutex
+	listWorkerKeysArgsForCall []struct {
+	}
+	listWorkerKeysReturns struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}
+	listWorkerKeysReturnsOnCall map[int]struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -724,6 +794,19 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	RevokeWorkerKeyStub        func(int) (bool, error)
+	revokeWorkerKeyMutex       sync.RWMutex
+	revokeWorkerKeyArgsForCall []struct {
+		arg1 int
+	}
+	revokeWorkerKeyReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeWorkerKeyReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	ScheduleJobStub        func(atc.PipelineRef, string) (bool, error)
 	scheduleJobMutex       sync.RWMutex
 	scheduleJobArgsForCall []struct {
@@ -738,6 +821,24 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	SearchBuildLogsStub        func(concourse.BuildLogSearch, concourse.Page) ([]atc.BuildLogMatch, concourse.Pagination, bool, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		arg1 concourse.BuildLogSearch
+		arg2 concourse.Page
+	}
+	searchBuildLogsReturns struct {
+		result1 []atc.BuildLogMatch
+		result2 concourse.Pagination
+		result3 bool
+		result4 error
+	}
+	searchBuildLogsReturnsOnCall map[int]struct {
+		result1 []atc.BuildLogMatch
+		result2 concourse.Pagination
+		result3 bool
+		result4 error
+	}
 	SetJobBuildCommentStub        func(atc.PipelineRef, string, string, string) (bool, error)
 	setJobBuildCommentMutex       sync.RWMutex
 	setJobBuildCommentArgsForCall []struct {
@@ -1067,6 +1168,73 @@ func (fake *FakeTeam) BuildInputsForJobReturnsOnCall(i int, result1 []atc.BuildI
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) BuildStats(arg1 concourse.BuildStatsFilter) (atc.BuildStats, bool, error) {
+	fake.buildStatsMutex.Lock()
+	ret, specificReturn := fake.buildStatsReturnsOnCall[len(fake.buildStatsArgsForCall)]
+	fake.buildStatsArgsForCall = append(fake.buildStatsArgsForCall, struct {
+		arg1 concourse.BuildStatsFilter
+	}{arg1})
+	stub := fake.BuildStatsStub
+	fakeReturns := fake.buildStatsReturns
+	fake.recordInvocation("BuildStats", []interface{}{arg1})
+	fake.buildStatsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) BuildStatsCallCount() int {
+	fake.buildStatsMutex.RLock()
+	defer fake.buildStatsMutex.RUnlock()
+	return len(fake.buildStatsArgsForCall)
+}
+
+func (fake *FakeTeam) BuildStatsCalls(stub func(concourse.BuildStatsFilter) (atc.BuildStats, bool, error)) {
+	fake.buildStatsMutex.Lock()
+	defer fake.buildStatsMutex.Unlock()
+	fake.BuildStatsStub = stub
+}
+
+func (fake *FakeTeam) BuildStatsArgsForCall(i int) concourse.BuildStatsFilter {
+	fake.buildStatsMutex.RLock()
+	defer fake.buildStatsMutex.RUnlock()
+	argsForCall := fake.buildStatsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) BuildStatsReturns(result1 atc.BuildStats, result2 bool, result3 error) {
+	fake.buildStatsMutex.Lock()
+	defer fake.buildStatsMutex.Unlock()
+	fake.BuildStatsStub = nil
+	fake.buildStatsReturns = struct {
+		result1 atc.BuildStats
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) BuildStatsReturnsOnCall(i int, result1 atc.BuildStats, result2 bool, result3 error) {
+	fake.buildStatsMutex.Lock()
+	defer fake.buildStatsMutex.Unlock()
+	fake.BuildStatsStub = nil
+	if fake.buildStatsReturnsOnCall == nil {
+		fake.buildStatsReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildStats
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildStatsReturnsOnCall[i] = struct {
+		result1 atc.BuildStats
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) Builds(arg1 concourse.Page) ([]atc.Build, concourse.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -1812,6 +1980,72 @@ func (fake *FakeTeam) CreateJobBuildReturnsOnCall(i int, result1 atc.Build, resu
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateJobBuildInTrace(arg1 atc.PipelineRef, arg2 string, arg3 string) (atc.Build, error) {
+	fake.createJobBuildInTraceMutex.Lock()
+	ret, specificReturn := fake.createJobBuildInTraceReturnsOnCall[len(fake.createJobBuildInTraceArgsForCall)]
+	fake.createJobBuildInTraceArgsForCall = append(fake.createJobBuildInTraceArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CreateJobBuildInTraceStub
+	fakeReturns := fake.createJobBuildInTraceReturns
+	fake.recordInvocation("CreateJobBuildInTrace", []interface{}{arg1, arg2, arg3})
+	fake.createJobBuildInTraceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateJobBuildInTraceCallCount() int {
+	fake.createJobBuildInTraceMutex.RLock()
+	defer fake.createJobBuildInTraceMutex.RUnlock()
+	return len(fake.createJobBuildInTraceArgsForCall)
+}
+
+func (fake *FakeTeam) CreateJobBuildInTraceCalls(stub func(atc.PipelineRef, string, string) (atc.Build, error)) {
+	fake.createJobBuildInTraceMutex.Lock()
+	defer fake.createJobBuildInTraceMutex.Unlock()
+	fake.CreateJobBuildInTraceStub = stub
+}
+
+func (fake *FakeTeam) CreateJobBuildInTraceArgsForCall(i int) (atc.PipelineRef, string, string) {
+	fake.createJobBuildInTraceMutex.RLock()
+	defer fake.createJobBuildInTraceMutex.RUnlock()
+	argsForCall := fake.createJobBuildInTraceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) CreateJobBuildInTraceReturns(result1 atc.Build, result2 error) {
+	fake.createJobBuildInTraceMutex.Lock()
+	defer fake.createJobBuildInTraceMutex.Unlock()
+	fake.CreateJobBuildInTraceStub = nil
+	fake.createJobBuildInTraceReturns = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateJobBuildInTraceReturnsOnCall(i int, result1 atc.Build, result2 error) {
+	fake.createJobBuildInTraceMutex.Lock()
+	defer fake.createJobBuildInTraceMutex.Unlock()
+	fake.CreateJobBuildInTraceStub = nil
+	if fake.createJobBuildInTraceReturnsOnCall == nil {
+		fake.createJobBuildInTraceReturnsOnCall = make(map[int]struct {
+			result1 atc.Build
+			result2 error
+		})
+	}
+	fake.createJobBuildInTraceReturnsOnCall[i] = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) CreateOrUpdate(arg1 atc.Team) (atc.Team, bool, bool, []concourse.ConfigWarning, error) {
 	fake.createOrUpdateMutex.Lock()
 	ret, specificReturn := fake.createOrUpdateReturnsOnCall[len(fake.createOrUpdateArgsForCall)]
@@ -2028,6 +2262,70 @@ func (fake *FakeTeam) CreatePipelineBuildReturnsOnCall(i int, result1 atc.Build,
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateWorkerKey(arg1 string) (atc.WorkerKey, error) {
+	fake.createWorkerKeyMutex.Lock()
+	ret, specificReturn := fake.createWorkerKeyReturnsOnCall[len(fake.createWorkerKeyArgsForCall)]
+	fake.createWorkerKeyArgsForCall = append(fake.createWorkerKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.CreateWorkerKeyStub
+	fakeReturns := fake.createWorkerKeyReturns
+	fake.recordInvocation("CreateWorkerKey", []interface{}{arg1})
+	fake.createWorkerKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateWorkerKeyCallCount() int {
+	fake.createWorkerKeyMutex.RLock()
+	defer fake.createWorkerKeyMutex.RUnlock()
+	return len(fake.createWorkerKeyArgsForCall)
+}
+
+func (fake *FakeTeam) CreateWorkerKeyCalls(stub func(string) (atc.WorkerKey, error)) {
+	fake.createWorkerKeyMutex.Lock()
+	defer fake.createWorkerKeyMutex.Unlock()
+	fake.CreateWorkerKeyStub = stub
+}
+
+func (fake *FakeTeam) CreateWorkerKeyArgsForCall(i int) string {
+	fake.createWorkerKeyMutex.RLock()
+	defer fake.createWorkerKeyMutex.RUnlock()
+	argsForCall := fake.createWorkerKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) CreateWorkerKeyReturns(result1 atc.WorkerKey, result2 error) {
+	fake.createWorkerKeyMutex.Lock()
+	defer fake.createWorkerKeyMutex.Unlock()
+	fake.CreateWorkerKeyStub = nil
+	fake.createWorkerKeyReturns = struct {
+		result1 atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateWorkerKeyReturnsOnCall(i int, result1 atc.WorkerKey, result2 error) {
+	fake.createWorkerKeyMutex.Lock()
+	defer fake.createWorkerKeyMutex.Unlock()
+	fake.CreateWorkerKeyStub = nil
+	if fake.createWorkerKeyReturnsOnCall == nil {
+		fake.createWorkerKeyReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerKey
+			result2 error
+		})
+	}
+	fake.createWorkerKeyReturnsOnCall[i] = struct {
+		result1 atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) DeletePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.deletePipelineMutex.Lock()
 	ret, specificReturn := fake.deletePipelineReturnsOnCall[len(fake.deletePipelineArgsForCall)]
@@ -2349,6 +2647,73 @@ func (fake *FakeTeam) ExposePipelineReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) FlakyJobs(arg1 concourse.BuildStatsFilter) ([]atc.FlakyJob, bool, error) {
+	fake.flakyJobsMutex.Lock()
+	ret, specificReturn := fake.flakyJobsReturnsOnCall[len(fake.flakyJobsArgsForCall)]
+	fake.flakyJobsArgsForCall = append(fake.flakyJobsArgsForCall, struct {
+		arg1 concourse.BuildStatsFilter
+	}{arg1})
+	stub := fake.FlakyJobsStub
+	fakeReturns := fake.flakyJobsReturns
+	fake.recordInvocation("FlakyJobs", []interface{}{arg1})
+	fake.flakyJobsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) FlakyJobsCallCount() int {
+	fake.flakyJobsMutex.RLock()
+	defer fake.flakyJobsMutex.RUnlock()
+	return len(fake.flakyJobsArgsForCall)
+}
+
+func (fake *FakeTeam) FlakyJobsCalls(stub func(concourse.BuildStatsFilter) ([]atc.FlakyJob, bool, error)) {
+	fake.flakyJobsMutex.Lock()
+	defer fake.flakyJobsMutex.Unlock()
+	fake.FlakyJobsStub = stub
+}
+
+func (fake *FakeTeam) FlakyJobsArgsForCall(i int) concourse.BuildStatsFilter {
+	fake.flakyJobsMutex.RLock()
+	defer fake.flakyJobsMutex.RUnlock()
+	argsForCall := fake.flakyJobsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) FlakyJobsReturns(result1 []atc.FlakyJob, result2 bool, result3 error) {
+	fake.flakyJobsMutex.Lock()
+	defer fake.flakyJobsMutex.Unlock()
+	fake.FlakyJobsStub = nil
+	fake.flakyJobsReturns = struct {
+		result1 []atc.FlakyJob
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) FlakyJobsReturnsOnCall(i int, result1 []atc.FlakyJob, result2 bool, result3 error) {
+	fake.flakyJobsMutex.Lock()
+	defer fake.flakyJobsMutex.Unlock()
+	fake.FlakyJobsStub = nil
+	if fake.flakyJobsReturnsOnCall == nil {
+		fake.flakyJobsReturnsOnCall = make(map[int]struct {
+			result1 []atc.FlakyJob
+			result2 bool
+			result3 error
+		})
+	}
+	fake.flakyJobsReturnsOnCall[i] = struct {
+		result1 []atc.FlakyJob
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) GetArtifact(arg1 int) (io.ReadCloser, error) {
 	fake.getArtifactMutex.Lock()
 	ret, specificReturn := fake.getArtifactReturnsOnCall[len(fake.getArtifactArgsForCall)]
@@ -3107,6 +3472,62 @@ func (fake *FakeTeam) ListVolumesReturnsOnCall(i int, result1 []atc.Volume, resu
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) ListWorkerKeys() ([]atc.WorkerKey, error) {
+	fake.listWorkerKeysMutex.Lock()
+	ret, specificReturn := fake.listWorkerKeysReturnsOnCall[len(fake.listWorkerKeysArgsForCall)]
+	fake.listWorkerKeysArgsForCall = append(fake.listWorkerKeysArgsForCall, struct {
+	}{})
+	stub := fake.ListWorkerKeysStub
+	fakeReturns := fake.listWorkerKeysReturns
+	fake.recordInvocation("ListWorkerKeys", []interface{}{})
+	fake.listWorkerKeysMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ListWorkerKeysCallCount() int {
+	fake.listWorkerKeysMutex.RLock()
+	defer fake.listWorkerKeysMutex.RUnlock()
+	return len(fake.listWorkerKeysArgsForCall)
+}
+
+func (fake *FakeTeam) ListWorkerKeysCalls(stub func() ([]atc.WorkerKey, error)) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = stub
+}
+
+func (fake *FakeTeam) ListWorkerKeysReturns(result1 []atc.WorkerKey, result2 error) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = nil
+	fake.listWorkerKeysReturns = struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ListWorkerKeysReturnsOnCall(i int, result1 []atc.WorkerKey, result2 error) {
+	fake.listWorkerKeysMutex.Lock()
+	defer fake.listWorkerKeysMutex.Unlock()
+	fake.ListWorkerKeysStub = nil
+	if fake.listWorkerKeysReturnsOnCall == nil {
+		fake.listWorkerKeysReturnsOnCall = make(map[int]struct {
+			result1 []atc.WorkerKey
+			result2 error
+		})
+	}
+	fake.listWorkerKeysReturnsOnCall[i] = struct {
+		result1 []atc.WorkerKey
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -4039,6 +4460,70 @@ func (fake *FakeTeam) ResourceVersionsReturnsOnCall(i int, result1 []atc.Resourc
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) RevokeWorkerKey(arg1 int) (bool, error) {
+	fake.revokeWorkerKeyMutex.Lock()
+	ret, specificReturn := fake.revokeWorkerKeyReturnsOnCall[len(fake.revokeWorkerKeyArgsForCall)]
+	fake.revokeWorkerKeyArgsForCall = append(fake.revokeWorkerKeyArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.RevokeWorkerKeyStub
+	fakeReturns := fake.revokeWorkerKeyReturns
+	fake.recordInvocation("RevokeWorkerKey", []interface{}{arg1})
+	fake.revokeWorkerKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RevokeWorkerKeyCallCount() int {
+	fake.revokeWorkerKeyMutex.RLock()
+	defer fake.revokeWorkerKeyMutex.RUnlock()
+	return len(fake.revokeWorkerKeyArgsForCall)
+}
+
+func (fake *FakeTeam) RevokeWorkerKeyCalls(stub func(int) (bool, error)) {
+	fake.revokeWorkerKeyMutex.Lock()
+	defer fake.revokeWorkerKeyMutex.Unlock()
+	fake.RevokeWorkerKeyStub = stub
+}
+
+func (fake *FakeTeam) RevokeWorkerKeyArgsForCall(i int) int {
+	fake.revokeWorkerKeyMutex.RLock()
+	defer fake.revokeWorkerKeyMutex.RUnlock()
+	argsForCall := fake.revokeWorkerKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) RevokeWorkerKeyReturns(result1 bool, result2 error) {
+	fake.revokeWorkerKeyMutex.Lock()
+	defer fake.revokeWorkerKeyMutex.Unlock()
+	fake.RevokeWorkerKeyStub = nil
+	fake.revokeWorkerKeyReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RevokeWorkerKeyReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeWorkerKeyMutex.Lock()
+	defer fake.revokeWorkerKeyMutex.Unlock()
+	fake.RevokeWorkerKeyStub = nil
+	if fake.revokeWorkerKeyReturnsOnCall == nil {
+		fake.revokeWorkerKeyReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeWorkerKeyReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) ScheduleJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.scheduleJobMutex.Lock()
 	ret, specificReturn := fake.scheduleJobReturnsOnCall[len(fake.scheduleJobArgsForCall)]
@@ -4104,6 +4589,77 @@ func (fake *FakeTeam) ScheduleJobReturnsOnCall(i int, result1 bool, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SearchBuildLogs(arg1 concourse.BuildLogSearch, arg2 concourse.Page) ([]atc.BuildLogMatch, concourse.Pagination, bool, error) {
+	fake.searchBuildLogsMutex.Lock()
+	ret, specificReturn := fake.searchBuildLogsReturnsOnCall[len(fake.searchBuildLogsArgsForCall)]
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		arg1 concourse.BuildLogSearch
+		arg2 concourse.Page
+	}{arg1, arg2})
+	stub := fake.SearchBuildLogsStub
+	fakeReturns := fake.searchBuildLogsReturns
+	fake.recordInvocation("SearchBuildLogs", []interface{}{arg1, arg2})
+	fake.searchBuildLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4
+}
+
+func (fake *FakeTeam) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakeTeam) SearchBuildLogsCalls(stub func(concourse.BuildLogSearch, concourse.Page) ([]atc.BuildLogMatch, concourse.Pagination, bool, error)) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = stub
+}
+
+func (fake *FakeTeam) SearchBuildLogsArgsForCall(i int) (concourse.BuildLogSearch, concourse.Page) {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	argsForCall := fake.searchBuildLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturns(result1 []atc.BuildLogMatch, result2 concourse.Pagination, result3 bool, result4 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 []atc.BuildLogMatch
+		result2 concourse.Pagination
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturnsOnCall(i int, result1 []atc.BuildLogMatch, result2 concourse.Pagination, result3 bool, result4 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	if fake.searchBuildLogsReturnsOnCall == nil {
+		fake.searchBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 []atc.BuildLogMatch
+			result2 concourse.Pagination
+			result3 bool
+			result4 error
+		})
+	}
+	fake.searchBuildLogsReturnsOnCall[i] = struct {
+		result1 []atc.BuildLogMatch
+		result2 concourse.Pagination
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
 func (fake *FakeTeam) SetJobBuildComment(arg1 atc.PipelineRef, arg2 string, arg3 string, arg4 string) (bool, error) {
 	fake.setJobBuildCommentMutex.Lock()
 	ret, specificReturn := fake.setJobBuildCommentReturnsOnCall[len(fake.setJobBuildCommentArgsForCall)]
@@ -4509,6 +5065,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.authMutex.RUnlock()
 	fake.buildInputsForJobMutex.RLock()
 	defer fake.buildInputsForJobMutex.RUnlock()
+	fake.buildStatsMutex.RLock()
+	defer fake.buildStatsMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
 	fake.buildsWithVersionAsInputMutex.RLock()
@@ -4531,12 +5089,16 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createBuildMutex.RUnlock()
 	fake.createJobBuildMutex.RLock()
 	defer fake.createJobBuildMutex.RUnlock()
+	fake.createJobBuildInTraceMutex.RLock()
+	defer fake.createJobBuildInTraceMutex.RUnlock()
 	fake.createOrUpdateMutex.RLock()
 	defer fake.createOrUpdateMutex.RUnlock()
 	fake.createOrUpdatePipelineConfigMutex.RLock()
 	defer fake.createOrUpdatePipelineConfigMutex.RUnlock()
 	fake.createPipelineBuildMutex.RLock()
 	defer fake.createPipelineBuildMutex.RUnlock()
+	fake.createWorkerKeyMutex.RLock()
+	defer fake.createWorkerKeyMutex.RUnlock()
 	fake.deletePipelineMutex.RLock()
 	defer fake.deletePipelineMutex.RUnlock()
 	fake.destroyTeamMutex.RLock()
@@ -4547,6 +5109,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.enableResourceVersionMutex.RUnlock()
 	fake.exposePipelineMutex.RLock()
 	defer fake.exposePipelineMutex.RUnlock()
+	fake.flakyJobsMutex.RLock()
+	defer fake.flakyJobsMutex.RUnlock()
 	fake.getArtifactMutex.RLock()
 	defer fake.getArtifactMutex.RUnlock()
 	fake.getContainerMutex.RLock()
@@ -4571,6 +5135,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.listResourcesMutex.RUnlock()
 	fake.listVolumesMutex.RLock()
 	defer fake.listVolumesMutex.RUnlock()
+	fake.listWorkerKeysMutex.RLock()
+	defer fake.listWorkerKeysMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.orderingPipelinesMutex.RLock()
@@ -4599,8 +5165,12 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.resourceMutex.RUnlock()
 	fake.resourceVersionsMutex.RLock()
 	defer fake.resourceVersionsMutex.RUnlock()
+	fake.revokeWorkerKeyMutex.RLock()
+	defer fake.revokeWorkerKeyMutex.RUnlock()
 	fake.scheduleJobMutex.RLock()
 	defer fake.scheduleJobMutex.RUnlock()
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
 	fake.setJobBuildCommentMutex.RLock()
 	defer fake.setJobBuildCommentMutex.RUnlock()
 	fake.setPinCommentMutex.RLock()
@@ -39,6 +39,7 @@ type Team interface {
 	JobBuild(pipelineRef atc.PipelineRef, jobName, buildName string) (atc.Build, bool, error)
 	JobBuilds(pipelineRef atc.PipelineRef, jobName string, page Page) ([]atc.Build, Pagination, bool, error)
 	CreateJobBuild(pipelineRef atc.PipelineRef, jobName string) (atc.Build, error)
+	CreateJobBuildInTrace(pipelineRef atc.PipelineRef, jobName string, traceparent string) (atc.Build, error)
 	RerunJobBuild(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error)
 	SetJobBuildComment(pipelineRef atc.PipelineRef, jobName string, buildName string, comment string) (bool, error)
 	ListJobs(pipelineRef atc.PipelineRef) ([]atc.Job, error)
@@ -72,11 +73,18 @@ type Team interface {
 	ListVolumes() ([]atc.Volume, error)
 	CreateBuild(plan atc.Plan) (atc.Build, error)
 	Builds(page Page) ([]atc.Build, Pagination, error)
+	SearchBuildLogs(search BuildLogSearch, page Page) ([]atc.BuildLogMatch, Pagination, bool, error)
+	BuildStats(filter BuildStatsFilter) (atc.BuildStats, bool, error)
+	FlakyJobs(filter BuildStatsFilter) ([]atc.FlakyJob, bool, error)
 	OrderingPipelines(pipelineNames []string) error
 	OrderingPipelinesWithinGroup(groupName string, instanceVars []atc.InstanceVars) error
 
 	CreateArtifact(io.Reader, string, []string) (atc.WorkerArtifact, error)
 	GetArtifact(int) (io.ReadCloser, error)
+
+	ListWorkerKeys() ([]atc.WorkerKey, error)
+	CreateWorkerKey(publicKey string) (atc.WorkerKey, error)
+	RevokeWorkerKey(id int) (bool, error)
 }
 
 type team struct {
@@ -0,0 +1,102 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) ListWorkerKeys() ([]atc.WorkerKey, error) {
+	var keys []atc.WorkerKey
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListWorkerKeys,
+	}, &internal.Response{
+		Result: &keys,
+	})
+	return keys, err
+}
+
+// CreateWorkerKey adds a key for the workers of key.TeamName, or for global
+// workers if it's empty.
+func (client *client) CreateWorkerKey(key atc.WorkerKey) (atc.WorkerKey, error) {
+	return createWorkerKey(client.connection, atc.CreateWorkerKey, nil, key)
+}
+
+func (client *client) RevokeWorkerKey(id int) (bool, error) {
+	return revokeWorkerKey(client.connection, atc.RevokeWorkerKey, rata.Params{
+		"worker_key_id": strconv.Itoa(id),
+	})
+}
+
+func (team *team) ListWorkerKeys() ([]atc.WorkerKey, error) {
+	var keys []atc.WorkerKey
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListTeamWorkerKeys,
+		Params:      rata.Params{"team_name": team.Name()},
+	}, &internal.Response{
+		Result: &keys,
+	})
+	return keys, err
+}
+
+func (team *team) CreateWorkerKey(publicKey string) (atc.WorkerKey, error) {
+	return createWorkerKey(team.connection, atc.CreateTeamWorkerKey, rata.Params{
+		"team_name": team.Name(),
+	}, atc.WorkerKey{PublicKey: publicKey})
+}
+
+func (team *team) RevokeWorkerKey(id int) (bool, error) {
+	return revokeWorkerKey(team.connection, atc.RevokeTeamWorkerKey, rata.Params{
+		"team_name":     team.Name(),
+		"worker_key_id": strconv.Itoa(id),
+	})
+}
+
+func createWorkerKey(connection internal.Connection, requestName string, params rata.Params, key atc.WorkerKey) (atc.WorkerKey, error) {
+	payload, err := json.Marshal(key)
+	if err != nil {
+		return atc.WorkerKey{}, err
+	}
+
+	var created atc.WorkerKey
+	err = connection.Send(internal.Request{
+		RequestName: requestName,
+		Params:      params,
+		Body:        bytes.NewBuffer(payload),
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, &internal.Response{
+		Result: &created,
+	})
+
+	if unexpectedResponseError, ok := err.(internal.UnexpectedResponseError); ok {
+		switch unexpectedResponseError.StatusCode {
+		case http.StatusBadRequest, http.StatusConflict:
+			return atc.WorkerKey{}, GenericError{Message: unexpectedResponseError.Body}
+		}
+	}
+
+	return created, err
+}
+
+func revokeWorkerKey(connection internal.Connection, requestName string, params rata.Params) (bool, error) {
+	err := connection.Send(internal.Request{
+		RequestName: requestName,
+		Params:      params,
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
@@ -0,0 +1,166 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Worker Keys", func() {
+	var expectedKeys []atc.WorkerKey
+
+	BeforeEach(func() {
+		expectedKeys = []atc.WorkerKey{
+			{ID: 1, PublicKey: "ssh-ed25519 AAAA", Fingerprint: "SHA256:global"},
+			{ID: 2, TeamName: "some-team", PublicKey: "ssh-ed25519 BBBB", Fingerprint: "SHA256:team"},
+		}
+	})
+
+	Describe("ListWorkerKeys", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/worker-keys"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedKeys),
+				),
+			)
+		})
+
+		It("returns all the keys", func() {
+			keys, err := client.ListWorkerKeys()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal(expectedKeys))
+		})
+	})
+
+	Describe("CreateWorkerKey", func() {
+		Context("when the key is created", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v1/worker-keys"),
+						ghttp.VerifyJSON(`{"id":0,"team_name":"some-team","public_key":"ssh-ed25519 BBBB"}`),
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedKeys[1]),
+					),
+				)
+			})
+
+			It("returns the key", func() {
+				key, err := client.CreateWorkerKey(atc.WorkerKey{TeamName: "some-team", PublicKey: "ssh-ed25519 BBBB"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(key).To(Equal(expectedKeys[1]))
+			})
+		})
+
+		Context("when the key already exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v1/worker-keys"),
+						ghttp.RespondWith(http.StatusConflict, "worker key already exists"),
+					),
+				)
+			})
+
+			It("returns the message as an error", func() {
+				_, err := client.CreateWorkerKey(atc.WorkerKey{PublicKey: "ssh-ed25519 BBBB"})
+				Expect(err).To(Equal(concourse.GenericError{Message: "worker key already exists"}))
+			})
+		})
+	})
+
+	Describe("RevokeWorkerKey", func() {
+		Context("when the key exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/worker-keys/42"),
+						ghttp.RespondWith(http.StatusNoContent, nil),
+					),
+				)
+			})
+
+			It("revokes the key", func() {
+				found, err := client.RevokeWorkerKey(42)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the key does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/worker-keys/42"),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				found, err := client.RevokeWorkerKey(42)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Team", func() {
+		Describe("ListWorkerKeys", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/worker-keys"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedKeys[1:]),
+					),
+				)
+			})
+
+			It("returns the keys of the team", func() {
+				keys, err := team.ListWorkerKeys()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys).To(Equal(expectedKeys[1:]))
+			})
+		})
+
+		Describe("CreateWorkerKey", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/worker-keys"),
+						ghttp.VerifyJSON(`{"id":0,"public_key":"ssh-ed25519 BBBB"}`),
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedKeys[1]),
+					),
+				)
+			})
+
+			It("returns the key", func() {
+				key, err := team.CreateWorkerKey("ssh-ed25519 BBBB")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(key).To(Equal(expectedKeys[1]))
+			})
+		})
+
+		Describe("RevokeWorkerKey", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/worker-keys/42"),
+						ghttp.RespondWith(http.StatusNoContent, nil),
+					),
+				)
+			})
+
+			It("revokes the key", func() {
+				found, err := team.RevokeWorkerKey(42)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+	})
+})
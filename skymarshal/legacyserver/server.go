@@ -39,6 +39,12 @@ func NewLegacyServer(config *LegacyConfig) (http.Handler, error) {
 			flyPort := r.FormValue("fly_port")
 			if flyPort != "" {
 				u.RawQuery = "redirect_uri=/fly_success%3Ffly_port=" + flyPort
+
+				// fly asks for a refresh token, which it then receives
+				// directly rather than through the fly success page
+				if r.FormValue("fly_refresh") == "true" {
+					u.RawQuery = url.Values{"fly_port": {flyPort}}.Encode()
+				}
 			}
 
 			http.Redirect(w, r, u.String(), http.StatusFound)
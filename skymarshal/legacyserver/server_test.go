@@ -65,6 +65,27 @@ var _ = Describe("Legacy API", func() {
 			})
 		})
 
+		Context("when fly asks for a refresh token", func() {
+
+			BeforeEach(func() {
+				request, err = http.NewRequest("GET", server.URL+"/login?fly_port=1234&fly_refresh=true", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				response, err = client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should pass the fly port on instead of a redirect_uri", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusFound))
+
+				url, err := response.Location()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(url.Path).To(Equal("/sky/login"))
+				Expect(url.Query()["fly_port"]).To(Equal([]string{"1234"}))
+				Expect(url.Query()).ToNot(HaveKey("redirect_uri"))
+			})
+		})
+
 		Context("when given other query param", func() {
 
 			BeforeEach(func() {
@@ -8,8 +8,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html/template"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -25,6 +27,11 @@ type SkyConfig struct {
 	TokenParser     token.Parser
 	OAuthConfig     *oauth2.Config
 	HTTPClient      *http.Client
+
+	// FlyOAuthConfig is used for browser logins started by fly which ask for
+	// a refresh token. The tokens are issued to fly's client, so that fly can
+	// refresh them itself.
+	FlyOAuthConfig *oauth2.Config
 }
 
 func NewSkyHandler(server *SkyServer) http.Handler {
@@ -47,6 +54,12 @@ func (s *SkyServer) Login(w http.ResponseWriter, r *http.Request) {
 
 	logger := s.config.Logger.Session("login")
 
+	// fly needs a refresh token of its own, which the web session can't give
+	if r.FormValue("fly_port") != "" {
+		s.NewLogin(w, r)
+		return
+	}
+
 	tokenString := s.config.TokenMiddleware.GetAuthToken(r)
 	if tokenString == "" {
 		s.NewLogin(w, r)
@@ -97,8 +110,22 @@ func (s *SkyServer) NewLogin(w http.ResponseWriter, r *http.Request) {
 		redirectURI = "/"
 	}
 
+	oauthConfig := s.config.OAuthConfig
+
+	flyPort := r.FormValue("fly_port")
+	if flyPort != "" {
+		if _, err := strconv.ParseUint(flyPort, 10, 16); err != nil || s.config.FlyOAuthConfig == nil {
+			logger.Error("invalid-fly-port", fmt.Errorf("unsupported fly port: %s", flyPort))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		oauthConfig = s.config.FlyOAuthConfig
+	}
+
 	stateToken := encode(stateToken{
 		RedirectURI: redirectURI,
+		FlyPort:     flyPort,
 		Entropy:     randomString(),
 	})
 
@@ -109,7 +136,7 @@ func (s *SkyServer) NewLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authCodeURL := s.config.OAuthConfig.AuthCodeURL(stateToken, oauth2.AccessTypeOffline)
+	authCodeURL := oauthConfig.AuthCodeURL(stateToken, oauth2.AccessTypeOffline)
 
 	http.Redirect(w, r, authCodeURL, http.StatusTemporaryRedirect)
 }
@@ -139,9 +166,18 @@ func (s *SkyServer) Callback(w http.ResponseWriter, r *http.Request) {
 
 	s.config.TokenMiddleware.UnsetStateToken(w)
 
+	state := decode(stateToken)
+
+	forFly := state.FlyPort != "" && s.config.FlyOAuthConfig != nil
+
+	oauthConfig := s.config.OAuthConfig
+	if forFly {
+		oauthConfig = s.config.FlyOAuthConfig
+	}
+
 	ctx := context.WithValue(r.Context(), oauth2.HTTPClient, s.config.HTTPClient)
 
-	dexToken, err := s.config.OAuthConfig.Exchange(ctx, r.FormValue("code"))
+	dexToken, err := oauthConfig.Exchange(ctx, r.FormValue("code"))
 	if err != nil {
 		logger.Error("failed-to-fetch-dex-token", err)
 		switch e := err.(type) {
@@ -154,9 +190,55 @@ func (s *SkyServer) Callback(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	s.Redirect(w, r, dexToken, decode(stateToken).RedirectURI)
+	if forFly {
+		s.RedirectToFly(w, r, dexToken, state.FlyPort)
+		return
+	}
+
+	s.Redirect(w, r, dexToken, state.RedirectURI)
 }
 
+// RedirectToFly hands the token and its refresh token to the server fly
+// listens on while logging in. They are posted from a form rather than put in
+// a redirect URL, so that they don't end up in the browser's history or in
+// any logs. The browser is sent back to the fly success page from there.
+func (s *SkyServer) RedirectToFly(w http.ResponseWriter, r *http.Request, oauth2Token *oauth2.Token, flyPort string) {
+	logger := s.config.Logger.Session("redirect-to-fly")
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	err := flyTokenForm.Execute(w, flyTokenFormData{
+		Action:       "http://127.0.0.1:" + flyPort + "/",
+		Token:        oauth2Token.TokenType + " " + oauth2Token.AccessToken,
+		RefreshToken: oauth2Token.RefreshToken,
+	})
+	if err != nil {
+		logger.Error("failed-to-render-fly-token-form", err)
+	}
+}
+
+type flyTokenFormData struct {
+	Action       string
+	Token        string
+	RefreshToken string
+}
+
+var flyTokenForm = template.Must(template.New("fly-token-form").Parse(`<!DOCTYPE html>
+<html>
+<body onload="document.forms[0].submit()">
+<form method="post" action="{{.Action}}">
+<input type="hidden" name="token" value="{{.Token}}">
+{{- if .RefreshToken}}
+<input type="hidden" name="refresh_token" value="{{.RefreshToken}}">
+{{- end}}
+<noscript><button type="submit">Send token to fly</button></noscript>
+</form>
+</body>
+</html>
+`))
+
 func (s *SkyServer) Redirect(w http.ResponseWriter, r *http.Request, oauth2Token *oauth2.Token, redirectURI string) {
 	logger := s.config.Logger.Session("redirect")
 
@@ -202,6 +284,7 @@ func (s *SkyServer) Logout(w http.ResponseWriter, r *http.Request) {
 
 type stateToken struct {
 	RedirectURI string `json:"redirect_uri"`
+	FlyPort     string `json:"fly_port,omitempty"`
 	Entropy     string `json:"entropy"`
 }
 
@@ -54,12 +54,20 @@ var _ = BeforeEach(func() {
 		Scopes:       []string{"some-scope"},
 	}
 
+	flyOAuthConfig := &oauth2.Config{
+		Endpoint:     endpoint,
+		ClientID:     "fly-client-id",
+		ClientSecret: "fly-client-secret",
+		Scopes:       []string{"some-scope", "offline_access"},
+	}
+
 	config = &skyserver.SkyConfig{
 		Logger:          lagertest.NewTestLogger("sky"),
 		TokenMiddleware: fakeTokenMiddleware,
 		TokenParser:     fakeTokenParser,
 		OAuthConfig:     oauthConfig,
 		HTTPClient:      dexServer.HTTPTestServer.Client(),
+		FlyOAuthConfig:  flyOAuthConfig,
 	}
 
 	server, err := skyserver.NewSkyServer(config)
@@ -2,7 +2,9 @@ package tracing
 
 import (
 	"context"
+	"net/http"
 
+	"github.com/concourse/concourse"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -28,13 +30,24 @@ type Config struct {
 	Jaeger      Jaeger
 	Stackdriver Stackdriver
 	OTLP        OTLP
+
+	// ClusterName is the name of the Concourse cluster, set from the ATC's
+	// own flag.
+	ClusterName string `no-flag:"true"`
 }
 
-func (c Config) resource() *resource.Resource {
+// Resource describes the process being traced. Metrics exporters use it too,
+// so that traces and metrics from the same process can be correlated.
+func (c Config) Resource() *resource.Resource {
 	attributes := []attribute.KeyValue{
 		semconv.TelemetrySDKNameKey.String("opentelemetry"),
 		semconv.TelemetrySDKLanguageKey.String("go"),
 		semconv.ServiceNameKey.String(c.ServiceName),
+		semconv.ServiceVersionKey.String(concourse.Version),
+	}
+
+	if c.ClusterName != "" {
+		attributes = append(attributes, attribute.String("concourse.cluster.name", c.ClusterName))
 	}
 
 	for key, value := range c.Attributes {
@@ -53,7 +66,7 @@ func (c Config) TraceProvider(exporter func() (sdktrace.SpanExporter, []sdktrace
 	options := append([]sdktrace.TracerProviderOption{
 		sdktrace.WithSampler(sdktrace.AlwaysSample()),
 		sdktrace.WithSyncer(exp),
-		sdktrace.WithResource(c.resource()),
+		sdktrace.WithResource(c.Resource()),
 	}, exporterOptions...)
 
 	provider := sdktrace.NewTracerProvider(options...)
@@ -142,6 +155,15 @@ type WithSpanContext interface {
 	SpanContext() propagation.TextMapCarrier
 }
 
+// HeaderSpanContext is the span context propagated with an HTTP request
+// through its W3C traceparent and tracestate headers, e.g. by whatever
+// triggered a build.
+type HeaderSpanContext http.Header
+
+func (h HeaderSpanContext) SpanContext() propagation.TextMapCarrier {
+	return propagation.HeaderCarrier(h)
+}
+
 func StartSpanFollowing(
 	ctx context.Context,
 	following WithSpanContext,
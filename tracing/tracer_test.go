@@ -3,9 +3,11 @@ package tracing_test
 import (
 	"context"
 
+	"github.com/concourse/concourse"
 	"github.com/concourse/concourse/tracing"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/oteltest"
+	"go.opentelemetry.io/otel/semconv"
 	"go.opentelemetry.io/otel/trace"
 
 	. "github.com/onsi/ginkgo"
@@ -91,4 +93,32 @@ var _ = Describe("Tracer", func() {
 			Expect(tracing.Configured).To(BeFalse())
 		})
 	})
+
+	Describe("Resource", func() {
+		It("describes the service and its cluster", func() {
+			c := tracing.Config{
+				ServiceName: "concourse-web",
+				ClusterName: "some-cluster",
+				Attributes:  map[string]string{"foo": "bar"},
+			}
+
+			resource := c.Resource()
+
+			value, found := resource.Set().Value(semconv.ServiceNameKey)
+			Expect(found).To(BeTrue())
+			Expect(value.AsString()).To(Equal("concourse-web"))
+
+			value, found = resource.Set().Value(semconv.ServiceVersionKey)
+			Expect(found).To(BeTrue())
+			Expect(value.AsString()).To(Equal(concourse.Version))
+
+			value, found = resource.Set().Value("concourse.cluster.name")
+			Expect(found).To(BeTrue())
+			Expect(value.AsString()).To(Equal("some-cluster"))
+
+			value, found = resource.Set().Value("foo")
+			Expect(found).To(BeTrue())
+			Expect(value.AsString()).To(Equal("bar"))
+		})
+	})
 })
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/rand"
 	"net"
 	"os"
@@ -54,6 +55,11 @@ type Client struct {
 
 	PrivateKey *rsa.PrivateKey
 
+	// Certificate is the path to an OpenSSH certificate of the public key of
+	// PrivateKey to authenticate with, if any. It's read whenever dialing so
+	// that it can be renewed while the worker is running.
+	Certificate string
+
 	Worker atc.Worker
 }
 
@@ -342,6 +348,13 @@ func (client *Client) dial(ctx context.Context, idleTimeout time.Duration) (*ssh
 		return nil, nil, fmt.Errorf("private key not provided")
 	}
 
+	if client.Certificate != "" {
+		pk, err = client.certSigner(pk)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	clientConfig := &ssh.ClientConfig{
 		Config: atc.DefaultSSHConfig(),
 
@@ -368,6 +381,30 @@ func (client *Client) dial(ctx context.Context, idleTimeout time.Duration) (*ssh
 	return ssh.NewClient(clientConn, chans, reqs), tcpConn.(*net.TCPConn), nil
 }
 
+func (client *Client) certSigner(signer ssh.Signer) (ssh.Signer, error) {
+	certBytes, err := ioutil.ReadFile(client.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read worker certificate: %s", err)
+	}
+
+	key, _, _, _, err := ssh.ParseAuthorizedKey(certBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse worker certificate: %s", err)
+	}
+
+	cert, ok := key.(*ssh.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("worker certificate is a plain public key")
+	}
+
+	certSigner, err := ssh.NewCertSigner(cert, signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct signer from worker certificate: %s", err)
+	}
+
+	return certSigner, nil
+}
+
 func (client *Client) tryDialAll(ctx context.Context) (net.Conn, string, error) {
 	logger := lagerctx.FromContext(ctx)
 
@@ -284,6 +284,25 @@ func (heartbeater *Heartbeater) pingWorker(logger lager.Logger) (atc.Worker, boo
 	registration.ActiveContainers = len(containers)
 	registration.ActiveVolumes = len(volumes)
 
+	capacity, err := heartbeater.gardenClient.Capacity()
+	if err != nil {
+		// not every runtime reports its capacity
+		logger.Debug("failed-to-fetch-capacity", lager.Data{"error": err.Error()})
+	} else {
+		resources := atc.WorkerResources{
+			Memory: capacity.MemoryInBytes,
+			Disk:   capacity.DiskInBytes,
+		}
+
+		if registration.Capacity != nil {
+			resources.CPU = registration.Capacity.CPU
+		}
+
+		if resources != (atc.WorkerResources{}) {
+			registration.Capacity = &resources
+		}
+	}
+
 	return registration, true
 }
 
@@ -3,6 +3,7 @@ package tsa_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -271,6 +272,47 @@ var _ = Describe("Heartbeater", func() {
 					Eventually(clientWriter).Should(gbytes.Say(`{"event":"heartbeated"}`))
 				})
 			})
+
+			Context("when Garden reports its capacity", func() {
+				BeforeEach(func() {
+					worker.Capacity = &atc.WorkerResources{CPU: 4096}
+					expectedWorker = worker
+
+					fakeGardenClient.CapacityReturns(garden.Capacity{
+						MemoryInBytes: 1024,
+						DiskInBytes:   2048,
+						MaxContainers: 250,
+					}, nil)
+
+					fakeATC1.AppendHandlers(verifyRegister)
+					fakeATC2.AppendHandlers(verifyHeartbeat)
+				})
+
+				It("includes the capacity along with the registered CPUs", func() {
+					expectedWorker.ActiveContainers = 2
+					expectedWorker.ActiveVolumes = 3
+					expectedWorker.Capacity = &atc.WorkerResources{
+						CPU:    4096,
+						Memory: 1024,
+						Disk:   2048,
+					}
+					Eventually(registrations).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
+				})
+			})
+
+			Context("when Garden fails to report its capacity", func() {
+				BeforeEach(func() {
+					fakeGardenClient.CapacityReturns(garden.Capacity{}, errors.New("not implemented"))
+
+					fakeATC1.AppendHandlers(verifyRegister)
+				})
+
+				It("registers without a capacity", func() {
+					expectedWorker.ActiveContainers = 2
+					expectedWorker.ActiveVolumes = 3
+					Eventually(registrations).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
+				})
+			})
 		})
 
 		Context("when heartbeat returns worker is landed", func() {
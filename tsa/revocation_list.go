@@ -0,0 +1,325 @@
+package tsa
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// krlMagic starts every OpenSSH key revocation list, as documented in
+// PROTOCOL.krl of OpenSSH.
+const krlMagic = "SSHKRL\n\x00"
+
+const krlFormatVersion = 1
+
+const (
+	krlSectionCertificates      = 1
+	krlSectionExplicitKey       = 2
+	krlSectionFingerprintSHA1   = 3
+	krlSectionSignature         = 4
+	krlSectionFingerprintSHA256 = 5
+
+	krlSectionCertSerialList   = 0x20
+	krlSectionCertSerialRange  = 0x21
+	krlSectionCertSerialBitmap = 0x22
+	krlSectionCertKeyID        = 0x23
+)
+
+// RevocationList is a set of revoked SSH keys and certificates, like the
+// RevokedKeys of sshd.
+type RevocationList struct {
+	keys   map[string]bool
+	sha1   map[string]bool
+	sha256 map[string]bool
+
+	certs []revokedCerts
+}
+
+// revokedCerts are the certificates revoked for a CA, or for any CA if ca is
+// empty.
+type revokedCerts struct {
+	ca []byte
+
+	serials []serialRange
+	bitmaps []serialBitmap
+	keyIDs  map[string]bool
+}
+
+type serialRange struct {
+	min, max uint64
+}
+
+type serialBitmap struct {
+	offset uint64
+	bits   *big.Int
+}
+
+// ParseRevocationList parses either an OpenSSH key revocation list (KRL), as
+// generated by `ssh-keygen -k`, or a list of public keys in authorized_keys
+// format. KRL signatures are not verified.
+func ParseRevocationList(data []byte) (*RevocationList, error) {
+	list := &RevocationList{
+		keys:   map[string]bool{},
+		sha1:   map[string]bool{},
+		sha256: map[string]bool{},
+	}
+
+	if !bytes.HasPrefix(data, []byte(krlMagic)) {
+		for _, line := range bytes.Split(data, []byte("\n")) {
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 || line[0] == '#' {
+				continue
+			}
+
+			key, _, _, _, err := ssh.ParseAuthorizedKey(line)
+			if err != nil {
+				return nil, fmt.Errorf("parse revoked key: %w", err)
+			}
+
+			list.keys[string(key.Marshal())] = true
+		}
+
+		return list, nil
+	}
+
+	r := &krlReader{data: data[len(krlMagic):]}
+
+	version := r.uint32()
+	if r.err == nil && version != krlFormatVersion {
+		return nil, fmt.Errorf("unsupported KRL format version %d", version)
+	}
+
+	r.uint64() // krl_version
+	r.uint64() // generated_date
+	r.uint64() // flags
+	r.string() // reserved
+	r.string() // comment
+
+	for r.err == nil && len(r.data) > 0 {
+		sectionType := r.byte()
+		section := &krlReader{data: r.string()}
+		if r.err != nil {
+			break
+		}
+
+		switch sectionType {
+		case krlSectionCertificates:
+			certs, err := parseRevokedCerts(section)
+			if err != nil {
+				return nil, err
+			}
+
+			list.certs = append(list.certs, certs)
+
+		case krlSectionExplicitKey:
+			for section.err == nil && len(section.data) > 0 {
+				list.keys[string(section.string())] = true
+			}
+
+		case krlSectionFingerprintSHA1:
+			for section.err == nil && len(section.data) > 0 {
+				list.sha1[string(section.string())] = true
+			}
+
+		case krlSectionFingerprintSHA256:
+			for section.err == nil && len(section.data) > 0 {
+				list.sha256[string(section.string())] = true
+			}
+
+		case krlSectionSignature:
+			// signatures cover everything before them; nothing else follows
+
+		default:
+			return nil, fmt.Errorf("unknown KRL section type %d", sectionType)
+		}
+
+		if section.err != nil {
+			return nil, section.err
+		}
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return list, nil
+}
+
+func parseRevokedCerts(r *krlReader) (revokedCerts, error) {
+	certs := revokedCerts{
+		ca:     r.string(),
+		keyIDs: map[string]bool{},
+	}
+
+	r.string() // reserved
+
+	for r.err == nil && len(r.data) > 0 {
+		sectionType := r.byte()
+		section := &krlReader{data: r.string()}
+		if r.err != nil {
+			break
+		}
+
+		switch sectionType {
+		case krlSectionCertSerialList:
+			for section.err == nil && len(section.data) > 0 {
+				serial := section.uint64()
+				certs.serials = append(certs.serials, serialRange{serial, serial})
+			}
+
+		case krlSectionCertSerialRange:
+			certs.serials = append(certs.serials, serialRange{
+				min: section.uint64(),
+				max: section.uint64(),
+			})
+
+		case krlSectionCertSerialBitmap:
+			certs.bitmaps = append(certs.bitmaps, serialBitmap{
+				offset: section.uint64(),
+				bits:   new(big.Int).SetBytes(section.string()),
+			})
+
+		case krlSectionCertKeyID:
+			for section.err == nil && len(section.data) > 0 {
+				certs.keyIDs[string(section.string())] = true
+			}
+
+		default:
+			return revokedCerts{}, fmt.Errorf("unknown KRL certificate section type %d", sectionType)
+		}
+
+		if section.err != nil {
+			return revokedCerts{}, section.err
+		}
+	}
+
+	return certs, r.err
+}
+
+// IsRevoked returns whether a key is revoked. Certificates are also revoked
+// when their key or the key of their CA is.
+func (list *RevocationList) IsRevoked(key ssh.PublicKey) bool {
+	if list == nil {
+		return false
+	}
+
+	if cert, ok := key.(*ssh.Certificate); ok {
+		return list.isCertRevoked(cert) ||
+			list.IsRevoked(cert.Key) ||
+			list.IsRevoked(cert.SignatureKey)
+	}
+
+	blob := key.Marshal()
+
+	sha1Sum := sha1.Sum(blob)
+	sha256Sum := sha256.Sum256(blob)
+
+	return list.keys[string(blob)] ||
+		list.sha1[string(sha1Sum[:])] ||
+		list.sha256[string(sha256Sum[:])]
+}
+
+func (list *RevocationList) isCertRevoked(cert *ssh.Certificate) bool {
+	ca := cert.SignatureKey.Marshal()
+
+	for _, certs := range list.certs {
+		if len(certs.ca) != 0 && !bytes.Equal(certs.ca, ca) {
+			continue
+		}
+
+		if certs.keyIDs[cert.KeyId] {
+			return true
+		}
+
+		for _, serials := range certs.serials {
+			if cert.Serial >= serials.min && cert.Serial <= serials.max {
+				return true
+			}
+		}
+
+		for _, bitmap := range certs.bitmaps {
+			if cert.Serial < bitmap.offset {
+				continue
+			}
+
+			bit := cert.Serial - bitmap.offset
+			if bit < uint64(bitmap.bits.BitLen()) && bitmap.bits.Bit(int(bit)) == 1 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+var errTruncatedKRL = errors.New("truncated KRL")
+
+// krlReader reads the SSH wire encoding of a KRL, remembering the first
+// error so that fields can be read one after another.
+type krlReader struct {
+	data []byte
+	err  error
+}
+
+func (r *krlReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+
+	if len(r.data) < n {
+		r.err = errTruncatedKRL
+		return nil
+	}
+
+	next := r.data[:n]
+	r.data = r.data[n:]
+
+	return next
+}
+
+func (r *krlReader) byte() byte {
+	b := r.next(1)
+	if b == nil {
+		return 0
+	}
+
+	return b[0]
+}
+
+func (r *krlReader) uint32() uint32 {
+	b := r.next(4)
+	if b == nil {
+		return 0
+	}
+
+	return binary.BigEndian.Uint32(b)
+}
+
+func (r *krlReader) uint64() uint64 {
+	b := r.next(8)
+	if b == nil {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(b)
+}
+
+func (r *krlReader) string() []byte {
+	length := r.uint32()
+	if r.err != nil {
+		return nil
+	}
+
+	if uint64(length) > uint64(len(r.data)) {
+		r.err = errTruncatedKRL
+		return nil
+	}
+
+	return r.next(int(length))
+}
@@ -0,0 +1,309 @@
+package tsa_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/concourse/concourse/tsa"
+	"golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RevocationList", func() {
+	var (
+		caSigner  ssh.Signer
+		workerKey ssh.PublicKey
+		otherKey  ssh.PublicKey
+		cert      *ssh.Certificate
+
+		data []byte
+
+		list     *tsa.RevocationList
+		parseErr error
+	)
+
+	newKey := func() (ssh.PublicKey, ssh.Signer) {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		signer, err := ssh.NewSignerFromKey(priv)
+		Expect(err).ToNot(HaveOccurred())
+
+		return signer.PublicKey(), signer
+	}
+
+	BeforeEach(func() {
+		_, caSigner = newKey()
+		workerKey, _ = newKey()
+		otherKey, _ = newKey()
+
+		cert = &ssh.Certificate{
+			Key:             workerKey,
+			Serial:          42,
+			CertType:        ssh.UserCert,
+			KeyId:           "some-worker",
+			ValidPrincipals: []string{"global"},
+			ValidBefore:     ssh.CertTimeInfinity,
+		}
+		Expect(cert.SignCert(rand.Reader, caSigner)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		list, parseErr = tsa.ParseRevocationList(data)
+	})
+
+	Context("with a list of public keys", func() {
+		BeforeEach(func() {
+			data = ssh.MarshalAuthorizedKey(workerKey)
+		})
+
+		It("revokes the keys", func() {
+			Expect(parseErr).ToNot(HaveOccurred())
+			Expect(list.IsRevoked(workerKey)).To(BeTrue())
+			Expect(list.IsRevoked(otherKey)).To(BeFalse())
+		})
+
+		It("revokes certificates of the keys", func() {
+			Expect(list.IsRevoked(cert)).To(BeTrue())
+		})
+	})
+
+	Context("with a list of public keys with comments and blank lines", func() {
+		BeforeEach(func() {
+			data = []byte("# revoked workers\n\n")
+			data = append(data, ssh.MarshalAuthorizedKey(workerKey)...)
+			data = append(data, []byte("\n# no longer used\n")...)
+		})
+
+		It("revokes the keys", func() {
+			Expect(parseErr).ToNot(HaveOccurred())
+			Expect(list.IsRevoked(workerKey)).To(BeTrue())
+			Expect(list.IsRevoked(otherKey)).To(BeFalse())
+		})
+	})
+
+	Context("with a list of only comments", func() {
+		BeforeEach(func() {
+			data = []byte("# nothing revoked yet\n")
+		})
+
+		It("revokes nothing", func() {
+			Expect(parseErr).ToNot(HaveOccurred())
+			Expect(list.IsRevoked(workerKey)).To(BeFalse())
+		})
+	})
+
+	Context("with a malformed list of public keys", func() {
+		BeforeEach(func() {
+			data = []byte("bogus")
+		})
+
+		It("errors", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+
+	Context("with a KRL", func() {
+		var sections [][]byte
+
+		BeforeEach(func() {
+			sections = nil
+		})
+
+		JustBeforeEach(func() {
+			data = krl(sections...)
+			list, parseErr = tsa.ParseRevocationList(data)
+			Expect(parseErr).ToNot(HaveOccurred())
+		})
+
+		Context("revoking explicit keys", func() {
+			BeforeEach(func() {
+				sections = append(sections, krlSection(2, krlString(workerKey.Marshal())))
+			})
+
+			It("revokes the keys and their certificates", func() {
+				Expect(list.IsRevoked(workerKey)).To(BeTrue())
+				Expect(list.IsRevoked(cert)).To(BeTrue())
+				Expect(list.IsRevoked(otherKey)).To(BeFalse())
+			})
+		})
+
+		Context("revoking SHA256 fingerprints", func() {
+			BeforeEach(func() {
+				sum := sha256.Sum256(workerKey.Marshal())
+				sections = append(sections, krlSection(5, krlString(sum[:])))
+			})
+
+			It("revokes the keys", func() {
+				Expect(list.IsRevoked(workerKey)).To(BeTrue())
+				Expect(list.IsRevoked(otherKey)).To(BeFalse())
+			})
+		})
+
+		Context("revoking the CA", func() {
+			BeforeEach(func() {
+				sections = append(sections, krlSection(2, krlString(caSigner.PublicKey().Marshal())))
+			})
+
+			It("revokes its certificates", func() {
+				Expect(list.IsRevoked(cert)).To(BeTrue())
+				Expect(list.IsRevoked(workerKey)).To(BeFalse())
+			})
+		})
+
+		Context("revoking certificate serials", func() {
+			var ca []byte
+			var serials []byte
+
+			BeforeEach(func() {
+				ca = caSigner.PublicKey().Marshal()
+			})
+
+			JustBeforeEach(func() {
+				list, parseErr = tsa.ParseRevocationList(krl(krlSection(1, krlCerts(ca, serials))))
+				Expect(parseErr).ToNot(HaveOccurred())
+			})
+
+			Context("in a list", func() {
+				BeforeEach(func() {
+					serials = krlSection(0x20, krlUint64(1), krlUint64(42))
+				})
+
+				It("revokes the certificate", func() {
+					Expect(list.IsRevoked(cert)).To(BeTrue())
+				})
+
+				Context("of another CA", func() {
+					BeforeEach(func() {
+						ca = otherKey.Marshal()
+					})
+
+					It("does not revoke the certificate", func() {
+						Expect(list.IsRevoked(cert)).To(BeFalse())
+					})
+				})
+			})
+
+			Context("in a range", func() {
+				BeforeEach(func() {
+					serials = krlSection(0x21, krlUint64(40), krlUint64(50))
+				})
+
+				It("revokes the certificate", func() {
+					Expect(list.IsRevoked(cert)).To(BeTrue())
+				})
+			})
+
+			Context("in a range not including it", func() {
+				BeforeEach(func() {
+					serials = krlSection(0x21, krlUint64(43), krlUint64(50))
+				})
+
+				It("does not revoke the certificate", func() {
+					Expect(list.IsRevoked(cert)).To(BeFalse())
+				})
+			})
+
+			Context("in a bitmap", func() {
+				BeforeEach(func() {
+					// bits 0 and 2 past 40
+					serials = krlSection(0x22, krlUint64(40), krlString([]byte{0x05}))
+				})
+
+				It("revokes the certificate", func() {
+					Expect(list.IsRevoked(cert)).To(BeTrue())
+				})
+			})
+
+			Context("by key ID", func() {
+				BeforeEach(func() {
+					serials = krlSection(0x23, krlString([]byte("some-worker")))
+				})
+
+				It("revokes the certificate", func() {
+					Expect(list.IsRevoked(cert)).To(BeTrue())
+				})
+
+				Context("of any CA", func() {
+					BeforeEach(func() {
+						ca = nil
+					})
+
+					It("revokes the certificate", func() {
+						Expect(list.IsRevoked(cert)).To(BeTrue())
+					})
+				})
+			})
+		})
+	})
+
+	Context("with a truncated KRL", func() {
+		BeforeEach(func() {
+			data = krl(krlSection(2, krlString(workerKey.Marshal())))
+			data = data[:len(data)-1]
+		})
+
+		It("errors", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+
+	Context("with a nil list", func() {
+		It("revokes nothing", func() {
+			var list *tsa.RevocationList
+			Expect(list.IsRevoked(workerKey)).To(BeFalse())
+		})
+	})
+})
+
+func krl(sections ...[]byte) []byte {
+	data := []byte("SSHKRL\n\x00")
+	data = append(data, 0, 0, 0, 1) // format version
+	data = append(data, krlUint64(1)...)
+	data = append(data, krlUint64(0)...)
+	data = append(data, krlUint64(0)...)
+	data = append(data, krlString(nil)...)
+	data = append(data, krlString([]byte("some comment"))...)
+
+	for _, section := range sections {
+		data = append(data, section...)
+	}
+
+	return data
+}
+
+func krlSection(sectionType byte, fields ...[]byte) []byte {
+	var data []byte
+	for _, field := range fields {
+		data = append(data, field...)
+	}
+
+	return append([]byte{sectionType}, krlString(data)...)
+}
+
+func krlCerts(ca []byte, sections ...[]byte) []byte {
+	data := krlString(ca)
+	data = append(data, krlString(nil)...)
+
+	for _, section := range sections {
+		data = append(data, section...)
+	}
+
+	return data
+}
+
+func krlString(s []byte) []byte {
+	data := make([]byte, 4, 4+len(s))
+	binary.BigEndian.PutUint32(data, uint32(len(s)))
+	return append(data, s...)
+}
+
+func krlUint64(n uint64) []byte {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, n)
+	return data
+}
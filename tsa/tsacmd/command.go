@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -44,6 +46,11 @@ type TSACommand struct {
 	TeamAuthorizedKeys     map[string]flag.AuthorizedKeys `long:"team-authorized-keys" value-name:"NAME:PATH" description:"Path to file containing keys to authorize, in SSH authorized_keys format (one public key per line)."`
 	TeamAuthorizedKeysFile flag.File                      `long:"team-authorized-keys-file" description:"Path to file containing a YAML array of teams and their authorized SSH keys, e.g. [{team:foo,ssh_keys:[key1,key2]}]."`
 
+	TrustedUserCAKeys flag.AuthorizedKeys `long:"trusted-user-ca-keys" description:"Path to file containing public keys of CAs whose OpenSSH user certificates are authorized, in SSH authorized_keys format. The certificate's principal must be 'global' for a global worker, or the name of the worker's team."`
+	RevokedKeys       flag.File           `long:"revoked-keys"         description:"Path to an OpenSSH key revocation list (KRL), or to a file of public keys, revoking keys and certificates of workers. Reloaded on SIGHUP, disconnecting the workers it revokes."`
+
+	WorkerKeys WorkerKeys `no-flag:"true"`
+
 	ATCURLs []flag.URL `long:"atc-url" required:"true" description:"ATC API endpoints to which workers will be registered."`
 
 	ClientID     string   `long:"client-id" default:"concourse-worker" description:"Client used to fetch a token from the auth server. NOTE: if you change this value you will also need to change the --system-claim-value flag so the atc knows to allow requests from this client."`
@@ -58,6 +65,36 @@ type TSACommand struct {
 	LogClusterName bool   `long:"log-cluster-name" description:"Log cluster name."`
 }
 
+// globalPrincipal is the certificate principal of workers that aren't
+// specific to a team.
+const globalPrincipal = "global"
+
+// authorizedKeyExtension records the key, or certificate, a connection was
+// authorized by, so that it can be closed once revoked or expired.
+const authorizedKeyExtension = "authorized-key"
+
+// sourceAddressOption restricts the addresses a certificate may be used from,
+// as a comma-separated list of addresses and CIDR ranges.
+const sourceAddressOption = "source-address"
+
+// workerKeyFingerprintExtension records which of the WorkerKeys a connection
+// was authorized by, so that it can be closed when the key is revoked.
+const workerKeyFingerprintExtension = "worker-key-fingerprint"
+
+// WorkerKeys authorizes workers by the keys managed through the ATC API,
+// rather than the TSA's own flags. Authorize returns the team the key is for,
+// or "" for global workers. Revocations receives the fingerprints of revoked
+// keys, whose workers are disconnected. As revocations may be missed, the
+// connected workers are also checked with IsRevoked every
+// workerKeyReconcileInterval.
+type WorkerKeys interface {
+	Authorize(ssh.PublicKey) (string, bool, error)
+	Revocations() <-chan string
+	IsRevoked(fingerprint string) bool
+}
+
+const workerKeyReconcileInterval = time.Minute
+
 type TeamAuthKeys struct {
 	Team     string
 	AuthKeys []ssh.PublicKey
@@ -105,16 +142,21 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 		return nil, fmt.Errorf("failed to load team authorized keys: %s", err)
 	}
 
-	if len(cmd.AuthorizedKeys.Keys)+len(cmd.TeamAuthorizedKeys) == 0 {
+	if len(cmd.AuthorizedKeys.Keys)+len(cmd.TeamAuthorizedKeys)+len(cmd.TrustedUserCAKeys.Keys) == 0 {
 		logger.Info("starting-tsa-without-authorized-keys")
 	}
 
+	revokedKeys, err := cmd.loadRevokedKeys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load revoked keys: %s", err)
+	}
+
 	sessionAuthTeam := &sessionTeam{
 		sessionTeams: make(map[string]string),
 		lock:         &sync.RWMutex{},
 	}
 
-	config, err := cmd.configureSSHServer(sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys)
+	config, err := cmd.configureSSHServer(sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys, cmd.TrustedUserCAKeys.Keys, revokedKeys)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure SSH server: %s", err)
 	}
@@ -142,12 +184,14 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 		config:               config,
 		httpClient:           httpClient,
 		sessionTeam:          sessionAuthTeam,
+		authorizedConns:      newAuthorizedConns(),
+		workerKeyConns:       newWorkerKeyConns(),
 		gardenRequestTimeout: cmd.GardenRequestTimeout,
 	}
 	// Starts a goroutine whose purpose is to listen to the
 	// SIGHUP syscall and reload configuration upon receiving the signal.
-	// For now it only reloads the TSACommand.AuthorizedKeys but
-	// other configuration can potentially be added.
+	// For now it only reloads the authorized keys, the trusted CA keys and
+	// the revoked keys, but other configuration can potentially be added.
 	go func() {
 		reloadWorkerKeys := make(chan os.Signal, 1)
 		defer close(reloadWorkerKeys)
@@ -171,18 +215,39 @@ func (cmd *TSACommand) Runner(args []string) (ifrit.Runner, error) {
 				continue
 			}
 
+			if cmd.TrustedUserCAKeys.File != "" {
+				err = cmd.TrustedUserCAKeys.Reload()
+				if err != nil {
+					logger.Error("failed to reload trusted user CA keys file : %s", err)
+					continue
+				}
+			}
+
+			revokedKeys, err := cmd.loadRevokedKeys()
+			if err != nil {
+				logger.Error("failed to load revoked keys : %s", err)
+				continue
+			}
+
 			// Reconfigure the SSH server with the new keys
-			config, err := cmd.configureSSHServer(sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys)
+			config, err := cmd.configureSSHServer(sessionAuthTeam, cmd.AuthorizedKeys.Keys, teamAuthorizedKeys, cmd.TrustedUserCAKeys.Keys, revokedKeys)
 			if err != nil {
 				logger.Error("failed to configure SSH server: %s", err)
 				continue
 			}
 
 			server.config = config
+
+			server.authorizedConns.CloseRevoked(logger, revokedKeys)
 		}
 	}()
 
-	return serverRunner{logger, server, listenAddr}, nil
+	var revocations <-chan string
+	if cmd.WorkerKeys != nil {
+		revocations = cmd.WorkerKeys.Revocations()
+	}
+
+	return serverRunner{logger, server, listenAddr, revocations, cmd.WorkerKeys, workerKeyReconcileInterval}, nil
 }
 
 func (cmd *TSACommand) constructLogger() (lager.Logger, *lager.ReconfigurableSink) {
@@ -238,16 +303,41 @@ func (cmd *TSACommand) loadTeamAuthorizedKeys() ([]TeamAuthKeys, error) {
 	return teamKeys, nil
 }
 
-func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authorizedKeys []ssh.PublicKey, teamAuthorizedKeys []TeamAuthKeys) (*ssh.ServerConfig, error) {
+func (cmd *TSACommand) loadRevokedKeys() (*tsa.RevocationList, error) {
+	if cmd.RevokedKeys == "" {
+		return nil, nil
+	}
+
+	revokedKeysBytes, err := ioutil.ReadFile(cmd.RevokedKeys.Path())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read revoked keys file: %s", err)
+	}
+
+	return tsa.ParseRevocationList(revokedKeysBytes)
+}
+
+func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authorizedKeys []ssh.PublicKey, teamAuthorizedKeys []TeamAuthKeys, trustedUserCAKeys []ssh.PublicKey, revokedKeys *tsa.RevocationList) (*ssh.ServerConfig, error) {
 	certChecker := &ssh.CertChecker{
 		IsUserAuthority: func(key ssh.PublicKey) bool {
+			for _, k := range trustedUserCAKeys {
+				if bytes.Equal(k.Marshal(), key.Marshal()) {
+					return true
+				}
+			}
+
 			return false
 		},
 
+		IsRevoked: func(cert *ssh.Certificate) bool {
+			return revokedKeys.IsRevoked(cert)
+		},
+
 		IsHostAuthority: func(key ssh.PublicKey, address string) bool {
 			return false
 		},
 
+		SupportedCriticalOptions: []string{sourceAddressOption},
+
 		UserKeyFallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
 			for _, k := range authorizedKeys {
 				if bytes.Equal(k.Marshal(), key.Marshal()) {
@@ -264,6 +354,25 @@ func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authoriz
 				}
 			}
 
+			if cmd.WorkerKeys != nil {
+				team, found, err := cmd.WorkerKeys.Authorize(key)
+				if err != nil {
+					return nil, fmt.Errorf("failed to look up worker key: %s", err)
+				}
+
+				if found {
+					if team != "" {
+						sessionAuthTeam.AuthorizeTeam(string(conn.SessionID()), team)
+					}
+
+					return &ssh.Permissions{
+						Extensions: map[string]string{
+							workerKeyFingerprintExtension: ssh.FingerprintSHA256(key),
+						},
+					}, nil
+				}
+			}
+
 			return nil, fmt.Errorf("unknown public key")
 		},
 	}
@@ -271,7 +380,25 @@ func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authoriz
 	config := &ssh.ServerConfig{
 		Config: atc.DefaultSSHConfig(),
 		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
-			return certChecker.Authenticate(conn, key)
+			if revokedKeys.IsRevoked(key) {
+				return nil, fmt.Errorf("revoked public key")
+			}
+
+			var permissions *ssh.Permissions
+			var err error
+
+			cert, ok := key.(*ssh.Certificate)
+			if ok {
+				permissions, err = authenticateCertificate(certChecker, sessionAuthTeam, conn, cert)
+			} else {
+				permissions, err = certChecker.Authenticate(conn, key)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			return withAuthorizedKey(permissions, key), nil
 		},
 	}
 
@@ -285,6 +412,101 @@ func (cmd *TSACommand) configureSSHServer(sessionAuthTeam *sessionTeam, authoriz
 	return config, nil
 }
 
+// authenticateCertificate authorizes a worker presenting a user certificate
+// of a trusted CA. Rather than the SSH user, which workers don't set, the
+// certificate's principal decides which team the worker is authorized for,
+// with 'global' authorizing a global worker.
+func authenticateCertificate(certChecker *ssh.CertChecker, sessionAuthTeam *sessionTeam, conn ssh.ConnMetadata, cert *ssh.Certificate) (*ssh.Permissions, error) {
+	if cert.CertType != ssh.UserCert {
+		return nil, fmt.Errorf("certificate has type %d", cert.CertType)
+	}
+
+	if !certChecker.IsUserAuthority(cert.SignatureKey) {
+		return nil, fmt.Errorf("certificate signed by unrecognized authority")
+	}
+
+	// certificates without principals are valid for any principal, which
+	// would leave the worker's team up to chance
+	if len(cert.ValidPrincipals) != 1 {
+		return nil, fmt.Errorf("certificate must have exactly one principal, has %d", len(cert.ValidPrincipals))
+	}
+
+	principal := cert.ValidPrincipals[0]
+
+	err := certChecker.CheckCert(principal, cert)
+	if err != nil {
+		return nil, err
+	}
+
+	if sourceAddress, ok := cert.CriticalOptions[sourceAddressOption]; ok {
+		err := checkSourceAddress(conn.RemoteAddr(), sourceAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if principal != globalPrincipal {
+		sessionAuthTeam.AuthorizeTeam(string(conn.SessionID()), principal)
+	}
+
+	return &cert.Permissions, nil
+}
+
+// checkSourceAddress returns an error unless the address is one of the
+// comma-separated addresses and CIDR ranges of a source-address option.
+func checkSourceAddress(addr net.Addr, sourceAddress string) error {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return fmt.Errorf("certificate restricted to source addresses, but remote address is %s", addr)
+	}
+
+	for _, allowed := range strings.Split(sourceAddress, ",") {
+		if strings.Contains(allowed, "/") {
+			_, ipNet, err := net.ParseCIDR(allowed)
+			if err != nil {
+				return fmt.Errorf("invalid source address %q: %s", allowed, err)
+			}
+
+			if ipNet.Contains(tcpAddr.IP) {
+				return nil
+			}
+
+			continue
+		}
+
+		ip := net.ParseIP(allowed)
+		if ip == nil {
+			return fmt.Errorf("invalid source address %q", allowed)
+		}
+
+		if ip.Equal(tcpAddr.IP) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("certificate not allowed from %s", tcpAddr.IP)
+}
+
+// withAuthorizedKey returns a copy of the permissions recording the key the
+// connection was authorized by.
+func withAuthorizedKey(permissions *ssh.Permissions, key ssh.PublicKey) *ssh.Permissions {
+	authorized := &ssh.Permissions{
+		Extensions: map[string]string{
+			authorizedKeyExtension: string(key.Marshal()),
+		},
+	}
+
+	if permissions != nil {
+		authorized.CriticalOptions = permissions.CriticalOptions
+
+		for name, value := range permissions.Extensions {
+			authorized.Extensions[name] = value
+		}
+	}
+
+	return authorized
+}
+
 func (cmd *TSACommand) debugBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.DebugBindIP, cmd.DebugBindPort)
 }
@@ -0,0 +1,271 @@
+package tsacmd
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"net"
+	"sync"
+
+	"github.com/concourse/concourse/tsa"
+	"github.com/concourse/flag"
+	"golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeConnMetadata is the connection of a worker, of which authentication
+// only looks at the session ID and the remote address.
+type fakeConnMetadata struct {
+	ssh.ConnMetadata
+	sessionID  string
+	remoteAddr net.Addr
+}
+
+func (conn fakeConnMetadata) SessionID() []byte    { return []byte(conn.sessionID) }
+func (conn fakeConnMetadata) RemoteAddr() net.Addr { return conn.remoteAddr }
+
+var _ = Describe("Certificate authentication", func() {
+	var (
+		caSigner  ssh.Signer
+		signer    ssh.Signer
+		workerKey ssh.PublicKey
+
+		revokedKeys *tsa.RevocationList
+
+		sessionAuthTeam *sessionTeam
+		config          *ssh.ServerConfig
+		conn            fakeConnMetadata
+
+		cert *ssh.Certificate
+
+		permissions *ssh.Permissions
+		authErr     error
+	)
+
+	newKey := func() (ssh.PublicKey, ssh.Signer) {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		signer, err := ssh.NewSignerFromKey(priv)
+		Expect(err).ToNot(HaveOccurred())
+
+		return signer.PublicKey(), signer
+	}
+
+	BeforeEach(func() {
+		_, caSigner = newKey()
+		signer = caSigner
+		workerKey, _ = newKey()
+
+		revokedKeys = nil
+
+		sessionAuthTeam = &sessionTeam{
+			sessionTeams: map[string]string{},
+			lock:         &sync.RWMutex{},
+		}
+
+		conn = fakeConnMetadata{
+			sessionID:  "some-session",
+			remoteAddr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234},
+		}
+
+		cert = &ssh.Certificate{
+			Key:             workerKey,
+			Serial:          42,
+			CertType:        ssh.UserCert,
+			KeyId:           "some-worker",
+			ValidPrincipals: []string{"some-team"},
+			ValidBefore:     ssh.CertTimeInfinity,
+			Permissions: ssh.Permissions{
+				Extensions: map[string]string{"permit-port-forwarding": ""},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(cert.SignCert(rand.Reader, signer)).To(Succeed())
+
+		hostKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+
+		cmd := &TSACommand{HostKey: &flag.PrivateKey{PrivateKey: hostKey}}
+
+		config, err = cmd.configureSSHServer(
+			sessionAuthTeam,
+			nil,
+			nil,
+			[]ssh.PublicKey{caSigner.PublicKey()},
+			revokedKeys,
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		permissions, authErr = config.PublicKeyCallback(conn, cert)
+	})
+
+	Context("with a certificate of a trusted CA", func() {
+		It("authorizes the worker for the team of the principal", func() {
+			Expect(authErr).ToNot(HaveOccurred())
+			Expect(sessionAuthTeam.AuthorizedTeamFor("some-session")).To(Equal("some-team"))
+		})
+
+		It("grants the permissions of the certificate", func() {
+			Expect(permissions.Extensions).To(HaveKeyWithValue("permit-port-forwarding", ""))
+		})
+
+		It("records the certificate the connection is authorized by", func() {
+			key, found := authorizedKey(permissions)
+			Expect(found).To(BeTrue())
+			Expect(key.Marshal()).To(Equal(cert.Marshal()))
+		})
+
+		Context("when the certificate is restricted to the worker's address", func() {
+			BeforeEach(func() {
+				cert.CriticalOptions = map[string]string{"source-address": "192.168.0.1,10.0.0.0/24"}
+			})
+
+			It("authorizes the worker", func() {
+				Expect(authErr).ToNot(HaveOccurred())
+				Expect(sessionAuthTeam.AuthorizedTeamFor("some-session")).To(Equal("some-team"))
+			})
+
+			It("keeps the restriction", func() {
+				Expect(permissions.CriticalOptions).To(HaveKeyWithValue("source-address", "192.168.0.1,10.0.0.0/24"))
+			})
+		})
+
+		Context("when the certificate is restricted to other addresses", func() {
+			BeforeEach(func() {
+				cert.CriticalOptions = map[string]string{"source-address": "192.168.0.1,10.0.1.0/24"}
+			})
+
+			It("rejects it", func() {
+				Expect(authErr).To(MatchError("certificate not allowed from 10.0.0.1"))
+				Expect(sessionAuthTeam.AuthorizedTeamFor("some-session")).To(BeEmpty())
+			})
+		})
+
+		Context("when the certificate has an unknown critical option", func() {
+			BeforeEach(func() {
+				cert.CriticalOptions = map[string]string{"force-command": "/bin/true"}
+			})
+
+			It("rejects it", func() {
+				Expect(authErr).To(HaveOccurred())
+				Expect(sessionAuthTeam.AuthorizedTeamFor("some-session")).To(BeEmpty())
+			})
+		})
+
+		Context("when the principal is global", func() {
+			BeforeEach(func() {
+				cert.ValidPrincipals = []string{"global"}
+			})
+
+			It("authorizes a global worker", func() {
+				Expect(authErr).ToNot(HaveOccurred())
+				Expect(sessionAuthTeam.AuthorizedTeamFor("some-session")).To(BeEmpty())
+			})
+		})
+
+		Context("when the certificate has no principal", func() {
+			BeforeEach(func() {
+				cert.ValidPrincipals = nil
+			})
+
+			It("rejects it, rather than leaving the team up to chance", func() {
+				Expect(authErr).To(MatchError("certificate must have exactly one principal, has 0"))
+				Expect(sessionAuthTeam.AuthorizedTeamFor("some-session")).To(BeEmpty())
+			})
+		})
+
+		Context("when the certificate has many principals", func() {
+			BeforeEach(func() {
+				cert.ValidPrincipals = []string{"some-team", "other-team"}
+			})
+
+			It("rejects it", func() {
+				Expect(authErr).To(MatchError("certificate must have exactly one principal, has 2"))
+				Expect(sessionAuthTeam.AuthorizedTeamFor("some-session")).To(BeEmpty())
+			})
+		})
+
+		Context("when the certificate is a host certificate", func() {
+			BeforeEach(func() {
+				cert.CertType = ssh.HostCert
+			})
+
+			It("rejects it", func() {
+				Expect(authErr).To(HaveOccurred())
+			})
+		})
+
+		Context("when the certificate has expired", func() {
+			BeforeEach(func() {
+				cert.ValidBefore = 1
+			})
+
+			It("rejects it", func() {
+				Expect(authErr).To(HaveOccurred())
+				Expect(sessionAuthTeam.AuthorizedTeamFor("some-session")).To(BeEmpty())
+			})
+		})
+
+		Context("when the key of the worker is revoked", func() {
+			BeforeEach(func() {
+				var err error
+				revokedKeys, err = tsa.ParseRevocationList(ssh.MarshalAuthorizedKey(workerKey))
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("rejects the certificate", func() {
+				Expect(authErr).To(MatchError("revoked public key"))
+				Expect(sessionAuthTeam.AuthorizedTeamFor("some-session")).To(BeEmpty())
+			})
+		})
+
+		Context("when another key is revoked", func() {
+			BeforeEach(func() {
+				otherKey, _ := newKey()
+
+				var err error
+				revokedKeys, err = tsa.ParseRevocationList(ssh.MarshalAuthorizedKey(otherKey))
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("authorizes the worker", func() {
+				Expect(authErr).ToNot(HaveOccurred())
+				Expect(sessionAuthTeam.AuthorizedTeamFor("some-session")).To(Equal("some-team"))
+			})
+		})
+	})
+
+	Context("with a certificate of a CA that isn't trusted", func() {
+		BeforeEach(func() {
+			_, signer = newKey()
+		})
+
+		It("rejects it", func() {
+			Expect(authErr).To(MatchError("certificate signed by unrecognized authority"))
+			Expect(sessionAuthTeam.AuthorizedTeamFor("some-session")).To(BeEmpty())
+		})
+	})
+
+	Describe("authenticateCertificate", func() {
+		It("rejects certificates that the checker revokes", func() {
+			certChecker := &ssh.CertChecker{
+				IsUserAuthority: func(key ssh.PublicKey) bool { return true },
+				IsRevoked:       func(*ssh.Certificate) bool { return true },
+			}
+
+			otherSessionTeam := &sessionTeam{
+				sessionTeams: map[string]string{},
+				lock:         &sync.RWMutex{},
+			}
+
+			_, err := authenticateCertificate(certChecker, otherSessionTeam, conn, cert)
+			Expect(err).To(MatchError(ContainSubstring("revoked")))
+			Expect(otherSessionTeam.AuthorizedTeamFor("some-session")).To(BeEmpty())
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"net/http"
 	"strings"
@@ -30,6 +31,8 @@ type server struct {
 	config               *ssh.ServerConfig
 	httpClient           *http.Client
 	sessionTeam          *sessionTeam
+	authorizedConns      *authorizedConns
+	workerKeyConns       *workerKeyConns
 }
 
 type sessionTeam struct {
@@ -60,6 +63,156 @@ func (s *sessionTeam) AuthorizedTeamFor(sessionID string) string {
 	return s.sessionTeams[sessionID]
 }
 
+// authorizedConns tracks connections by the key, or certificate, they were
+// authorized by.
+type authorizedConns struct {
+	conns map[ssh.Conn]ssh.PublicKey
+	lock  *sync.Mutex
+}
+
+func newAuthorizedConns() *authorizedConns {
+	return &authorizedConns{
+		conns: make(map[ssh.Conn]ssh.PublicKey),
+		lock:  &sync.Mutex{},
+	}
+}
+
+func (c *authorizedConns) Add(conn ssh.Conn, key ssh.PublicKey) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.conns[conn] = key
+}
+
+func (c *authorizedConns) Remove(conn ssh.Conn) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.conns, conn)
+}
+
+// CloseRevoked disconnects the workers whose key or certificate has been
+// revoked since they connected.
+func (c *authorizedConns) CloseRevoked(logger lager.Logger, revokedKeys *tsa.RevocationList) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for conn, key := range c.conns {
+		if !revokedKeys.IsRevoked(key) {
+			continue
+		}
+
+		logger.Info("disconnecting-revoked-worker", lager.Data{
+			"fingerprint": ssh.FingerprintSHA256(key),
+			"remote":      conn.RemoteAddr().String(),
+		})
+
+		conn.Close()
+	}
+}
+
+// authorizedKey returns the key, or certificate, a connection was authorized
+// by.
+func authorizedKey(permissions *ssh.Permissions) (ssh.PublicKey, bool) {
+	if permissions == nil {
+		return nil, false
+	}
+
+	blob, ok := permissions.Extensions[authorizedKeyExtension]
+	if !ok {
+		return nil, false
+	}
+
+	key, err := ssh.ParsePublicKey([]byte(blob))
+	if err != nil {
+		return nil, false
+	}
+
+	return key, true
+}
+
+// certificateExpiry returns when a certificate stops being valid, if ever.
+func certificateExpiry(cert *ssh.Certificate) (time.Time, bool) {
+	if cert.ValidBefore > math.MaxInt64 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(cert.ValidBefore), 0), true
+}
+
+// workerKeyConns tracks the connections of workers authorized by WorkerKeys,
+// by the fingerprint of the key.
+type workerKeyConns struct {
+	conns map[string]map[ssh.Conn]bool
+	lock  *sync.Mutex
+}
+
+func newWorkerKeyConns() *workerKeyConns {
+	return &workerKeyConns{
+		conns: make(map[string]map[ssh.Conn]bool),
+		lock:  &sync.Mutex{},
+	}
+}
+
+func (c *workerKeyConns) Add(fingerprint string, conn ssh.Conn) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.conns[fingerprint] == nil {
+		c.conns[fingerprint] = make(map[ssh.Conn]bool)
+	}
+
+	c.conns[fingerprint][conn] = true
+}
+
+func (c *workerKeyConns) Remove(fingerprint string, conn ssh.Conn) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.conns[fingerprint], conn)
+
+	if len(c.conns[fingerprint]) == 0 {
+		delete(c.conns, fingerprint)
+	}
+}
+
+// Close disconnects the workers using a revoked key.
+func (c *workerKeyConns) Close(logger lager.Logger, fingerprint string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for conn := range c.conns[fingerprint] {
+		logger.Info("disconnecting-revoked-worker-key", lager.Data{
+			"fingerprint": fingerprint,
+			"remote":      conn.RemoteAddr().String(),
+		})
+
+		conn.Close()
+	}
+}
+
+// CloseRevoked disconnects the workers using keys that have been revoked,
+// in case their revocation was missed.
+func (c *workerKeyConns) CloseRevoked(logger lager.Logger, workerKeys WorkerKeys) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for fingerprint, conns := range c.conns {
+		if !workerKeys.IsRevoked(fingerprint) {
+			continue
+		}
+
+		for conn := range conns {
+			logger.Info("disconnecting-revoked-worker-key", lager.Data{
+				"fingerprint": fingerprint,
+				"remote":      conn.RemoteAddr().String(),
+			})
+
+			conn.Close()
+		}
+	}
+}
+
 type ConnState struct {
 	Team string
 
@@ -111,6 +264,32 @@ func (server *server) handshake(logger lager.Logger, netConn net.Conn) {
 
 	defer conn.Close()
 
+	if key, ok := authorizedKey(conn.Permissions); ok {
+		server.authorizedConns.Add(conn, key)
+		defer server.authorizedConns.Remove(conn)
+
+		if cert, ok := key.(*ssh.Certificate); ok {
+			if expiry, ok := certificateExpiry(cert); ok {
+				expire := time.AfterFunc(time.Until(expiry), func() {
+					logger.Info("disconnecting-expired-certificate", lager.Data{
+						"key-id": cert.KeyId,
+					})
+
+					conn.Close()
+				})
+
+				defer expire.Stop()
+			}
+		}
+	}
+
+	if conn.Permissions != nil {
+		if fingerprint, ok := conn.Permissions.Extensions[workerKeyFingerprintExtension]; ok {
+			server.workerKeyConns.Add(fingerprint, conn)
+			defer server.workerKeyConns.Remove(fingerprint, conn)
+		}
+	}
+
 	ctx, cancel := context.WithCancel(lagerctx.NewContext(context.Background(), logger))
 	defer cancel()
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 )
@@ -14,6 +15,11 @@ type serverRunner struct {
 	server *server
 
 	listenAddr string
+
+	revocations <-chan string
+
+	workerKeys        WorkerKeys
+	reconcileInterval time.Duration
 }
 
 func (runner serverRunner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
@@ -33,12 +39,24 @@ func (runner serverRunner) Run(signals <-chan os.Signal, ready chan<- struct{})
 		runner.server.Serve(listener)
 	}()
 
+	var reconcile <-chan time.Time
+	if runner.workerKeys != nil {
+		ticker := time.NewTicker(runner.reconcileInterval)
+		defer ticker.Stop()
+
+		reconcile = ticker.C
+	}
+
 	for {
 		select {
 		case <-exited:
 			return nil
 		case <-signals:
 			listener.Close()
+		case fingerprint := <-runner.revocations:
+			runner.server.workerKeyConns.Close(runner.logger, fingerprint)
+		case <-reconcile:
+			runner.server.workerKeyConns.CloseRevoked(runner.logger, runner.workerKeys)
 		}
 	}
 }
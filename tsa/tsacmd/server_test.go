@@ -0,0 +1,153 @@
+package tsacmd
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/tsa"
+	"golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeConn is the connection of a worker, which only needs to be closed.
+type fakeConn struct {
+	ssh.Conn
+	closed bool
+}
+
+func (conn *fakeConn) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}
+}
+
+func (conn *fakeConn) Close() error {
+	conn.closed = true
+	return nil
+}
+
+// fakeWorkerKeys revokes the keys of the given fingerprints.
+type fakeWorkerKeys struct {
+	WorkerKeys
+	revoked map[string]bool
+}
+
+func (keys fakeWorkerKeys) IsRevoked(fingerprint string) bool {
+	return keys.revoked[fingerprint]
+}
+
+var _ = Describe("Worker key connections", func() {
+	Describe("CloseRevoked", func() {
+		It("closes the connections of keys revoked since they connected", func() {
+			conns := newWorkerKeyConns()
+
+			revokedConn := &fakeConn{}
+			otherConn := &fakeConn{}
+
+			conns.Add("revoked-fingerprint", revokedConn)
+			conns.Add("other-fingerprint", otherConn)
+
+			conns.CloseRevoked(lagertest.NewTestLogger("test"), fakeWorkerKeys{
+				revoked: map[string]bool{"revoked-fingerprint": true},
+			})
+
+			Expect(revokedConn.closed).To(BeTrue())
+			Expect(otherConn.closed).To(BeFalse())
+		})
+	})
+})
+
+var _ = Describe("Authorized connections", func() {
+	newKey := func() ssh.PublicKey {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		key, err := ssh.NewPublicKey(pub)
+		Expect(err).ToNot(HaveOccurred())
+
+		return key
+	}
+
+	Describe("CloseRevoked", func() {
+		var (
+			conns *authorizedConns
+
+			revokedKey ssh.PublicKey
+			otherKey   ssh.PublicKey
+
+			revokedConn *fakeConn
+			otherConn   *fakeConn
+		)
+
+		BeforeEach(func() {
+			conns = newAuthorizedConns()
+
+			revokedKey = newKey()
+			otherKey = newKey()
+
+			revokedConn = &fakeConn{}
+			otherConn = &fakeConn{}
+
+			conns.Add(revokedConn, revokedKey)
+			conns.Add(otherConn, otherKey)
+		})
+
+		It("closes the connections of revoked keys", func() {
+			revokedKeys, err := tsa.ParseRevocationList(ssh.MarshalAuthorizedKey(revokedKey))
+			Expect(err).ToNot(HaveOccurred())
+
+			conns.CloseRevoked(lagertest.NewTestLogger("test"), revokedKeys)
+
+			Expect(revokedConn.closed).To(BeTrue())
+			Expect(otherConn.closed).To(BeFalse())
+		})
+
+		It("closes the connections of certificates whose key is revoked", func() {
+			cert := &ssh.Certificate{Key: revokedKey, SignatureKey: otherKey}
+
+			certConn := &fakeConn{}
+			conns.Add(certConn, cert)
+
+			revokedKeys, err := tsa.ParseRevocationList(ssh.MarshalAuthorizedKey(revokedKey))
+			Expect(err).ToNot(HaveOccurred())
+
+			conns.CloseRevoked(lagertest.NewTestLogger("test"), revokedKeys)
+
+			Expect(certConn.closed).To(BeTrue())
+		})
+
+		It("leaves removed connections alone", func() {
+			conns.Remove(revokedConn)
+
+			revokedKeys, err := tsa.ParseRevocationList(ssh.MarshalAuthorizedKey(revokedKey))
+			Expect(err).ToNot(HaveOccurred())
+
+			conns.CloseRevoked(lagertest.NewTestLogger("test"), revokedKeys)
+
+			Expect(revokedConn.closed).To(BeFalse())
+		})
+
+		It("closes nothing without revoked keys", func() {
+			conns.CloseRevoked(lagertest.NewTestLogger("test"), nil)
+
+			Expect(revokedConn.closed).To(BeFalse())
+			Expect(otherConn.closed).To(BeFalse())
+		})
+	})
+
+	Describe("certificateExpiry", func() {
+		It("returns when the certificate stops being valid", func() {
+			expiry, ok := certificateExpiry(&ssh.Certificate{ValidBefore: 1600000000})
+			Expect(ok).To(BeTrue())
+			Expect(expiry).To(Equal(time.Unix(1600000000, 0)))
+		})
+
+		It("returns nothing for certificates valid forever", func() {
+			_, ok := certificateExpiry(&ssh.Certificate{ValidBefore: ssh.CertTimeInfinity})
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
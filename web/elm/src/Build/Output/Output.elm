@@ -190,6 +190,11 @@ handleEvent event ( model, effects ) =
             , effects
             )
 
+        TaskKilled origin reason time ->
+            ( updateStep origin.id (appendStepLog ("\u{001B}[31mkilled: " ++ reason ++ "\u{001B}[0m\n") time) model
+            , effects
+            )
+
         Initialize origin time ->
             ( updateStep origin.id (setInitialize time) model
             , effects
@@ -192,6 +192,7 @@ type BuildEvent
     | InitializeTask Origin Time.Posix
     | StartTask Origin Time.Posix
     | FinishTask Origin Int Time.Posix
+    | TaskKilled Origin String (Maybe Time.Posix)
     | Initialize Origin Time.Posix
     | Start Origin Time.Posix
     | Finish Origin Time.Posix Bool
@@ -131,6 +131,15 @@ decodeBuildEvent =
                                 (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
                             )
 
+                    "task-killed" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map3 TaskKilled
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "reason" decodeExitReason)
+                                (Json.Decode.maybe <| Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
                     "initialize" ->
                         Json.Decode.field
                             "data"
@@ -230,6 +239,48 @@ decodeBuildEvent =
             )
 
 
+decodeExitReason : Json.Decode.Decoder String
+decodeExitReason =
+    Json.Decode.map4
+        (\signal oomKilled limit peak ->
+            if oomKilled then
+                case List.filterMap identity [ Maybe.map ((++) "limit " << formatBytes) limit, Maybe.map ((++) "peak " << formatBytes) peak ] of
+                    [] ->
+                        "out of memory"
+
+                    details ->
+                        "out of memory (" ++ String.join ", " details ++ ")"
+
+            else
+                signal
+        )
+        (Json.Decode.field "signal" Json.Decode.string)
+        (Json.Decode.map (Maybe.withDefault False) <| Json.Decode.maybe <| Json.Decode.field "oom_killed" Json.Decode.bool)
+        (Json.Decode.maybe <| Json.Decode.field "memory_limit" Json.Decode.int)
+        (Json.Decode.maybe <| Json.Decode.field "memory_peak" Json.Decode.int)
+
+
+formatBytes : Int -> String
+formatBytes bytes =
+    let
+        scale value units =
+            case units of
+                unit :: rest ->
+                    if value < 1024 || List.isEmpty rest then
+                        ( value, unit )
+
+                    else
+                        scale (value / 1024) rest
+
+                [] ->
+                    ( value, "B" )
+
+        ( scaled, suffix ) =
+            scale (toFloat bytes) [ "B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB" ]
+    in
+    String.fromFloat (toFloat (round (scaled * 10)) / 10) ++ suffix
+
+
 dateFromSeconds : Int -> Time.Posix
 dateFromSeconds =
     Time.millisToPosix << (*) 1000
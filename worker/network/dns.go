@@ -1,7 +1,7 @@
 package network
 
 import (
-	"fmt"
+	"net"
 
 	"github.com/miekg/dns"
 )
@@ -18,19 +18,18 @@ func DNSServer() (*dns.Server, error) {
 		for _, server := range resolvConf.Servers {
 			// TODO: support the `search` configuration
 
-			response, _, err := client.Exchange(r, fmt.Sprintf("%s:%s", server, resolvConf.Port))
+			// JoinHostPort brackets IPv6 nameservers
+			response, _, err := client.Exchange(r, net.JoinHostPort(server, resolvConf.Port))
 			if err == nil {
 				response.Compress = true
 				_ = w.WriteMsg(response)
-				break
+				return
 			}
 		}
 
-		if err != nil {
-			var m *dns.Msg
-			m.SetRcode(r, dns.RcodeRefused)
-			_ = w.WriteMsg(m)
-		}
+		m := new(dns.Msg)
+		m.SetRcode(r, dns.RcodeRefused)
+		_ = w.WriteMsg(m)
 	})
 
 	return &dns.Server{
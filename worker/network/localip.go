@@ -0,0 +1,37 @@
+package network
+
+import (
+	"net"
+
+	"code.cloudfoundry.org/localip"
+)
+
+// LocalIP determines the address that the host uses for outbound traffic,
+// falling back to IPv6 on hosts that have no route for IPv4.
+func LocalIP() (string, error) {
+	ip, err := localip.LocalIP()
+	if err == nil {
+		return ip, nil
+	}
+
+	// as with the IPv4 lookup, dialing UDP only picks a route; nothing is
+	// actually sent to this address
+	addr, v6Err := net.ResolveUDPAddr("udp6", "[2001:db8::1]:1")
+	if v6Err != nil {
+		return "", err
+	}
+
+	conn, v6Err := net.DialUDP("udp6", nil, addr)
+	if v6Err != nil {
+		return "", err
+	}
+
+	defer conn.Close()
+
+	host, _, err := net.SplitHostPort(conn.LocalAddr().String())
+	if err != nil {
+		return "", err
+	}
+
+	return host, nil
+}
@@ -8,9 +8,14 @@ package runtime
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"sync"
+	"syscall"
 	"time"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	atcruntime "github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd"
 	bespec "github.com/concourse/concourse/worker/runtime/spec"
 	"github.com/containerd/containerd"
@@ -32,14 +37,31 @@ type GardenBackend struct {
 	userNamespace UserNamespace
 	initBinPath   string
 
-	maxContainers  int
-	requestTimeout time.Duration
-	createLock     TimeoutWithByPassLock
+	maxContainers    int
+	diskCapacityPath string
+	requestTimeout   time.Duration
+	createLock       TimeoutWithByPassLock
+
+	securityAllowList bespec.SecurityAllowList
+
+	warmPool  *WarmPool
+	claimLock *sync.Mutex
+
+	checkpointRestore bool
+
+	diskQuota DiskQuota
+
+	logger lager.Logger
 }
 
 //counterfeiter:generate . UserNamespace
 type UserNamespace interface {
 	MaxValidIds() (uid, gid uint32, err error)
+
+	// Rootless determines whether the backend runs in a user namespace
+	// whose root is an unprivileged user on the host.
+	//
+	Rootless() (rootless bool, err error)
 }
 
 func WithUserNamespace(s UserNamespace) GardenBackendOpt {
@@ -85,6 +107,64 @@ func WithMaxContainers(limit int) GardenBackendOpt {
 	}
 }
 
+// WithLogger configures the logger that failures which don't fail a request
+// are logged to.
+//
+func WithLogger(logger lager.Logger) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.logger = logger
+	}
+}
+
+// WithDiskCapacityPath configures the directory whose filesystem's size is
+// reported as the disk capacity of the backend.
+//
+func WithDiskCapacityPath(path string) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.diskCapacityPath = path
+	}
+}
+
+// WithSecurityAllowList configures the privileges that containers may be
+// granted through their security config.
+//
+func WithSecurityAllowList(allowList bespec.SecurityAllowList) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.securityAllowList = allowList
+	}
+}
+
+// WithWarmPool configures the backend to keep containers of some images
+// created ahead of time, for them to be claimed instead of being created.
+//
+func WithWarmPool(pool WarmPool) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		if pool.Namespaces == nil {
+			pool.Namespaces = hostNamespaces{}
+		}
+
+		b.warmPool = &pool
+	}
+}
+
+// WithCheckpointRestore allows the creation of migratable containers, whose
+// processes can be checkpointed and restored with CRIU.
+//
+func WithCheckpointRestore() GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.checkpointRestore = true
+	}
+}
+
+// WithDiskQuota enforces the disk limits of containers with the quota.
+// Without it, disk limits are only recorded.
+//
+func WithDiskQuota(quota DiskQuota) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.diskQuota = quota
+	}
+}
+
 // WithRequestTimeout configures the request timeout
 // Currently only used as timeout for acquiring the create container lock
 func WithRequestTimeout(requestTimeout time.Duration) GardenBackendOpt {
@@ -111,7 +191,8 @@ func NewGardenBackend(client libcontainerd.Client, opts ...GardenBackendOpt) (b
 	}
 
 	b = GardenBackend{
-		client: client,
+		client:    client,
+		claimLock: &sync.Mutex{},
 	}
 	for _, opt := range opts {
 		opt(&b)
@@ -134,6 +215,10 @@ func NewGardenBackend(client libcontainerd.Client, opts ...GardenBackendOpt) (b
 		b.killer = NewKiller()
 	}
 
+	if b.logger == nil {
+		b.logger = lager.NewLogger("garden-backend")
+	}
+
 	if b.rootfsManager == nil {
 		b.rootfsManager = NewRootfsManager()
 	}
@@ -187,23 +272,65 @@ func (b *GardenBackend) Ping() (err error) {
 
 // Create creates a new container.
 //
+// Containers requested with the atcruntime.WarmPoolPropertyName are instead
+// claimed from the warm pool, failing with ErrWarmPoolEmpty if there's none
+// available.
+//
+// Egress rules to host names are resolved here, so that they permit the
+// addresses the names resolve to from the worker.
+//
 func (b *GardenBackend) Create(gdnSpec garden.ContainerSpec) (garden.Container, error) {
 	ctx := context.Background()
 
+	hostRules, err := resolveEgressHosts(gdnSpec.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	gdnSpec.NetOut = append(gdnSpec.NetOut, hostRules...)
+
+	if image, found := gdnSpec.Properties[atcruntime.WarmPoolPropertyName]; found {
+		cont, err := b.claimWarmContainer(ctx, image, gdnSpec)
+		if err != nil {
+			return nil, fmt.Errorf("claim warm container: %w", err)
+		}
+
+		return NewContainer(
+			cont,
+			b.killer,
+			b.rootfsManager,
+			b.network,
+			b.diskQuota,
+			b.logger,
+		), nil
+	}
+
+	migratable := isMigratable(gdnSpec.Properties)
+	if migratable && !b.checkpointRestore {
+		return nil, ErrCheckpointRestoreDisabled
+	}
+
 	cont, err := b.createContainer(ctx, gdnSpec)
 	if err != nil {
 		return nil, fmt.Errorf("new container: %w", err)
 	}
 
-	err = b.startTask(ctx, cont)
-	if err != nil {
-		return nil, fmt.Errorf("starting task: %w", err)
+	// the task of a migratable container is only created once its process
+	// gets run, as its init process
+	if !migratable {
+		err = b.startTask(ctx, cont, gdnSpec)
+		if err != nil {
+			return nil, fmt.Errorf("starting task: %w", err)
+		}
 	}
 
 	return NewContainer(
 		cont,
 		b.killer,
 		b.rootfsManager,
+		b.network,
+		b.diskQuota,
+		b.logger,
 	), nil
 }
 
@@ -215,6 +342,17 @@ func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.Cont
 	}
 	defer b.createLock.Release()
 
+	if gdnSpec.Privileged {
+		rootless, err := b.userNamespace.Rootless()
+		if err != nil {
+			return nil, fmt.Errorf("checking for rootless: %w", err)
+		}
+
+		if rootless {
+			return nil, ErrPrivilegedRootless
+		}
+	}
+
 	err = b.checkContainerCapacity(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("checking container capacity: %w", err)
@@ -230,6 +368,11 @@ func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.Cont
 		return nil, fmt.Errorf("garden spec to oci spec: %w", err)
 	}
 
+	err = bespec.OciSecurity(oci, gdnSpec.Properties, b.securityAllowList)
+	if err != nil {
+		return nil, fmt.Errorf("security config: %w", err)
+	}
+
 	netMounts, err := b.network.SetupMounts(gdnSpec.Handle)
 	if err != nil {
 		return nil, fmt.Errorf("network setup mounts: %w", err)
@@ -237,22 +380,47 @@ func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.Cont
 
 	oci.Mounts = append(oci.Mounts, netMounts...)
 
+	if isMigratable(gdnSpec.Properties) {
+		err = migratableAnnotations(oci, gdnSpec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	labels, err := propertiesToLabels(gdnSpec.Properties)
 	if err != nil {
 		return nil, fmt.Errorf("convert properties to labels: %w", err)
 	}
-	return b.client.NewContainer(ctx, gdnSpec.Handle, labels, oci)
+
+	if gdnSpec.Limits.Disk.ByteHard == 0 || b.diskQuota == nil {
+		return b.client.NewContainer(ctx, gdnSpec.Handle, labels, oci)
+	}
+
+	projectID, err := b.diskQuota.Limit(writablePaths(oci), gdnSpec.Limits.Disk.ByteHard)
+	if err != nil {
+		return nil, fmt.Errorf("limit disk usage: %w", err)
+	}
+
+	oci.Annotations[diskQuotaProjectAnnotation] = strconv.FormatUint(uint64(projectID), 10)
+
+	cont, err := b.client.NewContainer(ctx, gdnSpec.Handle, labels, oci)
+	if err != nil {
+		_ = b.diskQuota.Release(projectID)
+		return nil, err
+	}
+
+	return cont, nil
 }
 
-func (b *GardenBackend) startTask(ctx context.Context, cont containerd.Container) error {
+func (b *GardenBackend) startTask(ctx context.Context, cont containerd.Container, gdnSpec garden.ContainerSpec) error {
 	task, err := cont.NewTask(ctx, cio.NullIO, containerd.WithNoNewKeyring)
 	if err != nil {
 		return fmt.Errorf("new task: %w", err)
 	}
 
-	err = b.network.Add(ctx, task, cont.ID())
+	err = addNetwork(ctx, b.network, task, cont.ID(), gdnSpec.Network, gdnSpec.NetOut)
 	if err != nil {
-		return fmt.Errorf("network add: %w", err)
+		return err
 	}
 
 	return task.Start(ctx)
@@ -267,7 +435,7 @@ func (b *GardenBackend) Destroy(handle string) error {
 
 	ctx := context.Background()
 
-	container, err := b.client.GetContainer(ctx, handle)
+	container, err := b.getContainer(ctx, handle)
 	if err != nil {
 		return fmt.Errorf("get container: %w", err)
 	}
@@ -278,20 +446,26 @@ func (b *GardenBackend) Destroy(handle string) error {
 			return fmt.Errorf("task lookup: %w", err)
 		}
 
-		err = container.Delete(ctx)
-		if err != nil {
-			return fmt.Errorf("deleting container: %w", err)
-		}
+		return b.deleteContainer(ctx, container)
+	}
 
-		return nil
+	migratable, err := migratableContainer(ctx, container)
+	if err != nil {
+		return err
 	}
 
-	err = b.killer.Kill(ctx, task, KillGracefully)
+	// the process of a migratable container is its init process, which the
+	// killer leaves alone
+	if migratable {
+		err = killInit(ctx, task, KillGracefully)
+	} else {
+		err = b.killer.Kill(ctx, task, KillGracefully)
+	}
 	if err != nil {
 		return fmt.Errorf("gracefully killing task: %w", err)
 	}
 
-	err = b.network.Remove(ctx, task, handle)
+	err = b.network.Remove(ctx, task, container.ID())
 	if err != nil {
 		return fmt.Errorf("network remove: %w", err)
 	}
@@ -301,11 +475,49 @@ func (b *GardenBackend) Destroy(handle string) error {
 		return fmt.Errorf("task remove: %w", err)
 	}
 
+	return b.deleteContainer(ctx, container)
+}
+
+// deleteContainer deletes a container whose task is gone, along with its
+// root filesystem if it was created for the warm pool.
+//
+func (b *GardenBackend) deleteContainer(ctx context.Context, container containerd.Container) error {
+	spec, err := container.Spec(ctx)
+	if err != nil {
+		return fmt.Errorf("container spec: %w", err)
+	}
+
 	err = container.Delete(ctx)
 	if err != nil {
 		return fmt.Errorf("deleting container: %w", err)
 	}
 
+	projectID, found, err := diskQuotaProject(spec)
+	if err != nil {
+		return err
+	}
+
+	if found && b.diskQuota != nil {
+		err = b.diskQuota.Release(projectID)
+		if err != nil {
+			return fmt.Errorf("release disk quota: %w", err)
+		}
+	}
+
+	if b.warmPool != nil && isWarmContainer(container.ID()) {
+		if spec != nil && spec.Root != nil {
+			err = b.warmPool.Namespaces.ReleaseRootfs(spec.Root.Path)
+			if err != nil {
+				return fmt.Errorf("release warm container rootfs: %w", err)
+			}
+		}
+
+		err = b.warmPool.Rootfs.Destroy(container.ID())
+		if err != nil {
+			return fmt.Errorf("destroy warm container rootfs: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -324,13 +536,23 @@ func (b *GardenBackend) Containers(properties garden.Properties) ([]garden.Conta
 		return nil, err
 	}
 
-	containers := make([]garden.Container, len(res))
-	for i, containerdContainer := range res {
-		containers[i] = NewContainer(
+	containers := make([]garden.Container, 0, len(res))
+	for _, containerdContainer := range res {
+		container := NewContainer(
 			containerdContainer,
 			b.killer,
 			b.rootfsManager,
+			b.network,
+			b.diskQuota,
+			b.logger,
 		)
+
+		// containers waiting in the warm pool are only known to the backend
+		if isWarmContainer(container.Handle()) {
+			continue
+		}
+
+		containers = append(containers, container)
 	}
 
 	return containers, nil
@@ -343,7 +565,7 @@ func (b *GardenBackend) Lookup(handle string) (garden.Container, error) {
 		return nil, ErrInvalidInput("empty handle")
 	}
 
-	containerdContainer, err := b.client.GetContainer(context.Background(), handle)
+	containerdContainer, err := b.getContainer(context.Background(), handle)
 	if err != nil {
 		return nil, fmt.Errorf("get container: %w", err)
 	}
@@ -352,9 +574,38 @@ func (b *GardenBackend) Lookup(handle string) (garden.Container, error) {
 		containerdContainer,
 		b.killer,
 		b.rootfsManager,
+		b.network,
+		b.diskQuota,
+		b.logger,
 	), nil
 }
 
+// getContainer gets the container with the specified handle, which may be a
+// container claimed from the warm pool under that handle.
+//
+func (b *GardenBackend) getContainer(ctx context.Context, handle string) (containerd.Container, error) {
+	container, err := b.client.GetContainer(ctx, handle)
+	if err == nil || b.warmPool == nil || !errdefs.IsNotFound(err) {
+		return container, err
+	}
+
+	filters, filterErr := propertiesToFilterList(garden.Properties{claimedHandleProperty: handle})
+	if filterErr != nil {
+		return nil, filterErr
+	}
+
+	claimed, listErr := b.client.Containers(ctx, filters...)
+	if listErr != nil {
+		return nil, fmt.Errorf("list claimed containers: %w", listErr)
+	}
+
+	if len(claimed) == 0 {
+		return nil, err
+	}
+
+	return claimed[0], nil
+}
+
 // GraceTime returns the value of the "garden.grace-time" property
 //
 func (b *GardenBackend) GraceTime(container garden.Container) (duration time.Duration) {
@@ -371,25 +622,88 @@ func (b *GardenBackend) GraceTime(container garden.Container) (duration time.Dur
 	return duration
 }
 
-// Capacity - Not Implemented
+// Capacity returns the total memory of the host, the size of the filesystem
+// holding container volumes (if configured), and the maximum number of
+// containers.
+//
+// Like memory, disk is reported as a total rather than what's free, as the
+// ATC subtracts what it has allocated to containers from it already.
 //
 func (b *GardenBackend) Capacity() (capacity garden.Capacity, err error) {
-	err = ErrNotImplemented
+	var info syscall.Sysinfo_t
+	err = syscall.Sysinfo(&info)
+	if err != nil {
+		err = fmt.Errorf("sysinfo: %w", err)
+		return
+	}
+
+	capacity.MemoryInBytes = uint64(info.Totalram) * uint64(info.Unit)
+	capacity.MaxContainers = uint64(b.maxContainers)
+
+	if b.diskCapacityPath != "" {
+		var stat syscall.Statfs_t
+		err = syscall.Statfs(b.diskCapacityPath, &stat)
+		if err != nil {
+			err = fmt.Errorf("statfs %s: %w", b.diskCapacityPath, err)
+			return
+		}
+
+		capacity.DiskInBytes = stat.Blocks * uint64(stat.Bsize)
+	}
+
 	return
 }
 
-// BulkInfo - Not Implemented
+// BulkInfo returns the info of each of the containers with the specified
+// handles. Failures are reported per container rather than failing the whole
+// request.
 //
-func (b *GardenBackend) BulkInfo(handles []string) (info map[string]garden.ContainerInfoEntry, err error) {
-	err = ErrNotImplemented
-	return
+func (b *GardenBackend) BulkInfo(handles []string) (map[string]garden.ContainerInfoEntry, error) {
+	info := make(map[string]garden.ContainerInfoEntry, len(handles))
+
+	for _, handle := range handles {
+		container, err := b.Lookup(handle)
+		if err != nil {
+			info[handle] = garden.ContainerInfoEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		containerInfo, err := container.Info()
+		if err != nil {
+			info[handle] = garden.ContainerInfoEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		info[handle] = garden.ContainerInfoEntry{Info: containerInfo}
+	}
+
+	return info, nil
 }
 
-// BulkMetrics - Not Implemented
+// BulkMetrics returns the metrics of each of the containers with the
+// specified handles. Failures are reported per container rather than failing
+// the whole request.
 //
-func (b *GardenBackend) BulkMetrics(handles []string) (metrics map[string]garden.ContainerMetricsEntry, err error) {
-	err = ErrNotImplemented
-	return
+func (b *GardenBackend) BulkMetrics(handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	metrics := make(map[string]garden.ContainerMetricsEntry, len(handles))
+
+	for _, handle := range handles {
+		container, err := b.Lookup(handle)
+		if err != nil {
+			metrics[handle] = garden.ContainerMetricsEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		containerMetrics, err := container.Metrics()
+		if err != nil {
+			metrics[handle] = garden.ContainerMetricsEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		metrics[handle] = garden.ContainerMetricsEntry{Metrics: containerMetrics}
+	}
+
+	return metrics, nil
 }
 
 // checkContainerCapacity ensures that Garden.MaxContainers is respected
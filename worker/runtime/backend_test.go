@@ -3,16 +3,23 @@ package runtime_test
 import (
 	"context"
 	"errors"
+	"net"
+	"os"
 	"sync"
+	"syscall"
 	"testing"
 	"time"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden/gardenfakes"
+	"github.com/concourse/concourse/atc"
+	atcruntime "github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
 	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
 	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/api/types"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/require"
@@ -132,6 +139,56 @@ func (s *BackendSuite) TestCreateContainerTaskStartFailure() {
 	s.EqualError(errors.Unwrap(err), "start-err")
 }
 
+func (s *BackendSuite) TestCreatePrivilegedContainerWhenRootless() {
+	s.userns.RootlessReturns(true, nil)
+	s.client.NewContainerReturns(nil, errors.New("err"))
+
+	spec := minimumValidGdnSpec
+	spec.Privileged = true
+
+	_, err := s.backend.Create(spec)
+	s.True(errors.Is(err, runtime.ErrPrivilegedRootless))
+	s.Equal(0, s.client.NewContainerCallCount())
+
+	spec.Privileged = false
+	_, _ = s.backend.Create(spec)
+	s.Equal(1, s.userns.RootlessCallCount())
+	s.Equal(1, s.client.NewContainerCallCount())
+}
+
+func (s *BackendSuite) TestCreateContainerWithSecurityConfig() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithSecurityAllowList(bespec.SecurityAllowList{
+			Capabilities: []string{"CAP_SYS_PTRACE"},
+		}),
+	)
+	s.NoError(err)
+
+	s.client.NewContainerReturns(nil, errors.New("err"))
+
+	spec := minimumValidGdnSpec
+	spec.Properties = garden.Properties{
+		atcruntime.SecurityPropertyName: `{"capabilities":{"add":["CAP_SYS_ADMIN"]}}`,
+	}
+
+	_, err = backend.Create(spec)
+	s.EqualError(errors.Unwrap(err), "security config: capability CAP_SYS_ADMIN is not allowed")
+	s.Equal(0, s.client.NewContainerCallCount())
+
+	spec.Properties = garden.Properties{
+		atcruntime.SecurityPropertyName: `{"capabilities":{"add":["CAP_SYS_PTRACE"]},"readonly_rootfs":true}`,
+	}
+
+	_, _ = backend.Create(spec)
+	s.Equal(1, s.client.NewContainerCallCount())
+
+	_, _, _, oci := s.client.NewContainerArgsForCall(0)
+	s.Contains(oci.Process.Capabilities.Bounding, "CAP_SYS_PTRACE")
+	s.True(oci.Root.Readonly)
+}
+
 func (s *BackendSuite) TestCreateContainerSetsHandle() {
 	fakeTask := new(libcontainerdfakes.FakeTask)
 	fakeContainer := new(libcontainerdfakes.FakeContainer)
@@ -146,6 +203,191 @@ func (s *BackendSuite) TestCreateContainerSetsHandle() {
 	s.Equal("handle", cont.Handle())
 }
 
+func (s *BackendSuite) TestCreateContainerAppliesNetOutRulesBeforeStarting() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.IDReturns("handle")
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	rules := []garden.NetOutRule{
+		{
+			Protocol: garden.ProtocolTCP,
+			Ports:    []garden.PortRange{garden.PortRangeFromPort(443)},
+		},
+	}
+
+	s.network.NetOutStub = func(string, []garden.NetOutRule) error {
+		s.Equal(0, fakeTask.StartCallCount())
+		return nil
+	}
+
+	spec := minimumValidGdnSpec
+	spec.NetOut = rules
+
+	_, err := s.backend.Create(spec)
+	s.NoError(err)
+
+	s.Equal(1, s.network.AddCallCount())
+	s.Equal(1, s.network.NetOutCallCount())
+	handle, netOutRules := s.network.NetOutArgsForCall(0)
+	s.Equal("handle", handle)
+	s.Equal(rules, netOutRules)
+	s.Equal(1, fakeTask.StartCallCount())
+}
+
+func (s *BackendSuite) TestCreateResolvesEgressHosts() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.IDReturns("handle")
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	cidrRule := garden.NetOutRule{
+		Protocol: garden.ProtocolTCP,
+		Ports:    []garden.PortRange{garden.PortRangeFromPort(443)},
+	}
+
+	spec := minimumValidGdnSpec
+	spec.NetOut = []garden.NetOutRule{cidrRule}
+	spec.Properties = garden.Properties{
+		atcruntime.EgressHostsPropertyName: `[{"host":"127.0.0.1","rule":{"protocol":1,"ports":[{"start":80,"end":80}]}}]`,
+	}
+
+	_, err := s.backend.Create(spec)
+	s.NoError(err)
+
+	s.Equal(1, s.network.NetOutCallCount())
+	_, netOutRules := s.network.NetOutArgsForCall(0)
+	s.Equal([]garden.NetOutRule{
+		cidrRule,
+		{
+			Protocol: garden.ProtocolTCP,
+			Networks: []garden.IPRange{garden.IPRangeFromIP(net.ParseIP("127.0.0.1"))},
+			Ports:    []garden.PortRange{garden.PortRangeFromPort(80)},
+		},
+	}, netOutRules)
+}
+
+func (s *BackendSuite) TestCreateWithInvalidEgressHosts() {
+	spec := minimumValidGdnSpec
+	spec.Properties = garden.Properties{
+		atcruntime.EgressHostsPropertyName: `not-json`,
+	}
+
+	_, err := s.backend.Create(spec)
+	s.Error(err)
+	s.Contains(err.Error(), "unmarshal egress host rules")
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
+func (s *BackendSuite) TestCreateWithDiskLimit() {
+	diskQuota := new(runtimefakes.FakeDiskQuota)
+	diskQuota.LimitReturns(65537, nil)
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithDiskQuota(diskQuota),
+	)
+	s.NoError(err)
+
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.NewTaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	spec := minimumValidGdnSpec
+	spec.Limits.Disk.ByteHard = 1024
+	spec.BindMounts = []garden.BindMount{
+		{SrcPath: "/volumes/output", DstPath: "/output", Mode: garden.BindMountModeRW},
+		{SrcPath: "/volumes/input", DstPath: "/input", Mode: garden.BindMountModeRO},
+	}
+
+	_, err = backend.Create(spec)
+	s.NoError(err)
+
+	s.Equal(1, diskQuota.LimitCallCount())
+	paths, bytes := diskQuota.LimitArgsForCall(0)
+	s.Equal([]string{"/rootfs", "/volumes/output"}, paths)
+	s.Equal(uint64(1024), bytes)
+
+	_, _, _, oci := s.client.NewContainerArgsForCall(0)
+	s.Equal("65537", oci.Annotations["concourse.disk-quota-project"])
+}
+
+func (s *BackendSuite) TestCreateWithDiskLimitReleasesQuotaOnFailure() {
+	diskQuota := new(runtimefakes.FakeDiskQuota)
+	diskQuota.LimitReturns(65537, nil)
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithDiskQuota(diskQuota),
+	)
+	s.NoError(err)
+
+	s.client.NewContainerReturns(nil, errors.New("new-container-err"))
+
+	spec := minimumValidGdnSpec
+	spec.Limits.Disk.ByteHard = 1024
+
+	_, err = backend.Create(spec)
+	s.Error(err)
+
+	s.Equal(1, diskQuota.ReleaseCallCount())
+	s.Equal(uint32(65537), diskQuota.ReleaseArgsForCall(0))
+}
+
+func (s *BackendSuite) TestCreateWithDiskLimitWithoutQuota() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.NewTaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	spec := minimumValidGdnSpec
+	spec.Limits.Disk.ByteHard = 1024
+
+	_, err := s.backend.Create(spec)
+	s.NoError(err)
+
+	_, _, _, oci := s.client.NewContainerArgsForCall(0)
+	s.NotContains(oci.Annotations, "concourse.disk-quota-project")
+}
+
+func (s *BackendSuite) TestCreateContainerNetOutFailure() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+	s.network.NetOutReturns(errors.New("net-out-err"))
+
+	_, err := s.backend.Create(minimumValidGdnSpec)
+	s.EqualError(errors.Unwrap(errors.Unwrap(err)), "net-out-err")
+	s.Equal(0, fakeTask.StartCallCount())
+}
+
+func (s *BackendSuite) TestCreateContainerWithoutNetwork() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	spec := minimumValidGdnSpec
+	spec.Network = atc.NetworkNone
+
+	_, err := s.backend.Create(spec)
+	s.NoError(err)
+
+	s.Equal(0, s.network.AddCallCount())
+	s.Equal(0, s.network.NetOutCallCount())
+	s.Equal(1, fakeTask.StartCallCount())
+}
+
 func (s *BackendSuite) TestCreateMaxContainersReached() {
 	backend, err := runtime.NewGardenBackend(s.client,
 		runtime.WithKiller(s.killer),
@@ -484,6 +726,31 @@ func (s *BackendSuite) TestDestroySucceeds() {
 	s.NoError(err)
 }
 
+func (s *BackendSuite) TestDestroyReleasesDiskQuota() {
+	diskQuota := new(runtimefakes.FakeDiskQuota)
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithKiller(s.killer),
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithDiskQuota(diskQuota),
+	)
+	s.NoError(err)
+
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.SpecReturns(&specs.Spec{
+		Annotations: map[string]string{"concourse.disk-quota-project": "65537"},
+	}, nil)
+	fakeContainer.TaskReturns(nil, errdefs.ErrNotFound)
+	s.client.GetContainerReturns(fakeContainer, nil)
+
+	err = backend.Destroy("some handle")
+	s.NoError(err)
+
+	s.Equal(1, fakeContainer.DeleteCallCount())
+	s.Equal(1, diskQuota.ReleaseCallCount())
+	s.Equal(uint32(65537), diskQuota.ReleaseArgsForCall(0))
+}
+
 func (s *BackendSuite) TestStartInitsClientAndSetsUpRestrictedNetworks() {
 	err := s.backend.Start()
 	s.NoError(err)
@@ -522,3 +789,80 @@ func (s *BackendSuite) TestGraceTimeReturnsDuration() {
 	result := s.backend.GraceTime(fakeContainer)
 	s.Equal(time.Duration(123), result)
 }
+
+func (s *BackendSuite) TestCapacity() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithMaxContainers(42),
+		runtime.WithDiskCapacityPath(os.TempDir()),
+	)
+	s.NoError(err)
+
+	capacity, err := backend.Capacity()
+	s.NoError(err)
+	s.NotZero(capacity.MemoryInBytes)
+	s.Equal(uint64(42), capacity.MaxContainers)
+
+	var stat syscall.Statfs_t
+	s.NoError(syscall.Statfs(os.TempDir(), &stat))
+	s.Equal(stat.Blocks*uint64(stat.Bsize), capacity.DiskInBytes)
+}
+
+func (s *BackendSuite) TestCapacityWithoutDiskCapacityPath() {
+	capacity, err := s.backend.Capacity()
+	s.NoError(err)
+	s.NotZero(capacity.MemoryInBytes)
+	s.Zero(capacity.DiskInBytes)
+}
+
+func (s *BackendSuite) TestCapacityWithMissingDiskCapacityPath() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithDiskCapacityPath("/does/not/exist"),
+	)
+	s.NoError(err)
+
+	_, err = backend.Capacity()
+	s.Error(err)
+}
+
+func (s *BackendSuite) TestBulkMetricsReportsErrorsPerContainer() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer.TaskReturns(fakeTask, nil)
+	fakeTask.MetricsReturns(&types.Metric{}, nil)
+
+	s.client.GetContainerStub = func(_ context.Context, handle string) (containerd.Container, error) {
+		if handle == "missing" {
+			return nil, errdefs.ErrNotFound
+		}
+
+		return fakeContainer, nil
+	}
+
+	metrics, err := s.backend.BulkMetrics([]string{"handle", "missing"})
+	s.NoError(err)
+	s.Len(metrics, 2)
+	s.Nil(metrics["handle"].Err)
+	s.NotNil(metrics["missing"].Err)
+}
+
+func (s *BackendSuite) TestBulkInfoReportsErrorsPerContainer() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.TaskReturns(nil, errdefs.ErrNotFound)
+
+	s.client.GetContainerStub = func(_ context.Context, handle string) (containerd.Container, error) {
+		if handle == "missing" {
+			return nil, errdefs.ErrNotFound
+		}
+
+		return fakeContainer, nil
+	}
+
+	info, err := s.backend.BulkInfo([]string{"handle", "missing"})
+	s.NoError(err)
+	s.Len(info, 2)
+	s.Nil(info["handle"].Err)
+	s.Equal("stopped", info["handle"].Info.State)
+	s.NotNil(info["missing"].Err)
+}
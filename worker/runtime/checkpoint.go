@@ -0,0 +1,310 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/atc"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/runtime/v2/runc/options"
+	"github.com/containerd/typeurl"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	// MigratableProperty marks a container whose process can be checkpointed
+	// and restored on another worker. Such containers are created without a
+	// task: the first process that gets run becomes their init process, as
+	// processes exec'd into a container can't be checkpointed.
+	//
+	MigratableProperty = "concourse:migratable"
+
+	// CheckpointProperty, when set on a migratable container, checkpoints
+	// its init process into the directory it's set to, leaving the process
+	// exited. It's only recorded once the checkpoint succeeded.
+	//
+	CheckpointProperty = "concourse:checkpoint"
+
+	// RestoreProperty is the directory holding the checkpoint that the init
+	// process of a migratable container is to be restored from, instead of
+	// being started.
+	//
+	RestoreProperty = "concourse:restore"
+
+	// initProcessProperty is set on migratable containers to the ID of the
+	// process that was run as their init process, so that it can be
+	// attached to.
+	//
+	initProcessProperty = "concourse:init-process"
+
+	// networkAnnotation and netOutAnnotation keep the networking of a
+	// migratable container around for when its task gets created.
+	//
+	networkAnnotation = "concourse:network"
+	netOutAnnotation  = "concourse:net-out"
+)
+
+// ErrCheckpointRestoreDisabled indicates that a migratable container was
+// requested from a backend that wasn't configured for checkpoint/restore.
+//
+var ErrCheckpointRestoreDisabled = errors.New("checkpoint/restore disabled")
+
+func isMigratable(properties garden.Properties) bool {
+	return properties[MigratableProperty] == "true"
+}
+
+// migratableContainer returns whether a containerd container was created as
+// migratable.
+//
+func migratableContainer(ctx context.Context, cont containerd.Container) (bool, error) {
+	labels, err := cont.Labels(ctx)
+	if err != nil {
+		return false, fmt.Errorf("labels retrieval: %w", err)
+	}
+
+	return isMigratable(labelsToProperties(labels)), nil
+}
+
+// migratableAnnotations records the networking of a migratable container in
+// the annotations of its spec.
+//
+func migratableAnnotations(oci *specs.Spec, gdnSpec garden.ContainerSpec) error {
+	netOut, err := json.Marshal(gdnSpec.NetOut)
+	if err != nil {
+		return fmt.Errorf("marshal net out rules: %w", err)
+	}
+
+	annotations := make(map[string]string, len(oci.Annotations)+2)
+	for k, v := range oci.Annotations {
+		annotations[k] = v
+	}
+
+	annotations[networkAnnotation] = gdnSpec.Network
+	annotations[netOutAnnotation] = string(netOut)
+
+	oci.Annotations = annotations
+
+	return nil
+}
+
+// addNetwork sets up the networking of a task that has yet to be started.
+//
+func addNetwork(ctx context.Context, network Network, task containerd.Task, id string, mode string, netOut []garden.NetOutRule) error {
+	// Containers without networking are left with only the loopback
+	// interface of their network namespace.
+	if mode == atc.NetworkNone {
+		return nil
+	}
+
+	err := network.Add(ctx, task, id)
+	if err != nil {
+		return fmt.Errorf("network add: %w", err)
+	}
+
+	// Egress rules must be in place before the task starts so that there's
+	// no window in which the container can reach arbitrary destinations.
+	err = network.NetOut(id, netOut)
+	if err != nil {
+		return fmt.Errorf("network net out: %w", err)
+	}
+
+	return nil
+}
+
+// runInit runs a process as the init process of a new task of a migratable
+// container, or restores the process it was checkpointed as if the
+// container was created from a checkpoint.
+//
+func (c *Container) runInit(
+	ctx context.Context,
+	id string,
+	containerSpec *specs.Spec,
+	procSpec specs.Process,
+	processIO garden.ProcessIO,
+	tty bool,
+) (garden.Process, error) {
+	properties, err := c.Properties()
+	if err != nil {
+		return nil, err
+	}
+
+	var netOut []garden.NetOutRule
+	err = json.Unmarshal([]byte(containerSpec.Annotations[netOutAnnotation]), &netOut)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal net out rules: %w", err)
+	}
+
+	err = c.container.Update(ctx, func(_ context.Context, _ *containerd.Client, cont *containers.Container) error {
+		containerSpec.Process = &procSpec
+
+		var err error
+		cont.Spec, err = typeurl.MarshalAny(containerSpec)
+		if err != nil {
+			return fmt.Errorf("marshal spec: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update container: %w", err)
+	}
+
+	opts := []containerd.NewTaskOpts{containerd.WithNoNewKeyring}
+	if restore := properties[RestoreProperty]; restore != "" {
+		opts = append(opts, containerd.WithRestoreImagePath(restore))
+	}
+
+	task, err := c.container.NewTask(ctx, cio.NewCreator(containerdCIO(processIO, tty)...), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("new task: %w", err)
+	}
+
+	err = addNetwork(ctx, c.network, task, c.container.ID(), containerSpec.Annotations[networkAnnotation], netOut)
+	if err != nil {
+		return nil, err
+	}
+
+	exitStatusC, err := task.Wait(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("task wait: %w", err)
+	}
+
+	err = task.Start(ctx)
+	if err != nil {
+		if isNoSuchExecutable(err) {
+			return nil, garden.ExecutableNotFoundError{Message: err.Error()}
+		}
+		return nil, fmt.Errorf("task start: %w", err)
+	}
+
+	err = c.SetProperty(initProcessProperty, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewProcess(task, exitStatusC, c.container, c.logger), nil
+}
+
+// attachInit attaches to the init process of a migratable container.
+//
+func (c *Container) attachInit(ctx context.Context, processIO garden.ProcessIO) (garden.Process, error) {
+	task, err := c.container.Task(ctx, cio.NewAttach(containerdCIO(processIO, false)...))
+	if err != nil {
+		return nil, fmt.Errorf("task: %w", err)
+	}
+
+	status, err := task.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("task status: %w", err)
+	}
+
+	if status.Status != containerd.Running {
+		return nil, fmt.Errorf("task not running: status = %s", status.Status)
+	}
+
+	exitStatusC, err := task.Wait(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("task wait: %w", err)
+	}
+
+	return NewProcess(task, exitStatusC, c.container, c.logger), nil
+}
+
+// checkpoint dumps the init process of a migratable container into a
+// directory, exiting it.
+//
+// Open TCP connections and processes exec'd into the container (e.g. the
+// sessions of `fly intercept`) can't be checkpointed, and fail it.
+//
+func (c *Container) checkpoint(ctx context.Context, path string) error {
+	migratable, err := migratableContainer(ctx, c.container)
+	if err != nil {
+		return err
+	}
+
+	if !migratable {
+		return ErrInvalidInput("container is not migratable")
+	}
+
+	task, err := c.container.Task(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("task lookup: %w", err)
+	}
+
+	_, err = task.Checkpoint(ctx, containerd.WithCheckpointImagePath(path), withCheckpointExit)
+	if err != nil {
+		return fmt.Errorf("checkpoint: %w", err)
+	}
+
+	// record the checkpoint so that it can be found again, e.g. after the
+	// ATC restarts
+	labels, err := propertiesToLabels(garden.Properties{CheckpointProperty: path})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.container.SetLabels(ctx, labels)
+	if err != nil {
+		return fmt.Errorf("set label: %w", err)
+	}
+
+	return nil
+}
+
+func withCheckpointExit(info *containerd.CheckpointTaskInfo) error {
+	opts, ok := info.Options.(*options.CheckpointOptions)
+	if !ok {
+		return fmt.Errorf("unexpected checkpoint options %T", info.Options)
+	}
+
+	opts.Exit = true
+	opts.Terminal = true
+
+	return nil
+}
+
+// killInit terminates the init process of a migratable container, which the
+// killer leaves alone. Graceful kills give it GracePeriod to exit after
+// GracefulSignal.
+//
+func killInit(ctx context.Context, task containerd.Task, behaviour KillBehaviour) error {
+	status, err := task.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("task status: %w", err)
+	}
+
+	if status.Status == containerd.Stopped {
+		return nil
+	}
+
+	if behaviour == KillGracefully {
+		exitStatusC, err := task.Wait(ctx)
+		if err != nil {
+			return fmt.Errorf("task wait: %w", err)
+		}
+
+		err = task.Kill(ctx, GracefulSignal)
+		if err != nil {
+			return fmt.Errorf("graceful kill: %w", err)
+		}
+
+		select {
+		case <-exitStatusC:
+			return nil
+		case <-time.After(GracePeriod):
+		}
+	}
+
+	err = task.Kill(ctx, UngracefulSignal)
+	if err != nil {
+		return fmt.Errorf("ungraceful kill: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,269 @@
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"syscall"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
+	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/runtime/v2/runc/options"
+	"github.com/containerd/typeurl"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CheckpointSuite struct {
+	suite.Suite
+	*require.Assertions
+
+	backend             runtime.GardenBackend
+	client              *libcontainerdfakes.FakeClient
+	network             *runtimefakes.FakeNetwork
+	killer              *runtimefakes.FakeKiller
+	rootfsManager       *runtimefakes.FakeRootfsManager
+	containerdContainer *libcontainerdfakes.FakeContainer
+	containerdTask      *libcontainerdfakes.FakeTask
+	container           *runtime.Container
+}
+
+func (s *CheckpointSuite) SetupTest() {
+	s.client = new(libcontainerdfakes.FakeClient)
+	s.network = new(runtimefakes.FakeNetwork)
+	s.killer = new(runtimefakes.FakeKiller)
+	s.rootfsManager = new(runtimefakes.FakeRootfsManager)
+	s.containerdContainer = new(libcontainerdfakes.FakeContainer)
+	s.containerdTask = new(libcontainerdfakes.FakeTask)
+
+	s.containerdContainer.IDReturns("handle")
+
+	var err error
+	s.backend, err = runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithKiller(s.killer),
+		runtime.WithUserNamespace(new(runtimefakes.FakeUserNamespace)),
+		runtime.WithCheckpointRestore(),
+	)
+	s.NoError(err)
+
+	s.container = runtime.NewContainer(
+		s.containerdContainer,
+		s.killer,
+		s.rootfsManager,
+		s.network,
+		nil,
+		lagertest.NewTestLogger("container"),
+	)
+}
+
+func (s *CheckpointSuite) labels(properties map[string]string) {
+	s.containerdContainer.LabelsStub = func(context.Context) (map[string]string, error) {
+		labels := map[string]string{}
+		for k, v := range properties {
+			labels[k+".0"] = v
+		}
+		return labels, nil
+	}
+}
+
+func (s *CheckpointSuite) TestCreateMigratableDoesNotStartTask() {
+	s.client.NewContainerReturns(s.containerdContainer, nil)
+
+	netOut := []garden.NetOutRule{{Protocol: garden.ProtocolTCP}}
+
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		NetOut:     netOut,
+		Properties: garden.Properties{runtime.MigratableProperty: "true"},
+	})
+	s.NoError(err)
+
+	s.Equal(0, s.containerdContainer.NewTaskCallCount())
+	s.Equal(0, s.network.AddCallCount())
+
+	_, _, labels, oci := s.client.NewContainerArgsForCall(0)
+	s.Equal("true", labels[runtime.MigratableProperty+".0"])
+	s.Equal(`[{"protocol":1}]`, oci.Annotations["concourse:net-out"])
+}
+
+func (s *CheckpointSuite) TestCreateMigratableWithoutCheckpointRestore() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(new(runtimefakes.FakeUserNamespace)),
+	)
+	s.NoError(err)
+
+	_, err = backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		Properties: garden.Properties{runtime.MigratableProperty: "true"},
+	})
+	s.True(errors.Is(err, runtime.ErrCheckpointRestoreDisabled))
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
+func (s *CheckpointSuite) migratableSpec() *containers.Container {
+	oci := &specs.Spec{
+		Process: &specs.Process{},
+		Root:    &specs.Root{Path: "/rootfs"},
+		Annotations: map[string]string{
+			"concourse:network": "",
+			"concourse:net-out": `[{"protocol":1}]`,
+		},
+	}
+
+	s.containerdContainer.SpecReturns(oci, nil)
+
+	spec, err := typeurl.MarshalAny(oci)
+	s.NoError(err)
+
+	updated := &containers.Container{Spec: spec}
+	s.containerdContainer.UpdateStub = func(ctx context.Context, opts ...containerd.UpdateContainerOpts) error {
+		for _, opt := range opts {
+			err := opt(ctx, nil, updated)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return updated
+}
+
+func (s *CheckpointSuite) TestRunStartsInitProcess() {
+	s.labels(map[string]string{runtime.MigratableProperty: "true"})
+	updated := s.migratableSpec()
+
+	s.containerdContainer.TaskReturns(nil, errdefs.ErrNotFound)
+	s.containerdContainer.NewTaskReturns(s.containerdTask, nil)
+
+	process, err := s.container.Run(garden.ProcessSpec{
+		ID:   "task",
+		Path: "/bin/build",
+	}, garden.ProcessIO{})
+	s.NoError(err)
+	s.Equal(0, s.containerdTask.ExecCallCount())
+
+	data, err := typeurl.UnmarshalAny(updated.Spec)
+	s.NoError(err)
+	s.Equal([]string{"/bin/build"}, data.(*specs.Spec).Process.Args)
+
+	_, _, opts := s.containerdContainer.NewTaskArgsForCall(0)
+	s.Len(opts, 1)
+
+	_, _, id := s.network.AddArgsForCall(0)
+	s.Equal("handle", id)
+	_, rules := s.network.NetOutArgsForCall(0)
+	s.Equal([]garden.NetOutRule{{Protocol: garden.ProtocolTCP}}, rules)
+
+	s.Equal(1, s.containerdTask.StartCallCount())
+
+	_, labels := s.containerdContainer.SetLabelsArgsForCall(0)
+	s.Equal(map[string]string{"concourse:init-process.0": "task"}, labels)
+
+	s.containerdTask.IDReturns("handle")
+	s.Equal("handle", process.ID())
+}
+
+func (s *CheckpointSuite) TestRunRestoresInitProcess() {
+	s.labels(map[string]string{
+		runtime.MigratableProperty: "true",
+		runtime.RestoreProperty:    "/checkpoint",
+	})
+	s.migratableSpec()
+
+	s.containerdContainer.TaskReturns(nil, errdefs.ErrNotFound)
+	s.containerdContainer.NewTaskReturns(s.containerdTask, nil)
+
+	_, err := s.container.Run(garden.ProcessSpec{ID: "task", Path: "/bin/build"}, garden.ProcessIO{})
+	s.NoError(err)
+
+	_, _, opts := s.containerdContainer.NewTaskArgsForCall(0)
+	s.Len(opts, 2)
+	s.Equal(1, s.containerdTask.StartCallCount())
+}
+
+func (s *CheckpointSuite) TestRunWithoutTaskInOtherContainers() {
+	s.migratableSpec()
+	s.containerdContainer.TaskReturns(nil, errdefs.ErrNotFound)
+
+	_, err := s.container.Run(garden.ProcessSpec{Path: "/bin/build"}, garden.ProcessIO{})
+	s.True(errors.Is(err, errdefs.ErrNotFound))
+	s.Equal(0, s.containerdContainer.NewTaskCallCount())
+}
+
+func (s *CheckpointSuite) TestAttachToInitProcess() {
+	s.labels(map[string]string{"concourse:init-process": "task"})
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.StatusReturns(containerd.Status{Status: containerd.Running}, nil)
+
+	_, err := s.container.Attach("task", garden.ProcessIO{})
+	s.NoError(err)
+
+	s.Equal(0, s.containerdTask.LoadProcessCallCount())
+	s.Equal(1, s.containerdTask.WaitCallCount())
+}
+
+func (s *CheckpointSuite) TestSetCheckpointPropertyCheckpoints() {
+	s.labels(map[string]string{runtime.MigratableProperty: "true"})
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+
+	err := s.container.SetProperty(runtime.CheckpointProperty, "/checkpoint")
+	s.NoError(err)
+
+	s.Equal(1, s.containerdTask.CheckpointCallCount())
+
+	_, labels := s.containerdContainer.SetLabelsArgsForCall(0)
+	s.Equal(map[string]string{runtime.CheckpointProperty + ".0": "/checkpoint"}, labels)
+
+	// the image path option depends on the runtime of the container, which
+	// is only known to containerd
+	_, opts := s.containerdTask.CheckpointArgsForCall(0)
+	s.Len(opts, 2)
+
+	info := &containerd.CheckpointTaskInfo{Options: &options.CheckpointOptions{}}
+	s.NoError(opts[1](info))
+	s.Equal(&options.CheckpointOptions{Exit: true, Terminal: true}, info.Options)
+}
+
+func (s *CheckpointSuite) TestSetCheckpointPropertyOnOtherContainers() {
+	err := s.container.SetProperty(runtime.CheckpointProperty, "/checkpoint")
+	s.Error(err)
+	s.Equal(0, s.containerdTask.CheckpointCallCount())
+}
+
+func (s *CheckpointSuite) TestStopKillsInitProcess() {
+	s.labels(map[string]string{runtime.MigratableProperty: "true"})
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.StatusReturns(containerd.Status{Status: containerd.Running}, nil)
+
+	err := s.container.Stop(true)
+	s.NoError(err)
+
+	s.Equal(0, s.killer.KillCallCount())
+	_, signal, _ := s.containerdTask.KillArgsForCall(0)
+	s.Equal(syscall.SIGKILL, signal)
+}
+
+func (s *CheckpointSuite) TestDestroyRemovesNetworkOfExitedInitProcess() {
+	s.labels(map[string]string{runtime.MigratableProperty: "true"})
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.StatusReturns(containerd.Status{Status: containerd.Stopped}, nil)
+	s.client.GetContainerReturns(s.containerdContainer, nil)
+
+	err := s.backend.Destroy("handle")
+	s.NoError(err)
+
+	s.Equal(0, s.containerdTask.KillCallCount())
+	s.Equal(1, s.network.RemoveCallCount())
+	s.Equal(1, s.containerdTask.DeleteCallCount())
+	s.Equal(1, s.containerdContainer.DeleteCallCount())
+}
@@ -2,10 +2,16 @@ package runtime
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net"
+	"os"
 	"path/filepath"
 	"strings"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime/iptables"
 	"github.com/containerd/containerd"
 	"github.com/containerd/go-cni"
@@ -28,10 +34,16 @@ type CNINetworkConfig struct {
 	NetworkName string
 
 	// Subnet is the subnet (in CIDR notation) which the veths should be
-	// added to.
+	// added to. It may be left empty when IPv6Subnet is set, making the
+	// network IPv6-only.
 	//
 	Subnet string
 
+	// IPv6Subnet is the IPv6 subnet (in CIDR notation) which the veths should
+	// also be added to, making the network dual-stack.
+	//
+	IPv6Subnet string
+
 	// MTU is the MTU of the bridge network interface.
 	//
 	MTU int
@@ -44,6 +56,13 @@ const (
 	networkMountsDir = "networkmounts"
 
 	ipTablesAdminChainName = "CONCOURSE-OPERATOR"
+
+	// ipTablesEgressChainName is the chain that dispatches traffic coming
+	// from containers with restricted egress to their own chains. Unlike the
+	// admin chain, it is not flushed when the worker starts so that the
+	// restrictions of existing containers survive restarts.
+	//
+	ipTablesEgressChainName = "CONCOURSE-EGRESS"
 )
 
 var (
@@ -63,6 +82,32 @@ func (c CNINetworkConfig) ToJSON() string {
 		mtu = fmt.Sprintf(`
       "mtu": %d,`, c.MTU)
 	}
+
+	// Each address family gets its own range so that containers on a
+	// dual-stack network get an address from both subnets.
+	//
+	var ranges, routes []string
+	for _, family := range []struct{ subnet, dst string }{
+		{c.Subnet, "0.0.0.0/0"},
+		{c.IPv6Subnet, "::/0"},
+	} {
+		if family.subnet == "" {
+			continue
+		}
+
+		ranges = append(ranges, fmt.Sprintf(`
+          [
+            {
+              "subnet": "%s"
+            }
+          ]`, family.subnet))
+
+		routes = append(routes, fmt.Sprintf(`
+          {
+            "dst": "%s"
+          }`, family.dst))
+	}
+
 	networksConfListFormat := `{
   "cniVersion": "0.4.0",
   "name": "%s",
@@ -75,11 +120,9 @@ func (c CNINetworkConfig) ToJSON() string {
 		mtu + `
       "ipam": {
         "type": "host-local",
-        "subnet": "%s",
-        "routes": [
-          {
-            "dst": "0.0.0.0/0"
-          }
+        "ranges": [` + strings.Join(ranges, ",") + `
+        ],
+        "routes": [` + strings.Join(routes, ",") + `
         ]
       }
     },
@@ -91,7 +134,7 @@ func (c CNINetworkConfig) ToJSON() string {
 }`
 
 	return fmt.Sprintf(networksConfListFormat,
-		c.NetworkName, c.BridgeName, c.Subnet, ipTablesAdminChainName,
+		c.NetworkName, c.BridgeName, ipTablesAdminChainName,
 	)
 }
 
@@ -179,6 +222,14 @@ func WithIptables(ipt iptables.Iptables) CNINetworkOpt {
 	}
 }
 
+// WithIp6tables allows for a custom implementation of the iptables.Iptables
+// interface to be provided for managing IPv6 rules.
+func WithIp6tables(ipt iptables.Iptables) CNINetworkOpt {
+	return func(n *cniNetwork) {
+		n.ip6t = ipt
+	}
+}
+
 // WithDefaultsForTesting testing damage
 //
 func WithDefaultsForTesting() CNINetworkOpt {
@@ -201,6 +252,7 @@ type cniNetwork struct {
 	restrictedNetworks []string
 	allowHostAccess    bool
 	ipt                iptables.Iptables
+	ip6t               iptables.Iptables
 }
 
 var _ Network = (*cniNetwork)(nil)
@@ -247,6 +299,14 @@ func NewCNINetwork(opts ...CNINetworkOpt) (*cniNetwork, error) {
 		}
 	}
 
+	if n.ip6t == nil && n.config.IPv6Subnet != "" {
+		n.ip6t, err = iptables.NewIPv6()
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize ip6tables: %w", err)
+		}
+	}
+
 	return n, nil
 }
 
@@ -271,7 +331,20 @@ func (n cniNetwork) SetupMounts(handle string) ([]specs.Mount, error) {
 		return nil, ErrInvalidInput("empty handle")
 	}
 
-	etcHosts, err := n.store.Create(
+	resolvContents, err := n.generateResolvConfContents()
+	if err != nil {
+		return nil, fmt.Errorf("generating resolv.conf: %w", err)
+	}
+
+	return setupNetworkMounts(n.store, handle, resolvContents)
+}
+
+// setupNetworkMounts creates the /etc/hosts, /etc/hostname and
+// /etc/resolv.conf files of a container, returning the mounts that bind them
+// into it.
+//
+func setupNetworkMounts(store FileStore, handle string, resolvContents []byte) ([]specs.Mount, error) {
+	etcHosts, err := store.Create(
 		filepath.Join(handle, "/hosts"),
 		[]byte("127.0.0.1 localhost\n"),
 	)
@@ -279,7 +352,7 @@ func (n cniNetwork) SetupMounts(handle string) ([]specs.Mount, error) {
 		return nil, fmt.Errorf("creating /etc/hosts: %w", err)
 	}
 
-	etcHostName, err := n.store.Create(
+	etcHostName, err := store.Create(
 		filepath.Join(handle, "/hostname"),
 		[]byte(handle+"\n"),
 	)
@@ -287,12 +360,7 @@ func (n cniNetwork) SetupMounts(handle string) ([]specs.Mount, error) {
 		return nil, fmt.Errorf("creating /etc/hostname: %w", err)
 	}
 
-	resolvContents, err := n.generateResolvConfContents()
-	if err != nil {
-		return nil, fmt.Errorf("generating resolv.conf: %w", err)
-	}
-
-	resolvConf, err := n.store.Create(
+	resolvConf, err := store.Create(
 		filepath.Join(handle, "/resolv.conf"),
 		resolvContents,
 	)
@@ -320,23 +388,95 @@ func (n cniNetwork) SetupMounts(handle string) ([]specs.Mount, error) {
 	}, nil
 }
 
+// renameNetworkHost rewrites the /etc/hosts and /etc/hostname files of a
+// container created by setupNetworkMounts to name the container's addresses
+// after the given host name rather than its handle. The files are rewritten
+// in place, as they're bind mounted into the container.
+//
+func renameNetworkHost(store FileStore, handle, hostname string) error {
+	hostsFile := filepath.Join(handle, "/hosts")
+
+	hosts, err := store.Read(hostsFile)
+	if err != nil {
+		return fmt.Errorf("reading /etc/hosts: %w", err)
+	}
+
+	lines := strings.Split(string(hosts), "\n")
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		for j, field := range fields {
+			if field == handle {
+				fields[j] = hostname
+				lines[i] = strings.Join(fields, " ")
+			}
+		}
+	}
+
+	_, err = store.Create(hostsFile, []byte(strings.Join(lines, "\n")))
+	if err != nil {
+		return fmt.Errorf("writing /etc/hosts: %w", err)
+	}
+
+	_, err = store.Create(filepath.Join(handle, "/hostname"), []byte(hostname+"\n"))
+	if err != nil {
+		return fmt.Errorf("writing /etc/hostname: %w", err)
+	}
+
+	return nil
+}
+
 const filterTable = "filter"
 
 func (n cniNetwork) setupRestrictedNetworks() error {
-	err := n.ipt.CreateChainOrFlushIfExists(filterTable, ipTablesAdminChainName)
+	for _, fw := range n.firewalls() {
+		err := n.setupAdminChain(fw)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setupAdminChain sets up the admin and egress chains of a firewall,
+// rejecting traffic to the restricted networks of its address family.
+//
+func (n cniNetwork) setupAdminChain(fw firewall) error {
+	err := fw.ipt.CreateChainOrFlushIfExists(filterTable, ipTablesAdminChainName)
 	if err != nil {
 		return fmt.Errorf("create chain or flush if exists failed: %w", err)
 	}
 
 	// Optimization that allows packets of ESTABLISHED and RELATED connections to go through without further rule matching
-	err = n.ipt.AppendRule(filterTable, ipTablesAdminChainName, "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "ACCEPT")
+	err = fw.ipt.AppendRule(filterTable, ipTablesAdminChainName, "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "ACCEPT")
 	if err != nil {
 		return fmt.Errorf("appending accept rule for RELATED & ESTABLISHED connections failed: %w", err)
 	}
 
+	exists, err := fw.ipt.ChainExists(filterTable, ipTablesEgressChainName)
+	if err != nil {
+		return fmt.Errorf("checking if egress chain exists failed: %w", err)
+	}
+
+	if !exists {
+		err = fw.ipt.CreateChainOrFlushIfExists(filterTable, ipTablesEgressChainName)
+		if err != nil {
+			return fmt.Errorf("create egress chain failed: %w", err)
+		}
+	}
+
+	err = fw.ipt.AppendRule(filterTable, ipTablesAdminChainName, "-j", ipTablesEgressChainName)
+	if err != nil {
+		return fmt.Errorf("appending jump rule to egress chain failed: %w", err)
+	}
+
 	for _, restrictedNetwork := range n.restrictedNetworks {
+		if isIPv6(restrictedNetwork) != fw.ipv6 {
+			continue
+		}
+
 		// Create REJECT rule in admin chain
-		err = n.ipt.AppendRule(filterTable, ipTablesAdminChainName, "-d", restrictedNetwork, "-j", "REJECT")
+		err = fw.ipt.AppendRule(filterTable, ipTablesAdminChainName, "-d", restrictedNetwork, "-j", "REJECT")
 		if err != nil {
 			return fmt.Errorf("appending reject rule for restricted network %s failed: %w", restrictedNetwork, err)
 		}
@@ -359,14 +499,21 @@ func (n cniNetwork) generateResolvConfContents() ([]byte, error) {
 }
 
 func (n cniNetwork) restrictHostAccess() error {
-	err := n.ipt.CreateChainOrFlushIfExists(filterTable, "INPUT")
-	if err != nil {
-		return fmt.Errorf("create chain or flush if exists failed: %w", err)
-	}
+	for _, fw := range n.firewalls() {
+		err := fw.ipt.CreateChainOrFlushIfExists(filterTable, "INPUT")
+		if err != nil {
+			return fmt.Errorf("create chain or flush if exists failed: %w", err)
+		}
 
-	err = n.ipt.AppendRule(filterTable, "INPUT", "-i", n.config.BridgeName, "-j", "REJECT", "--reject-with", "icmp-host-prohibited")
-	if err != nil {
-		return fmt.Errorf("error appending iptables rule: %w", err)
+		rejectWith := "icmp-host-prohibited"
+		if fw.ipv6 {
+			rejectWith = "icmp6-adm-prohibited"
+		}
+
+		err = fw.ipt.AppendRule(filterTable, "INPUT", "-i", n.config.BridgeName, "-j", "REJECT", "--reject-with", rejectWith)
+		if err != nil {
+			return fmt.Errorf("error appending iptables rule: %w", err)
+		}
 	}
 
 	return nil
@@ -391,11 +538,27 @@ func (n cniNetwork) Add(ctx context.Context, task containerd.Task, containerHand
 		return fmt.Errorf("cni net setup: no eth0 interface found")
 	}
 
+	// Containers on a dual-stack network get an address of each family
+	var ips []string
+	var hosts string
+	for _, ipConfig := range config.IPConfigs {
+		ip := ipConfig.IP.String()
+		ips = append(ips, ip)
+		hosts += ip + " " + containerHandle + "\n"
+	}
+
+	// Keep track of the container IPs so that egress rules can be applied
+	// (and cleaned up) after the network has been set up
+	_, err = n.store.Create(filepath.Join(containerHandle, "/ip"), []byte(strings.Join(ips, "\n")))
+	if err != nil {
+		return fmt.Errorf("storing container ip: %w", err)
+	}
+
 	// Update /etc/hosts on container
 	// This could not be done earlier because we only have the container IP after the network has been setup
 	return n.store.Append(
 		filepath.Join(containerHandle, "/hosts"),
-		[]byte(config.IPConfigs[0].IP.String()+" "+containerHandle+"\n"),
+		[]byte(hosts),
 	)
 }
 
@@ -407,6 +570,11 @@ func (n cniNetwork) Remove(ctx context.Context, task containerd.Task, handle str
 
 	id, netns := netId(task), netNsPath(task)
 
+	err = n.removeEgressRules(handle)
+	if err != nil {
+		return fmt.Errorf("egress rules teardown: %w", err)
+	}
+
 	err = n.store.Delete(handle)
 	if err != nil {
 		return fmt.Errorf("cni network mounts teardown: %w", err)
@@ -420,6 +588,245 @@ func (n cniNetwork) Remove(ctx context.Context, task containerd.Task, handle str
 	return nil
 }
 
+func (n cniNetwork) Rename(handle string, hostname string) error {
+	if handle == "" || hostname == "" {
+		return ErrInvalidInput("empty handle or hostname")
+	}
+
+	return renameNetworkHost(n.store, handle, hostname)
+}
+
+func (n cniNetwork) NetOut(handle string, rules []garden.NetOutRule) error {
+	if handle == "" {
+		return ErrInvalidInput("empty handle")
+	}
+
+	if len(rules) == 0 {
+		return nil
+	}
+
+	ips, err := n.containerIPs(handle)
+	if err != nil {
+		return err
+	}
+
+	if len(ips) == 0 {
+		return fmt.Errorf("container %s is not attached to a network", handle)
+	}
+
+	chain := egressChainName(handle)
+
+	for _, ip := range ips {
+		err = n.netOut(n.firewallFor(ip), ip, chain, rules)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// netOut applies the egress rules to the traffic from one of a container's
+// IPs, using the firewall for its address family.
+//
+func (n cniNetwork) netOut(fw firewall, ip, chain string, rules []garden.NetOutRule) error {
+	if fw.ipt == nil {
+		return fmt.Errorf("container ip %s is not managed by the network", ip)
+	}
+
+	exists, err := fw.ipt.ChainExists(filterTable, chain)
+	if err != nil {
+		return fmt.Errorf("checking if container egress chain exists: %w", err)
+	}
+
+	if !exists {
+		err = n.createEgressChain(fw, ip, chain)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Later rules take precedence over earlier ones, so every rule is
+	// inserted at the top of the chain. Permitted traffic RETURNs rather than
+	// being ACCEPTed so that the operator's restricted networks still apply.
+	//
+	for _, rule := range rules {
+		rulespecs, err := netOutRuleSpecs(rule, fw.ipv6)
+		if err != nil {
+			return err
+		}
+
+		for _, rulespec := range rulespecs {
+			err = fw.ipt.InsertRule(filterTable, chain, 1, append(rulespec, "-j", "RETURN")...)
+			if err != nil {
+				return fmt.Errorf("inserting egress rule: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// createEgressChain creates the chain holding the egress rules of the
+// container with the given IP, rejecting anything that the rules do not
+// permit apart from DNS queries to the configured nameservers.
+//
+func (n cniNetwork) createEgressChain(fw firewall, ip, chain string) error {
+	err := fw.ipt.CreateChainOrFlushIfExists(filterTable, chain)
+	if err != nil {
+		return fmt.Errorf("create container egress chain: %w", err)
+	}
+
+	nameServers, err := n.nameServerIPs()
+	if err != nil {
+		return fmt.Errorf("determining nameservers: %w", err)
+	}
+
+	for _, nameServer := range nameServers {
+		if isIPv6(nameServer) != fw.ipv6 {
+			continue
+		}
+
+		for _, protocol := range []string{"udp", "tcp"} {
+			err = fw.ipt.AppendRule(filterTable, chain, "-d", nameServer, "-p", protocol, "--dport", "53", "-j", "RETURN")
+			if err != nil {
+				return fmt.Errorf("appending dns rule for %s: %w", nameServer, err)
+			}
+		}
+	}
+
+	err = fw.ipt.AppendRule(filterTable, chain, "-j", "REJECT")
+	if err != nil {
+		return fmt.Errorf("appending reject rule: %w", err)
+	}
+
+	err = fw.ipt.AppendRule(filterTable, ipTablesEgressChainName, "-s", ip, "-j", chain)
+	if err != nil {
+		return fmt.Errorf("appending jump rule to container egress chain: %w", err)
+	}
+
+	return nil
+}
+
+func (n cniNetwork) removeEgressRules(handle string) error {
+	ips, err := n.containerIPs(handle)
+	if err != nil {
+		return err
+	}
+
+	chain := egressChainName(handle)
+
+	for _, ip := range ips {
+		fw := n.firewallFor(ip)
+		if fw.ipt == nil {
+			continue
+		}
+
+		err = fw.ipt.DeleteRuleIfExists(filterTable, ipTablesEgressChainName, "-s", ip, "-j", chain)
+		if err != nil {
+			return fmt.Errorf("deleting jump rule to container egress chain: %w", err)
+		}
+
+		err = fw.ipt.DeleteChainIfExists(filterTable, chain)
+		if err != nil {
+			return fmt.Errorf("deleting container egress chain: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// containerIPs retrieves the IPs recorded for a container when it was added
+// to the network, returning none for containers that were never added (i.e.,
+// those without networking).
+//
+func (n cniNetwork) containerIPs(handle string) ([]string, error) {
+	content, err := n.store.Read(filepath.Join(handle, "/ip"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("reading container ip: %w", err)
+	}
+
+	return strings.Fields(string(content)), nil
+}
+
+func (n cniNetwork) nameServerIPs() ([]string, error) {
+	entries := n.nameServers
+
+	if len(entries) == 0 {
+		var err error
+		entries, err = ParseHostResolveConf("/etc/resolv.conf")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var ips []string
+	for _, entry := range entries {
+		ip := strings.TrimSpace(strings.TrimPrefix(entry, "nameserver"))
+		if net.ParseIP(ip) != nil {
+			ips = append(ips, ip)
+		}
+	}
+
+	return ips, nil
+}
+
+// firewall pairs an Iptables with the address family whose rules it manages.
+//
+type firewall struct {
+	ipt  iptables.Iptables
+	ipv6 bool
+}
+
+// firewalls returns a firewall for each address family that the network hands
+// out addresses for.
+//
+func (n cniNetwork) firewalls() []firewall {
+	var fws []firewall
+	if n.config.Subnet != "" {
+		fws = append(fws, firewall{ipt: n.ipt})
+	}
+
+	if n.config.IPv6Subnet != "" {
+		fws = append(fws, firewall{ipt: n.ip6t, ipv6: true})
+	}
+
+	return fws
+}
+
+func (n cniNetwork) firewallFor(ip string) firewall {
+	if isIPv6(ip) {
+		return firewall{ipt: n.ip6t, ipv6: true}
+	}
+
+	return firewall{ipt: n.ipt}
+}
+
+// isIPv6 determines whether an address or network (in CIDR notation) is an
+// IPv6 one.
+//
+func isIPv6(addr string) bool {
+	if i := strings.Index(addr, "/"); i != -1 {
+		addr = addr[:i]
+	}
+
+	ip := net.ParseIP(addr)
+	return ip != nil && ip.To4() == nil
+}
+
+// egressChainName derives the name of the chain holding a container's egress
+// rules from its handle, keeping within the 28 characters that iptables allows
+// for chain names.
+//
+func egressChainName(handle string) string {
+	sum := sha256.Sum256([]byte(handle))
+	return "CONCOURSE-" + hex.EncodeToString(sum[:])[:16]
+}
+
 func netId(task containerd.Task) string {
 	return task.ID()
 }
@@ -2,11 +2,16 @@ package runtime_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net"
+	"os"
 	"reflect"
 	"strings"
+	"testing"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/containerd/go-cni"
 
 	"github.com/concourse/concourse/worker/runtime"
@@ -58,6 +63,70 @@ func (s *CNINetworkSuite) TestNewCNINetworkWithInvalidConfigDoesntFail() {
 	s.NoError(err)
 }
 
+func (s *CNINetworkSuite) TestCNINetworkConfigRanges() {
+	type ipam struct {
+		Ranges [][]struct {
+			Subnet string `json:"subnet"`
+		} `json:"ranges"`
+		Routes []struct {
+			Dst string `json:"dst"`
+		} `json:"routes"`
+	}
+
+	for _, tc := range []struct {
+		desc    string
+		config  runtime.CNINetworkConfig
+		subnets []string
+		routes  []string
+	}{
+		{
+			desc:    "ipv4",
+			config:  runtime.DefaultCNINetworkConfig,
+			subnets: []string{"10.80.0.0/16"},
+			routes:  []string{"0.0.0.0/0"},
+		},
+		{
+			desc: "dual-stack",
+			config: runtime.CNINetworkConfig{
+				Subnet:     "10.80.0.0/16",
+				IPv6Subnet: "fd80::/64",
+			},
+			subnets: []string{"10.80.0.0/16", "fd80::/64"},
+			routes:  []string{"0.0.0.0/0", "::/0"},
+		},
+		{
+			desc: "ipv6-only",
+			config: runtime.CNINetworkConfig{
+				IPv6Subnet: "fd80::/64",
+			},
+			subnets: []string{"fd80::/64"},
+			routes:  []string{"::/0"},
+		},
+	} {
+		s.T().Run(tc.desc, func(t *testing.T) {
+			var conf struct {
+				Plugins []struct {
+					IPAM ipam `json:"ipam"`
+				} `json:"plugins"`
+			}
+
+			err := json.Unmarshal([]byte(tc.config.ToJSON()), &conf)
+			s.NoError(err)
+
+			var subnets, routes []string
+			for _, r := range conf.Plugins[0].IPAM.Ranges {
+				subnets = append(subnets, r[0].Subnet)
+			}
+			for _, r := range conf.Plugins[0].IPAM.Routes {
+				routes = append(routes, r.Dst)
+			}
+
+			s.Equal(tc.subnets, subnets)
+			s.Equal(tc.routes, routes)
+		})
+	}
+}
+
 func (s *CNINetworkSuite) TestSetupMountsEmptyHandle() {
 	_, err := s.network.SetupMounts("")
 	s.EqualError(err, "empty handle")
@@ -218,6 +287,27 @@ func (s *CNINetworkSuite) TestSetupHostNetwork() {
 			expectedChainName: "CONCOURSE-OPERATOR",
 			expectedRuleSpec:  []string{"-d", "8.8.8.8", "-j", "REJECT"},
 		},
+		"creates the CONCOURSE-EGRESS chain": {
+			cniNetworkSetup: func() (runtime.Network, error) {
+				return runtime.NewCNINetwork(
+					runtime.WithDefaultsForTesting(),
+					runtime.WithIptables(s.iptables),
+				)
+			},
+			expectedTableName: "filter",
+			expectedChainName: "CONCOURSE-EGRESS",
+		},
+		"adds rule to CONCOURSE-OPERATOR chain to jump to the CONCOURSE-EGRESS chain": {
+			cniNetworkSetup: func() (runtime.Network, error) {
+				return runtime.NewCNINetwork(
+					runtime.WithDefaultsForTesting(),
+					runtime.WithIptables(s.iptables),
+				)
+			},
+			expectedTableName: "filter",
+			expectedChainName: "CONCOURSE-OPERATOR",
+			expectedRuleSpec:  []string{"-j", "CONCOURSE-EGRESS"},
+		},
 		"flushes the INPUT chain": {
 			cniNetworkSetup: func() (runtime.Network, error) {
 				return runtime.NewCNINetwork(
@@ -327,6 +417,11 @@ func (s *CNINetworkSuite) TestAdd() {
 	_, id, netns, _ := s.cni.SetupArgsForCall(0)
 	s.Equal("id", id)
 	s.Equal("/proc/123/ns/net", netns)
+
+	s.Equal(1, s.store.CreateCallCount())
+	name, content := s.store.CreateArgsForCall(0)
+	s.Equal("container-handle/ip", name)
+	s.Equal("10.8.0.1", string(content))
 }
 
 func (s *CNINetworkSuite) TestRemoveNilTask() {
@@ -359,3 +454,365 @@ func (s *CNINetworkSuite) TestRemove() {
 	path := s.store.DeleteArgsForCall(0)
 	s.Equal("some-handle", path)
 }
+
+func (s *CNINetworkSuite) TestRenameEmptyHostname() {
+	err := s.network.Rename("some-handle", "")
+	s.EqualError(err, "empty handle or hostname")
+}
+
+func (s *CNINetworkSuite) TestRename() {
+	s.store.ReadReturns([]byte("127.0.0.1 localhost\n10.80.0.2 some-handle\n"), nil)
+
+	err := s.network.Rename("some-handle", "claimed-handle")
+	s.NoError(err)
+
+	s.Equal("some-handle/hosts", s.store.ReadArgsForCall(0))
+
+	s.Equal(2, s.store.CreateCallCount())
+	name, content := s.store.CreateArgsForCall(0)
+	s.Equal("some-handle/hosts", name)
+	s.Equal("127.0.0.1 localhost\n10.80.0.2 claimed-handle\n", string(content))
+
+	name, content = s.store.CreateArgsForCall(1)
+	s.Equal("some-handle/hostname", name)
+	s.Equal("claimed-handle\n", string(content))
+}
+
+func (s *CNINetworkSuite) TestRenameReadError() {
+	s.store.ReadReturns(nil, errors.New("read-err"))
+
+	err := s.network.Rename("some-handle", "claimed-handle")
+	s.EqualError(errors.Unwrap(err), "read-err")
+	s.Equal(0, s.store.CreateCallCount())
+}
+
+func (s *CNINetworkSuite) TestSetupHostNetworkKeepsExistingEgressChain() {
+	s.iptables.ChainExistsReturns(true, nil)
+
+	err := s.network.SetupHostNetwork()
+	s.NoError(err)
+
+	for i := 0; i < s.iptables.CreateChainOrFlushIfExistsCallCount(); i++ {
+		_, chain := s.iptables.CreateChainOrFlushIfExistsArgsForCall(i)
+		s.NotEqual("CONCOURSE-EGRESS", chain)
+	}
+}
+
+func (s *CNINetworkSuite) TestRemoveDeletesEgressRules() {
+	task := new(libcontainerdfakes.FakeTask)
+	s.store.ReadReturns([]byte("10.80.0.2"), nil)
+
+	err := s.network.Remove(context.Background(), task, "some-handle")
+	s.NoError(err)
+
+	s.Equal("some-handle/ip", s.store.ReadArgsForCall(0))
+
+	s.Equal(1, s.iptables.DeleteRuleIfExistsCallCount())
+	table, chain, rulespec := s.iptables.DeleteRuleIfExistsArgsForCall(0)
+	s.Equal("filter", table)
+	s.Equal("CONCOURSE-EGRESS", chain)
+	s.Equal("-s", rulespec[0])
+	s.Equal("10.80.0.2", rulespec[1])
+
+	s.Equal(1, s.iptables.DeleteChainIfExistsCallCount())
+	_, egressChain := s.iptables.DeleteChainIfExistsArgsForCall(0)
+	s.Equal(rulespec[3], egressChain)
+}
+
+func (s *CNINetworkSuite) TestRemoveWithoutNetworkSkipsEgressRules() {
+	task := new(libcontainerdfakes.FakeTask)
+	s.store.ReadReturns(nil, fmt.Errorf("read file: %w", os.ErrNotExist))
+
+	err := s.network.Remove(context.Background(), task, "some-handle")
+	s.NoError(err)
+
+	s.Equal(0, s.iptables.DeleteRuleIfExistsCallCount())
+	s.Equal(0, s.iptables.DeleteChainIfExistsCallCount())
+}
+
+func (s *CNINetworkSuite) TestNetOutWithoutRules() {
+	err := s.network.NetOut("some-handle", nil)
+	s.NoError(err)
+
+	s.Equal(0, s.store.ReadCallCount())
+	s.Equal(0, s.iptables.InsertRuleCallCount())
+}
+
+func (s *CNINetworkSuite) TestNetOutWithoutNetwork() {
+	s.store.ReadReturns(nil, fmt.Errorf("read file: %w", os.ErrNotExist))
+
+	err := s.network.NetOut("some-handle", []garden.NetOutRule{{}})
+	s.EqualError(err, "container some-handle is not attached to a network")
+}
+
+func (s *CNINetworkSuite) TestNetOutCreatesEgressChain() {
+	network, err := runtime.NewCNINetwork(
+		runtime.WithDefaultsForTesting(),
+		runtime.WithCNIFileStore(s.store),
+		runtime.WithCNIClient(s.cni),
+		runtime.WithIptables(s.iptables),
+		runtime.WithNameServers([]string{"8.8.8.8"}),
+	)
+	s.NoError(err)
+
+	s.store.ReadReturns([]byte("10.80.0.2"), nil)
+
+	err = network.NetOut("some-handle", []garden.NetOutRule{{}})
+	s.NoError(err)
+
+	s.Equal(1, s.iptables.CreateChainOrFlushIfExistsCallCount())
+	_, chain := s.iptables.CreateChainOrFlushIfExistsArgsForCall(0)
+	s.True(strings.HasPrefix(chain, "CONCOURSE-"))
+	s.LessOrEqual(len(chain), 28)
+
+	var appended [][]string
+	for i := 0; i < s.iptables.AppendRuleCallCount(); i++ {
+		_, appendChain, rulespec := s.iptables.AppendRuleArgsForCall(i)
+		appended = append(appended, append([]string{appendChain}, rulespec...))
+	}
+
+	s.Equal([][]string{
+		{chain, "-d", "8.8.8.8", "-p", "udp", "--dport", "53", "-j", "RETURN"},
+		{chain, "-d", "8.8.8.8", "-p", "tcp", "--dport", "53", "-j", "RETURN"},
+		{chain, "-j", "REJECT"},
+		{"CONCOURSE-EGRESS", "-s", "10.80.0.2", "-j", chain},
+	}, appended)
+}
+
+func (s *CNINetworkSuite) TestNetOutReusesExistingEgressChain() {
+	s.store.ReadReturns([]byte("10.80.0.2"), nil)
+	s.iptables.ChainExistsReturns(true, nil)
+
+	err := s.network.NetOut("some-handle", []garden.NetOutRule{{}})
+	s.NoError(err)
+
+	s.Equal(0, s.iptables.CreateChainOrFlushIfExistsCallCount())
+	s.Equal(0, s.iptables.AppendRuleCallCount())
+	s.Equal(1, s.iptables.InsertRuleCallCount())
+}
+
+func (s *CNINetworkSuite) TestNetOutRuleSpecs() {
+	for _, tc := range []struct {
+		desc     string
+		rule     garden.NetOutRule
+		expected [][]string
+	}{
+		{
+			desc:     "all traffic",
+			rule:     garden.NetOutRule{},
+			expected: [][]string{{"-p", "all"}},
+		},
+		{
+			desc: "single ip and port",
+			rule: garden.NetOutRule{
+				Protocol: garden.ProtocolTCP,
+				Networks: []garden.IPRange{garden.IPRangeFromIP(net.ParseIP("1.2.3.4"))},
+				Ports:    []garden.PortRange{garden.PortRangeFromPort(443)},
+			},
+			expected: [][]string{{"-d", "1.2.3.4", "-p", "tcp", "--dport", "443"}},
+		},
+		{
+			desc: "ip range and port range for any protocol",
+			rule: garden.NetOutRule{
+				Networks: []garden.IPRange{{Start: net.ParseIP("10.0.0.1"), End: net.ParseIP("10.0.0.9")}},
+				Ports:    []garden.PortRange{{Start: 8000, End: 8080}},
+			},
+			expected: [][]string{
+				{"-m", "iprange", "--dst-range", "10.0.0.1-10.0.0.9", "-p", "tcp", "--dport", "8000:8080"},
+				{"-m", "iprange", "--dst-range", "10.0.0.1-10.0.0.9", "-p", "udp", "--dport", "8000:8080"},
+			},
+		},
+		{
+			desc: "ipv6 networks only",
+			rule: garden.NetOutRule{
+				Networks: []garden.IPRange{garden.IPRangeFromIP(net.ParseIP("::1"))},
+			},
+			expected: nil,
+		},
+		{
+			desc: "icmp type and code",
+			rule: garden.NetOutRule{
+				Protocol: garden.ProtocolICMP,
+				ICMPs:    &garden.ICMPControl{Type: 8, Code: garden.ICMPControlCode(0)},
+			},
+			expected: [][]string{{"-p", "icmp", "--icmp-type", "8/0"}},
+		},
+	} {
+		s.T().Run(tc.desc, func(t *testing.T) {
+			s.SetupTest()
+			s.store.ReadReturns([]byte("10.80.0.2"), nil)
+			s.iptables.ChainExistsReturns(true, nil)
+
+			err := s.network.NetOut("some-handle", []garden.NetOutRule{tc.rule})
+			s.NoError(err)
+
+			var inserted [][]string
+			for i := 0; i < s.iptables.InsertRuleCallCount(); i++ {
+				_, _, pos, rulespec := s.iptables.InsertRuleArgsForCall(i)
+				s.Equal(1, pos)
+				s.Equal([]string{"-j", "RETURN"}, rulespec[len(rulespec)-2:])
+				inserted = append(inserted, rulespec[:len(rulespec)-2])
+			}
+
+			s.Equal(tc.expected, inserted)
+		})
+	}
+}
+
+func (s *CNINetworkSuite) TestNetOutInvalidPortRange() {
+	s.store.ReadReturns([]byte("10.80.0.2"), nil)
+	s.iptables.ChainExistsReturns(true, nil)
+
+	err := s.network.NetOut("some-handle", []garden.NetOutRule{{
+		Protocol: garden.ProtocolTCP,
+		Ports:    []garden.PortRange{{Start: 90, End: 80}},
+	}})
+	s.EqualError(err, "invalid port range 90-80")
+}
+
+func (s *CNINetworkSuite) dualStackNetwork(ip6tables *iptablesfakes.FakeIptables, opts ...runtime.CNINetworkOpt) runtime.Network {
+	network, err := runtime.NewCNINetwork(append([]runtime.CNINetworkOpt{
+		runtime.WithDefaultsForTesting(),
+		runtime.WithCNIFileStore(s.store),
+		runtime.WithCNIClient(s.cni),
+		runtime.WithCNINetworkConfig(runtime.CNINetworkConfig{
+			BridgeName:  "concourse0",
+			NetworkName: "concourse",
+			Subnet:      "10.80.0.0/16",
+			IPv6Subnet:  "fd80::/64",
+		}),
+		runtime.WithIptables(s.iptables),
+		runtime.WithIp6tables(ip6tables),
+	}, opts...)...)
+	s.NoError(err)
+
+	return network
+}
+
+func appendedRules(ipt *iptablesfakes.FakeIptables) [][]string {
+	var appended [][]string
+	for i := 0; i < ipt.AppendRuleCallCount(); i++ {
+		_, chain, rulespec := ipt.AppendRuleArgsForCall(i)
+		appended = append(appended, append([]string{chain}, rulespec...))
+	}
+
+	return appended
+}
+
+func (s *CNINetworkSuite) TestSetupHostNetworkDualStack() {
+	ip6tables := new(iptablesfakes.FakeIptables)
+	network := s.dualStackNetwork(ip6tables,
+		runtime.WithRestrictedNetworks([]string{"1.1.1.1", "fd00::/8"}),
+	)
+
+	err := network.SetupHostNetwork()
+	s.NoError(err)
+
+	s.Equal([][]string{
+		{"CONCOURSE-OPERATOR", "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "ACCEPT"},
+		{"CONCOURSE-OPERATOR", "-j", "CONCOURSE-EGRESS"},
+		{"CONCOURSE-OPERATOR", "-d", "1.1.1.1", "-j", "REJECT"},
+		{"INPUT", "-i", "concourse0", "-j", "REJECT", "--reject-with", "icmp-host-prohibited"},
+	}, appendedRules(s.iptables))
+
+	s.Equal([][]string{
+		{"CONCOURSE-OPERATOR", "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "ACCEPT"},
+		{"CONCOURSE-OPERATOR", "-j", "CONCOURSE-EGRESS"},
+		{"CONCOURSE-OPERATOR", "-d", "fd00::/8", "-j", "REJECT"},
+		{"INPUT", "-i", "concourse0", "-j", "REJECT", "--reject-with", "icmp6-adm-prohibited"},
+	}, appendedRules(ip6tables))
+}
+
+func (s *CNINetworkSuite) TestSetupHostNetworkIPv6Only() {
+	ip6tables := new(iptablesfakes.FakeIptables)
+	network, err := runtime.NewCNINetwork(
+		runtime.WithDefaultsForTesting(),
+		runtime.WithCNINetworkConfig(runtime.CNINetworkConfig{
+			BridgeName: "concourse0",
+			IPv6Subnet: "fd80::/64",
+		}),
+		runtime.WithIptables(s.iptables),
+		runtime.WithIp6tables(ip6tables),
+	)
+	s.NoError(err)
+
+	err = network.SetupHostNetwork()
+	s.NoError(err)
+
+	s.Equal(0, s.iptables.AppendRuleCallCount())
+	s.NotZero(ip6tables.AppendRuleCallCount())
+}
+
+func (s *CNINetworkSuite) TestAddDualStack() {
+	task := new(libcontainerdfakes.FakeTask)
+
+	result := &cni.Result{
+		Interfaces: map[string]*cni.Config{
+			"eth0": {
+				IPConfigs: []*cni.IPConfig{
+					{IP: net.ParseIP("10.80.0.2")},
+					{IP: net.ParseIP("fd80::2")},
+				},
+			},
+		},
+	}
+	s.cni.SetupReturns(result, nil)
+
+	err := s.dualStackNetwork(new(iptablesfakes.FakeIptables)).Add(context.Background(), task, "container-handle")
+	s.NoError(err)
+
+	_, content := s.store.CreateArgsForCall(0)
+	s.Equal("10.80.0.2\nfd80::2", string(content))
+
+	_, hosts := s.store.AppendArgsForCall(0)
+	s.Equal("10.80.0.2 container-handle\nfd80::2 container-handle\n", string(hosts))
+}
+
+func (s *CNINetworkSuite) TestNetOutDualStack() {
+	ip6tables := new(iptablesfakes.FakeIptables)
+	network := s.dualStackNetwork(ip6tables,
+		runtime.WithNameServers([]string{"8.8.8.8", "2001:4860:4860::8888"}),
+	)
+
+	s.store.ReadReturns([]byte("10.80.0.2\nfd80::2"), nil)
+
+	err := network.NetOut("some-handle", []garden.NetOutRule{{
+		Protocol: garden.ProtocolICMP,
+		Networks: []garden.IPRange{
+			garden.IPRangeFromIP(net.ParseIP("1.2.3.4")),
+			garden.IPRangeFromIP(net.ParseIP("2001:db8::1")),
+		},
+	}})
+	s.NoError(err)
+
+	_, chain := ip6tables.CreateChainOrFlushIfExistsArgsForCall(0)
+
+	s.Equal([][]string{
+		{chain, "-d", "2001:4860:4860::8888", "-p", "udp", "--dport", "53", "-j", "RETURN"},
+		{chain, "-d", "2001:4860:4860::8888", "-p", "tcp", "--dport", "53", "-j", "RETURN"},
+		{chain, "-j", "REJECT"},
+		{"CONCOURSE-EGRESS", "-s", "fd80::2", "-j", chain},
+	}, appendedRules(ip6tables))
+
+	s.Equal(1, s.iptables.InsertRuleCallCount())
+	_, _, _, rulespec := s.iptables.InsertRuleArgsForCall(0)
+	s.Equal([]string{"-d", "1.2.3.4", "-p", "icmp", "-j", "RETURN"}, rulespec)
+
+	s.Equal(1, ip6tables.InsertRuleCallCount())
+	_, _, _, rulespec = ip6tables.InsertRuleArgsForCall(0)
+	s.Equal([]string{"-d", "2001:db8::1", "-p", "icmpv6", "-j", "RETURN"}, rulespec)
+}
+
+func (s *CNINetworkSuite) TestRemoveDualStackDeletesEgressRules() {
+	ip6tables := new(iptablesfakes.FakeIptables)
+	s.store.ReadReturns([]byte("10.80.0.2\nfd80::2"), nil)
+
+	err := s.dualStackNetwork(ip6tables).Remove(context.Background(), new(libcontainerdfakes.FakeTask), "some-handle")
+	s.NoError(err)
+
+	s.Equal(1, s.iptables.DeleteRuleIfExistsCallCount())
+	s.Equal(1, ip6tables.DeleteRuleIfExistsCallCount())
+	_, _, rulespec := ip6tables.DeleteRuleIfExistsArgsForCall(0)
+	s.Equal("fd80::2", rulespec[1])
+	s.Equal(1, ip6tables.DeleteChainIfExistsCallCount())
+}
@@ -5,11 +5,16 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"strconv"
 	"time"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	atcruntime "github.com/concourse/concourse/atc/runtime"
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/errdefs"
 	uuid "github.com/nu7hatch/gouuid"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -33,24 +38,49 @@ type Container struct {
 	container     containerd.Container
 	killer        Killer
 	rootfsManager RootfsManager
+	network       Network
+	diskQuota     DiskQuota
+	logger        lager.Logger
 }
 
+// NewContainer wraps a containerd container. The disk quota may be nil if
+// the disk limits of containers aren't enforced.
+//
 func NewContainer(
 	container containerd.Container,
 	killer Killer,
 	rootfsManager RootfsManager,
+	network Network,
+	diskQuota DiskQuota,
+	logger lager.Logger,
 ) *Container {
 	return &Container{
 		container:     container,
 		killer:        killer,
 		rootfsManager: rootfsManager,
+		network:       network,
+		diskQuota:     diskQuota,
+		logger:        logger,
 	}
 }
 
 var _ garden.Container = (*Container)(nil)
 
+// Handle returns the handle of the container, which is the one it was
+// claimed as for containers of the warm pool.
+//
 func (c *Container) Handle() string {
-	return c.container.ID()
+	id := c.container.ID()
+	if !isWarmContainer(id) {
+		return id
+	}
+
+	handle, err := c.Property(claimedHandleProperty)
+	if err != nil {
+		return id
+	}
+
+	return handle
 }
 
 // Stop stops a container.
@@ -68,7 +98,16 @@ func (c *Container) Stop(kill bool) error {
 		behaviour = KillUngracefully
 	}
 
-	err = c.killer.Kill(ctx, task, behaviour)
+	migratable, err := migratableContainer(ctx, c.container)
+	if err != nil {
+		return err
+	}
+
+	if migratable {
+		err = killInit(ctx, task, behaviour)
+	} else {
+		err = c.killer.Kill(ctx, task, behaviour)
+	}
 	if err != nil {
 		return fmt.Errorf("kill: %w", err)
 	}
@@ -78,6 +117,9 @@ func (c *Container) Stop(kill bool) error {
 
 // Run a process inside the container.
 //
+// The first process run in a migratable container becomes its init process,
+// unless the container is restored from a checkpoint.
+//
 func (c *Container) Run(
 	spec garden.ProcessSpec,
 	processIO garden.ProcessIO,
@@ -99,12 +141,26 @@ func (c *Container) Run(
 		return nil, fmt.Errorf("setup cwd: %w", err)
 	}
 
+	id := procID(spec)
+
 	task, err := c.container.Task(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("task retrieval: %w", err)
+		if !errdefs.IsNotFound(err) {
+			return nil, fmt.Errorf("task retrieval: %w", err)
+		}
+
+		migratable, merr := migratableContainer(ctx, c.container)
+		if merr != nil {
+			return nil, merr
+		}
+
+		if !migratable {
+			return nil, fmt.Errorf("task retrieval: %w", err)
+		}
+
+		return c.runInit(ctx, id, containerSpec, procSpec, processIO, spec.TTY != nil)
 	}
 
-	id := procID(spec)
 	cioOpts := containerdCIO(processIO, spec.TTY != nil)
 
 	proc, err := task.Exec(ctx, id, &procSpec, cio.NewCreator(cioOpts...))
@@ -146,7 +202,7 @@ func (c *Container) Run(
 		}
 	}
 
-	return NewProcess(proc, exitStatusC), nil
+	return NewProcess(proc, exitStatusC, c.container, c.logger), nil
 }
 
 // Attach starts streaming the output back to the client from a specified process.
@@ -158,6 +214,10 @@ func (c *Container) Attach(pid string, processIO garden.ProcessIO) (process gard
 		return nil, ErrInvalidInput("empty pid")
 	}
 
+	if init, err := c.Property(initProcessProperty); err == nil && init == pid {
+		return c.attachInit(ctx, processIO)
+	}
+
 	task, err := c.container.Task(ctx, cio.Load)
 	if err != nil {
 		return nil, fmt.Errorf("task: %w", err)
@@ -184,7 +244,7 @@ func (c *Container) Attach(pid string, processIO garden.ProcessIO) (process gard
 		return nil, fmt.Errorf("proc wait: %w", err)
 	}
 
-	return NewProcess(proc, exitStatusC), nil
+	return NewProcess(proc, exitStatusC, c.container, c.logger), nil
 }
 
 // Properties returns the current set of properties
@@ -202,7 +262,13 @@ func (c *Container) Properties() (garden.Properties, error) {
 
 // Property returns the value of the property with the specified name.
 //
+// The block IO of the container is computed on request rather than stored.
+//
 func (c *Container) Property(name string) (string, error) {
+	if name == atcruntime.BlockIOPropertyName {
+		return c.blockIO()
+	}
+
 	properties, err := c.Properties()
 	if err != nil {
 		return "", err
@@ -218,7 +284,14 @@ func (c *Container) Property(name string) (string, error) {
 
 // Set a named property on a container to a specified value.
 //
+// Setting CheckpointProperty checkpoints the process of a migratable
+// container instead.
+//
 func (c *Container) SetProperty(name string, value string) error {
+	if name == CheckpointProperty {
+		return c.checkpoint(context.Background(), value)
+	}
+
 	labelSet, err := propertiesToLabels(garden.Properties{name: value})
 	if err != nil {
 		return err
@@ -237,16 +310,124 @@ func (c *Container) RemoveProperty(name string) (err error) {
 	return
 }
 
-// Info - Not Implemented
-func (c *Container) Info() (info garden.ContainerInfo, err error) {
-	err = ErrNotImplemented
-	return
+// Info returns the state of the container, the processes running in it and
+// its properties. Containers whose task has exited or was never started are
+// "stopped".
+//
+func (c *Container) Info() (garden.ContainerInfo, error) {
+	ctx := context.Background()
+
+	properties, err := c.Properties()
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	info := garden.ContainerInfo{
+		State:      "stopped",
+		Properties: properties,
+	}
+
+	task, err := c.container.Task(ctx, cio.Load)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return info, nil
+		}
+
+		return garden.ContainerInfo{}, fmt.Errorf("task lookup: %w", err)
+	}
+
+	status, err := task.Status(ctx)
+	if err != nil {
+		return garden.ContainerInfo{}, fmt.Errorf("task status: %w", err)
+	}
+
+	if status.Status == containerd.Stopped {
+		return info, nil
+	}
+
+	info.State = "active"
+
+	processes, err := task.Pids(ctx)
+	if err != nil {
+		return garden.ContainerInfo{}, fmt.Errorf("task pids: %w", err)
+	}
+
+	for _, process := range processes {
+		info.ProcessIDs = append(info.ProcessIDs, strconv.FormatUint(uint64(process.Pid), 10))
+	}
+
+	return info, nil
 }
 
-// Metrics - Not Implemented
-func (c *Container) Metrics() (metrics garden.Metrics, err error) {
-	err = ErrNotImplemented
-	return
+// Metrics returns the CPU, memory and pid usage of the container's task as
+// reported by its cgroup.
+//
+func (c *Container) Metrics() (garden.Metrics, error) {
+	ctx := context.Background()
+
+	containerInfo, err := c.container.Info(ctx)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("container info: %w", err)
+	}
+
+	task, err := c.container.Task(ctx, cio.Load)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("task lookup: %w", err)
+	}
+
+	taskMetrics, err := task.Metrics(ctx)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("task metrics: %w", err)
+	}
+
+	metrics, err := toGardenMetrics(taskMetrics, time.Since(containerInfo.CreatedAt))
+	if err != nil {
+		return garden.Metrics{}, err
+	}
+
+	// Disk usage is only known for containers whose disk limit is enforced,
+	// as reported by the quota they're charged to.
+	spec, err := c.container.Spec(ctx)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("container spec: %w", err)
+	}
+
+	projectID, found, err := diskQuotaProject(spec)
+	if err != nil {
+		return garden.Metrics{}, err
+	}
+
+	if found && c.diskQuota != nil {
+		used, err := c.diskQuota.Usage(projectID)
+		if err != nil {
+			return garden.Metrics{}, fmt.Errorf("disk usage: %w", err)
+		}
+
+		metrics.DiskStat = garden.ContainerDiskStat{TotalBytesUsed: used}
+	}
+
+	return metrics, nil
+}
+
+func (c *Container) blockIO() (string, error) {
+	ctx := context.Background()
+
+	task, err := c.container.Task(ctx, cio.Load)
+	if err != nil {
+		return "", fmt.Errorf("task lookup: %w", err)
+	}
+
+	taskMetrics, err := task.Metrics(ctx)
+	if err != nil {
+		return "", fmt.Errorf("task metrics: %w", err)
+	}
+
+	read, written, err := blockIO(taskMetrics)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d %d", read, written), nil
 }
 
 // StreamIn - Not Implemented
@@ -297,9 +478,81 @@ func (c *Container) CurrentCPULimits() (garden.CPULimits, error) {
 	}, nil
 }
 
-// CurrentDiskLimits returns no limits (achieves parity with Guardian)
+// CurrentDiskLimits returns the disk limit recorded for the container when it
+// was created.
+//
 func (c *Container) CurrentDiskLimits() (garden.DiskLimits, error) {
-	return garden.DiskLimits{}, nil
+	spec, err := c.container.Spec(context.Background())
+	if err != nil {
+		return garden.DiskLimits{}, err
+	}
+
+	return diskLimits(spec)
+}
+
+func diskLimits(spec *specs.Spec) (garden.DiskLimits, error) {
+	if spec == nil {
+		return garden.DiskLimits{}, nil
+	}
+
+	value, found := spec.Annotations[bespec.DiskLimitAnnotation]
+	if !found {
+		return garden.DiskLimits{}, nil
+	}
+
+	limit, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return garden.DiskLimits{}, fmt.Errorf("parse disk limit: %w", err)
+	}
+
+	return garden.DiskLimits{ByteHard: limit}, nil
+}
+
+// diskQuotaProject returns the ID of the quota project that the disk usage of
+// a container is charged to, if any.
+//
+func diskQuotaProject(spec *specs.Spec) (uint32, bool, error) {
+	if spec == nil {
+		return 0, false, nil
+	}
+
+	value, found := spec.Annotations[diskQuotaProjectAnnotation]
+	if !found {
+		return 0, false, nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, false, fmt.Errorf("parse disk quota project: %w", err)
+	}
+
+	return uint32(id), true, nil
+}
+
+// writablePaths returns the paths on the host that the container can write
+// to: its rootfs and any read-write bind mounts.
+//
+func writablePaths(spec *specs.Spec) []string {
+	var paths []string
+
+	if spec.Root != nil && !spec.Root.Readonly {
+		paths = append(paths, spec.Root.Path)
+	}
+
+	for _, mount := range spec.Mounts {
+		if mount.Type != "bind" {
+			continue
+		}
+
+		for _, option := range mount.Options {
+			if option == "rw" {
+				paths = append(paths, mount.Source)
+				break
+			}
+		}
+	}
+
+	return paths
 }
 
 // CurrentMemoryLimits returns the memory limit in bytes allocated to the container
@@ -328,16 +581,23 @@ func (c *Container) NetIn(hostPort, containerPort uint32) (a, b uint32, err erro
 	return
 }
 
-// NetOut - Not Implemented
-func (c *Container) NetOut(netOutRule garden.NetOutRule) (err error) {
-	err = ErrNotImplemented
-	return
+// NetOut permits the container to reach the destinations described by the
+// rule. Once a rule has been added, all other egress traffic is rejected.
+//
+func (c *Container) NetOut(netOutRule garden.NetOutRule) error {
+	return c.BulkNetOut([]garden.NetOutRule{netOutRule})
 }
 
-// BulkNetOut - Not Implemented
-func (c *Container) BulkNetOut(netOutRules []garden.NetOutRule) (err error) {
-	err = ErrNotImplemented
-	return
+// BulkNetOut permits the container to reach the destinations described by
+// any of the rules.
+//
+func (c *Container) BulkNetOut(netOutRules []garden.NetOutRule) error {
+	err := c.network.NetOut(c.container.ID(), netOutRules)
+	if err != nil {
+		return fmt.Errorf("net out: %w", err)
+	}
+
+	return nil
 }
 
 func procID(gdnProcSpec garden.ProcessSpec) string {
@@ -2,11 +2,23 @@ package runtime_test
 
 import (
 	"errors"
+	"net"
+	"time"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager/lagertest"
+	atcruntime "github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
 	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
+	v1 "github.com/containerd/cgroups/stats/v1"
+	v2 "github.com/containerd/cgroups/v2/stats"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/api/types"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/typeurl"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -22,6 +34,8 @@ type ContainerSuite struct {
 	containerdTask      *libcontainerdfakes.FakeTask
 	rootfsManager       *runtimefakes.FakeRootfsManager
 	killer              *runtimefakes.FakeKiller
+	network             *runtimefakes.FakeNetwork
+	diskQuota           *runtimefakes.FakeDiskQuota
 }
 
 func (s *ContainerSuite) SetupTest() {
@@ -30,11 +44,16 @@ func (s *ContainerSuite) SetupTest() {
 	s.containerdTask = new(libcontainerdfakes.FakeTask)
 	s.rootfsManager = new(runtimefakes.FakeRootfsManager)
 	s.killer = new(runtimefakes.FakeKiller)
+	s.network = new(runtimefakes.FakeNetwork)
+	s.diskQuota = new(runtimefakes.FakeDiskQuota)
 
 	s.container = runtime.NewContainer(
 		s.containerdContainer,
 		s.killer,
 		s.rootfsManager,
+		s.network,
+		s.diskQuota,
+		lagertest.NewTestLogger("container"),
 	)
 }
 
@@ -437,3 +456,228 @@ func (s *ContainerSuite) TestCurrentMemoryLimitsReturnsLimit() {
 	s.NoError(err)
 	s.Equal(garden.MemoryLimits{LimitInBytes: uint64(limitBytes)}, limits)
 }
+
+func (s *ContainerSuite) TestCurrentDiskLimitsNoLimitSet() {
+	s.containerdContainer.SpecReturns(&specs.Spec{}, nil)
+	limits, err := s.container.CurrentDiskLimits()
+	s.NoError(err)
+	s.Equal(garden.DiskLimits{}, limits)
+}
+
+func (s *ContainerSuite) TestCurrentDiskLimitsReturnsLimit() {
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Annotations: map[string]string{bespec.DiskLimitAnnotation: "1024"},
+	}, nil)
+	limits, err := s.container.CurrentDiskLimits()
+	s.NoError(err)
+	s.Equal(garden.DiskLimits{ByteHard: 1024}, limits)
+}
+
+func (s *ContainerSuite) TestMetricsDiskUsageWithQuota() {
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Annotations: map[string]string{
+			bespec.DiskLimitAnnotation:     "1024",
+			"concourse.disk-quota-project": "65537",
+		},
+	}, nil)
+	s.diskQuota.UsageReturns(512, nil)
+
+	metrics, err := s.container.Metrics()
+	s.NoError(err)
+	s.Equal(garden.ContainerDiskStat{TotalBytesUsed: 512}, metrics.DiskStat)
+
+	s.Equal(1, s.diskQuota.UsageCallCount())
+	s.Equal(uint32(65537), s.diskQuota.UsageArgsForCall(0))
+}
+
+func (s *ContainerSuite) TestMetricsDiskUsageError() {
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Annotations: map[string]string{"concourse.disk-quota-project": "65537"},
+	}, nil)
+	s.diskQuota.UsageReturns(0, errors.New("quota-err"))
+
+	_, err := s.container.Metrics()
+	s.EqualError(errors.Unwrap(err), "quota-err")
+}
+
+func (s *ContainerSuite) TestMetricsNoDiskUsageWithoutQuota() {
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Annotations: map[string]string{bespec.DiskLimitAnnotation: "1024"},
+	}, nil)
+
+	metrics, err := s.container.Metrics()
+	s.NoError(err)
+	s.Equal(garden.ContainerDiskStat{}, metrics.DiskStat)
+	s.Equal(0, s.diskQuota.UsageCallCount())
+}
+
+func (s *ContainerSuite) TestInfoStoppedWithoutTask() {
+	s.containerdContainer.LabelsReturns(map[string]string{"foo.0": "bar"}, nil)
+	s.containerdContainer.TaskReturns(nil, errdefs.ErrNotFound)
+
+	info, err := s.container.Info()
+	s.NoError(err)
+	s.Equal("stopped", info.State)
+	s.Equal(garden.Properties{"foo": "bar"}, info.Properties)
+	s.Empty(info.ProcessIDs)
+}
+
+func (s *ContainerSuite) TestInfoActiveWithRunningTask() {
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.StatusReturns(containerd.Status{Status: containerd.Running}, nil)
+	s.containerdTask.PidsReturns([]containerd.ProcessInfo{{Pid: 123}, {Pid: 456}}, nil)
+
+	info, err := s.container.Info()
+	s.NoError(err)
+	s.Equal("active", info.State)
+	s.Equal([]string{"123", "456"}, info.ProcessIDs)
+}
+
+func (s *ContainerSuite) TestInfoStoppedWithExitedTask() {
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.StatusReturns(containerd.Status{Status: containerd.Stopped}, nil)
+
+	info, err := s.container.Info()
+	s.NoError(err)
+	s.Equal("stopped", info.State)
+	s.Equal(0, s.containerdTask.PidsCallCount())
+}
+
+func (s *ContainerSuite) TestInfoTaskLookupError() {
+	expectedErr := errors.New("task-lookup-err")
+	s.containerdContainer.TaskReturns(nil, expectedErr)
+
+	_, err := s.container.Info()
+	s.True(errors.Is(err, expectedErr))
+}
+
+func (s *ContainerSuite) TestMetricsCgroupsV1() {
+	s.containerdContainer.InfoReturns(containers.Container{CreatedAt: time.Now().Add(-time.Minute)}, nil)
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(s.taskMetrics(&v1.Metrics{
+		CPU: &v1.CPUStat{
+			Usage: &v1.CPUUsage{Total: 3000, User: 2000, Kernel: 1000},
+		},
+		Memory: &v1.MemoryStat{
+			TotalRSS:          100,
+			TotalInactiveFile: 50,
+			Usage:             &v1.MemoryEntry{Usage: 250},
+		},
+		Pids: &v1.PidsStat{Current: 3, Limit: 10},
+	}), nil)
+
+	metrics, err := s.container.Metrics()
+	s.NoError(err)
+	s.Equal(garden.ContainerCPUStat{Usage: 3000, User: 2000, System: 1000}, metrics.CPUStat)
+	s.Equal(uint64(200), metrics.MemoryStat.TotalUsageTowardLimit)
+	s.Equal(uint64(100), metrics.MemoryStat.TotalRss)
+	s.Equal(garden.ContainerPidStat{Current: 3, Max: 10}, metrics.PidStat)
+	s.GreaterOrEqual(metrics.Age, time.Minute)
+}
+
+func (s *ContainerSuite) TestMetricsCgroupsV2() {
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(s.taskMetrics(&v2.Metrics{
+		CPU: &v2.CPUStat{UsageUsec: 3, UserUsec: 2, SystemUsec: 1},
+		Memory: &v2.MemoryStat{
+			Anon:         100,
+			InactiveFile: 50,
+			Usage:        250,
+		},
+		Pids: &v2.PidsStat{Current: 3, Limit: 10},
+	}), nil)
+
+	metrics, err := s.container.Metrics()
+	s.NoError(err)
+	s.Equal(garden.ContainerCPUStat{Usage: 3000, User: 2000, System: 1000}, metrics.CPUStat)
+	s.Equal(uint64(200), metrics.MemoryStat.TotalUsageTowardLimit)
+	s.Equal(uint64(100), metrics.MemoryStat.Rss)
+	s.Equal(garden.ContainerPidStat{Current: 3, Max: 10}, metrics.PidStat)
+}
+
+func (s *ContainerSuite) TestMetricsTaskMetricsError() {
+	expectedErr := errors.New("metrics-err")
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(nil, expectedErr)
+
+	_, err := s.container.Metrics()
+	s.True(errors.Is(err, expectedErr))
+}
+
+func (s *ContainerSuite) TestBlockIOPropertyCgroupsV1() {
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(s.taskMetrics(&v1.Metrics{
+		Blkio: &v1.BlkIOStat{
+			IoServiceBytesRecursive: []*v1.BlkIOEntry{
+				{Op: "Read", Major: 8, Value: 100},
+				{Op: "Write", Major: 8, Value: 20},
+				{Op: "Read", Major: 9, Value: 1},
+				{Op: "Total", Major: 8, Value: 120},
+			},
+		},
+	}), nil)
+
+	blockIO, err := s.container.Property(atcruntime.BlockIOPropertyName)
+	s.NoError(err)
+	s.Equal("101 20", blockIO)
+	s.Equal(0, s.containerdContainer.LabelsCallCount())
+}
+
+func (s *ContainerSuite) TestBlockIOPropertyCgroupsV2() {
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(s.taskMetrics(&v2.Metrics{
+		Io: &v2.IOStat{
+			Usage: []*v2.IOEntry{
+				{Major: 8, Rbytes: 100, Wbytes: 20},
+				{Major: 9, Rbytes: 1, Wbytes: 2},
+			},
+		},
+	}), nil)
+
+	blockIO, err := s.container.Property(atcruntime.BlockIOPropertyName)
+	s.NoError(err)
+	s.Equal("101 22", blockIO)
+}
+
+func (s *ContainerSuite) TestBlockIOPropertyTaskMetricsError() {
+	expectedErr := errors.New("metrics-err")
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(nil, expectedErr)
+
+	_, err := s.container.Property(atcruntime.BlockIOPropertyName)
+	s.True(errors.Is(err, expectedErr))
+}
+
+func (s *ContainerSuite) taskMetrics(stats interface{}) *types.Metric {
+	data, err := typeurl.MarshalAny(stats)
+	s.NoError(err)
+
+	return &types.Metric{Data: data}
+}
+
+func (s *ContainerSuite) TestNetOut() {
+	s.containerdContainer.IDReturns("handle")
+
+	rule := garden.NetOutRule{
+		Protocol: garden.ProtocolUDP,
+		Networks: []garden.IPRange{garden.IPRangeFromIP(net.ParseIP("1.2.3.4"))},
+	}
+
+	err := s.container.NetOut(rule)
+	s.NoError(err)
+
+	s.Equal(1, s.network.NetOutCallCount())
+	handle, rules := s.network.NetOutArgsForCall(0)
+	s.Equal("handle", handle)
+	s.Equal([]garden.NetOutRule{rule}, rules)
+}
+
+func (s *ContainerSuite) TestBulkNetOutFailure() {
+	s.network.NetOutReturns(errors.New("net-out-err"))
+
+	err := s.container.BulkNetOut([]garden.NetOutRule{{}, {}})
+	s.EqualError(errors.Unwrap(err), "net-out-err")
+}
@@ -0,0 +1,319 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// diskQuotaProjectAnnotation is the annotation under which the ID of the
+// quota project that a container's disk usage is charged to is recorded.
+//
+const diskQuotaProjectAnnotation = "concourse.disk-quota-project"
+
+// DiskQuota enforces the disk limits of containers in the filesystem that
+// their volumes are on.
+//
+//counterfeiter:generate . DiskQuota
+type DiskQuota interface {
+	// Limit charges whatever gets written under the paths from now on to a
+	// new project, whose usage the filesystem limits to the given number of
+	// bytes, and returns the ID of the project.
+	//
+	Limit(paths []string, bytes uint64) (projectID uint32, err error)
+
+	// Usage returns the number of bytes charged to a project.
+	//
+	Usage(projectID uint32) (bytes uint64, err error)
+
+	// Release lifts the limit of a project, so that its ID can be handed out
+	// again once nothing is charged to it anymore.
+	//
+	Release(projectID uint32) error
+}
+
+// From linux/fs.h and linux/dqblk_xfs.h, which x/sys/unix doesn't cover.
+//
+const (
+	fsIocFsGetXattr = 0x801c581f
+	fsIocFsSetXattr = 0x401c5820
+
+	fsXflagProjInherit = 0x00000200
+
+	qXGetQuota = 0x5803
+	qXSetQLim  = 0x5804
+	prjQuota   = 2
+
+	fsDquotVersion = 1
+	fsProjQuota    = 4
+	fsDqBHard      = 1 << 3
+	fsDqBSoft      = 1 << 2
+
+	// quota limits and usage are counted in basic blocks
+	basicBlockSize = 512
+)
+
+// fsxattr mirrors struct fsxattr.
+//
+type fsxattr struct {
+	xflags     uint32
+	extsize    uint32
+	nextents   uint32
+	projid     uint32
+	cowextsize uint32
+	pad        [8]byte
+}
+
+// fsDiskQuota mirrors struct fs_disk_quota.
+//
+type fsDiskQuota struct {
+	version      int8
+	flags        int8
+	fieldmask    uint16
+	id           uint32
+	blkHardlimit uint64
+	blkSoftlimit uint64
+	inoHardlimit uint64
+	inoSoftlimit uint64
+	bcount       uint64
+	icount       uint64
+	itimer       int32
+	btimer       int32
+	iwarns       uint16
+	bwarns       uint16
+	padding2     int32
+	rtbHardlimit uint64
+	rtbSoftlimit uint64
+	rtbcount     uint64
+	rtbtimer     int32
+	rtbwarns     uint16
+	padding3     int16
+	padding4     [8]byte
+}
+
+// projectQuota limits the disk usage of containers with project quotas, as
+// supported by XFS filesystems mounted with the prjquota option.
+//
+// The directories under a container's writable paths are assigned to the
+// container's project, and marked for new files and directories to inherit
+// it. Files that already exist when the limit is set aren't charged to the
+// project, e.g. those of the image of a container.
+//
+type projectQuota struct {
+	device string
+
+	lock   sync.Mutex
+	nextID uint32
+}
+
+// minProjectID is the first project ID handed out. Lower IDs are left for
+// the projects that the operator might set up themselves.
+//
+const minProjectID = 1 << 16
+
+// NewProjectQuota sets up project quotas for the filesystem that path is on.
+// As quotactl(2) operates on block devices, a device node for the filesystem
+// is created at devicePath.
+//
+// It fails if the filesystem doesn't have project quotas enforced.
+//
+func NewProjectQuota(path, devicePath string) (DiskQuota, error) {
+	var stat unix.Stat_t
+	err := unix.Stat(path, &stat)
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", path, err)
+	}
+
+	err = os.Remove(devicePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("remove stale device node: %w", err)
+	}
+
+	err = unix.Mknod(devicePath, unix.S_IFBLK|0600, int(stat.Dev))
+	if err != nil {
+		return nil, fmt.Errorf("create device node: %w", err)
+	}
+
+	quota := &projectQuota{
+		device: devicePath,
+		nextID: minProjectID,
+	}
+
+	_, err = quota.get(minProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("project quotas are not enforced on the filesystem of %s: %w", path, err)
+	}
+
+	return quota, nil
+}
+
+func (q *projectQuota) Limit(paths []string, bytes uint64) (uint32, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	id, err := q.freeID()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, path := range paths {
+		err = filepath.WalkDir(path, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !entry.IsDir() {
+				return nil
+			}
+
+			return setProject(path, id)
+		})
+		if err != nil {
+			return 0, fmt.Errorf("assign %s to project: %w", path, err)
+		}
+	}
+
+	blocks := (bytes + basicBlockSize - 1) / basicBlockSize
+
+	err = q.set(id, blocks)
+	if err != nil {
+		return 0, fmt.Errorf("set quota: %w", err)
+	}
+
+	q.nextID = id + 1
+
+	return id, nil
+}
+
+func (q *projectQuota) Usage(id uint32) (uint64, error) {
+	quota, err := q.get(id)
+	if err != nil {
+		return 0, fmt.Errorf("get quota: %w", err)
+	}
+
+	return quota.bcount * basicBlockSize, nil
+}
+
+func (q *projectQuota) Release(id uint32) error {
+	err := q.set(id, 0)
+	if err != nil {
+		return fmt.Errorf("lift quota: %w", err)
+	}
+
+	return nil
+}
+
+// freeID finds the next project ID that has neither a limit nor anything
+// charged to it. IDs are handed out in a cycle, so that those of destroyed
+// containers are only reused once their volumes are likely gone.
+//
+func (q *projectQuota) freeID() (uint32, error) {
+	id := q.nextID
+	for {
+		if id < minProjectID {
+			id = minProjectID
+		}
+
+		quota, err := q.get(id)
+		if err != nil {
+			return 0, fmt.Errorf("get quota: %w", err)
+		}
+
+		if quota.blkHardlimit == 0 && quota.bcount == 0 {
+			return id, nil
+		}
+
+		id++
+		if id == q.nextID {
+			return 0, errors.New("no free project IDs")
+		}
+	}
+}
+
+func (q *projectQuota) get(id uint32) (fsDiskQuota, error) {
+	var quota fsDiskQuota
+	err := q.quotactl(qXGetQuota, id, &quota)
+	if errors.Is(err, unix.ENOENT) {
+		// no quota has been set for the project yet
+		return fsDiskQuota{}, nil
+	}
+
+	return quota, err
+}
+
+func (q *projectQuota) set(id uint32, blocks uint64) error {
+	quota := fsDiskQuota{
+		version:      fsDquotVersion,
+		flags:        fsProjQuota,
+		fieldmask:    fsDqBHard | fsDqBSoft,
+		id:           id,
+		blkHardlimit: blocks,
+		blkSoftlimit: blocks,
+	}
+
+	return q.quotactl(qXSetQLim, id, &quota)
+}
+
+func (q *projectQuota) quotactl(cmd int, id uint32, quota *fsDiskQuota) error {
+	device, err := unix.BytePtrFromString(q.device)
+	if err != nil {
+		return err
+	}
+
+	_, _, errno := unix.Syscall6(
+		unix.SYS_QUOTACTL,
+		uintptr(cmd<<8|prjQuota),
+		uintptr(unsafe.Pointer(device)),
+		uintptr(id),
+		uintptr(unsafe.Pointer(quota)),
+		0, 0,
+	)
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
+
+// setProject assigns a directory to a project, for the files and directories
+// created in it to inherit.
+//
+func setProject(path string, id uint32) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer dir.Close()
+
+	var attr fsxattr
+	err = ioctl(dir.Fd(), fsIocFsGetXattr, unsafe.Pointer(&attr))
+	if err != nil {
+		return fmt.Errorf("get attributes of %s: %w", path, err)
+	}
+
+	attr.projid = id
+	attr.xflags |= fsXflagProjInherit
+
+	err = ioctl(dir.Fd(), fsIocFsSetXattr, unsafe.Pointer(&attr))
+	if err != nil {
+		return fmt.Errorf("set attributes of %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func ioctl(fd uintptr, req uintptr, arg unsafe.Pointer) error {
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, fd, req, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
@@ -27,4 +27,9 @@ var (
 	// ErrNotImplemented indicates that a method is not implemented.
 	//
 	ErrNotImplemented = errors.New("not implemented")
+
+	// ErrPrivilegedRootless indicates that a privileged container was
+	// requested from a backend running rootless, which can't provide one.
+	//
+	ErrPrivilegedRootless = errors.New("privileged containers are not supported by rootless workers")
 )
@@ -0,0 +1,129 @@
+package runtime
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/concourse/concourse/atc"
+	v1 "github.com/containerd/cgroups/stats/v1"
+	v2 "github.com/containerd/cgroups/v2/stats"
+	"github.com/containerd/containerd/api/types"
+	"github.com/containerd/typeurl"
+	"golang.org/x/sys/unix"
+)
+
+// signalExitOffset is added to the number of the signal that killed a
+// process to form its exit status.
+//
+const signalExitOffset = 128
+
+// killedBySignal returns the signal that killed a process given its exit
+// status, if any. The shim reports a process killed by a signal with 128 plus
+// the number of the signal as its exit status, which is all containerd tells
+// of it. Only statuses which map to a known signal are taken as such.
+//
+func killedBySignal(exitStatus uint32) (syscall.Signal, bool) {
+	if exitStatus <= signalExitOffset {
+		return 0, false
+	}
+
+	signal := syscall.Signal(exitStatus - signalExitOffset)
+	if unix.SignalName(signal) == "" {
+		return 0, false
+	}
+
+	return signal, true
+}
+
+// memoryStats unmarshals the metrics of a task, which are either cgroups v1
+// or v2 stats depending on the host. It returns nil if there are none.
+//
+func memoryStats(taskMetrics *types.Metric) (interface{}, error) {
+	if taskMetrics == nil || taskMetrics.Data == nil {
+		return nil, nil
+	}
+
+	data, err := typeurl.UnmarshalAny(taskMetrics.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal metrics: %w", err)
+	}
+
+	switch data.(type) {
+	case *v1.Metrics, *v2.Metrics:
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unknown metrics type %T", data)
+	}
+}
+
+// oomKills returns the number of processes the kernel has OOM-killed in the
+// cgroup of a container so far.
+//
+func oomKills(stats interface{}) uint64 {
+	switch stats := stats.(type) {
+	case *v1.Metrics:
+		if stats.MemoryOomControl != nil {
+			return stats.MemoryOomControl.OomKill
+		}
+
+	case *v2.Metrics:
+		if stats.MemoryEvents != nil {
+			return stats.MemoryEvents.OomKill
+		}
+	}
+
+	return 0
+}
+
+// toExitReason builds the reason for a process having been killed by a
+// signal out of the metrics of its task.
+//
+// The OOM kill counter of a cgroup is cumulative, so the process is only
+// taken as OOM-killed if it was killed by SIGKILL and the counter went up
+// since it started (see oomKillsBefore). Another process of the container
+// being OOM-killed in the meantime can't be told apart from it.
+//
+// Peak memory usage isn't tracked by cgroups v2, and is left out then.
+//
+func toExitReason(signal syscall.Signal, oomKillsBefore uint64, taskMetrics *types.Metric) (atc.ExitReason, error) {
+	reason := atc.ExitReason{Signal: signalName(signal)}
+
+	if signal != syscall.SIGKILL {
+		return reason, nil
+	}
+
+	stats, err := memoryStats(taskMetrics)
+	if err != nil {
+		return atc.ExitReason{}, err
+	}
+
+	if oomKills(stats) <= oomKillsBefore {
+		return reason, nil
+	}
+
+	reason.OOMKilled = true
+
+	switch stats := stats.(type) {
+	case *v1.Metrics:
+		if stats.Memory != nil && stats.Memory.Usage != nil {
+			reason.MemoryLimit = stats.Memory.Usage.Limit
+			reason.MemoryPeak = stats.Memory.Usage.Max
+		}
+
+	case *v2.Metrics:
+		if stats.Memory != nil {
+			reason.MemoryLimit = stats.Memory.UsageLimit
+		}
+	}
+
+	return reason, nil
+}
+
+func signalName(signal syscall.Signal) string {
+	name := unix.SignalName(signal)
+	if name == "" {
+		return fmt.Sprintf("signal %d", signal)
+	}
+
+	return name
+}
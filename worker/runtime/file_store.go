@@ -20,6 +20,11 @@ type FileStore interface {
 	//
 	Append(name string, content []byte) error
 
+	// Read retrieves the contents of a file previously created in the
+	// store.
+	//
+	Read(name string) (content []byte, err error)
+
 	// DeleteFile removes a file previously created in the store.
 	//
 	Delete(name string) (err error)
@@ -70,7 +75,16 @@ func (f fileStore) Append(name string, content []byte) error {
 	return nil
 }
 
+func (f fileStore) Read(name string) ([]byte, error) {
+	absPath := filepath.Join(f.root, name)
 
+	content, err := ioutil.ReadFile(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	return content, nil
+}
 
 func (f fileStore) Delete(path string) error {
 	absPath := filepath.Join(f.root, path)
@@ -1,6 +1,7 @@
 package runtime_test
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -81,3 +82,17 @@ func (s *FileStoreSuite) TestDeleteDir() {
 	_, err = os.Stat(filepath.Dir(fpath))
 	s.True(os.IsNotExist(err))
 }
+
+func (s *FileStoreSuite) TestReadFile() {
+	_, err := s.store.Create("dir/name", []byte("hey"))
+	s.NoError(err)
+
+	content, err := s.store.Read("dir/name")
+	s.NoError(err)
+	s.Equal("hey", string(content))
+}
+
+func (s *FileStoreSuite) TestReadMissingFile() {
+	_, err := s.store.Read("dir/name")
+	s.True(os.IsNotExist(errors.Unwrap(err)))
+}
@@ -10,6 +10,10 @@ import (
 type Iptables interface {
 	CreateChainOrFlushIfExists(table string, chain string) error
 	AppendRule(table string, chain string, rulespec ...string) error
+	InsertRule(table string, chain string, pos int, rulespec ...string) error
+	DeleteRuleIfExists(table string, chain string, rulespec ...string) error
+	ChainExists(table string, chain string) (bool, error)
+	DeleteChainIfExists(table string, chain string) error
 }
 
 type iptables struct {
@@ -31,6 +35,20 @@ func New() (Iptables, error) {
 	return &ipt, nil
 }
 
+// NewIPv6 returns an Iptables that manages the IPv6 rules through ip6tables.
+func NewIPv6() (Iptables, error) {
+	g, err := goiptables.NewWithProtocol(goiptables.ProtocolIPv6)
+	if err != nil {
+		return nil, err
+	}
+
+	ipt := iptables{
+		goipt: g,
+	}
+
+	return &ipt, nil
+}
+
 func (ipt *iptables) CreateChainOrFlushIfExists(table string, chain string) error {
 	err := ipt.goipt.ClearChain(table, chain)
 	return err
@@ -40,3 +58,27 @@ func (ipt *iptables) AppendRule(table string, chain string, rulespec ...string)
 	err := ipt.goipt.Append(table, chain, rulespec...)
 	return err
 }
+
+func (ipt *iptables) InsertRule(table string, chain string, pos int, rulespec ...string) error {
+	err := ipt.goipt.Insert(table, chain, pos, rulespec...)
+	return err
+}
+
+func (ipt *iptables) DeleteRuleIfExists(table string, chain string, rulespec ...string) error {
+	err := ipt.goipt.DeleteIfExists(table, chain, rulespec...)
+	return err
+}
+
+func (ipt *iptables) ChainExists(table string, chain string) (bool, error) {
+	return ipt.goipt.ChainExists(table, chain)
+}
+
+func (ipt *iptables) DeleteChainIfExists(table string, chain string) error {
+	exists, err := ipt.goipt.ChainExists(table, chain)
+	if err != nil || !exists {
+		return err
+	}
+
+	err = ipt.goipt.ClearAndDeleteChain(table, chain)
+	return err
+}
@@ -21,6 +21,20 @@ type FakeIptables struct {
 	appendRuleReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ChainExistsStub        func(string, string) (bool, error)
+	chainExistsMutex       sync.RWMutex
+	chainExistsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	chainExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	chainExistsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	CreateChainOrFlushIfExistsStub        func(string, string) error
 	createChainOrFlushIfExistsMutex       sync.RWMutex
 	createChainOrFlushIfExistsArgsForCall []struct {
@@ -33,6 +47,45 @@ type FakeIptables struct {
 	createChainOrFlushIfExistsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteChainIfExistsStub        func(string, string) error
+	deleteChainIfExistsMutex       sync.RWMutex
+	deleteChainIfExistsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	deleteChainIfExistsReturns struct {
+		result1 error
+	}
+	deleteChainIfExistsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteRuleIfExistsStub        func(string, string, ...string) error
+	deleteRuleIfExistsMutex       sync.RWMutex
+	deleteRuleIfExistsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}
+	deleteRuleIfExistsReturns struct {
+		result1 error
+	}
+	deleteRuleIfExistsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	InsertRuleStub        func(string, string, int, ...string) error
+	insertRuleMutex       sync.RWMutex
+	insertRuleArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 []string
+	}
+	insertRuleReturns struct {
+		result1 error
+	}
+	insertRuleReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -100,6 +153,71 @@ func (fake *FakeIptables) AppendRuleReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeIptables) ChainExists(arg1 string, arg2 string) (bool, error) {
+	fake.chainExistsMutex.Lock()
+	ret, specificReturn := fake.chainExistsReturnsOnCall[len(fake.chainExistsArgsForCall)]
+	fake.chainExistsArgsForCall = append(fake.chainExistsArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ChainExistsStub
+	fakeReturns := fake.chainExistsReturns
+	fake.recordInvocation("ChainExists", []interface{}{arg1, arg2})
+	fake.chainExistsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIptables) ChainExistsCallCount() int {
+	fake.chainExistsMutex.RLock()
+	defer fake.chainExistsMutex.RUnlock()
+	return len(fake.chainExistsArgsForCall)
+}
+
+func (fake *FakeIptables) ChainExistsCalls(stub func(string, string) (bool, error)) {
+	fake.chainExistsMutex.Lock()
+	defer fake.chainExistsMutex.Unlock()
+	fake.ChainExistsStub = stub
+}
+
+func (fake *FakeIptables) ChainExistsArgsForCall(i int) (string, string) {
+	fake.chainExistsMutex.RLock()
+	defer fake.chainExistsMutex.RUnlock()
+	argsForCall := fake.chainExistsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeIptables) ChainExistsReturns(result1 bool, result2 error) {
+	fake.chainExistsMutex.Lock()
+	defer fake.chainExistsMutex.Unlock()
+	fake.ChainExistsStub = nil
+	fake.chainExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIptables) ChainExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.chainExistsMutex.Lock()
+	defer fake.chainExistsMutex.Unlock()
+	fake.ChainExistsStub = nil
+	if fake.chainExistsReturnsOnCall == nil {
+		fake.chainExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.chainExistsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeIptables) CreateChainOrFlushIfExists(arg1 string, arg2 string) error {
 	fake.createChainOrFlushIfExistsMutex.Lock()
 	ret, specificReturn := fake.createChainOrFlushIfExistsReturnsOnCall[len(fake.createChainOrFlushIfExistsArgsForCall)]
@@ -162,13 +280,210 @@ func (fake *FakeIptables) CreateChainOrFlushIfExistsReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeIptables) DeleteChainIfExists(arg1 string, arg2 string) error {
+	fake.deleteChainIfExistsMutex.Lock()
+	ret, specificReturn := fake.deleteChainIfExistsReturnsOnCall[len(fake.deleteChainIfExistsArgsForCall)]
+	fake.deleteChainIfExistsArgsForCall = append(fake.deleteChainIfExistsArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteChainIfExistsStub
+	fakeReturns := fake.deleteChainIfExistsReturns
+	fake.recordInvocation("DeleteChainIfExists", []interface{}{arg1, arg2})
+	fake.deleteChainIfExistsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeIptables) DeleteChainIfExistsCallCount() int {
+	fake.deleteChainIfExistsMutex.RLock()
+	defer fake.deleteChainIfExistsMutex.RUnlock()
+	return len(fake.deleteChainIfExistsArgsForCall)
+}
+
+func (fake *FakeIptables) DeleteChainIfExistsCalls(stub func(string, string) error) {
+	fake.deleteChainIfExistsMutex.Lock()
+	defer fake.deleteChainIfExistsMutex.Unlock()
+	fake.DeleteChainIfExistsStub = stub
+}
+
+func (fake *FakeIptables) DeleteChainIfExistsArgsForCall(i int) (string, string) {
+	fake.deleteChainIfExistsMutex.RLock()
+	defer fake.deleteChainIfExistsMutex.RUnlock()
+	argsForCall := fake.deleteChainIfExistsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeIptables) DeleteChainIfExistsReturns(result1 error) {
+	fake.deleteChainIfExistsMutex.Lock()
+	defer fake.deleteChainIfExistsMutex.Unlock()
+	fake.DeleteChainIfExistsStub = nil
+	fake.deleteChainIfExistsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIptables) DeleteChainIfExistsReturnsOnCall(i int, result1 error) {
+	fake.deleteChainIfExistsMutex.Lock()
+	defer fake.deleteChainIfExistsMutex.Unlock()
+	fake.DeleteChainIfExistsStub = nil
+	if fake.deleteChainIfExistsReturnsOnCall == nil {
+		fake.deleteChainIfExistsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteChainIfExistsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIptables) DeleteRuleIfExists(arg1 string, arg2 string, arg3 ...string) error {
+	fake.deleteRuleIfExistsMutex.Lock()
+	ret, specificReturn := fake.deleteRuleIfExistsReturnsOnCall[len(fake.deleteRuleIfExistsArgsForCall)]
+	fake.deleteRuleIfExistsArgsForCall = append(fake.deleteRuleIfExistsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteRuleIfExistsStub
+	fakeReturns := fake.deleteRuleIfExistsReturns
+	fake.recordInvocation("DeleteRuleIfExists", []interface{}{arg1, arg2, arg3})
+	fake.deleteRuleIfExistsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeIptables) DeleteRuleIfExistsCallCount() int {
+	fake.deleteRuleIfExistsMutex.RLock()
+	defer fake.deleteRuleIfExistsMutex.RUnlock()
+	return len(fake.deleteRuleIfExistsArgsForCall)
+}
+
+func (fake *FakeIptables) DeleteRuleIfExistsCalls(stub func(string, string, ...string) error) {
+	fake.deleteRuleIfExistsMutex.Lock()
+	defer fake.deleteRuleIfExistsMutex.Unlock()
+	fake.DeleteRuleIfExistsStub = stub
+}
+
+func (fake *FakeIptables) DeleteRuleIfExistsArgsForCall(i int) (string, string, []string) {
+	fake.deleteRuleIfExistsMutex.RLock()
+	defer fake.deleteRuleIfExistsMutex.RUnlock()
+	argsForCall := fake.deleteRuleIfExistsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeIptables) DeleteRuleIfExistsReturns(result1 error) {
+	fake.deleteRuleIfExistsMutex.Lock()
+	defer fake.deleteRuleIfExistsMutex.Unlock()
+	fake.DeleteRuleIfExistsStub = nil
+	fake.deleteRuleIfExistsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIptables) DeleteRuleIfExistsReturnsOnCall(i int, result1 error) {
+	fake.deleteRuleIfExistsMutex.Lock()
+	defer fake.deleteRuleIfExistsMutex.Unlock()
+	fake.DeleteRuleIfExistsStub = nil
+	if fake.deleteRuleIfExistsReturnsOnCall == nil {
+		fake.deleteRuleIfExistsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteRuleIfExistsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIptables) InsertRule(arg1 string, arg2 string, arg3 int, arg4 ...string) error {
+	fake.insertRuleMutex.Lock()
+	ret, specificReturn := fake.insertRuleReturnsOnCall[len(fake.insertRuleArgsForCall)]
+	fake.insertRuleArgsForCall = append(fake.insertRuleArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 []string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.InsertRuleStub
+	fakeReturns := fake.insertRuleReturns
+	fake.recordInvocation("InsertRule", []interface{}{arg1, arg2, arg3, arg4})
+	fake.insertRuleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeIptables) InsertRuleCallCount() int {
+	fake.insertRuleMutex.RLock()
+	defer fake.insertRuleMutex.RUnlock()
+	return len(fake.insertRuleArgsForCall)
+}
+
+func (fake *FakeIptables) InsertRuleCalls(stub func(string, string, int, ...string) error) {
+	fake.insertRuleMutex.Lock()
+	defer fake.insertRuleMutex.Unlock()
+	fake.InsertRuleStub = stub
+}
+
+func (fake *FakeIptables) InsertRuleArgsForCall(i int) (string, string, int, []string) {
+	fake.insertRuleMutex.RLock()
+	defer fake.insertRuleMutex.RUnlock()
+	argsForCall := fake.insertRuleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeIptables) InsertRuleReturns(result1 error) {
+	fake.insertRuleMutex.Lock()
+	defer fake.insertRuleMutex.Unlock()
+	fake.InsertRuleStub = nil
+	fake.insertRuleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIptables) InsertRuleReturnsOnCall(i int, result1 error) {
+	fake.insertRuleMutex.Lock()
+	defer fake.insertRuleMutex.Unlock()
+	fake.InsertRuleStub = nil
+	if fake.insertRuleReturnsOnCall == nil {
+		fake.insertRuleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.insertRuleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeIptables) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.appendRuleMutex.RLock()
 	defer fake.appendRuleMutex.RUnlock()
+	fake.chainExistsMutex.RLock()
+	defer fake.chainExistsMutex.RUnlock()
 	fake.createChainOrFlushIfExistsMutex.RLock()
 	defer fake.createChainOrFlushIfExistsMutex.RUnlock()
+	fake.deleteChainIfExistsMutex.RLock()
+	defer fake.deleteChainIfExistsMutex.RUnlock()
+	fake.deleteRuleIfExistsMutex.RLock()
+	defer fake.deleteRuleIfExistsMutex.RUnlock()
+	fake.insertRuleMutex.RLock()
+	defer fake.insertRuleMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
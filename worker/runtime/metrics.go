@@ -0,0 +1,172 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	v1 "github.com/containerd/cgroups/stats/v1"
+	v2 "github.com/containerd/cgroups/v2/stats"
+	"github.com/containerd/containerd/api/types"
+	"github.com/containerd/typeurl"
+)
+
+// toGardenMetrics converts the metrics of a containerd task, which are
+// either cgroups v1 or v2 stats depending on the host, into Garden metrics.
+//
+// Memory usage toward the limit excludes the inactive file cache, as that is
+// reclaimed by the kernel before a container would be killed for exceeding
+// its limit.
+//
+func toGardenMetrics(taskMetrics *types.Metric, age time.Duration) (garden.Metrics, error) {
+	if taskMetrics == nil || taskMetrics.Data == nil {
+		return garden.Metrics{Age: age}, nil
+	}
+
+	data, err := typeurl.UnmarshalAny(taskMetrics.Data)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("unmarshal metrics: %w", err)
+	}
+
+	metrics := garden.Metrics{Age: age}
+
+	switch stats := data.(type) {
+	case *v1.Metrics:
+		if stats.CPU != nil && stats.CPU.Usage != nil {
+			metrics.CPUStat = garden.ContainerCPUStat{
+				Usage:  stats.CPU.Usage.Total,
+				User:   stats.CPU.Usage.User,
+				System: stats.CPU.Usage.Kernel,
+			}
+		}
+
+		if stats.Memory != nil {
+			memory := stats.Memory
+			metrics.MemoryStat = garden.ContainerMemoryStat{
+				ActiveAnon:        memory.ActiveAnon,
+				ActiveFile:        memory.ActiveFile,
+				Cache:             memory.Cache,
+				InactiveAnon:      memory.InactiveAnon,
+				InactiveFile:      memory.InactiveFile,
+				MappedFile:        memory.MappedFile,
+				Pgfault:           memory.PgFault,
+				Pgmajfault:        memory.PgMajFault,
+				Rss:               memory.RSS,
+				TotalActiveAnon:   memory.TotalActiveAnon,
+				TotalActiveFile:   memory.TotalActiveFile,
+				TotalCache:        memory.TotalCache,
+				TotalInactiveAnon: memory.TotalInactiveAnon,
+				TotalInactiveFile: memory.TotalInactiveFile,
+				TotalMappedFile:   memory.TotalMappedFile,
+				TotalPgfault:      memory.TotalPgFault,
+				TotalPgmajfault:   memory.TotalPgMajFault,
+				TotalRss:          memory.TotalRSS,
+				Unevictable:       memory.Unevictable,
+			}
+
+			if memory.Usage != nil {
+				metrics.MemoryStat.TotalUsageTowardLimit = usageTowardLimit(memory.Usage.Usage, memory.TotalInactiveFile)
+			}
+		}
+
+		if stats.Pids != nil {
+			metrics.PidStat = garden.ContainerPidStat{
+				Current: stats.Pids.Current,
+				Max:     stats.Pids.Limit,
+			}
+		}
+
+	case *v2.Metrics:
+		if stats.CPU != nil {
+			metrics.CPUStat = garden.ContainerCPUStat{
+				Usage:  uint64(time.Duration(stats.CPU.UsageUsec) * time.Microsecond),
+				User:   uint64(time.Duration(stats.CPU.UserUsec) * time.Microsecond),
+				System: uint64(time.Duration(stats.CPU.SystemUsec) * time.Microsecond),
+			}
+		}
+
+		if stats.Memory != nil {
+			memory := stats.Memory
+			metrics.MemoryStat = garden.ContainerMemoryStat{
+				ActiveAnon:            memory.ActiveAnon,
+				ActiveFile:            memory.ActiveFile,
+				InactiveAnon:          memory.InactiveAnon,
+				InactiveFile:          memory.InactiveFile,
+				MappedFile:            memory.FileMapped,
+				Pgfault:               memory.Pgfault,
+				Pgmajfault:            memory.Pgmajfault,
+				Rss:                   memory.Anon,
+				Unevictable:           memory.Unevictable,
+				TotalUsageTowardLimit: usageTowardLimit(memory.Usage, memory.InactiveFile),
+			}
+		}
+
+		if stats.Pids != nil {
+			metrics.PidStat = garden.ContainerPidStat{
+				Current: stats.Pids.Current,
+				Max:     stats.Pids.Limit,
+			}
+		}
+
+	default:
+		return garden.Metrics{}, fmt.Errorf("unknown metrics type %T", data)
+	}
+
+	return metrics, nil
+}
+
+func usageTowardLimit(usage, inactiveFile uint64) uint64 {
+	if inactiveFile > usage {
+		return 0
+	}
+
+	return usage - inactiveFile
+}
+
+// blockIO returns the bytes read from and written to block devices according
+// to the metrics of a containerd task.
+//
+func blockIO(taskMetrics *types.Metric) (uint64, uint64, error) {
+	if taskMetrics == nil || taskMetrics.Data == nil {
+		return 0, 0, nil
+	}
+
+	data, err := typeurl.UnmarshalAny(taskMetrics.Data)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unmarshal metrics: %w", err)
+	}
+
+	var read, written uint64
+
+	switch stats := data.(type) {
+	case *v1.Metrics:
+		if stats.Blkio == nil {
+			break
+		}
+
+		for _, entry := range stats.Blkio.IoServiceBytesRecursive {
+			switch {
+			case strings.EqualFold(entry.Op, "read"):
+				read += entry.Value
+			case strings.EqualFold(entry.Op, "write"):
+				written += entry.Value
+			}
+		}
+
+	case *v2.Metrics:
+		if stats.Io == nil {
+			break
+		}
+
+		for _, entry := range stats.Io.Usage {
+			read += entry.Rbytes
+			written += entry.Wbytes
+		}
+
+	default:
+		return 0, 0, fmt.Errorf("unknown metrics type %T", data)
+	}
+
+	return read, written, nil
+}
@@ -0,0 +1,108 @@
+package runtime
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/garden"
+)
+
+// netOutRuleSpecs converts a Garden NetOutRule into the iptables rule
+// specifications (without a target) matching the traffic that it permits.
+//
+// A single rule may expand into several specifications, one for each
+// combination of destination network, port range and protocol, as iptables
+// can only match on one of each per rule. Only the destinations of the given
+// address family are included, as IPv4 and IPv6 rules live in separate
+// tables.
+//
+func netOutRuleSpecs(rule garden.NetOutRule, ipv6 bool) ([][]string, error) {
+	icmp := "icmp"
+	if ipv6 {
+		icmp = "icmpv6"
+	}
+
+	var protocols []string
+	switch rule.Protocol {
+	case garden.ProtocolAll:
+		if len(rule.Ports) == 0 {
+			protocols = []string{"all"}
+		} else {
+			protocols = []string{"tcp", "udp"}
+		}
+	case garden.ProtocolTCP:
+		protocols = []string{"tcp"}
+	case garden.ProtocolUDP:
+		protocols = []string{"udp"}
+	case garden.ProtocolICMP:
+		protocols = []string{icmp}
+	default:
+		return nil, fmt.Errorf("unknown protocol %d", rule.Protocol)
+	}
+
+	destinations := [][]string{nil}
+	if len(rule.Networks) > 0 {
+		destinations = nil
+		for _, network := range rule.Networks {
+			if (network.Start.To4() == nil) != ipv6 {
+				// Networks of the other address family are handled by the
+				// other table.
+				continue
+			}
+
+			destinations = append(destinations, ipRangeSpec(network))
+		}
+	}
+
+	var specs [][]string
+	for _, protocol := range protocols {
+		var matches [][]string
+
+		switch protocol {
+		case "tcp", "udp":
+			for _, ports := range rule.Ports {
+				if ports.End != 0 && ports.End < ports.Start {
+					return nil, fmt.Errorf("invalid port range %d-%d", ports.Start, ports.End)
+				}
+
+				dport := fmt.Sprintf("%d", ports.Start)
+				if ports.End != 0 && ports.End != ports.Start {
+					dport = fmt.Sprintf("%d:%d", ports.Start, ports.End)
+				}
+
+				matches = append(matches, []string{"--dport", dport})
+			}
+		case icmp:
+			if rule.ICMPs != nil {
+				icmpType := fmt.Sprintf("%d", rule.ICMPs.Type)
+				if rule.ICMPs.Code != nil {
+					icmpType = fmt.Sprintf("%d/%d", rule.ICMPs.Type, *rule.ICMPs.Code)
+				}
+
+				matches = append(matches, []string{"--" + icmp + "-type", icmpType})
+			}
+		}
+
+		if len(matches) == 0 {
+			matches = [][]string{nil}
+		}
+
+		for _, destination := range destinations {
+			for _, match := range matches {
+				spec := append([]string{}, destination...)
+				spec = append(spec, "-p", protocol)
+				spec = append(spec, match...)
+				specs = append(specs, spec)
+			}
+		}
+	}
+
+	return specs, nil
+}
+
+func ipRangeSpec(ipRange garden.IPRange) []string {
+	if ipRange.End == nil || ipRange.End.Equal(ipRange.Start) {
+		return []string{"-d", ipRange.Start.String()}
+	}
+
+	return []string{"-m", "iprange", "--dst-range", ipRange.Start.String() + "-" + ipRange.End.String()}
+}
@@ -2,7 +2,12 @@ package runtime
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net"
 
+	"code.cloudfoundry.org/garden"
+	atcruntime "github.com/concourse/concourse/atc/runtime"
 	"github.com/containerd/containerd"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -26,4 +31,51 @@ type Network interface {
 	// Removes a task from the network.
 	//
 	Remove(ctx context.Context, task containerd.Task, handle string) (err error)
+
+	// NetOut restricts the egress traffic of a container that was previously
+	// added to the network to the destinations permitted by the rules.
+	// Containers without any rules have unrestricted egress.
+	//
+	NetOut(handle string, rules []garden.NetOutRule) (err error)
+
+	// Rename changes the host name of a container that was previously added
+	// to the network from its handle to the given one in its /etc/hosts and
+	// /etc/hostname, e.g. when a container of the warm pool gets claimed.
+	//
+	Rename(handle string, hostname string) (err error)
+}
+
+// resolveEgressHosts resolves the egress rules to host names that the ATC
+// passes as a property into rules to the addresses the host names currently
+// resolve to.
+//
+func resolveEgressHosts(properties garden.Properties) ([]garden.NetOutRule, error) {
+	raw, found := properties[atcruntime.EgressHostsPropertyName]
+	if !found {
+		return nil, nil
+	}
+
+	var hostRules []atcruntime.EgressHostRule
+	err := json.Unmarshal([]byte(raw), &hostRules)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal egress host rules: %w", err)
+	}
+
+	rules := make([]garden.NetOutRule, 0, len(hostRules))
+	for _, hostRule := range hostRules {
+		ips, err := net.LookupIP(hostRule.Host)
+		if err != nil {
+			return nil, fmt.Errorf("resolve egress host: %w", err)
+		}
+
+		rule := hostRule.Rule
+		rule.Networks = nil
+		for _, ip := range ips {
+			rule.Networks = append(rule.Networks, garden.IPRangeFromIP(ip))
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
 }
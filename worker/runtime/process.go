@@ -2,10 +2,15 @@ package runtime
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"syscall"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	atcruntime "github.com/concourse/concourse/atc/runtime"
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/errdefs"
 )
@@ -13,16 +18,43 @@ import (
 type Process struct {
 	process     containerd.Process
 	exitStatusC <-chan containerd.ExitStatus
+	container   containerd.Container
+	logger      lager.Logger
+
+	// oomKillsBefore is the number of OOM kills in the container when the
+	// process was wrapped, to tell whether the process itself was OOM-killed.
+	oomKillsBefore uint64
 }
 
+// NewProcess wraps a containerd process of a container, noting how many
+// processes of the container have been OOM-killed so far.
+//
 func NewProcess(
 	p containerd.Process,
 	ch <-chan containerd.ExitStatus,
+	container containerd.Container,
+	logger lager.Logger,
 ) *Process {
-	return &Process{
+	process := &Process{
 		process:     p,
 		exitStatusC: ch,
+		container:   container,
+		logger:      logger,
+	}
+
+	if container != nil {
+		kills, err := process.oomKills()
+		if err != nil {
+			logger.Error("failed-to-get-oom-kills", err)
+
+			// don't attribute any OOM kill to the process
+			kills = math.MaxUint64
+		}
+
+		process.oomKillsBefore = kills
 	}
+
+	return process
 }
 
 var _ garden.Process = (*Process)(nil)
@@ -34,7 +66,12 @@ func (p *Process) ID() string {
 }
 
 // Wait for the process to terminate (either naturally, or from a signal), and
-// once done, delete it.
+// once done, delete it, unless it's the init process of a migratable
+// container.
+//
+// If the process was killed by a signal, the reason is recorded as a property
+// of its container (see atcruntime.ExitReasonPropertyName) before returning.
+// Failing to do so doesn't fail the wait.
 //
 func (p *Process) Wait() (int, error) {
 	status := <-p.exitStatusC
@@ -50,15 +87,88 @@ func (p *Process) Wait() (int, error) {
 
 	p.process.IO().Wait()
 
-	_, err = p.process.Delete(context.Background())
-	// ignore "not found" errors - the process was already deleted
-	if err != nil && !errors.Is(err, errdefs.ErrNotFound) {
-		return 0, fmt.Errorf("delete process: %w", err)
+	// the init process of a migratable container is its task, which is
+	// left for Destroy to delete along with the networking of the container
+	if _, isTask := p.process.(containerd.Task); !isTask {
+		_, err = p.process.Delete(context.Background())
+		// ignore "not found" errors - the process was already deleted
+		if err != nil && !errors.Is(err, errdefs.ErrNotFound) {
+			return 0, fmt.Errorf("delete process: %w", err)
+		}
+	}
+
+	signal, killed := killedBySignal(status.ExitCode())
+	if killed && p.container != nil {
+		// the process has exited either way, so its status is still returned
+		err = p.recordExitReason(signal)
+		if err != nil {
+			p.logger.Error("failed-to-record-exit-reason", err)
+		}
 	}
 
 	return int(status.ExitCode()), nil
 }
 
+// recordExitReason looks up whether the container ran out of memory, and
+// sets the reason for the process having been killed as a container label.
+//
+func (p *Process) recordExitReason(signal syscall.Signal) error {
+	ctx := context.Background()
+
+	task, err := p.container.Task(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("task lookup: %w", err)
+	}
+
+	taskMetrics, err := task.Metrics(ctx)
+	if err != nil {
+		return fmt.Errorf("task metrics: %w", err)
+	}
+
+	reason, err := toExitReason(signal, p.oomKillsBefore, taskMetrics)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(reason)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	labels, err := propertiesToLabels(garden.Properties{atcruntime.ExitReasonPropertyName: string(payload)})
+	if err != nil {
+		return err
+	}
+
+	_, err = p.container.SetLabels(ctx, labels)
+	if err != nil {
+		return fmt.Errorf("set labels: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Process) oomKills() (uint64, error) {
+	ctx := context.Background()
+
+	task, err := p.container.Task(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("task lookup: %w", err)
+	}
+
+	taskMetrics, err := task.Metrics(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("task metrics: %w", err)
+	}
+
+	stats, err := memoryStats(taskMetrics)
+	if err != nil {
+		return 0, err
+	}
+
+	return oomKills(stats), nil
+}
+
 // SetTTY resizes the process' terminal dimensions.
 //
 func (p *Process) SetTTY(spec garden.TTYSpec) error {
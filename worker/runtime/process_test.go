@@ -6,10 +6,16 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager/lagertest"
+	atcruntime "github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
+	v1 "github.com/containerd/cgroups/stats/v1"
+	v2 "github.com/containerd/cgroups/v2/stats"
 	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/api/types"
 	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/typeurl"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -18,18 +24,25 @@ type ProcessSuite struct {
 	suite.Suite
 	*require.Assertions
 
-	io                *libcontainerdfakes.FakeIO
-	containerdProcess *libcontainerdfakes.FakeProcess
-	ch                chan containerd.ExitStatus
-	process           *runtime.Process
+	io                  *libcontainerdfakes.FakeIO
+	containerdProcess   *libcontainerdfakes.FakeProcess
+	containerdContainer *libcontainerdfakes.FakeContainer
+	containerdTask      *libcontainerdfakes.FakeTask
+	ch                  chan containerd.ExitStatus
+	logger              *lagertest.TestLogger
+	process             *runtime.Process
 }
 
 func (s *ProcessSuite) SetupTest() {
 	s.io = new(libcontainerdfakes.FakeIO)
 	s.containerdProcess = new(libcontainerdfakes.FakeProcess)
+	s.containerdTask = new(libcontainerdfakes.FakeTask)
+	s.containerdContainer = new(libcontainerdfakes.FakeContainer)
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
 	s.ch = make(chan containerd.ExitStatus, 1)
+	s.logger = lagertest.NewTestLogger("process")
 
-	s.process = runtime.NewProcess(s.containerdProcess, s.ch)
+	s.process = runtime.NewProcess(s.containerdProcess, s.ch, s.containerdContainer, s.logger)
 }
 
 func (s *ProcessSuite) TestID() {
@@ -86,6 +99,134 @@ func (s *ProcessSuite) TestProcessWaitBlocksUntilIOFinishes() {
 	s.Equal(1, s.io.WaitCallCount())
 }
 
+func (s *ProcessSuite) TestProcessWaitExitedNormally() {
+	s.ch <- *containerd.NewExitStatus(1, time.Now(), nil)
+	s.containerdProcess.IOReturns(s.io)
+
+	status, err := s.process.Wait()
+	s.NoError(err)
+	s.Equal(1, status)
+	s.Equal(0, s.containerdContainer.SetLabelsCallCount())
+}
+
+func (s *ProcessSuite) TestProcessWaitOOMKilled() {
+	s.ch <- *containerd.NewExitStatus(137, time.Now(), nil)
+	s.containerdProcess.IOReturns(s.io)
+	s.containerdTask.MetricsReturns(s.taskMetrics(&v1.Metrics{
+		Memory: &v1.MemoryStat{
+			Usage: &v1.MemoryEntry{Limit: 2 << 30, Max: 2 << 30},
+		},
+		MemoryOomControl: &v1.MemoryOomControl{OomKill: 1},
+	}), nil)
+
+	status, err := s.process.Wait()
+	s.NoError(err)
+	s.Equal(137, status)
+
+	s.Equal(1, s.containerdContainer.SetLabelsCallCount())
+	_, labels := s.containerdContainer.SetLabelsArgsForCall(0)
+	s.JSONEq(
+		`{"signal":"SIGKILL","oom_killed":true,"memory_limit":2147483648,"memory_peak":2147483648}`,
+		labels[atcruntime.ExitReasonPropertyName+".0"],
+	)
+}
+
+func (s *ProcessSuite) TestProcessWaitOOMKilledCgroupsV2() {
+	s.ch <- *containerd.NewExitStatus(137, time.Now(), nil)
+	s.containerdProcess.IOReturns(s.io)
+	s.containerdTask.MetricsReturns(s.taskMetrics(&v2.Metrics{
+		Memory:       &v2.MemoryStat{UsageLimit: 1 << 30},
+		MemoryEvents: &v2.MemoryEvents{OomKill: 1},
+	}), nil)
+
+	_, err := s.process.Wait()
+	s.NoError(err)
+
+	_, labels := s.containerdContainer.SetLabelsArgsForCall(0)
+	s.JSONEq(
+		`{"signal":"SIGKILL","oom_killed":true,"memory_limit":1073741824}`,
+		labels[atcruntime.ExitReasonPropertyName+".0"],
+	)
+}
+
+func (s *ProcessSuite) TestProcessWaitKilledBySignal() {
+	s.ch <- *containerd.NewExitStatus(143, time.Now(), nil)
+	s.containerdProcess.IOReturns(s.io)
+	s.containerdTask.MetricsReturns(s.taskMetrics(&v1.Metrics{
+		MemoryOomControl: &v1.MemoryOomControl{OomKill: 1},
+	}), nil)
+
+	status, err := s.process.Wait()
+	s.NoError(err)
+	s.Equal(143, status)
+
+	_, labels := s.containerdContainer.SetLabelsArgsForCall(0)
+	s.JSONEq(`{"signal":"SIGTERM"}`, labels[atcruntime.ExitReasonPropertyName+".0"])
+}
+
+func (s *ProcessSuite) TestProcessWaitKilledAfterEarlierOOMKill() {
+	s.containerdTask.MetricsReturns(s.taskMetrics(&v1.Metrics{
+		MemoryOomControl: &v1.MemoryOomControl{OomKill: 1},
+	}), nil)
+	s.process = runtime.NewProcess(s.containerdProcess, s.ch, s.containerdContainer, s.logger)
+
+	s.ch <- *containerd.NewExitStatus(137, time.Now(), nil)
+	s.containerdProcess.IOReturns(s.io)
+
+	_, err := s.process.Wait()
+	s.NoError(err)
+
+	_, labels := s.containerdContainer.SetLabelsArgsForCall(0)
+	s.JSONEq(`{"signal":"SIGKILL"}`, labels[atcruntime.ExitReasonPropertyName+".0"])
+}
+
+func (s *ProcessSuite) TestProcessWaitOOMKillsUnknownAtStart() {
+	s.containerdTask.MetricsReturns(nil, errors.New("metrics-err"))
+	s.process = runtime.NewProcess(s.containerdProcess, s.ch, s.containerdContainer, s.logger)
+
+	s.ch <- *containerd.NewExitStatus(137, time.Now(), nil)
+	s.containerdProcess.IOReturns(s.io)
+	s.containerdTask.MetricsReturns(s.taskMetrics(&v1.Metrics{
+		MemoryOomControl: &v1.MemoryOomControl{OomKill: 1},
+	}), nil)
+
+	_, err := s.process.Wait()
+	s.NoError(err)
+
+	_, labels := s.containerdContainer.SetLabelsArgsForCall(0)
+	s.JSONEq(`{"signal":"SIGKILL"}`, labels[atcruntime.ExitReasonPropertyName+".0"])
+}
+
+func (s *ProcessSuite) TestProcessWaitExitedWithStatusOfNoSignal() {
+	s.ch <- *containerd.NewExitStatus(128+40, time.Now(), nil)
+	s.containerdProcess.IOReturns(s.io)
+
+	status, err := s.process.Wait()
+	s.NoError(err)
+	s.Equal(168, status)
+	s.Equal(0, s.containerdContainer.SetLabelsCallCount())
+}
+
+func (s *ProcessSuite) TestProcessWaitTaskMetricsError() {
+	s.ch <- *containerd.NewExitStatus(137, time.Now(), nil)
+	s.containerdProcess.IOReturns(s.io)
+
+	s.containerdTask.MetricsReturns(nil, errors.New("metrics-err"))
+
+	status, err := s.process.Wait()
+	s.NoError(err)
+	s.Equal(137, status)
+	s.Equal(0, s.containerdContainer.SetLabelsCallCount())
+	s.Contains(string(s.logger.Buffer().Contents()), "failed-to-record-exit-reason")
+}
+
+func (s *ProcessSuite) taskMetrics(stats interface{}) *types.Metric {
+	data, err := typeurl.MarshalAny(stats)
+	s.NoError(err)
+
+	return &types.Metric{Data: data}
+}
+
 func (s *ProcessSuite) TestSetTTYWithNilWindowSize() {
 	err := s.process.SetTTY(garden.TTYSpec{})
 	s.NoError(err)
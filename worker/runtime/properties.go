@@ -126,6 +126,9 @@ func labelsToProperties(labels map[string]string) garden.Properties {
 // note that the key in this case represents the label key, which is not the
 // same as the property key - refer to propertiesToLabels.
 //
+// keys and values with characters that containerd's filter syntax doesn't
+// allow unquoted (e.g. the ':' and '-' of `concourse:warm-pool`) are quoted.
+//
 func propertiesToFilterList(properties garden.Properties) ([]string, error) {
 	for k, v := range properties {
 		if k == "" || v == "" {
@@ -141,8 +144,27 @@ func propertiesToFilterList(properties garden.Properties) ([]string, error) {
 	filters := make([]string, 0, len(labels))
 
 	for k, v := range labels {
-		filters = append(filters, "labels."+k+"=="+v)
+		filters = append(filters, "labels."+quoteFilter(k, isFilterFieldRune)+"=="+quoteFilter(v, isFilterValueRune))
 	}
 
 	return filters, nil
 }
+
+func quoteFilter(s string, unquoted func(rune) bool) string {
+	for _, r := range s {
+		if !unquoted(r) {
+			return strconv.Quote(s)
+		}
+	}
+
+	return s
+}
+
+func isFilterFieldRune(r rune) bool {
+	return r == '_' || r == '.' ||
+		r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+}
+
+func isFilterValueRune(r rune) bool {
+	return isFilterFieldRune(r) || r == '-' || r == ':'
+}
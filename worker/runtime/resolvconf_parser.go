@@ -6,7 +6,7 @@ import (
 	"regexp"
 	"strings"
 
-	"code.cloudfoundry.org/localip"
+	"github.com/concourse/concourse/worker/network"
 )
 
 // Parse resolve.conf file from the provided path.
@@ -21,9 +21,9 @@ func ParseHostResolveConf(path string) ([]string, error) {
 
 	resolvContents := string(resolvConf)
 
-	loopbackNameserver := regexp.MustCompile(`^\s*nameserver\s+127\.0\.0\.\d+\s*$`)
+	loopbackNameserver := regexp.MustCompile(`^\s*nameserver\s+(127\.0\.0\.\d+|::1)\s*$`)
 	if loopbackNameserver.MatchString(resolvContents) {
-		ip, err := localip.LocalIP()
+		ip, err := network.LocalIP()
 		if err != nil {
 			return nil, err
 		}
@@ -42,7 +42,7 @@ func ParseHostResolveConf(path string) ([]string, error) {
 			continue
 		}
 
-		pattern := regexp.MustCompile(`127\.\d{1,3}\.\d{1,3}\.\d{1,3}`)
+		pattern := regexp.MustCompile(`127\.\d{1,3}\.\d{1,3}\.\d{1,3}|\s::1$`)
 		if !pattern.MatchString(strings.TrimSpace(resolvEntry)) {
 			nameserverFields := strings.Fields(resolvEntry)
 			if len(nameserverFields) != 2 {
@@ -20,6 +20,8 @@ func (s *ResolveconfParserSuite) TestParseHostResolvConf() {
 	file := `
 nameserver 8.8.8.8
 nameserver 127.0.0.16
+nameserver ::1
+nameserver 2001:4860:4860::8888
 nameserver something 9.9.9.9
 search something
 `
@@ -31,7 +33,7 @@ search something
 	entries, err := runtime.ParseHostResolveConf(path.Join(tmpDir, "resolv.conf"))
 	s.NoError(err)
 
-	s.Equal([]string{"nameserver 8.8.8.8", "search something"}, entries)
+	s.Equal([]string{"nameserver 8.8.8.8", "nameserver 2001:4860:4860::8888", "search something"}, entries)
 }
 
 func (s *ResolveconfParserSuite) TestParseHostResolvConfWithLoopback() {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"sync"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/containerd/containerd"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
@@ -24,6 +25,18 @@ type FakeNetwork struct {
 	addReturnsOnCall map[int]struct {
 		result1 error
 	}
+	NetOutStub        func(string, []garden.NetOutRule) error
+	netOutMutex       sync.RWMutex
+	netOutArgsForCall []struct {
+		arg1 string
+		arg2 []garden.NetOutRule
+	}
+	netOutReturns struct {
+		result1 error
+	}
+	netOutReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RemoveStub        func(context.Context, containerd.Task, string) error
 	removeMutex       sync.RWMutex
 	removeArgsForCall []struct {
@@ -37,6 +50,18 @@ type FakeNetwork struct {
 	removeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RenameStub        func(string, string) error
+	renameMutex       sync.RWMutex
+	renameArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	renameReturns struct {
+		result1 error
+	}
+	renameReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetupHostNetworkStub        func() error
 	setupHostNetworkMutex       sync.RWMutex
 	setupHostNetworkArgsForCall []struct {
@@ -127,6 +152,73 @@ func (fake *FakeNetwork) AddReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeNetwork) NetOut(arg1 string, arg2 []garden.NetOutRule) error {
+	var arg2Copy []garden.NetOutRule
+	if arg2 != nil {
+		arg2Copy = make([]garden.NetOutRule, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.netOutMutex.Lock()
+	ret, specificReturn := fake.netOutReturnsOnCall[len(fake.netOutArgsForCall)]
+	fake.netOutArgsForCall = append(fake.netOutArgsForCall, struct {
+		arg1 string
+		arg2 []garden.NetOutRule
+	}{arg1, arg2Copy})
+	stub := fake.NetOutStub
+	fakeReturns := fake.netOutReturns
+	fake.recordInvocation("NetOut", []interface{}{arg1, arg2Copy})
+	fake.netOutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNetwork) NetOutCallCount() int {
+	fake.netOutMutex.RLock()
+	defer fake.netOutMutex.RUnlock()
+	return len(fake.netOutArgsForCall)
+}
+
+func (fake *FakeNetwork) NetOutCalls(stub func(string, []garden.NetOutRule) error) {
+	fake.netOutMutex.Lock()
+	defer fake.netOutMutex.Unlock()
+	fake.NetOutStub = stub
+}
+
+func (fake *FakeNetwork) NetOutArgsForCall(i int) (string, []garden.NetOutRule) {
+	fake.netOutMutex.RLock()
+	defer fake.netOutMutex.RUnlock()
+	argsForCall := fake.netOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNetwork) NetOutReturns(result1 error) {
+	fake.netOutMutex.Lock()
+	defer fake.netOutMutex.Unlock()
+	fake.NetOutStub = nil
+	fake.netOutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetwork) NetOutReturnsOnCall(i int, result1 error) {
+	fake.netOutMutex.Lock()
+	defer fake.netOutMutex.Unlock()
+	fake.NetOutStub = nil
+	if fake.netOutReturnsOnCall == nil {
+		fake.netOutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.netOutReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNetwork) Remove(arg1 context.Context, arg2 containerd.Task, arg3 string) error {
 	fake.removeMutex.Lock()
 	ret, specificReturn := fake.removeReturnsOnCall[len(fake.removeArgsForCall)]
@@ -190,6 +282,68 @@ func (fake *FakeNetwork) RemoveReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeNetwork) Rename(arg1 string, arg2 string) error {
+	fake.renameMutex.Lock()
+	ret, specificReturn := fake.renameReturnsOnCall[len(fake.renameArgsForCall)]
+	fake.renameArgsForCall = append(fake.renameArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RenameStub
+	fakeReturns := fake.renameReturns
+	fake.recordInvocation("Rename", []interface{}{arg1, arg2})
+	fake.renameMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNetwork) RenameCallCount() int {
+	fake.renameMutex.RLock()
+	defer fake.renameMutex.RUnlock()
+	return len(fake.renameArgsForCall)
+}
+
+func (fake *FakeNetwork) RenameCalls(stub func(string, string) error) {
+	fake.renameMutex.Lock()
+	defer fake.renameMutex.Unlock()
+	fake.RenameStub = stub
+}
+
+func (fake *FakeNetwork) RenameArgsForCall(i int) (string, string) {
+	fake.renameMutex.RLock()
+	defer fake.renameMutex.RUnlock()
+	argsForCall := fake.renameArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNetwork) RenameReturns(result1 error) {
+	fake.renameMutex.Lock()
+	defer fake.renameMutex.Unlock()
+	fake.RenameStub = nil
+	fake.renameReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetwork) RenameReturnsOnCall(i int, result1 error) {
+	fake.renameMutex.Lock()
+	defer fake.renameMutex.Unlock()
+	fake.RenameStub = nil
+	if fake.renameReturnsOnCall == nil {
+		fake.renameReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.renameReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNetwork) SetupHostNetwork() error {
 	fake.setupHostNetworkMutex.Lock()
 	ret, specificReturn := fake.setupHostNetworkReturnsOnCall[len(fake.setupHostNetworkArgsForCall)]
@@ -312,8 +466,12 @@ func (fake *FakeNetwork) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.addMutex.RLock()
 	defer fake.addMutex.RUnlock()
+	fake.netOutMutex.RLock()
+	defer fake.netOutMutex.RUnlock()
 	fake.removeMutex.RLock()
 	defer fake.removeMutex.RUnlock()
+	fake.renameMutex.RLock()
+	defer fake.renameMutex.RUnlock()
 	fake.setupHostNetworkMutex.RLock()
 	defer fake.setupHostNetworkMutex.RUnlock()
 	fake.setupMountsMutex.RLock()
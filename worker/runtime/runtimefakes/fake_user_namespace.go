@@ -22,6 +22,18 @@ type FakeUserNamespace struct {
 		result2 uint32
 		result3 error
 	}
+	RootlessStub        func() (bool, error)
+	rootlessMutex       sync.RWMutex
+	rootlessArgsForCall []struct {
+	}
+	rootlessReturns struct {
+		result1 bool
+		result2 error
+	}
+	rootlessReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -85,11 +97,69 @@ func (fake *FakeUserNamespace) MaxValidIdsReturnsOnCall(i int, result1 uint32, r
 	}{result1, result2, result3}
 }
 
+func (fake *FakeUserNamespace) Rootless() (bool, error) {
+	fake.rootlessMutex.Lock()
+	ret, specificReturn := fake.rootlessReturnsOnCall[len(fake.rootlessArgsForCall)]
+	fake.rootlessArgsForCall = append(fake.rootlessArgsForCall, struct {
+	}{})
+	stub := fake.RootlessStub
+	fakeReturns := fake.rootlessReturns
+	fake.recordInvocation("Rootless", []interface{}{})
+	fake.rootlessMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUserNamespace) RootlessCallCount() int {
+	fake.rootlessMutex.RLock()
+	defer fake.rootlessMutex.RUnlock()
+	return len(fake.rootlessArgsForCall)
+}
+
+func (fake *FakeUserNamespace) RootlessCalls(stub func() (bool, error)) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = stub
+}
+
+func (fake *FakeUserNamespace) RootlessReturns(result1 bool, result2 error) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = nil
+	fake.rootlessReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUserNamespace) RootlessReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.rootlessMutex.Lock()
+	defer fake.rootlessMutex.Unlock()
+	fake.RootlessStub = nil
+	if fake.rootlessReturnsOnCall == nil {
+		fake.rootlessReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.rootlessReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeUserNamespace) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.maxValidIdsMutex.RLock()
 	defer fake.maxValidIdsMutex.RUnlock()
+	fake.rootlessMutex.RLock()
+	defer fake.rootlessMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
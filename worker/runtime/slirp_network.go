@@ -0,0 +1,270 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/containerd/containerd"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	// slirpIP is the address that slirp4netns gives to the container's
+	// interface when configuring it.
+	//
+	slirpIP = "10.0.2.100"
+
+	// slirpNameServer is the address at which slirp4netns forwards DNS
+	// queries to the nameservers of the host.
+	//
+	slirpNameServer = "10.0.2.3"
+
+	// slirpMTU is the MTU recommended by slirp4netns for best throughput.
+	//
+	slirpMTU = 65520
+
+	slirpReadyTimeout = 10 * time.Second
+)
+
+// SlirpNetworkOpt defines a functional option that when applied, modifies the
+// configuration of a SlirpNetwork.
+//
+type SlirpNetworkOpt func(n *slirpNetwork)
+
+// WithSlirpBinary is the path to the slirp4netns binary used to set up the
+// network of each container.
+//
+func WithSlirpBinary(path string) SlirpNetworkOpt {
+	return func(n *slirpNetwork) {
+		n.binary = path
+	}
+}
+
+// WithSlirpFileStore changes the default FileStore used to store files that
+// belong to network configurations for containers.
+//
+func WithSlirpFileStore(f FileStore) SlirpNetworkOpt {
+	return func(n *slirpNetwork) {
+		n.store = f
+	}
+}
+
+// WithSlirpNameServers sets the set of nameservers to be configured for the
+// /etc/resolv.conf inside the containers instead of slirp4netns' DNS
+// forwarder.
+//
+func WithSlirpNameServers(nameservers []string) SlirpNetworkOpt {
+	return func(n *slirpNetwork) {
+		for _, ns := range nameservers {
+			n.nameServers = append(n.nameServers, "nameserver "+ns)
+		}
+	}
+}
+
+// WithSlirpAllowHostAccess allows containers to talk to the host's loopback
+// interface through the gateway address.
+//
+func WithSlirpAllowHostAccess() SlirpNetworkOpt {
+	return func(n *slirpNetwork) {
+		n.allowHostAccess = true
+	}
+}
+
+// slirpNetwork gives each container its own user-mode network stack through
+// slirp4netns. Unlike the CNI network, it needs no privileges on the host, as
+// nothing but the container's network namespace is modified, making it
+// suitable for rootless workers.
+//
+type slirpNetwork struct {
+	binary          string
+	store           FileStore
+	nameServers     []string
+	allowHostAccess bool
+}
+
+var _ Network = (*slirpNetwork)(nil)
+
+func NewSlirpNetwork(opts ...SlirpNetworkOpt) (*slirpNetwork, error) {
+	n := &slirpNetwork{
+		binary: "slirp4netns",
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	if n.store == nil {
+		return nil, fmt.Errorf("no file store initialized")
+	}
+
+	return n, nil
+}
+
+// SetupHostNetwork does nothing, as the containers' traffic never goes
+// through the host's network stack.
+//
+func (n slirpNetwork) SetupHostNetwork() error {
+	return nil
+}
+
+func (n slirpNetwork) SetupMounts(handle string) ([]specs.Mount, error) {
+	if handle == "" {
+		return nil, ErrInvalidInput("empty handle")
+	}
+
+	resolvEntries := n.nameServers
+	if len(resolvEntries) == 0 {
+		resolvEntries = []string{"nameserver " + slirpNameServer}
+	}
+
+	return setupNetworkMounts(n.store, handle, []byte(strings.Join(resolvEntries, "\n")+"\n"))
+}
+
+// Add starts a slirp4netns process attached to the task's network namespace,
+// which configures the container's interface and keeps forwarding its
+// traffic until the container is removed.
+//
+func (n slirpNetwork) Add(ctx context.Context, task containerd.Task, containerHandle string) error {
+	if task == nil {
+		return ErrInvalidInput("nil task")
+	}
+
+	pid, err := n.startSlirp(task.Pid())
+	if err != nil {
+		return fmt.Errorf("slirp4netns: %w", err)
+	}
+
+	_, err = n.store.Create(filepath.Join(containerHandle, "/slirp.pid"), []byte(strconv.Itoa(pid)))
+	if err != nil {
+		return fmt.Errorf("storing slirp4netns pid: %w", err)
+	}
+
+	return n.store.Append(
+		filepath.Join(containerHandle, "/hosts"),
+		[]byte(slirpIP+" "+containerHandle+"\n"),
+	)
+}
+
+func (n slirpNetwork) startSlirp(taskPid uint32) (int, error) {
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return 0, fmt.Errorf("ready pipe: %w", err)
+	}
+	defer readyR.Close()
+
+	args := []string{
+		"--configure",
+		"--mtu=" + strconv.Itoa(slirpMTU),
+		"--ready-fd=3",
+		fmt.Sprintf("--userns-path=/proc/%d/ns/user", taskPid),
+	}
+
+	if !n.allowHostAccess {
+		args = append(args, "--disable-host-loopback")
+	}
+
+	args = append(args, strconv.Itoa(int(taskPid)), "tap0")
+
+	cmd := exec.Command(n.binary, args...)
+	cmd.ExtraFiles = []*os.File{readyW}
+
+	// keep the process out of the worker's process group so that it outlives
+	// restarts of the worker, like the container does
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	err = cmd.Start()
+	readyW.Close()
+	if err != nil {
+		return 0, fmt.Errorf("start: %w", err)
+	}
+
+	go cmd.Wait()
+
+	ready := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 1)
+		_, err := readyR.Read(buf)
+		ready <- err
+	}()
+
+	select {
+	case err = <-ready:
+	case <-time.After(slirpReadyTimeout):
+		err = errors.New("timed out")
+	}
+
+	if err != nil {
+		_ = cmd.Process.Kill()
+		return 0, fmt.Errorf("waiting for network to be configured: %w", err)
+	}
+
+	return cmd.Process.Pid, nil
+}
+
+func (n slirpNetwork) Remove(ctx context.Context, task containerd.Task, handle string) error {
+	if task == nil {
+		return ErrInvalidInput("nil task")
+	}
+
+	content, err := n.store.Read(filepath.Join(handle, "/slirp.pid"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("reading slirp4netns pid: %w", err)
+	}
+
+	if err == nil {
+		pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+		if err != nil {
+			return fmt.Errorf("parsing slirp4netns pid: %w", err)
+		}
+
+		// slirp4netns exits by itself once the network namespace is gone,
+		// so it having exited already is fine
+		err = syscall.Kill(pid, syscall.SIGTERM)
+		if err != nil && !errors.Is(err, syscall.ESRCH) {
+			return fmt.Errorf("stopping slirp4netns: %w", err)
+		}
+	}
+
+	err = n.store.Delete(handle)
+	if err != nil {
+		return fmt.Errorf("slirp network mounts teardown: %w", err)
+	}
+
+	return nil
+}
+
+// NetOut fails when given any rules, as slirp4netns has no way of filtering
+// the traffic of a container. The ATC doesn't place containers with egress
+// rules on rootless workers, so this only guards against older ATCs.
+//
+func (n slirpNetwork) NetOut(handle string, rules []garden.NetOutRule) error {
+	if handle == "" {
+		return ErrInvalidInput("empty handle")
+	}
+
+	if len(rules) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("egress rules are not supported by rootless workers")
+}
+
+// Rename names the container after the given host name in its /etc/hosts and
+// /etc/hostname, the same way as on CNI networks.
+//
+func (n slirpNetwork) Rename(handle string, hostname string) error {
+	if handle == "" || hostname == "" {
+		return ErrInvalidInput("empty handle or hostname")
+	}
+
+	return renameNetworkHost(n.store, handle, hostname)
+}
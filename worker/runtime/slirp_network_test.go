@@ -0,0 +1,138 @@
+package runtime_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
+	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type SlirpNetworkSuite struct {
+	suite.Suite
+	*require.Assertions
+
+	network runtime.Network
+	store   *runtimefakes.FakeFileStore
+	tmpDir  string
+}
+
+// fakeSlirp stands in for slirp4netns, recording its arguments and
+// signalling readiness before waiting around like the real one does.
+//
+const fakeSlirp = `#!/bin/sh
+echo "$@" > "$(dirname "$0")/args"
+printf 1 >&3
+exec sleep 60
+`
+
+func (s *SlirpNetworkSuite) SetupTest() {
+	var err error
+
+	s.tmpDir, err = ioutil.TempDir(os.TempDir(), "slirp-network")
+	s.NoError(err)
+
+	err = ioutil.WriteFile(filepath.Join(s.tmpDir, "slirp4netns"), []byte(fakeSlirp), 0755)
+	s.NoError(err)
+
+	s.store = new(runtimefakes.FakeFileStore)
+
+	s.network, err = runtime.NewSlirpNetwork(
+		runtime.WithSlirpBinary(filepath.Join(s.tmpDir, "slirp4netns")),
+		runtime.WithSlirpFileStore(s.store),
+	)
+	s.NoError(err)
+}
+
+func (s *SlirpNetworkSuite) TearDownTest() {
+	os.RemoveAll(s.tmpDir)
+}
+
+func (s *SlirpNetworkSuite) TestSetupMountsUsesSlirpDNS() {
+	_, err := s.network.SetupMounts("some-handle")
+	s.NoError(err)
+
+	s.Equal(3, s.store.CreateCallCount())
+	name, content := s.store.CreateArgsForCall(2)
+	s.Equal("some-handle/resolv.conf", name)
+	s.Equal("nameserver 10.0.2.3\n", string(content))
+}
+
+func (s *SlirpNetworkSuite) TestSetupMountsWithNameServers() {
+	network, err := runtime.NewSlirpNetwork(
+		runtime.WithSlirpFileStore(s.store),
+		runtime.WithSlirpNameServers([]string{"8.8.8.8"}),
+	)
+	s.NoError(err)
+
+	_, err = network.SetupMounts("some-handle")
+	s.NoError(err)
+
+	_, content := s.store.CreateArgsForCall(2)
+	s.Equal("nameserver 8.8.8.8\n", string(content))
+}
+
+func (s *SlirpNetworkSuite) TestAddAndRemove() {
+	task := new(libcontainerdfakes.FakeTask)
+	task.PidReturns(123)
+
+	err := s.network.Add(context.Background(), task, "some-handle")
+	s.NoError(err)
+
+	args, err := ioutil.ReadFile(filepath.Join(s.tmpDir, "args"))
+	s.NoError(err)
+	s.Equal(
+		"--configure --mtu=65520 --ready-fd=3 --userns-path=/proc/123/ns/user --disable-host-loopback 123 tap0\n",
+		string(args),
+	)
+
+	s.Equal(1, s.store.CreateCallCount())
+	name, content := s.store.CreateArgsForCall(0)
+	s.Equal("some-handle/slirp.pid", name)
+
+	pid, err := strconv.Atoi(string(content))
+	s.NoError(err)
+	s.NoError(syscall.Kill(pid, 0))
+
+	name, content = s.store.AppendArgsForCall(0)
+	s.Equal("some-handle/hosts", name)
+	s.Equal("10.0.2.100 some-handle\n", string(content))
+
+	s.store.ReadReturns([]byte(strconv.Itoa(pid)), nil)
+
+	err = s.network.Remove(context.Background(), task, "some-handle")
+	s.NoError(err)
+
+	s.Eventually(func() bool {
+		return syscall.Kill(pid, 0) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	s.Equal("some-handle", s.store.DeleteArgsForCall(0))
+}
+
+func (s *SlirpNetworkSuite) TestAddFailsWhenSlirpExits() {
+	err := ioutil.WriteFile(filepath.Join(s.tmpDir, "slirp4netns"), []byte("#!/bin/sh\nexit 1\n"), 0755)
+	s.NoError(err)
+
+	err = s.network.Add(context.Background(), new(libcontainerdfakes.FakeTask), "some-handle")
+	s.Error(err)
+	s.True(strings.HasPrefix(err.Error(), "slirp4netns: waiting for network to be configured"))
+	s.Equal(0, s.store.CreateCallCount())
+}
+
+func (s *SlirpNetworkSuite) TestNetOutRejectsRules() {
+	s.NoError(s.network.NetOut("some-handle", nil))
+
+	err := s.network.NetOut("some-handle", []garden.NetOutRule{{}})
+	s.EqualError(err, "egress rules are not supported by rootless workers")
+}
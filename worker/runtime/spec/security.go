@@ -0,0 +1,200 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"code.cloudfoundry.org/garden"
+	atcruntime "github.com/concourse/concourse/atc/runtime"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/sys/unix"
+)
+
+// capabilityAll drops every capability when given as one to drop.
+//
+const capabilityAll = "ALL"
+
+// Security grants a container fine-grained privileges on top of (or takes
+// them away from) those of an unprivileged container.
+//
+type Security struct {
+	Capabilities   SecurityCapabilities `json:"capabilities"`
+	Devices        []string             `json:"devices"`
+	Seccomp        string               `json:"seccomp"`
+	ReadOnlyRootfs bool                 `json:"readonly_rootfs"`
+}
+
+// SecurityCapabilities lists Linux capabilities (e.g. CAP_SYS_PTRACE) to add
+// to or drop from the default set of a container.
+//
+type SecurityCapabilities struct {
+	Add  []string `json:"add"`
+	Drop []string `json:"drop"`
+}
+
+// SecurityAllowList lists the privileges that containers may be granted
+// through their security config.
+//
+type SecurityAllowList struct {
+	Capabilities []string
+	Devices      []string
+
+	// SeccompProfiles are the profiles that containers may use instead of
+	// the default one, by name.
+	//
+	SeccompProfiles map[string]*specs.LinuxSeccomp
+}
+
+// OciSecurity applies the security config found in the properties of a
+// container (if any) to its OCI spec, failing if it grants privileges that
+// aren't in the allow-list.
+//
+func OciSecurity(oci *specs.Spec, properties garden.Properties, allowList SecurityAllowList) error {
+	raw, found := properties[atcruntime.SecurityPropertyName]
+	if !found {
+		return nil
+	}
+
+	var security Security
+	err := json.Unmarshal([]byte(raw), &security)
+	if err != nil {
+		return fmt.Errorf("parse security config: %w", err)
+	}
+
+	for _, capability := range security.Capabilities.Add {
+		if !contains(allowList.Capabilities, capability) {
+			return fmt.Errorf("capability %s is not allowed", capability)
+		}
+	}
+
+	for _, device := range security.Devices {
+		if !contains(allowList.Devices, device) {
+			return fmt.Errorf("device %s is not allowed", device)
+		}
+	}
+
+	var seccompProfile *specs.LinuxSeccomp
+	if security.Seccomp != "" {
+		seccompProfile, found = allowList.SeccompProfiles[security.Seccomp]
+		if !found {
+			return fmt.Errorf("seccomp profile %s is not allowed", security.Seccomp)
+		}
+	}
+
+	oci.Process.Capabilities = ociSecurityCapabilities(oci.Process.Capabilities, security.Capabilities)
+
+	for _, path := range security.Devices {
+		err = addDevice(oci, path)
+		if err != nil {
+			return fmt.Errorf("device %s: %w", path, err)
+		}
+	}
+
+	if seccompProfile != nil {
+		oci.Linux.Seccomp = seccompProfile
+	}
+
+	if security.ReadOnlyRootfs {
+		oci.Root.Readonly = true
+	}
+
+	return nil
+}
+
+// ociSecurityCapabilities drops and then adds capabilities to every set of
+// the default capabilities. New slices are built as the defaults are shared
+// between containers.
+//
+func ociSecurityCapabilities(defaults *specs.LinuxCapabilities, capabilities SecurityCapabilities) *specs.LinuxCapabilities {
+	var caps []string
+	if !contains(capabilities.Drop, capabilityAll) {
+		for _, capability := range defaults.Bounding {
+			if !contains(capabilities.Drop, capability) {
+				caps = append(caps, capability)
+			}
+		}
+	}
+
+	for _, capability := range capabilities.Add {
+		if !contains(caps, capability) {
+			caps = append(caps, capability)
+		}
+	}
+
+	return &specs.LinuxCapabilities{
+		Effective:   caps,
+		Bounding:    caps,
+		Inheritable: caps,
+		Permitted:   caps,
+	}
+}
+
+// addDevice creates the host's device node at path in the container and
+// allows the container to use it.
+//
+func addDevice(oci *specs.Spec, path string) error {
+	var stat unix.Stat_t
+	err := unix.Stat(path, &stat)
+	if err != nil {
+		return err
+	}
+
+	var deviceType string
+	switch stat.Mode & unix.S_IFMT {
+	case unix.S_IFCHR:
+		deviceType = "c"
+	case unix.S_IFBLK:
+		deviceType = "b"
+	default:
+		return fmt.Errorf("not a device")
+	}
+
+	var (
+		major    = int64(unix.Major(uint64(stat.Rdev)))
+		minor    = int64(unix.Minor(uint64(stat.Rdev)))
+		fileMode = os.FileMode(stat.Mode &^ unix.S_IFMT)
+	)
+
+	for _, device := range oci.Linux.Devices {
+		if device.Path == path {
+			return nil
+		}
+	}
+
+	oci.Linux.Devices = append(oci.Linux.Devices, specs.LinuxDevice{
+		Path:     path,
+		Type:     deviceType,
+		Major:    major,
+		Minor:    minor,
+		FileMode: &fileMode,
+	})
+
+	if oci.Linux.Resources == nil {
+		oci.Linux.Resources = &specs.LinuxResources{}
+	}
+
+	// the default rules are shared between containers
+	rules := make([]specs.LinuxDeviceCgroup, len(oci.Linux.Resources.Devices), len(oci.Linux.Resources.Devices)+1)
+	copy(rules, oci.Linux.Resources.Devices)
+
+	oci.Linux.Resources.Devices = append(rules, specs.LinuxDeviceCgroup{
+		Allow:  true,
+		Type:   deviceType,
+		Major:  &major,
+		Minor:  &minor,
+		Access: "rwm",
+	})
+
+	return nil
+}
+
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"code.cloudfoundry.org/garden"
@@ -13,6 +14,11 @@ import (
 
 const baseCgroupsPath = "garden"
 
+// DiskLimitAnnotation is the annotation under which the disk limit of a
+// container is recorded, as there's no equivalent in the OCI spec.
+//
+const DiskLimitAnnotation = "concourse.disk-limit"
+
 var isSwapLimitEnabled bool
 
 func init() {
@@ -38,7 +44,7 @@ func OciSpec(initBinPath string, gdn garden.ContainerSpec, maxUid, maxGid uint32
 	}
 
 	var rootfs string
-	rootfs, err = rootfsDir(gdn.RootFSPath)
+	rootfs, err = RootfsDir(gdn.RootFSPath)
 	if err != nil {
 		return
 	}
@@ -51,6 +57,7 @@ func OciSpec(initBinPath string, gdn garden.ContainerSpec, maxUid, maxGid uint32
 
 	resources := OciResources(gdn.Limits, isSwapLimitEnabled)
 	cgroupsPath := OciCgroupsPath(baseCgroupsPath, gdn.Handle, gdn.Privileged)
+	annotations := OciAnnotations(gdn.Properties, gdn.Limits.Disk)
 
 	oci = merge(
 		defaultGardenOciSpec(initBinPath, gdn.Privileged, maxUid, maxGid),
@@ -62,7 +69,7 @@ func OciSpec(initBinPath string, gdn garden.ContainerSpec, maxUid, maxGid uint32
 			},
 			Root:        &specs.Root{Path: rootfs},
 			Mounts:      mounts,
-			Annotations: map[string]string(gdn.Properties),
+			Annotations: annotations,
 			Linux: &specs.Linux{
 				Resources:   resources,
 				CgroupsPath: cgroupsPath,
@@ -73,6 +80,24 @@ func OciSpec(initBinPath string, gdn garden.ContainerSpec, maxUid, maxGid uint32
 	return
 }
 
+// OciAnnotations converts garden properties to oci spec annotations, adding
+// the disk limit (if any) so that it can be retrieved later.
+//
+func OciAnnotations(properties garden.Properties, disk garden.DiskLimits) map[string]string {
+	if disk.ByteHard == 0 {
+		return map[string]string(properties)
+	}
+
+	annotations := make(map[string]string, len(properties)+1)
+	for k, v := range properties {
+		annotations[k] = v
+	}
+
+	annotations[DiskLimitAnnotation] = strconv.FormatUint(disk.ByteHard, 10)
+
+	return annotations
+}
+
 // OciSpecBindMounts converts garden bindmounts to oci spec mounts.
 //
 func OciSpecBindMounts(bindMounts []garden.BindMount) (mounts []specs.Mount, err error) {
@@ -179,6 +204,39 @@ func OciResources(limits garden.Limits, swapLimitEnabled bool) *specs.LinuxResou
 	}
 }
 
+// OciClaim applies the handle, env, bind mounts and limits of a container
+// spec to the OCI spec of a running container, for when a container of the
+// warm pool is claimed. The hostname and bind mounts are only recorded, as
+// those of a running container can't be changed through its spec. The
+// resources to update the container's task with are returned, if any.
+//
+func OciClaim(oci *specs.Spec, gdn garden.ContainerSpec) (*specs.LinuxResources, error) {
+	mounts, err := OciSpecBindMounts(gdn.BindMounts)
+	if err != nil {
+		return nil, err
+	}
+
+	oci.Hostname = gdn.Handle
+	oci.Mounts = append(oci.Mounts, mounts...)
+	oci.Process.Env = append(oci.Process.Env, gdn.Env...)
+
+	limits := OciResources(gdn.Limits, isSwapLimitEnabled)
+	if limits == nil {
+		return nil, nil
+	}
+
+	if oci.Linux.Resources == nil {
+		oci.Linux.Resources = &specs.LinuxResources{}
+	}
+
+	// keep the device rules of the container
+	oci.Linux.Resources.CPU = limits.CPU
+	oci.Linux.Resources.Memory = limits.Memory
+	oci.Linux.Resources.Pids = limits.Pids
+
+	return oci.Linux.Resources, nil
+}
+
 func OciCgroupsPath(basePath, handle string, privileged bool) string {
 	if privileged {
 		return ""
@@ -237,10 +295,10 @@ func merge(dst, src *specs.Spec) *specs.Spec {
 	return dst
 }
 
-// rootfsDir takes a raw rootfs uri and extracts the directory that it points to,
+// RootfsDir takes a raw rootfs uri and extracts the directory that it points to,
 // if using a valid scheme (`raw://`)
 //
-func rootfsDir(raw string) (directory string, err error) {
+func RootfsDir(raw string) (directory string, err error) {
 	if raw == "" {
 		err = fmt.Errorf("rootfs must not be empty")
 		return
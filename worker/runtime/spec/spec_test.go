@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"code.cloudfoundry.org/garden"
+	atcruntime "github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/worker/runtime/spec"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/require"
@@ -364,6 +365,114 @@ func (s *SpecSuite) TestOciCgroupsPath() {
 	}
 }
 
+func (s *SpecSuite) TestOciAnnotations() {
+	for _, tc := range []struct {
+		desc       string
+		properties garden.Properties
+		disk       garden.DiskLimits
+		expected   map[string]string
+	}{
+		{
+			desc:       "without a disk limit",
+			properties: garden.Properties{"foo": "bar"},
+			expected:   map[string]string{"foo": "bar"},
+		},
+		{
+			desc:       "with a disk limit",
+			properties: garden.Properties{"foo": "bar"},
+			disk:       garden.DiskLimits{ByteHard: 1024},
+			expected:   map[string]string{"foo": "bar", spec.DiskLimitAnnotation: "1024"},
+		},
+	} {
+		s.T().Run(tc.desc, func(t *testing.T) {
+			s.Equal(tc.expected, spec.OciAnnotations(tc.properties, tc.disk))
+		})
+	}
+
+	properties := garden.Properties{"foo": "bar"}
+	spec.OciAnnotations(properties, garden.DiskLimits{ByteHard: 1024})
+	s.Equal(garden.Properties{"foo": "bar"}, properties)
+}
+
+func (s *SpecSuite) TestOciClaim() {
+	devices := []specs.LinuxDeviceCgroup{{Allow: false, Access: "rwm"}}
+
+	s.T().Run("without limits", func(t *testing.T) {
+		oci := &specs.Spec{
+			Process: &specs.Process{Env: []string{"FOO=bar"}},
+			Linux:   &specs.Linux{Resources: &specs.LinuxResources{Devices: devices}},
+		}
+
+		resources, err := spec.OciClaim(oci, garden.ContainerSpec{
+			Handle: "handle",
+			Env:    []string{"BAR=baz"},
+		})
+		s.NoError(err)
+		s.Nil(resources)
+		s.Equal("handle", oci.Hostname)
+		s.Equal([]string{"FOO=bar", "BAR=baz"}, oci.Process.Env)
+		s.Equal(&specs.LinuxResources{Devices: devices}, oci.Linux.Resources)
+	})
+
+	s.T().Run("with bind mounts", func(t *testing.T) {
+		oci := &specs.Spec{
+			Process: &specs.Process{},
+			Mounts:  []specs.Mount{{Destination: "/etc/ssl/certs"}},
+			Linux:   &specs.Linux{},
+		}
+
+		_, err := spec.OciClaim(oci, garden.ContainerSpec{
+			BindMounts: []garden.BindMount{
+				{SrcPath: "/volumes/input", DstPath: "/tmp/build/input", Mode: garden.BindMountModeRW},
+			},
+		})
+		s.NoError(err)
+		s.Equal([]specs.Mount{
+			{Destination: "/etc/ssl/certs"},
+			{
+				Source:      "/volumes/input",
+				Destination: "/tmp/build/input",
+				Type:        "bind",
+				Options:     []string{"bind", "rw"},
+			},
+		}, oci.Mounts)
+	})
+
+	s.T().Run("with invalid bind mounts", func(t *testing.T) {
+		oci := &specs.Spec{Process: &specs.Process{}, Linux: &specs.Linux{}}
+
+		_, err := spec.OciClaim(oci, garden.ContainerSpec{
+			BindMounts: []garden.BindMount{{SrcPath: "relative", DstPath: "/dst"}},
+		})
+		s.Error(err)
+	})
+
+	s.T().Run("with limits", func(t *testing.T) {
+		oci := &specs.Spec{
+			Process: &specs.Process{},
+			Linux:   &specs.Linux{Resources: &specs.LinuxResources{Devices: devices}},
+		}
+
+		resources, err := spec.OciClaim(oci, garden.ContainerSpec{
+			Limits: garden.Limits{
+				CPU: garden.CPULimits{Weight: 512},
+				Pid: garden.PidLimits{Max: 100},
+			},
+		})
+
+		s.NoError(err)
+
+		shares := uint64(512)
+		expected := &specs.LinuxResources{
+			Devices: devices,
+			CPU:     &specs.LinuxCPU{Shares: &shares},
+			Pids:    &specs.LinuxPids{Limit: 100},
+		}
+		s.Equal(expected, resources)
+		s.Equal(expected, oci.Linux.Resources)
+	})
+}
+
 func (s *SpecSuite) TestContainerSpec() {
 	var minimalContainerSpec = garden.ContainerSpec{
 		Handle: "handle", RootFSPath: "raw:///rootfs",
@@ -527,3 +636,119 @@ func (s *SpecSuite) TestContainerSpec() {
 		})
 	}
 }
+
+func (s *SpecSuite) TestOciSecurity() {
+	profile := &specs.LinuxSeccomp{DefaultAction: specs.ActAllow}
+
+	allowList := spec.SecurityAllowList{
+		Capabilities:    []string{"CAP_SYS_PTRACE"},
+		Devices:         []string{"/dev/null"},
+		SeccompProfiles: map[string]*specs.LinuxSeccomp{"unconfined": profile},
+	}
+
+	for _, tc := range []struct {
+		desc     string
+		security string
+		err      string
+		check    func(*specs.Spec)
+	}{
+		{
+			desc: "no security config",
+			check: func(oci *specs.Spec) {
+				s.Equal(&spec.UnprivilegedContainerCapabilities, oci.Process.Capabilities)
+				s.False(oci.Root.Readonly)
+			},
+		},
+		{
+			desc:     "capabilities",
+			security: `{"capabilities":{"add":["CAP_SYS_PTRACE"],"drop":["CAP_NET_RAW","CAP_MKNOD"]}}`,
+			check: func(oci *specs.Spec) {
+				caps := oci.Process.Capabilities
+				s.Contains(caps.Bounding, "CAP_SYS_PTRACE")
+				s.Contains(caps.Bounding, "CAP_CHOWN")
+				s.NotContains(caps.Bounding, "CAP_NET_RAW")
+				s.NotContains(caps.Bounding, "CAP_MKNOD")
+				s.Equal(caps.Bounding, caps.Effective)
+				s.Equal(caps.Bounding, caps.Inheritable)
+				s.Equal(caps.Bounding, caps.Permitted)
+
+				s.NotContains(spec.UnprivilegedContainerCapabilities.Bounding, "CAP_SYS_PTRACE",
+					"the default capabilities should be left alone")
+			},
+		},
+		{
+			desc:     "dropping all capabilities",
+			security: `{"capabilities":{"add":["CAP_SYS_PTRACE"],"drop":["ALL"]}}`,
+			check: func(oci *specs.Spec) {
+				s.Equal([]string{"CAP_SYS_PTRACE"}, oci.Process.Capabilities.Bounding)
+			},
+		},
+		{
+			desc:     "devices",
+			security: `{"devices":["/dev/null"]}`,
+			check: func(oci *specs.Spec) {
+				s.Len(oci.Linux.Devices, 1)
+				s.Equal("/dev/null", oci.Linux.Devices[0].Path)
+				s.Equal("c", oci.Linux.Devices[0].Type)
+				s.Equal(int64(1), oci.Linux.Devices[0].Major)
+				s.Equal(int64(3), oci.Linux.Devices[0].Minor)
+
+				s.Equal(specs.LinuxDeviceCgroup{
+					Allow: true, Type: "c", Major: int64Ptr(1), Minor: int64Ptr(3), Access: "rwm",
+				}, oci.Linux.Resources.Devices[len(oci.Linux.Resources.Devices)-1])
+				s.Len(spec.AnyContainerDevices, 1, "the default device rules should be left alone")
+			},
+		},
+		{
+			desc:     "seccomp profile",
+			security: `{"seccomp":"unconfined"}`,
+			check: func(oci *specs.Spec) {
+				s.Equal(profile, oci.Linux.Seccomp)
+			},
+		},
+		{
+			desc:     "read-only rootfs",
+			security: `{"readonly_rootfs":true}`,
+			check: func(oci *specs.Spec) {
+				s.True(oci.Root.Readonly)
+			},
+		},
+		{
+			desc:     "capability not in the allow-list",
+			security: `{"capabilities":{"add":["CAP_SYS_ADMIN"]}}`,
+			err:      "capability CAP_SYS_ADMIN is not allowed",
+		},
+		{
+			desc:     "device not in the allow-list",
+			security: `{"devices":["/dev/kvm"]}`,
+			err:      "device /dev/kvm is not allowed",
+		},
+		{
+			desc:     "unknown seccomp profile",
+			security: `{"seccomp":"other"}`,
+			err:      "seccomp profile other is not allowed",
+		},
+	} {
+		s.T().Run(tc.desc, func(t *testing.T) {
+			gdn := garden.ContainerSpec{
+				Handle: "handle", RootFSPath: "raw:///rootfs",
+				Properties: garden.Properties{},
+			}
+			if tc.security != "" {
+				gdn.Properties[atcruntime.SecurityPropertyName] = tc.security
+			}
+
+			oci, err := spec.OciSpec(spec.DefaultInitBinPath, gdn, dummyMaxUid, dummyMaxGid)
+			s.NoError(err)
+
+			err = spec.OciSecurity(oci, gdn.Properties, allowList)
+			if tc.err != "" {
+				s.EqualError(err, tc.err)
+				return
+			}
+
+			s.NoError(err)
+			tc.check(oci)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 )
 
 const (
@@ -30,10 +31,20 @@ func (s *userNamespace) MaxValidIds() (uint32, uint32, error) {
 	return maxValidUid, maxValidGid, nil
 }
 
-func maxValidFromFile(fname string) (uint32, error) {
+func (s *userNamespace) Rootless() (bool, error) {
 	f, err := os.Open(uidMap)
 	if err != nil {
-		return 0, fmt.Errorf("open %s: %w", uidMap, err)
+		return false, fmt.Errorf("open %s: %w", uidMap, err)
+	}
+	defer f.Close()
+
+	return RootMappedToUnprivileged(f)
+}
+
+func maxValidFromFile(fname string) (uint32, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return 0, fmt.Errorf("open %s: %w", fname, err)
 	}
 	defer f.Close()
 
@@ -113,6 +124,91 @@ func MaxValid(r io.Reader) (uint32, error) {
 	return val, nil
 }
 
+// RootMappedToUnprivileged determines from a permission map (see MaxValid)
+// whether root inside the user namespace is an unprivileged user outside of
+// it, as is the case for a worker running rootless.
+//
+// For example, given the following mapping from /proc/self/uid_map:
+//
+// 	0 1001 1
+// 	1 100000 65536
+//
+// root (0) is user 1001 outside of the namespace, and so it is unprivileged.
+//
+func RootMappedToUnprivileged(r io.Reader) (bool, error) {
+	scanner := bufio.NewScanner(r)
+
+	var (
+		inside, outside, size uint32
+		lines                 uint32
+	)
+
+	for scanner.Scan() {
+		_, err := fmt.Sscanf(
+			scanner.Text(),
+			"%d %d %d",
+			&inside, &outside, &size,
+		)
+		if err != nil {
+			return false, fmt.Errorf("scanf: %w", err)
+		}
+
+		lines++
+
+		if inside == 0 && size > 0 {
+			return outside != 0, nil
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return false, fmt.Errorf("scanning: %w", err)
+	}
+
+	if lines == 0 {
+		return false, fmt.Errorf("empty reader")
+	}
+
+	// root isn't mapped at all, so it can't be privileged outside either
+	return true, nil
+}
+
+// SubIDRange finds the range of subordinate ids that a file in the format of
+// /etc/subuid or /etc/subgid delegates to a user, identified by either their
+// name or id.
+//
+// For example, given the following entry:
+//
+// 	concourse:100000:65536
+//
+// the user `concourse` may map the 65536 ids starting at 100000 into the user
+// namespaces it creates.
+//
+func SubIDRange(r io.Reader, name, id string) (start, count uint32, found bool, err error) {
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		fields := strings.Split(strings.TrimSpace(scanner.Text()), ":")
+		if len(fields) != 3 || (fields[0] != name && fields[0] != id) {
+			continue
+		}
+
+		_, err = fmt.Sscanf(fields[1]+" "+fields[2], "%d %d", &start, &count)
+		if err != nil {
+			return 0, 0, false, fmt.Errorf("scanf: %w", err)
+		}
+
+		return start, count, true, nil
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("scanning: %w", err)
+	}
+
+	return 0, 0, false, nil
+}
+
 func maxUint(a, b uint32) uint32 {
 	if a > b {
 		return a
@@ -68,3 +68,96 @@ func (s *UserNamespaceSuite) TestMaxValid() {
 		})
 	}
 }
+
+func (s *UserNamespaceSuite) TestRootMappedToUnprivileged() {
+	for _, tc := range []struct {
+		desc      string
+		input     string
+		shouldErr bool
+		rootless  bool
+	}{
+		{
+			desc:      "empty input",
+			shouldErr: true,
+		},
+		{
+			desc:     "initial namespace",
+			input:    "0 0 4294967295",
+			rootless: false,
+		},
+		{
+			desc:     "root mapped to an unprivileged user",
+			input:    "0 1001 1\n1 100000 65536",
+			rootless: true,
+		},
+		{
+			desc:     "root mapped after other ranges",
+			input:    "1 100000 65536\n0 1001 1",
+			rootless: true,
+		},
+		{
+			desc:     "root not mapped",
+			input:    "1 100000 65536",
+			rootless: true,
+		},
+	} {
+		s.T().Run(tc.desc, func(t *testing.T) {
+			res, err := runtime.RootMappedToUnprivileged(bytes.NewBufferString(tc.input))
+			if tc.shouldErr {
+				s.Error(err)
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(tc.rootless, res)
+		})
+	}
+}
+
+func (s *UserNamespaceSuite) TestSubIDRange() {
+	const subuid = `someone-else:100000:65536
+concourse:165536:65536
+1001:231072:65536
+`
+
+	for _, tc := range []struct {
+		desc     string
+		name, id string
+		start    uint32
+		count    uint32
+		found    bool
+	}{
+		{
+			desc:  "matched by name",
+			name:  "concourse",
+			id:    "1000",
+			start: 165536,
+			count: 65536,
+			found: true,
+		},
+		{
+			desc:  "matched by id",
+			name:  "worker",
+			id:    "1001",
+			start: 231072,
+			count: 65536,
+			found: true,
+		},
+		{
+			desc: "not delegated",
+			name: "worker",
+			id:   "1002",
+		},
+	} {
+		s.T().Run(tc.desc, func(t *testing.T) {
+			start, count, found, err := runtime.SubIDRange(bytes.NewBufferString(subuid), tc.name, tc.id)
+			s.NoError(err)
+			s.Equal(tc.found, found)
+			s.Equal(tc.start, start)
+			s.Equal(tc.count, count)
+		})
+	}
+
+	_, _, _, err := runtime.SubIDRange(bytes.NewBufferString("concourse:a:b"), "concourse", "1000")
+	s.Error(err)
+}
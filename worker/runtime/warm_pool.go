@@ -0,0 +1,496 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	atcruntime "github.com/concourse/concourse/atc/runtime"
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/typeurl"
+	uuid "github.com/nu7hatch/gouuid"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// claimedHandleProperty is set on claimed containers to the handle they were
+// claimed as, since the IDs of containerd containers can't change.
+//
+const claimedHandleProperty = "concourse:claimed-handle"
+
+// ErrWarmPoolEmpty indicates that there's no container of the image waiting
+// in the warm pool. The container should be created as usual instead.
+//
+var ErrWarmPoolEmpty = errors.New("warm pool empty")
+
+//counterfeiter:generate . WarmPoolRootfs
+
+// WarmPoolRootfs provides the root filesystems of the containers in the warm
+// pool.
+//
+type WarmPoolRootfs interface {
+	// Create sets up a root filesystem of the image for the container with
+	// the given handle, returning its URI and whether the image must be run
+	// privileged. The image is either a base resource type or the image of a
+	// task (see atcruntime.WarmTaskImage).
+	//
+	Create(handle string, image string) (uri string, privileged bool, err error)
+
+	// Destroy removes the root filesystem of the container with the given
+	// handle.
+	//
+	Destroy(handle string) error
+
+	// Release removes anything kept to set up root filesystems of the image
+	// of a task, once it's no longer pooled.
+	//
+	Release(image string) error
+}
+
+//counterfeiter:generate . WarmPoolNamespaces
+
+// WarmPoolNamespaces changes what the processes of a running container see,
+// for it to become the container that it's claimed as.
+//
+type WarmPoolNamespaces interface {
+	// ShareRootfs makes a root filesystem a shared mount before a container
+	// gets started with it, so that mounts made under it later on show up in
+	// the container.
+	//
+	ShareRootfs(rootfs string) error
+
+	// ReleaseRootfs undoes ShareRootfs, unmounting whatever got mounted
+	// under the root filesystem since.
+	//
+	ReleaseRootfs(rootfs string) error
+
+	// BindMount mounts a directory of the host into a container through its
+	// shared root filesystem. Directories missing on the way to the mount
+	// point are created owned by the given user and group, i.e. the root
+	// user of the container.
+	//
+	BindMount(rootfs string, mount garden.BindMount, uid, gid uint32) error
+
+	// SetHostname sets the host name of the UTS namespace of a process.
+	//
+	SetHostname(pid uint32, hostname string) error
+}
+
+// WarmPool configures the backend to keep containers of the given images
+// created and running ahead of time, so that they can be handed out without
+// the latency of creating them.
+//
+// A claimed container is never returned to the pool: it gets destroyed just
+// like any other container once it's no longer needed.
+//
+type WarmPool struct {
+	// Images are the names of the images to keep containers of.
+	//
+	Images []string
+
+	// TaskImages keeps containers of the images of tasks marked `warm: true`
+	// too. The worker can't know these images ahead of time, so it learns
+	// them: claiming a container of an image that has none waiting gets it
+	// pooled from the next fill on, until it hasn't been claimed for
+	// TaskImageTTL.
+	//
+	TaskImages   bool
+	TaskImageTTL time.Duration
+
+	// Size is the number of containers to keep waiting for each image.
+	//
+	Size int
+
+	// Rootfs provides the root filesystems of the containers.
+	//
+	Rootfs WarmPoolRootfs
+
+	// Namespaces applies the volumes and host name of the container that a
+	// container of the pool is claimed as. Defaults to changing the
+	// namespaces from the host.
+	//
+	Namespaces WarmPoolNamespaces
+
+	// BindMounts are mounted into every container of the pool, e.g. the
+	// certificates of the worker.
+	//
+	BindMounts []garden.BindMount
+
+	// taskImages are the images of tasks currently pooled, along with when
+	// they were last claimed. They are guarded by the claim lock of the
+	// backend.
+	//
+	taskImages map[string]time.Time
+}
+
+func (pool *WarmPool) pools(image string) bool {
+	if pool == nil {
+		return false
+	}
+
+	if _, _, isTask := atcruntime.ParseWarmTaskImage(image); isTask {
+		return pool.TaskImages
+	}
+
+	for _, pooled := range pool.Images {
+		if pooled == image {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FillWarmPool creates as many containers as necessary for the warm pool to
+// have Size containers waiting for each image. The images of tasks that
+// haven't been claimed for a while leave the pool, along with their waiting
+// containers.
+//
+func (b *GardenBackend) FillWarmPool() error {
+	if b.warmPool == nil {
+		return nil
+	}
+
+	ctx := context.Background()
+
+	images, expired, err := b.warmImages(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, image := range expired {
+		err = b.drainWarmPool(ctx, image)
+		if err != nil {
+			return fmt.Errorf("drain warm containers of %s: %w", image, err)
+		}
+	}
+
+	for _, image := range images {
+		waiting, err := b.warmContainers(ctx, image)
+		if err != nil {
+			return fmt.Errorf("list warm containers of %s: %w", image, err)
+		}
+
+		for i := len(waiting); i < b.warmPool.Size; i++ {
+			err = b.createWarmContainer(ctx, image)
+			if err != nil {
+				return fmt.Errorf("create warm container of %s: %w", image, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// warmImages returns the images to keep containers of, and the images of
+// tasks which have expired since the last fill.
+//
+// The images of tasks are only known in memory, so on the first fill they're
+// recovered from the containers left waiting by a previous run of the worker.
+//
+func (b *GardenBackend) warmImages(ctx context.Context) (images []string, expired []string, err error) {
+	b.claimLock.Lock()
+	defer b.claimLock.Unlock()
+
+	images = append(images, b.warmPool.Images...)
+
+	if !b.warmPool.TaskImages {
+		return images, nil, nil
+	}
+
+	if b.warmPool.taskImages == nil {
+		b.warmPool.taskImages = map[string]time.Time{}
+
+		waiting, err := b.client.Containers(ctx, `labels."`+atcruntime.WarmPoolPropertyName+`.0"`)
+		if err != nil {
+			return nil, nil, fmt.Errorf("list warm containers: %w", err)
+		}
+
+		for _, cont := range waiting {
+			labels, err := cont.Labels(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("container labels: %w", err)
+			}
+
+			image := labelsToProperties(labels)[atcruntime.WarmPoolPropertyName]
+			if _, _, isTask := atcruntime.ParseWarmTaskImage(image); isTask {
+				b.warmPool.taskImages[image] = time.Now()
+			}
+		}
+	}
+
+	for image, claimed := range b.warmPool.taskImages {
+		if time.Since(claimed) > b.warmPool.TaskImageTTL {
+			expired = append(expired, image)
+		} else {
+			images = append(images, image)
+		}
+	}
+
+	sort.Strings(images)
+	sort.Strings(expired)
+
+	return images, expired, nil
+}
+
+// drainWarmPool destroys the containers waiting for an image of a task that
+// is no longer pooled, and releases what was kept to create them. The image
+// stays expired until this succeeds, e.g. once the containers claimed from
+// it are gone.
+//
+func (b *GardenBackend) drainWarmPool(ctx context.Context, image string) error {
+	waiting, err := b.warmContainers(ctx, image)
+	if err != nil {
+		return fmt.Errorf("list warm containers: %w", err)
+	}
+
+	for _, cont := range waiting {
+		err = b.Destroy(cont.ID())
+		if err != nil {
+			return fmt.Errorf("destroy warm container: %w", err)
+		}
+	}
+
+	err = b.warmPool.Rootfs.Release(image)
+	if err != nil {
+		return fmt.Errorf("release rootfs: %w", err)
+	}
+
+	b.claimLock.Lock()
+	defer b.claimLock.Unlock()
+
+	// the image may have been claimed again in the meantime
+	if time.Since(b.warmPool.taskImages[image]) > b.warmPool.TaskImageTTL {
+		delete(b.warmPool.taskImages, image)
+	}
+
+	return nil
+}
+
+func (b *GardenBackend) warmContainers(ctx context.Context, image string) ([]containerd.Container, error) {
+	filters, err := propertiesToFilterList(garden.Properties{atcruntime.WarmPoolPropertyName: image})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.client.Containers(ctx, filters...)
+}
+
+func (b *GardenBackend) createWarmContainer(ctx context.Context, image string) error {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return fmt.Errorf("generate handle: %w", err)
+	}
+
+	handle := atcruntime.WarmPoolHandlePrefix + id.String()
+
+	uri, privileged, err := b.warmPool.Rootfs.Create(handle, image)
+	if err != nil {
+		return fmt.Errorf("create rootfs: %w", err)
+	}
+
+	rootfs, err := bespec.RootfsDir(uri)
+	if err != nil {
+		_ = b.warmPool.Rootfs.Destroy(handle)
+		return err
+	}
+
+	err = b.warmPool.Namespaces.ShareRootfs(rootfs)
+	if err != nil {
+		_ = b.warmPool.Rootfs.Destroy(handle)
+		return fmt.Errorf("share rootfs: %w", err)
+	}
+
+	gdnSpec := garden.ContainerSpec{
+		Handle:     handle,
+		RootFSPath: uri,
+		Privileged: privileged,
+		BindMounts: b.warmPool.BindMounts,
+		Properties: garden.Properties{atcruntime.WarmPoolPropertyName: image},
+	}
+
+	cont, err := b.createContainer(ctx, gdnSpec)
+	if err != nil {
+		_ = b.warmPool.Namespaces.ReleaseRootfs(rootfs)
+		_ = b.warmPool.Rootfs.Destroy(handle)
+		return fmt.Errorf("new container: %w", err)
+	}
+
+	err = b.startTask(ctx, cont, gdnSpec)
+	if err != nil {
+		_ = b.Destroy(handle)
+		return fmt.Errorf("starting task: %w", err)
+	}
+
+	return nil
+}
+
+// warmClaim is what's needed to turn a container of the warm pool into the
+// container that it's claimed as, out of its OCI spec.
+//
+type warmClaim struct {
+	rootfs    string
+	uid, gid  uint32
+	resources *specs.LinuxResources
+}
+
+// claimWarmContainer hands out a container of the image from the warm pool
+// as the container described by the spec, applying what can still be
+// applied to a running container: its properties, env, volumes, limits,
+// egress rules and host name.
+//
+// Claiming a container of an image of a task that isn't pooled yet gets it
+// pooled, and fails with ErrWarmPoolEmpty.
+//
+func (b *GardenBackend) claimWarmContainer(ctx context.Context, image string, gdnSpec garden.ContainerSpec) (containerd.Container, error) {
+	if !b.warmPool.pools(image) {
+		return nil, ErrWarmPoolEmpty
+	}
+
+	b.claimLock.Lock()
+	defer b.claimLock.Unlock()
+
+	if _, _, isTask := atcruntime.ParseWarmTaskImage(image); isTask && b.warmPool.taskImages != nil {
+		b.warmPool.taskImages[image] = time.Now()
+	}
+
+	waiting, err := b.warmContainers(ctx, image)
+	if err != nil {
+		return nil, fmt.Errorf("list warm containers: %w", err)
+	}
+
+	if len(waiting) == 0 {
+		return nil, ErrWarmPoolEmpty
+	}
+
+	cont := waiting[0]
+
+	properties := garden.Properties{claimedHandleProperty: gdnSpec.Handle}
+	for name, value := range gdnSpec.Properties {
+		if name != atcruntime.WarmPoolPropertyName {
+			properties[name] = value
+		}
+	}
+
+	labels, err := propertiesToLabels(properties)
+	if err != nil {
+		return nil, fmt.Errorf("convert properties to labels: %w", err)
+	}
+
+	var claim warmClaim
+	err = cont.Update(ctx, func(_ context.Context, _ *containerd.Client, c *containers.Container) error {
+		data, err := typeurl.UnmarshalAny(c.Spec)
+		if err != nil {
+			return fmt.Errorf("unmarshal spec: %w", err)
+		}
+
+		oci, ok := data.(*specs.Spec)
+		if !ok {
+			return fmt.Errorf("unexpected spec type %T", data)
+		}
+
+		claim.resources, err = bespec.OciClaim(oci, gdnSpec)
+		if err != nil {
+			return fmt.Errorf("claim spec: %w", err)
+		}
+
+		if oci.Root != nil {
+			claim.rootfs = oci.Root.Path
+		}
+
+		if oci.Linux != nil {
+			claim.uid = containerRootID(oci.Linux.UIDMappings)
+			claim.gid = containerRootID(oci.Linux.GIDMappings)
+		}
+
+		c.Spec, err = typeurl.MarshalAny(oci)
+		if err != nil {
+			return fmt.Errorf("marshal spec: %w", err)
+		}
+
+		// the container leaves the pool along with its warm pool labels
+		c.Labels = labels
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update container: %w", err)
+	}
+
+	err = b.setupClaimedContainer(ctx, cont, claim, gdnSpec)
+	if err != nil {
+		_ = b.Destroy(cont.ID())
+		return nil, err
+	}
+
+	return cont, nil
+}
+
+func (b *GardenBackend) setupClaimedContainer(ctx context.Context, cont containerd.Container, claim warmClaim, gdnSpec garden.ContainerSpec) error {
+	task, err := cont.Task(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("task lookup: %w", err)
+	}
+
+	if claim.resources != nil {
+		err = task.Update(ctx, containerd.WithResources(claim.resources))
+		if err != nil {
+			return fmt.Errorf("update task resources: %w", err)
+		}
+	}
+
+	for _, mount := range gdnSpec.BindMounts {
+		// a read-only bind mount would have to be remounted, which doesn't
+		// propagate into the container
+		if mount.Mode != garden.BindMountModeRW {
+			return fmt.Errorf("cannot bind mount %s read-only into a running container", mount.DstPath)
+		}
+
+		err = b.warmPool.Namespaces.BindMount(claim.rootfs, mount, claim.uid, claim.gid)
+		if err != nil {
+			return fmt.Errorf("bind mount %s: %w", mount.DstPath, err)
+		}
+	}
+
+	err = b.network.Rename(cont.ID(), gdnSpec.Handle)
+	if err != nil {
+		return fmt.Errorf("network rename: %w", err)
+	}
+
+	err = b.warmPool.Namespaces.SetHostname(task.Pid(), gdnSpec.Handle)
+	if err != nil {
+		return fmt.Errorf("set hostname: %w", err)
+	}
+
+	err = b.network.NetOut(cont.ID(), gdnSpec.NetOut)
+	if err != nil {
+		return fmt.Errorf("network net out: %w", err)
+	}
+
+	return nil
+}
+
+// containerRootID returns the ID on the host of the root user or group of a
+// container, given the ID mappings of its user namespace, if any.
+//
+func containerRootID(mappings []specs.LinuxIDMapping) uint32 {
+	for _, mapping := range mappings {
+		if mapping.ContainerID == 0 {
+			return mapping.HostID
+		}
+	}
+
+	return 0
+}
+
+// isWarmContainer returns whether a container was created for the warm pool,
+// whether or not it has been claimed since.
+//
+func isWarmContainer(id string) bool {
+	return strings.HasPrefix(id, atcruntime.WarmPoolHandlePrefix)
+}
@@ -0,0 +1,137 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+
+	"code.cloudfoundry.org/garden"
+	"golang.org/x/sys/unix"
+)
+
+// hostNamespaces implements WarmPoolNamespaces from the host, i.e. the mount
+// namespace of the worker, which containers inherit the mounts of their root
+// filesystem from.
+//
+type hostNamespaces struct{}
+
+func (hostNamespaces) ShareRootfs(rootfs string) error {
+	// only mount points can be made shared
+	err := unix.Mount(rootfs, rootfs, "", unix.MS_BIND|unix.MS_REC, "")
+	if err != nil {
+		return fmt.Errorf("bind mount %s: %w", rootfs, err)
+	}
+
+	err = unix.Mount("", rootfs, "", unix.MS_SHARED|unix.MS_REC, "")
+	if err != nil {
+		_ = unix.Unmount(rootfs, unix.MNT_DETACH)
+		return fmt.Errorf("make %s shared: %w", rootfs, err)
+	}
+
+	return nil
+}
+
+func (hostNamespaces) ReleaseRootfs(rootfs string) error {
+	err := unix.Unmount(rootfs, unix.MNT_DETACH)
+	if err != nil && !errors.Is(err, unix.EINVAL) {
+		return fmt.Errorf("unmount %s: %w", rootfs, err)
+	}
+
+	return nil
+}
+
+func (hostNamespaces) BindMount(rootfs string, mount garden.BindMount, uid, gid uint32) error {
+	info, err := os.Stat(mount.SrcPath)
+	if err != nil {
+		return fmt.Errorf("stat source: %w", err)
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("source %s is not a directory", mount.SrcPath)
+	}
+
+	dst, err := mountPoint(rootfs, mount.DstPath, uid, gid)
+	if err != nil {
+		return err
+	}
+
+	err = unix.Mount(mount.SrcPath, dst, "", unix.MS_BIND|unix.MS_REC, "")
+	if err != nil {
+		return fmt.Errorf("bind mount %s: %w", dst, err)
+	}
+
+	return nil
+}
+
+// mountPoint returns the path on the host of a directory of a container,
+// creating it and the directories leading to it if they're missing. Symbolic
+// links are refused, as they would be resolved on the host rather than in the
+// container.
+//
+func mountPoint(rootfs, dir string, uid, gid uint32) (string, error) {
+	path := rootfs
+
+	for _, name := range strings.Split(filepath.Clean("/"+dir), "/") {
+		if name == "" {
+			continue
+		}
+
+		path = filepath.Join(path, name)
+
+		info, err := os.Lstat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			err = os.Mkdir(path, 0755)
+			if err != nil {
+				return "", fmt.Errorf("create mount point: %w", err)
+			}
+
+			err = os.Lchown(path, int(uid), int(gid))
+			if err != nil {
+				return "", fmt.Errorf("chown mount point: %w", err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("stat mount point: %w", err)
+		}
+
+		if !info.IsDir() {
+			return "", fmt.Errorf("mount point %s is not a directory", dir)
+		}
+	}
+
+	return path, nil
+}
+
+func (hostNamespaces) SetHostname(pid uint32, hostname string) error {
+	ns, err := os.Open(fmt.Sprintf("/proc/%d/ns/uts", pid))
+	if err != nil {
+		return fmt.Errorf("open uts namespace: %w", err)
+	}
+
+	defer ns.Close()
+
+	errs := make(chan error, 1)
+
+	go func() {
+		// the thread is left locked, so that it exits along with the
+		// goroutine instead of going back to the scheduler in the namespace
+		// of the container
+		goruntime.LockOSThread()
+
+		err := unix.Setns(int(ns.Fd()), unix.CLONE_NEWUTS)
+		if err != nil {
+			errs <- fmt.Errorf("enter uts namespace: %w", err)
+			return
+		}
+
+		errs <- unix.Sethostname([]byte(hostname))
+	}()
+
+	return <-errs
+}
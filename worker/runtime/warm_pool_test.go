@@ -0,0 +1,433 @@
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	atcruntime "github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
+	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/typeurl"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type WarmPoolSuite struct {
+	suite.Suite
+	*require.Assertions
+
+	backend    runtime.GardenBackend
+	client     *libcontainerdfakes.FakeClient
+	network    *runtimefakes.FakeNetwork
+	killer     *runtimefakes.FakeKiller
+	rootfs     *runtimefakes.FakeWarmPoolRootfs
+	namespaces *runtimefakes.FakeWarmPoolNamespaces
+}
+
+func (s *WarmPoolSuite) SetupTest() {
+	s.client = new(libcontainerdfakes.FakeClient)
+	s.network = new(runtimefakes.FakeNetwork)
+	s.killer = new(runtimefakes.FakeKiller)
+	s.rootfs = new(runtimefakes.FakeWarmPoolRootfs)
+	s.namespaces = new(runtimefakes.FakeWarmPoolNamespaces)
+
+	s.backend = s.newBackend(time.Hour)
+}
+
+func (s *WarmPoolSuite) newBackend(taskImageTTL time.Duration) runtime.GardenBackend {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithKiller(s.killer),
+		runtime.WithUserNamespace(new(runtimefakes.FakeUserNamespace)),
+		runtime.WithWarmPool(runtime.WarmPool{
+			Images:       []string{"git"},
+			TaskImages:   true,
+			TaskImageTTL: taskImageTTL,
+			Size:         2,
+			Rootfs:       s.rootfs,
+			Namespaces:   s.namespaces,
+		}),
+	)
+	s.NoError(err)
+
+	return backend
+}
+
+func (s *WarmPoolSuite) warmContainer(id string, labels map[string]string) *libcontainerdfakes.FakeContainer {
+	container := new(libcontainerdfakes.FakeContainer)
+	container.IDReturns(id)
+	container.LabelsStub = func(context.Context) (map[string]string, error) {
+		copied := map[string]string{}
+		for k, v := range labels {
+			copied[k] = v
+		}
+		return copied, nil
+	}
+	return container
+}
+
+func (s *WarmPoolSuite) TestFillCreatesMissingContainers() {
+	s.client.ContainersReturns([]containerd.Container{
+		s.warmContainer("warm-pool-existing", nil),
+	}, nil)
+	s.rootfs.CreateReturns("raw:///warm/rootfs", true, nil)
+
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.NewTaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	err := s.backend.FillWarmPool()
+	s.NoError(err)
+
+	// the task images left in the pool are recovered first
+	_, filters := s.client.ContainersArgsForCall(0)
+	s.Equal([]string{`labels."concourse:warm-pool.0"`}, filters)
+
+	_, filters = s.client.ContainersArgsForCall(1)
+	s.Equal([]string{`labels."concourse:warm-pool.0"==git`}, filters)
+
+	s.Equal(1, s.rootfs.CreateCallCount())
+	handle, image := s.rootfs.CreateArgsForCall(0)
+	s.True(strings.HasPrefix(handle, atcruntime.WarmPoolHandlePrefix))
+	s.Equal("git", image)
+
+	_, id, labels, oci := s.client.NewContainerArgsForCall(0)
+	s.Equal(handle, id)
+	s.Equal("git", labels[atcruntime.WarmPoolPropertyName+".0"])
+	s.Equal("/warm/rootfs", oci.Root.Path)
+	s.Equal(1, fakeContainer.NewTaskCallCount())
+
+	s.Equal("/warm/rootfs", s.namespaces.ShareRootfsArgsForCall(0))
+}
+
+func (s *WarmPoolSuite) TestFillCreatesContainersOfClaimedTaskImages() {
+	image := atcruntime.WarmTaskImage("image-volume", false)
+
+	s.rootfs.CreateReturns("raw:///warm/rootfs", false, nil)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.NewTaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	err := s.backend.FillWarmPool()
+	s.NoError(err)
+	s.Equal(2, s.rootfs.CreateCallCount())
+
+	_, err = s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		Properties: garden.Properties{atcruntime.WarmPoolPropertyName: image},
+	})
+	s.True(errors.Is(err, runtime.ErrWarmPoolEmpty))
+
+	err = s.backend.FillWarmPool()
+	s.NoError(err)
+
+	var created []string
+	for i := 2; i < s.rootfs.CreateCallCount(); i++ {
+		_, image := s.rootfs.CreateArgsForCall(i)
+		created = append(created, image)
+	}
+	s.ElementsMatch([]string{"git", "git", image, image}, created)
+}
+
+func (s *WarmPoolSuite) TestFillDrainsExpiredTaskImages() {
+	image := atcruntime.WarmTaskImage("image-volume", true)
+
+	waiting := s.warmContainer("warm-pool-waiting", map[string]string{
+		atcruntime.WarmPoolPropertyName + ".0": image,
+	})
+	waiting.TaskReturns(nil, errdefs.ErrNotFound)
+	s.client.ContainersReturns([]containerd.Container{waiting}, nil)
+	s.client.GetContainerReturns(waiting, nil)
+
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.NewTaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+	s.rootfs.CreateReturns("raw:///warm/rootfs", false, nil)
+
+	// every task image has expired by the time the pool gets filled
+	backend := s.newBackend(-time.Nanosecond)
+
+	err := backend.FillWarmPool()
+	s.NoError(err)
+
+	s.Equal(1, waiting.DeleteCallCount())
+	s.Equal("warm-pool-waiting", s.rootfs.DestroyArgsForCall(0))
+	s.Equal(1, s.rootfs.ReleaseCallCount())
+	s.Equal(image, s.rootfs.ReleaseArgsForCall(0))
+
+	for i := 0; i < s.rootfs.CreateCallCount(); i++ {
+		_, created := s.rootfs.CreateArgsForCall(i)
+		s.Equal("git", created)
+	}
+}
+
+func (s *WarmPoolSuite) TestFillDestroysRootfsWhenCreationFails() {
+	s.client.NewContainerReturns(nil, errors.New("new-container-failed"))
+	s.rootfs.CreateReturns("raw:///warm/rootfs", false, nil)
+
+	err := s.backend.FillWarmPool()
+	s.Error(err)
+
+	s.Equal(1, s.rootfs.DestroyCallCount())
+	handle, _ := s.rootfs.CreateArgsForCall(0)
+	s.Equal(handle, s.rootfs.DestroyArgsForCall(0))
+}
+
+func (s *WarmPoolSuite) TestCreateWithUnpooledImage() {
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		Properties: garden.Properties{atcruntime.WarmPoolPropertyName: "s3"},
+	})
+	s.True(errors.Is(err, runtime.ErrWarmPoolEmpty))
+
+	s.Equal(0, s.client.ContainersCallCount())
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
+func (s *WarmPoolSuite) TestCreateWithEmptyPool() {
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		Properties: garden.Properties{atcruntime.WarmPoolPropertyName: "git"},
+	})
+	s.True(errors.Is(err, runtime.ErrWarmPoolEmpty))
+
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
+func (s *WarmPoolSuite) TestCreateClaimsWarmContainer() {
+	spec, err := typeurl.MarshalAny(&specs.Spec{
+		Process: &specs.Process{Env: []string{"PATH=/bin"}},
+		Linux:   &specs.Linux{},
+	})
+	s.NoError(err)
+
+	claimed := &containers.Container{
+		Spec:   spec,
+		Labels: map[string]string{atcruntime.WarmPoolPropertyName + ".0": "git"},
+	}
+
+	warm := s.warmContainer("warm-pool-some-id", map[string]string{
+		"concourse:claimed-handle.0": "handle",
+	})
+	warm.UpdateStub = func(ctx context.Context, opts ...containerd.UpdateContainerOpts) error {
+		for _, opt := range opts {
+			err := opt(ctx, nil, claimed)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	task := new(libcontainerdfakes.FakeTask)
+	task.PidReturns(42)
+	warm.TaskReturns(task, nil)
+	s.client.ContainersReturns([]containerd.Container{warm}, nil)
+
+	memory := garden.MemoryLimits{LimitInBytes: 1024}
+	netOut := []garden.NetOutRule{{Protocol: garden.ProtocolTCP}}
+
+	container, err := s.backend.Create(garden.ContainerSpec{
+		Handle: "handle",
+		Env:    []string{"FOO=bar"},
+		Limits: garden.Limits{Memory: memory},
+		NetOut: netOut,
+		Properties: garden.Properties{
+			atcruntime.WarmPoolPropertyName: "git",
+			"user":                          "root",
+		},
+	})
+	s.NoError(err)
+	s.Equal("handle", container.Handle())
+
+	s.Equal(map[string]string{
+		"concourse:claimed-handle.0": "handle",
+		"user.0":                     "root",
+	}, claimed.Labels)
+
+	data, err := typeurl.UnmarshalAny(claimed.Spec)
+	s.NoError(err)
+	oci := data.(*specs.Spec)
+	s.Equal([]string{"PATH=/bin", "FOO=bar"}, oci.Process.Env)
+	s.Equal(int64(1024), *oci.Linux.Resources.Memory.Limit)
+
+	s.Equal("handle", oci.Hostname)
+
+	s.Equal(1, task.UpdateCallCount())
+
+	id, hostname := s.network.RenameArgsForCall(0)
+	s.Equal("warm-pool-some-id", id)
+	s.Equal("handle", hostname)
+
+	pid, hostname := s.namespaces.SetHostnameArgsForCall(0)
+	s.Equal(uint32(42), pid)
+	s.Equal("handle", hostname)
+
+	id, rules := s.network.NetOutArgsForCall(0)
+	s.Equal("warm-pool-some-id", id)
+	s.Equal(netOut, rules)
+}
+
+func (s *WarmPoolSuite) TestCreateClaimsWarmContainerOfTaskImage() {
+	image := atcruntime.WarmTaskImage("image-volume", false)
+
+	spec, err := typeurl.MarshalAny(&specs.Spec{
+		Process: &specs.Process{},
+		Root:    &specs.Root{Path: "/warm/rootfs"},
+		Linux: &specs.Linux{
+			UIDMappings: []specs.LinuxIDMapping{{ContainerID: 0, HostID: 1000, Size: 1}},
+			GIDMappings: []specs.LinuxIDMapping{{ContainerID: 0, HostID: 2000, Size: 1}},
+		},
+	})
+	s.NoError(err)
+
+	claimed := &containers.Container{Spec: spec}
+
+	warm := s.warmContainer("warm-pool-some-id", map[string]string{
+		"concourse:claimed-handle.0": "handle",
+	})
+	warm.UpdateStub = func(ctx context.Context, opts ...containerd.UpdateContainerOpts) error {
+		for _, opt := range opts {
+			err := opt(ctx, nil, claimed)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	warm.TaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.ContainersReturns([]containerd.Container{warm}, nil)
+
+	scratch := garden.BindMount{
+		SrcPath: "/volumes/scratch",
+		DstPath: "/scratch",
+		Mode:    garden.BindMountModeRW,
+	}
+
+	_, err = s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		BindMounts: []garden.BindMount{scratch},
+		Properties: garden.Properties{atcruntime.WarmPoolPropertyName: image},
+	})
+	s.NoError(err)
+
+	data, err := typeurl.UnmarshalAny(claimed.Spec)
+	s.NoError(err)
+	oci := data.(*specs.Spec)
+	s.Contains(oci.Mounts, specs.Mount{
+		Source:      "/volumes/scratch",
+		Destination: "/scratch",
+		Type:        "bind",
+		Options:     []string{"bind", "rw"},
+	})
+
+	s.Equal(1, s.namespaces.BindMountCallCount())
+	rootfs, mount, uid, gid := s.namespaces.BindMountArgsForCall(0)
+	s.Equal("/warm/rootfs", rootfs)
+	s.Equal(scratch, mount)
+	s.Equal(uint32(1000), uid)
+	s.Equal(uint32(2000), gid)
+}
+
+func (s *WarmPoolSuite) TestCreateRejectsReadOnlyBindMountsOfClaimedContainers() {
+	warm := s.warmContainer("warm-pool-some-id", nil)
+	warm.TaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.ContainersReturns([]containerd.Container{warm}, nil)
+	s.client.GetContainerReturns(warm, nil)
+
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle: "handle",
+		BindMounts: []garden.BindMount{{
+			SrcPath: "/volumes/input",
+			DstPath: "/input",
+			Mode:    garden.BindMountModeRO,
+		}},
+		Properties: garden.Properties{atcruntime.WarmPoolPropertyName: "git"},
+	})
+	s.Error(err)
+
+	s.Equal(0, s.namespaces.BindMountCallCount())
+	s.Equal(1, warm.DeleteCallCount())
+}
+
+func (s *WarmPoolSuite) TestCreateDestroysContainerWhenClaimFails() {
+	warm := s.warmContainer("warm-pool-some-id", nil)
+	warm.TaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.ContainersReturns([]containerd.Container{warm}, nil)
+	s.client.GetContainerReturns(warm, nil)
+	s.network.NetOutReturns(errors.New("net-out-failed"))
+
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		Properties: garden.Properties{atcruntime.WarmPoolPropertyName: "git"},
+	})
+	s.EqualError(errors.Unwrap(errors.Unwrap(err)), "net-out-failed")
+
+	s.Equal(1, warm.DeleteCallCount())
+	s.Equal("warm-pool-some-id", s.rootfs.DestroyArgsForCall(0))
+}
+
+func (s *WarmPoolSuite) TestLookupClaimedContainer() {
+	claimed := s.warmContainer("warm-pool-some-id", map[string]string{
+		"concourse:claimed-handle.0": "handle",
+	})
+	s.client.GetContainerReturns(nil, errdefs.ErrNotFound)
+	s.client.ContainersReturns([]containerd.Container{claimed}, nil)
+
+	container, err := s.backend.Lookup("handle")
+	s.NoError(err)
+	s.Equal("handle", container.Handle())
+
+	_, filters := s.client.ContainersArgsForCall(0)
+	s.Equal([]string{`labels."concourse:claimed-handle.0"==handle`}, filters)
+}
+
+func (s *WarmPoolSuite) TestLookupUnknownContainer() {
+	s.client.GetContainerReturns(nil, errdefs.ErrNotFound)
+
+	_, err := s.backend.Lookup("handle")
+	s.True(errors.Is(err, errdefs.ErrNotFound))
+}
+
+func (s *WarmPoolSuite) TestDestroyRemovesWarmContainerRootfs() {
+	claimed := s.warmContainer("warm-pool-some-id", nil)
+	task := new(libcontainerdfakes.FakeTask)
+	claimed.TaskReturns(task, nil)
+	claimed.SpecReturns(&specs.Spec{Root: &specs.Root{Path: "/warm/rootfs"}}, nil)
+	s.client.GetContainerReturns(claimed, nil)
+
+	err := s.backend.Destroy("handle")
+	s.NoError(err)
+
+	_, _, id := s.network.RemoveArgsForCall(0)
+	s.Equal("warm-pool-some-id", id)
+	s.Equal(1, claimed.DeleteCallCount())
+	s.Equal("/warm/rootfs", s.namespaces.ReleaseRootfsArgsForCall(0))
+	s.Equal("warm-pool-some-id", s.rootfs.DestroyArgsForCall(0))
+}
+
+func (s *WarmPoolSuite) TestContainersHidesIdleWarmContainers() {
+	s.client.ContainersReturns([]containerd.Container{
+		s.warmContainer("warm-pool-idle", map[string]string{
+			atcruntime.WarmPoolPropertyName + ".0": "git",
+		}),
+		s.warmContainer("warm-pool-claimed", map[string]string{
+			"concourse:claimed-handle.0": "handle",
+		}),
+		s.warmContainer("other-handle", nil),
+	}, nil)
+
+	containers, err := s.backend.Containers(nil)
+	s.NoError(err)
+
+	s.Len(containers, 2)
+	s.Equal("handle", containers[0].Handle())
+	s.Equal("other-handle", containers[1].Handle())
+}
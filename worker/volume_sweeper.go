@@ -3,12 +3,14 @@ package worker
 import (
 	"context"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/baggageclaim"
+	atcruntime "github.com/concourse/concourse/atc/runtime"
 )
 
 // volumeSweeper is an ifrit.Runner that periodically reports and
@@ -63,6 +65,11 @@ func (sweeper *volumeSweeper) sweep(logger lager.Logger) {
 	} else {
 		handles := []string{}
 		for _, volume := range volumes {
+			// the volumes backing the warm pool of a containerd worker
+			if strings.HasPrefix(volume.Handle(), atcruntime.WarmPoolHandlePrefix) {
+				continue
+			}
+
 			handles = append(handles, volume.Handle())
 		}
 
@@ -3,21 +3,26 @@
 package workercmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strings"
 	"syscall"
 	"time"
 
 	"code.cloudfoundry.org/lager"
-	"code.cloudfoundry.org/localip"
+	"github.com/concourse/concourse/atc"
 	concourseCmd "github.com/concourse/concourse/cmd"
 	"github.com/concourse/concourse/worker/network"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd"
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
 )
@@ -57,17 +62,17 @@ func (cmd *WorkerCommand) containerdGardenServerRunner(
 	logger lager.Logger,
 	containerdAddr string,
 	dnsServers []string,
-) (ifrit.Runner, error) {
+) (ifrit.Runner, *runtime.GardenBackend, error) {
 	const graceTime = 0
 
 	cniNetwork, err := cmd.buildUpNetworkOpts(logger, dnsServers)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create CNI network opts: %w", err)
+		return nil, nil, fmt.Errorf("failed to create CNI network opts: %w", err)
 	}
 
 	backendOpts, err := cmd.buildUpBackendOpts(logger, cniNetwork)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create contianerd backend opts: %w", err)
+		return nil, nil, fmt.Errorf("failed to create contianerd backend opts: %w", err)
 	}
 
 	gardenBackend, err := runtime.NewGardenBackend(
@@ -75,7 +80,7 @@ func (cmd *WorkerCommand) containerdGardenServerRunner(
 		backendOpts...,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("containerd containerd init: %w", err)
+		return nil, nil, fmt.Errorf("containerd containerd init: %w", err)
 	}
 
 	return newGardenServerRunner(
@@ -84,10 +89,14 @@ func (cmd *WorkerCommand) containerdGardenServerRunner(
 		graceTime,
 		&gardenBackend,
 		logger,
-	), nil
+	), &gardenBackend, nil
 }
 
 func (cmd *WorkerCommand) buildUpNetworkOpts(logger lager.Logger, dnsServers []string) (runtime.Network, error) {
+	if cmd.Containerd.Rootless {
+		return cmd.buildUpSlirpNetwork(logger, dnsServers)
+	}
+
 	logger.Debug("create-cni-network-opts")
 	if cmd.Containerd.CNIPluginsDir == "" {
 		pluginsDir := concourseCmd.DiscoverAsset("bin")
@@ -119,6 +128,19 @@ func (cmd *WorkerCommand) buildUpNetworkOpts(logger lager.Logger, dnsServers []s
 	if cmd.Containerd.Network.Pool != "" {
 		networkConfig.Subnet = cmd.Containerd.Network.Pool
 	}
+	if cmd.Containerd.Network.IPv6Pool != "" {
+		ip, _, err := net.ParseCIDR(cmd.Containerd.Network.IPv6Pool)
+		if err != nil || ip.To4() != nil {
+			return nil, fmt.Errorf("invalid IPv6 network pool %q", cmd.Containerd.Network.IPv6Pool)
+		}
+		networkConfig.IPv6Subnet = cmd.Containerd.Network.IPv6Pool
+	}
+	if cmd.Containerd.Network.IPv6Only {
+		if networkConfig.IPv6Subnet == "" {
+			return nil, fmt.Errorf("--containerd-ipv6-only requires --containerd-ipv6-network-pool to be set")
+		}
+		networkConfig.Subnet = ""
+	}
 	var err error
 	networkConfig.MTU, err = cmd.Containerd.mtu()
 	if err != nil {
@@ -129,6 +151,30 @@ func (cmd *WorkerCommand) buildUpNetworkOpts(logger lager.Logger, dnsServers []s
 	return runtime.NewCNINetwork(networkOpts...)
 }
 
+// buildUpSlirpNetwork sets up the network of rootless workers, which can't
+// create the bridge and iptables rules of the CNI network.
+func (cmd *WorkerCommand) buildUpSlirpNetwork(logger lager.Logger, dnsServers []string) (runtime.Network, error) {
+	logger.Debug("create-slirp-network-opts")
+
+	networkOpts := []runtime.SlirpNetworkOpt{
+		runtime.WithSlirpFileStore(runtime.FileStoreWithWorkDir(cmd.WorkDir.Path())),
+	}
+
+	if cmd.Containerd.Slirp4netnsBin != "" {
+		networkOpts = append(networkOpts, runtime.WithSlirpBinary(cmd.Containerd.Slirp4netnsBin))
+	}
+
+	if len(dnsServers) > 0 {
+		networkOpts = append(networkOpts, runtime.WithSlirpNameServers(dnsServers))
+	}
+
+	if cmd.Containerd.Network.AllowHostAccess {
+		networkOpts = append(networkOpts, runtime.WithSlirpAllowHostAccess())
+	}
+
+	return runtime.NewSlirpNetwork(networkOpts...)
+}
+
 func (cmd *WorkerCommand) buildUpBackendOpts(logger lager.Logger, cniNetwork runtime.Network) ([]runtime.GardenBackendOpt, error) {
 	logger.Debug("create-containerd-backendOpts")
 
@@ -140,25 +186,135 @@ func (cmd *WorkerCommand) buildUpBackendOpts(logger lager.Logger, cniNetwork run
 		cmd.Containerd.InitBin = initBin
 	}
 
-	return []runtime.GardenBackendOpt{
+	securityAllowList, err := cmd.backendSecurityAllowList()
+	if err != nil {
+		return nil, err
+	}
+
+	warmPool, err := cmd.warmPool(logger.Session("warm-pool"))
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []runtime.GardenBackendOpt{
 		runtime.WithNetwork(cniNetwork),
 		runtime.WithRequestTimeout(cmd.Containerd.RequestTimeout),
 		runtime.WithMaxContainers(cmd.Containerd.MaxContainers),
 		runtime.WithInitBinPath(cmd.Containerd.InitBin),
-	}, nil
+		runtime.WithDiskCapacityPath(filepath.Join(cmd.WorkDir.Path(), "volumes")),
+		runtime.WithSecurityAllowList(securityAllowList),
+		runtime.WithLogger(logger.Session("backend")),
+	}
+
+	if warmPool != nil {
+		opts = append(opts, runtime.WithWarmPool(*warmPool))
+	}
+
+	if cmd.Containerd.CheckpointRestore {
+		opts = append(opts, runtime.WithCheckpointRestore())
+	}
+
+	if cmd.Containerd.DiskQuota {
+		// baggageclaim is set up later on, but the quota needs to know the
+		// filesystem of the volumes now
+		volumesDir := filepath.Join(cmd.WorkDir.Path(), "volumes")
+
+		err := os.MkdirAll(volumesDir, 0755)
+		if err != nil {
+			return nil, err
+		}
+
+		diskQuota, err := runtime.NewProjectQuota(volumesDir, filepath.Join(cmd.WorkDir.Path(), "volumes-device"))
+		if err != nil {
+			return nil, fmt.Errorf("disk quota: %w", err)
+		}
+
+		opts = append(opts, runtime.WithDiskQuota(diskQuota))
+	}
+
+	return opts, nil
+}
+
+// securityAllowList is advertised for the ATC to place tasks with security
+// configs only on workers that allow them.
+func (cmd *WorkerCommand) securityAllowList() *atc.SecurityAllowList {
+	allowList := &atc.SecurityAllowList{
+		Capabilities: cmd.Containerd.Security.AllowedCapabilities,
+		Devices:      cmd.Containerd.Security.AllowedDevices,
+	}
+
+	for name := range cmd.Containerd.Security.SeccompProfiles {
+		allowList.SeccompProfiles = append(allowList.SeccompProfiles, name)
+	}
+
+	sort.Strings(allowList.SeccompProfiles)
+
+	return allowList
+}
+
+func (cmd *WorkerCommand) validateSecurityAllowList() error {
+	security := cmd.Containerd.Security
+
+	errs := atc.SecurityConfig{
+		Capabilities: atc.CapabilitiesConfig{Add: security.AllowedCapabilities},
+		Devices:      security.AllowedDevices,
+	}.Validate()
+
+	for name := range security.SeccompProfiles {
+		errs = append(errs, atc.SecurityConfig{Seccomp: name}.Validate()...)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid task security allow-list: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// backendSecurityAllowList loads the seccomp profiles of the allow-list.
+func (cmd *WorkerCommand) backendSecurityAllowList() (bespec.SecurityAllowList, error) {
+	allowList := bespec.SecurityAllowList{
+		Capabilities:    cmd.Containerd.Security.AllowedCapabilities,
+		Devices:         cmd.Containerd.Security.AllowedDevices,
+		SeccompProfiles: map[string]*specs.LinuxSeccomp{},
+	}
+
+	for name, path := range cmd.Containerd.Security.SeccompProfiles {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return bespec.SecurityAllowList{}, fmt.Errorf("read seccomp profile %s: %w", name, err)
+		}
+
+		var profile specs.LinuxSeccomp
+		err = json.Unmarshal(content, &profile)
+		if err != nil {
+			return bespec.SecurityAllowList{}, fmt.Errorf("parse seccomp profile %s: %w", name, err)
+		}
+
+		allowList.SeccompProfiles[name] = &profile
+	}
+
+	return allowList, nil
 }
 
 // containerdRunner spawns a containerd and a Garden server process for use as the container
 // runtime of Concourse.
 func (cmd *WorkerCommand) containerdRunner(logger lager.Logger) (ifrit.Runner, error) {
-	const sock = "/run/containerd/containerd.sock"
-
 	var (
+		sock   = "/run/containerd/containerd.sock"
 		config = filepath.Join(cmd.WorkDir.Path(), "containerd.toml")
 		root   = filepath.Join(cmd.WorkDir.Path(), "containerd")
 		bin    = "containerd"
+		args   []string
 	)
 
+	// /run is only writable by the host's root, so a rootless containerd
+	// keeps its socket and state in the work dir instead
+	if cmd.Containerd.Rootless {
+		sock = filepath.Join(cmd.WorkDir.Path(), "containerd.sock")
+		args = append(args, "--state="+filepath.Join(cmd.WorkDir.Path(), "containerd-state"))
+	}
+
 	err := os.MkdirAll(root, 0755)
 	if err != nil {
 		return nil, err
@@ -177,11 +333,11 @@ func (cmd *WorkerCommand) containerdRunner(logger lager.Logger) (ifrit.Runner, e
 		bin = cmd.Containerd.Bin
 	}
 
-	command := exec.Command(bin,
-		"--address="+sock,
-		"--root="+root,
-		"--config="+config,
-	)
+	command := exec.Command(bin, append([]string{
+		"--address=" + sock,
+		"--root=" + root,
+		"--config=" + config,
+	}, args...)...)
 
 	command.Stdout = os.Stdout
 	command.Stderr = os.Stderr
@@ -199,7 +355,7 @@ func (cmd *WorkerCommand) containerdRunner(logger lager.Logger) (ifrit.Runner, e
 			return nil, err
 		}
 
-		lip, err := localip.LocalIP()
+		lip, err := network.LocalIP()
 		if err != nil {
 			return nil, err
 		}
@@ -215,7 +371,7 @@ func (cmd *WorkerCommand) containerdRunner(logger lager.Logger) (ifrit.Runner, e
 		})
 	}
 
-	gardenServerRunner, err := cmd.containerdGardenServerRunner(
+	gardenServerRunner, gardenBackend, err := cmd.containerdGardenServerRunner(
 		logger,
 		sock,
 		dnsServers,
@@ -246,6 +402,16 @@ func (cmd *WorkerCommand) containerdRunner(logger lager.Logger) (ifrit.Runner, e
 		},
 	}, members...)
 
+	if cmd.Containerd.keepsWarmPool() {
+		members = append(members, grouper.Member{
+			Name: "warm-pool",
+			Runner: concourseCmd.NewLoggingRunner(
+				logger.Session("warm-pool-runner"),
+				cmd.warmPoolRunner(logger.Session("warm-pool"), gardenBackend),
+			),
+		})
+	}
+
 	// Using the Ordered strategy to ensure containerd is up before the garden server is started
 	return grouper.NewOrdered(os.Interrupt, members), nil
 }
@@ -255,7 +421,7 @@ func (cmd ContainerdRuntime) externalIP() (net.IP, error) {
 		return cmd.Network.ExternalIP.IP, nil
 	}
 
-	localIP, err := localip.LocalIP()
+	localIP, err := network.LocalIP()
 	if err != nil {
 		return nil, fmt.Errorf("Couldn't determine local IP to use for --external-ip parameter. You can use the --external-ip flag to pass an external IP explicitly.")
 	}
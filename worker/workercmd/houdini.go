@@ -30,5 +30,5 @@ func (cmd *WorkerCommand) houdiniRunner(logger lager.Logger) (ifrit.Runner, erro
 }
 
 func (cmd *WorkerCommand) bindAddr() string {
-	return fmt.Sprintf("%s:%d", cmd.BindIP.IP, cmd.BindPort)
+	return hostPort(cmd.BindIP.IP, cmd.BindPort)
 }
@@ -0,0 +1,208 @@
+package workercmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"syscall"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/tedsuo/ifrit"
+)
+
+// rootlessChildEnv marks the worker process that was re-executed inside the
+// user namespace of a rootless worker.
+const rootlessChildEnv = "CONCOURSE_ROOTLESS_CHILD"
+
+// rootlessSyncFd is the file descriptor on which the re-executed worker waits
+// for its id mappings to be written.
+const rootlessSyncFd = 3
+
+func (cmd *WorkerCommand) rootless() bool {
+	return cmd.Runtime == containerdRuntime && cmd.Containerd.Rootless
+}
+
+// enterRootless re-executes a rootless worker inside new user and mount
+// namespaces in which the current user is root, returning a runner for the
+// re-executed worker. Within those namespaces containerd, baggageclaim and the
+// Garden backend can be run as usual, without any privileges on the host.
+//
+// No runner is returned when the worker isn't rootless or when it is already
+// the re-executed one, in which case it should carry on as usual.
+func (cmd *WorkerCommand) enterRootless(logger lager.Logger) (ifrit.Runner, error) {
+	if !cmd.rootless() {
+		return nil, nil
+	}
+
+	if os.Getenv(rootlessChildEnv) != "" {
+		// the parent closes its end once the id mappings are in place, after
+		// which this process is root within the namespace
+		sync := os.NewFile(rootlessSyncFd, "rootless-sync")
+		_, err := io.Copy(ioutil.Discard, sync)
+		sync.Close()
+		if err != nil {
+			return nil, fmt.Errorf("waiting for id mappings: %w", err)
+		}
+
+		if os.Geteuid() != 0 {
+			return nil, fmt.Errorf("id mappings were not set up")
+		}
+
+		return nil, nil
+	}
+
+	if os.Geteuid() == 0 {
+		return nil, fmt.Errorf("--containerd-rootless must be used as an unprivileged user")
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("finding executable: %w", err)
+	}
+
+	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		syncR, syncW, err := os.Pipe()
+		if err != nil {
+			return fmt.Errorf("sync pipe: %w", err)
+		}
+		defer syncW.Close()
+
+		child := exec.Command(self, os.Args[1:]...)
+		child.Env = append(os.Environ(), rootlessChildEnv+"=1")
+		child.Stdin = os.Stdin
+		child.Stdout = os.Stdout
+		child.Stderr = os.Stderr
+		child.ExtraFiles = []*os.File{syncR}
+		child.SysProcAttr = &syscall.SysProcAttr{
+			Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS,
+			Pdeathsig:  syscall.SIGKILL,
+		}
+
+		err = child.Start()
+		syncR.Close()
+		if err != nil {
+			return fmt.Errorf("starting worker in user namespace: %w", err)
+		}
+
+		err = writeIDMappings(logger, child.Process.Pid)
+		if err != nil {
+			_ = child.Process.Kill()
+			_ = child.Wait()
+			return fmt.Errorf("mapping ids: %w", err)
+		}
+
+		syncW.Close()
+
+		close(ready)
+
+		waitErr := make(chan error, 1)
+		go func() {
+			waitErr <- child.Wait()
+		}()
+
+		for {
+			select {
+			case sig := <-signals:
+				_ = child.Process.Signal(sig)
+			case err := <-waitErr:
+				return err
+			}
+		}
+	}), nil
+}
+
+// writeIDMappings maps the current user to root within the user namespace of
+// the given process, along with the subordinate ids delegated to the user so
+// that containers can run as other users.
+//
+// Mapping subordinate ids requires the setuid newuidmap and newgidmap helpers.
+// Without them (or without any delegated ids) only the current user can be
+// mapped, leaving containers only able to run as root.
+func writeIDMappings(logger lager.Logger, pid int) error {
+	currentUser, err := user.Current()
+	if err != nil {
+		return err
+	}
+
+	uid, gid := strconv.Itoa(os.Geteuid()), strconv.Itoa(os.Getegid())
+
+	uidStart, uidCount, uidFound, err := subIDRange("/etc/subuid", currentUser)
+	if err != nil {
+		return err
+	}
+
+	gidStart, gidCount, gidFound, err := subIDRange("/etc/subgid", currentUser)
+	if err != nil {
+		return err
+	}
+
+	newuidmap, uidmapErr := exec.LookPath("newuidmap")
+	newgidmap, gidmapErr := exec.LookPath("newgidmap")
+
+	if uidFound && gidFound && uidmapErr == nil && gidmapErr == nil {
+		err = runIDMapHelper(newuidmap, pid, uid, uidStart, uidCount)
+		if err != nil {
+			return err
+		}
+
+		return runIDMapHelper(newgidmap, pid, gid, gidStart, gidCount)
+	}
+
+	logger.Info("mapping-only-current-user", lager.Data{
+		"reason": "no subordinate ids delegated in /etc/subuid and /etc/subgid, or newuidmap and newgidmap are missing",
+	})
+
+	procDir := fmt.Sprintf("/proc/%d", pid)
+
+	err = ioutil.WriteFile(procDir+"/uid_map", []byte("0 "+uid+" 1\n"), 0644)
+	if err != nil {
+		return fmt.Errorf("writing uid_map: %w", err)
+	}
+
+	// unprivileged processes may only write the gid_map once setgroups(2)
+	// has been denied
+	err = ioutil.WriteFile(procDir+"/setgroups", []byte("deny"), 0644)
+	if err != nil {
+		return fmt.Errorf("denying setgroups: %w", err)
+	}
+
+	err = ioutil.WriteFile(procDir+"/gid_map", []byte("0 "+gid+" 1\n"), 0644)
+	if err != nil {
+		return fmt.Errorf("writing gid_map: %w", err)
+	}
+
+	return nil
+}
+
+func subIDRange(path string, u *user.User) (uint32, uint32, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, 0, false, nil
+		}
+
+		return 0, 0, false, err
+	}
+	defer f.Close()
+
+	return runtime.SubIDRange(f, u.Username, u.Uid)
+}
+
+// runIDMapHelper maps id to root and the subordinate ids from 1 onwards.
+func runIDMapHelper(helper string, pid int, id string, start, count uint32) error {
+	output, err := exec.Command(helper,
+		strconv.Itoa(pid),
+		"0", id, "1",
+		"1", strconv.FormatUint(uint64(start), 10), strconv.FormatUint(uint64(count), 10),
+	).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", helper, err, output)
+	}
+
+	return nil
+}
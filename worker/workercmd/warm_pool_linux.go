@@ -0,0 +1,214 @@
+package workercmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
+	bclient "github.com/concourse/baggageclaim/client"
+	"github.com/concourse/concourse/atc"
+	atcruntime "github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/tedsuo/ifrit"
+)
+
+// warmPoolRootfs provides the root filesystems of the containers of the warm
+// pool as copy-on-write volumes of their images, which are imported into
+// baggageclaim once: from the worker's resource types, or from the volumes
+// that the ATC fetched the images of tasks into.
+type warmPoolRootfs struct {
+	logger        lager.Logger
+	client        baggageclaim.Client
+	resourceTypes map[string]atc.WorkerResourceType
+}
+
+func (rootfs warmPoolRootfs) Create(handle string, image string) (string, bool, error) {
+	imageVolume, privileged, rootfsPath, err := rootfs.imageVolume(image)
+	if err != nil {
+		return "", false, fmt.Errorf("import image: %w", err)
+	}
+
+	volume, err := rootfs.client.CreateVolume(
+		rootfs.logger.Session("create-cow-volume"),
+		handle,
+		baggageclaim.VolumeSpec{
+			Strategy:   baggageclaim.COWStrategy{Parent: imageVolume},
+			Privileged: privileged,
+		},
+	)
+	if err != nil {
+		return "", false, fmt.Errorf("create cow volume: %w", err)
+	}
+
+	return "raw://" + path.Join(volume.Path(), rootfsPath), privileged, nil
+}
+
+func (rootfs warmPoolRootfs) Destroy(handle string) error {
+	return rootfs.client.DestroyVolume(rootfs.logger.Session("destroy-volume"), handle)
+}
+
+func (rootfs warmPoolRootfs) Release(image string) error {
+	if _, _, isTask := atcruntime.ParseWarmTaskImage(image); !isTask {
+		return nil
+	}
+
+	return rootfs.client.DestroyVolume(rootfs.logger.Session("destroy-image-volume"), taskImageVolumeHandle(image))
+}
+
+// imageVolume finds or imports the volume holding an image, returning it
+// along with whether the image runs privileged and where its root filesystem
+// is within the volume.
+func (rootfs warmPoolRootfs) imageVolume(image string) (baggageclaim.Volume, bool, string, error) {
+	if sourceHandle, privileged, isTask := atcruntime.ParseWarmTaskImage(image); isTask {
+		volume, err := rootfs.taskImageVolume(image, sourceHandle)
+		return volume, privileged, "rootfs", err
+	}
+
+	resourceType, found := rootfs.resourceTypes[image]
+	if !found {
+		return nil, false, "", fmt.Errorf("unknown resource type %s", image)
+	}
+
+	// the handle includes the version of the resource type so that upgrading
+	// the worker imports the new image
+	handle := atcruntime.WarmPoolHandlePrefix + "image-" + resourceType.Type + "-" + resourceType.Version
+
+	volume, found, err := rootfs.client.LookupVolume(rootfs.logger.Session("lookup-image-volume"), handle)
+	if err != nil || found {
+		return volume, resourceType.Privileged, "", err
+	}
+
+	volume, err = rootfs.importVolume(handle, resourceType.Image, resourceType.Privileged)
+	return volume, resourceType.Privileged, "", err
+}
+
+// taskImageVolume finds or imports a copy of the volume that the ATC fetched
+// the image of a task into, since the ATC destroys its volume whenever it
+// sees fit.
+func (rootfs warmPoolRootfs) taskImageVolume(image string, sourceHandle string) (baggageclaim.Volume, error) {
+	handle := taskImageVolumeHandle(image)
+
+	volume, found, err := rootfs.client.LookupVolume(rootfs.logger.Session("lookup-image-volume"), handle)
+	if err != nil || found {
+		return volume, err
+	}
+
+	source, found, err := rootfs.client.LookupVolume(rootfs.logger.Session("lookup-source-volume"), sourceHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("volume %s of the image is gone", sourceHandle)
+	}
+
+	// the copy keeps the ids of the source, which the copy-on-write volumes
+	// of the containers then map for them to be privileged or not
+	sourcePrivileged, err := source.GetPrivileged()
+	if err != nil {
+		return nil, err
+	}
+
+	return rootfs.importVolume(handle, source.Path(), sourcePrivileged)
+}
+
+func (rootfs warmPoolRootfs) importVolume(handle string, path string, privileged bool) (baggageclaim.Volume, error) {
+	return rootfs.client.CreateVolume(
+		rootfs.logger.Session("create-image-volume"),
+		handle,
+		baggageclaim.VolumeSpec{
+			Strategy:   baggageclaim.ImportStrategy{Path: path},
+			Privileged: privileged,
+		},
+	)
+}
+
+// taskImageVolumeHandle is the handle of the copy of the volume of the image
+// of a task, which is the same whether its containers are privileged or not.
+func taskImageVolumeHandle(image string) string {
+	sourceHandle, _, _ := atcruntime.ParseWarmTaskImage(image)
+	return atcruntime.WarmPoolHandlePrefix + "task-image-" + sourceHandle
+}
+
+// keepsWarmPool returns whether any image is to be pooled.
+func (cmd ContainerdRuntime) keepsWarmPool() bool {
+	return len(cmd.WarmPool.ResourceTypes) > 0 || cmd.WarmPool.TaskImages
+}
+
+// warmPool configures the warm pool of the containerd backend, if any image
+// is to be pooled.
+func (cmd *WorkerCommand) warmPool(logger lager.Logger) (*runtime.WarmPool, error) {
+	if !cmd.Containerd.keepsWarmPool() {
+		return nil, nil
+	}
+
+	resourceTypes, err := cmd.loadResources(logger.Session("load-resources"))
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]atc.WorkerResourceType{}
+	for _, resourceType := range resourceTypes {
+		byName[resourceType.Type] = resourceType
+	}
+
+	for _, name := range cmd.Containerd.WarmPool.ResourceTypes {
+		if _, found := byName[name]; !found {
+			return nil, fmt.Errorf("cannot keep warm containers of unknown resource type %s", name)
+		}
+	}
+
+	var bindMounts []garden.BindMount
+	if cmd.Certs.Dir != "" {
+		bindMounts = append(bindMounts, garden.BindMount{
+			SrcPath: cmd.Certs.Dir,
+			DstPath: "/etc/ssl/certs",
+			Mode:    garden.BindMountModeRO,
+		})
+	}
+
+	return &runtime.WarmPool{
+		Images:       cmd.Containerd.WarmPool.ResourceTypes,
+		TaskImages:   cmd.Containerd.WarmPool.TaskImages,
+		TaskImageTTL: cmd.Containerd.WarmPool.TaskImageTTL,
+		Size:         cmd.Containerd.WarmPool.Size,
+		Rootfs: warmPoolRootfs{
+			logger: logger.Session("rootfs"),
+			// baggageclaim may still be starting, which the next tick will
+			// retry rather than a retry loop of the client
+			client:        bclient.NewWithHTTPClient(cmd.baggageclaimURL(), &http.Client{Timeout: 5 * time.Minute}),
+			resourceTypes: byName,
+		},
+		BindMounts: bindMounts,
+	}, nil
+}
+
+// warmPoolRunner periodically tops up the warm pool of the backend. Failing
+// to do so only loses the head start of the containers, and so is retried on
+// the next tick.
+func (cmd *WorkerCommand) warmPoolRunner(logger lager.Logger, backend *runtime.GardenBackend) ifrit.Runner {
+	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		ticker := time.NewTicker(cmd.Containerd.WarmPool.Interval)
+		defer ticker.Stop()
+
+		close(ready)
+
+		for {
+			select {
+			case <-ticker.C:
+				err := backend.FillWarmPool()
+				if err != nil {
+					logger.Error("failed-to-fill-warm-pool", err)
+				}
+
+			case <-signals:
+				return nil
+			}
+		}
+	})
+}
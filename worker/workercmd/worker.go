@@ -2,9 +2,11 @@ package workercmd
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -81,6 +83,17 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 
 	logger, _ := cmd.Logger.Logger("worker")
 
+	// rootless workers re-execute themselves inside a user namespace, where
+	// they carry on as usual
+	rootlessRunner, err := cmd.enterRootless(logger.Session("rootless"))
+	if err != nil {
+		return nil, err
+	}
+
+	if rootlessRunner != nil {
+		return rootlessRunner, nil
+	}
+
 	atcWorker, gardenServerRunner, err := cmd.gardenServerRunner(logger.Session("garden"))
 	if err != nil {
 		return nil, err
@@ -172,7 +185,7 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 			Runner: concourseCmd.NewLoggingRunner(
 				logger.Session("debug-runner"),
 				http_server.New(
-					fmt.Sprintf("%s:%d", cmd.DebugBindIP.IP, cmd.DebugBindPort),
+					hostPort(cmd.DebugBindIP.IP, cmd.DebugBindPort),
 					http.DefaultServeMux,
 				),
 			),
@@ -182,7 +195,7 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 			Runner: concourseCmd.NewLoggingRunner(
 				logger.Session("healthcheck-runner"),
 				http_server.New(
-					fmt.Sprintf("%s:%d", cmd.HealthcheckBindIP.IP, cmd.HealthcheckBindPort),
+					hostPort(cmd.HealthcheckBindIP.IP, cmd.HealthcheckBindPort),
 					http.HandlerFunc(healthChecker.CheckHealth),
 				),
 			),
@@ -222,7 +235,7 @@ func (cmd *WorkerCommand) gardenAddr() string {
 		return cmd.ExternalGardenURL.URL.Host
 	}
 
-	return fmt.Sprintf("%s:%d", cmd.BindIP, cmd.BindPort)
+	return hostPort(cmd.BindIP.IP, cmd.BindPort)
 }
 
 func (cmd *WorkerCommand) gardenURL() string {
@@ -230,13 +243,18 @@ func (cmd *WorkerCommand) gardenURL() string {
 }
 
 func (cmd *WorkerCommand) baggageclaimAddr() string {
-	return fmt.Sprintf("%s:%d", cmd.Baggageclaim.BindIP, cmd.Baggageclaim.BindPort)
+	return hostPort(cmd.Baggageclaim.BindIP.IP, cmd.Baggageclaim.BindPort)
 }
 
 func (cmd *WorkerCommand) baggageclaimURL() string {
 	return fmt.Sprintf("http://%s", cmd.baggageclaimAddr())
 }
 
+// hostPort joins an IP and port into an address, bracketing IPv6 addresses.
+func hostPort(ip net.IP, port uint16) string {
+	return net.JoinHostPort(ip.String(), strconv.Itoa(int(port)))
+}
+
 func (cmd *WorkerCommand) workerName() (string, error) {
 	if cmd.Worker.Name != "" {
 		return cmd.Worker.Name, nil
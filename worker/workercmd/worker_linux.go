@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
@@ -44,6 +45,9 @@ type ContainerdRuntime struct {
 	CNIPluginsDir  string        `long:"cni-plugins-dir" description:"Path to CNI network plugins. By default will set to the concourse/bin directory the concourse binary is in."`
 	RequestTimeout time.Duration `long:"request-timeout" default:"5m" description:"How long to wait for requests to Containerd to complete. 0 means no timeout."`
 
+	Rootless       bool   `long:"rootless" description:"Run containerd, baggageclaim and the Garden backend as the current unprivileged user, inside a user namespace. Containers are networked through slirp4netns, and privileged containers are not supported."`
+	Slirp4netnsBin string `long:"slirp4netns-bin" description:"Path to a slirp4netns executable for rootless container networking (non-absolute names get resolved from $PATH)."`
+
 	Network struct {
 		ExternalIP flag.IP `long:"external-ip" description:"IP address to use to reach container's mapped ports. Autodetected if not specified."`
 		//TODO can DNSConfig be simplifed to just a bool rather than struct with a bool?
@@ -51,10 +55,30 @@ type ContainerdRuntime struct {
 		DNSServers         []string  `long:"dns-server" description:"DNS server IP address to use instead of automatically determined servers. Can be specified multiple times."`
 		RestrictedNetworks []string  `long:"restricted-network" description:"Network ranges to which traffic from containers will be restricted. Can be specified multiple times."`
 		Pool               string    `long:"network-pool" default:"10.80.0.0/16" description:"Network range to use for dynamically allocated container subnets."`
+		IPv6Pool           string    `long:"ipv6-network-pool" description:"IPv6 network range to use for dynamically allocated container addresses. Setting it gives containers both IPv4 and IPv6 addresses."`
+		IPv6Only           bool      `long:"ipv6-only" description:"Only give containers IPv6 addresses, taken from the --containerd-ipv6-network-pool."`
 		MTU                int       `long:"mtu" description:"MTU size for container network interfaces. Defaults to the MTU of the interface used for outbound access by the host."`
 		AllowHostAccess    bool      `long:"allow-host-access" description:"Allow containers to reach the host's network. This is turned off by default."`
 	} `group:"Container Networking"`
 
+	Security struct {
+		AllowedCapabilities []string          `long:"allowed-capability" description:"Linux capability (e.g. CAP_SYS_PTRACE) that tasks may add to their containers through their security config. Can be specified multiple times."`
+		AllowedDevices      []string          `long:"allowed-device" description:"Path to a device (e.g. /dev/fuse) that tasks may use in their containers through their security config. Can be specified multiple times."`
+		SeccompProfiles     map[string]string `long:"seccomp-profile" description:"Seccomp profile, in the OCI runtime spec format, that tasks may use instead of the default one through their security config. Given as NAME:PATH. Can be specified multiple times."`
+	} `group:"Task Security"`
+
+	CheckpointRestore bool `long:"checkpoint-restore" description:"Allow the containers of migratable tasks to be checkpointed with CRIU, so that the ATC can move them to another worker when this one is landed or retired. Requires criu to be installed."`
+
+	DiskQuota bool `long:"disk-quota" description:"Enforce the disk limits of tasks with project quotas. Requires the volumes directory to be on an XFS filesystem mounted with the prjquota option. Without it, disk limits are not enforced."`
+
+	WarmPool struct {
+		ResourceTypes []string      `long:"warm-pool-resource-type" description:"Resource type to keep started containers of, for checks to claim instead of creating their own. Can be specified multiple times."`
+		TaskImages    bool          `long:"warm-pool-task-images" description:"Keep started containers of the images of tasks marked warm: true, for their steps to claim instead of creating their own. An image is pooled once a task on this worker has used it."`
+		TaskImageTTL  time.Duration `long:"warm-pool-task-image-ttl" default:"1h" description:"Time after which the image of a task stops being pooled, if no task has used it since."`
+		Size          int           `long:"warm-pool-size" default:"2" description:"Number of started containers to keep for each image of the warm pool."`
+		Interval      time.Duration `long:"warm-pool-interval" default:"10s" description:"Interval on which the warm pool is topped up with new containers."`
+	} `group:"Warm Pool"`
+
 	MaxContainers int `long:"max-containers" default:"250" description:"Max container capacity. 0 means no limit."`
 }
 
@@ -88,6 +112,23 @@ func (cmd *WorkerCommand) gardenServerRunner(logger lager.Logger) (atc.Worker, i
 
 	worker := cmd.Worker.Worker()
 	worker.Platform = "linux"
+	worker.Rootless = cmd.rootless()
+
+	// only the containerd runtime honors the security and network configs
+	// of tasks, keeps a warm pool and checkpoints tasks
+	if cmd.Runtime == containerdRuntime {
+		worker.SecurityAllowList = cmd.securityAllowList()
+		worker.WarmPool = cmd.Containerd.WarmPool.ResourceTypes
+		worker.WarmTaskImages = cmd.Containerd.WarmPool.TaskImages
+		worker.CheckpointRestore = cmd.Containerd.CheckpointRestore
+		worker.NetworkConfig = true
+	}
+
+	// the runtime reports the memory and disk capacity with each heartbeat,
+	// but Garden has no way to report CPUs
+	worker.Capacity = &atc.WorkerResources{
+		CPU: uint64(runtime.NumCPU()) * 1024,
+	}
 
 	if cmd.Certs.Dir != "" {
 		worker.CertsPath = &cmd.Certs.Dir
@@ -243,6 +284,21 @@ func (cmd *WorkerCommand) verifyRuntimeFlags() error {
 		if cmd.hasFlags(guardianEnvPrefix) {
 			return fmt.Errorf("cannot use %s environment variables with Containerd", guardianEnvPrefix)
 		}
+		if cmd.Containerd.Rootless && cmd.Containerd.Network.DNS.Enable {
+			return fmt.Errorf("cannot use the DNS proxy with a rootless worker, which forwards DNS queries through slirp4netns instead")
+		}
+		if err := cmd.validateSecurityAllowList(); err != nil {
+			return err
+		}
+		if cmd.Containerd.Rootless && cmd.Containerd.CheckpointRestore {
+			return fmt.Errorf("cannot checkpoint containers of a rootless worker, as CRIU requires root")
+		}
+		if cmd.Containerd.Rootless && cmd.Containerd.DiskQuota {
+			return fmt.Errorf("cannot enforce disk quotas on a rootless worker, as setting quotas requires root")
+		}
+		if cmd.Containerd.keepsWarmPool() && cmd.Containerd.WarmPool.Size <= 0 {
+			return fmt.Errorf("--containerd-warm-pool-size must be positive to keep a warm pool")
+		}
 	case cmd.Runtime == guardianRuntime:
 		if cmd.hasFlags(containerdEnvPrefix) {
 			return fmt.Errorf("cannot use %s environment variables with Guardian", containerdEnvPrefix)